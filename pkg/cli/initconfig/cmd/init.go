@@ -182,6 +182,8 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		commonrepo.NewWorkflowViewColl(),
 		commonrepo.NewWorkflowV4TemplateColl(),
 		commonrepo.NewWorkflowV4TemplateVersionColl(),
+		commonrepo.NewWorkflowV4GitSourceColl(),
+		commonrepo.NewCoverageReportColl(),
 		commonrepo.NewVariableSetColl(),
 		commonrepo.NewJobInfoColl(),
 		commonrepo.NewStatDashboardConfigColl(),
@@ -205,6 +207,9 @@ func createOrUpdateMongodbIndex(ctx context.Context) {
 		commonrepo.NewEnvInfoColl(),
 		commonrepo.NewApprovalTicketColl(),
 		commonrepo.NewWorkflowTaskRevertColl(),
+		commonrepo.NewTerminalSessionColl(),
+		commonrepo.NewTerminalAccessRequestColl(),
+		commonrepo.NewWebhookDeliveryColl(),
 
 		// msg queue
 		commonrepo.NewMsgQueueCommonColl(),
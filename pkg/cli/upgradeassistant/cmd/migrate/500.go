@@ -91,6 +91,16 @@ func V430ToV500() error {
 		return err
 	}
 
+	err = migrateUserSCIMColumns500(migrationInfo)
+	if err != nil {
+		return err
+	}
+
+	err = migrateUserGroupSCIMColumn500(migrationInfo)
+	if err != nil {
+		return err
+	}
+
 	err = migrateWorkflowTemplateVersion500(migrationInfo)
 	if err != nil {
 		return err
@@ -160,6 +170,52 @@ func migrateUserContactIndexes500(migrationInfo *internalmodels.Migration) error
 	return nil
 }
 
+// migrateUserSCIMColumns500 adds the columns SCIM provisioning uses to deactivate users and track
+// their id in the identity provider.
+func migrateUserSCIMColumns500(migrationInfo *internalmodels.Migration) error {
+	if migrationInfo.Migration500UserSCIMColumns {
+		return nil
+	}
+
+	for _, field := range []string{"Disabled", "ExternalID"} {
+		if !repository.DB.Migrator().HasColumn(&usermodels.User{}, field) {
+			if err := repository.DB.Migrator().AddColumn(&usermodels.User{}, field); err != nil {
+				return fmt.Errorf("failed to add column %s for user table, err: %s", field, err)
+			}
+		}
+	}
+
+	if err := internalmongodb.NewMigrationColl().UpdateMigrationStatus(migrationInfo.ID, map[string]interface{}{
+		getMigrationFieldBsonTag(migrationInfo, &migrationInfo.Migration500UserSCIMColumns): true,
+	}); err != nil {
+		return fmt.Errorf("failed to update migration 5.0.0 user scim columns status, err: %s", err)
+	}
+
+	return nil
+}
+
+// migrateUserGroupSCIMColumn500 adds the column recording which SCIM identity type provisioned a user group,
+// SCIM clients can only manage the groups they provisioned.
+func migrateUserGroupSCIMColumn500(migrationInfo *internalmodels.Migration) error {
+	if migrationInfo.Migration500UserGroupSCIMColumn {
+		return nil
+	}
+
+	if !repository.DB.Migrator().HasColumn(&usermodels.UserGroup{}, "IdentityType") {
+		if err := repository.DB.Migrator().AddColumn(&usermodels.UserGroup{}, "IdentityType"); err != nil {
+			return fmt.Errorf("failed to add column IdentityType for user group table, err: %s", err)
+		}
+	}
+
+	if err := internalmongodb.NewMigrationColl().UpdateMigrationStatus(migrationInfo.ID, map[string]interface{}{
+		getMigrationFieldBsonTag(migrationInfo, &migrationInfo.Migration500UserGroupSCIMColumn): true,
+	}); err != nil {
+		return fmt.Errorf("failed to update migration 5.0.0 user group scim column status, err: %s", err)
+	}
+
+	return nil
+}
+
 func migrateWorkflowTemplateVersion500(migrationInfo *internalmodels.Migration) error {
 	if migrationInfo.Migration500WorkflowTemplateVersion {
 		return nil
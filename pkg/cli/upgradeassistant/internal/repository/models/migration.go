@@ -50,6 +50,8 @@ type Migration struct {
 	Migration500ServiceModule                   bool               `bson:"migration_500_service_module"`
 	Migration500ServiceModuleSkipped            int                `bson:"migration_500_service_module_skipped"`
 	Migration500ServiceModuleErrors             []string           `bson:"migration_500_service_module_errors"`
+	Migration500UserSCIMColumns                 bool               `bson:"migration_500_user_scim_columns"`
+	Migration500UserGroupSCIMColumn             bool               `bson:"migration_500_user_group_scim_column"`
 	Error                                       string             `bson:"error"`
 }
 
@@ -55,7 +55,7 @@ func (a *Agent) start(stop chan struct{}) {
 	InitAgent()
 
 	// Start the agent core service
-	agentCtl := agent.NewAgentController()
+	agentCtl := agent.NewAgentController(stop)
 	go agentCtl.Start(a.Ctx)
 
 	// Start the heartbeat service
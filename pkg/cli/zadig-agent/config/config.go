@@ -45,6 +45,7 @@ func NewAgentConfig() *AgentConfig {
 		InstallTime:   viper.GetInt64(common.AGENT_INSTALL_TIME),
 		InstallUser:   viper.GetString(common.AGENT_INSTALL_USER),
 		WorkDirectory: viper.GetString(common.AGENT_WORK_DIRECTORY),
+		PushDispatch:  viper.GetBool(common.AGENT_PUSH_DISPATCH),
 	}
 }
 
@@ -77,6 +78,9 @@ type AgentConfig struct {
 	BuildCommit       string `yaml:"build_commit"`
 	BuildTime         string `yaml:"build_time"`
 	EnableDebug       bool   `yaml:"enable_debug"`
+	// PushDispatch makes the agent keep a persistent connection to zadig server over which
+	// jobs are pushed, polling is only used while the connection is down.
+	PushDispatch bool `yaml:"push_dispatch"`
 }
 
 func InitConfig() bool {
@@ -313,6 +317,10 @@ func GetEnableDebug() bool {
 	return agentConfig.EnableDebug
 }
 
+func GetPushDispatch() bool {
+	return agentConfig.PushDispatch || viper.GetBool(common.AGENT_PUSH_DISPATCH)
+}
+
 func GetAgentVersion() string {
 	return agentConfig.AgentVersion
 }
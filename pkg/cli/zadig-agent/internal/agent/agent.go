@@ -19,6 +19,8 @@ package agent
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/config"
@@ -30,13 +32,16 @@ import (
 	"github.com/koderover/zadig/v2/pkg/util"
 )
 
-func NewAgentController() *AgentController {
+func NewAgentController(stopAgentChan chan struct{}) *AgentController {
 	return &AgentController{
 		Client:               network.NewZadigClient(),
+		StopAgentChan:        stopAgentChan,
 		StopPollingJobChan:   make(chan struct{}, 1),
 		StopRunJobChan:       make(chan struct{}, 1),
 		ConcurrencyBlockTime: common.DefaultAgentConcurrencyBlockTime,
 		CurrentJobNum:        0,
+		jobFinishedChan:      make(chan struct{}, 1),
+		runningJobs:          make(map[string]*jobexecutor.JobExecutor),
 	}
 }
 
@@ -45,10 +50,18 @@ type AgentController struct {
 	JobChan              chan *types.ZadigJobTask
 	StopPollingJobChan   chan struct{}
 	StopRunJobChan       chan struct{}
+	StopAgentChan        chan struct{}
 	Concurrency          int
 	ConcurrencyBlockTime int
 	CurrentJobNum        int
 	WorkingDirectory     string
+
+	// dispatchConnected is set while the push dispatch connection is up, polling pauses meanwhile
+	dispatchConnected atomic.Bool
+	jobFinishedChan   chan struct{}
+	runningJobsLock   sync.Mutex
+	runningJobs       map[string]*jobexecutor.JobExecutor
+	offlineOnce       sync.Once
 }
 
 func (c *AgentController) Start(ctx context.Context) {
@@ -57,6 +70,23 @@ func (c *AgentController) Start(ctx context.Context) {
 	go c.PollingJob(ctx)
 
 	go c.RunJob(ctx)
+
+	if config.GetPushDispatch() {
+		go c.DispatchJob(ctx)
+	}
+}
+
+// Offline stops the agent, zadig server asks for it once the vm is taken offline.
+// Both the heartbeat and the dispatch connection may report it, the agent is only stopped once.
+func (c *AgentController) Offline() {
+	c.offlineOnce.Do(func() {
+		log.Infof("agent is offline")
+		if c.StopAgentChan == nil {
+			return
+		}
+		c.StopAgentChan <- struct{}{}
+		close(c.StopAgentChan)
+	})
 }
 
 func (c *AgentController) StopPollingJob() {
@@ -86,6 +116,11 @@ func (c *AgentController) PollingJob(ctx context.Context) {
 			log.Infof("stop polling job, received stop signal.")
 			return
 		default:
+			if c.dispatchConnected.Load() {
+				time.Sleep(time.Duration(c.ConcurrencyBlockTime) * time.Second)
+				continue
+			}
+
 			if config.GetAgentStatus() == common.AGENT_STATUS_RUNNING && config.GetScheduleWorkflow() && c.CurrentJobNum < config.GetConcurrency() {
 				job, err := c.Client.RequestJob()
 				if err != nil {
@@ -131,22 +166,27 @@ func (c *AgentController) RunJob(ctx context.Context) {
 				return
 			}
 
-			go func() {
-				defer func() {
-					c.CurrentJobNum--
-				}()
-				if err := c.RunSingleJob(ctx, job); err != nil {
-					log.Errorf("failed to run job, error: %s", err)
-				}
-			}()
+			go c.runJob(ctx, job)
 		}
 	}
 }
 
+func (c *AgentController) runJob(ctx context.Context, job *types.ZadigJobTask) {
+	defer func() {
+		c.CurrentJobNum--
+		c.notifyJobFinished()
+	}()
+	if err := c.RunSingleJob(ctx, job); err != nil {
+		log.Errorf("failed to run job, error: %s", err)
+	}
+}
+
 func (c *AgentController) RunSingleJob(ctx context.Context, job *types.ZadigJobTask) error {
 	var err error
 	jobCtx, cancel := context.WithCancel(ctx)
 	executor := jobexecutor.NewJobExecutor(jobCtx, job, c.Client, cancel)
+	c.addRunningJob(job.ID, executor)
+	defer c.removeRunningJob(job.ID)
 
 	// execute some init job before execute zadig job
 	err = executor.BeforeExecute()
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/config"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/helper/log"
+	jobexecutor "github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/agent/job"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common"
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/network"
+)
+
+// DispatchJob keeps a persistent connection to zadig server and runs the jobs pushed over it.
+// While the connection is down the agent falls back to polling.
+func (c *AgentController) DispatchJob(ctx context.Context) {
+	log.Infof("start push dispatch.")
+	interval := common.DefaultDispatchReconnectInterval
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infof("stop push dispatch, received context cancel signal.")
+			return
+		default:
+		}
+
+		if config.GetAgentStatus() != common.AGENT_STATUS_RUNNING || !config.GetScheduleWorkflow() {
+			time.Sleep(time.Duration(c.ConcurrencyBlockTime) * time.Second)
+			continue
+		}
+
+		conn, err := c.Client.ConnectDispatch()
+		if err != nil {
+			log.Warnf("failed to connect push dispatch, falling back to polling, will retry in %d seconds, error: %s", interval, err)
+			time.Sleep(time.Duration(interval) * time.Second)
+			interval *= 2
+			if interval > common.MaxDispatchReconnectInterval {
+				interval = common.MaxDispatchReconnectInterval
+			}
+			continue
+		}
+		interval = common.DefaultDispatchReconnectInterval
+
+		log.Infof("push dispatch connected, polling paused.")
+		if err := c.serveDispatch(ctx, conn); err != nil {
+			log.Warnf("push dispatch disconnected, falling back to polling, error: %s", err)
+		}
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
+
+func (c *AgentController) serveDispatch(ctx context.Context, conn *network.DispatchConn) error {
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c.dispatchConnected.Store(true)
+	defer c.dispatchConnected.Store(false)
+
+	go func() {
+		// closing the connection unblocks the reader below
+		<-sessionCtx.Done()
+		_ = conn.Close()
+	}()
+
+	// report the free capacity every time a job finishes so that zadig server can push the next one
+	go func() {
+		for {
+			select {
+			case <-sessionCtx.Done():
+				return
+			case <-c.jobFinishedChan:
+				if err := conn.SendReady(c.capacity()); err != nil {
+					log.Warnf("failed to send ready to zadig server, error: %s", err)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	if err := conn.SendReady(c.capacity()); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := conn.Read()
+		if err != nil {
+			if sessionCtx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		switch msg.Type {
+		case network.DispatchMessageJob:
+			job := msg.Job
+			if job == nil || job.ID == "" {
+				continue
+			}
+			c.CurrentJobNum++
+			log.Infof("DispatchJob: received job workflow name: %v, task id: %v, project name: %v, job name: %v",
+				job.WorkflowName, job.TaskID, job.ProjectName, job.JobName)
+			if config.GetEnableDebug() {
+				log.Debugf("received job detail: %+v", job)
+			}
+			go c.runJob(ctx, job)
+		case network.DispatchMessageCancel:
+			c.cancelRunningJob(msg.JobID)
+		case network.DispatchMessageConfig:
+			if msg.Config == nil {
+				continue
+			}
+			c.HandleServerConfig(msg.Config)
+			if msg.Config.NeedOffline {
+				return nil
+			}
+			if err := conn.SendReady(c.capacity()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// capacity is the number of jobs the agent can accept right now.
+func (c *AgentController) capacity() int {
+	if config.GetAgentStatus() != common.AGENT_STATUS_RUNNING || !config.GetScheduleWorkflow() {
+		return 0
+	}
+	if free := config.GetConcurrency() - c.CurrentJobNum; free > 0 {
+		return free
+	}
+	return 0
+}
+
+func (c *AgentController) notifyJobFinished() {
+	select {
+	case c.jobFinishedChan <- struct{}{}:
+	default:
+	}
+}
+
+func (c *AgentController) addRunningJob(jobID string, executor *jobexecutor.JobExecutor) {
+	c.runningJobsLock.Lock()
+	defer c.runningJobsLock.Unlock()
+	c.runningJobs[jobID] = executor
+}
+
+func (c *AgentController) removeRunningJob(jobID string) {
+	c.runningJobsLock.Lock()
+	defer c.runningJobsLock.Unlock()
+	delete(c.runningJobs, jobID)
+}
+
+// cancelRunningJob marks the job as cancelled, the executor picks it up the same way
+// as a cancellation returned by the job report.
+func (c *AgentController) cancelRunningJob(jobID string) {
+	c.runningJobsLock.Lock()
+	defer c.runningJobsLock.Unlock()
+	if executor, ok := c.runningJobs[jobID]; ok {
+		log.Infof("job %s is cancelled by zadig server.", jobID)
+		*executor.Cancel = true
+	}
+}
+
+// HandleServerConfig handles the configuration zadig server sends with the heartbeat response or over the dispatch
+// connection, so that both paths behave the same. NeedUpdateAgentVersion is not handled in either path, the agent
+// self update is disabled for now.
+func (c *AgentController) HandleServerConfig(resp *network.HeartbeatServerResponse) {
+	if resp.NeedOffline {
+		c.Offline()
+	}
+
+	if err := ApplyServerConfig(resp); err != nil {
+		log.Errorf("failed to update agent config: %v", err)
+	}
+}
+
+// ApplyServerConfig saves the agent configuration sent by zadig server.
+func ApplyServerConfig(resp *network.HeartbeatServerResponse) error {
+	agentConfig := new(config.AgentConfig)
+	if resp.VmName != "" {
+		agentConfig.VmName = resp.VmName
+	}
+	if resp.Description != "" {
+		agentConfig.Description = resp.Description
+	}
+	if resp.ZadigVersion != "" {
+		agentConfig.ZadigVersion = resp.ZadigVersion
+	}
+	if resp.ServerURL != "" {
+		agentConfig.ServerURL = resp.ServerURL
+	}
+	if resp.WorkDir != "" {
+		agentConfig.WorkDirectory = resp.WorkDir
+	}
+	agentConfig.ScheduleWorkflow = resp.ScheduleWorkflow
+
+	if resp.Concurrency > 0 {
+		agentConfig.Concurrency = resp.Concurrency
+	}
+
+	if resp.CacheType != "" {
+		agentConfig.CacheType = resp.CacheType
+	}
+
+	return config.BatchUpdateAgentConfig(agentConfig)
+}
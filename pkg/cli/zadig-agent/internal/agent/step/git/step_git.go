@@ -37,6 +37,7 @@ import (
 	agenttypes "github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
 	codehostmodels "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/models"
 	gittool "github.com/koderover/zadig/v2/pkg/tool/git"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 	"github.com/koderover/zadig/v2/pkg/util"
@@ -179,7 +180,7 @@ func (s *GitStep) buildGitCommands(repo *types.Repository, hostNames sets.String
 	if len(owner) == 0 {
 		owner = repo.RepoOwner
 	}
-	if repo.Source == types.ProviderGitlab {
+	if repo.Source == types.ProviderGitlab || repo.Source == types.ProviderGitea {
 		u, _ := url.Parse(repo.Address)
 		host := strings.TrimSuffix(strings.Join([]string{u.Host, u.Path}, "/"), "/")
 		cmds = append(cmds, &common.Command{
@@ -203,6 +204,17 @@ func (s *GitStep) buildGitCommands(repo *types.Repository, hostNames sets.String
 			Cmd:          gitcmd.RemoteAdd(repo.RemoteName, fmt.Sprintf("%s://%s:%s@%s/%s/%s.git", u.Scheme, user, repo.Password, host, owner, repo.RepoName)),
 			DisableTrace: true,
 		})
+	} else if repo.Source == types.ProviderBitbucket {
+		u, err := url.Parse(bitbucket.CloneURL(repo.Address, owner, repo.RepoName))
+		if err != nil {
+			s.Logger.Errorf("failed to parse url,err:%s", err)
+		} else {
+			u.User = bitbucket.CloneUserInfo(repo.Username, repo.Password, repo.OauthToken)
+			cmds = append(cmds, &common.Command{
+				Cmd:          gitcmd.RemoteAdd(repo.RemoteName, u.String()),
+				DisableTrace: true,
+			})
+		}
 	} else if repo.Source == types.ProviderGitee || repo.Source == types.ProviderGiteeEE {
 		cmds = append(cmds, &common.Command{Cmd: gitcmd.RemoteAdd(repo.RemoteName, HTTPSCloneURL(repo.Source, repo.OauthToken, repo.RepoOwner, repo.RepoName, repo.Address)), DisableTrace: true})
 	} else if repo.Source == types.ProviderOther {
@@ -421,7 +433,7 @@ func convertToOAuthURL(repoURL, submoduleURL string, mainRepo *types.Repository,
 }
 
 func setAuthInSubmoduleURL(u *url.URL, mainRepo *types.Repository, codeHost *codehostmodels.CodeHost) (*url.URL, error) {
-	if mainRepo.Source == types.ProviderGitlab || mainRepo.Source == types.ProviderGitee || mainRepo.Source == types.ProviderGiteeEE {
+	if mainRepo.Source == types.ProviderGitlab || mainRepo.Source == types.ProviderGitee || mainRepo.Source == types.ProviderGiteeEE || mainRepo.Source == types.ProviderGitea {
 		if strings.HasPrefix(u.String(), mainRepo.Address) {
 			u.User = url.UserPassword(step.OauthTokenPrefix, mainRepo.OauthToken)
 			return u, nil
@@ -431,6 +443,11 @@ func setAuthInSubmoduleURL(u *url.URL, mainRepo *types.Repository, codeHost *cod
 			u.User = url.UserPassword(mainRepo.Username, mainRepo.Password)
 			return u, nil
 		}
+	} else if mainRepo.Source == types.ProviderBitbucket {
+		if strings.HasPrefix(u.String(), mainRepo.Address) {
+			u.User = bitbucket.CloneUserInfo(mainRepo.Username, mainRepo.Password, mainRepo.OauthToken)
+			return u, nil
+		}
 	} else if mainRepo.Source == types.ProviderOther {
 		if mainRepo.AuthType == types.SSHAuthType {
 			// don't process ssh protocol
@@ -447,7 +464,7 @@ func setAuthInSubmoduleURL(u *url.URL, mainRepo *types.Repository, codeHost *cod
 		}
 	}
 
-	if codeHost.Type == types.ProviderGitlab || codeHost.Type == types.ProviderGitee || codeHost.Type == types.ProviderGiteeEE {
+	if codeHost.Type == types.ProviderGitlab || codeHost.Type == types.ProviderGitee || codeHost.Type == types.ProviderGiteeEE || codeHost.Type == types.ProviderGitea {
 		if strings.HasPrefix(u.String(), codeHost.Address) {
 			u.User = url.UserPassword(step.OauthTokenPrefix, codeHost.AccessToken)
 			return u, nil
@@ -458,6 +475,11 @@ func setAuthInSubmoduleURL(u *url.URL, mainRepo *types.Repository, codeHost *cod
 			u.User = url.UserPassword(codeHost.Username, codeHost.Password)
 			return u, nil
 		}
+	} else if codeHost.Type == types.ProviderBitbucket {
+		if strings.HasPrefix(u.String(), codeHost.Address) {
+			u.User = bitbucket.CloneUserInfo(codeHost.Username, codeHost.Password, codeHost.AccessToken)
+			return u, nil
+		}
 	} else if codeHost.Type == types.ProviderOther {
 		if codeHost.AuthType == types.SSHAuthType {
 			// don't process ssh protocol
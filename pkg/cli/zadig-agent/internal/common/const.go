@@ -30,6 +30,7 @@ const (
 	AGENT_UPDATE_TIME    = "AGENT_UPDATE_TIME"
 	AGENT_UPDATE_USER    = "AGENT_UPDATE_USER"
 	AGENT_WORK_DIRECTORY = "AGENT_WORK_DIRECTORY"
+	AGENT_PUSH_DISPATCH  = "AGENT_PUSH_DISPATCH"
 )
 
 // agent status
@@ -74,6 +75,8 @@ const (
 	DefaultAgentConcurrency          = 10
 	DefaultAgentConcurrencyBlockTime = 5
 	DefaultAgentPollingInterval      = 3
+	DefaultDispatchReconnectInterval = 5
+	MaxDispatchReconnectInterval     = 60
 	DefaultJobReportInterval         = 1
 	DefaultJobLogReadNum             = 100
 )
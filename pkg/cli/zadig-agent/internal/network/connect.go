@@ -30,6 +30,7 @@ const (
 	RequestJobBaseUrl   = "/api/aslan/vm/agents/job/request"
 	ReportJobBaseUrl    = "/api/aslan/vm/agents/job/report"
 	DownloadFileBaseUrl = "/api/aslan/vm/agents/tempFile/download/%s"
+	DispatchBaseUrl     = "/api/aslan/vm/agents/connect"
 )
 
 type RegisterAgentParameters struct {
@@ -137,12 +138,15 @@ func VerifyAgent(config *AgentConfig, parameters *VerifyAgentParameters) (*Verif
 }
 
 type HeartbeatParameters struct {
-	MemTotal      uint64 `json:"mem_total"`
-	UsedMem       uint64 `json:"used_mem"`
-	DiskSpace     uint64 `json:"disk_space"`
-	FreeDiskSpace uint64 `json:"free_disk_space"`
-	Hostname      string `json:"hostname"`
-	AgentVersion  string `json:"agent_version"`
+	MemTotal      uint64  `json:"mem_total"`
+	UsedMem       uint64  `json:"used_mem"`
+	CpuNum        int     `json:"cpu_num"`
+	CpuUsage      float64 `json:"cpu_usage"`
+	DiskSpace     uint64  `json:"disk_space"`
+	FreeDiskSpace uint64  `json:"free_disk_space"`
+	Hostname      string  `json:"hostname"`
+	AgentVersion  string  `json:"agent_version"`
+	RunningJobs   int     `json:"running_jobs"`
 }
 
 type HeartbeatServerRequest struct {
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/koderover/zadig/v2/pkg/cli/zadig-agent/internal/common/types"
+)
+
+const (
+	DispatchMessageReady  = "ready"
+	DispatchMessageJob    = "job"
+	DispatchMessageCancel = "cancel"
+	DispatchMessageConfig = "config"
+
+	dispatchWriteTimeout = 10 * time.Second
+	// dispatchReadTimeout must be longer than the ping interval of zadig server
+	dispatchReadTimeout = 90 * time.Second
+)
+
+type DispatchMessage struct {
+	Type     string                   `json:"type"`
+	Capacity int                      `json:"capacity,omitempty"`
+	Job      *types.ZadigJobTask      `json:"job,omitempty"`
+	JobID    string                   `json:"job_id,omitempty"`
+	Config   *HeartbeatServerResponse `json:"config,omitempty"`
+}
+
+// DispatchConn is the persistent connection over which zadig server pushes jobs,
+// cancellations and config changes to the agent.
+type DispatchConn struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+}
+
+func (c *ZadigClient) ConnectDispatch() (*DispatchConn, error) {
+	dispatchURL, err := getDispatchURL(c.AgentConfig.URL, c.AgentConfig.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: dispatchWriteTimeout,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: true},
+	}
+	conn, resp, err := dialer.Dial(dispatchURL, nil)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("failed to connect to zadig server, status: %s, error: %v", resp.Status, err)
+		}
+		return nil, fmt.Errorf("failed to connect to zadig server, error: %v", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(dispatchReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(dispatchReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(dispatchWriteTimeout))
+	})
+
+	return &DispatchConn{conn: conn}, nil
+}
+
+func getDispatchURL(serverURL, token string) (string, error) {
+	u, err := url.Parse(GetFullURL(strings.TrimSuffix(serverURL, "/"), DispatchBaseUrl))
+	if err != nil {
+		return "", fmt.Errorf("invalid zadig server url %s, error: %v", serverURL, err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	default:
+		return "", fmt.Errorf("unsupported zadig server url scheme: %s", u.Scheme)
+	}
+	u.RawQuery = url.Values{"token": []string{token}}.Encode()
+	return u.String(), nil
+}
+
+// Read blocks until the next message from zadig server arrives.
+func (d *DispatchConn) Read() (*DispatchMessage, error) {
+	msg := new(DispatchMessage)
+	if err := d.conn.ReadJSON(msg); err != nil {
+		return nil, err
+	}
+	_ = d.conn.SetReadDeadline(time.Now().Add(dispatchReadTimeout))
+	return msg, nil
+}
+
+// SendReady tells zadig server how many more jobs the agent can accept.
+func (d *DispatchConn) SendReady(capacity int) error {
+	d.writeLock.Lock()
+	defer d.writeLock.Unlock()
+
+	if err := d.conn.SetWriteDeadline(time.Now().Add(dispatchWriteTimeout)); err != nil {
+		return err
+	}
+	return d.conn.WriteJSON(&DispatchMessage{Type: DispatchMessageReady, Capacity: capacity})
+}
+
+func (d *DispatchConn) Close() error {
+	return d.conn.Close()
+}
@@ -77,7 +77,7 @@ func (h *HeartbeatService) Start(ctx context.Context) {
 			}
 			// execute heartbeat detection logic
 			util.Go(func() {
-				Heartbeat(h.AgentCtl, errChan, successChan, h.StopAgentChan)
+				Heartbeat(h.AgentCtl, errChan, successChan)
 			})
 		case err := <-errChan:
 			log.Errorf("failed to ping zadig server, err: %v", err)
@@ -104,7 +104,7 @@ func (h *HeartbeatService) Start(ctx context.Context) {
 
 }
 
-func Heartbeat(agentCtl *agent.AgentController, errChan chan error, successChan chan struct{}, stopChan chan struct{}) {
+func Heartbeat(agentCtl *agent.AgentController, errChan chan error, successChan chan struct{}) {
 	parameters, err := osutil.GetPlatformParameters()
 	if err != nil {
 		panic(fmt.Errorf("failed to get platform parameters: %v", err))
@@ -116,6 +116,7 @@ func Heartbeat(agentCtl *agent.AgentController, errChan chan error, successChan
 		panic(fmt.Errorf("failed to convert platform parameters to register agent parameters: %v", err))
 	}
 	params.AgentVersion = agentconfig.GetAgentVersion()
+	params.RunningJobs = agentCtl.CurrentJobNum
 
 	config := &network.AgentConfig{
 		Token: agentconfig.GetAgentToken(),
@@ -136,42 +137,7 @@ func Heartbeat(agentCtl *agent.AgentController, errChan chan error, successChan
 	// 	}
 	// }
 
-	if resp.NeedOffline {
-		log.Infof("agent is offline")
-		stopChan <- struct{}{}
-		close(stopChan)
-	}
-
-	agentConfig := new(agentconfig.AgentConfig)
-	if resp.VmName != "" {
-		agentConfig.VmName = resp.VmName
-	}
-	if resp.Description != "" {
-		agentConfig.Description = resp.Description
-	}
-	if resp.ZadigVersion != "" {
-		agentConfig.ZadigVersion = resp.ZadigVersion
-	}
-	if resp.ServerURL != "" {
-		agentConfig.ServerURL = resp.ServerURL
-	}
-	if resp.WorkDir != "" {
-		agentConfig.WorkDirectory = resp.WorkDir
-	}
-	agentConfig.ScheduleWorkflow = resp.ScheduleWorkflow
-
-	if resp.Concurrency > 0 {
-		agentConfig.Concurrency = resp.Concurrency
-	}
-
-	if resp.CacheType != "" {
-		agentConfig.CacheType = resp.CacheType
-	}
-
-	err = agentconfig.BatchUpdateAgentConfig(agentConfig)
-	if err != nil {
-		log.Errorf("failed to update agent config: %v", err)
-	}
+	agentCtl.HandleServerConfig(resp)
 
 	successChan <- struct{}{}
 }
@@ -24,20 +24,22 @@ import (
 	"os/user"
 	"runtime"
 
+	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/mem"
 )
 
 type PlatformParameters struct {
-	IP            string `json:"ip"`
-	OS            string `json:"os"`
-	Arch          string `json:"arch"`
-	CurrentUser   string `json:"current_user"`
-	MemTotal      uint64 `json:"mem_total"`
-	UsedMem       uint64 `json:"used_mem"`
-	CpuNum        int    `json:"cpu_num"`
-	DiskSpace     uint64 `json:"disk_space"`
-	FreeDiskSpace uint64 `json:"free_disk_space"`
-	Hostname      string `json:"hostname"`
+	IP            string  `json:"ip"`
+	OS            string  `json:"os"`
+	Arch          string  `json:"arch"`
+	CurrentUser   string  `json:"current_user"`
+	MemTotal      uint64  `json:"mem_total"`
+	UsedMem       uint64  `json:"used_mem"`
+	CpuNum        int     `json:"cpu_num"`
+	CpuUsage      float64 `json:"cpu_usage"`
+	DiskSpace     uint64  `json:"disk_space"`
+	FreeDiskSpace uint64  `json:"free_disk_space"`
+	Hostname      string  `json:"hostname"`
 }
 
 func GetPlatformParameters() (*PlatformParameters, error) {
@@ -52,6 +54,7 @@ func GetPlatformParameters() (*PlatformParameters, error) {
 	parameters.OS = GetOSName()
 	parameters.Arch = GetOSArch()
 	parameters.CpuNum = GetCpuNum()
+	parameters.CpuUsage = GetCpuUsage()
 
 	currentUser, err := GetHostCurrentUser()
 	if err != nil {
@@ -137,6 +140,15 @@ func GetCpuNum() int {
 	return runtime.NumCPU()
 }
 
+// GetCpuUsage returns the cpu usage in percent since the previous call, 0 if it can not be read.
+func GetCpuUsage() float64 {
+	percent, err := cpu.Percent(0, false)
+	if err != nil || len(percent) == 0 {
+		return 0
+	}
+	return percent[0]
+}
+
 func GetHostCurrentUser() (string, error) {
 	currentUser, err := user.Current()
 	if err != nil {
@@ -61,6 +61,11 @@ func DefaultRecycleDay() int {
 	return int(defaultRecycleDayValue)
 }
 
+// WebhookDeliveryRetentionDays returns the number of days webhook deliveries are kept, 0 means the default
+func WebhookDeliveryRetentionDays() int {
+	return viper.GetInt(setting.ENVWebhookDeliveryRetentionDays)
+}
+
 func PodName() string {
 	return viper.GetString(setting.ENVPodName)
 }
@@ -210,6 +210,12 @@ const (
 	StepSonarGetMetrics   StepType = "sonar_get_metrics"
 	StepAIReviewReport    StepType = "ai_review_report"
 	StepDistributeImage   StepType = "distribute_image"
+	StepSBOM              StepType = "sbom"
+	StepImageSign         StepType = "image_sign"
+	StepImageVulnScan     StepType = "image_vuln_scan"
+	StepRestoreCache      StepType = "restore_cache"
+	StepSaveCache         StepType = "save_cache"
+	StepCoverageReport    StepType = "coverage_report"
 	StepDebugBefore       StepType = "debug_before"
 	StepDebugAfter        StepType = "debug_after"
 )
@@ -266,6 +272,9 @@ const (
 	JobAI                   JobType = "ai-task"
 	JobSAEDeploy            JobType = "sae-deploy"
 	JobApisix               JobType = "apisix"
+	JobSchemaMigration      JobType = "schema-migration"
+	JobProgressiveDelivery  JobType = "k8s-progressive-delivery"
+	JobTerraform            JobType = "terraform"
 )
 
 const (
@@ -288,8 +297,41 @@ const (
 type DBInstanceType string
 
 const (
-	DBInstanceTypeMySQL   DBInstanceType = "mysql"
-	DBInstanceTypeMariaDB DBInstanceType = "mariadb"
+	DBInstanceTypeMySQL      DBInstanceType = "mysql"
+	DBInstanceTypeMariaDB    DBInstanceType = "mariadb"
+	DBInstanceTypePostgreSQL DBInstanceType = "postgresql"
+)
+
+type SchemaMigrationAction string
+
+const (
+	// SchemaMigrationActionUp applies the pending migrations
+	SchemaMigrationActionUp SchemaMigrationAction = "up"
+	// SchemaMigrationActionDown rolls back the applied migrations with their down scripts
+	SchemaMigrationActionDown SchemaMigrationAction = "down"
+	// SchemaMigrationActionInfo only reports the applied and pending migrations
+	SchemaMigrationActionInfo SchemaMigrationAction = "info"
+)
+
+const (
+	SchemaMigrationOutputCurrentVersion = "current_version"
+	SchemaMigrationOutputPendingCount   = "pending_count"
+)
+
+type TerraformBackendType string
+
+const (
+	// TerraformBackendDefault uses the backend declared in the terraform configuration
+	TerraformBackendDefault TerraformBackendType = "default"
+	// TerraformBackendS3 keeps the state in an S3 compatible object storage integrated in zadig
+	TerraformBackendS3 TerraformBackendType = "s3"
+)
+
+const (
+	TerraformOutputHasChanges = "has_changes"
+	TerraformOutputAdd        = "add"
+	TerraformOutputChange     = "change"
+	TerraformOutputDestroy    = "destroy"
 )
 
 type DMSJobExecuteMode string
@@ -302,8 +344,9 @@ const (
 type ObservabilityType string
 
 const (
-	ObservabilityTypeGrafana   ObservabilityType = "grafana"
-	ObservabilityTypeGuanceyun ObservabilityType = "guanceyun"
+	ObservabilityTypeGrafana    ObservabilityType = "grafana"
+	ObservabilityTypeGuanceyun  ObservabilityType = "guanceyun"
+	ObservabilityTypePrometheus ObservabilityType = "prometheus"
 )
 
 type ApprovalType string
@@ -314,6 +357,7 @@ const (
 	LarkApprovalIntl ApprovalType = "lark_intl"
 	DingTalkApproval ApprovalType = "dingtalk"
 	WorkWXApproval   ApprovalType = "workwx"
+	SlackApproval    ApprovalType = "slack"
 )
 
 type SAEUpdateStrategy string
@@ -502,6 +546,34 @@ const (
 	GrayDeploymentSuffix       = "-zadig-gray"
 )
 
+// for custom progressive delivery job
+const (
+	ProgressiveLabelKey         = "zadig-progressive-delivery"
+	ProgressiveLabelValue       = "canary"
+	ProgressiveDeploymentSuffix = "-zadig-progressive"
+)
+
+// AnalysisCondition is the comparison a measured metric value must satisfy against the threshold
+type AnalysisCondition string
+
+const (
+	AnalysisConditionLessThan       AnalysisCondition = "<"
+	AnalysisConditionLessOrEqual    AnalysisCondition = "<="
+	AnalysisConditionGreaterThan    AnalysisCondition = ">"
+	AnalysisConditionGreaterOrEqual AnalysisCondition = ">="
+	AnalysisConditionEqual          AnalysisCondition = "=="
+)
+
+type AnalysisVerdict string
+
+const (
+	AnalysisVerdictRunning      AnalysisVerdict = "running"
+	AnalysisVerdictSuccessful   AnalysisVerdict = "successful"
+	AnalysisVerdictFailed       AnalysisVerdict = "failed"
+	AnalysisVerdictInconclusive AnalysisVerdict = "inconclusive"
+	AnalysisVerdictSkipped      AnalysisVerdict = "skipped"
+)
+
 type WorkflowTriggerType string
 
 const (
@@ -583,6 +655,7 @@ const (
 	TestJobHTMLReportArchiveStepName = "html-report-archive-step"
 	TestJobArchiveResultStepName     = "archive-result-step"
 	TestJobObjectStorageStepName     = "object-storage-step"
+	TestJobCoverageReportStepName    = "coverage-report-step"
 )
 
 const (
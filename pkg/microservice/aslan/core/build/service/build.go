@@ -34,6 +34,7 @@ import (
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/types"
@@ -328,6 +329,9 @@ func CreateBuild(username string, build *commonmodels.Build, log *zap.SugaredLog
 			return e.ErrCreateBuildModule.AddDesc(err.Error())
 		}
 	}
+	if err := buildcache.ValidateRules(build.CacheRules); err != nil {
+		return e.ErrCreateBuildModule.AddDesc(err.Error())
+	}
 
 	if err := commonrepo.NewBuildColl().Create(build); err != nil {
 		log.Errorf("[Build.Create] %s error: %v", build.Name, err)
@@ -344,6 +348,9 @@ func UpdateBuild(username string, build *commonmodels.Build, log *zap.SugaredLog
 	if err := commonutil.CheckDefineResourceParam(build.PreBuild.ResReq, build.PreBuild.ResReqSpec); err != nil {
 		return e.ErrUpdateBuildModule.AddDesc(err.Error())
 	}
+	if err := buildcache.ValidateRules(build.CacheRules); err != nil {
+		return e.ErrUpdateBuildModule.AddDesc(err.Error())
+	}
 
 	existed, err := commonrepo.NewBuildColl().Find(&commonrepo.BuildFindOption{Name: build.Name, ProductName: build.ProductName})
 	if err == nil && existed.PreBuild != nil && build.PreBuild != nil {
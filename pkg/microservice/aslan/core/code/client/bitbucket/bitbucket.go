@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+)
+
+type Config struct {
+	Address     string `json:"address"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	AccessToken string `json:"access_token"`
+	EnableProxy bool   `json:"enable_proxy"`
+	DisableSSL  bool   `json:"disable_ssl"`
+}
+
+type Client struct {
+	Client *bitbucket.Client
+}
+
+func (c *Config) Open(id int, logger *zap.SugaredLogger) (client.CodeHostClient, error) {
+	cli, err := bitbucket.NewClient(c.Address, c.Username, c.Password, c.AccessToken, config.ProxyHTTPSAddr(), c.EnableProxy, c.DisableSSL)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Client: cli}, nil
+}
+
+func (c *Client) ListBranches(opt client.ListOpt) ([]*client.Branch, error) {
+	branches, err := c.Client.ListBranches(opt.Namespace, opt.ProjectName, opt.Key)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.Branch
+	for _, o := range branches {
+		res = append(res, &client.Branch{
+			Name: o.Name,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListTags(opt client.ListOpt) ([]*client.Tag, error) {
+	tags, err := c.Client.ListTags(opt.Namespace, opt.ProjectName, opt.Key)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.Tag
+	for _, o := range tags {
+		res = append(res, &client.Tag{
+			Name:    o.Name,
+			Message: o.Message,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListPrs(opt client.ListOpt) ([]*client.PullRequest, error) {
+	prs, err := c.Client.ListPullRequests(opt.Namespace, opt.ProjectName, opt.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.PullRequest
+	for _, o := range prs {
+		res = append(res, &client.PullRequest{
+			ID:             o.ID,
+			Number:         o.ID,
+			Title:          o.Title,
+			State:          o.State,
+			CreatedAt:      o.CreatedAt,
+			UpdatedAt:      o.UpdatedAt,
+			User:           o.Author,
+			AuthorUsername: o.Author,
+			SourceBranch:   o.SourceBranch,
+			TargetBranch:   o.TargetBranch,
+		})
+	}
+	return res, nil
+}
+
+// ListNamespaces lists the workspaces of bitbucket cloud or the projects of bitbucket data center
+func (c *Client) ListNamespaces(keyword string) ([]*client.Namespace, error) {
+	namespaces, err := c.Client.ListNamespaces(keyword)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.Namespace
+	for _, o := range namespaces {
+		res = append(res, &client.Namespace{
+			Name: o.Key,
+			Path: o.Key,
+			Kind: client.OrgKind,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListProjects(opt client.ListOpt) ([]*client.Project, error) {
+	repos, err := c.Client.ListRepositories(opt.Namespace, opt.Key, &bitbucket.ListOptions{
+		Page:        opt.Page,
+		PerPage:     opt.PerPage,
+		NoPaginated: opt.PerPage > 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.Project
+	for _, o := range repos {
+		res = append(res, &client.Project{
+			Name:          o.Name,
+			Description:   o.DisplayName,
+			DefaultBranch: o.DefaultBranch,
+			Namespace:     o.Namespace,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
+	commits, err := c.Client.ListCommits(opt.Namespace, opt.ProjectName, opt.TargetBranch, &bitbucket.ListOptions{
+		Page:        opt.Page,
+		PerPage:     opt.PerPage,
+		NoPaginated: true,
+	})
+	if err != nil {
+		return nil, e.ErrCodehostListCommits.AddDesc(err.Error())
+	}
+	var res []*client.Commit
+	for _, o := range commits {
+		res = append(res, &client.Commit{
+			ID:        o.ID,
+			Message:   o.Message,
+			Author:    o.Author,
+			CreatedAt: o.CreatedAt,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
+)
+
+type Config struct {
+	Address     string `json:"address"`
+	AccessToken string `json:"access_token"`
+	EnableProxy bool   `json:"enable_proxy"`
+	DisableSSL  bool   `json:"disable_ssl"`
+}
+
+type Client struct {
+	Client *gitea.Client
+}
+
+func (c *Config) Open(id int, logger *zap.SugaredLogger) (client.CodeHostClient, error) {
+	cli, err := gitea.NewClient(id, c.Address, c.AccessToken, config.ProxyHTTPSAddr(), c.EnableProxy, c.DisableSSL)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Client: cli}, nil
+}
+
+func (c *Client) ListBranches(opt client.ListOpt) ([]*client.Branch, error) {
+	bList, err := c.Client.ListBranches(opt.Namespace, opt.ProjectName, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.Branch
+	for _, o := range bList {
+		matched, _ := regexp.MatchString(fmt.Sprintf(`%s`, opt.Key), o.Name)
+		if matched {
+			res = append(res, &client.Branch{
+				Name:      o.Name,
+				Protected: o.Protected,
+			})
+		}
+	}
+	return res, nil
+}
+
+func (c *Client) IsBranchMerged(opt client.BranchMergeCheckOpt) (bool, error) {
+	if opt.SourceBranch == opt.TargetBranch {
+		return true, nil
+	}
+
+	compare, err := c.Client.Compare(opt.Namespace, opt.ProjectName, opt.TargetBranch, opt.SourceBranch)
+	if err != nil {
+		return false, err
+	}
+
+	return compare.TotalCommits == 0 && len(compare.Commits) == 0, nil
+}
+
+func (c *Client) ListTags(opt client.ListOpt) ([]*client.Tag, error) {
+	tags, err := c.Client.ListTags(opt.Namespace, opt.ProjectName, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.Tag
+	for _, o := range tags {
+		matched, _ := regexp.MatchString(fmt.Sprintf(`%s`, opt.Key), o.Name)
+		if matched {
+			res = append(res, &client.Tag{
+				Name:       o.Name,
+				Message:    o.Message,
+				ZipballURL: o.ZipballURL,
+				TarballURL: o.TarballURL,
+			})
+		}
+	}
+	return res, nil
+}
+
+func (c *Client) ListPrs(opt client.ListOpt) ([]*client.PullRequest, error) {
+	prs, err := c.Client.ListPullRequests(opt.Namespace, opt.ProjectName, "open", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []*client.PullRequest
+	for _, o := range prs {
+		if opt.TargetBranch != "" && o.Base.Ref != opt.TargetBranch {
+			continue
+		}
+		res = append(res, &client.PullRequest{
+			ID:             o.Number,
+			CreatedAt:      o.Created.Unix(),
+			UpdatedAt:      o.Updated.Unix(),
+			State:          o.State,
+			User:           o.User.Login,
+			Number:         o.Number,
+			AuthorUsername: o.User.Login,
+			Title:          o.Title,
+			SourceBranch:   o.Head.Ref,
+			TargetBranch:   o.Base.Ref,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListNamespaces(keyword string) ([]*client.Namespace, error) {
+	user, err := c.Client.GetAuthenticatedUser()
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := c.Client.ListOrganizationsForAuthenticatedUser(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*client.Namespace{{
+		Name: user.Login,
+		Path: user.Login,
+		Kind: client.UserKind,
+	}}
+	for _, o := range organizations {
+		res = append(res, &client.Namespace{
+			Name: o.Username,
+			Path: o.Username,
+			Kind: client.OrgKind,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListProjects(opt client.ListOpt) ([]*client.Project, error) {
+	var repos []*gitea.Repository
+	var err error
+	listOpt := &gitea.ListOptions{Page: opt.Page, PerPage: opt.PerPage, NoPaginated: opt.PerPage > 0}
+	switch {
+	case opt.NamespaceType == client.OrgKind:
+		repos, err = c.Client.ListRepositoriesForOrg(opt.Namespace, listOpt)
+	case opt.Key != "":
+		repos, err = c.Client.SearchRepositories(opt.Key, listOpt)
+	default:
+		repos, err = c.Client.ListRepositoriesForAuthenticatedUser(listOpt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*client.Project
+	for _, o := range repos {
+		// the user repo api returns all repos the user has access to, filter them with the query namespace
+		if len(opt.Namespace) > 0 && o.Owner.Login != opt.Namespace {
+			continue
+		}
+		res = append(res, &client.Project{
+			ID:            int(o.ID),
+			Name:          o.Name,
+			DefaultBranch: o.DefaultBranch,
+			Namespace:     o.Owner.Login,
+		})
+	}
+	return res, nil
+}
+
+func (c *Client) ListCommits(opt client.ListOpt) ([]*client.Commit, error) {
+	commits, err := c.Client.ListCommits(opt.Namespace, opt.ProjectName, opt.TargetBranch, "", &gitea.ListOptions{
+		Page:        opt.Page,
+		PerPage:     opt.PerPage,
+		NoPaginated: true,
+	})
+	if err != nil {
+		return nil, e.ErrCodehostListCommits.AddDesc(err.Error())
+	}
+	var res []*client.Commit
+	for _, o := range commits {
+		commit := &client.Commit{
+			ID:      o.SHA,
+			Message: o.Commit.Message,
+		}
+		if o.Commit.Author != nil {
+			commit.Author = o.Commit.Author.Name
+			if t, err := time.Parse(time.RFC3339, o.Commit.Author.Date); err == nil {
+				commit.CreatedAt = t.Unix()
+			}
+		}
+		res = append(res, commit)
+	}
+	return res, nil
+}
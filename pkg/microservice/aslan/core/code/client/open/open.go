@@ -23,8 +23,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/bitbucket"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/gerrit"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/git"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/gitea"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/gitee"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/github"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/client/gitlab"
@@ -37,12 +39,14 @@ type ClientConfig interface {
 }
 
 var ClientsConfig = map[string]func() ClientConfig{
-	setting.SourceFromOther:   func() ClientConfig { return new(git.Config) },
-	setting.SourceFromGitlab:  func() ClientConfig { return new(gitlab.Config) },
-	setting.SourceFromGithub:  func() ClientConfig { return new(github.Config) },
-	setting.SourceFromGerrit:  func() ClientConfig { return new(gerrit.Config) },
-	setting.SourceFromGitee:   func() ClientConfig { return new(gitee.Config) },
-	setting.SourceFromGiteeEE: func() ClientConfig { return new(gitee.EEConfig) },
+	setting.SourceFromOther:     func() ClientConfig { return new(git.Config) },
+	setting.SourceFromGitlab:    func() ClientConfig { return new(gitlab.Config) },
+	setting.SourceFromGithub:    func() ClientConfig { return new(github.Config) },
+	setting.SourceFromGerrit:    func() ClientConfig { return new(gerrit.Config) },
+	setting.SourceFromGitee:     func() ClientConfig { return new(gitee.Config) },
+	setting.SourceFromGiteeEE:   func() ClientConfig { return new(gitee.EEConfig) },
+	setting.SourceFromGitea:     func() ClientConfig { return new(gitea.Config) },
+	setting.SourceFromBitbucket: func() ClientConfig { return new(bitbucket.Config) },
 }
 
 func OpenClient(ch *systemconfig.CodeHost, log *zap.SugaredLogger) (client.CodeHostClient, error) {
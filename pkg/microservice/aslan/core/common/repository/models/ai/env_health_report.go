@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ai
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// EnvHealthReport is the result of a rule based environment analysis run without an LLM.
+// New and Resolved are computed against the previous report of the same environment.
+type EnvHealthReport struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty"                json:"id,omitempty"`
+	ProjectName string              `bson:"project_name"                 json:"project_name"`
+	EnvName     string              `bson:"env_name"                     json:"env_name"`
+	Production  bool                `bson:"production"                   json:"production"`
+	Status      string              `bson:"status"                       json:"status"`
+	Problems    []*EnvHealthProblem `bson:"problems"                     json:"problems"`
+	New         []*EnvHealthProblem `bson:"new"                          json:"new"`
+	Resolved    []*EnvHealthProblem `bson:"resolved"                     json:"resolved"`
+	Errors      []string            `bson:"errors"                       json:"errors"`
+	Err         string              `bson:"err"                          json:"err"`
+	StartTime   int64               `bson:"start_time"                   json:"start_time"`
+	EndTime     int64               `bson:"end_time"                     json:"end_time"`
+	TriggerName string              `bson:"trigger_name"                 json:"trigger_name"`
+	CreatedBy   string              `bson:"created_by"                   json:"created_by"`
+}
+
+type EnvHealthProblem struct {
+	Kind     string   `bson:"kind"     json:"kind"`
+	Name     string   `bson:"name"     json:"name"`
+	Failures []string `bson:"failures" json:"failures"`
+}
+
+func (p *EnvHealthProblem) Key() string {
+	return p.Kind + "/" + p.Name
+}
+
+func (EnvHealthReport) TableName() string {
+	return "env_health_report"
+}
@@ -74,6 +74,9 @@ type Build struct {
 	CacheEnable  bool               `bson:"cache_enable"   json:"cache_enable"`
 	CacheDirType types.CacheDirType `bson:"cache_dir_type" json:"cache_dir_type"`
 	CacheUserDir string             `bson:"cache_user_dir" json:"cache_user_dir"`
+	// CacheRules replaces the single cache dir with named, content-keyed caches when set
+	CacheRules      []*types.CacheRule `bson:"cache_rules,omitempty"        json:"cache_rules,omitempty"`
+	CacheQuotaInMiB int64              `bson:"cache_quota_in_mib,omitempty" json:"cache_quota_in_mib,omitempty"`
 	// New since V1.10.0. Only to tell the webpage should the advanced settings be displayed
 	EnablePrivilegedMode     bool      `bson:"enable_privileged_mode" json:"enable_privileged_mode"`
 	AdvancedSettingsModified bool      `bson:"advanced_setting_modified" json:"advanced_setting_modified"`
@@ -136,6 +139,30 @@ type PostBuild struct {
 	ObjectStorageUpload *ObjectStorageUpload `bson:"object_storage_upload"  json:"object_storage_upload"`
 	FileArchive         *FileArchive         `bson:"file_archive,omitempty" json:"file_archive,omitempty"`
 	Scripts             string               `bson:"scripts"                json:"scripts"`
+	SupplyChain         *SupplyChain         `bson:"supply_chain,omitempty" json:"supply_chain,omitempty"`
+}
+
+// SupplyChain takes effect after the image is built and pushed by the docker build
+type SupplyChain struct {
+	SBOM    *SBOMGeneration `bson:"sbom"    json:"sbom"`
+	Signing *ImageSigning   `bson:"signing" json:"signing"`
+}
+
+type SBOMGeneration struct {
+	Enabled bool `bson:"enabled"     json:"enabled"`
+	// Format is cyclonedx or spdx, cyclonedx is used by default
+	Format string `bson:"format"      json:"format"`
+	// ScanSource generates another SBOM for the source tree besides the image
+	ScanSource bool `bson:"scan_source" json:"scan_source"`
+}
+
+type ImageSigning struct {
+	Enabled bool `bson:"enabled"     json:"enabled"`
+	// KeyRef is the cosign key reference, e.g. env://COSIGN_PRIVATE_KEY for a key saved in the keyvault
+	KeyRef     string `bson:"key_ref"     json:"key_ref"`
+	TlogUpload bool   `bson:"tlog_upload" json:"tlog_upload"`
+	// AttachSBOM attaches the generated SBOM to the image as an attestation
+	AttachSBOM bool `bson:"attach_sbom" json:"attach_sbom"`
 }
 
 type FileArchive struct {
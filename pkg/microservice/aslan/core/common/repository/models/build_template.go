@@ -40,6 +40,8 @@ type BuildTemplate struct {
 	CacheEnable              bool               `bson:"cache_enable"                  json:"cache_enable"`
 	CacheDirType             types.CacheDirType `bson:"cache_dir_type"                json:"cache_dir_type"`
 	CacheUserDir             string             `bson:"cache_user_dir"                json:"cache_user_dir"`
+	CacheRules               []*types.CacheRule `bson:"cache_rules,omitempty"         json:"cache_rules,omitempty"`
+	CacheQuotaInMiB          int64              `bson:"cache_quota_in_mib,omitempty"  json:"cache_quota_in_mib,omitempty"`
 	AdvancedSettingsModified bool               `bson:"advanced_setting_modified"     json:"advanced_setting_modified"`
 	EnablePrivilegedMode     bool               `bson:"enable_privileged_mode"        json:"enable_privileged_mode"`
 	Outputs                  []*Output          `bson:"outputs"                       json:"outputs"`
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+)
+
+// CoverageReport is the coverage collected by the coverage report step of a testing job
+type CoverageReport struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	ProjectName      string             `bson:"project_name"`
+	WorkflowName     string             `bson:"workflow_name"`
+	JobName          string             `bson:"job_name"`
+	JobTaskName      string             `bson:"job_task_name"`
+	TaskID           int64              `bson:"task_id"`
+	RetryNum         int                `bson:"retry_num"`
+	ServiceName      string             `bson:"service_name"`
+	ServiceModule    string             `bson:"service_module"`
+	ZadigTestName    string             `bson:"zadig_test_name"`
+	CodehostID       int                `bson:"codehost_id"`
+	RepoOwner        string             `bson:"repo_owner"`
+	RepoName         string             `bson:"repo_name"`
+	Branch           string             `bson:"branch"`
+	CommitID         string             `bson:"commit_id"`
+	PR               int                `bson:"pr"`
+	coverage.Summary `bson:",inline"`
+	// Files is empty if the per file coverage could not be downloaded from the object storage
+	Files      []*coverage.FileCoverage `bson:"files"`
+	CreateTime int64                    `bson:"create_time"`
+}
+
+func (CoverageReport) TableName() string {
+	return "coverage_report"
+}
@@ -20,22 +20,38 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/tool/database"
 )
 
 type DBInstance struct {
-	ID        primitive.ObjectID    `bson:"_id,omitempty"         json:"id,omitempty"`
-	Type      config.DBInstanceType `bson:"type"                  json:"type"`
-	Name      string                `bson:"name"                  json:"name"`
-	Projects  []string              `bson:"projects"              json:"projects"`
-	Host      string                `bson:"host"                  json:"host"`
-	Port      string                `bson:"port"                  json:"port"`
-	Username  string                `bson:"username"              json:"username"`
-	Password  string                `bson:"password"              json:"password,omitempty"`
-	UpdateBy  string                `bson:"update_by"             json:"update_by"`
-	CreatedAt int64                 `bson:"created_at"            json:"created_at"`
-	UpdatedAt int64                 `bson:"updated_at"            json:"updated_at"`
+	ID          primitive.ObjectID    `bson:"_id,omitempty"         json:"id,omitempty"`
+	Type        config.DBInstanceType `bson:"type"                  json:"type"`
+	Name        string                `bson:"name"                  json:"name"`
+	Projects    []string              `bson:"projects"              json:"projects"`
+	Host        string                `bson:"host"                  json:"host"`
+	Port        string                `bson:"port"                  json:"port"`
+	Username    string                `bson:"username"              json:"username"`
+	Password    string                `bson:"password"              json:"password,omitempty"`
+	Database    string                `bson:"database"              json:"database"`
+	SSLMode     string                `bson:"ssl_mode,omitempty"      json:"ssl_mode,omitempty"`
+	SSLRootCert string                `bson:"ssl_root_cert,omitempty" json:"ssl_root_cert,omitempty"`
+	UpdateBy    string                `bson:"update_by"             json:"update_by"`
+	CreatedAt   int64                 `bson:"created_at"            json:"created_at"`
+	UpdatedAt   int64                 `bson:"updated_at"            json:"updated_at"`
 }
 
 func (h DBInstance) TableName() string {
 	return "db_instance"
 }
+
+func (h *DBInstance) ConnInfo() *database.ConnInfo {
+	return &database.ConnInfo{
+		Host:        h.Host,
+		Port:        h.Port,
+		Username:    h.Username,
+		Password:    h.Password,
+		Database:    h.Database,
+		SSLMode:     h.SSLMode,
+		SSLRootCert: h.SSLRootCert,
+	}
+}
@@ -56,6 +56,10 @@ type IMApp struct {
 	WorkWXApprovalTemplateIDList map[string]string `json:"-"                           bson:"workwx_approval_template_id_list"`
 	WorkWXToken                  string            `json:"workwx_token"                bson:"workwx_token"`
 	WorkWXAESKey                 string            `json:"workwx_aes_key"              bson:"workwx_aes_key"`
+
+	// slack fields
+	SlackBotToken      string `json:"slack_bot_token"      bson:"slack_bot_token"`
+	SlackSigningSecret string `json:"slack_signing_secret" bson:"slack_signing_secret"`
 }
 
 func (IMApp) TableName() string {
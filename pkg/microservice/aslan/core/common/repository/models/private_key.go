@@ -63,6 +63,8 @@ type VMInfo struct {
 	DiskSpace     uint64 `bson:"disk_space"           json:"disk_space"`
 	FreeDiskSpace uint64 `bson:"free_disk_space"      json:"free_disk_space"`
 	HostName      string `bson:"host_name"            json:"host_name"`
+	// CpuUsage is the cpu usage in percent reported by the last heartbeat
+	CpuUsage float64 `bson:"cpu_usage"            json:"cpu_usage"`
 }
 
 type VMAgent struct {
@@ -76,6 +78,7 @@ type VMAgent struct {
 	AgentVersion      string `bson:"agent_version"        json:"agent_version"`
 	ZadigVersion      string `bson:"zadig_version"        json:"zadig_version"`
 	LastHeartbeatTime int64  `bson:"last_heartbeat_time"  json:"last_heartbeat_time"`
+	RunningJobs       int    `bson:"running_jobs"         json:"running_jobs"`
 }
 
 func (PrivateKey) TableName() string {
@@ -88,6 +88,9 @@ type Product struct {
 
 	// For production environment
 	Production bool `json:"production" bson:"production"`
+
+	// TerminalPolicy governs the interactive pod exec sessions opened in this environment.
+	TerminalPolicy *TerminalPolicy `bson:"terminal_policy,omitempty" json:"terminal_policy,omitempty"`
 }
 
 type NotificationEvent string
@@ -95,6 +98,8 @@ type NotificationEvent string
 const (
 	NotificationEventAnalyzerNoraml   NotificationEvent = "notification_event_analyzer_normal"
 	NotificationEventAnalyzerAbnormal NotificationEvent = "notification_event_analyzer_abnormal"
+	// NotificationEventHealthReportChanged fires when a scheduled health report finds new or resolved problems
+	NotificationEventHealthReportChanged NotificationEvent = "notification_event_health_report_changed"
 )
 
 type WebHookType string
@@ -114,22 +119,38 @@ type NotificationConfig struct {
 type ResourceType string
 
 const (
-	ResourceTypePod           ResourceType = "Pod"
-	ResourceTypeDeployment    ResourceType = "Deployment"
-	ResourceTypeDaemonSet     ResourceType = "DaemonSet"
-	ResourceTypeReplicaSet    ResourceType = "ReplicaSet"
-	ResourceTypePVC           ResourceType = "PersistentVolumeClaim"
-	ResourceTypeService       ResourceType = "Service"
-	ResourceTypeIngress       ResourceType = "Ingress"
-	ResourceTypeStatefulSet   ResourceType = "StatefulSet"
-	ResourceTypeCronJob       ResourceType = "CronJob"
-	ResourceTypeHPA           ResourceType = "HorizontalPodAutoScaler"
-	ResourceTypePDB           ResourceType = "PodDisruptionBudget"
-	ResourceTypeNetworkPolicy ResourceType = "NetworkPolicy"
+	ResourceTypePod             ResourceType = "Pod"
+	ResourceTypeDeployment      ResourceType = "Deployment"
+	ResourceTypeDaemonSet       ResourceType = "DaemonSet"
+	ResourceTypeReplicaSet      ResourceType = "ReplicaSet"
+	ResourceTypePVC             ResourceType = "PersistentVolumeClaim"
+	ResourceTypeService         ResourceType = "Service"
+	ResourceTypeIngress         ResourceType = "Ingress"
+	ResourceTypeStatefulSet     ResourceType = "StatefulSet"
+	ResourceTypeCronJob         ResourceType = "CronJob"
+	ResourceTypeHPA             ResourceType = "HorizontalPodAutoScaler"
+	ResourceTypePDB             ResourceType = "PodDisruptionBudget"
+	ResourceTypeNetworkPolicy   ResourceType = "NetworkPolicy"
+	ResourceTypeVirtualService  ResourceType = "VirtualService"
+	ResourceTypeDestinationRule ResourceType = "DestinationRule"
+	ResourceTypeResourceQuota   ResourceType = "ResourceQuota"
 )
 
 type AnalysisConfig struct {
 	ResourceTypes []ResourceType `bson:"resource_types" json:"resource_types"`
+	// CustomRules is a yaml list of declarative analyzer rules, see analysis.Rule for the format
+	CustomRules string `bson:"custom_rules" json:"custom_rules"`
+}
+
+type TerminalPolicy struct {
+	// Record uploads an asciicast recording of every shell session to the default object storage.
+	Record          bool `bson:"record"           json:"record"`
+	RequireReason   bool `bson:"require_reason"   json:"require_reason"`
+	RequireApproval bool `bson:"require_approval" json:"require_approval"`
+	// Approvers are the IDs of the users who can approve a shell request, project admins are used if empty.
+	Approvers []string `bson:"approvers" json:"approvers"`
+	// ApprovalTTL is the number of minutes an approved request can be used to open shells.
+	ApprovalTTL int64 `bson:"approval_ttl" json:"approval_ttl"`
 }
 
 type CreateUpdateCommonEnvCfgArgs struct {
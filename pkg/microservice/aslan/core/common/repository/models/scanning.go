@@ -22,6 +22,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -53,6 +54,8 @@ type Scanning struct {
 	ReviewIncludePaths []string                 `bson:"review_include_paths,omitempty" json:"review_include_paths,omitempty"`
 	ReviewExcludePaths []string                 `bson:"review_exclude_paths,omitempty" json:"review_exclude_paths,omitempty"`
 	ReviewRules        []*ReviewRule            `bson:"review_rules,omitempty" json:"review_rules,omitempty"`
+	// ImageScanSetting is for image vulnerability type only
+	ImageScanSetting *ImageScanSetting `bson:"image_scan_setting,omitempty" json:"image_scan_setting,omitempty"`
 
 	CreatedAt int64  `bson:"created_at" json:"created_at"`
 	UpdatedAt int64  `bson:"updated_at" json:"updated_at"`
@@ -110,6 +113,18 @@ type ScanningHook struct {
 	AutoCancel    bool                   `bson:"auto_cancel"   json:"auto_cancel"`
 }
 
+// ImageScanSetting configures the image vulnerability scanning
+type ImageScanSetting struct {
+	// Image supports variables, e.g. the IMAGE output of a build job
+	Image      string `bson:"image"       json:"image"`
+	RegistryID string `bson:"registry_id" json:"registry_id"`
+	// DBRepository is the mirror of the vulnerability database, which could be updated offline
+	DBRepository  string             `bson:"db_repository"  json:"db_repository"`
+	SkipDBUpdate  bool               `bson:"skip_db_update" json:"skip_db_update"`
+	IgnoreUnfixed bool               `bson:"ignore_unfixed" json:"ignore_unfixed"`
+	Thresholds    []*trivy.Threshold `bson:"thresholds"     json:"thresholds"`
+}
+
 type SonarInfo struct {
 	ServerAddress string `bson:"server_address" json:"server_address"`
 	Token         string `bson:"token"          json:"token"`
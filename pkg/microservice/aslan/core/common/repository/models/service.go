@@ -71,6 +71,7 @@ type Service struct {
 	ServiceVariableKVs []*commontypes.ServiceVariableKV `bson:"service_variable_kvs"           json:"service_variable_kvs"` // New since 1.18.0, stores the variable kvs of k8s services
 	ServiceVars        []string                         `bson:"service_vars"                   json:"service_vars"`         // DEPRECATED, New since 1.16.0, stores keys in variables which can be set in env
 	HelmChart          *HelmChart                       `bson:"helm_chart,omitempty"           json:"helm_chart,omitempty"`
+	Kustomize          *KustomizeConfig                 `bson:"kustomize,omitempty"            json:"kustomize,omitempty"`
 	EnvConfigs         []*EnvConfig                     `bson:"env_configs,omitempty"          json:"env_configs,omitempty"`
 	EnvStatuses        []*EnvStatus                     `bson:"env_statuses,omitempty"         json:"env_statuses,omitempty"`
 	ReleaseNaming      string                           `bson:"release_naming"                 json:"release_naming"`
@@ -217,6 +218,44 @@ type HelmChart struct {
 	ValuesYaml string `bson:"values_yaml"        json:"values_yaml"`
 }
 
+// KustomizeConfig describes a service rendered by kustomize. Files is a snapshot of the load path
+// taken when the service is loaded, so that every revision can be rendered again without the repository.
+type KustomizeConfig struct {
+	// BasePath is the kustomization used by environments without an overlay, relative to the load path
+	BasePath string              `bson:"base_path"          json:"base_path"`
+	Overlays []*KustomizeOverlay `bson:"overlays"           json:"overlays"`
+	Files    []*KustomizeFile    `bson:"files"              json:"-"`
+}
+
+type KustomizeOverlay struct {
+	EnvName string `bson:"env_name"           json:"env_name"`
+	// Path is the overlay kustomization of the environment, relative to the load path
+	Path string `bson:"path"               json:"path"`
+}
+
+type KustomizeFile struct {
+	Path    string `bson:"path"               json:"path"`
+	Content string `bson:"content"            json:"content"`
+}
+
+// OverlayPath returns the kustomization path used to render the service in the environment.
+func (k *KustomizeConfig) OverlayPath(envName string) string {
+	for _, overlay := range k.Overlays {
+		if overlay.EnvName == envName {
+			return overlay.Path
+		}
+	}
+	return k.BasePath
+}
+
+func (k *KustomizeConfig) FileMap() map[string]string {
+	files := make(map[string]string, len(k.Files))
+	for _, file := range k.Files {
+		files[file.Path] = file.Content
+	}
+	return files
+}
+
 type HelmService struct {
 	ProductName string       `json:"product_name"`
 	Project     string       `json:"project"`
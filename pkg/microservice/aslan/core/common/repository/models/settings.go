@@ -98,14 +98,19 @@ type ReleasePlanHookSettings struct {
 	EnableCallBack bool                   `json:"enable_call_back" bson:"enable_call_back"`
 	HookAddress    string                 `json:"hook_address" bson:"hook_address"`
 	HookSecret     string                 `json:"hook_secret" bson:"hook_secret"`
+	SigningSecret  string                 `json:"signing_secret" bson:"signing_secret"`
 	HookEvents     []ReleasePlanHookEvent `json:"hook_events" bson:"hook_events"`
+	// SlackNotificationConfig posts the hook events to slack as well, it is optional.
+	SlackNotificationConfig *SlackNotificationConfig `json:"slack_notification_config,omitempty" bson:"slack_notification_config,omitempty"`
 }
 
 type WorkflowHookSettings struct {
-	Enable      bool                `json:"enable" bson:"enable"`
-	HookAddress string              `json:"hook_address" bson:"hook_address"`
-	HookSecret  string              `json:"hook_secret" bson:"hook_secret"`
-	HookEvents  []WorkflowHookEvent `json:"hook_events" bson:"hook_events"`
+	Enable      bool   `json:"enable" bson:"enable"`
+	HookAddress string `json:"hook_address" bson:"hook_address"`
+	HookSecret  string `json:"hook_secret" bson:"hook_secret"`
+	// SigningSecret is the HMAC-SHA256 key of the payload signature, payloads are not signed if empty.
+	SigningSecret string              `json:"signing_secret" bson:"signing_secret"`
+	HookEvents    []WorkflowHookEvent `json:"hook_events" bson:"hook_events"`
 }
 
 func (r *ReleasePlanHookSettings) ToHookSettings() *HookSettings {
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TerminalSession is the audit record of an interactive shell opened into a pod of an environment.
+type TerminalSession struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty"     json:"id,omitempty"`
+	ProjectName     string             `bson:"project_name"      json:"project_name"`
+	EnvName         string             `bson:"env_name"          json:"env_name"`
+	Production      bool               `bson:"production"        json:"production"`
+	Namespace       string             `bson:"namespace"         json:"namespace"`
+	ClusterID       string             `bson:"cluster_id"        json:"cluster_id"`
+	PodName         string             `bson:"pod_name"          json:"pod_name"`
+	ContainerName   string             `bson:"container_name"    json:"container_name"`
+	UserID          string             `bson:"user_id"           json:"user_id"`
+	Username        string             `bson:"username"          json:"username"`
+	Reason          string             `bson:"reason"            json:"reason"`
+	AccessRequestID string             `bson:"access_request_id" json:"access_request_id"`
+	// Recorded is set once the asciicast recording has been uploaded to StorageID/ObjectKey.
+	Recorded  bool   `bson:"recorded"   json:"recorded"`
+	StorageID string `bson:"storage_id" json:"-"`
+	ObjectKey string `bson:"object_key" json:"-"`
+	StartTime int64  `bson:"start_time" json:"start_time"`
+	EndTime   int64  `bson:"end_time"   json:"end_time"`
+}
+
+func (TerminalSession) TableName() string {
+	return "terminal_session"
+}
+
+type TerminalAccessRequestStatus string
+
+const (
+	TerminalAccessRequestStatusPending  TerminalAccessRequestStatus = "pending"
+	TerminalAccessRequestStatusApproved TerminalAccessRequestStatus = "approved"
+	TerminalAccessRequestStatusRejected TerminalAccessRequestStatus = "rejected"
+)
+
+// TerminalAccessRequest asks the approvers of an environment for permission to open shells into its pods.
+type TerminalAccessRequest struct {
+	ID           primitive.ObjectID          `bson:"_id,omitempty" json:"id,omitempty"`
+	ProjectName  string                      `bson:"project_name"  json:"project_name"`
+	EnvName      string                      `bson:"env_name"      json:"env_name"`
+	Production   bool                        `bson:"production"    json:"production"`
+	UserID       string                      `bson:"user_id"       json:"user_id"`
+	Username     string                      `bson:"username"      json:"username"`
+	Reason       string                      `bson:"reason"        json:"reason"`
+	Status       TerminalAccessRequestStatus `bson:"status"        json:"status"`
+	ReviewerID   string                      `bson:"reviewer_id"   json:"reviewer_id"`
+	ReviewerName string                      `bson:"reviewer_name" json:"reviewer_name"`
+	Comment      string                      `bson:"comment"       json:"comment"`
+	// ExpireTime is the unix time after which an approved request can no longer open shells.
+	ExpireTime int64 `bson:"expire_time" json:"expire_time"`
+	CreateTime int64 `bson:"create_time" json:"create_time"`
+	UpdateTime int64 `bson:"update_time" json:"update_time"`
+}
+
+func (TerminalAccessRequest) TableName() string {
+	return "terminal_access_request"
+}
@@ -21,6 +21,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -43,6 +44,12 @@ type Testing struct {
 	// Junit 测试报告
 	TestResultPath          string `bson:"test_result_path"         json:"test_result_path"`
 	JUnitTestResultPassRate int    `bson:"junit_test_result_pass_rate"         json:"junit_test_result_pass_rate"`
+	// 覆盖率报告
+	CoverageReportPaths []string        `bson:"coverage_report_paths,omitempty" json:"coverage_report_paths,omitempty"`
+	CoverageFormat      coverage.Format `bson:"coverage_format,omitempty"       json:"coverage_format,omitempty"`
+	// the job fails if the line coverage is below MinLineCoverage or drops more than MaxCoverageDrop compared to the base branch, 0 means no limit
+	MinLineCoverage float64 `bson:"min_line_coverage,omitempty"     json:"min_line_coverage,omitempty"`
+	MaxCoverageDrop float64 `bson:"max_coverage_drop,omitempty"     json:"max_coverage_drop,omitempty"`
 	// html 测试报告
 	TestReportPath string `bson:"test_report_path"         json:"test_report_path"`
 	Threshold      int    `bson:"threshold"                json:"threshold"`
@@ -69,6 +76,9 @@ type Testing struct {
 	CacheEnable  bool               `bson:"cache_enable"              json:"cache_enable"`
 	CacheDirType types.CacheDirType `bson:"cache_dir_type"            json:"cache_dir_type"`
 	CacheUserDir string             `bson:"cache_user_dir"            json:"cache_user_dir"`
+	// CacheRules replaces the single cache dir with named, content-keyed caches when set
+	CacheRules      []*types.CacheRule `bson:"cache_rules,omitempty"        json:"cache_rules,omitempty"`
+	CacheQuotaInMiB int64              `bson:"cache_quota_in_mib,omitempty" json:"cache_quota_in_mib,omitempty"`
 	// New since V1.10.0. Only to tell the webpage should the advanced settings be displayed
 	AdvancedSettingsModified bool      `bson:"advanced_setting_modified" json:"advanced_setting_modified"`
 	Outputs                  []*Output `bson:"outputs"                   json:"outputs"`
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending means the delivery is waiting for its next attempt.
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSuccess WebhookDeliveryStatus = "success"
+	WebhookDeliveryStatusFailed  WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an outbound webhook event, it keeps everything needed to send it again after aslan restarts.
+type WebhookDelivery struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	EventUUID  string             `bson:"event_uuid"    json:"event_uuid"`
+	ObjectKind string             `bson:"object_kind"   json:"object_kind"`
+	Event      string             `bson:"event"         json:"event"`
+	Address    string             `bson:"address"       json:"address"`
+	// Token and SigningSecret are copied from the webhook config at the time of the event.
+	Token         string                    `bson:"token"             json:"-"`
+	SigningSecret string                    `bson:"signing_secret"    json:"-"`
+	Payload       string                    `bson:"payload"           json:"payload,omitempty"`
+	Status        WebhookDeliveryStatus     `bson:"status"            json:"status"`
+	Attempts      []*WebhookDeliveryAttempt `bson:"attempts"          json:"attempts"`
+	MaxAttempts   int                       `bson:"max_attempts"      json:"max_attempts"`
+	// NextAttemptTime is the unix time of the next automatic retry of a pending delivery.
+	NextAttemptTime int64 `bson:"next_attempt_time" json:"next_attempt_time"`
+	// LockedUntil keeps other aslan replicas from sending the delivery while an attempt is in flight.
+	LockedUntil int64 `bson:"locked_until"      json:"-"`
+	CreateTime  int64 `bson:"create_time"       json:"create_time"`
+	UpdateTime  int64 `bson:"update_time"       json:"update_time"`
+	// CreatedAt is the creation time stored as a date, the TTL index removes the delivery once the retention has passed.
+	CreatedAt time.Time `bson:"created_at" json:"-"`
+}
+
+type WebhookDeliveryAttempt struct {
+	WebhookUUID     string            `bson:"webhook_uuid"     json:"webhook_uuid"`
+	Redelivery      bool              `bson:"redelivery"       json:"redelivery"`
+	RequestHeaders  map[string]string `bson:"request_headers"  json:"request_headers"`
+	ResponseStatus  int               `bson:"response_status"  json:"response_status"`
+	ResponseHeaders map[string]string `bson:"response_headers" json:"response_headers"`
+	ResponseBody    string            `bson:"response_body"    json:"response_body"`
+	Error           string            `bson:"error"            json:"error"`
+	StartTime       int64             `bson:"start_time"       json:"start_time"`
+	// Duration is the round trip time of the request in milliseconds.
+	Duration int64 `bson:"duration" json:"duration"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_delivery"
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/blueking"
 	"github.com/koderover/zadig/v2/pkg/tool/pingcode"
+	"github.com/koderover/zadig/v2/pkg/tool/terraform"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -124,6 +125,10 @@ type JobTask struct {
 	ServiceModules   []*WorkflowServiceModule `bson:"service_modules"     json:"service_modules"`
 	Infrastructure   string                   `bson:"infrastructure"      json:"infrastructure"`
 	VMLabels         []string                 `bson:"vm_labels"           json:"vm_labels"`
+	// DependsOn contains the origin names of the workflow jobs this job waits for
+	DependsOn []string `bson:"depends_on,omitempty" json:"depends_on,omitempty"`
+	// Matrix is set on the sub-tasks expanded from a job matrix
+	Matrix *JobTaskMatrix `bson:"matrix,omitempty" json:"matrix,omitempty"`
 
 	ErrorPolicy   *JobErrorPolicy   `bson:"error_policy"         yaml:"error_policy"         json:"error_policy"`
 	ExecutePolicy *JobExecutePolicy `bson:"execute_policy"       yaml:"execute_policy"       json:"execute_policy"`
@@ -136,6 +141,14 @@ type JobTask struct {
 	Reverted   bool `bson:"reverted"    json:"reverted"    yaml:"reverted"`
 }
 
+type JobTaskMatrix struct {
+	// Group is the key of the task the sub-task was expanded from, shared by all sub-tasks of a matrix
+	Group       string            `bson:"group"        json:"group"`
+	Values      map[string]string `bson:"values"       json:"values"`
+	MaxParallel int               `bson:"max_parallel" json:"max_parallel"`
+	FailFast    bool              `bson:"fail_fast"    json:"fail_fast"`
+}
+
 type TaskJobInfo struct {
 	RandStr       string `bson:"rand_str"        json:"rand_str"`
 	TestType      string `bson:"test_type"       json:"test_type"`
@@ -251,6 +264,16 @@ type JobTaskDeploySpec struct {
 	Image         string `bson:"image"                            json:"image"                               yaml:"-"`
 	// for revert
 	OriginRevision int64 `bson:"origin_revision"                   json:"origin_revision"                      yaml:"origin_revision"`
+	// images without a valid signature are refused if it is enabled
+	ImageSignatureVerification *ImageSignatureVerification `bson:"image_signature_verification" json:"image_signature_verification" yaml:"image_signature_verification"`
+}
+
+func (j *JobTaskDeploySpec) GetDeployImages() []string {
+	images := make([]string, 0)
+	for _, serviceAndImage := range j.ServiceAndImages {
+		images = append(images, serviceAndImage.Image)
+	}
+	return images
 }
 
 type JobTaskRestartSpec struct {
@@ -320,6 +343,8 @@ type JobTaskHelmDeploySpec struct {
 	ReplaceResources             []Resource                `bson:"replace_resources"                json:"replace_resources"                   yaml:"replace_resources"`
 	OriginRevision               int64                     `bson:"origin_revision"                  json:"origin_revision"                     yaml:"origin_revision"`
 	ValueMergeStrategy           config.ValueMergeStrategy `bson:"value_merge_strategy"             json:"value_merge_strategy"                yaml:"value_merge_strategy"`
+	// images without a valid signature are refused if it is enabled
+	ImageSignatureVerification *ImageSignatureVerification `bson:"image_signature_verification" json:"image_signature_verification" yaml:"image_signature_verification"`
 }
 
 func (j *JobTaskHelmDeploySpec) GetDeployImages() []string {
@@ -455,6 +480,48 @@ type JobTaskGrayReleaseSpec struct {
 	Events        *Events `bson:"events"                json:"events"               yaml:"events"`
 }
 
+type JobTaskProgressiveDeliverySpec struct {
+	ClusterID          string `bson:"cluster_id"             json:"cluster_id"             yaml:"cluster_id"`
+	ClusterName        string `bson:"cluster_name"           json:"cluster_name"           yaml:"cluster_name"`
+	Namespace          string `bson:"namespace"              json:"namespace"              yaml:"namespace"`
+	WorkloadType       string `bson:"workload_type"          json:"workload_type"          yaml:"workload_type"`
+	WorkloadName       string `bson:"workload_name"          json:"workload_name"          yaml:"workload_name"`
+	ContainerName      string `bson:"container_name"         json:"container_name"         yaml:"container_name"`
+	Image              string `bson:"image"                  json:"image"                  yaml:"image"`
+	CanaryWorkloadName string `bson:"canary_workload_name"   json:"canary_workload_name"   yaml:"canary_workload_name"`
+	TotalReplica       int    `bson:"total_replica"          json:"total_replica"          yaml:"total_replica"`
+	// unit is minute.
+	DeployTimeout int64                    `bson:"deploy_timeout"         json:"deploy_timeout"         yaml:"deploy_timeout"`
+	Steps         []*ProgressiveStep       `bson:"steps"                  json:"steps"                  yaml:"steps"`
+	StepResults   []*ProgressiveStepResult `bson:"step_results"           json:"step_results"           yaml:"step_results"`
+	Promoted      bool                     `bson:"promoted"               json:"promoted"               yaml:"promoted"`
+	RolledBack    bool                     `bson:"rolled_back"            json:"rolled_back"            yaml:"rolled_back"`
+	Events        *Events                  `bson:"events"                 json:"events"                 yaml:"events"`
+}
+
+type ProgressiveStepResult struct {
+	Weight        int                          `bson:"weight"                 json:"weight"                 yaml:"weight"`
+	CanaryReplica int                          `bson:"canary_replica"         json:"canary_replica"         yaml:"canary_replica"`
+	StableReplica int                          `bson:"stable_replica"         json:"stable_replica"         yaml:"stable_replica"`
+	Verdict       config.AnalysisVerdict       `bson:"verdict"                json:"verdict"                yaml:"verdict"`
+	Analyses      []*ProgressiveAnalysisResult `bson:"analyses"               json:"analyses"               yaml:"analyses"`
+	StartTime     int64                        `bson:"start_time"             json:"start_time"             yaml:"start_time"`
+	EndTime       int64                        `bson:"end_time"               json:"end_time"               yaml:"end_time"`
+}
+
+type ProgressiveAnalysisResult struct {
+	Name         string                 `bson:"name"                   json:"name"                   yaml:"name"`
+	Verdict      config.AnalysisVerdict `bson:"verdict"                json:"verdict"                yaml:"verdict"`
+	Measurements []*AnalysisMeasurement `bson:"measurements"           json:"measurements"           yaml:"measurements"`
+}
+
+type AnalysisMeasurement struct {
+	Value   float64                `bson:"value"                  json:"value"                  yaml:"value"`
+	Verdict config.AnalysisVerdict `bson:"verdict"                json:"verdict"                yaml:"verdict"`
+	Error   string                 `bson:"error,omitempty"        json:"error,omitempty"        yaml:"error,omitempty"`
+	Time    int64                  `bson:"time"                   json:"time"                   yaml:"time"`
+}
+
 type JobIstioReleaseSpec struct {
 	FirstJob          bool            `bson:"first_job"          json:"first_job"          yaml:"first_job"`
 	Timeout           int64           `bson:"timeout"            json:"timeout"            yaml:"timeout"`
@@ -625,6 +692,8 @@ type JobTaskSQLSpec struct {
 	ID                      string                `bson:"id" json:"id" yaml:"id"`
 	Type                    config.DBInstanceType `bson:"type" json:"type" yaml:"type"`
 	SQL                     string                `bson:"sql" json:"sql" yaml:"sql"`
+	Transactional           bool                  `bson:"transactional" json:"transactional" yaml:"transactional"`
+	StatementTimeout        int64                 `bson:"statement_timeout" json:"statement_timeout" yaml:"statement_timeout"`
 	Results                 []*SQLExecResult      `bson:"results" json:"results" yaml:"results"`
 }
 
@@ -633,6 +702,57 @@ type SQLExecResult struct {
 	ElapsedTime  int64                 `bson:"elapsed_time" json:"elapsed_time" yaml:"elapsed_time"`
 	RowsAffected int64                 `bson:"rows_affected" json:"rows_affected" yaml:"rows_affected"`
 	Status       setting.SQLExecStatus `bson:"status" json:"status" yaml:"status"`
+	Error        string                `bson:"error" json:"error" yaml:"error"`
+	ErrorCode    string                `bson:"error_code" json:"error_code" yaml:"error_code"`
+	Plan         string                `bson:"plan" json:"plan" yaml:"plan"`
+}
+
+type JobTaskSchemaMigrationSpec struct {
+	ID               string                       `bson:"id"                json:"id"                yaml:"id"`
+	Type             config.DBInstanceType        `bson:"type"              json:"type"              yaml:"type"`
+	Repo             *types.Repository            `bson:"repo"              json:"repo"              yaml:"repo"`
+	Path             string                       `bson:"path"              json:"path"              yaml:"path"`
+	HistoryTable     string                       `bson:"history_table"     json:"history_table"     yaml:"history_table"`
+	Action           config.SchemaMigrationAction `bson:"action"            json:"action"            yaml:"action"`
+	TargetVersion    string                       `bson:"target_version"    json:"target_version"    yaml:"target_version"`
+	IgnoreDrift      bool                         `bson:"ignore_drift"      json:"ignore_drift"      yaml:"ignore_drift"`
+	StatementTimeout int64                        `bson:"statement_timeout" json:"statement_timeout" yaml:"statement_timeout"`
+	// CurrentVersion, AppliedVersions and PendingVersions reflect the state after the job finishes
+	CurrentVersion  string                   `bson:"current_version"  json:"current_version"  yaml:"current_version"`
+	AppliedVersions []string                 `bson:"applied_versions" json:"applied_versions" yaml:"applied_versions"`
+	PendingVersions []string                 `bson:"pending_versions" json:"pending_versions" yaml:"pending_versions"`
+	Migrations      []*SchemaMigrationResult `bson:"migrations"       json:"migrations"       yaml:"migrations"`
+}
+
+type JobTaskTerraformSpec struct {
+	Binary    string `bson:"binary"        json:"binary"        yaml:"binary"`
+	WorkDir   string `bson:"work_dir"      json:"work_dir"      yaml:"work_dir"`
+	Workspace string `bson:"workspace"     json:"workspace"     yaml:"workspace"`
+	Destroy   bool   `bson:"destroy"       json:"destroy"       yaml:"destroy"`
+	// ArtifactPath is the object path of the rendered plan in the default object storage
+	ArtifactPath string `bson:"artifact_path" json:"artifact_path" yaml:"artifact_path"`
+	// Plan and Apply run in the job runtime one after another, apply downloads the plan saved by plan
+	Plan     *JobTaskFreestyleSpec `bson:"plan"               json:"plan"               yaml:"plan"`
+	Apply    *JobTaskFreestyleSpec `bson:"apply"              json:"apply"              yaml:"apply"`
+	Approval *JobTaskApprovalSpec  `bson:"approval,omitempty" json:"approval,omitempty" yaml:"approval,omitempty"`
+	// PlanJobName and ApplyJobName are the names the logs of the phases are saved with
+	PlanJobName  string                 `bson:"plan_job_name"  json:"plan_job_name"  yaml:"plan_job_name"`
+	ApplyJobName string                 `bson:"apply_job_name" json:"apply_job_name" yaml:"apply_job_name"`
+	PlanSummary  *terraform.PlanSummary `bson:"plan_summary"   json:"plan_summary"   yaml:"plan_summary"`
+	Applied      bool                   `bson:"applied"        json:"applied"        yaml:"applied"`
+}
+
+type SchemaMigrationResult struct {
+	Version         string                        `bson:"version"          json:"version"          yaml:"version"`
+	Description     string                        `bson:"description"      json:"description"      yaml:"description"`
+	File            string                        `bson:"file"             json:"file"             yaml:"file"`
+	Checksum        string                        `bson:"checksum"         json:"checksum"         yaml:"checksum"`
+	AppliedChecksum string                        `bson:"applied_checksum" json:"applied_checksum" yaml:"applied_checksum"`
+	InstalledBy     string                        `bson:"installed_by"     json:"installed_by"     yaml:"installed_by"`
+	InstalledAt     int64                         `bson:"installed_at"     json:"installed_at"     yaml:"installed_at"`
+	ElapsedTime     int64                         `bson:"elapsed_time"     json:"elapsed_time"     yaml:"elapsed_time"`
+	Status          setting.SchemaMigrationStatus `bson:"status"           json:"status"           yaml:"status"`
+	Error           string                        `bson:"error"            json:"error"            yaml:"error"`
 }
 
 type JobTaskDMSSpec struct {
@@ -699,6 +819,7 @@ type JobTaskApprovalSpec struct {
 	LarkApproval     *LarkApproval       `bson:"lark_approval"               yaml:"lark_approval,omitempty"       json:"lark_approval,omitempty"`
 	DingTalkApproval *DingTalkApproval   `bson:"dingtalk_approval"           yaml:"dingtalk_approval,omitempty"   json:"dingtalk_approval,omitempty"`
 	WorkWXApproval   *WorkWXApproval     `bson:"workwx_approval"             yaml:"workwx_approval,omitempty"     json:"workwx_approval,omitempty"`
+	SlackApproval    *SlackApproval      `bson:"slack_approval"              yaml:"slack_approval,omitempty"      json:"slack_approval,omitempty"`
 	ApprovalMessage  string              `bson:"approval_message"            yaml:"approval_message,omitempty"    json:"approval_message,omitempty"`
 	ApprovalTitle    string              `bson:"approval_title"              yaml:"approval_title,omitempty"      json:"approval_title,omitempty"`
 }
@@ -1037,6 +1158,7 @@ type JobTaskNotificationSpec struct {
 	WechatNotificationConfig     *WechatNotificationConfig     `bson:"wechat_notification_config,omitempty"      yaml:"wechat_notification_config,omitempty"      json:"wechat_notification_config,omitempty"`
 	DingDingNotificationConfig   *DingDingNotificationConfig   `bson:"dingding_notification_config,omitempty"    yaml:"dingding_notification_config,omitempty"    json:"dingding_notification_config,omitempty"`
 	MSTeamsNotificationConfig    *MSTeamsNotificationConfig    `bson:"msteams_notification_config,omitempty"     yaml:"msteams_notification_config,omitempty"     json:"msteams_notification_config,omitempty"`
+	SlackNotificationConfig      *SlackNotificationConfig      `bson:"slack_notification_config,omitempty"       yaml:"slack_notification_config,omitempty"       json:"slack_notification_config,omitempty"`
 	MailNotificationConfig       *MailNotificationConfig       `bson:"mail_notification_config,omitempty"        yaml:"mail_notification_config,omitempty"        json:"mail_notification_config,omitempty"`
 	WebhookNotificationConfig    *WebhookNotificationConfig    `bson:"webhook_notification_config,omitempty"     yaml:"webhook_notification_config,omitempty"     json:"webhook_notification_config,omitempty"`
 
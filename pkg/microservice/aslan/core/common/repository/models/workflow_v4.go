@@ -73,6 +73,9 @@ type WorkflowV4 struct {
 	EnableApprovalTicket bool                     `bson:"enable_approval_ticket" yaml:"enable_approval_ticket" json:"enable_approval_ticket"`
 	ApprovalTicketID     string                   `bson:"approval_ticket_id"     yaml:"approval_ticket_id"     json:"approval_ticket_id"`
 	TemplateBinding      *WorkflowTemplateBinding `bson:"template_binding,omitempty" yaml:"template_binding,omitempty" json:"template_binding,omitempty"`
+	// GitSource is set when the workflow definition is managed in a git repository,
+	// such workflows can only be changed by pushing to the repository.
+	GitSource *WorkflowV4GitRef `bson:"git_source,omitempty" yaml:"-" json:"git_source,omitempty"`
 
 	// all hookCtls are deprecated
 	HookCtls        []*WorkflowV4Hook `bson:"hook_ctl"            yaml:"-"                   json:"hook_ctl"`
@@ -92,6 +95,14 @@ type WorkflowTemplateBinding struct {
 	InvalidPatches []*InvalidJSONPatch   `bson:"invalid_patches,omitempty"       yaml:"invalid_patches,omitempty"       json:"invalid_patches,omitempty"`
 }
 
+// WorkflowV4GitRef records where a git managed workflow comes from. Hash is the workflow hash
+// right after the last sync, a different current hash means the workflow drifted from git.
+type WorkflowV4GitRef struct {
+	Path      string `bson:"path"       yaml:"path"       json:"path"`
+	CommitSHA string `bson:"commit_sha" yaml:"commit_sha" json:"commit_sha"`
+	Hash      string `bson:"hash"       yaml:"hash"       json:"hash"`
+}
+
 type JSONPatchOperation struct {
 	Operation string      `bson:"op"              yaml:"op"              json:"op"`
 	Path      string      `bson:"path"            yaml:"path"            json:"path"`
@@ -110,7 +121,7 @@ func (w *WorkflowV4) UpdateHash() {
 
 func (w *WorkflowV4) CalculateHash() [md5.Size]byte {
 	fieldList := make(map[string]interface{})
-	ignoringFieldList := []string{"CreatedBy", "CreateTime", "UpdatedBy", "UpdateTime", "Description", "Hash", "DisplayName", "HookCtls", "JiraHookCtls", "MeegoHookCtls", "GeneralHookCtls", "ConcurrencyLimit", "ShareStorages", "NotifyCtls"}
+	ignoringFieldList := []string{"CreatedBy", "CreateTime", "UpdatedBy", "UpdateTime", "Description", "Hash", "DisplayName", "HookCtls", "JiraHookCtls", "MeegoHookCtls", "GeneralHookCtls", "ConcurrencyLimit", "ShareStorages", "NotifyCtls", "GitSource"}
 	ignoringFields := sets.NewString(ignoringFieldList...)
 
 	val := reflect.ValueOf(*w)
@@ -210,6 +221,7 @@ type Approval struct {
 	LarkApproval     *LarkApproval       `bson:"lark_approval"               yaml:"lark_approval,omitempty"       json:"lark_approval,omitempty"`
 	DingTalkApproval *DingTalkApproval   `bson:"dingtalk_approval"           yaml:"dingtalk_approval,omitempty"   json:"dingtalk_approval,omitempty"`
 	WorkWXApproval   *WorkWXApproval     `bson:"workwx_approval"             yaml:"workwx_approval,omitempty"     json:"workwx_approval,omitempty"`
+	SlackApproval    *SlackApproval      `bson:"slack_approval"              yaml:"slack_approval,omitempty"      json:"slack_approval,omitempty"`
 }
 
 type NativeApproval struct {
@@ -347,6 +359,21 @@ type WorkWXApproval struct {
 	InstanceID string `bson:"instance_id" yaml:"instance_id" json:"instance_id"`
 }
 
+// SlackApproval posts Approve/Reject buttons to a slack channel, the decisions are recorded by the
+// native approval flow so the approvers and the needed approvers follow the native rules.
+type SlackApproval struct {
+	Timeout int `bson:"timeout"                     yaml:"timeout"                    json:"timeout"`
+	// ID: slack im app mongodb id
+	ID              string  `bson:"approval_id"                 yaml:"approval_id"                json:"approval_id"`
+	Channel         string  `bson:"channel"                     yaml:"channel"                    json:"channel"`
+	ApproveUsers    []*User `bson:"approve_users"               yaml:"approve_users"              json:"approve_users"`
+	NeededApprovers int     `bson:"needed_approvers"            yaml:"needed_approvers"           json:"needed_approvers"`
+	// ChannelID and MessageTS identify the posted approval message, they are used to replace the
+	// buttons with the result once the approval finishes.
+	ChannelID string `bson:"channel_id"                  yaml:"channel_id"                 json:"channel_id"`
+	MessageTS string `bson:"message_ts"                  yaml:"message_ts"                 json:"message_ts"`
+}
+
 type User struct {
 	Type            string                `bson:"type"                        yaml:"type"                       json:"type"`
 	UserID          string                `bson:"user_id,omitempty"           yaml:"user_id,omitempty"          json:"user_id,omitempty"`
@@ -370,6 +397,9 @@ type Job struct {
 	ExecutePolicy  *JobExecutePolicy        `bson:"execute_policy"       yaml:"execute_policy"       json:"execute_policy"`
 	ServiceModules []*WorkflowServiceModule `bson:"service_modules"                                  json:"service_modules"`
 	NotifyCtls     []*NotifyCtl             `bson:"notify_ctls,omitempty" yaml:"notify_ctls,omitempty" json:"notify_ctls,omitempty"`
+	// DependsOn lists the names of the jobs, possibly in other stages, that must finish before this job starts.
+	// when any job declares it the workflow is scheduled by dependency graph instead of stage by stage.
+	DependsOn []string `bson:"depends_on,omitempty" yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
 }
 
 type JobErrorPolicy struct {
@@ -451,6 +481,7 @@ type FreestyleJobSpec struct {
 
 	Runtime         *RuntimeInfo                  `bson:"runtime"              yaml:"runtime"             json:"runtime"`
 	AdvancedSetting *FreestyleJobAdvancedSettings `bson:"advanced_setting"     yaml:"advanced_setting"    json:"advanced_setting"`
+	Matrix          *JobMatrix                    `bson:"matrix,omitempty"     yaml:"matrix,omitempty"    json:"matrix,omitempty"`
 
 	// Deprecated
 	Steps []*Step `bson:"steps"                yaml:"steps"               json:"steps"`
@@ -501,6 +532,7 @@ type ZadigBuildJobSpec struct {
 	DefaultServiceAndBuilds []*ServiceAndBuild      `bson:"default_service_and_builds" yaml:"default_service_and_builds"  json:"default_service_and_builds"`
 	ServiceAndBuilds        []*ServiceAndBuild      `bson:"service_and_builds"         yaml:"service_and_builds"          json:"service_and_builds"`
 	ServiceAndBuildsOptions []*ServiceAndBuild      `bson:"service_and_builds_options" yaml:"service_and_builds_options"  json:"service_and_builds_options"`
+	Matrix                  *JobMatrix              `bson:"matrix,omitempty"           yaml:"matrix,omitempty"            json:"matrix,omitempty"`
 	ServiceWithModule       `bson:",inline"                    yaml:",inline"                     json:",inline"`
 }
 
@@ -556,6 +588,16 @@ type ZadigDeployJobSpec struct {
 
 	// TODO: Deprecated in 2.3.0, this field is now used for saving the default service module info for deployment.
 	DefaultServices []*ServiceAndImage `bson:"service_and_images" yaml:"service_and_images" json:"service_and_images"`
+
+	ImageSignatureVerification *ImageSignatureVerification `bson:"image_signature_verification" yaml:"image_signature_verification" json:"image_signature_verification"`
+}
+
+// ImageSignatureVerification refuses to deploy the images which are not signed by the key, the signatures created by
+// the image signing of the build job are verified.
+type ImageSignatureVerification struct {
+	Enabled bool `bson:"enabled"    yaml:"enabled"    json:"enabled"`
+	// PublicKey is the PEM encoded public key of the cosign key pair
+	PublicKey string `bson:"public_key" yaml:"public_key" json:"public_key"`
 }
 
 type ServiceAndVMDeploy struct {
@@ -763,6 +805,24 @@ type ZadigTestingJobSpec struct {
 	// in config: this is the test infos for all the services
 	ServiceAndTests    []*ServiceAndTest `bson:"service_and_tests"    yaml:"service_and_tests"    json:"service_and_tests"`
 	ServiceTestOptions []*ServiceAndTest `bson:"service_test_options" yaml:"service_test_options" json:"service_test_options"`
+	Matrix             *JobMatrix        `bson:"matrix,omitempty"     yaml:"matrix,omitempty"     json:"matrix,omitempty"`
+}
+
+// JobMatrix fans every task of a job out to one sub-task per combination of the axis values,
+// the values are passed to the sub-tasks as MATRIX_<AXIS> variables.
+type JobMatrix struct {
+	Axes    []*JobMatrixAxis    `bson:"axes"              yaml:"axes"              json:"axes"`
+	Include []map[string]string `bson:"include,omitempty" yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude []map[string]string `bson:"exclude,omitempty" yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	// MaxParallel limits the sub-tasks of a task running at the same time, 0 means no limit
+	MaxParallel int `bson:"max_parallel" yaml:"max_parallel" json:"max_parallel"`
+	// FailFast skips the sub-tasks not started yet once one of them failed
+	FailFast bool `bson:"fail_fast" yaml:"fail_fast" json:"fail_fast"`
+}
+
+type JobMatrixAxis struct {
+	Name   string   `bson:"name"   yaml:"name"   json:"name"`
+	Values []string `bson:"values" yaml:"values" json:"values"`
 }
 
 type ServiceAndTest struct {
@@ -963,6 +1023,43 @@ type GrayReleaseTarget struct {
 	Image         string `bson:"image,omitempty"           json:"image,omitempty"          yaml:"image,omitempty"`
 }
 
+type ProgressiveDeliveryJobSpec struct {
+	ClusterID        string `bson:"cluster_id"             json:"cluster_id"            yaml:"cluster_id"`
+	Namespace        string `bson:"namespace"              json:"namespace"             yaml:"namespace"`
+	DockerRegistryID string `bson:"docker_registry_id"     json:"docker_registry_id"    yaml:"docker_registry_id"`
+	// unit is minute.
+	DeployTimeout int64                `bson:"deploy_timeout"         json:"deploy_timeout"        yaml:"deploy_timeout"`
+	Steps         []*ProgressiveStep   `bson:"steps"                  json:"steps"                 yaml:"steps"`
+	Targets       []*GrayReleaseTarget `bson:"targets"                json:"targets"               yaml:"targets"`
+	TargetOptions []*GrayReleaseTarget `bson:"target_options"         json:"target_options"        yaml:"target_options"`
+}
+
+type ProgressiveStep struct {
+	// Weight is the percentage of replicas running the new image.
+	Weight int `bson:"weight"                 json:"weight"                yaml:"weight"`
+	// Pause is the time to wait before the analyses start, unit is second.
+	Pause    int64                  `bson:"pause"                  json:"pause"                 yaml:"pause"`
+	Analyses []*ProgressiveAnalysis `bson:"analyses"               json:"analyses"              yaml:"analyses"`
+}
+
+type ProgressiveAnalysis struct {
+	Name     string                   `bson:"name"                   json:"name"                  yaml:"name"`
+	Provider config.ObservabilityType `bson:"provider"               json:"provider"              yaml:"provider"`
+	// ID is the id of the observability integration
+	ID string `bson:"id"                     json:"id"                    yaml:"id"`
+	// DataSourceUID is the uid of the grafana prometheus data source
+	DataSourceUID string `bson:"datasource_uid"         json:"datasource_uid"        yaml:"datasource_uid"`
+	// Query is the PromQL used by grafana and prometheus providers
+	Query string `bson:"query"                  json:"query"                 yaml:"query"`
+	// Monitors are used by guanceyun provider, the measured value is the number of monitors alerting at or above their level
+	Monitors     []*GuanceyunMonitor      `bson:"monitors"               json:"monitors"              yaml:"monitors"`
+	Condition    config.AnalysisCondition `bson:"condition"              json:"condition"             yaml:"condition"`
+	Threshold    float64                  `bson:"threshold"              json:"threshold"             yaml:"threshold"`
+	Interval     int64                    `bson:"interval"               json:"interval"              yaml:"interval"`
+	Count        int                      `bson:"count"                  json:"count"                 yaml:"count"`
+	FailureLimit int                      `bson:"failure_limit"          json:"failure_limit"         yaml:"failure_limit"`
+}
+
 type K8sPatchJobSpec struct {
 	ClusterID        string       `bson:"cluster_id"             json:"cluster_id"            yaml:"cluster_id"`
 	ClusterSource    string       `bson:"cluster_source"         json:"cluster_source"        yaml:"cluster_source"`
@@ -1102,10 +1199,57 @@ type UpdateEnvIstioConfigJobSpec struct {
 
 type SQLJobSpec struct {
 	// ID db instance id
-	ID     string                `bson:"id" json:"id" yaml:"id"`
-	Type   config.DBInstanceType `bson:"type" json:"type" yaml:"type"`
-	SQL    string                `bson:"sql" json:"sql" yaml:"sql"`
+	ID               string                `bson:"id" json:"id" yaml:"id"`
+	Type             config.DBInstanceType `bson:"type" json:"type" yaml:"type"`
+	SQL              string                `bson:"sql" json:"sql" yaml:"sql"`
+	Source           string                `bson:"source" json:"source" yaml:"source"`
+	Transactional    bool                  `bson:"transactional" json:"transactional" yaml:"transactional"`
+	StatementTimeout int64                 `bson:"statement_timeout" json:"statement_timeout" yaml:"statement_timeout"`
+}
+
+type SchemaMigrationJobSpec struct {
+	// ID db instance id
+	ID     string                `bson:"id"     json:"id"     yaml:"id"`
+	Type   config.DBInstanceType `bson:"type"   json:"type"   yaml:"type"`
 	Source string                `bson:"source" json:"source" yaml:"source"`
+	// Repo and Path locate the directory of the versioned migration files
+	Repo             *types.Repository            `bson:"repo"              json:"repo"              yaml:"repo"`
+	Path             string                       `bson:"path"              json:"path"              yaml:"path"`
+	HistoryTable     string                       `bson:"history_table"     json:"history_table"     yaml:"history_table"`
+	Action           config.SchemaMigrationAction `bson:"action"            json:"action"            yaml:"action"`
+	TargetVersion    string                       `bson:"target_version"    json:"target_version"    yaml:"target_version"`
+	IgnoreDrift      bool                         `bson:"ignore_drift"      json:"ignore_drift"      yaml:"ignore_drift"`
+	StatementTimeout int64                        `bson:"statement_timeout" json:"statement_timeout" yaml:"statement_timeout"`
+}
+
+type TerraformJobSpec struct {
+	// Repo and WorkDir locate the root module of the terraform configuration
+	Repo    *types.Repository `bson:"repo"             json:"repo"             yaml:"repo"`
+	WorkDir string            `bson:"work_dir"         json:"work_dir"         yaml:"work_dir"`
+	// Binary is terraform or tofu, it should be provided by the image or the installed packages
+	Binary    string `bson:"binary"           json:"binary"           yaml:"binary"`
+	Workspace string `bson:"workspace"        json:"workspace"        yaml:"workspace"`
+	Destroy   bool   `bson:"destroy"          json:"destroy"          yaml:"destroy"`
+	// Variables are passed to terraform as TF_VAR_ environment variables
+	Variables       RuntimeKeyValList    `bson:"variables"        json:"variables"        yaml:"variables"`
+	Backend         *TerraformBackend    `bson:"backend"          json:"backend"          yaml:"backend"`
+	Approval        *TerraformApproval   `bson:"approval"         json:"approval"         yaml:"approval"`
+	Runtime         *RuntimeInfo         `bson:"runtime"          json:"runtime"          yaml:"runtime"`
+	AdvancedSetting *JobAdvancedSettings `bson:"advanced_setting" json:"advanced_setting" yaml:"advanced_setting"`
+}
+
+type TerraformBackend struct {
+	Type config.TerraformBackendType `bson:"type"              json:"type"              yaml:"type"`
+	// ObjectStorageID is used by the s3 backend, the default object storage is used if it is empty
+	ObjectStorageID string `bson:"object_storage_id" json:"object_storage_id" yaml:"object_storage_id"`
+	// Key is the object key of the state, the default is {project}/{workflow}/{job}/terraform.tfstate
+	Key string `bson:"key"               json:"key"               yaml:"key"`
+}
+
+// TerraformApproval pauses the job between plan and apply, it is skipped if the plan has no changes.
+type TerraformApproval struct {
+	Enabled          bool `bson:"enabled" json:"enabled" yaml:"enabled"`
+	*ApprovalJobSpec `bson:",inline"  json:",inline"  yaml:",inline"`
 }
 
 type DMSJobSpec struct {
@@ -1246,6 +1390,7 @@ type ApprovalJobSpec struct {
 	LarkApproval          *LarkApproval           `bson:"lark_approval"               yaml:"lark_approval,omitempty"           json:"lark_approval,omitempty"`
 	DingTalkApproval      *DingTalkApproval       `bson:"dingtalk_approval"           yaml:"dingtalk_approval,omitempty"       json:"dingtalk_approval,omitempty"`
 	WorkWXApproval        *WorkWXApproval         `bson:"workwx_approval"             yaml:"workwx_approval,omitempty"         json:"workwx_approval,omitempty"`
+	SlackApproval         *SlackApproval          `bson:"slack_approval"              yaml:"slack_approval,omitempty"          json:"slack_approval,omitempty"`
 	ApprovalMessage       string                  `bson:"approval_message"            yaml:"approval_message,omitempty"        json:"approval_message,omitempty"`
 	ApprovalMessageSource config.DeploySourceType `bson:"approval_message_source"     yaml:"approval_message_source,omitempty" json:"approval_message_source,omitempty"`
 	ApprovalTitle         string                  `bson:"approval_title"              yaml:"approval_title,omitempty"          json:"approval_title,omitempty"`
@@ -1261,6 +1406,7 @@ type NotificationJobSpec struct {
 	WechatNotificationConfig     *WechatNotificationConfig     `bson:"wechat_notification_config,omitempty"      yaml:"wechat_notification_config,omitempty"      json:"wechat_notification_config,omitempty"`
 	DingDingNotificationConfig   *DingDingNotificationConfig   `bson:"dingding_notification_config,omitempty"    yaml:"dingding_notification_config,omitempty"    json:"dingding_notification_config,omitempty"`
 	MSTeamsNotificationConfig    *MSTeamsNotificationConfig    `bson:"msteams_notification_config,omitempty"     yaml:"msteams_notification_config,omitempty"     json:"msteams_notification_config,omitempty"`
+	SlackNotificationConfig      *SlackNotificationConfig      `bson:"slack_notification_config,omitempty"       yaml:"slack_notification_config,omitempty"       json:"slack_notification_config,omitempty"`
 	MailNotificationConfig       *MailNotificationConfig       `bson:"mail_notification_config,omitempty"        yaml:"mail_notification_config,omitempty"        json:"mail_notification_config,omitempty"`
 	WebhookNotificationConfig    *WebhookNotificationConfig    `bson:"webhook_notification_config,omitempty"     yaml:"webhook_notification_config,omitempty"     json:"webhook_notification_config,omitempty"`
 
@@ -1446,14 +1592,25 @@ type MSTeamsNotificationConfig struct {
 	DynamicRecipients DynamicRecipients `bson:"dynamic_recipients" json:"dynamic_recipients"  yaml:"dynamic_recipients"`
 }
 
+// SlackNotificationConfig posts Block Kit messages through a slack IM app, AtUsers are zadig users
+// mentioned by looking up their email in the slack workspace.
+type SlackNotificationConfig struct {
+	AppID             string            `bson:"app_id"              json:"app_id"              yaml:"app_id"`
+	Channel           string            `bson:"channel"             json:"channel"             yaml:"channel"`
+	AtUsers           []*User           `bson:"at_users"            json:"at_users"            yaml:"at_users"`
+	DynamicRecipients DynamicRecipients `bson:"dynamic_recipients"  json:"dynamic_recipients"  yaml:"dynamic_recipients"`
+	IsAtAll           bool              `bson:"is_at_all"           json:"is_at_all"           yaml:"is_at_all"`
+}
+
 type MailNotificationConfig struct {
 	TargetUsers       []*User           `bson:"target_users"        json:"target_users"        yaml:"target_users"`
 	DynamicRecipients DynamicRecipients `bson:"dynamic_recipients"  json:"dynamic_recipients"  yaml:"dynamic_recipients"`
 }
 
 type WebhookNotificationConfig struct {
-	Address string `bson:"address"       yaml:"address"        json:"address"`
-	Token   string `bson:"token"         yaml:"token"          json:"token"`
+	Address       string `bson:"address"        yaml:"address"         json:"address"`
+	Token         string `bson:"token"          yaml:"token"           json:"token"`
+	SigningSecret string `bson:"signing_secret" yaml:"signing_secret"  json:"signing_secret"`
 }
 
 type SAEDeployJobSpec struct {
@@ -1735,6 +1892,8 @@ type JobProperties struct {
 	CacheDirType        types.CacheDirType     `bson:"cache_dir_type"         json:"cache_dir_type"        yaml:"cache_dir_type"`
 	CacheUserDir        string                 `bson:"cache_user_dir"         json:"cache_user_dir"        yaml:"cache_user_dir"`
 	IgnoreCache         bool                   `bson:"ignore_cache,omitempty" json:"ignore_cache,omitempty" yaml:"ignore_cache,omitempty"`
+	CacheRules          []*types.CacheRule     `bson:"cache_rules,omitempty"  json:"cache_rules,omitempty" yaml:"cache_rules,omitempty"`
+	CacheQuotaInMiB     int64                  `bson:"cache_quota_in_mib,omitempty" json:"cache_quota_in_mib,omitempty" yaml:"cache_quota_in_mib,omitempty"`
 	ShareStorageDetails []*StorageDetail       `bson:"share_storage_details"  json:"share_storage_details" yaml:"-"`
 	EnablePrivileged    bool                   `bson:"enable_privileged,omitempty" json:"enable_privileged,omitempty" yaml:"enable_privileged,omitempty"`
 	UseHostDockerDaemon bool                   `bson:"use_host_docker_daemon,omitempty" json:"use_host_docker_daemon,omitempty" yaml:"use_host_docker_daemon"`
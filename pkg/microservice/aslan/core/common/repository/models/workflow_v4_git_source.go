@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WorkflowV4GitSource binds the workflows of a project to a directory in a git repository,
+// every yaml file in the directory holds one workflow definition.
+type WorkflowV4GitSource struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"   json:"id"`
+	ProjectName   string             `bson:"project_name"    json:"project_name"`
+	CodehostID    int                `bson:"codehost_id"     json:"codehost_id"`
+	Source        string             `bson:"source"          json:"source"`
+	RepoOwner     string             `bson:"repo_owner"      json:"repo_owner"`
+	Namespace     string             `bson:"namespace"       json:"namespace"`
+	RepoName      string             `bson:"repo_name"       json:"repo_name"`
+	BranchName    string             `bson:"branch_name"     json:"branch_name"`
+	Path          string             `bson:"path"            json:"path"`
+	Commit        *Commit            `bson:"commit"          json:"commit"`
+	LastSyncTime  int64              `bson:"last_sync_time"  json:"last_sync_time"`
+	LastSyncError string             `bson:"last_sync_error" json:"last_sync_error"`
+	CreatedBy     string             `bson:"created_by"      json:"created_by"`
+	CreateTime    int64              `bson:"create_time"     json:"create_time"`
+	UpdatedBy     string             `bson:"updated_by"      json:"updated_by"`
+	UpdateTime    int64              `bson:"update_time"     json:"update_time"`
+}
+
+func (WorkflowV4GitSource) TableName() string {
+	return "workflow_v4_git_source"
+}
+
+// GetNamespace returns the namespace of the repository, falling back to the repo owner.
+func (s *WorkflowV4GitSource) GetNamespace() string {
+	if s.Namespace != "" {
+		return s.Namespace
+	}
+	return s.RepoOwner
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ai
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/ai"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type EnvHealthReportColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewEnvHealthReportColl() *EnvHealthReportColl {
+	name := ai.EnvHealthReport{}.TableName()
+	return &EnvHealthReportColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *EnvHealthReportColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *EnvHealthReportColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "env_name", Value: 1},
+				bson.E{Key: "production", Value: 1},
+				bson.E{Key: "start_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+
+	return err
+}
+
+type EnvHealthReportListOption struct {
+	ProjectName string
+	EnvName     string
+	Production  bool
+	PageNum     int64
+	PageSize    int64
+}
+
+func (c *EnvHealthReportColl) ListByOptions(opts EnvHealthReportListOption) ([]*ai.EnvHealthReport, int64, error) {
+	query := bson.M{
+		"project_name": opts.ProjectName,
+		"env_name":     opts.EnvName,
+		"production":   opts.Production,
+	}
+
+	if opts.PageNum == 0 {
+		opts.PageNum = 1
+	}
+	if opts.PageSize == 0 {
+		opts.PageSize = 10
+	}
+
+	resp := make([]*ai.EnvHealthReport, 0)
+	opt := options.Find().
+		SetSkip((opts.PageNum - 1) * opts.PageSize).
+		SetLimit(opts.PageSize).
+		SetSort(bson.D{{Key: "start_time", Value: -1}})
+
+	cursor, err := c.Collection.Find(context.TODO(), query, opt)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.Collection.CountDocuments(context.TODO(), query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp, count, nil
+}
+
+// FindLatestSuccess returns the most recent successful report of the environment, it is the
+// baseline the next report is diffed against.
+func (c *EnvHealthReportColl) FindLatestSuccess(projectName, envName string, production bool) (*ai.EnvHealthReport, error) {
+	query := bson.M{
+		"project_name": projectName,
+		"env_name":     envName,
+		"production":   production,
+		"status":       setting.AIEnvAnalysisStatusSuccess,
+	}
+
+	resp := new(ai.EnvHealthReport)
+	opt := options.FindOne().SetSort(bson.D{{Key: "start_time", Value: -1}})
+	err := c.Collection.FindOne(context.TODO(), query, opt).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *EnvHealthReportColl) Create(args *ai.EnvHealthReport) error {
+	if args == nil {
+		return errors.New("nil env health report")
+	}
+
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
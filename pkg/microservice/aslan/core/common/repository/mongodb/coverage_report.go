@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type CoverageReportColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+// CoverageReportFindOption finds the latest report of a testing on a branch, pull request runs are excluded
+// since they do not represent the branch.
+type CoverageReportFindOption struct {
+	ZadigTestName string
+	ServiceName   string
+	ServiceModule string
+	RepoOwner     string
+	RepoName      string
+	Branch        string
+}
+
+type ListCoverageReportOption struct {
+	ProjectNames  []string
+	ZadigTestName string
+	Branch        string
+	StartTime     int64
+	EndTime       int64
+	// ExcludePR skips the reports of pull request runs
+	ExcludePR bool
+}
+
+func NewCoverageReportColl() *CoverageReportColl {
+	name := models.CoverageReport{}.TableName()
+	return &CoverageReportColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *CoverageReportColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *CoverageReportColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "zadig_test_name", Value: 1},
+				bson.E{Key: "branch", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false).SetName("test_branch_index"),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "create_time", Value: 1},
+			},
+			Options: options.Index().SetUnique(false).SetName("project_time_index"),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *CoverageReportColl) Create(args *models.CoverageReport) (string, error) {
+	if args == nil {
+		return "", errors.New("nil coverage report")
+	}
+
+	res, err := c.InsertOne(context.TODO(), args)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (c *CoverageReportColl) GetByID(idString string) (*models.CoverageReport, error) {
+	id, err := primitive.ObjectIDFromHex(idString)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(models.CoverageReport)
+	err = c.FindOne(context.TODO(), bson.M{"_id": id}).Decode(resp)
+	return resp, err
+}
+
+// FindLatest returns the latest report matching the option, mongo.ErrNoDocuments is returned if there is none
+func (c *CoverageReportColl) FindLatest(opt *CoverageReportFindOption) (*models.CoverageReport, error) {
+	query := bson.M{
+		"zadig_test_name": opt.ZadigTestName,
+		"service_name":    opt.ServiceName,
+		"service_module":  opt.ServiceModule,
+		"repo_owner":      opt.RepoOwner,
+		"repo_name":       opt.RepoName,
+		"branch":          opt.Branch,
+		"pr":              0,
+	}
+	opts := options.FindOne().
+		SetSort(bson.D{bson.E{Key: "create_time", Value: -1}}).
+		SetProjection(bson.M{"files": 0})
+
+	resp := new(models.CoverageReport)
+	if err := c.FindOne(context.TODO(), query, opts).Decode(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// List returns the reports without the per file coverage, the oldest first
+func (c *CoverageReportColl) List(opt *ListCoverageReportOption) ([]*models.CoverageReport, error) {
+	query := bson.M{}
+	if len(opt.ProjectNames) > 0 {
+		query["project_name"] = bson.M{"$in": opt.ProjectNames}
+	}
+	if opt.ZadigTestName != "" {
+		query["zadig_test_name"] = opt.ZadigTestName
+	}
+	if opt.Branch != "" {
+		query["branch"] = opt.Branch
+	}
+	if opt.ExcludePR {
+		query["pr"] = 0
+	}
+	if opt.StartTime > 0 || opt.EndTime > 0 {
+		timeQuery := bson.M{}
+		if opt.StartTime > 0 {
+			timeQuery["$gte"] = opt.StartTime
+		}
+		if opt.EndTime > 0 {
+			timeQuery["$lte"] = opt.EndTime
+		}
+		query["create_time"] = timeQuery
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "create_time", Value: 1}}).
+		SetProjection(bson.M{"files": 0})
+
+	resp := make([]*models.CoverageReport, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query, opts)
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(context.TODO(), &resp)
+	return resp, err
+}
@@ -117,14 +117,17 @@ func (c *DBInstanceColl) Update(id string, args *models.DBInstance) error {
 	args.UpdatedAt = time.Now().Unix()
 	query := bson.M{"_id": oid}
 	change := bson.M{"$set": bson.M{
-		"name":       args.Name,
-		"host":       args.Host,
-		"port":       args.Port,
-		"projects":   args.Projects,
-		"username":   args.Username,
-		"password":   args.Password,
-		"update_by":  args.UpdateBy,
-		"updated_at": time.Now().Unix(),
+		"name":          args.Name,
+		"host":          args.Host,
+		"port":          args.Port,
+		"projects":      args.Projects,
+		"username":      args.Username,
+		"password":      args.Password,
+		"database":      args.Database,
+		"ssl_mode":      args.SSLMode,
+		"ssl_root_cert": args.SSLRootCert,
+		"update_by":     args.UpdateBy,
+		"updated_at":    time.Now().Unix(),
 	}}
 
 	_, err = c.UpdateOne(context.TODO(), query, change, options.Update().SetUpsert(true))
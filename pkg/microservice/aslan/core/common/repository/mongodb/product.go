@@ -862,12 +862,13 @@ func (c *ProductColl) ListEnvByNamespace(clusterID, namespace string) ([]*models
 	return resp, nil
 }
 
-func (c *ProductColl) UpdateConfigs(envName, productName string, analysisConfig *models.AnalysisConfig, notificationConfigs []*models.NotificationConfig, updateBy string) error {
+func (c *ProductColl) UpdateConfigs(envName, productName string, analysisConfig *models.AnalysisConfig, notificationConfigs []*models.NotificationConfig, terminalPolicy *models.TerminalPolicy, updateBy string) error {
 	query := bson.M{"env_name": envName, "product_name": productName}
 
 	change := bson.M{"$set": bson.M{
 		"analysis_config":      analysisConfig,
 		"notification_configs": notificationConfigs,
+		"terminal_policy":      terminalPolicy,
 		"update_time":          time.Now().Unix(),
 		"update_by":            updateBy,
 	}}
@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type TerminalSessionColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewTerminalSessionColl() *TerminalSessionColl {
+	name := models.TerminalSession{}.TableName()
+	return &TerminalSessionColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *TerminalSessionColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *TerminalSessionColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "env_name", Value: 1},
+				bson.E{Key: "pod_name", Value: 1},
+				bson.E{Key: "start_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "username", Value: 1},
+				bson.E{Key: "start_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *TerminalSessionColl) Create(args *models.TerminalSession) error {
+	if args == nil {
+		return errors.New("nil terminal session args")
+	}
+
+	res, err := c.InsertOne(context.TODO(), args)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		args.ID = oid
+	}
+	return nil
+}
+
+// Finish marks the session as closed, recording where its asciicast recording was uploaded to, if any.
+func (c *TerminalSessionColl) Finish(args *models.TerminalSession) error {
+	if args == nil || args.ID.IsZero() {
+		return errors.New("nil terminal session args")
+	}
+
+	change := bson.M{"$set": bson.M{
+		"recorded":   args.Recorded,
+		"storage_id": args.StorageID,
+		"object_key": args.ObjectKey,
+		"end_time":   args.EndTime,
+	}}
+	_, err := c.UpdateByID(context.TODO(), args.ID, change)
+	return err
+}
+
+func (c *TerminalSessionColl) GetByID(idString string) (*models.TerminalSession, error) {
+	id, err := primitive.ObjectIDFromHex(idString)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(models.TerminalSession)
+	err = c.FindOne(context.TODO(), bson.M{"_id": id}).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type TerminalSessionListOption struct {
+	ProjectName string
+	EnvName     string
+	Production  *bool
+	PodName     string
+	Username    string
+	// StartTime and EndTime bound the session start time, zero means unbounded.
+	StartTime int64
+	EndTime   int64
+	Page      int64
+	PageSize  int64
+}
+
+func (c *TerminalSessionColl) List(opt *TerminalSessionListOption) ([]*models.TerminalSession, int64, error) {
+	if opt == nil {
+		return nil, 0, errors.New("nil ListOption")
+	}
+
+	query := bson.M{}
+	if opt.ProjectName != "" {
+		query["project_name"] = opt.ProjectName
+	}
+	if opt.EnvName != "" {
+		query["env_name"] = opt.EnvName
+	}
+	if opt.Production != nil {
+		query["production"] = *opt.Production
+	}
+	if opt.PodName != "" {
+		query["pod_name"] = opt.PodName
+	}
+	if opt.Username != "" {
+		query["username"] = opt.Username
+	}
+	timeQuery := bson.M{}
+	if opt.StartTime > 0 {
+		timeQuery["$gte"] = opt.StartTime
+	}
+	if opt.EndTime > 0 {
+		timeQuery["$lte"] = opt.EndTime
+	}
+	if len(timeQuery) > 0 {
+		query["start_time"] = timeQuery
+	}
+
+	opts := options.Find().SetSort(bson.D{{"start_time", -1}})
+	if opt.Page > 0 && opt.PageSize > 0 {
+		opts.SetSkip((opt.Page - 1) * opt.PageSize).SetLimit(opt.PageSize)
+	}
+
+	ctx := context.Background()
+	cursor, err := c.Collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp := make([]*models.TerminalSession, 0)
+	if err := cursor.All(ctx, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp, count, nil
+}
+
+type TerminalAccessRequestColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewTerminalAccessRequestColl() *TerminalAccessRequestColl {
+	name := models.TerminalAccessRequest{}.TableName()
+	return &TerminalAccessRequestColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *TerminalAccessRequestColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *TerminalAccessRequestColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "project_name", Value: 1},
+				bson.E{Key: "env_name", Value: 1},
+				bson.E{Key: "status", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "user_id", Value: 1},
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *TerminalAccessRequestColl) Create(args *models.TerminalAccessRequest) error {
+	if args == nil {
+		return errors.New("nil terminal access request args")
+	}
+
+	args.CreateTime = time.Now().Unix()
+	args.UpdateTime = args.CreateTime
+	res, err := c.InsertOne(context.TODO(), args)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		args.ID = oid
+	}
+	return nil
+}
+
+func (c *TerminalAccessRequestColl) GetByID(idString string) (*models.TerminalAccessRequest, error) {
+	id, err := primitive.ObjectIDFromHex(idString)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(models.TerminalAccessRequest)
+	err = c.FindOne(context.TODO(), bson.M{"_id": id}).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type TerminalAccessRequestListOption struct {
+	ProjectName string
+	EnvName     string
+	Production  *bool
+	UserID      string
+	Status      models.TerminalAccessRequestStatus
+}
+
+func (c *TerminalAccessRequestColl) List(opt *TerminalAccessRequestListOption) ([]*models.TerminalAccessRequest, error) {
+	if opt == nil {
+		return nil, errors.New("nil ListOption")
+	}
+
+	query := bson.M{}
+	if opt.ProjectName != "" {
+		query["project_name"] = opt.ProjectName
+	}
+	if opt.EnvName != "" {
+		query["env_name"] = opt.EnvName
+	}
+	if opt.Production != nil {
+		query["production"] = *opt.Production
+	}
+	if opt.UserID != "" {
+		query["user_id"] = opt.UserID
+	}
+	if opt.Status != "" {
+		query["status"] = opt.Status
+	}
+
+	ctx := context.Background()
+	cursor, err := c.Collection.Find(ctx, query, options.Find().SetSort(bson.D{{"create_time", -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*models.TerminalAccessRequest, 0)
+	if err := cursor.All(ctx, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Review moves a pending request to its final status, it returns mongo.ErrNoDocuments if the
+// request does not exist or has already been reviewed.
+func (c *TerminalAccessRequestColl) Review(args *models.TerminalAccessRequest) error {
+	if args == nil || args.ID.IsZero() {
+		return errors.New("nil terminal access request args")
+	}
+
+	query := bson.M{"_id": args.ID, "status": models.TerminalAccessRequestStatusPending}
+	change := bson.M{"$set": bson.M{
+		"status":        args.Status,
+		"reviewer_id":   args.ReviewerID,
+		"reviewer_name": args.ReviewerName,
+		"comment":       args.Comment,
+		"expire_time":   args.ExpireTime,
+		"update_time":   time.Now().Unix(),
+	}}
+	res, err := c.UpdateOne(context.TODO(), query, change)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
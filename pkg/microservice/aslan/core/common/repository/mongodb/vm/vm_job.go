@@ -182,8 +182,10 @@ func (c *VMJobColl) FindOldestByTags(labels []string) (*vm.VMJob, error) {
 }
 
 type VMJobOpts struct {
-	Names  []string
-	Status string
+	Names    []string
+	Status   string
+	Statuses []string
+	VMID     string
 }
 
 func (c *VMJobColl) ListByOpts(opts *VMJobOpts) ([]*vm.VMJob, error) {
@@ -194,6 +196,12 @@ func (c *VMJobColl) ListByOpts(opts *VMJobOpts) ([]*vm.VMJob, error) {
 	if opts.Status != "" {
 		query["status"] = opts.Status
 	}
+	if len(opts.Statuses) > 0 {
+		query["status"] = bson.M{"$in": opts.Statuses}
+	}
+	if opts.VMID != "" {
+		query["vm_id"] = opts.VMID
+	}
 
 	res := make([]*vm.VMJob, 0)
 	cursor, err := c.Collection.Find(context.Background(), query)
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type WebhookDeliveryColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+// defaultWebhookDeliveryRetentionDays is used when WEBHOOK_DELIVERY_RETENTION_DAYS is not set
+const defaultWebhookDeliveryRetentionDays = 30
+
+func NewWebhookDeliveryColl() *WebhookDeliveryColl {
+	name := models.WebhookDelivery{}.TableName()
+	return &WebhookDeliveryColl{Collection: mongotool.Database(config.MongoDatabase()).Collection(name), coll: name}
+}
+
+func (c *WebhookDeliveryColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *WebhookDeliveryColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				bson.E{Key: "event_uuid", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "status", Value: 1},
+				bson.E{Key: "next_attempt_time", Value: 1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "create_time", Value: -1},
+			},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+
+	if _, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx)); err != nil {
+		return err
+	}
+	return c.ensureRetentionIndex(ctx)
+}
+
+// ensureRetentionIndex creates the TTL index removing deliveries after the retention, the expiration
+// of an existing index is updated in place when the retention is changed.
+func (c *WebhookDeliveryColl) ensureRetentionIndex(ctx context.Context) error {
+	retentionDays := config.WebhookDeliveryRetentionDays()
+	if retentionDays <= 0 {
+		retentionDays = defaultWebhookDeliveryRetentionDays
+	}
+	expireAfterSeconds := int32(retentionDays * 24 * 3600)
+
+	keys := bson.D{bson.E{Key: "created_at", Value: 1}}
+	_, err := c.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetExpireAfterSeconds(expireAfterSeconds),
+	}, mongotool.CreateIndexOptions(ctx))
+	var cmdErr mongo.CommandError
+	if err == nil || !errors.As(err, &cmdErr) || cmdErr.Name != "IndexOptionsConflict" {
+		return err
+	}
+
+	return c.Database().RunCommand(ctx, bson.D{
+		bson.E{Key: "collMod", Value: c.coll},
+		bson.E{Key: "index", Value: bson.D{
+			bson.E{Key: "keyPattern", Value: keys},
+			bson.E{Key: "expireAfterSeconds", Value: expireAfterSeconds},
+		}},
+	}).Err()
+}
+
+func (c *WebhookDeliveryColl) Create(args *models.WebhookDelivery) error {
+	if args == nil {
+		return errors.New("nil webhook delivery args")
+	}
+
+	// attempts are pushed to the array later on
+	if args.Attempts == nil {
+		args.Attempts = make([]*models.WebhookDeliveryAttempt, 0)
+	}
+	args.CreatedAt = time.Now()
+	args.CreateTime = args.CreatedAt.Unix()
+	args.UpdateTime = args.CreateTime
+	_, err := c.InsertOne(context.TODO(), args)
+	return err
+}
+
+func (c *WebhookDeliveryColl) GetByEventUUID(eventUUID string) (*models.WebhookDelivery, error) {
+	resp := new(models.WebhookDelivery)
+	err := c.FindOne(context.TODO(), bson.M{"event_uuid": eventUUID}).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type WebhookDeliveryListOption struct {
+	ObjectKind string
+	Event      string
+	Address    string
+	Status     models.WebhookDeliveryStatus
+	Page       int64
+	PageSize   int64
+}
+
+// List returns the deliveries without their payloads, newest first.
+func (c *WebhookDeliveryColl) List(opt *WebhookDeliveryListOption) ([]*models.WebhookDelivery, int64, error) {
+	if opt == nil {
+		return nil, 0, errors.New("nil ListOption")
+	}
+
+	query := bson.M{}
+	if opt.ObjectKind != "" {
+		query["object_kind"] = opt.ObjectKind
+	}
+	if opt.Event != "" {
+		query["event"] = opt.Event
+	}
+	if opt.Address != "" {
+		query["address"] = opt.Address
+	}
+	if opt.Status != "" {
+		query["status"] = opt.Status
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{"create_time", -1}}).
+		SetProjection(bson.M{"payload": 0, "attempts.response_body": 0})
+	if opt.Page > 0 && opt.PageSize > 0 {
+		opts.SetSkip((opt.Page - 1) * opt.PageSize).SetLimit(opt.PageSize)
+	}
+
+	ctx := context.Background()
+	cursor, err := c.Collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp := make([]*models.WebhookDelivery, 0)
+	if err := cursor.All(ctx, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp, count, nil
+}
+
+// ClaimDue locks a pending delivery whose next attempt is due for the given lease,
+// it returns mongo.ErrNoDocuments if there is nothing to retry.
+func (c *WebhookDeliveryColl) ClaimDue(now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	query := bson.M{
+		"status":            models.WebhookDeliveryStatusPending,
+		"next_attempt_time": bson.M{"$lte": now.Unix()},
+		"locked_until":      bson.M{"$lt": now.Unix()},
+	}
+	return c.claim(query, now, lease)
+}
+
+// Claim locks the delivery of the event for the given lease, it returns mongo.ErrNoDocuments
+// if the delivery does not exist or is being sent right now.
+func (c *WebhookDeliveryColl) Claim(eventUUID string, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	query := bson.M{
+		"event_uuid":   eventUUID,
+		"locked_until": bson.M{"$lt": now.Unix()},
+	}
+	return c.claim(query, now, lease)
+}
+
+func (c *WebhookDeliveryColl) claim(query bson.M, now time.Time, lease time.Duration) (*models.WebhookDelivery, error) {
+	change := bson.M{"$set": bson.M{
+		"locked_until": now.Add(lease).Unix(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetSort(bson.D{{"next_attempt_time", 1}})
+
+	resp := new(models.WebhookDelivery)
+	err := c.FindOneAndUpdate(context.TODO(), query, change, opts).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddAttempt records the attempt, saves the resulting status of the delivery and releases its lock.
+func (c *WebhookDeliveryColl) AddAttempt(args *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error {
+	if args == nil || attempt == nil {
+		return errors.New("nil webhook delivery args")
+	}
+
+	change := bson.M{
+		"$push": bson.M{"attempts": attempt},
+		"$set": bson.M{
+			"status":            args.Status,
+			"next_attempt_time": args.NextAttemptTime,
+			"locked_until":      0,
+			"update_time":       time.Now().Unix(),
+		},
+	}
+	_, err := c.UpdateOne(context.TODO(), bson.M{"event_uuid": args.EventUUID}, change)
+	return err
+}
@@ -422,6 +422,26 @@ func (c *WorkflowV4Coll) Update(idString string, obj *models.WorkflowV4) error {
 	return err
 }
 
+// SetGitSource marks the workflow as managed in git, a nil ref releases it.
+func (c *WorkflowV4Coll) SetGitSource(name string, ref *models.WorkflowV4GitRef) error {
+	query := bson.M{"name": name}
+	change := bson.M{"$set": bson.M{"git_source": ref}}
+	if ref == nil {
+		change = bson.M{"$unset": bson.M{"git_source": ""}}
+	}
+
+	_, err := c.UpdateOne(context.TODO(), query, change)
+	return err
+}
+
+func (c *WorkflowV4Coll) UnsetGitSourceByProject(projectName string) error {
+	query := bson.M{"project": projectName, "git_source": bson.M{"$exists": true}}
+	change := bson.M{"$unset": bson.M{"git_source": ""}}
+
+	_, err := c.UpdateMany(context.TODO(), query, change)
+	return err
+}
+
 func (c *WorkflowV4Coll) CacheAIReleaseSpecialistRulePlans(ctx context.Context, workflowName, jobName, sourceRule string, rulePlans map[string]*models.AIReleaseSpecialistRulePlan) (bool, error) {
 	if len(rulePlans) == 0 {
 		return false, errors.New("ai release specialist rule plans cannot be empty")
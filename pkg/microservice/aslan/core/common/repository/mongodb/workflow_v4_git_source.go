@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	mongotool "github.com/koderover/zadig/v2/pkg/tool/mongo"
+)
+
+type WorkflowV4GitSourceColl struct {
+	*mongo.Collection
+
+	coll string
+}
+
+func NewWorkflowV4GitSourceColl() *WorkflowV4GitSourceColl {
+	name := models.WorkflowV4GitSource{}.TableName()
+	return &WorkflowV4GitSourceColl{
+		Collection: mongotool.Database(config.MongoDatabase()).Collection(name),
+		coll:       name,
+	}
+}
+
+func (c *WorkflowV4GitSourceColl) GetCollectionName() string {
+	return c.coll
+}
+
+func (c *WorkflowV4GitSourceColl) EnsureIndex(ctx context.Context) error {
+	mod := []mongo.IndexModel{
+		{
+			Keys:    bson.D{bson.E{Key: "project_name", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_project_name"),
+		},
+		{
+			Keys: bson.D{
+				bson.E{Key: "codehost_id", Value: 1},
+				bson.E{Key: "repo_name", Value: 1},
+				bson.E{Key: "branch_name", Value: 1},
+			},
+			Options: options.Index().SetUnique(false).SetName("idx_repo_branch"),
+		},
+	}
+
+	_, err := c.Indexes().CreateMany(ctx, mod, mongotool.CreateIndexOptions(ctx))
+	return err
+}
+
+func (c *WorkflowV4GitSourceColl) Find(projectName string) (*models.WorkflowV4GitSource, error) {
+	resp := new(models.WorkflowV4GitSource)
+	err := c.FindOne(context.TODO(), bson.M{"project_name": projectName}).Decode(resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type ListWorkflowV4GitSourceOption struct {
+	CodehostID int
+	RepoName   string
+	BranchName string
+}
+
+func (c *WorkflowV4GitSourceColl) List(opt *ListWorkflowV4GitSourceOption) ([]*models.WorkflowV4GitSource, error) {
+	query := bson.M{}
+	if opt != nil {
+		if opt.CodehostID > 0 {
+			query["codehost_id"] = opt.CodehostID
+		}
+		if opt.RepoName != "" {
+			query["repo_name"] = opt.RepoName
+		}
+		if opt.BranchName != "" {
+			query["branch_name"] = opt.BranchName
+		}
+	}
+
+	resp := make([]*models.WorkflowV4GitSource, 0)
+	cursor, err := c.Collection.Find(context.TODO(), query)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(context.TODO(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Upsert creates or replaces the git source of the project, the sync status is kept.
+func (c *WorkflowV4GitSourceColl) Upsert(args *models.WorkflowV4GitSource) error {
+	now := time.Now().Unix()
+	query := bson.M{"project_name": args.ProjectName}
+	change := bson.M{
+		"$set": bson.M{
+			"codehost_id": args.CodehostID,
+			"source":      args.Source,
+			"repo_owner":  args.RepoOwner,
+			"namespace":   args.Namespace,
+			"repo_name":   args.RepoName,
+			"branch_name": args.BranchName,
+			"path":        args.Path,
+			"updated_by":  args.UpdatedBy,
+			"update_time": now,
+		},
+		"$setOnInsert": bson.M{
+			"created_by":  args.UpdatedBy,
+			"create_time": now,
+		},
+	}
+
+	_, err := c.UpdateOne(context.TODO(), query, change, options.Update().SetUpsert(true))
+	return err
+}
+
+func (c *WorkflowV4GitSourceColl) UpdateSyncStatus(projectName string, commit *models.Commit, syncErr string) error {
+	query := bson.M{"project_name": projectName}
+	set := bson.M{
+		"last_sync_time":  time.Now().Unix(),
+		"last_sync_error": syncErr,
+	}
+	if commit != nil {
+		set["commit"] = commit
+	}
+
+	_, err := c.UpdateOne(context.TODO(), query, bson.M{"$set": set})
+	return err
+}
+
+func (c *WorkflowV4GitSourceColl) Delete(projectName string) error {
+	_, err := c.DeleteOne(context.TODO(), bson.M{"project_name": projectName})
+	return err
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+)
+
+type Client struct {
+	*bitbucket.Client
+}
+
+// NewClient creates a bitbucket client with the credentials of the code host, either the username and app password
+// or the access token is used.
+func NewClient(ch *systemconfig.CodeHost, proxyAddr string) (*Client, error) {
+	c, err := bitbucket.NewClient(ch.Address, ch.Username, ch.Password, ch.AccessToken, proxyAddr, ch.EnableProxy, ch.DisableSSL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{Client: c}, nil
+}
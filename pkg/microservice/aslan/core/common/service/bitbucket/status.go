@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/github"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+)
+
+// bitbucket cloud limits the key of a build status to 40 characters
+const maxBuildStatusKeyLength = 40
+
+type StatusOptions struct {
+	Owner       string
+	Repo        string
+	Ref         string
+	State       bitbucket.BuildState
+	Description string
+
+	AslanURL    string
+	PipeName    string
+	DisplayName string
+	ProductName string
+	PipeType    config.PipelineType
+	TaskID      int64
+}
+
+// UpdateBuildStatus reports the status of the workflow to the commit, the workflow name is used as the key
+// so that the status of later tasks of the same workflow overwrites the previous one.
+func (c *Client) UpdateBuildStatus(opt *StatusOptions) error {
+	key := opt.PipeName
+	if len(key) > maxBuildStatusKeyLength {
+		key = key[:maxBuildStatusKeyLength]
+	}
+
+	return c.CreateBuildStatus(opt.Owner, opt.Repo, opt.Ref, &bitbucket.BuildStatus{
+		Key:         key,
+		State:       opt.State,
+		Name:        setting.ProductName + "/" + opt.DisplayName,
+		Description: opt.Description,
+		URL: github.GetTaskLink(
+			opt.AslanURL,
+			opt.ProductName,
+			opt.PipeName,
+			opt.DisplayName,
+			opt.PipeType,
+			opt.TaskID,
+		),
+	})
+}
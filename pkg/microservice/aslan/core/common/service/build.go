@@ -316,6 +316,8 @@ func FillBuildDetail(moduleBuild *commonmodels.Build, serviceName, serviceModule
 	moduleBuild.CacheEnable = buildTemplate.CacheEnable
 	moduleBuild.CacheDirType = buildTemplate.CacheDirType
 	moduleBuild.CacheUserDir = buildTemplate.CacheUserDir
+	moduleBuild.CacheRules = buildTemplate.CacheRules
+	moduleBuild.CacheQuotaInMiB = buildTemplate.CacheQuotaInMiB
 	moduleBuild.AdvancedSettingsModified = buildTemplate.AdvancedSettingsModified
 	moduleBuild.EnablePrivilegedMode = buildTemplate.EnablePrivilegedMode
 	moduleBuild.Outputs = buildTemplate.Outputs
@@ -35,6 +35,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/step"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/util"
@@ -109,6 +110,11 @@ func RunGitCmds(codehostDetail *systemconfig.CodeHost, repoOwner, repoNamespace,
 		repo.Password = password
 		tokens = append(tokens, repo.Password)
 	}
+	if repo.Source == setting.SourceFromBitbucket {
+		repo.User = codehostDetail.Username
+		repo.Password = codehostDetail.Password
+		tokens = append(tokens, repo.Password)
+	}
 	tokens = append(tokens, repo.OauthToken)
 	cmds = append(cmds, buildGitCommands(repo, hostNames)...)
 
@@ -214,7 +220,7 @@ func buildGitCommands(repo *Repo, hostNames sets.String) []*Command {
 		owner = repo.Owner
 	}
 
-	if repo.Source == setting.SourceFromGitlab {
+	if repo.Source == setting.SourceFromGitlab || repo.Source == setting.SourceFromGitea {
 		u, _ := url.Parse(repo.Address)
 		url := OAuthCloneURL(repo.Source, repo.OauthToken, u.Host, repo.Owner, repo.Name, u.Scheme)
 		cmds = append(cmds, &Command{
@@ -230,6 +236,17 @@ func buildGitCommands(repo *Repo, hostNames sets.String) []*Command {
 			Cmd:          RemoteAdd(repo.RemoteName, u.String()),
 			DisableTrace: true,
 		})
+	} else if repo.Source == setting.SourceFromBitbucket {
+		u, err := url.Parse(bitbucket.CloneURL(repo.Address, owner, repo.Name))
+		if err != nil {
+			log.Errorf("failed to parse url,err:%s", err)
+		} else {
+			u.User = bitbucket.CloneUserInfo(repo.User, repo.Password, repo.OauthToken)
+			cmds = append(cmds, &Command{
+				Cmd:          RemoteAdd(repo.RemoteName, u.String()),
+				DisableTrace: true,
+			})
+		}
 	} else if repo.Source == setting.SourceFromGiteeEE || repo.Source == setting.SourceFromGitee {
 		giteeURL := step.HTTPSCloneURL(repo.Source, repo.OauthToken, repo.Owner, repo.Name, repo.Address)
 		cmds = append(cmds, &Command{Cmd: RemoteAdd(repo.RemoteName, giteeURL), DisableTrace: true})
@@ -350,7 +367,7 @@ func ShowLastLog() *exec.Cmd {
 }
 
 func OAuthCloneURL(source, token, address, owner, name, scheme string) string {
-	if strings.ToLower(source) == setting.SourceFromGitlab || strings.ToLower(source) == setting.SourceFromOther {
+	if strings.ToLower(source) == setting.SourceFromGitlab || strings.ToLower(source) == setting.SourceFromGitea || strings.ToLower(source) == setting.SourceFromOther {
 		// address 需要传过来
 		return fmt.Sprintf("%s://%s:%s@%s/%s/%s.git", scheme, "oauth2", token, address, owner, name)
 	}
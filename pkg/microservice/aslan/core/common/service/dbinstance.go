@@ -17,11 +17,6 @@ limitations under the License.
 package service
 
 import (
-	"database/sql"
-	"fmt"
-
-	_ "github.com/go-sql-driver/mysql"
-
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -30,6 +25,7 @@ import (
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/tool/crypto"
+	"github.com/koderover/zadig/v2/pkg/tool/database"
 )
 
 func ListDBInstances(encryptedKey string, log *zap.SugaredLogger) ([]*commonmodels.DBInstance, error) {
@@ -123,25 +119,13 @@ func ValidateDBInstance(args *commonmodels.DBInstance) error {
 	if args == nil {
 		return errors.New("nil DBInstance")
 	}
-	switch args.Type {
-	case config.DBInstanceTypeMySQL, config.DBInstanceTypeMariaDB:
-		return validateMySQLInstance(args)
-	default:
+	if !database.IsSupported(args.Type) {
 		return errors.Errorf("invalid db type %s", args.Type)
 	}
-}
-
-func validateMySQLInstance(args *commonmodels.DBInstance) error {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8&parseTime=True&loc=Local", args.Username, args.Password, args.Host, args.Port)
-
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		return errors.Errorf("connect mysql failed, err: %s", err)
-	}
-	defer db.Close()
-
-	if err = db.Ping(); err != nil {
-		return errors.Errorf("ping mysql failed, err: %s", err)
+	if args.Type == config.DBInstanceTypePostgreSQL {
+		if err := database.ValidatePostgreSQLSSLMode(args.SSLMode); err != nil {
+			return err
+		}
 	}
-	return nil
+	return database.Ping(args.Type, args.ConnInfo())
 }
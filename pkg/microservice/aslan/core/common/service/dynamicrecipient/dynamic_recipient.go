@@ -51,6 +51,11 @@ var supportedDynamicRecipientKinds = map[setting.NotifyWebHookType]map[dynamicRe
 		dynamicRecipientKindMobile: {},
 		dynamicRecipientKindUserID: {},
 	},
+	setting.NotifyWebHookTypeSlack: {
+		dynamicRecipientKindEmail:  {},
+		dynamicRecipientKindMobile: {},
+		dynamicRecipientKindUserID: {},
+	},
 	setting.NotifyWebHookTypeMail: {
 		dynamicRecipientKindEmail:  {},
 		dynamicRecipientKindMobile: {},
@@ -87,6 +92,7 @@ type NotificationConfigs struct {
 	LarkPerson *commonmodels.LarkPersonNotificationConfig
 	DingDing   *commonmodels.DingDingNotificationConfig
 	MSTeams    *commonmodels.MSTeamsNotificationConfig
+	Slack      *commonmodels.SlackNotificationConfig
 	Mail       *commonmodels.MailNotificationConfig
 }
 
@@ -198,6 +204,11 @@ func ResolveNotificationConfigs(keyMap map[string]string, configs NotificationCo
 		emails := resolver.ResolveEmails(append([]string(cfg.DynamicRecipients), templates...))
 		cfg.AtEmails = UniqStrings(append(cfg.AtEmails, emails...))
 	}
+	if cfg := configs.Slack; cfg != nil {
+		// slack mentions are looked up by email, so the resolved recipients share the mail user form
+		emails := resolver.ResolveEmails([]string(cfg.DynamicRecipients))
+		cfg.AtUsers = UniqMailUsers(append(cfg.AtUsers, BuildMailUsersFromEmails(emails)...))
+	}
 	if cfg := configs.Mail; cfg != nil {
 		emails := resolver.ResolveEmails([]string(cfg.DynamicRecipients))
 		cfg.TargetUsers = UniqMailUsers(append(cfg.TargetUsers, BuildMailUsersFromEmails(emails)...))
@@ -1178,7 +1178,12 @@ func serviceHasTrackableWorkload(productInfo *commonmodels.Product, serviceTmpl
 		return resourcesHaveTrackableWorkload(productSvc.Resources)
 	}
 
-	renderedYaml, err := kube.RenderServiceYaml(serviceTmpl.Yaml, productInfo.ProductName, serviceTmpl.ServiceName, productInfo.GetSvcRender(serviceTmpl.ServiceName))
+	svcYaml, err := kube.ServiceTemplateYaml(serviceTmpl, productInfo.EnvName)
+	if err != nil {
+		log.Errorf("failed to get service yaml for workload status, err: %s", err)
+		return true
+	}
+	renderedYaml, err := kube.RenderServiceYaml(svcYaml, productInfo.ProductName, serviceTmpl.ServiceName, productInfo.GetSvcRender(serviceTmpl.ServiceName))
 	if err != nil {
 		log.Errorf("failed to render service yaml for workload status, err: %s", err)
 		return true
@@ -24,6 +24,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/git"
+	giteaservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitea"
 	githubservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/github"
 	gitlabservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitlab"
 	"github.com/koderover/zadig/v2/pkg/setting"
@@ -127,6 +128,8 @@ func GetTreeGetter(codeHostID int) (TreeGetter, error) {
 		return githubservice.NewClient(ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy), nil
 	case setting.SourceFromGitlab:
 		return gitlabservice.NewClient(ch.ID, ch.Address, ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy, ch.DisableSSL)
+	case setting.SourceFromGitea:
+		return giteaservice.NewClient(ch.ID, ch.Address, ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy, ch.DisableSSL)
 	default:
 		log.Errorf("GetTreeGetter invalid source: %s", ch.Type)
 		return nil, fmt.Errorf("invalid source: %s", ch.Type)
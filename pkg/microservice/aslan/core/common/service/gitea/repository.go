@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/git"
+)
+
+func (c *Client) GetTree(owner, repo, path, branch string) ([]*git.TreeNode, error) {
+	var treeNodes []*git.TreeNode
+
+	entries, err := c.ListTree(owner, repo, path, branch)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range entries {
+		treeNodes = append(treeNodes, &git.TreeNode{
+			Name:     t.Name,
+			Size:     t.Size,
+			IsDir:    t.Type == "dir",
+			FullPath: t.Path,
+		})
+	}
+	return treeNodes, nil
+}
+
+func (c *Client) GetLatestRepositoryCommit(owner, repo, path, branch string) (*git.RepositoryCommit, error) {
+	res, err := c.Client.GetLatestRepositoryCommit(owner, repo, path, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &git.RepositoryCommit{SHA: res.SHA, Message: res.Commit.Message}, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/github"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
+)
+
+type StatusOptions struct {
+	Owner       string
+	Repo        string
+	Ref         string
+	State       gitea.StatusState
+	Description string
+
+	AslanURL    string
+	PipeName    string
+	DisplayName string
+	ProductName string
+	PipeType    config.PipelineType
+	TaskID      int64
+}
+
+func (c *Client) UpdateCheckStatus(opt *StatusOptions) error {
+	_, err := c.CreateStatus(opt.Owner, opt.Repo, opt.Ref, &gitea.CreateStatusOption{
+		State:       opt.State,
+		Description: opt.Description,
+		TargetURL: github.GetTaskLink(
+			opt.AslanURL,
+			opt.ProductName,
+			opt.PipeName,
+			opt.DisplayName,
+			opt.PipeType,
+			opt.TaskID,
+		),
+		Context: setting.ProductName + "/" + opt.DisplayName,
+	})
+	return err
+}
@@ -446,7 +446,7 @@ func (w *Service) createNotifyBodyOfWorkflowIM(weChatNotification *wechatNotific
 							prLinkBuilder = func(baseURL, owner, repoName string, prID int) string {
 								return fmt.Sprintf("%s/%s/%s/pull/%d", baseURL, owner, repoName, prID)
 							}
-						case types.ProviderGitee:
+						case types.ProviderGitee, types.ProviderGitea:
 							prLinkBuilder = func(baseURL, owner, repoName string, prID int) string {
 								return fmt.Sprintf("%s/%s/%s/pulls/%d", baseURL, owner, repoName, prID)
 							}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instantmessage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	slackservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/slack"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhooknotify"
+	"github.com/koderover/zadig/v2/pkg/tool/slack"
+)
+
+var (
+	markdownHeadingRegexp = regexp.MustCompile(`^#{1,6}\s*(.*)$`)
+	markdownBoldRegexp    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	markdownLinkRegexp    = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+)
+
+// slackMarkdown converts the markdown shared by the dingtalk, wechat and msteams notifications
+// into slack mrkdwn, which has no headings and uses single asterisks for bold.
+func slackMarkdown(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		line = markdownBoldRegexp.ReplaceAllString(line, "*$1*")
+		line = markdownLinkRegexp.ReplaceAllString(line, "<$2|$1>")
+		if match := markdownHeadingRegexp.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			line = ""
+			if heading := strings.TrimSpace(strings.ReplaceAll(match[1], "*", "")); heading != "" {
+				line = "*" + heading + "*"
+			}
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func slackStatusEmoji(taskStatus config.Status) string {
+	switch taskStatus {
+	case config.StatusPassed, config.StatusCreated:
+		return ":white_check_mark:"
+	case config.StatusFailed, config.StatusReject:
+		return ":x:"
+	case config.StatusWaitingApprove:
+		return ":hourglass_flowing_sand:"
+	default:
+		return ":warning:"
+	}
+}
+
+// sendSlackMessage posts the notification as a Block Kit message, the first line of the content
+// is the title and is rendered as the header like the msteams card does.
+func (w *Service) sendSlackMessage(cfg *models.SlackNotificationConfig, title, content, actionURL string, taskStatus config.Status) error {
+	if _, body, found := strings.Cut(content, "\n"); found {
+		content = body
+	}
+	title = strings.TrimSpace(markdownHeadingRegexp.ReplaceAllString(strings.TrimSpace(title), "$1"))
+	title = strings.ReplaceAll(title, "**", "")
+
+	return postSlackMessage(cfg, title, actionURL,
+		slack.NewSectionBlock(fmt.Sprintf("%s %s", slackStatusEmoji(taskStatus), slackMarkdown(content))),
+	)
+}
+
+// SendSlackNotification posts a title and a markdown content as they are, it is used by the
+// notification job whose content is written by the user.
+func (w *Service) SendSlackNotification(cfg *models.SlackNotificationConfig, title, content, actionURL string) error {
+	return postSlackMessage(cfg, title, actionURL, slack.NewSectionBlock(slackMarkdown(content)))
+}
+
+// SendReleasePlanSlackNotification posts a release plan event to slack.
+func (w *Service) SendReleasePlanSlackNotification(cfg *models.SlackNotificationConfig, plan *webhooknotify.ReleasePlanHookBody, detailURL string) error {
+	doneJobs := 0
+	for _, job := range plan.Jobs {
+		if job.Status == config.ReleasePlanJobStatusDone || job.Status == config.ReleasePlanJobStatusSkipped {
+			doneJobs++
+		}
+	}
+
+	title := fmt.Sprintf("Release plan %s #%d", plan.Name, plan.Index)
+	blocks := []*slack.Block{
+		slack.NewSectionBlock(fmt.Sprintf("*Event:* %s", plan.EventName)),
+		slack.NewFieldsBlock(
+			fmt.Sprintf("*Manager:*\n%s", plan.Manager),
+			fmt.Sprintf("*Status:*\n%s", plan.Status),
+			fmt.Sprintf("*Jobs done:*\n%d/%d", doneJobs, len(plan.Jobs)),
+		),
+	}
+	if plan.Description != "" {
+		blocks = append(blocks, slack.NewSectionBlock(slackMarkdown(plan.Description)))
+	}
+	return postSlackMessage(cfg, title, detailURL, blocks...)
+}
+
+// postSlackMessage wraps the body with the header, the mentions and the link to zadig.
+func postSlackMessage(cfg *models.SlackNotificationConfig, title, actionURL string, body ...*slack.Block) error {
+	if cfg == nil {
+		return fmt.Errorf("slack notification config is empty")
+	}
+	client, err := slackservice.GetSlackClientByIMAppID(cfg.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to get slack client, error: %s", err)
+	}
+
+	blocks := append([]*slack.Block{slack.NewHeaderBlock(title)}, body...)
+
+	mentions := make([]string, 0)
+	if cfg.IsAtAll {
+		mentions = append(mentions, "<!channel>")
+	}
+	for _, id := range slackservice.GetSlackUserIDs(cfg.AppID, client, cfg.AtUsers) {
+		mentions = append(mentions, slack.Mention(id))
+	}
+	if len(mentions) > 0 {
+		blocks = append(blocks, slack.NewContextBlock(strings.Join(mentions, " ")))
+	}
+	if actionURL != "" {
+		blocks = append(blocks, slack.NewActionsBlock("", slack.NewLinkButton("View in Zadig", actionURL)))
+	}
+
+	if _, err := client.PostMessage(cfg.Channel, title, blocks); err != nil {
+		return fmt.Errorf("failed to send message to slack: %v", err)
+	}
+	return nil
+}
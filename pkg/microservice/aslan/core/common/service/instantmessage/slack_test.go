@@ -0,0 +1,29 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instantmessage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlackMarkdown(t *testing.T) {
+	content := "#### **Workflow** demo\n**Status**: passed\n\n[more](https://zadig.example.com/task/1)"
+	expected := "*Workflow demo*\n*Status*: passed\n\n<https://zadig.example.com/task/1|more>"
+	require.Equal(t, expected, slackMarkdown(content))
+}
@@ -87,6 +87,7 @@ var (
 		"jobTypeK8sResourcePatch": "更新 K8s YAML 任务",
 		"jobTypeK8sGrayRollback":  "灰度回滚",
 		"jobTypeGrayDeploy":       "灰度发布",
+		"jobTypeProgressive":      "渐进式发布",
 		"jobTypeIstioRelease":     "Istio 发布",
 		"jobTypeIstioRollback":    "Istio 回滚",
 		"jobTypeIstioStrategy":    "更新 Istio 灰度策略",
@@ -100,6 +101,8 @@ var (
 		"jobTypeJenkinsJob":       "执行 Jenkins job",
 		"jobTypeBlueKingJob":      "执行蓝鲸作业",
 		"jobTypeSql":              "SQL 数据变更",
+		"jobTypeSchemaMigration":  "数据库版本迁移",
+		"jobTypeTerraform":        "Terraform 基础设施变更",
 		"jobTypeNotification":     "通知",
 		"jobTypeSaeDeploy":        "SAE 应用部署",
 		"jobTypeAITask":           "AI 任务",
@@ -171,6 +174,7 @@ var (
 		"jobTypeK8sResourcePatch": "Kubernetes Resource Patch",
 		"jobTypeK8sGrayRollback":  "Gray Rollback",
 		"jobTypeGrayDeploy":       "Gray Release",
+		"jobTypeProgressive":      "Progressive Delivery",
 		"jobTypeIstioRelease":     "Istio Release",
 		"jobTypeIstioRollback":    "Istio Rollback",
 		"jobTypeIstioStrategy":    "Istio Strategy",
@@ -184,6 +188,8 @@ var (
 		"jobTypeJenkinsJob":       "Execute Jenkins job",
 		"jobTypeBlueKingJob":      "Execute BlueKing job",
 		"jobTypeSql":              "SQL Changes",
+		"jobTypeSchemaMigration":  "Schema Migration",
+		"jobTypeTerraform":        "Terraform Infrastructure",
 		"jobTypeNotification":     "Notification",
 		"jobTypeSaeDeploy":        "SAE Deploy",
 		"jobTypeAITask":           "AI Task",
@@ -449,6 +455,7 @@ func resolveWorkflowNotifyDynamicRecipients(task *models.WorkflowTask, notify *m
 		LarkPerson: notify.LarkPersonNotificationConfig,
 		DingDing:   notify.DingDingNotificationConfig,
 		MSTeams:    notify.MSTeamsNotificationConfig,
+		Slack:      notify.SlackNotificationConfig,
 		Mail:       notify.MailNotificationConfig,
 	})
 }
@@ -1292,7 +1299,7 @@ func (w *Service) SendSystemWorkflowHook(task *models.WorkflowTask, hookSetting
 		return err
 	}
 
-	return webhooknotify.NewClient(hookSetting.HookAddress, hookSetting.HookSecret).SendWorkflowWebhook(webhookNotify, workflowHookEventToWebhookEvent(hookEvent))
+	return webhooknotify.NewClient(hookSetting.HookAddress, hookSetting.HookSecret, hookSetting.SigningSecret).SendWorkflowWebhook(webhookNotify, workflowHookEventToWebhookEvent(hookEvent))
 }
 
 func isWorkflowHookEventEnabled(hookSetting *models.WorkflowHookSettings, hookEvent models.WorkflowHookEvent) bool {
@@ -1694,6 +1701,8 @@ func getJobTaskTplExec(tplcontent string, args *jobTaskNotification, language st
 				return getText("jobTypeK8sGrayRollback", language)
 			case string(config.JobK8sGrayRelease):
 				return getText("jobTypeGrayDeploy", language)
+			case string(config.JobProgressiveDelivery):
+				return getText("jobTypeProgressive", language)
 			case string(config.JobIstioRelease):
 				return getText("jobTypeIstioRelease", language)
 			case string(config.JobIstioRollback):
@@ -1724,6 +1733,10 @@ func getJobTaskTplExec(tplcontent string, args *jobTaskNotification, language st
 				return getText("jobTypeBlueKingJob", language)
 			case string(config.JobSQL):
 				return getText("jobTypeSql", language)
+			case string(config.JobSchemaMigration):
+				return getText("jobTypeSchemaMigration", language)
+			case string(config.JobTerraform):
+				return getText("jobTypeTerraform", language)
 			case string(config.JobNotification):
 				return getText("jobTypeNotification", language)
 			case string(config.JobSAEDeploy):
@@ -1836,7 +1849,7 @@ func appendInlineNotifyAtContent(content string, notify *models.NotifyCtl) strin
 
 func (w *Service) sendNotification(title, content string, notify *models.NotifyCtl, card *LarkCard, webhookNotify *webhooknotify.WorkflowNotify, taskStatus config.Status) error {
 	link := ""
-	if notify.WebHookType == setting.NotifyWebHookTypeDingDing || notify.WebHookType == setting.NotifyWebHookTypeWechatWork || notify.WebHookType == setting.NotifyWebHookTypeMSTeam {
+	if notify.WebHookType == setting.NotifyWebHookTypeDingDing || notify.WebHookType == setting.NotifyWebHookTypeWechatWork || notify.WebHookType == setting.NotifyWebHookTypeMSTeam || notify.WebHookType == setting.NotifyWebHookTypeSlack {
 		switch webhookNotify.TaskType {
 		case config.WorkflowTaskTypeWorkflow:
 			link = fmt.Sprintf("%s/v1/projects/detail/%s/pipelines/custom/%s/%d?display_name=%s", configbase.SystemAddress(), webhookNotify.ProjectName, webhookNotify.WorkflowName, webhookNotify.TaskID, url.PathEscape(webhookNotify.WorkflowDisplayName))
@@ -1855,6 +1868,10 @@ func (w *Service) sendNotification(title, content string, notify *models.NotifyC
 		if err := w.sendMSTeamsMessage(notify.MSTeamsNotificationConfig.HookAddress, title, content, link, notify.MSTeamsNotificationConfig.AtEmails, taskStatus); err != nil {
 			return err
 		}
+	case setting.NotifyWebHookTypeSlack:
+		if err := w.sendSlackMessage(notify.SlackNotificationConfig, title, content, link, taskStatus); err != nil {
+			return err
+		}
 	case setting.NotifyWebHookTypeDingDing:
 		if err := w.sendDingDingMessage(notify.DingDingNotificationConfig.HookAddress, title, content, link, notify.DingDingNotificationConfig.AtMobiles, notify.DingDingNotificationConfig.IsAtAll); err != nil {
 			return err
@@ -1871,7 +1888,7 @@ func (w *Service) sendNotification(title, content string, notify *models.NotifyC
 			return err
 		}
 	case setting.NotifyWebHookTypeWebook:
-		webhookclient := webhooknotify.NewClient(notify.WebhookNotificationConfig.Address, notify.WebhookNotificationConfig.Token)
+		webhookclient := webhooknotify.NewClient(notify.WebhookNotificationConfig.Address, notify.WebhookNotificationConfig.Token, notify.WebhookNotificationConfig.SigningSecret)
 		err := webhookclient.SendWorkflowWebhook(webhookNotify, webhooknotify.WebHookNotifyEventWorkflow)
 		if err != nil {
 			return fmt.Errorf("failed to send notification to webhook, address %s, token: %s, error: %v", notify.WebhookNotificationConfig.Address, notify.WebhookNotificationConfig.Token, err)
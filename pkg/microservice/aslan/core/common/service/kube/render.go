@@ -49,6 +49,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/serializer"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/util"
 	"github.com/koderover/zadig/v2/pkg/util/converter"
@@ -443,7 +444,11 @@ func FetchCurrentAppliedYaml(option *GeneSvcYamlOption) (string, int, error) {
 		}
 		return importedAllManifests, 0, err
 	} else {
-		fullRenderedYaml, err := RenderServiceYaml(prodSvcTemplate.Yaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
+		prodSvcYaml, err := ServiceTemplateYaml(prodSvcTemplate, option.EnvName)
+		if err != nil {
+			return "", 0, err
+		}
+		fullRenderedYaml, err := RenderServiceYaml(prodSvcYaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
 		if err != nil {
 			return "", 0, err
 		}
@@ -509,7 +514,11 @@ func resolveCurrentAppliedClusterName(productInfo *models.Product, serviceName s
 }
 
 func FetchImportedAllManifests(envInfo *models.Product, serviceTmp *models.Service, svcRender *template.ServiceRender, clusterName string) (string, []*WorkloadResource, error) {
-	fullRenderedYaml, err := RenderServiceYaml(serviceTmp.Yaml, envInfo.ProductName, serviceTmp.ServiceName, svcRender)
+	svcYaml, err := ServiceTemplateYaml(serviceTmp, envInfo.EnvName)
+	if err != nil {
+		return "", nil, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(svcYaml, envInfo.ProductName, serviceTmp.ServiceName, svcRender)
 	if err != nil {
 		return "", nil, err
 	}
@@ -765,7 +774,11 @@ func deleteEmptyMap(m map[string]interface{}, key string) {
 }
 
 func FetchImportedManifests(option *GeneSvcYamlOption, productInfo *models.Product, serviceTmp *models.Service, svcRender *template.ServiceRender) (string, []*WorkloadResource, error) {
-	fullRenderedYaml, err := RenderServiceYaml(serviceTmp.Yaml, option.ProductName, option.ServiceName, svcRender)
+	svcYaml, err := ServiceTemplateYaml(serviceTmp, productInfo.EnvName)
+	if err != nil {
+		return "", nil, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(svcYaml, option.ProductName, option.ServiceName, svcRender)
 	if err != nil {
 		return "", nil, err
 	}
@@ -938,7 +951,11 @@ func GenerateRenderedYaml(option *GeneSvcYamlOption) (string, int, []*WorkloadRe
 		!option.UpdateServiceRevision &&
 		option.ReplicaOverrides == nil &&
 		!option.IgnoreCurrentReplicaOverrides {
-		currentRenderedYaml, renderErr := RenderServiceYaml(prodSvcTemplate.Yaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
+		prodSvcYaml, err := ServiceTemplateYaml(prodSvcTemplate, option.EnvName)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		currentRenderedYaml, renderErr := RenderServiceYaml(prodSvcYaml, option.ProductName, option.ServiceName, curProductSvc.GetServiceRender())
 		if renderErr != nil {
 			return "", 0, nil, fmt.Errorf("failed to render current service yaml: %v", renderErr)
 		}
@@ -982,7 +999,11 @@ func GenerateRenderedYaml(option *GeneSvcYamlOption) (string, int, []*WorkloadRe
 
 	serviceRender.OverrideYaml.YamlContent = mergedYaml
 
-	fullRenderedYaml, err := RenderServiceYaml(latestSvcTemplate.Yaml, option.ProductName, option.ServiceName, serviceRender)
+	latestSvcYaml, err := ServiceTemplateYaml(latestSvcTemplate, option.EnvName)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	fullRenderedYaml, err := RenderServiceYaml(latestSvcYaml, option.ProductName, option.ServiceName, serviceRender)
 	if err != nil {
 		return "", 0, nil, fmt.Errorf("failed to render service yaml: %v", err)
 	}
@@ -1093,6 +1114,20 @@ func replicaMapsEqual(left, right map[string]int32) bool {
 	return true
 }
 
+// ServiceTemplateYaml returns the yaml template of the service in the environment. The yaml of a kustomize
+// service is built from its kustomization snapshot, using the overlay of the environment if there is one.
+func ServiceTemplateYaml(svcTmpl *commonmodels.Service, envName string) (string, error) {
+	if svcTmpl.Source != setting.SourceFromKustomize || svcTmpl.Kustomize == nil {
+		return svcTmpl.Yaml, nil
+	}
+
+	renderedYaml, err := kustomize.Build(svcTmpl.Kustomize.FileMap(), svcTmpl.Kustomize.OverlayPath(envName))
+	if err != nil {
+		return "", fmt.Errorf("failed to build kustomization of service %s: %v", svcTmpl.ServiceName, err)
+	}
+	return renderedYaml, nil
+}
+
 func RenderServiceYaml(originYaml, productName, serviceName string, svcRender *template.ServiceRender) (string, error) {
 	if svcRender == nil {
 		originYaml = strings.ReplaceAll(originYaml, setting.TemplateVariableProduct, productName)
@@ -1126,7 +1161,11 @@ func RenderEnvService(prod *commonmodels.Product, serviceRender *template.Servic
 
 func RenderEnvServiceWithTempl(prod *commonmodels.Product, serviceRender *template.ServiceRender, service *commonmodels.ProductService, svcTmpl *commonmodels.Service, clusterName string) (yaml string, err error) {
 	// Note only the keys in TemplateService.ServiceVar can work
-	parsedYaml, err := RenderServiceYaml(svcTmpl.Yaml, prod.ProductName, svcTmpl.ServiceName, serviceRender)
+	svcYaml, err := ServiceTemplateYaml(svcTmpl, prod.EnvName)
+	if err != nil {
+		return "", err
+	}
+	parsedYaml, err := RenderServiceYaml(svcYaml, prod.ProductName, svcTmpl.ServiceName, serviceRender)
 	if err != nil {
 		log.Errorf("failed to render service yaml, err: %s", err)
 		return "", err
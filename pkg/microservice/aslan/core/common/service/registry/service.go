@@ -91,6 +91,8 @@ type Service interface {
 	ListRepoImages(option ListRepoImagesOption, log *zap.SugaredLogger) (*ReposResp, error)
 	GetImageInfo(option GetRepoImageDetailOption, log *zap.SugaredLogger) (*commonmodels.DeliveryImage, error)
 	CheckImageExist(option CheckImageExistOption, log *zap.SugaredLogger) (bool, error)
+	// VerifyImageSignature returns the digest of the verified image
+	VerifyImageSignature(option VerifyImageSignatureOption, log *zap.SugaredLogger) (string, error)
 }
 
 func NewV2Service(provider string, tlsEnabled bool, tlsCert string) Service {
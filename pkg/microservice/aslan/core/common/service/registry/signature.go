@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/ocischema"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// signatures created by cosign are stored as an OCI image tagged with the digest of the signed image
+	cosignSignatureTagSuffix     = ".sig"
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation    = "dev.cosignproject.cosign/signature"
+)
+
+type VerifyImageSignatureOption struct {
+	Endpoint
+	Image string
+	Tag   string
+	// Digest takes precedence over Tag if it is set
+	Digest    string
+	PublicKey string
+}
+
+// simpleSigningPayload is the payload signed by cosign, only the fields needed for verification are kept
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+func (s *v2RegistryService) VerifyImageSignature(option VerifyImageSignatureOption, log *zap.SugaredLogger) (string, error) {
+	publicKey, err := ParsePublicKey(option.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	cli, err := s.createClient(option.Endpoint, log)
+	if err != nil {
+		return "", err
+	}
+
+	repoName := strings.Trim(strings.Join([]string{option.Namespace, option.Image}, "/"), "/")
+	repo, err := cli.getRepository(repoName)
+	if err != nil {
+		return "", err
+	}
+
+	var dgst digest.Digest
+	if option.Digest != "" {
+		dgst, err = digest.Parse(option.Digest)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		desc, err := repo.Tags(cli.ctx).Get(cli.ctx, option.Tag)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get digest of %s:%s", repoName, option.Tag)
+		}
+		dgst = desc.Digest
+	}
+
+	manifestService, err := repo.Manifests(cli.ctx)
+	if err != nil {
+		return "", err
+	}
+	sigTag := strings.Replace(dgst.String(), ":", "-", 1) + cosignSignatureTagSuffix
+	m, err := manifestService.Get(cli.ctx, "", distribution.WithTag(sigTag))
+	if err != nil {
+		if isRegistryImageNotFound(err) {
+			return "", fmt.Errorf("no signature found for %s@%s", repoName, dgst)
+		}
+		return "", errors.Wrapf(err, "failed to get signature of %s@%s", repoName, dgst)
+	}
+	sigManifest, ok := m.(*ocischema.DeserializedManifest)
+	if !ok {
+		return "", fmt.Errorf("signature of %s@%s is not an oci manifest", repoName, dgst)
+	}
+
+	blobService := repo.Blobs(cli.ctx)
+	for _, layer := range sigManifest.Layers {
+		signature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if layer.MediaType != cosignSimpleSigningMediaType || !ok {
+			continue
+		}
+		payload, err := blobService.Get(cli.ctx, layer.Digest)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get signature payload of %s@%s", repoName, dgst)
+		}
+		if err := VerifySimpleSigning(payload, signature, publicKey, dgst); err != nil {
+			log.Debugf("signature in layer %s of %s@%s is not valid: %s", layer.Digest, repoName, dgst, err)
+			continue
+		}
+		return dgst.String(), nil
+	}
+
+	return "", fmt.Errorf("no valid signature found for %s@%s", repoName, dgst)
+}
+
+// VerifyImageSignature of swr uses the registry api since the signatures are stored as normal images
+func (s *swrService) VerifyImageSignature(option VerifyImageSignatureOption, log *zap.SugaredLogger) (string, error) {
+	return (&v2RegistryService{EnableHTTPS: true}).VerifyImageSignature(option, log)
+}
+
+// VerifyImageSignature of ecr uses the registry api with the temporary credential since the signatures are stored as normal images
+func (s *ecrService) VerifyImageSignature(option VerifyImageSignatureOption, log *zap.SugaredLogger) (string, error) {
+	return (&v2RegistryService{EnableHTTPS: true}).VerifyImageSignature(option, log)
+}
+
+// ParsePublicKey parses a PEM encoded public key, the key format generated by `cosign generate-key-pair` is supported.
+func ParsePublicKey(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(key)))
+	if block == nil {
+		return nil, errors.New("invalid public key, PEM block not found")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	return publicKey, nil
+}
+
+// VerifySimpleSigning verifies the base64 encoded signature of the simple signing payload and makes sure that
+// the payload is signed for the given manifest digest.
+func VerifySimpleSigning(payload []byte, signature string, publicKey crypto.PublicKey, dgst digest.Digest) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature encoding")
+	}
+
+	hash := sha256.Sum256(payload)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], sig) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], sig); err != nil {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	p := &simpleSigningPayload{}
+	if err := json.Unmarshal(payload, p); err != nil {
+		return errors.Wrap(err, "invalid signature payload")
+	}
+	if p.Critical.Image.DockerManifestDigest != dgst.String() {
+		return fmt.Errorf("signature is for %s, not %s", p.Critical.Image.DockerManifestDigest, dgst)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySimpleSigning(t *testing.T) {
+	assert := require.New(t)
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(err)
+	publicKey, err := ParsePublicKey(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	assert.NoError(err)
+
+	dgst := digest.FromString("manifest")
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"koderover.io/zadig/aslan"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, dgst))
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+	assert.NoError(err)
+	signature := base64.StdEncoding.EncodeToString(sig)
+
+	assert.NoError(VerifySimpleSigning(payload, signature, publicKey, dgst))
+	// the signature is bound to the digest of the signed image
+	assert.Error(VerifySimpleSigning(payload, signature, publicKey, digest.FromString("another manifest")))
+	// the payload can not be modified
+	assert.Error(VerifySimpleSigning(append(payload, ' '), signature, publicKey, dgst))
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+	assert.Error(VerifySimpleSigning(payload, signature, &otherKey.PublicKey, dgst))
+}
+
+func TestParsePublicKey(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := ParsePublicKey("not a key")
+	assert.Error(err)
+	_, err = ParsePublicKey("-----BEGIN PUBLIC KEY-----\naW52YWxpZA==\n-----END PUBLIC KEY-----")
+	assert.Error(err)
+}
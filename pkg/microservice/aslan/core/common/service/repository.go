@@ -32,6 +32,8 @@ import (
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	"github.com/koderover/zadig/v2/pkg/tool/gerrit"
+	bitbuckettool "github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+	giteatool "github.com/koderover/zadig/v2/pkg/tool/git/gitea"
 	"github.com/koderover/zadig/v2/pkg/tool/gitee"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/types"
@@ -190,6 +192,80 @@ func FillRepositoryInfo(repo *types.Repository) error {
 				return nil
 			}
 		}
+	} else if codeHostInfo.Type == systemconfig.GiteaProvider {
+		if repo.CommitID != "" {
+			return nil
+		}
+		gitCli, err := giteatool.NewClient(codeHostInfo.ID, codeHostInfo.Address, codeHostInfo.AccessToken, config.ProxyHTTPSAddr(), codeHostInfo.EnableProxy, codeHostInfo.DisableSSL)
+		if err != nil {
+			return fmt.Errorf("failed to create gitea client, err: %s", err)
+		}
+
+		var ref string
+		if repo.Tag != "" && len(repo.PRs) == 0 {
+			ref = repo.Tag
+		} else if repo.Branch != "" && len(repo.PRs) == 0 {
+			ref = repo.Branch
+		} else if len(repo.PRs) > 0 {
+			pr, err := gitCli.GetPullRequest(repo.GetRepoNamespace(), repo.RepoName, getLatestPrNum(repo))
+			if err != nil || pr.Head == nil {
+				log.Warnf("gitea setBuildInfo failed, use build %+v, err: %v", repo, err)
+				return nil
+			}
+			ref = pr.Head.Sha
+		} else {
+			return nil
+		}
+
+		commit, err := gitCli.GetLatestRepositoryCommit(repo.GetRepoNamespace(), repo.RepoName, "", ref)
+		if err != nil {
+			log.Warnf("gitea setBuildInfo failed, use build %+v, err: %s", repo, err)
+			return nil
+		}
+		repo.CommitID = commit.SHA
+		if commit.Commit != nil {
+			repo.CommitMessage = commit.Commit.Message
+			if commit.Commit.Author != nil {
+				repo.AuthorName = commit.Commit.Author.Name
+			}
+		}
+	} else if codeHostInfo.Type == systemconfig.BitbucketProvider {
+		gitCli, err := bitbuckettool.NewClient(codeHostInfo.Address, codeHostInfo.Username, codeHostInfo.Password, codeHostInfo.AccessToken, config.ProxyHTTPSAddr(), codeHostInfo.EnableProxy, codeHostInfo.DisableSSL)
+		if err != nil {
+			return fmt.Errorf("failed to create bitbucket client, err: %s", err)
+		}
+
+		var ref string
+		if repo.Tag != "" && len(repo.PRs) == 0 {
+			ref = repo.Tag
+		} else if repo.Branch != "" && len(repo.PRs) == 0 {
+			ref = repo.Branch
+		} else if len(repo.PRs) > 0 {
+			pr, err := gitCli.GetPullRequest(repo.GetRepoNamespace(), repo.RepoName, getLatestPrNum(repo))
+			if err != nil {
+				log.Warnf("bitbucket setBuildInfo failed, use build %+v, err: %v", repo, err)
+				return nil
+			}
+			// bitbucket cloud does not provide refs for pull requests, the source branch is checked out instead
+			if gitCli.Cloud && repo.CheckoutRef == "" {
+				repo.CheckoutRef = types.BranchRef(pr.SourceBranch)
+			}
+			ref = pr.SourceCommit
+		} else {
+			return nil
+		}
+		if repo.CommitID != "" {
+			return nil
+		}
+
+		commit, err := gitCli.GetCommit(repo.GetRepoNamespace(), repo.RepoName, ref)
+		if err != nil {
+			log.Warnf("bitbucket setBuildInfo failed, use build %+v, err: %s", repo, err)
+			return nil
+		}
+		repo.CommitID = commit.ID
+		repo.CommitMessage = commit.Message
+		repo.AuthorName = commit.Author
 	} else if codeHostInfo.Type == systemconfig.OtherProvider {
 		repo.SSHKey = codeHostInfo.SSHKey
 		repo.PrivateAccessToken = codeHostInfo.PrivateAccessToken
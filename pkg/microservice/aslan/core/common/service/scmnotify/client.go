@@ -36,6 +36,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	"github.com/koderover/zadig/v2/pkg/tool/gerrit"
+	giteatool "github.com/koderover/zadig/v2/pkg/tool/git/gitea"
 	gitlabtool "github.com/koderover/zadig/v2/pkg/tool/git/gitlab"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
@@ -49,12 +50,18 @@ func NewClient() *Client {
 }
 
 func (c *Client) UpsertAIReviewComment(codehostID int, projectID, repoOwner, repoName string, prID int, comment string) error {
+	return c.UpsertMarkedComment(codehostID, projectID, repoOwner, repoName, prID, aiReviewCommentMarker, comment)
+}
+
+// UpsertMarkedComment updates the pull/merge request comment containing the marker, or creates it if there is none,
+// so a report published on every push stays a single comment.
+func (c *Client) UpsertMarkedComment(codehostID int, projectID, repoOwner, repoName string, prID int, marker, comment string) error {
 	if prID <= 0 {
 		return fmt.Errorf("invalid pull/merge request ID %d", prID)
 	}
 	codeHostDetail, err := systemconfig.New().GetCodeHost(codehostID)
 	if err != nil {
-		return errors.Wrapf(err, "codehost %d not found to publish AI review result", codehostID)
+		return errors.Wrapf(err, "codehost %d not found to publish comment", codehostID)
 	}
 
 	switch strings.ToLower(codeHostDetail.Type) {
@@ -70,7 +77,7 @@ func (c *Client) UpsertAIReviewComment(codehostID int, projectID, repoOwner, rep
 		if err != nil {
 			return fmt.Errorf("create gitlab client: %w", err)
 		}
-		return c.upsertGitLabAIReviewComment(cli.Client, projectID, prID, comment)
+		return c.upsertGitLabMarkedComment(cli.Client, projectID, prID, marker, comment)
 	case setting.SourceFromGithub:
 		cli, err := githubservice.GetGithubAppClientByOwner(repoOwner)
 		if err != nil {
@@ -79,13 +86,13 @@ func (c *Client) UpsertAIReviewComment(codehostID int, projectID, repoOwner, rep
 		if cli == nil {
 			cli = githubservice.NewClient(codeHostDetail.AccessToken, config.ProxyHTTPSAddr(), codeHostDetail.EnableProxy)
 		}
-		return c.upsertGitHubAIReviewComment(cli.Client.Client, repoOwner, repoName, prID, comment)
+		return c.upsertGitHubMarkedComment(cli.Client.Client, repoOwner, repoName, prID, marker, comment)
 	default:
-		return fmt.Errorf("codehost type %q does not support AI review comments", codeHostDetail.Type)
+		return fmt.Errorf("codehost type %q does not support pull request comments", codeHostDetail.Type)
 	}
 }
 
-func (c *Client) upsertGitHubAIReviewComment(cli *githubapi.Client, repoOwner, repoName string, prID int, comment string) error {
+func (c *Client) upsertGitHubMarkedComment(cli *githubapi.Client, repoOwner, repoName string, prID int, marker, comment string) error {
 	ctx := context.Background()
 	options := &githubapi.IssueListCommentsOptions{
 		Sort:      githubapi.String("updated"),
@@ -97,11 +104,11 @@ func (c *Client) upsertGitHubAIReviewComment(cli *githubapi.Client, repoOwner, r
 	for {
 		comments, resp, err := cli.Issues.ListComments(ctx, repoOwner, repoName, prID, options)
 		if err != nil {
-			c.logAIReviewCommentFallback("list GitHub pull request comments", err)
-			return createGitHubAIReviewComment(ctx, cli, repoOwner, repoName, prID, comment)
+			c.logCommentFallback("list GitHub pull request comments", err)
+			return createGitHubComment(ctx, cli, repoOwner, repoName, prID, comment)
 		}
 		for _, existingComment := range comments {
-			if existingComment == nil || !strings.Contains(existingComment.GetBody(), aiReviewCommentMarker) {
+			if existingComment == nil || !strings.Contains(existingComment.GetBody(), marker) {
 				continue
 			}
 			_, _, err = cli.Issues.EditComment(
@@ -114,18 +121,18 @@ func (c *Client) upsertGitHubAIReviewComment(cli *githubapi.Client, repoOwner, r
 			if err == nil {
 				return nil
 			}
-			c.logAIReviewCommentFallback("update GitHub pull request comment", err)
-			return createGitHubAIReviewComment(ctx, cli, repoOwner, repoName, prID, comment)
+			c.logCommentFallback("update GitHub pull request comment", err)
+			return createGitHubComment(ctx, cli, repoOwner, repoName, prID, comment)
 		}
 		if resp == nil || resp.NextPage == 0 {
 			break
 		}
 		options.Page = resp.NextPage
 	}
-	return createGitHubAIReviewComment(ctx, cli, repoOwner, repoName, prID, comment)
+	return createGitHubComment(ctx, cli, repoOwner, repoName, prID, comment)
 }
 
-func createGitHubAIReviewComment(ctx context.Context, cli *githubapi.Client, repoOwner, repoName string, prID int, comment string) error {
+func createGitHubComment(ctx context.Context, cli *githubapi.Client, repoOwner, repoName string, prID int, comment string) error {
 	_, _, err := cli.Issues.CreateComment(
 		ctx,
 		repoOwner,
@@ -139,7 +146,7 @@ func createGitHubAIReviewComment(ctx context.Context, cli *githubapi.Client, rep
 	return nil
 }
 
-func (c *Client) upsertGitLabAIReviewComment(cli *gitlab.Client, projectID string, prID int, comment string) error {
+func (c *Client) upsertGitLabMarkedComment(cli *gitlab.Client, projectID string, prID int, marker, comment string) error {
 	options := &gitlab.ListMergeRequestNotesOptions{
 		OrderBy: gitlab.String("updated_at"),
 		Sort:    gitlab.String("desc"),
@@ -150,11 +157,11 @@ func (c *Client) upsertGitLabAIReviewComment(cli *gitlab.Client, projectID strin
 	for {
 		notes, resp, err := cli.Notes.ListMergeRequestNotes(projectID, prID, options)
 		if err != nil {
-			c.logAIReviewCommentFallback("list GitLab merge request notes", err)
-			return createGitLabAIReviewComment(cli, projectID, prID, comment)
+			c.logCommentFallback("list GitLab merge request notes", err)
+			return createGitLabComment(cli, projectID, prID, comment)
 		}
 		for _, note := range notes {
-			if note == nil || !strings.Contains(note.Body, aiReviewCommentMarker) {
+			if note == nil || !strings.Contains(note.Body, marker) {
 				continue
 			}
 			_, _, err = cli.Notes.UpdateMergeRequestNote(
@@ -166,18 +173,18 @@ func (c *Client) upsertGitLabAIReviewComment(cli *gitlab.Client, projectID strin
 			if err == nil {
 				return nil
 			}
-			c.logAIReviewCommentFallback("update GitLab merge request note", err)
-			return createGitLabAIReviewComment(cli, projectID, prID, comment)
+			c.logCommentFallback("update GitLab merge request note", err)
+			return createGitLabComment(cli, projectID, prID, comment)
 		}
 		if resp == nil || resp.NextPage == 0 {
 			break
 		}
 		options.Page = resp.NextPage
 	}
-	return createGitLabAIReviewComment(cli, projectID, prID, comment)
+	return createGitLabComment(cli, projectID, prID, comment)
 }
 
-func createGitLabAIReviewComment(cli *gitlab.Client, projectID string, prID int, comment string) error {
+func createGitLabComment(cli *gitlab.Client, projectID string, prID int, comment string) error {
 	_, _, err := cli.Notes.CreateMergeRequestNote(
 		projectID,
 		prID,
@@ -189,9 +196,9 @@ func createGitLabAIReviewComment(cli *gitlab.Client, projectID string, prID int,
 	return nil
 }
 
-func (c *Client) logAIReviewCommentFallback(operation string, err error) {
+func (c *Client) logCommentFallback(operation string, err error) {
 	if c.logger != nil {
-		c.logger.Warnf("failed to %s, fallback to creating a new AI review comment: %v", operation, err)
+		c.logger.Warnf("failed to %s, fallback to creating a new comment: %v", operation, err)
 	}
 }
 
@@ -336,6 +343,30 @@ func (c *Client) Comment(notify *models.Notification) error {
 		if err != nil {
 			return fmt.Errorf("failed to comment gitee due to %s/%d %v", notify.ProjectID, notify.PrID, err)
 		}
+	} else if strings.ToLower(codeHostDetail.Type) == setting.SourceFromGitea {
+		cli, err := giteatool.NewClient(codeHostDetail.ID, codeHostDetail.Address, codeHostDetail.AccessToken, config.ProxyHTTPSAddr(), codeHostDetail.EnableProxy, codeHostDetail.DisableSSL)
+		if err != nil {
+			return fmt.Errorf("create gitea client failed err: %v", err)
+		}
+		if notify.CommentID == "" {
+			// create comment
+			var issueComment *giteatool.Comment
+			issueComment, err = cli.CreateIssueComment(notify.RepoOwner, notify.RepoName, notify.PrID, comment)
+			if err == nil {
+				notify.CommentID = strconv.FormatInt(issueComment.ID, 10)
+			}
+		} else {
+			// update comment
+			commentID, err := strconv.ParseInt(notify.CommentID, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse commentID %v, err: %s", notify.CommentID, err)
+			}
+			_, err = cli.EditIssueComment(notify.RepoOwner, notify.RepoName, commentID, comment)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to comment gitea due to %s/%s/%d %v", notify.RepoOwner, notify.RepoName, notify.PrID, err)
+		}
 	} else {
 		return fmt.Errorf("non gitlab source not supported to comment")
 	}
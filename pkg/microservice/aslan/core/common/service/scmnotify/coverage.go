@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scmnotify
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+)
+
+// maxCoverageCommentFiles is the max number of changed files listed in the coverage comment
+const maxCoverageCommentFiles = 20
+
+// CoverageReport is the coverage of a testing run on a pull request and its delta against the base branch.
+type CoverageReport struct {
+	TestName      string
+	ServiceName   string
+	ServiceModule string
+	BaseBranch    string
+	CommitID      string
+	Summary       *coverage.Summary
+	// Base is nil if there is no report of the base branch yet
+	Base         *coverage.Summary
+	GateFailures []string
+	Files        []*coverage.FileDelta
+}
+
+func (s *Service) PublishCoverageReport(codehostID int, repoOwner, repoName string, prID int, report *CoverageReport, logger *zap.SugaredLogger) error {
+	if report == nil || report.Summary == nil || prID <= 0 {
+		return nil
+	}
+	projectID := strings.TrimLeft(repoOwner+"/"+repoName, "/")
+	// every testing of the pull request keeps its own comment
+	marker := fmt.Sprintf("<!-- zadig-coverage:%s/%s/%s -->", report.TestName, report.ServiceName, report.ServiceModule)
+	comment := formatCoverageComment(report) + "\n" + marker
+	if err := s.Client.UpsertMarkedComment(codehostID, projectID, repoOwner, repoName, prID, marker, comment); err != nil {
+		return fmt.Errorf("publish coverage report: %w", err)
+	}
+	logger.Infof("published coverage report of testing %s to %s #%d", report.TestName, projectID, prID)
+	return nil
+}
+
+func formatCoverageComment(report *CoverageReport) string {
+	status := "✅ 覆盖率检查通过"
+	if len(report.GateFailures) > 0 {
+		status = "❌ 覆盖率检查未通过"
+	}
+
+	var builder strings.Builder
+	title := report.TestName
+	if report.ServiceName != "" {
+		title = fmt.Sprintf("%s (%s/%s)", report.TestName, report.ServiceName, report.ServiceModule)
+	}
+	fmt.Fprintf(&builder, "## Zadig Coverage: %s\n\n", markdownText(title))
+	fmt.Fprintf(&builder, "**%s**\n\n", status)
+	for _, reason := range report.GateFailures {
+		fmt.Fprintf(&builder, "- %s\n", markdownText(reason))
+	}
+	if len(report.GateFailures) > 0 {
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("| | 当前 | " + baseColumnTitle(report) + " | 变化 |\n| --- | --- | --- | --- |\n")
+	fmt.Fprintf(&builder, "| 行覆盖率 | %s | %s | %s |\n",
+		formatRate(report.Summary.LineRate, report.Summary.LinesCovered, report.Summary.LinesTotal),
+		formatBaseRate(report.Base, true),
+		formatDelta(report.Base, report.Summary.LineRate, true),
+	)
+	if report.Summary.BranchesTotal > 0 {
+		fmt.Fprintf(&builder, "| 分支覆盖率 | %s | %s | %s |\n",
+			formatRate(report.Summary.BranchRate, report.Summary.BranchesCovered, report.Summary.BranchesTotal),
+			formatBaseRate(report.Base, false),
+			formatDelta(report.Base, report.Summary.BranchRate, false),
+		)
+	}
+	if report.CommitID != "" {
+		fmt.Fprintf(&builder, "\n- Commit：`%s`\n", markdownInline(report.CommitID))
+	}
+
+	if report.Base != nil && len(report.Files) > 0 {
+		builder.WriteString("\n### 覆盖率变化的文件\n\n| 文件 | 当前 | 基准 | 变化 |\n| --- | --- | --- | --- |\n")
+		for i, file := range report.Files {
+			if i >= maxCoverageCommentFiles {
+				fmt.Fprintf(&builder, "\n另有 %d 个文件的覆盖率发生变化。\n", len(report.Files)-maxCoverageCommentFiles)
+				break
+			}
+			base := fmt.Sprintf("%.2f%%", file.BaseLineRate)
+			if file.New {
+				base = "新文件"
+			}
+			fmt.Fprintf(&builder, "| `%s` | %.2f%% | %s | %+.2f%% |\n", markdownInline(file.Path), file.LineRate, base, file.Delta)
+		}
+	}
+	return builder.String()
+}
+
+func baseColumnTitle(report *CoverageReport) string {
+	if report.BaseBranch == "" {
+		return "基准"
+	}
+	return fmt.Sprintf("基准 (`%s`)", markdownInline(report.BaseBranch))
+}
+
+func formatRate(rate float64, covered, total int) string {
+	return fmt.Sprintf("%.2f%% (%d/%d)", rate, covered, total)
+}
+
+func formatBaseRate(base *coverage.Summary, line bool) string {
+	switch {
+	case base == nil:
+		return "-"
+	case line:
+		return formatRate(base.LineRate, base.LinesCovered, base.LinesTotal)
+	case base.BranchesTotal == 0:
+		return "-"
+	default:
+		return formatRate(base.BranchRate, base.BranchesCovered, base.BranchesTotal)
+	}
+}
+
+func formatDelta(base *coverage.Summary, rate float64, line bool) string {
+	if base == nil || (!line && base.BranchesTotal == 0) {
+		return "-"
+	}
+	baseRate := base.LineRate
+	if !line {
+		baseRate = base.BranchRate
+	}
+	delta := rate - baseRate
+	switch {
+	case delta > 0.005:
+		return fmt.Sprintf("🔼 %+.2f%%", delta)
+	case delta < -0.005:
+		return fmt.Sprintf("🔽 %+.2f%%", delta)
+	default:
+		return "0.00%"
+	}
+}
@@ -30,10 +30,15 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/task"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	bitbucketservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/bitbucket"
+	giteaservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitea"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/github"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
 	"github.com/koderover/zadig/v2/pkg/util"
 )
@@ -686,6 +691,15 @@ func (s *Service) CreateGitCheckForWorkflowV4(workflowArgs *models.WorkflowV4, t
 		return nil
 	}
 
+	if ch, err := systemconfig.New().GetCodeHost(hook.CodehostID); err == nil {
+		switch ch.Type {
+		case setting.SourceFromGitea:
+			return updateGiteaCheckStatus(ch, workflowArgs, taskID, gitea.StatusPending, fmt.Sprintf("Workflow [%s] is queued.", workflowArgs.DisplayName))
+		case setting.SourceFromBitbucket:
+			return updateBitbucketBuildStatus(ch, workflowArgs, taskID, bitbucket.BuildStateInProgress, fmt.Sprintf("Workflow [%s] is queued.", workflowArgs.DisplayName))
+		}
+	}
+
 	ghApp, err := github.GetGithubAppClientByOwner(hook.Owner)
 	if err != nil {
 		log.Errorf("getGithubAppClient failed, err:%v", err)
@@ -745,6 +759,15 @@ func (s *Service) UpdateGitCheckForWorkflowV4(workflowArgs *models.WorkflowV4, t
 		return nil
 	}
 
+	if ch, err := systemconfig.New().GetCodeHost(hook.CodehostID); err == nil {
+		switch ch.Type {
+		case setting.SourceFromGitea:
+			return updateGiteaCheckStatus(ch, workflowArgs, taskID, gitea.StatusPending, fmt.Sprintf("Workflow [%s] is running.", workflowArgs.DisplayName))
+		case setting.SourceFromBitbucket:
+			return updateBitbucketBuildStatus(ch, workflowArgs, taskID, bitbucket.BuildStateInProgress, fmt.Sprintf("Workflow [%s] is running.", workflowArgs.DisplayName))
+		}
+	}
+
 	ghApp, err := github.GetGithubAppClientByOwner(hook.Owner)
 	if err != nil {
 		log.Errorf("getGithubAppClient failed, err:%v", err)
@@ -805,6 +828,15 @@ func (s *Service) CompleteGitCheckForWorkflowV4(workflowArgs *models.WorkflowV4,
 		return nil
 	}
 
+	if ch, err := systemconfig.New().GetCodeHost(hook.CodehostID); err == nil {
+		switch ch.Type {
+		case setting.SourceFromGitea:
+			return updateGiteaCheckStatus(ch, workflowArgs, taskID, getGiteaStatusFromCIStatus(getCheckStatus(status)), fmt.Sprintf("Workflow [%s] is %s.", workflowArgs.DisplayName, getCheckStatus(status)))
+		case setting.SourceFromBitbucket:
+			return updateBitbucketBuildStatus(ch, workflowArgs, taskID, getBitbucketStateFromCIStatus(getCheckStatus(status)), fmt.Sprintf("Workflow [%s] is %s.", workflowArgs.DisplayName, getCheckStatus(status)))
+		}
+	}
+
 	ghApp, err := github.GetGithubAppClientByOwner(hook.Owner)
 	if err != nil {
 		log.Errorf("getGithubAppClient failed, err:%v", err)
@@ -891,6 +923,74 @@ func getGitHubStatusFromCIStatus(status github.CIStatus) string {
 	}
 }
 
+func getGiteaStatusFromCIStatus(status github.CIStatus) gitea.StatusState {
+	switch status {
+	case github.CIStatusSuccess:
+		return gitea.StatusSuccess
+	case github.CIStatusFailure, github.CIStatusTimeout:
+		return gitea.StatusFailure
+	case github.CIStatusCancelled, github.CIStatusRejected:
+		return gitea.StatusWarning
+	default:
+		return gitea.StatusError
+	}
+}
+
+func updateGiteaCheckStatus(ch *systemconfig.CodeHost, workflowArgs *models.WorkflowV4, taskID int64, state gitea.StatusState, description string) error {
+	hook := workflowArgs.HookPayload
+	cli, err := giteaservice.NewClient(ch.ID, ch.Address, ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy, ch.DisableSSL)
+	if err != nil {
+		return e.ErrGithubUpdateStatus.AddErr(err)
+	}
+
+	return cli.UpdateCheckStatus(&giteaservice.StatusOptions{
+		Owner:       hook.Owner,
+		Repo:        hook.Repo,
+		Ref:         hook.Ref,
+		State:       state,
+		Description: description,
+		AslanURL:    configbase.SystemAddress(),
+		PipeName:    workflowArgs.Name,
+		DisplayName: getDisplayName(workflowArgs),
+		PipeType:    config.WorkflowTypeV4,
+		ProductName: workflowArgs.Project,
+		TaskID:      taskID,
+	})
+}
+
+func getBitbucketStateFromCIStatus(status github.CIStatus) bitbucket.BuildState {
+	switch status {
+	case github.CIStatusSuccess:
+		return bitbucket.BuildStateSuccessful
+	case github.CIStatusCancelled, github.CIStatusRejected:
+		return bitbucket.BuildStateStopped
+	default:
+		return bitbucket.BuildStateFailed
+	}
+}
+
+func updateBitbucketBuildStatus(ch *systemconfig.CodeHost, workflowArgs *models.WorkflowV4, taskID int64, state bitbucket.BuildState, description string) error {
+	hook := workflowArgs.HookPayload
+	cli, err := bitbucketservice.NewClient(ch, config.ProxyHTTPSAddr())
+	if err != nil {
+		return e.ErrGithubUpdateStatus.AddErr(err)
+	}
+
+	return cli.UpdateBuildStatus(&bitbucketservice.StatusOptions{
+		Owner:       hook.Owner,
+		Repo:        hook.Repo,
+		Ref:         hook.Ref,
+		State:       state,
+		Description: description,
+		AslanURL:    configbase.SystemAddress(),
+		PipeName:    workflowArgs.Name,
+		DisplayName: getDisplayName(workflowArgs),
+		PipeType:    config.WorkflowTypeV4,
+		ProductName: workflowArgs.Project,
+		TaskID:      taskID,
+	})
+}
+
 func getDisplayName(args *models.WorkflowV4) string {
 	if args.DisplayName != "" {
 		return args.DisplayName
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scmnotify
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const workflowLintCommentMarker = "<!-- zadig-workflow-lint -->"
+
+// WorkflowLintReport is the dry-run result of syncing the workflow definitions of a pull request.
+type WorkflowLintReport struct {
+	ProjectName string
+	CommitSHA   string
+	Changes     []*WorkflowLintChange
+	Errors      []*WorkflowLintError
+}
+
+type WorkflowLintChange struct {
+	WorkflowName string
+	Path         string
+	Action       string
+	DiffCount    int
+}
+
+type WorkflowLintError struct {
+	WorkflowName string
+	Path         string
+	Error        string
+}
+
+func (s *Service) PublishWorkflowLintReport(codehostID int, repoOwner, repoName string, prID int, report *WorkflowLintReport, logger *zap.SugaredLogger) error {
+	if report == nil || prID <= 0 {
+		return nil
+	}
+	projectID := strings.TrimLeft(repoOwner+"/"+repoName, "/")
+	comment := formatWorkflowLintComment(report)
+	if err := s.Client.UpsertMarkedComment(codehostID, projectID, repoOwner, repoName, prID, workflowLintCommentMarker, comment); err != nil {
+		return fmt.Errorf("publish workflow lint result: %w", err)
+	}
+	logger.Infof("published workflow lint result of project %s to %s #%d", report.ProjectName, projectID, prID)
+	return nil
+}
+
+func formatWorkflowLintComment(report *WorkflowLintReport) string {
+	status := "✅ 工作流定义校验通过"
+	if len(report.Errors) > 0 {
+		status = "❌ 工作流定义校验失败，合并后不会同步"
+	}
+
+	var builder strings.Builder
+	builder.WriteString("## Zadig Workflow Lint\n\n")
+	fmt.Fprintf(&builder, "**%s**\n\n", status)
+	fmt.Fprintf(&builder, "- 项目：`%s`\n- Commit：`%s`\n", markdownInline(report.ProjectName), markdownInline(report.CommitSHA))
+
+	if len(report.Errors) > 0 {
+		builder.WriteString("\n### Errors\n\n| 文件 | 工作流 | 错误 |\n| --- | --- | --- |\n")
+		for _, lintErr := range report.Errors {
+			fmt.Fprintf(&builder, "| `%s` | %s | %s |\n", markdownInline(lintErr.Path), markdownTableCell(lintErr.WorkflowName), markdownTableCell(lintErr.Error))
+		}
+	}
+
+	changed := 0
+	var changes strings.Builder
+	for _, change := range report.Changes {
+		if change.Action == "unchanged" {
+			continue
+		}
+		changed++
+		fmt.Fprintf(&changes, "| `%s` | %s | %s | %d |\n", markdownInline(change.Path), markdownTableCell(change.WorkflowName), change.Action, change.DiffCount)
+	}
+	if changed == 0 {
+		builder.WriteString("\n合并后工作流不会发生变化。\n")
+	} else {
+		builder.WriteString("\n### 合并后的变更\n\n| 文件 | 工作流 | 操作 | 变更项 |\n| --- | --- | --- | --- |\n")
+		builder.WriteString(changes.String())
+	}
+
+	builder.WriteString("\n" + workflowLintCommentMarker)
+	return builder.String()
+}
+
+func markdownTableCell(value string) string {
+	return strings.ReplaceAll(singleLineText(markdownText(value)), "|", "\\|")
+}
@@ -1455,7 +1455,12 @@ func GetServiceImpl(serviceName string, serviceTmpl *commonmodels.Service, workL
 			return nil, e.ErrGetService.AddDesc(fmt.Sprintf("failed to find service in environment: %s", envName))
 		}
 
-		parsedYaml, err := kube.RenderServiceYaml(serviceTmpl.Yaml, productName, serviceTmpl.ServiceName, service.GetServiceRender())
+		svcYaml, err := kube.ServiceTemplateYaml(serviceTmpl, envName)
+		if err != nil {
+			log.Errorf("failed to get service yaml, err: %s", err)
+			return nil, err
+		}
+		parsedYaml, err := kube.RenderServiceYaml(svcYaml, productName, serviceTmpl.ServiceName, service.GetServiceRender())
 		if err != nil {
 			log.Errorf("failed to render service yaml, err: %s", err)
 			return nil, err
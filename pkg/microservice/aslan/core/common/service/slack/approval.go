@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	approvalservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/approval"
+	"github.com/koderover/zadig/v2/pkg/shared/client/user"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/slack"
+)
+
+const (
+	ApprovalActionApprove = "zadig_approval_approve"
+	ApprovalActionReject  = "zadig_approval_reject"
+
+	approvalBlockID = "zadig_approval"
+)
+
+// NewApprovalBlocks renders an approval request, the buttons carry the native approval key which
+// is all the interaction handler needs to record the decision.
+func NewApprovalBlocks(title, content, detailURL, approveKey string, mentions []string) []*slack.Block {
+	blocks := []*slack.Block{
+		slack.NewHeaderBlock(title),
+		slack.NewSectionBlock(content),
+	}
+	if len(mentions) > 0 {
+		blocks = append(blocks, slack.NewContextBlock(mentionText(mentions)))
+	}
+	blocks = append(blocks, slack.NewActionsBlock(approvalBlockID,
+		slack.NewButton("Approve", ApprovalActionApprove, approveKey, slack.ButtonStylePrimary),
+		slack.NewButton("Reject", ApprovalActionReject, approveKey, slack.ButtonStyleDanger),
+		slack.NewLinkButton("View in Zadig", detailURL),
+	))
+	return blocks
+}
+
+// NewApprovalResultBlocks replaces the buttons of a finished approval with its result and the
+// decisions of every approver.
+func NewApprovalResultBlocks(title, content, detailURL string, status config.Status, approval *models.NativeApproval) []*slack.Block {
+	blocks := []*slack.Block{
+		slack.NewHeaderBlock(title),
+		slack.NewSectionBlock(content),
+		slack.NewDividerBlock(),
+		slack.NewSectionBlock(fmt.Sprintf("*Result:* %s", status)),
+	}
+	if approval != nil {
+		decisions := make([]string, 0)
+		for _, u := range approval.ApproveUsers {
+			if u.RejectOrApprove == "" {
+				continue
+			}
+			decision := fmt.Sprintf("%s: %s", u.UserName, u.RejectOrApprove)
+			if u.Comment != "" {
+				decision += fmt.Sprintf(" (%s)", u.Comment)
+			}
+			decisions = append(decisions, decision)
+		}
+		if len(decisions) > 0 {
+			blocks = append(blocks, slack.NewContextBlock(strings.Join(decisions, "\n")))
+		}
+	}
+	blocks = append(blocks, slack.NewActionsBlock("", slack.NewLinkButton("View in Zadig", detailURL)))
+	return blocks
+}
+
+func mentionText(slackUserIDs []string) string {
+	mentions := make([]string, 0, len(slackUserIDs))
+	for _, id := range slackUserIDs {
+		mentions = append(mentions, slack.Mention(id))
+	}
+	return strings.Join(mentions, " ")
+}
+
+// HandleInteraction verifies and handles the interactivity request slack sends when a button of
+// an approval message is clicked.
+func HandleInteraction(appID string, body []byte, timestamp, signature string) error {
+	app, err := GetSlackIMApp(appID)
+	if err != nil {
+		return err
+	}
+	if err := slack.VerifySignature(app.SlackSigningSecret, timestamp, signature, body, time.Now()); err != nil {
+		return errors.Wrap(err, "verify slack request")
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return errors.Wrap(err, "parse slack interaction request")
+	}
+	payload := new(slack.InteractionPayload)
+	if err := json.Unmarshal([]byte(form.Get("payload")), payload); err != nil {
+		return errors.Wrap(err, "decode slack interaction payload")
+	}
+	if payload.Type != slack.InteractionTypeBlockActions || payload.User == nil {
+		return nil
+	}
+
+	client := slack.NewClient(app.SlackBotToken)
+	for _, action := range payload.Actions {
+		var approve bool
+		switch action.ActionID {
+		case ApprovalActionApprove:
+			approve = true
+		case ApprovalActionReject:
+			approve = false
+		default:
+			continue
+		}
+
+		if err := handleApprovalAction(client, payload.User.ID, action.Value, approve); err != nil {
+			log.Warnf("slack user %s failed to handle approval %s, error: %s", payload.User.ID, action.Value, err)
+			if payload.ResponseURL != "" {
+				if respErr := slack.Respond(payload.ResponseURL, err.Error()); respErr != nil {
+					log.Errorf("failed to respond to slack interaction, error: %s", respErr)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// handleApprovalAction maps the slack user to one of the approvers by email and records the
+// decision through the native approval flow, which rejects users not listed as approvers.
+func handleApprovalAction(client *slack.Client, slackUserID, approveKey string, approve bool) error {
+	approval, ok := approvalservice.GlobalApproveMap.GetApproval(approveKey)
+	if !ok {
+		return fmt.Errorf("the approval has finished or expired")
+	}
+
+	slackUser, err := client.GetUserInfo(slackUserID)
+	if err != nil {
+		return err
+	}
+	if slackUser.Profile == nil || slackUser.Profile.Email == "" {
+		return fmt.Errorf("cannot read the email of your slack account")
+	}
+
+	for _, approver := range approval.ApproveUsers {
+		info, err := user.New().GetUserByID(approver.UserID)
+		if err != nil {
+			log.Warnf("failed to get approver %s, error: %s", approver.UserID, err)
+			continue
+		}
+		if !strings.EqualFold(info.Email, slackUser.Profile.Email) {
+			continue
+		}
+		_, err = approvalservice.GlobalApproveMap.DoApproval(approveKey, info.Name, approver.UserID, "", approve)
+		return err
+	}
+	return fmt.Errorf("%s is not an approver of this approval", slackUser.Profile.Email)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/koderover/zadig/v2/pkg/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/user"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/slack"
+)
+
+const slackUserCacheExpiry = 24 * time.Hour
+
+func GetSlackClientByIMAppID(id string) (*slack.Client, error) {
+	app, err := GetSlackIMApp(id)
+	if err != nil {
+		return nil, err
+	}
+	return slack.NewClient(app.SlackBotToken), nil
+}
+
+func GetSlackIMApp(id string) (*models.IMApp, error) {
+	app, err := mongodb.NewIMAppColl().GetByID(context.Background(), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get slack app data")
+	}
+	if app.Type != setting.IMSlack {
+		return nil, errors.Errorf("unexpected im app type %s", app.Type)
+	}
+	return app, nil
+}
+
+func slackUserCacheKey(appID, email string) string {
+	return fmt.Sprintf("slack-user-%s-%s", appID, email)
+}
+
+// GetSlackUserIDByEmail maps an email to the slack member id, the result is cached since slack
+// rate limits the lookup api.
+func GetSlackUserIDByEmail(appID string, client *slack.Client, email string) (string, error) {
+	redisCache := cache.NewRedisCache(config.RedisCommonCacheTokenDB())
+	key := slackUserCacheKey(appID, email)
+	if id, err := redisCache.GetString(key); err == nil && id != "" {
+		return id, nil
+	}
+
+	slackUser, err := client.LookupUserByEmail(email)
+	if err != nil {
+		return "", err
+	}
+	if err := redisCache.Write(key, slackUser.ID, slackUserCacheExpiry); err != nil {
+		log.Warnf("failed to cache slack user id of %s, error: %s", email, err)
+	}
+	return slackUser.ID, nil
+}
+
+// GetSlackUserIDs maps zadig users, user groups and plain email recipients to slack member ids.
+// Users that cannot be found in the slack workspace are skipped.
+func GetSlackUserIDs(appID string, client *slack.Client, users []*models.User) []string {
+	emails := make([]string, 0)
+	for _, u := range users {
+		if u != nil && u.Type == "email" && u.UserName != "" {
+			emails = append(emails, u.UserName)
+		}
+	}
+
+	flatUsers, userMap := util.GeneFlatUsers(users)
+	for _, u := range flatUsers {
+		info, ok := userMap[u.UserID]
+		if !ok {
+			var err error
+			info, err = user.New().GetUserByID(u.UserID)
+			if err != nil {
+				log.Warnf("failed to get user %s, error: %s", u.UserID, err)
+				continue
+			}
+		}
+		if info.Email == "" {
+			log.Warnf("user %s has no email, cannot mention it in slack", info.Name)
+			continue
+		}
+		emails = append(emails, info.Email)
+	}
+
+	resp := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, email := range emails {
+		id, err := GetSlackUserIDByEmail(appID, client, email)
+		if err != nil {
+			log.Warnf("failed to find slack user by email %s, error: %s", email, err)
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		resp = append(resp, id)
+	}
+	return resp
+}
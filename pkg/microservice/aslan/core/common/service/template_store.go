@@ -45,7 +45,7 @@ func ProcessYamlTemplateWebhook(updated, current *models.YamlTemplate, logger *z
 			namespace = current.RepoOwner
 		}
 		switch current.Source {
-		case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE:
+		case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE, setting.SourceFromGitea:
 			currentHooks = append(currentHooks, &webhook.WebHook{
 				Owner:      current.RepoOwner,
 				Namespace:  namespace,
@@ -71,7 +71,7 @@ func ProcessYamlTemplateWebhook(updated, current *models.YamlTemplate, logger *z
 			namespace = updated.RepoOwner
 		}
 		switch updated.Source {
-		case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE:
+		case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE, setting.SourceFromGitea:
 			updatedHooks = append(updatedHooks, &webhook.WebHook{
 				Owner:      updated.RepoOwner,
 				Namespace:  namespace,
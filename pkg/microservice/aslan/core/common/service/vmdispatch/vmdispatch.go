@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmdispatch
+
+import (
+	"encoding/json"
+
+	"github.com/koderover/zadig/v2/pkg/config"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+// channel is the redis pub/sub channel used to fan out vm agent dispatch events
+// to every aslan replica, since an agent is only connected to one of them.
+const channel = "vm-agent-dispatch"
+
+type EventType string
+
+const (
+	EventJobCreated    EventType = "job_created"
+	EventJobCancelled  EventType = "job_cancelled"
+	EventConfigChanged EventType = "config_changed"
+)
+
+type Event struct {
+	Type  EventType `json:"type"`
+	VMID  string    `json:"vm_id,omitempty"`
+	JobID string    `json:"job_id,omitempty"`
+}
+
+// NotifyJobCreated tells the connected agents that a new vm job is waiting to be claimed.
+func NotifyJobCreated(jobID string) {
+	publish(&Event{Type: EventJobCreated, JobID: jobID})
+}
+
+// NotifyJobCancelled tells the agent running the given vm job to stop it.
+func NotifyJobCancelled(jobID string) {
+	publish(&Event{Type: EventJobCancelled, JobID: jobID})
+}
+
+// NotifyConfigChanged tells the agent of the given vm to reload its configuration.
+func NotifyConfigChanged(vmID string) {
+	publish(&Event{Type: EventConfigChanged, VMID: vmID})
+}
+
+// Subscribe returns a channel of dispatch events and a function to close the subscription.
+func Subscribe() (<-chan *Event, func() error) {
+	msgChan, closeFunc := cache.NewRedisCache(config.RedisCommonCacheTokenDB()).Subscribe(channel)
+	eventChan := make(chan *Event)
+	go func() {
+		defer close(eventChan)
+		for msg := range msgChan {
+			event := new(Event)
+			if err := json.Unmarshal([]byte(msg.Payload), event); err != nil {
+				log.Warnf("invalid vm dispatch event %s, error: %s", msg.Payload, err)
+				continue
+			}
+			eventChan <- event
+		}
+	}()
+	return eventChan, closeFunc
+}
+
+// publish is best effort: agents that miss an event still pick the change up
+// through the periodic sweep or through polling.
+func publish(event *Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("failed to marshal vm dispatch event, error: %s", err)
+		return
+	}
+	if err := cache.NewRedisCache(config.RedisCommonCacheTokenDB()).Publish(channel, string(payload)); err != nil {
+		log.Warnf("failed to publish vm dispatch event %s, error: %s", payload, err)
+	}
+}
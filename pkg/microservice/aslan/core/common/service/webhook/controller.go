@@ -27,11 +27,14 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/bitbucket"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitea"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitee"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/github"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitlab"
 	codehostdb "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
 
@@ -149,6 +152,16 @@ func ensureSafeDelete(ref, repoName, repoOwner, repoAddress string) (bool, error
 	return true, nil
 }
 
+// newBitbucketClient creates the client with the code host since bitbucket may use username and app password
+// which are not carried by the task
+func newBitbucketClient(codehostID int) (*bitbucket.Client, error) {
+	ch, err := systemconfig.New().GetCodeHost(codehostID)
+	if err != nil {
+		return nil, err
+	}
+	return bitbucket.NewClient(ch, config.ProxyHTTPSAddr())
+}
+
 func removeWebhook(t *task, logger *zap.Logger) {
 	coll := mongodb.NewWebHookColl()
 	var cl hookCreateDeleter
@@ -166,6 +179,20 @@ func removeWebhook(t *task, logger *zap.Logger) {
 		}
 	case setting.SourceFromGitee, setting.SourceFromGiteeEE:
 		cl = gitee.NewClient(t.ID, t.token, config.ProxyHTTPSAddr(), t.enableProxy, t.address)
+	case setting.SourceFromGitea:
+		cl, err = gitea.NewClient(t.ID, t.address, t.token, config.ProxyHTTPSAddr(), t.enableProxy, t.disbaleSSL)
+		if err != nil {
+			t.err = err
+			t.doneCh <- struct{}{}
+			return
+		}
+	case setting.SourceFromBitbucket:
+		cl, err = newBitbucketClient(t.ID)
+		if err != nil {
+			t.err = err
+			t.doneCh <- struct{}{}
+			return
+		}
 	default:
 		t.err = fmt.Errorf("invaild source: %s", t.from)
 		t.doneCh <- struct{}{}
@@ -244,6 +271,20 @@ func addWebhook(t *task, logger *zap.Logger) {
 		}
 	case setting.SourceFromGitee, setting.SourceFromGiteeEE:
 		cl = gitee.NewClient(t.ID, t.token, config.ProxyHTTPSAddr(), t.enableProxy, t.address)
+	case setting.SourceFromGitea:
+		cl, err = gitea.NewClient(t.ID, t.address, t.token, config.ProxyHTTPSAddr(), t.enableProxy, t.disbaleSSL)
+		if err != nil {
+			t.err = err
+			t.doneCh <- struct{}{}
+			return
+		}
+	case setting.SourceFromBitbucket:
+		cl, err = newBitbucketClient(t.ID)
+		if err != nil {
+			t.err = err
+			t.doneCh <- struct{}{}
+			return
+		}
 	default:
 		t.err = fmt.Errorf("invaild source: %s", t.from)
 		t.doneCh <- struct{}{}
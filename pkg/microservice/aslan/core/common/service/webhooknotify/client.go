@@ -17,22 +17,28 @@ limitations under the License.
 package webhooknotify
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/koderover/zadig/v2/pkg/config"
-	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
 
 type webhookNotifyclient struct {
-	Token   string
-	Address string
+	Token         string
+	SigningSecret string
+	Address       string
 }
 
-func NewClient(address, token string) *webhookNotifyclient {
+func NewClient(address, token, signingSecret string) *webhookNotifyclient {
 	return &webhookNotifyclient{
-		Token:   token,
-		Address: address,
+		Token:         token,
+		SigningSecret: signingSecret,
+		Address:       address,
 	}
 }
 
@@ -54,27 +60,31 @@ func (c *webhookNotifyclient) SendReleasePlanWebhook(webhookNotify *ReleasePlanH
 	return c.sendWebhook(notify)
 }
 
+// sendWebhook saves the delivery of the event before its first attempt, so that it is retried
+// in the background if the attempt fails.
 func (c *webhookNotifyclient) sendWebhook(notify *WebHookNotify) error {
-	resp, err := httpclient.Post(
-		c.Address,
-		httpclient.SetBody(notify),
-		httpclient.SetHeader(TokenHeader, c.Token),
-		httpclient.SetHeader(InstanceHeader, config.SystemAddress()),
-		httpclient.SetHeader(EventHeader, string(notify.Event)),
-		httpclient.SetHeader(EventUUIDHeader, uuid.New().String()),
-		httpclient.SetHeader(WebhookUUIDHeader, uuid.New().String()),
-	)
+	payload, err := json.Marshal(notify)
 	if err != nil {
-		return fmt.Errorf("failed to execute post http request, url: %s, error: %v", c.Address, err)
+		return fmt.Errorf("failed to marshal webhook payload, error: %v", err)
 	}
 
-	if resp.IsError() {
-		err := httpclient.NewErrorFromRestyResponse(resp)
-		return err
+	now := time.Now()
+	delivery := &commonmodels.WebhookDelivery{
+		EventUUID:       uuid.New().String(),
+		ObjectKind:      string(notify.ObjectKind),
+		Event:           string(notify.Event),
+		Address:         c.Address,
+		Token:           c.Token,
+		SigningSecret:   c.SigningSecret,
+		Payload:         string(payload),
+		Status:          commonmodels.WebhookDeliveryStatusPending,
+		MaxAttempts:     MaxDeliveryAttempts,
+		NextAttemptTime: now.Unix(),
+		LockedUntil:     now.Add(deliveryLease).Unix(),
 	}
-
-	if resp.IsSuccess() {
-		return nil
+	if err := commonrepo.NewWebhookDeliveryColl().Create(delivery); err != nil {
+		log.Errorf("failed to save webhook delivery %s, the event will not be retried, error: %v", delivery.EventUUID, err)
 	}
-	return nil
+
+	return deliver(delivery, false)
 }
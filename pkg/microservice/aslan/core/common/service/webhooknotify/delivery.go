@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooknotify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/koderover/zadig/v2/pkg/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	MaxDeliveryAttempts = 8
+
+	retryBaseInterval = 30 * time.Second
+	retryMaxInterval  = time.Hour
+	// an attempt holds the delivery for longer than the request timeout so that it is never sent twice at once
+	deliveryLease = 3 * TimeoutSeconds * time.Second
+	// maxRetriesPerRun bounds the deliveries retried by one run of RetryDueDeliveries
+	maxRetriesPerRun = 50
+	// maxRecordedBodySize truncates the response bodies kept in the delivery log
+	maxRecordedBodySize = 64 * 1024
+)
+
+// Sign returns the signature of a payload sent at the given unix time. Receivers verify it by
+// computing the HMAC-SHA256 of "<timestamp>.<body>" with the signing secret of the webhook.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay is the exponential backoff before the next automatic attempt, given the number of attempts made so far.
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseInterval
+	for i := 1; i < attempts && delay < retryMaxInterval; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxInterval {
+		delay = retryMaxInterval
+	}
+	return delay
+}
+
+// RetryDueDeliveries sends the pending deliveries whose next attempt is due, it is run periodically by aslan.
+func RetryDueDeliveries() {
+	coll := commonrepo.NewWebhookDeliveryColl()
+	for i := 0; i < maxRetriesPerRun; i++ {
+		delivery, err := coll.ClaimDue(time.Now(), deliveryLease)
+		if err != nil {
+			if err != mongo.ErrNoDocuments {
+				log.Errorf("failed to find webhook deliveries to retry, error: %v", err)
+			}
+			return
+		}
+
+		if err := deliver(delivery, false); err != nil {
+			log.Warnf("failed to retry webhook delivery %s, attempts: %d, error: %v", delivery.EventUUID, len(delivery.Attempts), err)
+		}
+	}
+}
+
+// Redeliver sends the event again with its original payload and event UUID. The result of the
+// attempt is recorded in the returned delivery rather than returned as an error.
+func Redeliver(eventUUID string) (*commonmodels.WebhookDelivery, error) {
+	delivery, err := commonrepo.NewWebhookDeliveryColl().Claim(eventUUID, time.Now(), deliveryLease)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("webhook delivery %s does not exist or is being sent", eventUUID)
+		}
+		return nil, err
+	}
+
+	if err := deliver(delivery, true); err != nil {
+		log.Warnf("failed to redeliver webhook %s, error: %v", eventUUID, err)
+	}
+	return delivery, nil
+}
+
+func deliver(delivery *commonmodels.WebhookDelivery, redelivery bool) error {
+	attempt, err := post(delivery, redelivery)
+
+	switch {
+	case err == nil:
+		delivery.Status = commonmodels.WebhookDeliveryStatusSuccess
+		delivery.NextAttemptTime = 0
+	case redelivery:
+		// a failed redelivery leaves the automatic retries of a pending delivery as they are
+		if delivery.Status != commonmodels.WebhookDeliveryStatusPending {
+			delivery.Status = commonmodels.WebhookDeliveryStatusFailed
+		}
+	default:
+		attempts := 1
+		for _, previous := range delivery.Attempts {
+			if !previous.Redelivery {
+				attempts++
+			}
+		}
+		if attempts < delivery.MaxAttempts {
+			delivery.Status = commonmodels.WebhookDeliveryStatusPending
+			delivery.NextAttemptTime = time.Now().Add(retryDelay(attempts)).Unix()
+		} else {
+			delivery.Status = commonmodels.WebhookDeliveryStatusFailed
+			delivery.NextAttemptTime = 0
+		}
+	}
+
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	if updateErr := commonrepo.NewWebhookDeliveryColl().AddAttempt(delivery, attempt); updateErr != nil {
+		log.Errorf("failed to save attempt of webhook delivery %s, error: %v", delivery.EventUUID, updateErr)
+	}
+	return err
+}
+
+func post(delivery *commonmodels.WebhookDelivery, redelivery bool) (*commonmodels.WebhookDeliveryAttempt, error) {
+	start := time.Now()
+	headers := map[string]string{
+		TokenHeader:       delivery.Token,
+		InstanceHeader:    config.SystemAddress(),
+		EventHeader:       delivery.Event,
+		EventUUIDHeader:   delivery.EventUUID,
+		WebhookUUIDHeader: uuid.New().String(),
+		TimestampHeader:   strconv.FormatInt(start.Unix(), 10),
+	}
+	if delivery.SigningSecret != "" {
+		headers[SignatureHeader] = Sign(delivery.SigningSecret, start.Unix(), []byte(delivery.Payload))
+	}
+
+	resp, err := httpclient.Post(
+		delivery.Address,
+		httpclient.SetBody([]byte(delivery.Payload)),
+		httpclient.SetHeaders(headers),
+	)
+
+	attempt := &commonmodels.WebhookDeliveryAttempt{
+		WebhookUUID:    headers[WebhookUUIDHeader],
+		Redelivery:     redelivery,
+		RequestHeaders: headers,
+		StartTime:      start.Unix(),
+		Duration:       time.Since(start).Milliseconds(),
+	}
+	if delivery.Token != "" {
+		attempt.RequestHeaders[TokenHeader] = "********"
+	}
+	if resp != nil {
+		attempt.ResponseStatus = resp.StatusCode()
+		attempt.ResponseHeaders = flattenHeader(resp.Header())
+		attempt.ResponseBody = truncateBody(resp.String())
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to execute post http request, url: %s, error: %v", delivery.Address, err)
+		attempt.Error = err.Error()
+	}
+	return attempt, err
+}
+
+func flattenHeader(header http.Header) map[string]string {
+	resp := make(map[string]string, len(header))
+	for key, values := range header {
+		resp[key] = strings.Join(values, ", ")
+	}
+	return resp
+}
+
+func truncateBody(body string) string {
+	if len(body) <= maxRecordedBodySize {
+		return body
+	}
+	return body[:maxRecordedBodySize]
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooknotify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"event":"workflow"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=039e313279963d305ba262fd0671d89d05c0ea04f1adfd7605dd21d33e741965", Sign("secret", 1700000000, []byte(`{"event":"workflow"}`)))
+	assert.NotEqual(t, Sign("secret", 1700000000, []byte(`{}`)), Sign("secret", 1700000001, []byte(`{}`)))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, 16*time.Minute, retryDelay(6))
+	assert.Equal(t, time.Hour, retryDelay(20))
+}
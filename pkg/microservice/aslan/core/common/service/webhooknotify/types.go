@@ -31,6 +31,8 @@ const (
 	EventHeader       = "X-Zadig-Event"
 	EventUUIDHeader   = "X-Zadig-Event-UUID"
 	WebhookUUIDHeader = "X-Zadig-Webhook-UUID"
+	TimestampHeader   = "X-Zadig-Timestamp"
+	SignatureHeader   = "X-Zadig-Signature-256"
 
 	TimeoutSeconds = 60
 )
@@ -88,7 +88,7 @@ func ProcessWebhook(updatedHooks, currentHooks interface{}, name string, logger
 			}
 
 			switch ch.Type {
-			case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE:
+			case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE, setting.SourceFromGitea:
 				err = webhook.NewClient().RemoveWebHook(&webhook.TaskOption{
 					ID:          ch.ID,
 					Name:        wh.name,
@@ -126,7 +126,7 @@ func ProcessWebhook(updatedHooks, currentHooks interface{}, name string, logger
 			}
 
 			switch ch.Type {
-			case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE:
+			case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitee, setting.SourceFromGiteeEE, setting.SourceFromGitea:
 				err = webhook.NewClient().AddWebHook(&webhook.TaskOption{
 					ID:        ch.ID,
 					Name:      wh.name,
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflowcontroller
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+	workflowtool "github.com/koderover/zadig/v2/pkg/tool/workflow"
+)
+
+// HasJobDependencies reports whether any job of the task declares explicit dependencies,
+// in which case the task is scheduled by dependency graph instead of stage by stage.
+func HasJobDependencies(stages []*commonmodels.StageTask) bool {
+	for _, stage := range stages {
+		for _, job := range stage.Jobs {
+			if len(job.DependsOn) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jobOriginName(job *commonmodels.JobTask) string {
+	if job.OriginName != "" {
+		return job.OriginName
+	}
+	return job.Name
+}
+
+// BuildJobTaskDAG builds the dependency graph of the job tasks, dependencies on workflow jobs
+// that generated no task, e.g. skipped ones, are ignored.
+func BuildJobTaskDAG(stages []*commonmodels.StageTask) (*workflowtool.DAG, error) {
+	origins := make(map[string]bool)
+	for _, stage := range stages {
+		for _, job := range stage.Jobs {
+			origins[jobOriginName(job)] = true
+		}
+	}
+
+	dagStages := make([]*workflowtool.DAGStage, 0, len(stages))
+	for _, stage := range stages {
+		dagStage := &workflowtool.DAGStage{Parallel: stage.Parallel}
+		for _, job := range stage.Jobs {
+			node := &workflowtool.DAGNode{Name: job.Name, Group: jobOriginName(job)}
+			for _, dep := range job.DependsOn {
+				if origins[dep] {
+					node.DependsOn = append(node.DependsOn, dep)
+				}
+			}
+			dagStage.Nodes = append(dagStage.Nodes, node)
+		}
+		dagStages = append(dagStages, dagStage)
+	}
+	return workflowtool.BuildStageDAG(dagStages)
+}
+
+// RunStagesByDependency runs the jobs of all stages by dependency graph, a stage starts with its first job
+// and finishes with its last one, so the stage status keeps summarizing the jobs it contains.
+func RunStagesByDependency(ctx context.Context, stages []*commonmodels.StageTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
+	dag, err := BuildJobTaskDAG(stages)
+	if err != nil {
+		for _, stage := range stages {
+			if stage.Status == config.StatusPassed || stage.Status == config.StatusSkipped || stage.Status == config.StatusUnstable {
+				continue
+			}
+			stage.Status = config.StatusFailed
+			stage.Error = err.Error()
+			logger.Errorf("failed to schedule stage: %s by job dependencies, error: %s", stage.Name, err)
+			break
+		}
+		ack()
+		return
+	}
+
+	jobs := make([]*commonmodels.JobTask, 0)
+	dependencies := make(map[string][]string)
+	jobStage := make(map[string]*commonmodels.StageTask)
+	stageRemaining := make(map[*commonmodels.StageTask]int)
+	originRemaining := make(map[string]int)
+	for _, stage := range stages {
+		// should skip passed stage when workflow task be restarted
+		if stage.Status == config.StatusPassed || stage.Status == config.StatusSkipped || stage.Status == config.StatusUnstable {
+			continue
+		}
+		if len(stage.Jobs) == 0 {
+			updateStageStatus(ctx, stage)
+			continue
+		}
+		stage.Status = ""
+		for _, job := range stage.Jobs {
+			jobs = append(jobs, job)
+			dependencies[job.Name] = dag.Dependencies(job.Name)
+			jobStage[job.Name] = stage
+			originRemaining[jobOriginName(job)]++
+		}
+		stageRemaining[stage] = len(stage.Jobs)
+	}
+	// jobs of finished stages are not run again, dependencies on them are already satisfied
+	for name, deps := range dependencies {
+		unfinished := make([]string, 0, len(deps))
+		for _, dep := range deps {
+			if _, ok := jobStage[dep]; ok {
+				unfinished = append(unfinished, dep)
+			}
+		}
+		dependencies[name] = unfinished
+	}
+
+	started := func(job *commonmodels.JobTask) {
+		stage := jobStage[job.Name]
+		if stage.Status == config.StatusRunning {
+			return
+		}
+		stage.Status = config.StatusRunning
+		stage.StartTime = time.Now().Unix()
+		logger.Infof("start stage: %s,status: %s", stage.Name, stage.Status)
+		ack()
+	}
+	finished := func(job *commonmodels.JobTask) {
+		originRemaining[jobOriginName(job)]--
+		if originRemaining[jobOriginName(job)] == 0 {
+			setJobImagesVariables(workflowCtx, jobOriginName(job))
+		}
+
+		stage := jobStage[job.Name]
+		stageRemaining[stage]--
+		if stageRemaining[stage] == 0 {
+			updateStageStatus(ctx, stage)
+			stage.EndTime = time.Now().Unix()
+			logger.Infof("finish stage: %s,status: %s", stage.Name, stage.Status)
+			ack()
+		}
+	}
+	jobcontroller.RunJobsByDependency(ctx, jobs, dependencies, workflowCtx, concurrency, logger, ack, started, finished)
+
+	// stages cut short by a failed job are summarized from the jobs that did run
+	for stage, remaining := range stageRemaining {
+		if remaining > 0 && stage.Status == config.StatusRunning {
+			updateStageStatus(ctx, stage)
+			stage.EndTime = time.Now().Unix()
+			logger.Infof("finish stage: %s,status: %s", stage.Name, stage.Status)
+		}
+	}
+	ack()
+}
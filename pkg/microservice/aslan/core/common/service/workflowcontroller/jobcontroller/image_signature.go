@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/distribution/reference"
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/registry"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+)
+
+// verifyImageSignatures makes sure every image to be deployed carries a valid signature of the configured key,
+// the registry credentials are looked up from the integrated registries by the image's domain.
+// Every image is pinned to its verified digest in place so that the deployed image can't differ from the verified one.
+func verifyImageSignatures(images []*string, verification *commonmodels.ImageSignatureVerification, logger *zap.SugaredLogger) error {
+	if verification == nil || !verification.Enabled {
+		return nil
+	}
+
+	registries, err := commonrepo.NewRegistryNamespaceColl().FindAll(&commonrepo.FindRegOps{})
+	if err != nil {
+		return fmt.Errorf("failed to list registries, error: %s", err)
+	}
+
+	for _, image := range images {
+		if *image == "" {
+			continue
+		}
+		pinnedImage, err := verifyImageSignature(*image, verification.PublicKey, registries, logger)
+		if err != nil {
+			return fmt.Errorf("image %s failed signature verification: %s", *image, err)
+		}
+		*image = pinnedImage
+	}
+	return nil
+}
+
+// verifyImageSignature returns the image pinned to the verified digest, the tag is kept for readability
+// since the digest takes precedence over the tag when the image is pulled
+func verifyImageSignature(image, publicKey string, registries []*commonmodels.RegistryNamespace, logger *zap.SugaredLogger) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image, error: %s", err)
+	}
+	if reference.IsNameOnly(named) {
+		return "", fmt.Errorf("image must be specified with a tag or digest")
+	}
+	domain, path := reference.Domain(named), reference.Path(named)
+
+	reg := findImageRegistry(domain, path, registries)
+	if reg == nil {
+		return "", fmt.Errorf("registry %s is not integrated", domain)
+	}
+	reg, err = commonutil.DecodeRegistry(reg)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode registry credential, error: %s", err)
+	}
+
+	option := registry.VerifyImageSignatureOption{
+		Endpoint: registry.Endpoint{
+			Addr:   reg.RegAddr,
+			Ak:     reg.AccessKey,
+			Sk:     reg.SecretKey,
+			Region: reg.Region,
+		},
+		Image:     path,
+		PublicKey: publicKey,
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		option.Digest = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		option.Tag = tagged.Tag()
+	}
+
+	var regService registry.Service
+	if reg.AdvancedSetting != nil {
+		regService = registry.NewV2Service(reg.RegProvider, reg.AdvancedSetting.TLSEnabled, reg.AdvancedSetting.TLSCert)
+	} else {
+		regService = registry.NewV2Service(reg.RegProvider, true, "")
+	}
+	verifiedDigest, err := regService.VerifyImageSignature(option, logger)
+	if err != nil {
+		return "", err
+	}
+	if option.Digest != "" {
+		return image, nil
+	}
+	return image + "@" + verifiedDigest, nil
+}
+
+// findImageRegistry returns the registry matching the image domain, the one with the longest matching namespace wins
+func findImageRegistry(domain, path string, registries []*commonmodels.RegistryNamespace) *commonmodels.RegistryNamespace {
+	var found *commonmodels.RegistryNamespace
+	bestScore := -2
+	for _, reg := range registries {
+		addr, err := reg.GetRegistryAddress()
+		if err != nil || strings.TrimSuffix(addr, "/") != domain {
+			continue
+		}
+		score := -1
+		if reg.Namespace == "" {
+			score = 0
+		} else if strings.HasPrefix(path, reg.Namespace+"/") {
+			score = len(reg.Namespace)
+		}
+		if score > bestScore {
+			found, bestScore = reg, score
+		}
+	}
+	return found
+}
@@ -178,6 +178,12 @@ func initJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTas
 		jobCtl = NewJenkinsJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobSQL):
 		jobCtl = NewSQLJobCtl(job, workflowCtx, ack, logger)
+	case string(config.JobSchemaMigration):
+		jobCtl = NewSchemaMigrationJobCtl(job, workflowCtx, ack, logger)
+	case string(config.JobProgressiveDelivery):
+		jobCtl = NewProgressiveDeliveryJobCtl(job, workflowCtx, ack, logger)
+	case string(config.JobTerraform):
+		jobCtl = NewTerraformJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobBlueKing):
 		jobCtl = NewBlueKingJobCtl(job, workflowCtx, ack, logger)
 	case string(config.JobApproval):
@@ -200,7 +206,7 @@ func initJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTas
 	return jobCtl
 }
 
-func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, notifier *jobNotifier, logger *zap.SugaredLogger, ack func()) {
+func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, notifier *jobNotifier, matrix *matrixLimiter, logger *zap.SugaredLogger, ack func()) {
 	setJobStartTimeContext(job, workflowCtx)
 
 	// Keep the original execution timestamps when a completed job is skipped
@@ -260,6 +266,20 @@ func runJob(ctx context.Context, job *commonmodels.JobTask, workflowCtx *commonm
 		return
 	}
 
+	if !matrix.acquire(ctx, job) {
+		job.Status = config.StatusSkipped
+		job.Error = "skipped since another sub-task of the matrix failed"
+		if ctx.Err() != nil {
+			job.Status = config.StatusCancelled
+			job.Error = ""
+		}
+		job.StartTime = time.Now().Unix()
+		job.EndTime = time.Now().Unix()
+		ack()
+		return
+	}
+	defer matrix.release(job)
+
 	job.Status = config.StatusPrepare
 	job.StartTime = time.Now().Unix()
 	job.K8sJobName = getJobName(workflowCtx.WorkflowName, workflowCtx.TaskID)
@@ -545,20 +565,79 @@ func waitForManualErrorHandling(ctx context.Context, workflowCtx *commonmodels.W
 func RunJobs(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func()) {
 	notifier := newJobNotifier(jobs, workflowCtx, logger)
 	defer notifier.flush()
+	matrix := newMatrixLimiter(jobs)
 
 	if concurrency == 1 {
 		for _, job := range jobs {
-			runJob(ctx, job, workflowCtx, notifier, logger, ack)
+			runJob(ctx, job, workflowCtx, notifier, matrix, logger, ack)
 			if jobStatusFailed(job.Status) {
 				return
 			}
 		}
 		return
 	}
-	jobPool := NewPool(ctx, jobs, workflowCtx, notifier, concurrency, logger, ack)
+	jobPool := NewPool(ctx, jobs, workflowCtx, notifier, matrix, concurrency, logger, ack)
 	jobPool.Run()
 }
 
+// RunJobsByDependency starts every job as soon as the jobs it depends on have finished, dependencies maps a job name
+// to the names of the jobs it waits for. At most concurrency jobs run at the same time and no new job is started after
+// one of them failed or paused. started and finished are called from the scheduling goroutine only.
+func RunJobsByDependency(ctx context.Context, jobs []*commonmodels.JobTask, dependencies map[string][]string, workflowCtx *commonmodels.WorkflowTaskCtx, concurrency int, logger *zap.SugaredLogger, ack func(), started, finished func(job *commonmodels.JobTask)) {
+	notifier := newJobNotifier(jobs, workflowCtx, logger)
+	defer notifier.flush()
+	matrix := newMatrixLimiter(jobs)
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	remaining := make(map[string]int, len(jobs))
+	dependents := make(map[string][]*commonmodels.JobTask, len(jobs))
+	ready := make([]*commonmodels.JobTask, 0)
+	for _, job := range jobs {
+		remaining[job.Name] = len(dependencies[job.Name])
+		for _, dep := range dependencies[job.Name] {
+			dependents[dep] = append(dependents[dep], job)
+		}
+		if remaining[job.Name] == 0 {
+			ready = append(ready, job)
+		}
+	}
+
+	done := make(chan *commonmodels.JobTask)
+	running := 0
+	stopped := false
+	for {
+		for !stopped && running < concurrency && len(ready) > 0 {
+			job := ready[0]
+			ready = ready[1:]
+			running++
+			started(job)
+			go func(job *commonmodels.JobTask) {
+				runJob(ctx, job, workflowCtx, notifier, matrix, logger, ack)
+				done <- job
+			}(job)
+		}
+		if running == 0 {
+			return
+		}
+
+		job := <-done
+		running--
+		finished(job)
+		if jobStatusFailed(job.Status) || job.Status == config.StatusPause {
+			stopped = true
+			continue
+		}
+		for _, dependent := range dependents[job.Name] {
+			remaining[dependent.Name]--
+			if remaining[dependent.Name] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+}
+
 func CleanWorkflowJobs(ctx context.Context, workflowTask *commonmodels.WorkflowTask, workflowCtx *commonmodels.WorkflowTaskCtx, logger *zap.SugaredLogger, ack func()) {
 	for _, stage := range workflowTask.Stages {
 		for _, job := range stage.Jobs {
@@ -591,6 +670,7 @@ type Pool struct {
 	Jobs        []*commonmodels.JobTask
 	workflowCtx *commonmodels.WorkflowTaskCtx
 	notifier    *jobNotifier
+	matrix      *matrixLimiter
 	concurrency int
 	jobsChan    chan *commonmodels.JobTask
 	logger      *zap.SugaredLogger
@@ -601,12 +681,13 @@ type Pool struct {
 
 // NewPool initializes a new pool with the given tasks and
 // at the given concurrency.
-func NewPool(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, notifier *jobNotifier, concurrency int, logger *zap.SugaredLogger, ack func()) *Pool {
+func NewPool(ctx context.Context, jobs []*commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, notifier *jobNotifier, matrix *matrixLimiter, concurrency int, logger *zap.SugaredLogger, ack func()) *Pool {
 	return &Pool{
 		Jobs:        jobs,
 		concurrency: concurrency,
 		workflowCtx: workflowCtx,
 		notifier:    notifier,
+		matrix:      matrix,
 		jobsChan:    make(chan *commonmodels.JobTask),
 		logger:      logger,
 		ack:         ack,
@@ -635,7 +716,7 @@ func (p *Pool) Run() {
 // The work loop for any single goroutine.
 func (p *Pool) work() {
 	for job := range p.jobsChan {
-		runJob(p.ctx, job, p.workflowCtx, p.notifier, p.logger, p.ack)
+		runJob(p.ctx, job, p.workflowCtx, p.notifier, p.matrix, p.logger, p.ack)
 		p.wg.Done()
 	}
 }
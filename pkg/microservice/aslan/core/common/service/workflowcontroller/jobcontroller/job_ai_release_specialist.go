@@ -2083,7 +2083,7 @@ func getApprovalDecision(spec *commonmodels.JobTaskApprovalSpec) string {
 		return ""
 	}
 	switch spec.Type {
-	case config.NativeApproval:
+	case config.NativeApproval, config.SlackApproval:
 		if spec.NativeApproval != nil {
 			return string(spec.NativeApproval.RejectOrApprove)
 		}
@@ -2150,7 +2150,7 @@ func mergeApprovalDecisions(decisions []string) string {
 }
 
 func getApprovalNeededApprovers(spec *commonmodels.JobTaskApprovalSpec) int {
-	if spec == nil || spec.Type != config.NativeApproval || spec.NativeApproval == nil {
+	if spec == nil || (spec.Type != config.NativeApproval && spec.Type != config.SlackApproval) || spec.NativeApproval == nil {
 		return 0
 	}
 	return spec.NativeApproval.NeededApprovers
@@ -2162,7 +2162,7 @@ func getApprovalApprovers(spec *commonmodels.JobTaskApprovalSpec) []string {
 	}
 	approvers := make([]string, 0)
 	switch spec.Type {
-	case config.NativeApproval:
+	case config.NativeApproval, config.SlackApproval:
 		if spec.NativeApproval != nil {
 			for _, user := range spec.NativeApproval.ApproveUsers {
 				if user == nil {
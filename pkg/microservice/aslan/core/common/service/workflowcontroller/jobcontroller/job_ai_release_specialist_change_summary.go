@@ -155,7 +155,7 @@ func buildAISQLExecutionSummary(spec *commonmodels.JobTaskSQLSpec) *commonmodels
 		case setting.SQLExecStatusSuccess:
 			result.SuccessfulStatementCount++
 			result.RowsAffected += statement.RowsAffected
-		case setting.SQLExecStatusFailed:
+		case setting.SQLExecStatusFailed, setting.SQLExecStatusRollback:
 			result.FailedStatementCount++
 		default:
 			result.PendingStatementCount++
@@ -36,6 +36,7 @@ import (
 	approvalservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/approval"
 	dingservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/dingtalk"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/instantmessage"
+	slackservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/slack"
 	workwxservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workwx"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/dingtalk"
@@ -73,28 +74,31 @@ func (c *ApprovalJobCtl) Run(ctx context.Context) {
 	c.job.Status = config.StatusWaitingApprove
 	c.ack()
 
-	var err error
-	var status config.Status
+	status, err := waitForApprove(ctx, c.jobTaskSpec, c.workflowCtx, c.job, c.ack)
+	c.job.Status = status
+	if err != nil {
+		c.job.Error = err.Error()
+	}
 
-	switch c.jobTaskSpec.Type {
+	return
+}
+
+// waitForApprove waits for the approval of the job with the approval type of the spec.
+func waitForApprove(ctx context.Context, spec *commonmodels.JobTaskApprovalSpec, workflowCtx *commonmodels.WorkflowTaskCtx, job *commonmodels.JobTask, ack func()) (config.Status, error) {
+	switch spec.Type {
 	case config.NativeApproval:
-		status, err = waitForNativeApprove(ctx, c.jobTaskSpec, c.workflowCtx.WorkflowName, c.job.Name, c.workflowCtx.TaskID, c.ack)
+		return waitForNativeApprove(ctx, spec, workflowCtx.WorkflowName, job.Name, workflowCtx.TaskID, ack)
 	case config.LarkApproval, config.LarkApprovalIntl:
-		status, err = waitForLarkApprove(ctx, c.jobTaskSpec, c.workflowCtx, c.job.DisplayName, c.ack)
+		return waitForLarkApprove(ctx, spec, workflowCtx, job.DisplayName, ack)
 	case config.DingTalkApproval:
-		status, err = waitForDingTalkApprove(ctx, c.jobTaskSpec, c.workflowCtx, c.job.DisplayName, c.ack)
+		return waitForDingTalkApprove(ctx, spec, workflowCtx, job.DisplayName, ack)
 	case config.WorkWXApproval:
-		status, err = waitForWorkWXApprove(ctx, c.jobTaskSpec, c.workflowCtx, c.job.DisplayName, c.ack)
+		return waitForWorkWXApprove(ctx, spec, workflowCtx, job.DisplayName, ack)
+	case config.SlackApproval:
+		return waitForSlackApprove(ctx, spec, workflowCtx, job.Name, job.DisplayName, ack)
 	default:
-		err = errors.New("invalid approval type")
+		return "", errors.New("invalid approval type")
 	}
-
-	c.job.Status = status
-	if err != nil {
-		c.job.Error = err.Error()
-	}
-
-	return
 }
 
 func getDingTalkApprovalProcessCode(client *dingtalk.Client, defaultProcessCode, approvalTitle string) (string, error) {
@@ -189,10 +193,11 @@ func getWorkWXApprovalTemplateID(client *workwx.Client, imApp *commonmodels.IMAp
 
 func waitForNativeApprove(ctx context.Context, spec *commonmodels.JobTaskApprovalSpec, workflowName, jobName string, taskID int64, ack func()) (config.Status, error) {
 	log.Infof("waitForNativeApprove start")
-	return waitForNativeApproveWithCallback(ctx, spec, workflowName, jobName, taskID, ack, func() {
+	return waitForNativeApproveWithCallback(ctx, spec, workflowName, jobName, taskID, ack, func() error {
 		if err := instantmessage.NewWeChatClient().SendWorkflowTaskApproveNotifications(workflowName, taskID, nil); err != nil {
 			log.Errorf("send approve notification failed, error: %v", err)
 		}
+		return nil
 	})
 }
 
@@ -200,7 +205,9 @@ func waitForNativeApproveCore(ctx context.Context, spec *commonmodels.JobTaskApp
 	return waitForNativeApproveWithCallback(ctx, spec, workflowName, jobName, taskID, ack, nil)
 }
 
-func waitForNativeApproveWithCallback(ctx context.Context, spec *commonmodels.JobTaskApprovalSpec, workflowName, jobName string, taskID int64, ack func(), afterRegister func()) (config.Status, error) {
+// waitForNativeApproveWithCallback waits for the native approval, afterRegister is called once the approval can be
+// operated on, the job fails if it returns an error.
+func waitForNativeApproveWithCallback(ctx context.Context, spec *commonmodels.JobTaskApprovalSpec, workflowName, jobName string, taskID int64, ack func(), afterRegister func() error) (config.Status, error) {
 	approval := spec.NativeApproval
 
 	if approval == nil {
@@ -218,7 +225,9 @@ func waitForNativeApproveWithCallback(ctx context.Context, spec *commonmodels.Jo
 		approvalservice.GlobalApproveMap.DeleteApproval(approveKey)
 	}()
 	if afterRegister != nil {
-		afterRegister()
+		if err := afterRegister(); err != nil {
+			return config.StatusFailed, err
+		}
 	}
 
 	timeoutChan := time.After(time.Duration(timeout) * time.Minute)
@@ -825,6 +834,88 @@ func waitForWorkWXApprove(ctx context.Context, spec *commonmodels.JobTaskApprova
 	}
 }
 
+// waitForSlackApprove runs a native approval and posts its Approve/Reject buttons to slack, so the
+// approval can be decided either in slack or in zadig.
+func waitForSlackApprove(ctx context.Context, spec *commonmodels.JobTaskApprovalSpec, workflowCtx *commonmodels.WorkflowTaskCtx, jobName, jobDisplayName string, ack func()) (config.Status, error) {
+	log.Infof("waitForSlackApprove start")
+	approval := spec.SlackApproval
+	if approval == nil {
+		return config.StatusFailed, fmt.Errorf("waitForApprove: slack approval data not found")
+	}
+
+	client, err := slackservice.GetSlackClientByIMAppID(approval.ID)
+	if err != nil {
+		return config.StatusFailed, fmt.Errorf("get slack client error: %s", err)
+	}
+
+	timeout := spec.Timeout
+	if timeout == 0 {
+		timeout = 60
+	}
+	spec.NativeApproval = &commonmodels.NativeApproval{
+		Timeout:         int(timeout),
+		ApproveUsers:    approval.ApproveUsers,
+		NeededApprovers: approval.NeededApprovers,
+	}
+
+	detailURL := fmt.Sprintf("%s/v1/projects/detail/%s/pipelines/custom/%s/%d?display_name=%s",
+		configbase.SystemAddress(),
+		workflowCtx.ProjectName,
+		workflowCtx.WorkflowName,
+		workflowCtx.TaskID,
+		url.QueryEscape(workflowCtx.WorkflowDisplayName),
+	)
+
+	task, deployFormContent, err := generateDeployFormContent(workflowCtx.WorkflowName, workflowCtx.TaskID)
+	if err != nil {
+		log.Errorf("generate deploy env failed: %v", err)
+		return config.StatusFailed, fmt.Errorf("generate deploy env failed, error: %s", err)
+	}
+
+	title := strings.TrimSpace(spec.ApprovalTitle)
+	if title == "" {
+		title = fmt.Sprintf("%s: %s", workflowCtx.WorkflowDisplayName, jobDisplayName)
+	}
+	content := fmt.Sprintf("*Project:* %s\n*Workflow:* %s #%d\n*Job:* %s", workflowCtx.ProjectDisplayName, workflowCtx.WorkflowDisplayName, workflowCtx.TaskID, jobDisplayName)
+	if spec.Description != "" {
+		content += fmt.Sprintf("\n*Description:* %s", spec.Description)
+	}
+	if deployFormContent = strings.TrimSpace(deployFormContent); deployFormContent != "" {
+		content += "\n" + deployFormContent
+	}
+	if task.Remark != "" {
+		content += fmt.Sprintf("\n*Remark:* %s", task.Remark)
+	}
+	if spec.ApprovalMessage != "" {
+		content = spec.ApprovalMessage
+	}
+
+	approveKey := fmt.Sprintf("%s-%s-%d", workflowCtx.WorkflowName, jobName, workflowCtx.TaskID)
+	status, err := waitForNativeApproveWithCallback(ctx, spec, workflowCtx.WorkflowName, jobName, workflowCtx.TaskID, ack, func() error {
+		mentions := slackservice.GetSlackUserIDs(approval.ID, client, approval.ApproveUsers)
+		resp, err := client.PostMessage(approval.Channel, title, slackservice.NewApprovalBlocks(title, content, detailURL, approveKey, mentions))
+		if err != nil {
+			return fmt.Errorf("post slack approval message error: %s", err)
+		}
+		approval.ChannelID = resp.Channel
+		approval.MessageTS = resp.TS
+		ack()
+
+		if err := instantmessage.NewWeChatClient().SendWorkflowTaskApproveNotifications(workflowCtx.WorkflowName, workflowCtx.TaskID, task); err != nil {
+			log.Errorf("send approve notification failed, error: %v", err)
+		}
+		return nil
+	})
+
+	if approval.MessageTS != "" {
+		blocks := slackservice.NewApprovalResultBlocks(title, content, detailURL, status, spec.NativeApproval)
+		if updateErr := client.UpdateMessage(approval.ChannelID, approval.MessageTS, title, blocks); updateErr != nil {
+			log.Warnf("waitForSlackApprove: update approval message failed: %v", updateErr)
+		}
+	}
+	return status, err
+}
+
 func generateDeployFormContent(workflowName string, taskID int64) (*commonmodels.WorkflowTask, string, error) {
 	task, err := mongodb.NewworkflowTaskv4Coll().Find(workflowName, taskID)
 	if err != nil {
@@ -210,6 +210,17 @@ func (c *DeployJobCtl) run(ctx context.Context) error {
 		return errors.New(msg)
 	}
 
+	if slices.Contains(c.jobTaskSpec.DeployContents, config.DeployImage) {
+		images := make([]*string, 0, len(c.jobTaskSpec.ServiceAndImages))
+		for _, serviceAndImage := range c.jobTaskSpec.ServiceAndImages {
+			images = append(images, &serviceAndImage.Image)
+		}
+		if err := verifyImageSignatures(images, c.jobTaskSpec.ImageSignatureVerification, c.logger); err != nil {
+			logError(c.job, err.Error(), c.logger)
+			return err
+		}
+	}
+
 	c.namespace = env.Namespace
 	c.jobTaskSpec.ClusterID = env.ClusterID
 
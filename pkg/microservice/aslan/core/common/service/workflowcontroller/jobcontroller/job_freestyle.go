@@ -51,6 +51,7 @@ import (
 	vmmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/vm"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/vmdispatch"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/stepcontroller"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/multicluster/service"
 	"github.com/koderover/zadig/v2/pkg/setting"
@@ -354,6 +355,7 @@ func (c *FreestyleJobCtl) runVMJob(ctx context.Context) (string, error) {
 		logError(c.job, msg, c.logger)
 		return "", errors.New(msg)
 	}
+	vmdispatch.NotifyJobCreated(vmJob.ID.Hex())
 	return vmJob.ID.Hex(), nil
 }
 
@@ -1212,12 +1214,14 @@ func (c *FreestyleJobCtl) vmJobWait(ctx context.Context, jobID string) {
 			c.logger.Errorf("update vm job status error: %v", err)
 			c.job.Error = fmt.Errorf("update vm job status %s error: %v", string(config.ReleasePlanStatusCancel), err).Error()
 		}
+		vmdispatch.NotifyJobCancelled(jobID)
 	case config.StatusTimeout:
 		err := vmmongodb.NewVMJobColl().UpdateStatus(jobID, string(config.StatusTimeout))
 		if err != nil {
 			c.logger.Errorf("update vm job status error: %v", err)
 			c.job.Error = fmt.Errorf("update vm job status %s error: %v", string(config.StatusTimeout), err).Error()
 		}
+		vmdispatch.NotifyJobCancelled(jobID)
 	}
 }
 
@@ -100,6 +100,17 @@ func (c *HelmDeployJobCtl) Run(ctx context.Context) {
 		return
 	}
 
+	if slices.Contains(c.jobTaskSpec.DeployContents, config.DeployImage) {
+		images := make([]*string, 0, len(c.jobTaskSpec.ImageAndModules))
+		for _, imageAndModule := range c.jobTaskSpec.ImageAndModules {
+			images = append(images, &imageAndModule.Image)
+		}
+		if err := verifyImageSignatures(images, c.jobTaskSpec.ImageSignatureVerification, c.logger); err != nil {
+			logError(c.job, err.Error(), c.logger)
+			return
+		}
+	}
+
 	c.namespace = productInfo.Namespace
 	c.jobTaskSpec.ClusterID = productInfo.ClusterID
 
@@ -142,6 +142,15 @@ func (c *NotificationJobCtl) Run(ctx context.Context) {
 			c.ack()
 			return
 		}
+	} else if c.jobTaskSpec.WebHookType == setting.NotifyWebHookTypeSlack {
+		err := sendSlackMessage(c.workflowCtx.ProjectName, c.workflowCtx.WorkflowName, c.workflowCtx.WorkflowDisplayName, c.workflowCtx.TaskID, c.jobTaskSpec.SlackNotificationConfig, c.jobTaskSpec.Title, c.jobTaskSpec.Content)
+		if err != nil {
+			c.logger.Error(err)
+			c.job.Status = config.StatusFailed
+			c.job.Error = err.Error()
+			c.ack()
+			return
+		}
 	} else if c.jobTaskSpec.WebHookType == setting.NotifyWebHookTypeWechatWork {
 		err := sendWorkWxMessage(c.workflowCtx.ProjectName, c.workflowCtx.WorkflowName, c.workflowCtx.WorkflowDisplayName, c.workflowCtx.TaskID, c.jobTaskSpec.WechatNotificationConfig.HookAddress, c.jobTaskSpec.Title, c.jobTaskSpec.Content, c.jobTaskSpec.WechatNotificationConfig.AtUsers, c.jobTaskSpec.WechatNotificationConfig.IsAtAll)
 		if err != nil {
@@ -247,6 +256,7 @@ func (c *NotificationJobCtl) resolveDynamicRecipients(keyMap map[string]string)
 		LarkPerson: c.jobTaskSpec.LarkPersonNotificationConfig,
 		DingDing:   c.jobTaskSpec.DingDingNotificationConfig,
 		MSTeams:    c.jobTaskSpec.MSTeamsNotificationConfig,
+		Slack:      c.jobTaskSpec.SlackNotificationConfig,
 		Mail:       c.jobTaskSpec.MailNotificationConfig,
 	})
 }
@@ -432,6 +442,17 @@ func sendWorkWxMessage(productName, workflowName, workflowDisplayName string, ta
 	return nil
 }
 
+func sendSlackMessage(productName, workflowName, workflowDisplayName string, taskID int64, cfg *commonmodels.SlackNotificationConfig, title, message string) error {
+	actionURL := fmt.Sprintf("%s/v1/projects/detail/%s/pipelines/custom/%s/%d?display_name=%s",
+		configbase.SystemAddress(),
+		productName,
+		workflowName,
+		taskID,
+		url.PathEscape(workflowDisplayName),
+	)
+	return instantmessage.NewWeChatClient().SendSlackNotification(cfg, title, message, actionURL)
+}
+
 func sendMSTeamsMessage(productName, workflowName, workflowDisplayName string, taskID int64, uri, title, message string, emailList []string) error {
 	card, err := adaptivecard.NewTextBlockCard(message, title, true)
 	if err != nil {
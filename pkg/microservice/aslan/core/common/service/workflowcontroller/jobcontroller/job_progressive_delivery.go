@@ -0,0 +1,528 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	crClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"github.com/koderover/zadig/v2/pkg/tool/grafana"
+	"github.com/koderover/zadig/v2/pkg/tool/guanceyun"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/updater"
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
+)
+
+const (
+	defaultAnalysisInterval = 60
+	// guanceyun openapi allows 20 requests per minute by default
+	minGuanceyunAnalysisInterval = 10
+)
+
+type ProgressiveDeliveryJobCtl struct {
+	job         *commonmodels.JobTask
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	logger      *zap.SugaredLogger
+	kubeClient  crClient.Client
+	// stableImage is the image of the origin deployment before the delivery, it is restored on rollback
+	stableImage string
+	jobTaskSpec *commonmodels.JobTaskProgressiveDeliverySpec
+	ack         func()
+}
+
+func NewProgressiveDeliveryJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, ack func(), logger *zap.SugaredLogger) *ProgressiveDeliveryJobCtl {
+	jobTaskSpec := &commonmodels.JobTaskProgressiveDeliverySpec{}
+	if err := commonmodels.IToi(job.Spec, jobTaskSpec); err != nil {
+		logger.Error(err)
+	}
+	if jobTaskSpec.Events == nil {
+		jobTaskSpec.Events = &commonmodels.Events{}
+	}
+	job.Spec = jobTaskSpec
+	return &ProgressiveDeliveryJobCtl{
+		job:         job,
+		workflowCtx: workflowCtx,
+		logger:      logger,
+		ack:         ack,
+		jobTaskSpec: jobTaskSpec,
+	}
+}
+
+func (c *ProgressiveDeliveryJobCtl) Clean(ctx context.Context) {}
+
+func (c *ProgressiveDeliveryJobCtl) Run(ctx context.Context) {
+	c.job.Status = config.StatusRunning
+	c.ack()
+
+	var err error
+	c.kubeClient, err = clientmanager.NewKubeClientManager().GetControllerRuntimeClient(c.jobTaskSpec.ClusterID)
+	if err != nil {
+		c.Errorf("can't init k8s client: %v", err)
+		return
+	}
+	deployment, found, err := getter.GetDeployment(c.jobTaskSpec.Namespace, c.jobTaskSpec.WorkloadName, c.kubeClient)
+	if err != nil || !found {
+		c.Errorf("deployment: %s not found: %v", c.jobTaskSpec.WorkloadName, err)
+		return
+	}
+	if c.jobTaskSpec.TotalReplica == 0 && deployment.Spec.Replicas != nil {
+		c.jobTaskSpec.TotalReplica = int(*deployment.Spec.Replicas)
+	}
+
+	_, found, err = getter.GetDeployment(c.jobTaskSpec.Namespace, c.jobTaskSpec.CanaryWorkloadName, c.kubeClient)
+	if err != nil {
+		c.Errorf("get deployment: %s error: %v", c.jobTaskSpec.CanaryWorkloadName, err)
+		return
+	}
+	if found {
+		c.Errorf("canary deployment: %s already exists, another progressive delivery may be running", c.jobTaskSpec.CanaryWorkloadName)
+		return
+	}
+
+	canaryDeployment := deployment.DeepCopy()
+	canaryDeployment.Name = c.jobTaskSpec.CanaryWorkloadName
+	canaryDeployment.ObjectMeta.ResourceVersion = ""
+	canaryDeployment.Spec.Replicas = int32Ptr(0)
+	if canaryDeployment.Spec.Template.Labels == nil {
+		canaryDeployment.Spec.Template.Labels = make(map[string]string)
+	}
+	canaryDeployment.Spec.Template.Labels[config.ProgressiveLabelKey] = config.ProgressiveLabelValue
+	containerFound := false
+	for i := range canaryDeployment.Spec.Template.Spec.Containers {
+		if canaryDeployment.Spec.Template.Spec.Containers[i].Name == c.jobTaskSpec.ContainerName {
+			c.stableImage = canaryDeployment.Spec.Template.Spec.Containers[i].Image
+			canaryDeployment.Spec.Template.Spec.Containers[i].Image = c.jobTaskSpec.Image
+			containerFound = true
+			break
+		}
+	}
+	if !containerFound {
+		c.Errorf("container: %s not found in deployment: %s", c.jobTaskSpec.ContainerName, c.jobTaskSpec.WorkloadName)
+		return
+	}
+	if err := updater.CreateDeploymentV2(ctx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, canaryDeployment); err != nil {
+		c.Errorf("create canary deployment: %s failed: %v", c.jobTaskSpec.CanaryWorkloadName, err)
+		return
+	}
+	c.jobTaskSpec.Events.Info(fmt.Sprintf("canary deployment: %s created", c.jobTaskSpec.CanaryWorkloadName))
+	c.ack()
+
+	c.jobTaskSpec.StepResults = make([]*commonmodels.ProgressiveStepResult, 0, len(c.jobTaskSpec.Steps))
+	for i, step := range c.jobTaskSpec.Steps {
+		if step.Weight >= 100 {
+			break
+		}
+
+		canaryReplica, stableReplica := progressiveReplicas(c.jobTaskSpec.TotalReplica, step.Weight)
+		result := &commonmodels.ProgressiveStepResult{
+			Weight:        step.Weight,
+			CanaryReplica: canaryReplica,
+			StableReplica: stableReplica,
+			Verdict:       config.AnalysisVerdictRunning,
+			StartTime:     time.Now().Unix(),
+		}
+		c.jobTaskSpec.StepResults = append(c.jobTaskSpec.StepResults, result)
+		c.jobTaskSpec.Events.Info(fmt.Sprintf("step %d: shifting %d%% of replicas to the new image", i+1, step.Weight))
+		c.ack()
+
+		if err := c.scale(ctx, canaryReplica, stableReplica); err != nil {
+			result.Verdict = config.AnalysisVerdictFailed
+			result.EndTime = time.Now().Unix()
+			c.abort(ctx, config.StatusFailed, fmt.Sprintf("step %d: %v", i+1, err))
+			return
+		}
+
+		if step.Pause > 0 {
+			c.jobTaskSpec.Events.Info(fmt.Sprintf("step %d: pausing for %ds", i+1, step.Pause))
+			c.ack()
+			if !sleepWithContext(ctx, time.Duration(step.Pause)*time.Second) {
+				result.Verdict = config.AnalysisVerdictSkipped
+				result.EndTime = time.Now().Unix()
+				c.abort(ctx, config.StatusCancelled, "progressive delivery cancelled")
+				return
+			}
+		}
+
+		result.Verdict = c.analyse(ctx, step, result)
+		result.EndTime = time.Now().Unix()
+		c.ack()
+		switch result.Verdict {
+		case config.AnalysisVerdictSuccessful:
+			c.jobTaskSpec.Events.Info(fmt.Sprintf("step %d: analysis successful", i+1))
+		case config.AnalysisVerdictSkipped:
+			c.abort(ctx, config.StatusCancelled, "progressive delivery cancelled")
+			return
+		default:
+			c.abort(ctx, config.StatusFailed, fmt.Sprintf("step %d: analysis %s, aborting", i+1, result.Verdict))
+			return
+		}
+	}
+
+	if err := c.promote(ctx); err != nil {
+		status := config.StatusFailed
+		if ctx.Err() != nil {
+			status = config.StatusCancelled
+		}
+		c.abort(ctx, status, fmt.Sprintf("promote failed: %v, rolling back", err))
+		return
+	}
+	c.job.Status = config.StatusPassed
+}
+
+// scale shifts replicas between the canary and the stable deployment, the canary is scaled
+// first so that the serving capacity never drops during the shift.
+func (c *ProgressiveDeliveryJobCtl) scale(ctx context.Context, canaryReplica, stableReplica int) error {
+	if err := updater.ScaleDeploymentV2(ctx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, c.jobTaskSpec.CanaryWorkloadName, canaryReplica); err != nil {
+		return fmt.Errorf("scale canary deployment: %s failed: %v", c.jobTaskSpec.CanaryWorkloadName, err)
+	}
+	if _, err := waitDeploymentReady(ctx, c.jobTaskSpec.CanaryWorkloadName, c.jobTaskSpec.Namespace, c.timeout(), c.kubeClient, c.logger); err != nil {
+		return err
+	}
+	c.jobTaskSpec.Events.Info(fmt.Sprintf("canary deployment: %s replica set to %d", c.jobTaskSpec.CanaryWorkloadName, canaryReplica))
+	c.ack()
+
+	if err := updater.ScaleDeploymentV2(ctx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, c.jobTaskSpec.WorkloadName, stableReplica); err != nil {
+		return fmt.Errorf("scale deployment: %s failed: %v", c.jobTaskSpec.WorkloadName, err)
+	}
+	if _, err := waitDeploymentReady(ctx, c.jobTaskSpec.WorkloadName, c.jobTaskSpec.Namespace, c.timeout(), c.kubeClient, c.logger); err != nil {
+		return err
+	}
+	c.jobTaskSpec.Events.Info(fmt.Sprintf("deployment: %s replica set to %d", c.jobTaskSpec.WorkloadName, stableReplica))
+	c.ack()
+	return nil
+}
+
+// promote rolls the new image out to the origin deployment and removes the canary deployment.
+func (c *ProgressiveDeliveryJobCtl) promote(ctx context.Context) error {
+	if err := updater.UpdateDeploymentV2(ctx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, c.jobTaskSpec.WorkloadName, func(d *appsv1.Deployment) error {
+		d.Spec.Replicas = int32Ptr(int32(c.jobTaskSpec.TotalReplica))
+		for i, container := range d.Spec.Template.Spec.Containers {
+			if container.Name == c.jobTaskSpec.ContainerName {
+				d.Spec.Template.Spec.Containers[i].Image = c.jobTaskSpec.Image
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("update deployment: %s failed: %v", c.jobTaskSpec.WorkloadName, err)
+	}
+	if _, err := waitDeploymentReady(ctx, c.jobTaskSpec.WorkloadName, c.jobTaskSpec.Namespace, c.timeout(), c.kubeClient, c.logger); err != nil {
+		return err
+	}
+	c.jobTaskSpec.Events.Info(fmt.Sprintf("deployment: %s image set to %s", c.jobTaskSpec.WorkloadName, c.jobTaskSpec.Image))
+	c.ack()
+
+	if err := updater.DeleteDeploymentAndWaitV2(ctx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, time.Duration(c.timeout())*time.Second, updater.WithName(c.jobTaskSpec.CanaryWorkloadName)); err != nil {
+		return fmt.Errorf("delete canary deployment: %s failed: %v", c.jobTaskSpec.CanaryWorkloadName, err)
+	}
+	c.jobTaskSpec.Events.Info(fmt.Sprintf("canary deployment: %s deleted", c.jobTaskSpec.CanaryWorkloadName))
+	c.jobTaskSpec.Promoted = true
+	c.ack()
+	return nil
+}
+
+// abort restores the origin deployment and removes the canary deployment, the job ends with the given status.
+func (c *ProgressiveDeliveryJobCtl) abort(ctx context.Context, status config.Status, reason string) {
+	c.jobTaskSpec.Events.Error(reason)
+	c.ack()
+
+	// the rollback must finish even if the task has been cancelled
+	rollbackCtx := context.Background()
+	rolledBack := true
+	// the image of the origin deployment is restored as well since a failed promotion may have changed it
+	if err := updater.UpdateDeploymentV2(rollbackCtx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, c.jobTaskSpec.WorkloadName, func(d *appsv1.Deployment) error {
+		d.Spec.Replicas = int32Ptr(int32(c.jobTaskSpec.TotalReplica))
+		for i, container := range d.Spec.Template.Spec.Containers {
+			if container.Name == c.jobTaskSpec.ContainerName && c.stableImage != "" {
+				d.Spec.Template.Spec.Containers[i].Image = c.stableImage
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		rolledBack = false
+		c.jobTaskSpec.Events.Error(fmt.Sprintf("rollback deployment: %s replica failed: %v", c.jobTaskSpec.WorkloadName, err))
+	} else if _, err := waitDeploymentReady(rollbackCtx, c.jobTaskSpec.WorkloadName, c.jobTaskSpec.Namespace, c.timeout(), c.kubeClient, c.logger); err != nil {
+		rolledBack = false
+		c.jobTaskSpec.Events.Error(fmt.Sprintf("rollback deployment: %s failed: %v", c.jobTaskSpec.WorkloadName, err))
+	} else {
+		c.jobTaskSpec.Events.Info(fmt.Sprintf("deployment: %s replica restored to %d", c.jobTaskSpec.WorkloadName, c.jobTaskSpec.TotalReplica))
+	}
+	if err := updater.DeleteDeploymentAndWaitV2(rollbackCtx, c.jobTaskSpec.ClusterID, c.jobTaskSpec.Namespace, time.Duration(c.timeout())*time.Second, updater.WithName(c.jobTaskSpec.CanaryWorkloadName)); err != nil {
+		rolledBack = false
+		c.jobTaskSpec.Events.Error(fmt.Sprintf("delete canary deployment: %s failed: %v", c.jobTaskSpec.CanaryWorkloadName, err))
+	} else {
+		c.jobTaskSpec.Events.Info(fmt.Sprintf("canary deployment: %s deleted", c.jobTaskSpec.CanaryWorkloadName))
+	}
+	c.jobTaskSpec.RolledBack = rolledBack
+
+	c.job.Status = status
+	c.job.Error = reason
+	c.logger.Error(reason)
+}
+
+type analysisRun struct {
+	analysis *commonmodels.ProgressiveAnalysis
+	result   *commonmodels.ProgressiveAnalysisResult
+	info     *commonmodels.Observability
+	next     time.Time
+}
+
+// analyse takes the measurements of all analyses of the step, each analysis at its own interval.
+// It returns as soon as one analysis has failed or become inconclusive.
+func (c *ProgressiveDeliveryJobCtl) analyse(ctx context.Context, step *commonmodels.ProgressiveStep, stepResult *commonmodels.ProgressiveStepResult) config.AnalysisVerdict {
+	if len(step.Analyses) == 0 {
+		return config.AnalysisVerdictSuccessful
+	}
+
+	start := time.Now()
+	runs := make([]*analysisRun, 0, len(step.Analyses))
+	stepResult.Analyses = make([]*commonmodels.ProgressiveAnalysisResult, 0, len(step.Analyses))
+	for _, analysis := range step.Analyses {
+		result := &commonmodels.ProgressiveAnalysisResult{
+			Name:         analysis.Name,
+			Verdict:      config.AnalysisVerdictRunning,
+			Measurements: make([]*commonmodels.AnalysisMeasurement, 0),
+		}
+		stepResult.Analyses = append(stepResult.Analyses, result)
+		run := &analysisRun{analysis: analysis, result: result, next: start}
+
+		info, err := mongodb.NewObservabilityColl().GetByID(context.Background(), analysis.ID)
+		if err != nil {
+			result.Verdict = config.AnalysisVerdictInconclusive
+			result.Measurements = append(result.Measurements, &commonmodels.AnalysisMeasurement{
+				Verdict: config.AnalysisVerdictInconclusive,
+				Error:   fmt.Sprintf("get observability info error: %v", err),
+				Time:    time.Now().Unix(),
+			})
+			return config.AnalysisVerdictInconclusive
+		}
+		run.info = info
+		runs = append(runs, run)
+	}
+	c.ack()
+
+	for {
+		finished := true
+		for _, run := range runs {
+			if run.result.Verdict != config.AnalysisVerdictRunning {
+				continue
+			}
+			finished = false
+			if time.Now().Before(run.next) {
+				continue
+			}
+
+			measurement := &commonmodels.AnalysisMeasurement{Time: time.Now().Unix()}
+			value, err := measureAnalysis(run.analysis, run.info, start)
+			if err != nil {
+				measurement.Verdict = config.AnalysisVerdictInconclusive
+				measurement.Error = err.Error()
+			} else {
+				measurement.Value = value
+				ok, err := evaluateAnalysisCondition(value, run.analysis.Condition, run.analysis.Threshold)
+				switch {
+				case err != nil:
+					measurement.Verdict = config.AnalysisVerdictInconclusive
+					measurement.Error = err.Error()
+				case ok:
+					measurement.Verdict = config.AnalysisVerdictSuccessful
+				default:
+					measurement.Verdict = config.AnalysisVerdictFailed
+				}
+			}
+			run.result.Measurements = append(run.result.Measurements, measurement)
+			run.result.Verdict = analysisVerdict(run.result.Measurements, analysisCount(run.analysis), run.analysis.FailureLimit)
+			run.next = time.Now().Add(analysisInterval(run.analysis))
+			c.ack()
+
+			if run.result.Verdict == config.AnalysisVerdictFailed || run.result.Verdict == config.AnalysisVerdictInconclusive {
+				return run.result.Verdict
+			}
+		}
+		if finished {
+			return config.AnalysisVerdictSuccessful
+		}
+
+		if !sleepWithContext(ctx, time.Second) {
+			for _, run := range runs {
+				if run.result.Verdict == config.AnalysisVerdictRunning {
+					run.result.Verdict = config.AnalysisVerdictSkipped
+				}
+			}
+			return config.AnalysisVerdictSkipped
+		}
+	}
+}
+
+// measureAnalysis takes one measurement of the analysis, since is the time the analysis of the step started.
+func measureAnalysis(analysis *commonmodels.ProgressiveAnalysis, info *commonmodels.Observability, since time.Time) (float64, error) {
+	switch analysis.Provider {
+	case config.ObservabilityTypeGrafana:
+		return grafana.NewClient(info.Host, info.GrafanaToken).QueryPrometheus(analysis.DataSourceUID, analysis.Query, time.Now())
+	case config.ObservabilityTypePrometheus:
+		return prometheus.NewClient(info.Host, info.ApiKey).Query(analysis.Query, time.Now())
+	case config.ObservabilityTypeGuanceyun:
+		args := make([]*guanceyun.SearchEventByMonitorArg, 0, len(analysis.Monitors))
+		monitorMap := make(map[string]*commonmodels.GuanceyunMonitor)
+		for _, monitor := range analysis.Monitors {
+			args = append(args, &guanceyun.SearchEventByMonitorArg{
+				CheckerName: monitor.Name,
+				CheckerID:   monitor.ID,
+			})
+			monitorMap[monitor.ID] = monitor
+		}
+		events, err := guanceyun.NewClient(info.Host, info.ApiKey).SearchEventByChecker(args, since.UnixMilli(), time.Now().UnixMilli())
+		if err != nil {
+			return 0, err
+		}
+		alerting := 0
+		for _, event := range events {
+			if monitor, ok := monitorMap[event.CheckerID]; ok && guanceyun.LevelMap[event.EventLevel] >= guanceyun.LevelMap[monitor.Level] {
+				alerting++
+			}
+		}
+		return float64(alerting), nil
+	default:
+		return 0, fmt.Errorf("unsupported analysis provider: %s", analysis.Provider)
+	}
+}
+
+func evaluateAnalysisCondition(value float64, condition config.AnalysisCondition, threshold float64) (bool, error) {
+	if math.IsNaN(value) {
+		return false, fmt.Errorf("measured value is NaN")
+	}
+	switch condition {
+	case config.AnalysisConditionLessThan:
+		return value < threshold, nil
+	case config.AnalysisConditionLessOrEqual:
+		return value <= threshold, nil
+	case config.AnalysisConditionGreaterThan:
+		return value > threshold, nil
+	case config.AnalysisConditionGreaterOrEqual:
+		return value >= threshold, nil
+	case config.AnalysisConditionEqual:
+		return value == threshold, nil
+	default:
+		return false, fmt.Errorf("invalid analysis condition: %s", condition)
+	}
+}
+
+// analysisVerdict decides the verdict of an analysis from its measurements. The analysis fails once the
+// failed measurements exceed the failure limit, and is inconclusive once the failed and errored ones do.
+func analysisVerdict(measurements []*commonmodels.AnalysisMeasurement, count, failureLimit int) config.AnalysisVerdict {
+	failed, inconclusive := 0, 0
+	for _, measurement := range measurements {
+		switch measurement.Verdict {
+		case config.AnalysisVerdictFailed:
+			failed++
+		case config.AnalysisVerdictInconclusive:
+			inconclusive++
+		}
+	}
+	switch {
+	case failed > failureLimit:
+		return config.AnalysisVerdictFailed
+	case failed+inconclusive > failureLimit:
+		return config.AnalysisVerdictInconclusive
+	case len(measurements) >= count:
+		return config.AnalysisVerdictSuccessful
+	default:
+		return config.AnalysisVerdictRunning
+	}
+}
+
+func analysisCount(analysis *commonmodels.ProgressiveAnalysis) int {
+	if analysis.Count <= 0 {
+		return 1
+	}
+	return analysis.Count
+}
+
+func analysisInterval(analysis *commonmodels.ProgressiveAnalysis) time.Duration {
+	interval := analysis.Interval
+	if interval <= 0 {
+		interval = defaultAnalysisInterval
+	}
+	if analysis.Provider == config.ObservabilityTypeGuanceyun && interval < minGuanceyunAnalysisInterval {
+		interval = minGuanceyunAnalysisInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// progressiveReplicas splits the total replicas by the canary weight, a step with a positive weight
+// always runs at least one canary replica.
+func progressiveReplicas(total, weight int) (int, int) {
+	canary := int(math.Ceil(float64(total) * float64(weight) / 100))
+	if weight > 0 && canary == 0 {
+		canary = 1
+	}
+	if canary > total {
+		canary = total
+	}
+	return canary, total - canary
+}
+
+// sleepWithContext returns false if the context is done before the duration elapses.
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (c *ProgressiveDeliveryJobCtl) Errorf(format string, a ...any) {
+	errMsg := fmt.Sprintf(format, a...)
+	logError(c.job, errMsg, c.logger)
+	c.jobTaskSpec.Events.Error(errMsg)
+}
+
+func (c *ProgressiveDeliveryJobCtl) timeout() int64 {
+	if c.jobTaskSpec.DeployTimeout == 0 {
+		return setting.DeployTimeout
+	}
+	return c.jobTaskSpec.DeployTimeout * 60
+}
+
+func (c *ProgressiveDeliveryJobCtl) SaveInfo(ctx context.Context) error {
+	return mongodb.NewJobInfoColl().Create(context.TODO(), &commonmodels.JobInfo{
+		Type:                c.job.JobType,
+		WorkflowName:        c.workflowCtx.WorkflowName,
+		WorkflowDisplayName: c.workflowCtx.WorkflowDisplayName,
+		TaskID:              c.workflowCtx.TaskID,
+		ProductName:         c.workflowCtx.ProjectName,
+		StartTime:           c.job.StartTime,
+		EndTime:             c.job.EndTime,
+		Duration:            c.job.EndTime - c.job.StartTime,
+		Status:              string(c.job.Status),
+	})
+}
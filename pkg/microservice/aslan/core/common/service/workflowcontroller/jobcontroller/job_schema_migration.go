@@ -0,0 +1,297 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	fsservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/database"
+	"github.com/koderover/zadig/v2/pkg/types"
+	runtimejob "github.com/koderover/zadig/v2/pkg/types/job"
+)
+
+type SchemaMigrationJobCtl struct {
+	job         *commonmodels.JobTask
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	logger      *zap.SugaredLogger
+	jobTaskSpec *commonmodels.JobTaskSchemaMigrationSpec
+	ack         func()
+}
+
+func NewSchemaMigrationJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, ack func(), logger *zap.SugaredLogger) *SchemaMigrationJobCtl {
+	jobTaskSpec := &commonmodels.JobTaskSchemaMigrationSpec{}
+	if err := commonmodels.IToi(job.Spec, jobTaskSpec); err != nil {
+		logger.Error(err)
+	}
+	job.Spec = jobTaskSpec
+	return &SchemaMigrationJobCtl{
+		job:         job,
+		workflowCtx: workflowCtx,
+		logger:      logger,
+		ack:         ack,
+		jobTaskSpec: jobTaskSpec,
+	}
+}
+
+func (c *SchemaMigrationJobCtl) Clean(ctx context.Context) {}
+
+func (c *SchemaMigrationJobCtl) Run(ctx context.Context) {
+	c.job.Status = config.StatusRunning
+	c.ack()
+
+	if err := c.run(ctx); err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return
+	}
+
+	c.job.Status = config.StatusPassed
+}
+
+func (c *SchemaMigrationJobCtl) run(ctx context.Context) error {
+	info, err := mongodb.NewDBInstanceColl().Find(&mongodb.DBInstanceCollFindOption{Id: c.jobTaskSpec.ID})
+	if err != nil {
+		return fmt.Errorf("failed to find db instance, error: %v", err)
+	}
+
+	files, err := downloadMigrationFiles(c.jobTaskSpec.Repo, c.jobTaskSpec.Path)
+	if err != nil {
+		return err
+	}
+	migrations, err := database.ParseMigrations(files)
+	if err != nil {
+		return fmt.Errorf("failed to parse migration files, error: %v", err)
+	}
+
+	db, dialect, err := database.Open(info.Type, info.ConnInfo())
+	if err != nil {
+		return fmt.Errorf("connect db error: %v", err)
+	}
+	defer db.Close()
+	c.jobTaskSpec.Type = dialect.Type()
+
+	migrator, err := database.NewMigrator(db, dialect, c.jobTaskSpec.HistoryTable)
+	if err != nil {
+		return err
+	}
+	if err := migrator.EnsureHistoryTable(ctx); err != nil {
+		return err
+	}
+	applied, err := migrator.Applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	infos := database.ResolveMigrations(migrations, applied)
+	c.setResults(infos)
+	c.ack()
+
+	if !c.jobTaskSpec.IgnoreDrift {
+		drifted := make([]string, 0)
+		for _, info := range infos {
+			if info.Status == setting.SchemaMigrationStatusDrifted || info.Status == setting.SchemaMigrationStatusMissing {
+				drifted = append(drifted, info.Version)
+			}
+		}
+		if len(drifted) > 0 {
+			return fmt.Errorf("migration drift detected, the migration files of version %s have been changed or removed after being applied", strings.Join(drifted, ", "))
+		}
+	}
+
+	timeout := time.Duration(c.jobTaskSpec.StatementTimeout) * time.Second
+	switch c.jobTaskSpec.Action {
+	case config.SchemaMigrationActionUp:
+		for i, info := range infos {
+			if info.Status != setting.SchemaMigrationStatusPending {
+				continue
+			}
+			if c.jobTaskSpec.TargetVersion != "" && database.CompareVersions(info.Version, c.jobTaskSpec.TargetVersion) > 0 {
+				break
+			}
+
+			result := c.jobTaskSpec.Migrations[i]
+			elapsed, err := migrator.Apply(ctx, info.Migration, c.workflowCtx.WorkflowTaskCreatorUsername, timeout)
+			result.ElapsedTime = elapsed
+			if err != nil {
+				result.Status = setting.SchemaMigrationStatusFailed
+				result.Error = err.Error()
+				c.ack()
+				return fmt.Errorf("failed to apply migration %s, error: %v", info.Version, err)
+			}
+			result.Status = setting.SchemaMigrationStatusSuccess
+			result.InstalledBy = c.workflowCtx.WorkflowTaskCreatorUsername
+			result.InstalledAt = time.Now().Unix()
+			result.AppliedChecksum = info.Migration.Checksum
+			c.updateVersions()
+			c.ack()
+		}
+	case config.SchemaMigrationActionDown:
+		reverted := 0
+		for i := len(infos) - 1; i >= 0; i-- {
+			info := infos[i]
+			if info.Applied == nil {
+				continue
+			}
+			if c.jobTaskSpec.TargetVersion == "" && reverted > 0 {
+				break
+			}
+			if c.jobTaskSpec.TargetVersion != "" && database.CompareVersions(info.Version, c.jobTaskSpec.TargetVersion) <= 0 {
+				break
+			}
+			if info.Migration == nil {
+				return fmt.Errorf("failed to revert migration %s, its migration files are missing", info.Version)
+			}
+
+			result := c.jobTaskSpec.Migrations[i]
+			elapsed, err := migrator.Revert(ctx, info.Migration, timeout)
+			result.ElapsedTime = elapsed
+			if err != nil {
+				result.Status = setting.SchemaMigrationStatusFailed
+				result.Error = err.Error()
+				c.ack()
+				return fmt.Errorf("failed to revert migration %s, error: %v", info.Version, err)
+			}
+			result.Status = setting.SchemaMigrationStatusReverted
+			reverted++
+			c.updateVersions()
+			c.ack()
+		}
+	case config.SchemaMigrationActionInfo:
+	default:
+		return fmt.Errorf("invalid schema migration action: %s", c.jobTaskSpec.Action)
+	}
+
+	c.updateVersions()
+	c.workflowCtx.GlobalContextSet(runtimejob.GetJobOutputKey(c.job.Key, config.SchemaMigrationOutputCurrentVersion), c.jobTaskSpec.CurrentVersion)
+	c.workflowCtx.GlobalContextSet(runtimejob.GetJobOutputKey(c.job.Key, config.SchemaMigrationOutputPendingCount), strconv.Itoa(len(c.jobTaskSpec.PendingVersions)))
+	return nil
+}
+
+func (c *SchemaMigrationJobCtl) setResults(infos []*database.MigrationInfo) {
+	c.jobTaskSpec.Migrations = make([]*commonmodels.SchemaMigrationResult, 0, len(infos))
+	for _, info := range infos {
+		result := &commonmodels.SchemaMigrationResult{
+			Version: info.Version,
+			Status:  info.Status,
+		}
+		if info.Migration != nil {
+			result.Description = info.Migration.Description
+			result.File = info.Migration.UpFile
+			result.Checksum = info.Migration.Checksum
+		}
+		if info.Applied != nil {
+			result.Description = info.Applied.Description
+			result.AppliedChecksum = info.Applied.Checksum
+			result.InstalledBy = info.Applied.InstalledBy
+			result.InstalledAt = info.Applied.InstalledAt
+			result.ElapsedTime = info.Applied.ExecutionTime
+		}
+		c.jobTaskSpec.Migrations = append(c.jobTaskSpec.Migrations, result)
+	}
+	c.updateVersions()
+}
+
+// updateVersions refreshes the applied and pending version lists from the migration results.
+func (c *SchemaMigrationJobCtl) updateVersions() {
+	c.jobTaskSpec.AppliedVersions = make([]string, 0)
+	c.jobTaskSpec.PendingVersions = make([]string, 0)
+	c.jobTaskSpec.CurrentVersion = ""
+	for _, result := range c.jobTaskSpec.Migrations {
+		switch result.Status {
+		case setting.SchemaMigrationStatusApplied, setting.SchemaMigrationStatusSuccess, setting.SchemaMigrationStatusDrifted, setting.SchemaMigrationStatusMissing:
+			c.jobTaskSpec.AppliedVersions = append(c.jobTaskSpec.AppliedVersions, result.Version)
+			c.jobTaskSpec.CurrentVersion = result.Version
+		case setting.SchemaMigrationStatusPending, setting.SchemaMigrationStatusReverted:
+			c.jobTaskSpec.PendingVersions = append(c.jobTaskSpec.PendingVersions, result.Version)
+		case setting.SchemaMigrationStatusFailed:
+			if result.AppliedChecksum != "" {
+				c.jobTaskSpec.AppliedVersions = append(c.jobTaskSpec.AppliedVersions, result.Version)
+				c.jobTaskSpec.CurrentVersion = result.Version
+			} else {
+				c.jobTaskSpec.PendingVersions = append(c.jobTaskSpec.PendingVersions, result.Version)
+			}
+		}
+	}
+}
+
+// downloadMigrationFiles downloads the sql files in the migration directory of the repo, keyed by file path.
+func downloadMigrationFiles(repo *types.Repository, dir string) (map[string]string, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("migration repository is not specified")
+	}
+
+	getter, err := fsservice.GetTreeGetter(repo.CodehostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration repository client, error: %v", err)
+	}
+
+	owner := repo.GetRepoNamespace()
+	ref := repo.Branch
+	switch {
+	case repo.CommitID != "":
+		ref = repo.CommitID
+	case repo.Tag != "":
+		ref = repo.Tag
+	}
+
+	nodes, err := getter.GetTree(owner, repo.RepoName, dir, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration files in %s/%s:%s, error: %v", owner, repo.RepoName, dir, err)
+	}
+
+	files := make(map[string]string)
+	for _, node := range nodes {
+		if node.IsDir || path.Ext(node.Name) != ".sql" {
+			continue
+		}
+		filePath := node.FullPath
+		if filePath == "" {
+			filePath = path.Join(dir, node.Name)
+		}
+		content, err := getter.GetFileContent(owner, repo.RepoName, filePath, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download migration file %s, error: %v", filePath, err)
+		}
+		files[filePath] = string(content)
+	}
+	return files, nil
+}
+
+func (c *SchemaMigrationJobCtl) SaveInfo(ctx context.Context) error {
+	return mongodb.NewJobInfoColl().Create(context.TODO(), &commonmodels.JobInfo{
+		Type:                c.job.JobType,
+		WorkflowName:        c.workflowCtx.WorkflowName,
+		WorkflowDisplayName: c.workflowCtx.WorkflowDisplayName,
+		TaskID:              c.workflowCtx.TaskID,
+		ProductName:         c.workflowCtx.ProjectName,
+		StartTime:           c.job.StartTime,
+		EndTime:             c.job.EndTime,
+		Duration:            c.job.EndTime - c.job.StartTime,
+		Status:              string(c.job.Status),
+	})
+}
@@ -30,6 +30,8 @@ import (
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/database"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
 
 type SQLJobCtl struct {
@@ -69,14 +71,8 @@ func (c *SQLJobCtl) Run(ctx context.Context) {
 	}
 	c.dbInfo = info
 
-	switch info.Type {
-	case config.DBInstanceTypeMySQL, config.DBInstanceTypeMariaDB:
-		if err := c.ExecMySQLStatement(); err != nil {
-			logError(c.job, err.Error(), c.logger)
-			return
-		}
-	default:
-		logError(c.job, "invalid db type", c.logger)
+	if err := ExecSQLStatements(ctx, c.dbInfo, c.jobTaskSpec, c.ack); err != nil {
+		logError(c.job, err.Error(), c.logger)
 		return
 	}
 
@@ -84,49 +80,213 @@ func (c *SQLJobCtl) Run(ctx context.Context) {
 	return
 }
 
-func (c *SQLJobCtl) ExecMySQLStatement() error {
-	info := c.dbInfo
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
 
-	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8&multiStatements=true", info.Username, info.Password, info.Host, info.Port))
+// ExplainSQLStatements splits the sql of the spec into statements and records the execution plan of each of them in
+// spec.Results without executing anything, so that the plans can be reviewed before the job is approved and executed.
+func ExplainSQLStatements(ctx context.Context, info *commonmodels.DBInstance, spec *commonmodels.JobTaskSQLSpec) error {
+	db, dialect, err := openSQLStatements(info, spec)
 	if err != nil {
-		return errors.Errorf("connect db error: %v", err)
+		return err
 	}
 	defer db.Close()
 
-	sqls := strings.SplitAfter(c.jobTaskSpec.SQL, ";")
-	for _, sql := range sqls {
-		if sql == "" {
-			continue
-		}
+	explainSQLStatements(ctx, db, dialect, spec, func() {})
+	return nil
+}
 
-		execResult := &commonmodels.SQLExecResult{}
+// ExecSQLStatements splits the sql of the spec into statements with the dialect of the db instance and runs them,
+// the result of each statement is recorded in spec.Results. onUpdate, if not nil, is called whenever the results change.
+// The statements are explained before any of them is executed, the plans are kept in the results.
+func ExecSQLStatements(ctx context.Context, info *commonmodels.DBInstance, spec *commonmodels.JobTaskSQLSpec, onUpdate func()) error {
+	if onUpdate == nil {
+		onUpdate = func() {}
+	}
+
+	db, dialect, err := openSQLStatements(info, spec)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	onUpdate()
 
-		execResult.SQL = strings.TrimSpace(sql)
+	explainSQLStatements(ctx, db, dialect, spec, onUpdate)
+	for _, execResult := range spec.Results {
 		execResult.Status = setting.SQLExecStatusNotExec
+		execResult.ElapsedTime = 0
+	}
+	onUpdate()
+
+	var (
+		executor sqlExecutor = db
+		tx       *sql.Tx
+	)
+	if spec.Transactional {
+		// statements such as DDL in mysql commit the transaction implicitly, a rollback would not undo them
+		for _, execResult := range spec.Results {
+			if dialect.ImplicitCommit(execResult.SQL) {
+				return errors.Errorf("statement \"%s\" commits implicitly and cannot run in transactional mode", execResult.SQL)
+			}
+		}
+		tx, err = db.BeginTx(ctx, nil)
+		if err != nil {
+			return errors.Errorf("begin transaction error: %v", err)
+		}
+		executor = tx
+	}
 
-		c.jobTaskSpec.Results = append(c.jobTaskSpec.Results, execResult)
+	rollback := func() {
+		if tx == nil {
+			return
+		}
+		if err := tx.Rollback(); err != nil {
+			log.Errorf("failed to rollback sql transaction, error: %v", err)
+			return
+		}
+		for _, execResult := range spec.Results {
+			if execResult.Status == setting.SQLExecStatusSuccess {
+				execResult.Status = setting.SQLExecStatusRollback
+			}
+		}
 	}
 
-	for _, execResult := range c.jobTaskSpec.Results {
+	for _, execResult := range spec.Results {
+		stmtCtx, cancel := statementContext(ctx, spec.StatementTimeout)
 		now := time.Now()
-		result, err := db.Exec(execResult.SQL)
+		result, err := executor.ExecContext(stmtCtx, execResult.SQL)
+		cancel()
+		execResult.ElapsedTime = time.Now().Sub(now).Milliseconds()
 		if err != nil {
 			execResult.Status = setting.SQLExecStatusFailed
+			execResult.Error = err.Error()
+			execResult.ErrorCode = dialect.ErrorCode(err)
+			rollback()
+			onUpdate()
 			return errors.Errorf("exec SQL \"%s\" error: %v", execResult.SQL, err)
 		}
 		execResult.Status = setting.SQLExecStatusSuccess
-		execResult.ElapsedTime = time.Now().Sub(now).Milliseconds()
 
 		rowsAffected, err := result.RowsAffected()
 		if err != nil {
+			rollback()
+			onUpdate()
 			return errors.Errorf("get affect rows error: %v", err)
 		}
 		execResult.RowsAffected = rowsAffected
+		onUpdate()
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			for _, execResult := range spec.Results {
+				execResult.Status = setting.SQLExecStatusRollback
+			}
+			onUpdate()
+			return errors.Errorf("commit transaction error: %v", err)
+		}
 	}
 
 	return nil
 }
 
+// openSQLStatements connects to the db instance and resets spec.Results to the statements split from the sql of the spec.
+func openSQLStatements(info *commonmodels.DBInstance, spec *commonmodels.JobTaskSQLSpec) (*sql.DB, database.Dialect, error) {
+	db, dialect, err := database.Open(info.Type, info.ConnInfo())
+	if err != nil {
+		return nil, nil, errors.Errorf("connect db error: %v", err)
+	}
+	spec.Type = dialect.Type()
+
+	stmts, err := dialect.Split(spec.SQL)
+	if err != nil {
+		db.Close()
+		return nil, nil, errors.Errorf("parse sql error: %v", err)
+	}
+
+	spec.Results = make([]*commonmodels.SQLExecResult, 0, len(stmts))
+	for _, stmt := range stmts {
+		spec.Results = append(spec.Results, &commonmodels.SQLExecResult{
+			SQL:    stmt,
+			Status: setting.SQLExecStatusNotExec,
+		})
+	}
+	return db, dialect, nil
+}
+
+// explainSQLStatements records the execution plan of every statement which supports EXPLAIN, statements such as DDL
+// are left without a plan. A statement may depend on the ones before it, e.g. an insert into a table created by the
+// same script, so a failed EXPLAIN is recorded as the plan of the statement instead of failing the job.
+func explainSQLStatements(ctx context.Context, db sqlExecutor, dialect database.Dialect, spec *commonmodels.JobTaskSQLSpec, onUpdate func()) {
+	for _, execResult := range spec.Results {
+		explainSQL, ok := dialect.Explain(execResult.SQL)
+		if !ok {
+			continue
+		}
+
+		stmtCtx, cancel := statementContext(ctx, spec.StatementTimeout)
+		now := time.Now()
+		plan, err := queryAsText(stmtCtx, db, explainSQL)
+		cancel()
+		execResult.ElapsedTime = time.Now().Sub(now).Milliseconds()
+		if err != nil {
+			execResult.Plan = fmt.Sprintf("explain error: %v", err)
+		} else {
+			execResult.Status = setting.SQLExecStatusExplained
+			execResult.Plan = plan
+		}
+		onUpdate()
+	}
+}
+
+func statementContext(ctx context.Context, timeout int64) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+}
+
+// queryAsText runs the query and renders the rows as text, one row per line with columns separated by " | ".
+func queryAsText(ctx context.Context, db sqlExecutor, query string) (string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{strings.Join(columns, " | ")}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return "", err
+		}
+		fields := make([]string, 0, len(values))
+		for _, v := range values {
+			if v.Valid {
+				fields = append(fields, v.String)
+			} else {
+				fields = append(fields, "NULL")
+			}
+		}
+		lines = append(lines, strings.Join(fields, " | "))
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
 func (c *SQLJobCtl) SaveInfo(ctx context.Context) error {
 	return mongodb.NewJobInfoColl().Create(context.TODO(), &commonmodels.JobInfo{
 		Type:                c.job.JobType,
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/tool/terraform"
+	runtimejob "github.com/koderover/zadig/v2/pkg/types/job"
+)
+
+const (
+	terraformPhasePlan  = "plan"
+	terraformPhaseApply = "apply"
+)
+
+type TerraformJobCtl struct {
+	job         *commonmodels.JobTask
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	logger      *zap.SugaredLogger
+	jobTaskSpec *commonmodels.JobTaskTerraformSpec
+	ack         func()
+}
+
+func NewTerraformJobCtl(job *commonmodels.JobTask, workflowCtx *commonmodels.WorkflowTaskCtx, ack func(), logger *zap.SugaredLogger) *TerraformJobCtl {
+	jobTaskSpec := &commonmodels.JobTaskTerraformSpec{}
+	if err := commonmodels.IToi(job.Spec, jobTaskSpec); err != nil {
+		logger.Error(err)
+	}
+	job.Spec = jobTaskSpec
+	return &TerraformJobCtl{
+		job:         job,
+		workflowCtx: workflowCtx,
+		logger:      logger,
+		ack:         ack,
+		jobTaskSpec: jobTaskSpec,
+	}
+}
+
+func (c *TerraformJobCtl) Clean(ctx context.Context) {}
+
+func (c *TerraformJobCtl) Run(ctx context.Context) {
+	c.job.Status = config.StatusRunning
+	c.ack()
+
+	if err := c.run(ctx); err != nil {
+		logError(c.job, err.Error(), c.logger)
+		return
+	}
+}
+
+func (c *TerraformJobCtl) run(ctx context.Context) error {
+	if c.jobTaskSpec.Plan == nil || c.jobTaskSpec.Apply == nil {
+		return fmt.Errorf("terraform plan or apply spec not found")
+	}
+
+	planJob, err := c.runPhase(ctx, terraformPhasePlan, c.jobTaskSpec.Plan)
+	if err != nil {
+		return err
+	}
+	if planJob.Status != config.StatusPassed {
+		c.job.Status = planJob.Status
+		c.job.Error = planJob.Error
+		return nil
+	}
+
+	summary, err := c.getPlanSummary()
+	if err != nil {
+		return fmt.Errorf("failed to get the plan summary, error: %v", err)
+	}
+	c.jobTaskSpec.PlanSummary = summary
+	c.setOutputs()
+	c.ack()
+	c.logger.Infof("terraform job %s plan: %s", c.job.Name, summary)
+
+	if !summary.HasChanges() {
+		c.job.Status = config.StatusPassed
+		return nil
+	}
+
+	if c.jobTaskSpec.Approval != nil {
+		c.job.Status = config.StatusWaitingApprove
+		c.ack()
+		sendJobNotifications(c.workflowCtx, c.job, config.StatusWaitingApprove, c.logger)
+
+		status, err := waitForApprove(ctx, c.jobTaskSpec.Approval, c.workflowCtx, c.job, c.ack)
+		if err != nil {
+			c.job.Status = status
+			c.job.Error = err.Error()
+			return nil
+		}
+		if status != config.StatusPassed {
+			c.job.Status = status
+			return nil
+		}
+		c.job.Status = config.StatusRunning
+		c.ack()
+	}
+
+	applyJob, err := c.runPhase(ctx, terraformPhaseApply, c.jobTaskSpec.Apply)
+	if err != nil {
+		return err
+	}
+	c.job.Status = applyJob.Status
+	c.job.Error = applyJob.Error
+	c.jobTaskSpec.Applied = applyJob.Status == config.StatusPassed
+	return nil
+}
+
+// runPhase runs the plan or apply phase as a freestyle job, the logs of the phase are saved with the phase job name.
+func (c *TerraformJobCtl) runPhase(ctx context.Context, phase string, spec *commonmodels.JobTaskFreestyleSpec) (*commonmodels.JobTask, error) {
+	phaseJob := &commonmodels.JobTask{
+		Key:            c.job.Key,
+		Name:           fmt.Sprintf("%s-%s", c.job.Name, phase),
+		DisplayName:    c.job.DisplayName,
+		OriginName:     c.job.OriginName,
+		JobInfo:        c.job.JobInfo,
+		JobType:        string(config.JobFreestyle),
+		Spec:           spec,
+		Timeout:        c.job.Timeout,
+		Infrastructure: spec.Properties.Infrastructure,
+		VMLabels:       spec.Properties.VMLabels,
+		K8sJobName:     getJobName(c.workflowCtx.WorkflowName, c.workflowCtx.TaskID),
+		Status:         config.StatusRunning,
+	}
+	phaseCtl := NewFreestyleJobCtl(phaseJob, c.workflowCtx, c.ack, c.logger)
+	// keep the phase spec in the task so that the step status of the phase is saved
+	switch phase {
+	case terraformPhasePlan:
+		c.jobTaskSpec.PlanJobName = phaseJob.Name
+		c.jobTaskSpec.Plan = phaseCtl.jobTaskSpec
+	case terraformPhaseApply:
+		c.jobTaskSpec.ApplyJobName = phaseJob.Name
+		c.jobTaskSpec.Apply = phaseCtl.jobTaskSpec
+	default:
+		return nil, fmt.Errorf("invalid terraform phase: %s", phase)
+	}
+	c.ack()
+
+	phaseCtl.Run(ctx)
+	if phaseJob.Status != config.StatusPassed && phaseJob.Error == "" {
+		phaseJob.Error = fmt.Sprintf("terraform %s %s", phase, phaseJob.Status)
+	}
+	return phaseJob, nil
+}
+
+func (c *TerraformJobCtl) getPlanSummary() (*terraform.PlanSummary, error) {
+	storage, err := mongodb.NewS3StorageColl().FindDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default object storage, error: %v", err)
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client, error: %v", err)
+	}
+
+	objectKey := strings.TrimLeft(path.Join(storage.Subfolder, c.jobTaskSpec.ArtifactPath, terraform.PlanJSONFile), "/")
+	object, err := client.GetFile(storage.Bucket, objectKey, &s3tool.DownloadOption{RetryNum: 3})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s, error: %v", objectKey, err)
+	}
+	defer object.Body.Close()
+
+	data, err := io.ReadAll(object.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s, error: %v", objectKey, err)
+	}
+	return terraform.ParsePlan(data)
+}
+
+func (c *TerraformJobCtl) setOutputs() {
+	summary := c.jobTaskSpec.PlanSummary
+	c.workflowCtx.GlobalContextSet(runtimejob.GetJobOutputKey(c.job.Key, config.TerraformOutputHasChanges), strconv.FormatBool(summary.HasChanges()))
+	c.workflowCtx.GlobalContextSet(runtimejob.GetJobOutputKey(c.job.Key, config.TerraformOutputAdd), strconv.Itoa(summary.Add+summary.Replace))
+	c.workflowCtx.GlobalContextSet(runtimejob.GetJobOutputKey(c.job.Key, config.TerraformOutputChange), strconv.Itoa(summary.Change))
+	c.workflowCtx.GlobalContextSet(runtimejob.GetJobOutputKey(c.job.Key, config.TerraformOutputDestroy), strconv.Itoa(summary.Destroy+summary.Replace))
+}
+
+func (c *TerraformJobCtl) SaveInfo(ctx context.Context) error {
+	return mongodb.NewJobInfoColl().Create(context.TODO(), &commonmodels.JobInfo{
+		Type:                c.job.JobType,
+		WorkflowName:        c.workflowCtx.WorkflowName,
+		WorkflowDisplayName: c.workflowCtx.WorkflowDisplayName,
+		TaskID:              c.workflowCtx.TaskID,
+		ProductName:         c.workflowCtx.ProjectName,
+		StartTime:           c.job.StartTime,
+		EndTime:             c.job.EndTime,
+		Duration:            c.job.EndTime - c.job.StartTime,
+		Status:              string(c.job.Status),
+	})
+}
@@ -526,7 +526,10 @@ EOF`,
 		if jobTaskSpec.Properties.CacheDirType == commontypes.WorkspaceCacheDir {
 			mountPath = workflowCtx.Workspace
 		}
-		if jobTaskSpec.Properties.IgnoreCache {
+		// named caches are restored and saved as archives by the cache steps, the volume only holds the store
+		if len(jobTaskSpec.Properties.CacheRules) > 0 {
+			mountPath = commontypes.NamedCacheNFSMountPath
+		} else if jobTaskSpec.Properties.IgnoreCache {
 			job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
 				Name: ignoreCacheRuntimeVolumeName,
 				VolumeSource: corev1.VolumeSource{
@@ -1186,6 +1189,15 @@ func getJobOutputFromConfigMap(namespace, containerName string, jobTask *commonm
 	if report := cm.Data[commontypes.JobAIReviewReportKey]; report != "" {
 		workflowCtx.GlobalContextSet(job.GetJobOutputKey(jobTask.Key, commontypes.JobAIReviewReportKey), report)
 	}
+	if summary := cm.Data[commontypes.JobImageVulnScanKey]; summary != "" {
+		workflowCtx.GlobalContextSet(job.GetJobOutputKey(jobTask.Key, commontypes.JobImageVulnScanKey), summary)
+	}
+	if stats := cm.Data[commontypes.JobCacheStatsKey]; stats != "" {
+		workflowCtx.GlobalContextSet(job.GetJobOutputKey(jobTask.Key, commontypes.JobCacheStatsKey), stats)
+	}
+	if result := cm.Data[commontypes.JobCoverageReportKey]; result != "" {
+		workflowCtx.GlobalContextSet(job.GetJobOutputKey(jobTask.Key, commontypes.JobCoverageReportKey), result)
+	}
 	return nil
 }
 
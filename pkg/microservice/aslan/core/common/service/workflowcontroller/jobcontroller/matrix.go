@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobcontroller
+
+import (
+	"context"
+	"sync"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+// matrixLimiter enforces the max parallel and fail fast settings of the sub-tasks expanded from the same job matrix.
+type matrixLimiter struct {
+	mu     sync.Mutex
+	groups map[string]*matrixGroup
+}
+
+type matrixGroup struct {
+	slots    chan struct{}
+	failFast bool
+	failed   bool
+}
+
+func newMatrixLimiter(jobs []*commonmodels.JobTask) *matrixLimiter {
+	limiter := &matrixLimiter{groups: map[string]*matrixGroup{}}
+	for _, job := range jobs {
+		if job.Matrix == nil {
+			continue
+		}
+		if _, ok := limiter.groups[job.Matrix.Group]; ok {
+			continue
+		}
+		group := &matrixGroup{failFast: job.Matrix.FailFast}
+		if job.Matrix.MaxParallel > 0 {
+			group.slots = make(chan struct{}, job.Matrix.MaxParallel)
+		}
+		limiter.groups[job.Matrix.Group] = group
+	}
+	return limiter
+}
+
+func (l *matrixLimiter) group(job *commonmodels.JobTask) *matrixGroup {
+	if l == nil || job.Matrix == nil {
+		return nil
+	}
+	return l.groups[job.Matrix.Group]
+}
+
+func (l *matrixLimiter) groupFailed(group *matrixGroup) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return group.failed
+}
+
+// acquire waits until the job may start, it returns false if the job must not run because another sub-task
+// of its matrix failed fast or the workflow was cancelled while waiting.
+func (l *matrixLimiter) acquire(ctx context.Context, job *commonmodels.JobTask) bool {
+	group := l.group(job)
+	if group == nil {
+		return true
+	}
+	if l.groupFailed(group) {
+		return false
+	}
+	if group.slots != nil {
+		select {
+		case group.slots <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	if l.groupFailed(group) {
+		l.releaseSlot(group)
+		return false
+	}
+	return true
+}
+
+// release frees the slot taken by the job, a job that did not succeed makes the remaining sub-tasks fail fast.
+func (l *matrixLimiter) release(job *commonmodels.JobTask) {
+	group := l.group(job)
+	if group == nil {
+		return
+	}
+	if job.Status != config.StatusPassed && job.Status != config.StatusUnstable && job.Status != config.StatusSkipped {
+		l.mu.Lock()
+		group.failed = group.failFast
+		l.mu.Unlock()
+	}
+	l.releaseSlot(group)
+}
+
+func (l *matrixLimiter) releaseSlot(group *matrixGroup) {
+	if group.slots != nil {
+		<-group.slots
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
@@ -63,18 +64,24 @@ func (c *CustomStageCtl) AfterRun() {
 	// set IMAGES workflow variable
 	// set after a stage has been done for build and some other type job maybe split to many job tasks in one stage
 	// after stage run, concurrent competition of workflowCtx.GlobalContext is not exist
+	setJobImagesVariables(c.workflowCtx)
+}
 
+// setJobImagesVariables sets the {{.job.<name>.IMAGES}} variable of the given jobs from the images their tasks output,
+// all jobs are handled if no name is given.
+func setJobImagesVariables(workflowCtx *commonmodels.WorkflowTaskCtx, jobNames ...string) {
+	wanted := sets.NewString(jobNames...)
 	jobImages := map[string][]string{}
-	for k, v := range c.workflowCtx.GlobalContextGetAll() {
+	for k, v := range workflowCtx.GlobalContextGetAll() {
 		list := reg.FindStringSubmatch(k)
-		if len(list) > 0 {
+		if len(list) > 0 && (wanted.Len() == 0 || wanted.Has(list[1])) {
 			jobImages[list[1]] = append(jobImages[list[1]], v)
 		}
 	}
 	for jobName, images := range jobImages {
 		key := fmt.Sprintf("{{.job.%s.IMAGES}}", jobName)
-		if _, ok := c.workflowCtx.GlobalContextGet(key); !ok {
-			c.workflowCtx.GlobalContextSet(key, strings.Join(images, ","))
+		if _, ok := workflowCtx.GlobalContextGet(key); !ok {
+			workflowCtx.GlobalContextSet(key, strings.Join(images, ","))
 		}
 	}
 }
@@ -103,6 +103,16 @@ func instantiateStepCtl(step *commonmodels.StepTask, workflowCtx *commonmodels.W
 		stepCtl, err = NewAIReviewReportCtl(step, workflowCtx, logger)
 	case config.StepDistributeImage:
 		stepCtl, err = NewDistributeCtl(step, workflowCtx, jobKey, logger)
+	case config.StepSBOM:
+		stepCtl, err = NewSBOMCtl(step, logger)
+	case config.StepImageSign:
+		stepCtl, err = NewImageSignCtl(step, logger)
+	case config.StepImageVulnScan:
+		stepCtl, err = NewImageVulnScanCtl(step, workflowCtx, logger)
+	case config.StepRestoreCache, config.StepSaveCache:
+		stepCtl, err = NewCacheCtl(step, workflowCtx, logger)
+	case config.StepCoverageReport:
+		stepCtl, err = NewCoverageReportCtl(step, workflowCtx, logger)
 	case config.StepDebugBefore, config.StepDebugAfter:
 		stepCtl, err = NewDebugCtl()
 	default:
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	jobtypes "github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type cacheCtl struct {
+	step        *commonmodels.StepTask
+	cacheSpec   *step.StepCacheSpec
+	workflowCtx *commonmodels.WorkflowTaskCtx
+	log         *zap.SugaredLogger
+}
+
+func NewCacheCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, log *zap.SugaredLogger) (*cacheCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal cache spec error: %v", err)
+	}
+	cacheSpec := &step.StepCacheSpec{}
+	if err := yaml.Unmarshal(yamlString, &cacheSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal cache spec error: %v", err)
+	}
+	stepTask.Spec = cacheSpec
+	return &cacheCtl{cacheSpec: cacheSpec, workflowCtx: workflowCtx, log: log, step: stepTask}, nil
+}
+
+func (s *cacheCtl) PreRun(ctx context.Context) error {
+	if len(s.cacheSpec.Rules) == 0 {
+		return fmt.Errorf("no cache is configured")
+	}
+	if s.cacheSpec.StoreDir == "" {
+		return fmt.Errorf("cache store dir is not configured")
+	}
+	return nil
+}
+
+// AfterRun records the per cache hit/miss stats returned by the job executor in the task
+func (s *cacheCtl) AfterRun(ctx context.Context) error {
+	key := job.GetJobOutputKey(s.step.JobKey, jobtypes.JobCacheStatsKey)
+	statsJSON, ok := s.workflowCtx.GlobalContextGet(key)
+	if !ok {
+		s.cacheSpec.CollectionError = "cache stats were not returned by the job executor"
+		s.step.Spec = s.cacheSpec
+		return nil
+	}
+	stats := make([]*step.CacheStat, 0)
+	if err := json.Unmarshal([]byte(statsJSON), &stats); err != nil {
+		s.cacheSpec.CollectionError = fmt.Sprintf("cache stats are invalid JSON: %v", err)
+		s.step.Spec = s.cacheSpec
+		s.log.Errorf("decode cache stats: %v", err)
+		return nil
+	}
+	s.cacheSpec.Stats = stats
+	s.cacheSpec.CollectionError = ""
+	s.step.Spec = s.cacheSpec
+	return nil
+}
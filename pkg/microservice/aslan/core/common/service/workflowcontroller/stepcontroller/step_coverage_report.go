@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/scmnotify"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+	jobtypes "github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type coverageReportCtl struct {
+	step         *commonmodels.StepTask
+	coverageSpec *step.StepCoverageReportSpec
+	workflowCtx  *commonmodels.WorkflowTaskCtx
+	log          *zap.SugaredLogger
+}
+
+func NewCoverageReportCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, log *zap.SugaredLogger) (*coverageReportCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal coverage report spec error: %v", err)
+	}
+	coverageSpec := &step.StepCoverageReportSpec{}
+	if err := yaml.Unmarshal(yamlString, &coverageSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal coverage report spec error: %v", err)
+	}
+	stepTask.Spec = coverageSpec
+	return &coverageReportCtl{coverageSpec: coverageSpec, workflowCtx: workflowCtx, log: log, step: stepTask}, nil
+}
+
+// PreRun sets the base coverage used by the drop gate before the spec is sent to the job executor
+func (s *coverageReportCtl) PreRun(ctx context.Context) error {
+	if len(s.coverageSpec.ReportPaths) == 0 {
+		return fmt.Errorf("coverage report path is not configured")
+	}
+	if s.coverageSpec.S3Storage == nil {
+		modelS3, err := commonrepo.NewS3StorageColl().FindDefault()
+		if err != nil {
+			return err
+		}
+		s.coverageSpec.S3Storage = modelS3toS3(modelS3)
+	}
+
+	if s.coverageSpec.TestName != "" && s.coverageSpec.Branch != "" {
+		base, err := commonrepo.NewCoverageReportColl().FindLatest(&commonrepo.CoverageReportFindOption{
+			ZadigTestName: s.coverageSpec.TestName,
+			ServiceName:   s.coverageSpec.ServiceName,
+			ServiceModule: s.coverageSpec.ServiceModule,
+			RepoOwner:     s.coverageSpec.RepoOwner,
+			RepoName:      s.coverageSpec.RepoName,
+			Branch:        s.coverageSpec.Branch,
+		})
+		switch {
+		case err == nil:
+			s.coverageSpec.Base = &base.Summary
+			s.coverageSpec.BaseReportID = base.ID.Hex()
+		case err != mongo.ErrNoDocuments:
+			s.log.Warnf("failed to find the base coverage of testing %s on branch %s: %v", s.coverageSpec.TestName, s.coverageSpec.Branch, err)
+		}
+	}
+	s.step.Spec = s.coverageSpec
+	return nil
+}
+
+// AfterRun records the coverage returned by the job executor and publishes the delta to the pull request
+func (s *coverageReportCtl) AfterRun(ctx context.Context) error {
+	key := job.GetJobOutputKey(s.step.JobKey, jobtypes.JobCoverageReportKey)
+	resultJSON, ok := s.workflowCtx.GlobalContextGet(key)
+	if !ok {
+		s.coverageSpec.CollectionError = "coverage summary was not returned by the job executor"
+		s.step.Spec = s.coverageSpec
+		return nil
+	}
+	result := &step.CoverageReportResult{}
+	if err := json.Unmarshal([]byte(resultJSON), result); err != nil || result.Summary == nil {
+		s.coverageSpec.CollectionError = fmt.Sprintf("coverage summary is invalid: %v", err)
+		s.step.Spec = s.coverageSpec
+		s.log.Errorf("decode coverage summary: %v", err)
+		return nil
+	}
+	s.coverageSpec.Summary = result.Summary
+	s.coverageSpec.GateFailures = result.GateFailures
+	s.coverageSpec.CollectionError = ""
+	s.step.Spec = s.coverageSpec
+
+	// debug runs do not count in the coverage trends
+	if s.coverageSpec.TestName == "" || s.workflowCtx.IsDebug {
+		return nil
+	}
+	files, err := s.downloadFiles()
+	if err != nil {
+		s.log.Warnf("failed to download the coverage report of testing %s: %v", s.coverageSpec.TestName, err)
+	}
+	report := &commonmodels.CoverageReport{
+		ProjectName:   s.coverageSpec.TestProject,
+		WorkflowName:  s.coverageSpec.SourceWorkflow,
+		JobName:       s.coverageSpec.SourceJobKey,
+		JobTaskName:   s.coverageSpec.JobTaskName,
+		TaskID:        s.coverageSpec.TaskID,
+		RetryNum:      s.workflowCtx.RetryNum,
+		ServiceName:   s.coverageSpec.ServiceName,
+		ServiceModule: s.coverageSpec.ServiceModule,
+		ZadigTestName: s.coverageSpec.TestName,
+		CodehostID:    s.coverageSpec.CodehostID,
+		RepoOwner:     s.coverageSpec.RepoOwner,
+		RepoName:      s.coverageSpec.RepoName,
+		Branch:        s.coverageSpec.Branch,
+		CommitID:      s.coverageSpec.CommitID,
+		PR:            s.coverageSpec.PR,
+		Summary:       *result.Summary,
+		Files:         files,
+		CreateTime:    time.Now().Unix(),
+	}
+	if _, err := commonrepo.NewCoverageReportColl().Create(report); err != nil {
+		s.log.Errorf("save coverage report of testing %s failed, error: %v", s.coverageSpec.TestName, err)
+	}
+
+	if s.coverageSpec.PR <= 0 {
+		return nil
+	}
+	comment := &scmnotify.CoverageReport{
+		TestName:      s.coverageSpec.TestName,
+		ServiceName:   s.coverageSpec.ServiceName,
+		ServiceModule: s.coverageSpec.ServiceModule,
+		BaseBranch:    s.coverageSpec.Branch,
+		CommitID:      s.coverageSpec.CommitID,
+		Summary:       result.Summary,
+		Base:          s.coverageSpec.Base,
+		GateFailures:  result.GateFailures,
+	}
+	if s.coverageSpec.BaseReportID != "" && len(files) > 0 {
+		if base, err := commonrepo.NewCoverageReportColl().GetByID(s.coverageSpec.BaseReportID); err == nil {
+			comment.Files = coverage.DiffFiles(&coverage.Report{Files: files}, &coverage.Report{Files: base.Files})
+		} else {
+			s.log.Warnf("failed to find the base coverage report %s: %v", s.coverageSpec.BaseReportID, err)
+		}
+	}
+	if err := scmnotify.NewService().PublishCoverageReport(
+		s.coverageSpec.CodehostID,
+		s.coverageSpec.RepoOwner,
+		s.coverageSpec.RepoName,
+		s.coverageSpec.PR,
+		comment,
+		s.log,
+	); err != nil {
+		s.log.Warnf("failed to publish coverage report: %v", err)
+	}
+	return nil
+}
+
+func (s *coverageReportCtl) downloadFiles() ([]*coverage.FileCoverage, error) {
+	storage := s.coverageSpec.S3Storage
+	if storage == nil || s.coverageSpec.S3DestDir == "" {
+		return nil, nil
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("create s3 client: %v", err)
+	}
+	filename, err := util.GenerateTmpFile()
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(filename)
+
+	objectKey := strings.TrimLeft(path.Join(storage.Subfolder, s.coverageSpec.S3DestDir, coverage.ReportJSONFile), "/")
+	if err := client.Download(storage.Bucket, objectKey, filename); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	report := &coverage.Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("decode coverage report: %v", err)
+	}
+	return report.Files, nil
+}
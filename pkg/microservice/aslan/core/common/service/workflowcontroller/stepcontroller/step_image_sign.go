@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type imageSignCtl struct {
+	step          *commonmodels.StepTask
+	imageSignSpec *step.StepImageSignSpec
+	log           *zap.SugaredLogger
+}
+
+func NewImageSignCtl(stepTask *commonmodels.StepTask, log *zap.SugaredLogger) (*imageSignCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal image sign spec error: %v", err)
+	}
+	imageSignSpec := &step.StepImageSignSpec{}
+	if err := yaml.Unmarshal(yamlString, &imageSignSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal image sign spec error: %v", err)
+	}
+	stepTask.Spec = imageSignSpec
+	return &imageSignCtl{imageSignSpec: imageSignSpec, log: log, step: stepTask}, nil
+}
+
+func (s *imageSignCtl) PreRun(ctx context.Context) error {
+	if s.imageSignSpec.KeyRef == "" {
+		return fmt.Errorf("signing key of image %s is not configured", s.imageSignSpec.Image)
+	}
+	return nil
+}
+
+func (s *imageSignCtl) AfterRun(ctx context.Context) error {
+	return nil
+}
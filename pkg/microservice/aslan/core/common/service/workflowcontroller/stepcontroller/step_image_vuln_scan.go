@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
+	jobtypes "github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/job"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type imageVulnScanCtl struct {
+	step         *commonmodels.StepTask
+	vulnScanSpec *step.StepImageVulnScanSpec
+	workflowCtx  *commonmodels.WorkflowTaskCtx
+	log          *zap.SugaredLogger
+}
+
+func NewImageVulnScanCtl(stepTask *commonmodels.StepTask, workflowCtx *commonmodels.WorkflowTaskCtx, log *zap.SugaredLogger) (*imageVulnScanCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal image vulnerability scan spec error: %v", err)
+	}
+	vulnScanSpec := &step.StepImageVulnScanSpec{}
+	if err := yaml.Unmarshal(yamlString, &vulnScanSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal image vulnerability scan spec error: %v", err)
+	}
+	stepTask.Spec = vulnScanSpec
+	return &imageVulnScanCtl{vulnScanSpec: vulnScanSpec, workflowCtx: workflowCtx, log: log, step: stepTask}, nil
+}
+
+func (s *imageVulnScanCtl) PreRun(ctx context.Context) error {
+	if s.vulnScanSpec.Image == "" {
+		return fmt.Errorf("image to scan is not configured")
+	}
+	return nil
+}
+
+// AfterRun records the vulnerability summary returned by the job executor in the task
+func (s *imageVulnScanCtl) AfterRun(ctx context.Context) error {
+	key := job.GetJobOutputKey(s.step.JobKey, jobtypes.JobImageVulnScanKey)
+	summaryJSON, ok := s.workflowCtx.GlobalContextGet(key)
+	if !ok {
+		s.vulnScanSpec.CollectionError = "image vulnerability scan summary was not returned by the job executor"
+		s.step.Spec = s.vulnScanSpec
+		return nil
+	}
+	summary := &trivy.Summary{}
+	if err := json.Unmarshal([]byte(summaryJSON), summary); err != nil {
+		s.vulnScanSpec.CollectionError = fmt.Sprintf("image vulnerability scan summary is invalid JSON: %v", err)
+		s.step.Spec = s.vulnScanSpec
+		s.log.Errorf("decode image vulnerability scan summary: %v", err)
+		return nil
+	}
+	s.vulnScanSpec.Summary = summary
+	s.vulnScanSpec.ExceededReasons = summary.ExceededThresholds(s.vulnScanSpec.Thresholds)
+	s.vulnScanSpec.CollectionError = ""
+	s.step.Spec = s.vulnScanSpec
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stepcontroller
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type sbomCtl struct {
+	step     *commonmodels.StepTask
+	sbomSpec *step.StepSBOMSpec
+	log      *zap.SugaredLogger
+}
+
+func NewSBOMCtl(stepTask *commonmodels.StepTask, log *zap.SugaredLogger) (*sbomCtl, error) {
+	yamlString, err := yaml.Marshal(stepTask.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal sbom spec error: %v", err)
+	}
+	sbomSpec := &step.StepSBOMSpec{}
+	if err := yaml.Unmarshal(yamlString, &sbomSpec); err != nil {
+		return nil, fmt.Errorf("unmarshal sbom spec error: %v", err)
+	}
+	stepTask.Spec = sbomSpec
+	return &sbomCtl{sbomSpec: sbomSpec, log: log, step: stepTask}, nil
+}
+
+func (s *sbomCtl) PreRun(ctx context.Context) error {
+	return nil
+}
+
+func (s *sbomCtl) AfterRun(ctx context.Context) error {
+	return nil
+}
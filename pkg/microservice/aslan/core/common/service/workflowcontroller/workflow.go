@@ -281,7 +281,11 @@ func (c *workflowCtl) Run(ctx context.Context, concurrency int) {
 	if err := scmnotify.NewService().UpdateGitCheckForWorkflowV4(c.workflowTask.WorkflowArgs, c.workflowTask.TaskID, c.logger); err != nil {
 		log.Warnf("Failed to update github check status for custom workflow %s, taskID: %d the error is: %s", c.workflowTask.WorkflowName, c.workflowTask.TaskID, err)
 	}
-	RunStages(ctx, c.workflowTask.Stages, workflowCtx, concurrency, c.logger, c.ack)
+	if HasJobDependencies(c.workflowTask.Stages) {
+		RunStagesByDependency(ctx, c.workflowTask.Stages, workflowCtx, concurrency, c.logger, c.ack)
+	} else {
+		RunStages(ctx, c.workflowTask.Stages, workflowCtx, concurrency, c.logger, c.ack)
+	}
 	updateworkflowStatus(c.workflowTask)
 }
 
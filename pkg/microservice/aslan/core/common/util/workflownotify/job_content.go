@@ -131,7 +131,7 @@ func BuildWorkflowJobContents(args *BuildJobContentsArgs) ([]string, []*webhookn
 							prLinkBuilder = func(baseURL, owner, repoName string, prID int) string {
 								return fmt.Sprintf("%s/%s/%s/pull/%d", baseURL, owner, repoName, prID)
 							}
-						case types.ProviderGitee:
+						case types.ProviderGitee, types.ProviderGitea:
 							prLinkBuilder = func(baseURL, owner, repoName string, prID int) string {
 								return fmt.Sprintf("%s/%s/%s/pulls/%d", baseURL, owner, repoName, prID)
 							}
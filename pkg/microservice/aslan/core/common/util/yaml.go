@@ -7,6 +7,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/git"
+	giteaservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitea"
 	githubservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/github"
 	gitlabservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/gitlab"
 	"github.com/koderover/zadig/v2/pkg/setting"
@@ -26,6 +27,8 @@ func GetYAMLLoader(ch *systemconfig.CodeHost) (YAMLLoader, error) {
 		return githubservice.NewClient(ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy), nil
 	case setting.SourceFromGitlab:
 		return gitlabservice.NewClient(ch.ID, ch.Address, ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy, ch.DisableSSL)
+	case setting.SourceFromGitea:
+		return giteaservice.NewClient(ch.ID, ch.Address, ch.AccessToken, config.ProxyHTTPSAddr(), ch.EnableProxy, ch.DisableSSL)
 	default:
 		// should not have happened here
 		log.DPanicf("invalid source: %s", ch.Type)
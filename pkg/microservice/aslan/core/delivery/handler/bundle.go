@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	deliveryservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/delivery/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary Export Delivery Version Bundle
+// @Description Export a delivery version as an offline bundle, which contains images, charts, yaml, checksums and a manifest
+// @Tags 	delivery
+// @Produce octet-stream
+// @Param 	id 				path 		string		true	"id"
+// @Param 	projectName 	query 		string		true	"projectName"
+// @Success 200
+// @Router /api/aslan/delivery/releases/{id}/bundle [get]
+func ExportDeliveryVersionBundle(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ID := c.Param("id")
+	if ID == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("id can't be empty!")
+		return
+	}
+	projectKey := c.Query("projectName")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can't be empty!")
+		return
+	}
+
+	permit := false
+	if ctx.Resources.IsSystemAdmin {
+		permit = true
+	} else {
+		if ctx.Resources.SystemActions.DeliveryCenter.ViewVersion {
+			permit = true
+		}
+
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; ok {
+			if ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin ||
+				ctx.Resources.ProjectAuthInfo[projectKey].Version.View {
+				permit = true
+			}
+		}
+	}
+
+	if !permit {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	bundlePath, cleanup, err := deliveryservice.ExportDeliveryVersionBundle(projectKey, ID, ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+	defer cleanup()
+
+	c.FileAttachment(bundlePath, filepath.Base(bundlePath))
+}
+
+// @Summary Import Delivery Version Bundle
+// @Description Import an offline bundle as a delivery version, images and charts are pushed to the appointed registry and chart repo in the background
+// @Tags 	delivery
+// @Accept 	multipart/form-data
+// @Produce json
+// @Param 	projectName 		query 		string		true	"projectName"
+// @Param 	version 			query 		string		false	"version name, defaults to the one in the bundle"
+// @Param 	imageRegistryID 	query 		string		false	"target image registry id"
+// @Param 	chartRepoName 		query 		string		false	"target chart repo name"
+// @Param 	file 				formData 	file		true	"bundle file"
+// @Success 200 				{object} 	models.DeliveryVersionV2
+// @Router /api/aslan/delivery/releases/bundle [post]
+func ImportDeliveryVersionBundle(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(deliveryservice.ImportDeliveryBundleArgs)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	if args.ProjectName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can't be empty!")
+		return
+	}
+	args.CreateBy = ctx.UserName
+
+	// authorization checks
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[args.ProjectName]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+
+		if !ctx.Resources.ProjectAuthInfo[args.ProjectName].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[args.ProjectName].Version.Create {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc(fmt.Sprintf("failed to get file: %v", err))
+		return
+	}
+	defer file.Close()
+
+	version, err := deliveryservice.ImportDeliveryVersionBundle(args, file, ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+	internalhandler.InsertOperationLog(c, ctx.UserName, args.ProjectName, "导入", "版本交付", version.Version, version.Version, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	ctx.Resp = version
+}
@@ -18,6 +18,7 @@ package handler
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/gin-gonic/gin"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
@@ -365,3 +366,142 @@ func OpenAPIRetryCreateDeliveryVersion(c *gin.Context) {
 
 	ctx.RespErr = service.OpenAPIRetryCreateDeliveryVersion(id)
 }
+
+// @Summary 导出离线交付包
+// @Description 导出离线交付包，包含镜像、Chart、YAML、校验和以及清单
+// @Tags 	OpenAPI
+// @Produce octet-stream
+// @Param 	id				path		string		true	"版本 ID"
+// @Param 	projectKey		query		string		true	"项目标识"
+// @Success 200
+// @Router /openapi/delivery/releases/{id}/bundle [get]
+func OpenAPIExportDeliveryVersionBundle(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.Logger.Errorf("failed to generate authorization info for user: %s, error: %s", ctx.UserID, err)
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ID := c.Param("id")
+	if ID == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("id can't be empty!")
+		return
+	}
+	projectKey := c.Query("projectKey")
+	if projectKey == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectKey can't be empty!")
+		return
+	}
+
+	permit := false
+	if ctx.Resources.IsSystemAdmin {
+		permit = true
+	} else {
+		if ctx.Resources.SystemActions.DeliveryCenter.ViewVersion {
+			permit = true
+		}
+
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; ok {
+			if ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin ||
+				ctx.Resources.ProjectAuthInfo[projectKey].Version.View {
+				permit = true
+			}
+		}
+	}
+
+	if !permit {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	bundlePath, cleanup, err := service.ExportDeliveryVersionBundle(projectKey, ID, ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+	defer cleanup()
+
+	c.FileAttachment(bundlePath, filepath.Base(bundlePath))
+}
+
+type OpenAPIImportDeliveryBundleRequest struct {
+	ProjectKey      string `form:"projectKey" binding:"required"`
+	Version         string `form:"version"`
+	ImageRegistryID string `form:"imageRegistryID"`
+	ChartRepoName   string `form:"chartRepoName"`
+}
+
+// @Summary 导入离线交付包
+// @Description 导入离线交付包并创建版本，镜像和 Chart 会在后台推送到指定的镜像仓库和 Chart 仓库
+// @Tags 	OpenAPI
+// @Accept 	multipart/form-data
+// @Produce json
+// @Param 	projectKey			query		string		true	"项目标识"
+// @Param 	version				query		string		false	"版本名称，默认使用交付包中的版本名称"
+// @Param 	imageRegistryID		query		string		false	"目标镜像仓库 ID"
+// @Param 	chartRepoName		query		string		false	"目标 Chart 仓库名称"
+// @Param 	file				formData	file		true	"交付包文件"
+// @Success 200
+// @Router /openapi/delivery/releases/bundle [post]
+func OpenAPIImportDeliveryVersionBundle(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.Logger.Errorf("failed to generate authorization info for user: %s, error: %s", ctx.UserID, err)
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	req := new(OpenAPIImportDeliveryBundleRequest)
+	if err := c.ShouldBindQuery(req); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc(err.Error())
+		return
+	}
+
+	projectKey := req.ProjectKey
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Version.Create {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	err = commonutil.CheckZadigProfessionalLicense()
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc(fmt.Sprintf("failed to get file: %v", err))
+		return
+	}
+	defer file.Close()
+
+	_, ctx.RespErr = service.ImportDeliveryVersionBundle(&service.ImportDeliveryBundleArgs{
+		ProjectName:     projectKey,
+		Version:         req.Version,
+		ImageRegistryID: req.ImageRegistryID,
+		ChartRepoName:   req.ChartRepoName,
+		CreateBy:        ctx.UserName + "(OpenAPI)",
+	}, file, ctx.Logger)
+}
@@ -42,6 +42,8 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		deliveryRelease.POST("/helm", CreateHelmDeliveryVersionV2)
 		deliveryRelease.POST("/retry", RetryDeliveryVersion)
 		deliveryRelease.GET("/check", CheckDeliveryVersion)
+		deliveryRelease.GET("/:id/bundle", ExportDeliveryVersionBundle)
+		deliveryRelease.POST("/bundle", ImportDeliveryVersionBundle)
 		deliveryRelease.POST("/helm/global-variables", ApplyDeliveryGlobalVariables)
 		deliveryRelease.GET("/helm/charts", DownloadDeliveryChart)
 		deliveryRelease.GET("/helm/charts/version", GetChartVersionFromRepo)
@@ -62,5 +64,7 @@ func (*OpenAPIRouter) Inject(router *gin.RouterGroup) {
 		deliveryRelease.POST("/k8s", OpenAPICreateK8SDeliveryVersion)
 		deliveryRelease.POST("/helm", OpenAPICreateHelmDeliveryVersion)
 		deliveryRelease.POST("/retry", OpenAPIRetryCreateDeliveryVersion)
+		deliveryRelease.GET("/:id/bundle", OpenAPIExportDeliveryVersionBundle)
+		deliveryRelease.POST("/bundle", OpenAPIImportDeliveryVersionBundle)
 	}
 }
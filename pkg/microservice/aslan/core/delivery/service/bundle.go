@@ -0,0 +1,762 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	chartloader "helm.sh/helm/v3/pkg/chart/loader"
+	helmchartutil "helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	templaterepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/template"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/ocilayout"
+	"github.com/koderover/zadig/v2/pkg/util"
+	fsutil "github.com/koderover/zadig/v2/pkg/util/fs"
+)
+
+// An offline bundle is a gzipped tarball with the following layout:
+//
+//	manifest.json       describes the delivery version, see DeliveryBundleManifest
+//	checksums.sha256    sha256 of every other file in the bundle, in the format of sha256sum
+//	images/             images of the version as an OCI image layout, indexed by image reference
+//	charts/             chart packages of a helm version
+//	yaml/               rendered yaml of a k8s version, or values.yaml of a helm version
+const (
+	deliveryBundleSchemaVersion = "v1"
+	deliveryBundleManifestFile  = "manifest.json"
+	deliveryBundleChecksumFile  = "checksums.sha256"
+	deliveryBundleImageDir      = "images"
+	deliveryBundleChartDir      = "charts"
+	deliveryBundleYamlDir       = "yaml"
+	deliveryBundleTimeout       = 2 * time.Hour
+)
+
+type DeliveryBundleManifest struct {
+	SchemaVersion string                        `json:"schema_version"`
+	Version       string                        `json:"version"`
+	ProjectName   string                        `json:"project_name"`
+	Type          setting.DeliveryVersionType   `json:"type"`
+	Source        setting.DeliveryVersionSource `json:"source"`
+	Desc          string                        `json:"desc"`
+	Labels        []string                      `json:"labels"`
+	Services      []*DeliveryBundleService      `json:"services"`
+	CreatedBy     string                        `json:"created_by"`
+	CreatedAt     int64                         `json:"created_at"`
+	ExportedAt    int64                         `json:"exported_at"`
+}
+
+type DeliveryBundleService struct {
+	ServiceName  string `json:"service_name"`
+	ChartName    string `json:"chart_name,omitempty"`
+	ChartVersion string `json:"chart_version,omitempty"`
+	// ChartFile and YamlFile are paths relative to the root of the bundle
+	ChartFile string                 `json:"chart_file,omitempty"`
+	YamlFile  string                 `json:"yaml_file,omitempty"`
+	Images    []*DeliveryBundleImage `json:"images"`
+}
+
+type DeliveryBundleImage struct {
+	ContainerName string                      `json:"container_name"`
+	ImageName     string                      `json:"image_name"`
+	ImagePath     *commonmodels.ImagePathSpec `json:"image_path,omitempty"`
+	// Image is the reference used in the exported yaml, it is also the name of the image in the OCI layout
+	Image  string `json:"image"`
+	Digest string `json:"digest"`
+}
+
+type ImportDeliveryBundleArgs struct {
+	ProjectName string `form:"projectName"`
+	// Version overrides the version name recorded in the bundle
+	Version         string `form:"version"`
+	ImageRegistryID string `form:"imageRegistryID"`
+	ChartRepoName   string `form:"chartRepoName"`
+	CreateBy        string `form:"-"`
+}
+
+// ExportDeliveryVersionBundle packs a delivery version into an offline bundle, the caller is responsible
+// for calling the returned cleanup function after the bundle is consumed.
+func ExportDeliveryVersionBundle(projectName, id string, logger *zap.SugaredLogger) (string, func(), error) {
+	version, err := commonrepo.NewDeliveryVersionV2Coll().FindByID(id)
+	if err != nil {
+		return "", nil, e.ErrGetDeliveryVersion.AddErr(err)
+	}
+	if version.ProjectName != projectName {
+		return "", nil, e.ErrGetDeliveryVersion.AddDesc(fmt.Sprintf("version %s not found in project %s", id, projectName))
+	}
+	if version.Status != setting.DeliveryVersionStatusSuccess {
+		return "", nil, e.ErrExportDeliveryVersion.AddDesc(fmt.Sprintf("can't export version with status: %s", version.Status))
+	}
+
+	workDir, err := os.MkdirTemp("", "delivery-bundle-")
+	if err != nil {
+		return "", nil, e.ErrExportDeliveryVersion.AddErr(err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			log.Warnf("failed to remove delivery bundle dir %s, err: %s", workDir, err)
+		}
+	}
+
+	bundlePath, err := buildDeliveryBundle(version, workDir, logger)
+	if err != nil {
+		cleanup()
+		logger.Errorf("failed to export delivery version %s of project %s, err: %s", version.Version, version.ProjectName, err)
+		return "", nil, e.ErrExportDeliveryVersion.AddErr(err)
+	}
+	return bundlePath, cleanup, nil
+}
+
+func buildDeliveryBundle(version *commonmodels.DeliveryVersionV2, workDir string, logger *zap.SugaredLogger) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryBundleTimeout)
+	defer cancel()
+
+	contentDir := filepath.Join(workDir, "content")
+	for _, dir := range []string{deliveryBundleChartDir, deliveryBundleYamlDir} {
+		if err := os.MkdirAll(filepath.Join(contentDir, dir), 0755); err != nil {
+			return "", err
+		}
+	}
+
+	registryMap, err := buildRegistryMap()
+	if err != nil {
+		return "", fmt.Errorf("failed to build registry map")
+	}
+
+	layout, err := ocilayout.Open(filepath.Join(contentDir, deliveryBundleImageDir))
+	if err != nil {
+		return "", err
+	}
+
+	var chartRepo *commonmodels.HelmRepo
+	if version.Type == setting.DeliveryVersionTypeChart && version.ChartRepoName != "" {
+		chartRepo, err = getChartRepoData(version.ChartRepoName)
+		if err != nil {
+			return "", fmt.Errorf("failed to query chart repo %s, err: %s", version.ChartRepoName, err)
+		}
+	}
+
+	manifest := &DeliveryBundleManifest{
+		SchemaVersion: deliveryBundleSchemaVersion,
+		Version:       version.Version,
+		ProjectName:   version.ProjectName,
+		Type:          version.Type,
+		Source:        version.Source,
+		Desc:          version.Desc,
+		Labels:        version.Labels,
+		CreatedBy:     version.CreatedBy,
+		CreatedAt:     version.CreatedAt,
+		ExportedAt:    time.Now().Unix(),
+	}
+
+	pulledImages := make(map[string]string)
+	for _, service := range version.Services {
+		bundleService := &DeliveryBundleService{
+			ServiceName:  service.ServiceName,
+			ChartName:    service.ChartName,
+			ChartVersion: service.ChartVersion,
+			Images:       make([]*DeliveryBundleImage, 0, len(service.Images)),
+		}
+
+		if service.YamlContent != "" {
+			bundleService.YamlFile = path.Join(deliveryBundleYamlDir, fmt.Sprintf("%s.yaml", service.ServiceName))
+			if err := os.WriteFile(filepath.Join(contentDir, bundleService.YamlFile), []byte(service.YamlContent), 0644); err != nil {
+				return "", err
+			}
+		}
+
+		if chartRepo != nil && service.ChartVersion != "" {
+			chartPath, err := downloadChartV2(version.ProjectName, version.Version, service.ChartName, service.ChartVersion, chartRepo, false)
+			if err != nil {
+				return "", fmt.Errorf("failed to download chart %s-%s, err: %s", service.ChartName, service.ChartVersion, err)
+			}
+			bundleService.ChartFile = path.Join(deliveryBundleChartDir, filepath.Base(chartPath))
+			if err := copyFile(chartPath, filepath.Join(contentDir, bundleService.ChartFile)); err != nil {
+				return "", err
+			}
+		}
+
+		for _, image := range service.Images {
+			ref := image.TargetImage
+			if ref == "" {
+				ref = image.SourceImage
+			}
+			if ref == "" {
+				continue
+			}
+
+			digest, ok := pulledImages[ref]
+			if !ok {
+				logger.Infof("exporting image %s of delivery version %s", ref, version.Version)
+				digest, err = layout.Pull(ctx, ref, toOCIRegistry(findImageRegistryV2(ref, registryMap)))
+				if err != nil {
+					return "", err
+				}
+				pulledImages[ref] = digest
+			}
+
+			bundleService.Images = append(bundleService.Images, &DeliveryBundleImage{
+				ContainerName: image.ContainerName,
+				ImageName:     image.ImageName,
+				ImagePath:     image.ImagePath,
+				Image:         ref,
+				Digest:        digest,
+			})
+		}
+		manifest.Services = append(manifest.Services, bundleService)
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(contentDir, deliveryBundleManifestFile), manifestBytes, 0644); err != nil {
+		return "", err
+	}
+	if err := writeDeliveryBundleChecksums(contentDir); err != nil {
+		return "", fmt.Errorf("failed to generate checksums, err: %s", err)
+	}
+
+	bundlePath := filepath.Join(workDir, fmt.Sprintf("%s-%s.tar.gz", version.ProjectName, util.SanitizeName(version.Version)))
+	if err := fsutil.Tar(os.DirFS(contentDir), bundlePath); err != nil {
+		return "", fmt.Errorf("failed to archive bundle, err: %s", err)
+	}
+	return bundlePath, nil
+}
+
+// ImportDeliveryVersionBundle validates an offline bundle and creates the delivery version from it, images and
+// charts are then pushed to the appointed registry and chart repo in the background.
+func ImportDeliveryVersionBundle(args *ImportDeliveryBundleArgs, bundle io.ReadCloser, logger *zap.SugaredLogger) (*commonmodels.DeliveryVersionV2, error) {
+	workDir, err := os.MkdirTemp("", "delivery-bundle-")
+	if err != nil {
+		return nil, e.ErrImportDeliveryVersion.AddErr(err)
+	}
+
+	version, err := prepareDeliveryBundleImport(args, bundle, workDir)
+	if err != nil {
+		_ = os.RemoveAll(workDir)
+		logger.Errorf("failed to import delivery bundle to project %s, err: %s", args.ProjectName, err)
+		return nil, e.ErrImportDeliveryVersion.AddErr(err)
+	}
+
+	go func() {
+		defer func() {
+			_ = os.RemoveAll(workDir)
+		}()
+		pushDeliveryBundle(version, args.ImageRegistryID, filepath.Join(workDir, "content"))
+	}()
+
+	return version, nil
+}
+
+func prepareDeliveryBundleImport(args *ImportDeliveryBundleArgs, bundle io.ReadCloser, workDir string) (*commonmodels.DeliveryVersionV2, error) {
+	if _, err := templaterepo.NewProductColl().Find(args.ProjectName); err != nil {
+		return nil, fmt.Errorf("failed to find project %s, err: %s", args.ProjectName, err)
+	}
+
+	bundlePath := filepath.Join(workDir, "bundle.tar.gz")
+	if err := fsutil.SaveFile(bundle, bundlePath); err != nil {
+		return nil, fmt.Errorf("failed to save bundle, err: %s", err)
+	}
+	contentDir := filepath.Join(workDir, "content")
+	if err := fsutil.Untar(bundlePath, contentDir); err != nil {
+		return nil, fmt.Errorf("failed to extract bundle, err: %s", err)
+	}
+	if err := os.Remove(bundlePath); err != nil {
+		return nil, err
+	}
+
+	manifest, err := readDeliveryBundle(contentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	versionName := manifest.Version
+	if args.Version != "" {
+		versionName = args.Version
+	}
+	if err := CheckDeliveryVersion(args.ProjectName, versionName); err != nil {
+		return nil, err
+	}
+
+	registryMap, err := buildRegistryMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build registry map")
+	}
+	targetRegistry, err := checkDeliveryVersionRegistry(&CreateDeliveryVersionRequest{
+		ProjectName:     args.ProjectName,
+		ImageRegistryID: args.ImageRegistryID,
+	}, registryMap)
+	if err != nil {
+		return nil, err
+	}
+
+	version := &commonmodels.DeliveryVersionV2{
+		Version:         versionName,
+		ProjectName:     args.ProjectName,
+		Type:            manifest.Type,
+		Source:          setting.DeliveryVersionSourceBundle,
+		Desc:            manifest.Desc,
+		Labels:          manifest.Labels,
+		ImageRegistryID: args.ImageRegistryID,
+		Status:          setting.DeliveryVersionStatusCreating,
+		CreatedBy:       args.CreateBy,
+		CreatedAt:       time.Now().Unix(),
+	}
+	if manifest.Type == setting.DeliveryVersionTypeChart {
+		version.ChartRepoName = args.ChartRepoName
+	}
+
+	for _, bundleService := range manifest.Services {
+		service := &commonmodels.DeliveryVersionService{
+			ServiceName:  bundleService.ServiceName,
+			ChartName:    bundleService.ChartName,
+			ChartVersion: bundleService.ChartVersion,
+			Images:       make([]*commonmodels.DeliveryVersionImage, 0, len(bundleService.Images)),
+		}
+		if bundleService.YamlFile != "" {
+			content, err := os.ReadFile(filepath.Join(contentDir, filepath.FromSlash(bundleService.YamlFile)))
+			if err != nil {
+				return nil, err
+			}
+			service.YamlContent = string(content)
+		}
+		if bundleService.ChartFile != "" && version.ChartRepoName == "" {
+			return nil, fmt.Errorf("chart repo not appointed for chart %s", bundleService.ChartName)
+		}
+
+		for _, bundleImage := range bundleService.Images {
+			if targetRegistry == nil {
+				return nil, fmt.Errorf("target registry not appointed")
+			}
+			targetImage := buildBundleTargetImage(targetRegistry, bundleImage.Image)
+			service.Images = append(service.Images, &commonmodels.DeliveryVersionImage{
+				ContainerName:  bundleImage.ContainerName,
+				ImageName:      bundleImage.ImageName,
+				ImagePath:      bundleImage.ImagePath,
+				SourceImage:    bundleImage.Image,
+				SourceImageTag: commonutil.ExtractImageTag(bundleImage.Image),
+				TargetImage:    targetImage,
+				TargetImageTag: commonutil.ExtractImageTag(targetImage),
+				PushImage:      true,
+			})
+		}
+		version.Services = append(version.Services, service)
+	}
+
+	if err := commonrepo.NewDeliveryVersionV2Coll().Create(version); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("version %s already exist", version.Version)
+		}
+		return nil, fmt.Errorf("failed to insert delivery version %s, err: %s", version.Version, err)
+	}
+	return version, nil
+}
+
+func pushDeliveryBundle(version *commonmodels.DeliveryVersionV2, registryID, contentDir string) {
+	err := pushDeliveryBundleContent(version, registryID, contentDir)
+	if dbErr := commonrepo.NewDeliveryVersionV2Coll().UpdateServiceStatus(version); dbErr != nil {
+		log.Errorf("failed to update services of delivery version %s, err: %s", version.Version, dbErr)
+	}
+	if err != nil {
+		log.Errorf("failed to push delivery bundle of version %s in project %s, err: %s", version.Version, version.ProjectName, err)
+		updateVersionStatusV2(version.Version, version.ProjectName, setting.DeliveryVersionStatusFailed, err.Error())
+		return
+	}
+	updateVersionStatusV2(version.Version, version.ProjectName, setting.DeliveryVersionStatusSuccess, "")
+}
+
+func pushDeliveryBundleContent(version *commonmodels.DeliveryVersionV2, registryID, contentDir string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryBundleTimeout)
+	defer cancel()
+
+	manifest, err := readDeliveryBundleManifest(contentDir)
+	if err != nil {
+		return err
+	}
+
+	var targetRegistry *ocilayout.Registry
+	if registryID != "" {
+		registryMap, err := buildRegistryMap()
+		if err != nil {
+			return fmt.Errorf("failed to build registry map")
+		}
+		for _, registry := range registryMap {
+			if registry.ID.Hex() == registryID {
+				targetRegistry = toOCIRegistry(registry)
+				break
+			}
+		}
+		if targetRegistry == nil {
+			return fmt.Errorf("can not find target registry")
+		}
+	}
+
+	layout, err := ocilayout.Open(filepath.Join(contentDir, deliveryBundleImageDir))
+	if err != nil {
+		return err
+	}
+
+	pushedImages := sets.NewString()
+	for _, service := range version.Services {
+		for _, image := range service.Images {
+			if pushedImages.Has(image.TargetImage) {
+				image.Status = config.StatusPassed
+				continue
+			}
+			if _, err := layout.Push(ctx, image.SourceImage, image.TargetImage, targetRegistry); err != nil {
+				image.Status = config.StatusFailed
+				image.Error = err.Error()
+				return err
+			}
+			image.Status = config.StatusPassed
+			pushedImages.Insert(image.TargetImage)
+		}
+
+		switch version.Type {
+		case setting.DeliveryVersionTypeYaml:
+			if err := updateDeliveryServiceImageInYaml(service, version.Source); err != nil {
+				return fmt.Errorf("failed to update service image in yaml, serviceName: %s, err: %s", service.ServiceName, err)
+			}
+		case setting.DeliveryVersionTypeChart:
+			if service.YamlContent == "" {
+				continue
+			}
+			values, err := updateBundleValuesImage(service)
+			if err != nil {
+				return fmt.Errorf("failed to update values image, serviceName: %s, err: %s", service.ServiceName, err)
+			}
+			service.YamlContent = string(values)
+		}
+	}
+
+	if version.Type != setting.DeliveryVersionTypeChart {
+		return nil
+	}
+
+	chartFiles := make(map[string]string)
+	for _, bundleService := range manifest.Services {
+		chartFiles[bundleService.ServiceName] = bundleService.ChartFile
+	}
+
+	chartRepo, err := getChartRepoData(version.ChartRepoName)
+	if err != nil {
+		return fmt.Errorf("failed to query chart repo %s, err: %s", version.ChartRepoName, err)
+	}
+	outDir := filepath.Join(filepath.Dir(contentDir), "charts")
+	for _, service := range version.Services {
+		chartFile := chartFiles[service.ServiceName]
+		if chartFile == "" {
+			service.ChartStatus = config.StatusPassed
+			continue
+		}
+		if err := pushBundleChart(service, filepath.Join(contentDir, filepath.FromSlash(chartFile)), chartRepo, outDir); err != nil {
+			service.ChartStatus = config.StatusFailed
+			service.Error = err.Error()
+			return err
+		}
+		service.ChartStatus = config.StatusPassed
+	}
+	return nil
+}
+
+// updateBundleValuesImage points the images in values.yaml to the target registry, images which can't be located
+// by the image path are replaced literally.
+func updateBundleValuesImage(service *commonmodels.DeliveryVersionService) ([]byte, error) {
+	located := make([]*commonmodels.DeliveryVersionImage, 0, len(service.Images))
+	for _, image := range service.Images {
+		if image.ImagePath != nil {
+			located = append(located, image)
+			continue
+		}
+		service.YamlContent = strings.ReplaceAll(service.YamlContent, image.SourceImage, image.TargetImage)
+	}
+
+	return updateValuesImage(&commonmodels.DeliveryVersionService{
+		ServiceName: service.ServiceName,
+		YamlContent: service.YamlContent,
+		Images:      located,
+	})
+}
+
+func pushBundleChart(service *commonmodels.DeliveryVersionService, chartPath string, chartRepo *commonmodels.HelmRepo, outDir string) error {
+	chartRequested, err := chartloader.Load(chartPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load chart %s", filepath.Base(chartPath))
+	}
+
+	if service.YamlContent != "" {
+		for _, file := range chartRequested.Raw {
+			if file.Name == setting.ValuesYaml {
+				file.Data = []byte(service.YamlContent)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	chartPackagePath, err := helmchartutil.Save(chartRequested, outDir)
+	if err != nil {
+		return err
+	}
+
+	client, err := commonutil.NewHelmClient(chartRepo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create chart repo client, repoName: %s", chartRepo.RepoName)
+	}
+
+	proxy, err := commonutil.GenHelmChartProxy(chartRepo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate helm chart proxy, repoName: %s", chartRepo.RepoName)
+	}
+
+	log.Infof("pushing chart %s to %s...", filepath.Base(chartPackagePath), chartRepo.URL)
+	err = client.PushChart(commonutil.GeneHelmRepo(chartRepo), chartPackagePath, proxy)
+	if err != nil {
+		return errors.Wrapf(err, "failed to push chart: %s", chartPackagePath)
+	}
+	return nil
+}
+
+// readDeliveryBundle verifies the checksums of an extracted bundle and returns its manifest.
+func readDeliveryBundle(root string) (*DeliveryBundleManifest, error) {
+	files, err := verifyDeliveryBundleChecksums(root)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := readDeliveryBundleManifest(root)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.SchemaVersion != deliveryBundleSchemaVersion {
+		return nil, fmt.Errorf("unsupported bundle schema version: %s", manifest.SchemaVersion)
+	}
+	if manifest.Type != setting.DeliveryVersionTypeYaml && manifest.Type != setting.DeliveryVersionTypeChart {
+		return nil, fmt.Errorf("invalid version type: %s", manifest.Type)
+	}
+
+	var layout *ocilayout.Layout
+	for _, service := range manifest.Services {
+		for _, file := range []string{service.ChartFile, service.YamlFile} {
+			if file != "" && !files.Has(file) {
+				return nil, fmt.Errorf("file %s of service %s is missing in the bundle", file, service.ServiceName)
+			}
+		}
+
+		for _, image := range service.Images {
+			if layout == nil {
+				layout, err = ocilayout.Open(filepath.Join(root, deliveryBundleImageDir))
+				if err != nil {
+					return nil, err
+				}
+			}
+			digest, err := layout.Resolve(context.Background(), image.Image)
+			if err != nil {
+				return nil, err
+			}
+			if digest != image.Digest {
+				return nil, fmt.Errorf("digest mismatch for image %s, expected %s, got %s", image.Image, image.Digest, digest)
+			}
+		}
+	}
+	return manifest, nil
+}
+
+func readDeliveryBundleManifest(root string) (*DeliveryBundleManifest, error) {
+	content, err := os.ReadFile(filepath.Join(root, deliveryBundleManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle manifest, err: %s", err)
+	}
+	manifest := new(DeliveryBundleManifest)
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("invalid bundle manifest, err: %s", err)
+	}
+	return manifest, nil
+}
+
+func writeDeliveryBundleChecksums(root string) error {
+	var sb strings.Builder
+	err := filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == deliveryBundleChecksumFile {
+			return nil
+		}
+		sum, err := sha256File(p)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(fmt.Sprintf("%s  %s\n", sum, rel))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, deliveryBundleChecksumFile), []byte(sb.String()), 0644)
+}
+
+// verifyDeliveryBundleChecksums checks every file of the bundle against the checksum file and returns the
+// verified files, files that are not recorded in the checksum file are rejected.
+func verifyDeliveryBundleChecksums(root string) (sets.String, error) {
+	file, err := os.Open(filepath.Join(root, deliveryBundleChecksumFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle checksums, err: %s", err)
+	}
+	defer file.Close()
+
+	verified := sets.NewString()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		expected, rel, ok := strings.Cut(line, "  ")
+		if !ok || !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return nil, fmt.Errorf("invalid checksum line: %s", line)
+		}
+		sum, err := sha256File(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check file %s, err: %s", rel, err)
+		}
+		if sum != expected {
+			return nil, fmt.Errorf("checksum mismatch for file %s", rel)
+		}
+		verified.Insert(rel)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != deliveryBundleChecksumFile && !verified.Has(rel) {
+			return fmt.Errorf("file %s is not recorded in the bundle checksums", rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !verified.Has(deliveryBundleManifestFile) {
+		return nil, fmt.Errorf("bundle manifest is not recorded in the bundle checksums")
+	}
+	return verified, nil
+}
+
+func sha256File(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return fsutil.SaveFile(in, dst)
+}
+
+// findImageRegistryV2 returns the registry whose address and namespace prefix the image, nil is returned
+// for images of registries unknown to zadig, which are accessed anonymously.
+func findImageRegistryV2(image string, registryMap map[string]*commonmodels.RegistryNamespace) *commonmodels.RegistryNamespace {
+	idx := strings.LastIndex(image, "/")
+	if idx < 0 {
+		return nil
+	}
+	return registryMap[image[:idx]]
+}
+
+func buildBundleTargetImage(registry *commonmodels.RegistryNamespace, image string) string {
+	registryURL := strings.TrimPrefix(strings.TrimPrefix(registry.RegAddr, "https://"), "http://")
+	registryURL = strings.TrimSuffix(fmt.Sprintf("%s/%s", strings.TrimSuffix(registryURL, "/"), registry.Namespace), "/")
+
+	tag := commonutil.ExtractImageTag(image)
+	if tag == "" {
+		tag = "latest"
+	}
+	return fmt.Sprintf("%s/%s:%s", registryURL, commonutil.ExtractImageName(image), tag)
+}
+
+func toOCIRegistry(registry *commonmodels.RegistryNamespace) *ocilayout.Registry {
+	if registry == nil {
+		return nil
+	}
+
+	resp := &ocilayout.Registry{
+		Address:  registry.RegAddr,
+		Username: registry.AccessKey,
+		Password: registry.SecretKey,
+	}
+	// SWR and ECR are signed by well known CAs
+	if registry.RegProvider != config.RegistryTypeSWR && registry.RegProvider != config.RegistryTypeAWS && registry.AdvancedSetting != nil {
+		if registry.AdvancedSetting.TLSEnabled {
+			resp.CACert = registry.AdvancedSetting.TLSCert
+		} else {
+			resp.Insecure = true
+		}
+	}
+	return resp
+}
@@ -565,6 +565,9 @@ func RetryDeliveryVersionV2(id string, logger *zap.SugaredLogger) error {
 		logger.Errorf("failed to query delivery version data, id: %s, error: %s", id, err)
 		return fmt.Errorf("failed to query delivery version data, id: %s, error: %s", id, err)
 	}
+	if deliveryVersion.Source == setting.DeliveryVersionSourceBundle {
+		return fmt.Errorf("version imported from an offline bundle can't be retried, please import the bundle again")
+	}
 
 	if deliveryVersion.Type == setting.DeliveryVersionTypeChart {
 		return RetryCreateHelmDeliveryVersionV2(deliveryVersion, logger)
@@ -214,6 +214,10 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		environments.GET("/:name/analysis/cron", GetEnvAnalysisCron)
 		environments.PUT("/:name/analysis/cron", UpsertEnvAnalysisCron)
 		environments.GET("/analysis/history", GetEnvAnalysisHistory)
+		environments.POST("/:name/health-reports", RunEnvHealthReport)
+		environments.GET("/:name/health-reports", ListEnvHealthReports)
+		environments.GET("/:name/health-reports/cron", GetEnvHealthReportCron)
+		environments.PUT("/:name/health-reports/cron", UpsertEnvHealthReportCron)
 
 		environments.POST("/:name/sleep", EnvSleep)
 		environments.GET("/:name/sleep/cron", GetEnvSleepCron)
@@ -91,7 +91,12 @@ func GetServiceDiff(envName, productName, serviceName string, production bool, l
 
 	svcRender := serviceInfo.GetServiceRender()
 
-	resp.Current.Yaml, err = kube.RenderServiceYaml(oldService.Yaml, productName, serviceName, svcRender)
+	oldServiceYaml, err := kube.ServiceTemplateYaml(oldService, envName)
+	if err != nil {
+		log.Errorf("failed to build current service yaml, err: %s", err)
+		return nil, err
+	}
+	resp.Current.Yaml, err = kube.RenderServiceYaml(oldServiceYaml, productName, serviceName, svcRender)
 	if err != nil {
 		log.Error("failed to RenderServiceYaml, err: %s", err)
 		return nil, err
@@ -108,7 +113,12 @@ func GetServiceDiff(envName, productName, serviceName string, production bool, l
 	svcRender.OverrideYaml.YamlContent = mergedYaml
 	svcRender.OverrideYaml.RenderVariableKVs = mergedServiceVariableKVs
 
-	resp.Latest.Yaml, err = kube.RenderServiceYaml(newService.Yaml, productName, serviceName, svcRender)
+	newServiceYaml, err := kube.ServiceTemplateYaml(newService, envName)
+	if err != nil {
+		log.Errorf("failed to build latest service yaml, err: %s", err)
+		return nil, err
+	}
+	resp.Latest.Yaml, err = kube.RenderServiceYaml(newServiceYaml, productName, serviceName, svcRender)
 	if err != nil {
 		log.Error("failed to RenderServiceYaml, err: %s", err)
 		return nil, err
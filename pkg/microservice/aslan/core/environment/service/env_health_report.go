@@ -0,0 +1,317 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configbase "github.com/koderover/zadig/v2/pkg/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/ai"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	airepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/ai"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/imnotify"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/analysis"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// envAnalysisFilters returns the analyzers configured for the environment, custom rules are
+// addressed by their names. A nil filter list means the core analyzers, an empty one means nothing.
+func envAnalysisFilters(env *commonmodels.Product) ([]string, []analysis.Rule, error) {
+	if env.AnalysisConfig == nil {
+		return nil, nil, nil
+	}
+
+	rules, err := analysis.ParseRules([]byte(env.AnalysisConfig.CustomRules))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filters := make([]string, 0)
+	for _, resourceType := range env.AnalysisConfig.ResourceTypes {
+		filters = append(filters, string(resourceType))
+	}
+	for _, rule := range rules {
+		filters = append(filters, rule.Name)
+	}
+	return filters, rules, nil
+}
+
+// RunEnvHealthReport runs the analyzers of the environment without an LLM, stores the problems
+// found and diffs them against the previous report. Scheduled runs notify the IM channels of the
+// environment when problems appeared or were resolved since then.
+func RunEnvHealthReport(projectName, envName string, production bool, triggerName, userName string, logger *zap.SugaredLogger) (*ai.EnvHealthReport, error) {
+	var err error
+	report := &ai.EnvHealthReport{
+		ProjectName: projectName,
+		EnvName:     envName,
+		Production:  production,
+		Problems:    make([]*ai.EnvHealthProblem, 0),
+		New:         make([]*ai.EnvHealthProblem, 0),
+		Resolved:    make([]*ai.EnvHealthProblem, 0),
+		TriggerName: triggerName,
+		CreatedBy:   userName,
+		StartTime:   time.Now().Unix(),
+	}
+
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		EnvName:    envName,
+		Name:       projectName,
+		Production: &production,
+	})
+	if err != nil {
+		return nil, e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to get environment %s/%s, err: %w", projectName, envName, err))
+	}
+
+	previous, err := airepo.NewEnvHealthReportColl().FindLatestSuccess(projectName, envName, production)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to get previous health report, err: %w", err))
+	}
+
+	defer func() {
+		if err != nil {
+			report.Err = err.Error()
+			report.Status = setting.AIEnvAnalysisStatusFailed
+		} else {
+			report.Status = setting.AIEnvAnalysisStatusSuccess
+		}
+		report.EndTime = time.Now().Unix()
+		if createErr := airepo.NewEnvHealthReportColl().Create(report); createErr != nil {
+			logger.Errorf("failed to save env health report, err: %s", createErr)
+		}
+	}()
+
+	filters, rules, err := envAnalysisFilters(env)
+	if err != nil {
+		return report, e.ErrAnalysisEnvResource.AddErr(err)
+	}
+	if filters != nil && len(filters) == 0 {
+		return report, nil
+	}
+
+	analysiser, err := analysis.NewAnalysis(
+		context.TODO(), env.ClusterID,
+		nil,
+		filters, env.Namespace,
+		true,  // noCache bool
+		false, // explain bool
+		10,    // maxConcurrency int
+		false, // withDoc bool
+	)
+	if err != nil {
+		return report, e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to create analysiser, err: %w", err))
+	}
+	analysiser.Rules = rules
+	analysiser.RunAnalysis(filters)
+
+	report.Errors = analysiser.Errors
+	report.Problems = envHealthProblems(analysiser.Results)
+	var previousProblems []*ai.EnvHealthProblem
+	if previous != nil {
+		previousProblems = previous.Problems
+	}
+	report.New, report.Resolved = diffEnvHealthProblems(previousProblems, report.Problems)
+
+	if triggerName == setting.CronTaskCreator && (len(report.New) > 0 || len(report.Resolved) > 0) {
+		util.Go(func() {
+			if err := EnvHealthReportNotification(report, env.NotificationConfigs); err != nil {
+				log.Errorf("failed to send env health report notification, err: %s", err)
+			}
+		})
+	}
+
+	return report, nil
+}
+
+func envHealthProblems(results []analysis.Result) []*ai.EnvHealthProblem {
+	problems := make([]*ai.EnvHealthProblem, 0, len(results))
+	for _, result := range results {
+		problem := &ai.EnvHealthProblem{
+			Kind:     result.Kind,
+			Name:     result.Name,
+			Failures: make([]string, 0, len(result.Error)),
+		}
+		for _, failure := range result.Error {
+			problem.Failures = append(problem.Failures, failure.Text)
+		}
+		sort.Strings(problem.Failures)
+		problems = append(problems, problem)
+	}
+	sort.Slice(problems, func(i, j int) bool {
+		return problems[i].Key() < problems[j].Key()
+	})
+	return problems
+}
+
+// diffEnvHealthProblems compares the failures of two reports. A resource with a failure that was
+// not reported before is new, and a failure that is no longer reported is resolved.
+func diffEnvHealthProblems(previous, current []*ai.EnvHealthProblem) ([]*ai.EnvHealthProblem, []*ai.EnvHealthProblem) {
+	newProblems := subtractEnvHealthProblems(current, previous)
+	resolvedProblems := subtractEnvHealthProblems(previous, current)
+	return newProblems, resolvedProblems
+}
+
+// subtractEnvHealthProblems returns the failures in a that are not in b, grouped by resource
+func subtractEnvHealthProblems(a, b []*ai.EnvHealthProblem) []*ai.EnvHealthProblem {
+	known := make(map[string]sets.String)
+	for _, problem := range b {
+		known[problem.Key()] = sets.NewString(problem.Failures...)
+	}
+
+	resp := make([]*ai.EnvHealthProblem, 0)
+	for _, problem := range a {
+		failures := make([]string, 0)
+		for _, failure := range problem.Failures {
+			if !known[problem.Key()].Has(failure) {
+				failures = append(failures, failure)
+			}
+		}
+		if len(failures) > 0 {
+			resp = append(resp, &ai.EnvHealthProblem{
+				Kind:     problem.Kind,
+				Name:     problem.Name,
+				Failures: failures,
+			})
+		}
+	}
+	return resp
+}
+
+func EnvHealthReportNotification(report *ai.EnvHealthReport, configs []*commonmodels.NotificationConfig) error {
+	for _, notifyConfig := range configs {
+		eventSet := sets.NewString()
+		for _, event := range notifyConfig.Events {
+			eventSet.Insert(string(event))
+		}
+		if !eventSet.Has(string(commonmodels.NotificationEventHealthReportChanged)) {
+			continue
+		}
+
+		title, sections := getEnvHealthReportNotificationContent(report)
+		envDetailURL := fmt.Sprintf("%s/v1/projects/detail/%s/envs/detail?envName=%s", configbase.SystemAddress(), report.ProjectName, report.EnvName)
+		buttonContent := "点击查看更多信息"
+
+		imnotifyClient := imnotify.NewIMNotifyClient()
+		switch imnotify.IMNotifyType(notifyConfig.WebHookType) {
+		case imnotify.IMNotifyTypeDingDing:
+			content := fmt.Sprintf("### %s\n%s\n\n---\n\n[%s](%s)", title, strings.Join(sections, "\n"), buttonContent, envDetailURL)
+			if err := imnotifyClient.SendDingDingMessage(notifyConfig.WebHookURL, title, content, nil, false); err != nil {
+				return err
+			}
+		case imnotify.IMNotifyTypeLark:
+			status := config.StatusPassed
+			if len(report.Problems) > 0 {
+				status = config.StatusFailed
+			}
+			lc := imnotify.NewLarkCard()
+			lc.SetConfig(true)
+			lc.SetHeader(imnotify.GetColorTemplateWithStatus(status), title, "plain_text")
+			for idx, section := range sections {
+				lc.AddI18NElementsZhcnFeild(section, idx == 0)
+			}
+			lc.AddI18NElementsZhcnAction(buttonContent, envDetailURL)
+			if err := imnotifyClient.SendFeishuMessage(notifyConfig.WebHookURL, lc); err != nil {
+				return err
+			}
+		case imnotify.IMNotifyTypeWeChat:
+			content := fmt.Sprintf("### %s\n%s\n[%s](%s)", title, strings.Join(sections, "\n"), buttonContent, envDetailURL)
+			if err := imnotifyClient.SendWeChatWorkMessage(imnotify.WeChatTextTypeMarkdown, notifyConfig.WebHookURL, content); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func getEnvHealthReportNotificationContent(report *ai.EnvHealthReport) (string, []string) {
+	icon := "👍"
+	if len(report.Problems) > 0 {
+		icon = "⚠️"
+	}
+	title := fmt.Sprintf("%s %s / %s 环境健康报告", icon, report.ProjectName, report.EnvName)
+
+	sections := []string{
+		fmt.Sprintf("**巡检时间：%s** \n", time.Unix(report.StartTime, 0).Format("2006-01-02 15:04:05")),
+		fmt.Sprintf("当前问题 %d 个，新增 %d 个，已恢复 %d 个 \n", len(report.Problems), len(report.New), len(report.Resolved)),
+	}
+	if len(report.New) > 0 {
+		sections = append(sections, "**新增问题：** \n"+formatEnvHealthProblems(report.New))
+	}
+	if len(report.Resolved) > 0 {
+		sections = append(sections, "**已恢复：** \n"+formatEnvHealthProblems(report.Resolved))
+	}
+	return title, sections
+}
+
+func formatEnvHealthProblems(problems []*ai.EnvHealthProblem) string {
+	builder := &strings.Builder{}
+	for _, problem := range problems {
+		for _, failure := range problem.Failures {
+			builder.WriteString(fmt.Sprintf("- %s %s: %s \n", problem.Kind, problem.Name, failure))
+		}
+	}
+	return builder.String()
+}
+
+func ListEnvHealthReports(projectName, envName string, production bool, pageNum, pageSize int, logger *zap.SugaredLogger) ([]*ai.EnvHealthReport, int64, error) {
+	reports, count, err := airepo.NewEnvHealthReportColl().ListByOptions(airepo.EnvHealthReportListOption{
+		ProjectName: projectName,
+		EnvName:     envName,
+		Production:  production,
+		PageNum:     int64(pageNum),
+		PageSize:    int64(pageSize),
+	})
+	if err != nil {
+		logger.Errorf("Failed to list env health reports, project name: %s, env name: %s, error: %v", projectName, envName, err)
+		return nil, 0, err
+	}
+	return reports, count, nil
+}
+
+func getEnvHealthReportCronName(projectName, envName string) string {
+	return fmt.Sprintf("%s-%s-%s", envName, projectName, setting.EnvHealthReportCronjob)
+}
+
+func UpsertEnvHealthReportCron(projectName, envName string, production *bool, req *EnvAnalysisCronArg, logger *zap.SugaredLogger) error {
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{
+		EnvName:    envName,
+		Name:       projectName,
+		Production: production,
+	})
+	if err != nil {
+		return e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to get environment %s/%s, err: %w", projectName, envName, err))
+	}
+
+	return upsertEnvCronjob(env, getEnvHealthReportCronName(projectName, envName), setting.EnvHealthReportCronjob, req)
+}
+
+func GetEnvHealthReportCron(projectName, envName string, logger *zap.SugaredLogger) (*EnvAnalysisCronArg, error) {
+	return getEnvCronjob(projectName, envName, getEnvHealthReportCronName(projectName, envName), setting.EnvHealthReportCronjob, logger)
+}
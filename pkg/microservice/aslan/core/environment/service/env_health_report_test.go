@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/ai"
+)
+
+var _ = Describe("Testing env health report", func() {
+
+	Describe("test diffEnvHealthProblems", func() {
+		previous := []*ai.EnvHealthProblem{
+			{Kind: "Pod", Name: "default/web", Failures: []string{"CrashLoopBackOff"}},
+			{Kind: "Service", Name: "default/api", Failures: []string{"no endpoints", "not ready endpoints"}},
+		}
+		current := []*ai.EnvHealthProblem{
+			{Kind: "Service", Name: "default/api", Failures: []string{"no endpoints"}},
+			{Kind: "ResourceQuota", Name: "default/quota", Failures: []string{"exhausted"}},
+		}
+
+		It("should report new and resolved failures", func() {
+			newProblems, resolved := diffEnvHealthProblems(previous, current)
+			Expect(newProblems).To(Equal([]*ai.EnvHealthProblem{
+				{Kind: "ResourceQuota", Name: "default/quota", Failures: []string{"exhausted"}},
+			}))
+			Expect(resolved).To(Equal([]*ai.EnvHealthProblem{
+				{Kind: "Pod", Name: "default/web", Failures: []string{"CrashLoopBackOff"}},
+				{Kind: "Service", Name: "default/api", Failures: []string{"not ready endpoints"}},
+			}))
+		})
+
+		It("should treat every failure as new without a previous report", func() {
+			newProblems, resolved := diffEnvHealthProblems(nil, current)
+			Expect(newProblems).To(HaveLen(2))
+			Expect(resolved).To(BeEmpty())
+		})
+	})
+})
@@ -3811,6 +3811,7 @@ func UpdateProductGlobalVariablesWithRender(templateProduct *templatemodels.Prod
 type EnvConfigsArgs struct {
 	AnalysisConfig      *models.AnalysisConfig       `json:"analysis_config"`
 	NotificationConfigs []*models.NotificationConfig `json:"notification_configs"`
+	TerminalPolicy      *models.TerminalPolicy       `json:"terminal_policy"`
 }
 
 func GetEnvConfigs(projectName, envName string, production *bool, logger *zap.SugaredLogger) (*EnvConfigsArgs, error) {
@@ -3833,9 +3834,15 @@ func GetEnvConfigs(projectName, envName string, production *bool, logger *zap.Su
 		notificationConfigs = env.NotificationConfigs
 	}
 
+	terminalPolicy := &models.TerminalPolicy{}
+	if env.TerminalPolicy != nil {
+		terminalPolicy = env.TerminalPolicy
+	}
+
 	configs := &EnvConfigsArgs{
 		AnalysisConfig:      analysisConfig,
 		NotificationConfigs: notificationConfigs,
+		TerminalPolicy:      terminalPolicy,
 	}
 	return configs, nil
 }
@@ -3858,7 +3865,17 @@ func UpdateEnvConfigs(projectName, envName, userName string, arg *EnvConfigsArgs
 		}
 	}
 
-	err = commonrepo.NewProductColl().UpdateConfigs(envName, projectName, arg.AnalysisConfig, arg.NotificationConfigs, userName)
+	if arg.AnalysisConfig != nil {
+		if _, err := analysis.ParseRules([]byte(arg.AnalysisConfig.CustomRules)); err != nil {
+			return e.ErrUpdateEnvConfigs.AddErr(err)
+		}
+	}
+
+	if arg.TerminalPolicy != nil && arg.TerminalPolicy.ApprovalTTL < 0 {
+		return e.ErrUpdateEnvConfigs.AddErr(fmt.Errorf("invalid terminal approval ttl %d", arg.TerminalPolicy.ApprovalTTL))
+	}
+
+	err = commonrepo.NewProductColl().UpdateConfigs(envName, projectName, arg.AnalysisConfig, arg.NotificationConfigs, arg.TerminalPolicy, userName)
 	if err != nil {
 		return e.ErrUpdateEnvConfigs.AddErr(fmt.Errorf("failed to update environment %s/%s, err: %w", projectName, envName, err))
 	}
@@ -3920,15 +3937,12 @@ func EnvAnalysis(projectName, envName string, production *bool, triggerName stri
 		return resp, e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to get environment %s/%s, err: %w", projectName, envName, err))
 	}
 
-	filters := []string{}
-	if env.AnalysisConfig != nil {
-		if len(env.AnalysisConfig.ResourceTypes) == 0 {
-			return resp, nil
-		} else {
-			for _, resourceType := range env.AnalysisConfig.ResourceTypes {
-				filters = append(filters, string(resourceType))
-			}
-		}
+	filters, rules, err := envAnalysisFilters(env)
+	if err != nil {
+		return resp, e.ErrAnalysisEnvResource.AddErr(err)
+	}
+	if filters != nil && len(filters) == 0 {
+		return resp, nil
 	}
 
 	ctx := context.TODO()
@@ -3950,6 +3964,7 @@ func EnvAnalysis(projectName, envName string, production *bool, triggerName stri
 		return resp, e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to create analysiser, err: %w", err))
 	}
 
+	analysiser.Rules = rules
 	analysiser.RunAnalysis(filters)
 	err = analysiser.GetAIResults(false)
 	if err != nil {
@@ -3993,9 +4008,14 @@ func UpsertEnvAnalysisCron(projectName, envName string, production *bool, req *E
 		return e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to get environment %s/%s, err: %w", projectName, envName, err))
 	}
 
+	return upsertEnvCronjob(env, getEnvAnalysisCronName(projectName, envName), setting.EnvAnalysisCronjob, req)
+}
+
+// upsertEnvCronjob creates or updates the cron job of the given type bound to the environment and
+// notifies the cron service about it.
+func upsertEnvCronjob(env *commonmodels.Product, name, jobType string, req *EnvAnalysisCronArg) error {
 	found := false
-	name := getEnvAnalysisCronName(projectName, envName)
-	cron, err := commonrepo.NewCronjobColl().GetByName(name, setting.EnvAnalysisCronjob)
+	cron, err := commonrepo.NewCronjobColl().GetByName(name, jobType)
 	if err != nil {
 		if err != mongo.ErrNoDocuments && err != mongo.ErrNilDocument {
 			return e.ErrAnalysisEnvResource.AddErr(fmt.Errorf("failed to get cron job %s, err: %w", name, err))
@@ -4020,14 +4040,14 @@ func UpsertEnvAnalysisCron(projectName, envName string, production *bool, req *E
 			// need to disable cronjob
 			payload = &commonservice.CronjobPayload{
 				Name:       name,
-				JobType:    setting.EnvAnalysisCronjob,
+				JobType:    jobType,
 				Action:     setting.TypeEnableCronjob,
 				DeleteList: []string{cron.ID.Hex()},
 			}
 		} else if !origEnabled && req.Enable || origEnabled && req.Enable {
 			payload = &commonservice.CronjobPayload{
 				Name:    name,
-				JobType: setting.EnvAnalysisCronjob,
+				JobType: jobType,
 				Action:  setting.TypeEnableCronjob,
 				JobList: []*commonmodels.Schedule{cronJobToSchedule(cron)},
 			}
@@ -4039,7 +4059,7 @@ func UpsertEnvAnalysisCron(projectName, envName string, production *bool, req *E
 		input := &commonmodels.Cronjob{
 			Name:    name,
 			Enabled: req.Enable,
-			Type:    setting.EnvAnalysisCronjob,
+			Type:    jobType,
 			Cron:    req.Cron,
 			EnvAnalysisArgs: &commonmodels.EnvArgs{
 				ProductName: env.ProductName,
@@ -4060,7 +4080,7 @@ func UpsertEnvAnalysisCron(projectName, envName string, production *bool, req *E
 
 		payload = &commonservice.CronjobPayload{
 			Name:    name,
-			JobType: setting.EnvAnalysisCronjob,
+			JobType: jobType,
 			Action:  setting.TypeEnableCronjob,
 			JobList: []*commonmodels.Schedule{cronJobToSchedule(input)},
 		}
@@ -4100,13 +4120,16 @@ func cronJobToSchedule(input *commonmodels.Cronjob) *commonmodels.Schedule {
 }
 
 func GetEnvAnalysisCron(projectName, envName string, production *bool, logger *zap.SugaredLogger) (*EnvAnalysisCronArg, error) {
-	name := getEnvAnalysisCronName(projectName, envName)
+	return getEnvCronjob(projectName, envName, getEnvAnalysisCronName(projectName, envName), setting.EnvAnalysisCronjob, logger)
+}
+
+func getEnvCronjob(projectName, envName, name, jobType string, logger *zap.SugaredLogger) (*EnvAnalysisCronArg, error) {
 	crons, err := commonrepo.NewCronjobColl().List(&commonrepo.ListCronjobParam{
 		ParentName: name,
-		ParentType: setting.EnvAnalysisCronjob,
+		ParentType: jobType,
 	})
 	if err != nil {
-		fmtErr := fmt.Errorf("Failed to list env analysis cron jobs, project name %s, env name: %s, error: %w", projectName, envName, err)
+		fmtErr := fmt.Errorf("Failed to list env %s cron jobs, project name %s, env name: %s, error: %w", jobType, projectName, envName, err)
 		logger.Error(fmtErr)
 		return nil, e.ErrGetCronjob.AddErr(fmtErr)
 	}
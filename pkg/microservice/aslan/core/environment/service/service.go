@@ -190,7 +190,12 @@ func GetServiceWorkloads(svcTmpl *commonmodels.Service, env *commonmodels.Produc
 	}
 
 	svcRender := env.GetSvcRender(svcTmpl.ServiceName)
-	parsedYaml, err := kube.RenderServiceYaml(svcTmpl.Yaml, productName, svcTmpl.ServiceName, svcRender)
+	svcYaml, err := kube.ServiceTemplateYaml(svcTmpl, envName)
+	if err != nil {
+		log.Errorf("failed to get service yaml, err: %s", err)
+		return nil, err
+	}
+	parsedYaml, err := kube.RenderServiceYaml(svcYaml, productName, svcTmpl.ServiceName, svcRender)
 	if err != nil {
 		log.Errorf("failed to render service yaml, err: %s", err)
 		return nil, err
@@ -41,6 +41,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	approvalservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/approval"
 	dingservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/dingtalk"
+	slackservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/slack"
 	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	"github.com/koderover/zadig/v2/pkg/shared/client/user"
 	"github.com/koderover/zadig/v2/pkg/tool/dingtalk"
@@ -137,6 +138,8 @@ func createApprovalInstance(plan *models.ReleasePlan, phone string) error {
 		return createDingTalkApproval(plan.Approval.DingTalkApproval, plan.Manager, phone, formContent, language)
 	case config.WorkWXApproval:
 		return createWorkWXApproval(plan.Approval.WorkWXApproval, plan.Manager, phone, formContent, language)
+	case config.SlackApproval:
+		return createSlackApproval(plan, detailURL, language)
 	default:
 		return errors.New("invalid approval type")
 	}
@@ -450,6 +453,122 @@ func createNativeApproval(plan *models.ReleasePlan, url string) error {
 	return nil
 }
 
+// createSlackApproval posts the approval message with Approve/Reject buttons, the decisions made in slack are recorded
+// by a native approval saved in plan.Approval.NativeApproval and picked up by the approval watcher.
+func createSlackApproval(plan *models.ReleasePlan, detailURL, language string) error {
+	if plan == nil || plan.Approval == nil || plan.Approval.SlackApproval == nil {
+		return errors.New("createSlackApproval: slack approval data not found")
+	}
+	approval := plan.Approval.SlackApproval
+
+	client, err := slackservice.GetSlackClientByIMAppID(approval.ID)
+	if err != nil {
+		return errors.Wrap(err, "get slack client")
+	}
+
+	approvalUsers, _ := util.GeneFlatUsers(approval.ApproveUsers)
+	nativeApproval := &models.NativeApproval{
+		Timeout:         approval.Timeout,
+		ApproveUsers:    approvalUsers,
+		NeededApprovers: approval.NeededApprovers,
+		InstanceCode:    uuid.New().String(),
+	}
+	approvalservice.GlobalApproveMap.SetApproval(nativeApproval.InstanceCode, nativeApproval)
+
+	title, content := getSlackApprovalMessage(plan, language)
+	mentions := slackservice.GetSlackUserIDs(approval.ID, client, approvalUsers)
+	resp, err := client.PostMessage(approval.Channel, title, slackservice.NewApprovalBlocks(title, content, detailURL, nativeApproval.InstanceCode, mentions))
+	if err != nil {
+		approvalservice.GlobalApproveMap.DeleteApproval(nativeApproval.InstanceCode)
+		return errors.Wrap(err, "post slack approval message")
+	}
+	approval.ChannelID = resp.Channel
+	approval.MessageTS = resp.TS
+
+	plan.Approval.NativeApproval = nativeApproval
+	plan.Approval.StartTime = time.Now().Unix()
+	return nil
+}
+
+func updateSlackApproval(ctx context.Context, plan *models.ReleasePlan) error {
+	approvalInfo := plan.Approval
+	if approvalInfo == nil || approvalInfo.SlackApproval == nil || approvalInfo.NativeApproval == nil {
+		return errors.New("updateSlackApproval: approval data not found")
+	}
+	approvalKey := approvalInfo.NativeApproval.InstanceCode
+	if approvalKey == "" {
+		return errors.New("updateSlackApproval: instance code not found")
+	}
+
+	// the approval entry expires with the timeout, only restore it after aslan restarts within the timeout
+	timeout := approvalInfo.SlackApproval.Timeout > 0 && approvalInfo.StartTime > 0 &&
+		time.Now().Unix() > approvalInfo.StartTime+int64(approvalInfo.SlackApproval.Timeout)*60
+	decided := false
+	if _, ok := approvalservice.GlobalApproveMap.GetApproval(approvalKey); ok || !timeout {
+		if !ok {
+			// restore data after restart aslan
+			log.Infof("updateSlackApproval: approval instance code %s not found, set it", approvalKey)
+			approvalservice.GlobalApproveMap.SetApproval(approvalKey, approvalInfo.NativeApproval)
+		}
+
+		approved, rejected, approval, err := approvalservice.GlobalApproveMap.IsApproval(approvalKey)
+		if err != nil {
+			return errors.Wrap(err, "is approval")
+		}
+		approvalInfo.NativeApproval = approval
+		switch {
+		case rejected:
+			approvalInfo.Status = config.StatusReject
+			decided = true
+		case approved:
+			approvalInfo.Status = config.StatusPassed
+			decided = true
+		}
+	}
+	if !decided {
+		if !timeout {
+			return nil
+		}
+		log.Infof("updateSlackApproval: approval instance code %s timeout", approvalKey)
+		approvalservice.GlobalApproveMap.DeleteApproval(approvalKey)
+		approvalInfo.Status = config.StatusTimeout
+	}
+
+	// the result is already decided, failing to update the message should not block the plan
+	client, err := slackservice.GetSlackClientByIMAppID(approvalInfo.SlackApproval.ID)
+	if err != nil {
+		log.Warnf("updateSlackApproval: get slack client error: %v", err)
+		return nil
+	}
+	systemSetting, err := mongodb.NewSystemSettingColl().Get()
+	if err != nil {
+		log.Warnf("updateSlackApproval: get system setting error: %v", err)
+		return nil
+	}
+	detailURL := fmt.Sprintf("%s/v1/releasePlan/detail?id=%s",
+		configbase.SystemAddress(),
+		url.QueryEscape(plan.ID.Hex()),
+	)
+	title, content := getSlackApprovalMessage(plan, systemSetting.Language)
+	blocks := slackservice.NewApprovalResultBlocks(title, content, detailURL, approvalInfo.Status, approvalInfo.NativeApproval)
+	if err := client.UpdateMessage(approvalInfo.SlackApproval.ChannelID, approvalInfo.SlackApproval.MessageTS, title, blocks); err != nil {
+		log.Warnf("updateSlackApproval: update approval message error: %v", err)
+	}
+	return nil
+}
+
+func getSlackApprovalMessage(plan *models.ReleasePlan, language string) (string, string) {
+	title := fmt.Sprintf("%s %s %s", getText("approvalTextReleasePlan", language), plan.Name, getText("approvalTextPendingApproval", language))
+	content := fmt.Sprintf("*%s:* %s\n*%s:* %s", getText("approvalTextReleasePlanName", language), plan.Name, getText("approvalTextReleaseManager", language), plan.Manager)
+	if plan.StartTime != 0 && plan.EndTime != 0 {
+		content += fmt.Sprintf("\n*%s:* %s", getText("approvalTextReleaseWindow", language), time.Unix(plan.StartTime, 0).Format("2006-01-02 15:04:05")+"-"+time.Unix(plan.EndTime, 0).Format("2006-01-02 15:04:05"))
+	}
+	if plan.ScheduleExecuteTime != 0 {
+		content += fmt.Sprintf("\n*%s:* %s", getText("approvalTextTimer", language), time.Unix(plan.ScheduleExecuteTime, 0).Format("2006-01-02 15:04"))
+	}
+	return title, content
+}
+
 func getApprovalMailTemplate(language string) string {
 	if language == string(config.SystemLanguageEnUS) {
 		return string(approvalENHTML)
@@ -177,6 +177,20 @@ func lintApproval(approval *models.Approval) error {
 	case config.WorkWXApproval:
 		// TODO: add some linting here
 		return nil
+	case config.SlackApproval:
+		if approval.SlackApproval == nil {
+			return errors.New("approval not found")
+		}
+		if approval.SlackApproval.ID == "" || approval.SlackApproval.Channel == "" {
+			return errors.New("slack app and channel should be set")
+		}
+		allApproveUsers, _ := util.GeneFlatUsers(approval.SlackApproval.ApproveUsers)
+		if len(allApproveUsers) == 0 {
+			return errors.New("num of approve-users is 0")
+		}
+		if len(allApproveUsers) < approval.SlackApproval.NeededApprovers {
+			return errors.New("all approve users should not less than needed approvers")
+		}
 	default:
 		return errors.Errorf("invalid approval type %s", approval.Type)
 	}
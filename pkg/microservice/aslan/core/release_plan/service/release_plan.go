@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -35,6 +36,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	configbase "github.com/koderover/zadig/v2/pkg/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
@@ -44,6 +46,7 @@ import (
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	approvalservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/approval"
 	dingservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/dingtalk"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/instantmessage"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhooknotify"
 	runtimeWorkflowController "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller"
 	workwxservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workwx"
@@ -1557,6 +1560,15 @@ func clearApprovalData(approval *models.Approval) error {
 			user.OperationTime = 0
 			user.Comment = ""
 		}
+	case config.SlackApproval:
+		if approval.SlackApproval == nil {
+			return errors.New("nil slack approval")
+		}
+		// the decisions are recreated with the next slack message
+		approval.NativeApproval = nil
+		approval.StartTime = 0
+		approval.SlackApproval.ChannelID = ""
+		approval.SlackApproval.MessageTS = ""
 	}
 	return nil
 }
@@ -1985,7 +1997,17 @@ func sendReleasePlanHook(plan *models.ReleasePlan, systemHookSetting *commonmode
 			return err
 		}
 
-		err = webhooknotify.NewClient(systemHookSetting.HookAddress, systemHookSetting.HookSecret).SendReleasePlanWebhook(hookBody, releasePlanHookEventToWebhookEvent(hookBody.EventName))
+		if systemHookSetting.SlackNotificationConfig != nil {
+			detailURL := fmt.Sprintf("%s/v1/releasePlan/detail?id=%s", configbase.SystemAddress(), url.QueryEscape(plan.ID.Hex()))
+			if err := instantmessage.NewWeChatClient().SendReleasePlanSlackNotification(systemHookSetting.SlackNotificationConfig, hookBody, detailURL); err != nil {
+				log.Errorf("failed to send release plan slack notification, plan: %s, err: %v", plan.ID.Hex(), err)
+			}
+		}
+		if systemHookSetting.HookAddress == "" {
+			return nil
+		}
+
+		err = webhooknotify.NewClient(systemHookSetting.HookAddress, systemHookSetting.HookSecret, systemHookSetting.SigningSecret).SendReleasePlanWebhook(hookBody, releasePlanHookEventToWebhookEvent(hookBody.EventName))
 		if err != nil {
 			err = errors.Wrap(err, "send release plan hook")
 			log.Error(err)
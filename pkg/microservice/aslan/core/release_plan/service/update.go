@@ -62,8 +62,9 @@ const (
 	VerbRetry   = "重试"
 	VerbSkip    = "跳过"
 
-	DetailApprovalReject = "审批被拒绝"
-	DetailApprovalPass   = "审批通过"
+	DetailApprovalReject  = "审批被拒绝"
+	DetailApprovalPass    = "审批通过"
+	DetailApprovalTimeout = "审批超时"
 
 	UserNameSystem = "系统"
 )
@@ -111,8 +112,9 @@ var VerbI18nMap = map[string]string{
 }
 
 var DetailI18nMap = map[string]string{
-	DetailApprovalReject: "Approval Rejected",
-	DetailApprovalPass:   "Approval Passed",
+	DetailApprovalReject:  "Approval Rejected",
+	DetailApprovalPass:    "Approval Passed",
+	DetailApprovalTimeout: "Approval Timeout",
 }
 
 var UserNameI18nMap = map[string]string{
@@ -217,6 +217,8 @@ func updatePlanApproval(plan *models.ReleasePlan) error {
 		err = updateDingTalkApproval(ctx, plan.Approval)
 	case config.WorkWXApproval:
 		err = updateWorkWXApproval(ctx, plan.Approval)
+	case config.SlackApproval:
+		err = updateSlackApproval(ctx, plan)
 	// NativeApproval is update when approve
 	case config.NativeApproval:
 		return nil
@@ -274,6 +276,20 @@ func updatePlanApproval(plan *models.ReleasePlan) error {
 		}
 		plan.Status = config.ReleasePlanStatusApprovalDenied
 		plan.ApprovalTime = time.Now().Unix()
+	case config.StatusTimeout:
+		planLog = &models.ReleasePlanLog{
+			PlanID:     plan.ID.Hex(),
+			Username:   UserNameSystem,
+			Verb:       VerbUpdate,
+			TargetName: releasePlanTargetTypeDisplayName(TargetTypeReleasePlanStatus),
+			TargetType: TargetTypeReleasePlanStatus,
+			Detail:     DetailApprovalTimeout,
+			Before:     beforeStatus,
+			After:      config.ReleasePlanStatusApprovalDenied,
+			CreatedAt:  time.Now().Unix(),
+		}
+		plan.Status = config.ReleasePlanStatusApprovalDenied
+		plan.ApprovalTime = time.Now().Unix()
 	}
 
 	if err := mongodb.NewReleasePlanColl().UpdateByID(ctx, plan.ID.Hex(), plan); err != nil {
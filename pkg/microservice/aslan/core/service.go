@@ -36,6 +36,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhook"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhooknotify"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	environmentservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/environment/service"
@@ -43,12 +44,14 @@ import (
 	releaseplanservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/release_plan/service"
 	sprintservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/sprint_management/service"
 	systemservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
+	vmservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/vm/service"
 	hubserverconfig "github.com/koderover/zadig/v2/pkg/microservice/hubserver/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/hubserver/core/repository/mongodb"
 	mongodb2 "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
 	redisEventBus "github.com/koderover/zadig/v2/pkg/tool/eventbus/redis"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
 	"github.com/koderover/zadig/v2/pkg/tool/git/gitlab"
 	gormtool "github.com/koderover/zadig/v2/pkg/tool/gorm"
 	"github.com/koderover/zadig/v2/pkg/tool/klock"
@@ -154,6 +157,8 @@ func Start(ctx context.Context) {
 
 	go multiclusterservice.ClusterApplyUpgrade()
 
+	go vmservice.RunAgentDispatcher(ctx.Done())
+
 	initRsaKey()
 
 	log.Debugf("initRsaKey took %s milli seconds", time.Now().UnixMilli()-start)
@@ -202,8 +207,30 @@ func initCron() {
 		log.Debugf("[CRONJOB] gitlab token updated....")
 	}))
 
+	Scheduler.NewJob(newgoCron.DurationJob(5*time.Minute), newgoCron.NewTask(func() {
+		log.Debugf("[CRONJOB] updating tokens for gitea....")
+		codehostList, err := mongodb2.NewCodehostColl().List(&mongodb2.ListArgs{
+			Source: setting.SourceFromGitea,
+		})
+
+		if err != nil {
+			log.Errorf("failed to list gitea codehost err:%v", err)
+			return
+		}
+		for _, codehost := range codehostList {
+			_, err := gitea.UpdateGiteaToken(codehost.ID, codehost.AccessToken, codehost.DisableSSL)
+			if err != nil {
+				log.Errorf("failed to update gitea token for host: %d, error: %s", codehost.ID, err)
+			}
+		}
+		log.Debugf("[CRONJOB] gitea token updated....")
+	}))
+
 	Scheduler.NewJob(newgoCron.DailyJob(1, newgoCron.NewAtTimes(newgoCron.NewAtTime(4, 0, 0))), newgoCron.NewTask(cleanCacheFiles))
 
+	// retry the outbound webhooks that failed, including the ones pending before aslan restarted
+	Scheduler.NewJob(newgoCron.DurationJob(30*time.Second), newgoCron.NewTask(webhooknotify.RetryDueDeliveries))
+
 	Scheduler.Start()
 }
 
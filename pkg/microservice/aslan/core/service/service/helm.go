@@ -1398,6 +1398,7 @@ func geneCreationDetail(args *helmServiceCreationArgs) interface{} {
 		setting.SourceFromGerrit,
 		setting.SourceFromGitee,
 		setting.SourceFromGiteeEE,
+		setting.SourceFromGitea,
 		// FIXME this is a temporary solution, remove when possible
 		setting.SourceFromGitRepo:
 		return &models.CreateFromRepo{
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/27149chen/afero"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	fsservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/kustomize"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// loadKustomizeService loads each service path as a kustomization, the base and every overlay are built
+// once here so that a broken kustomization is rejected before a new service revision is created.
+func loadKustomizeService(username string, ch *systemconfig.CodeHost, owner, namespace, repo, branch string, args *LoadServiceReq, force, production bool, logger *zap.SugaredLogger) error {
+	logger.Infof("Loading kustomize service from %s with owner %s, namespace %s, repo %s, branch %s and path %v", ch.Type, owner, namespace, repo, branch, args.ServicePaths)
+
+	loader, err := commonutil.GetYAMLLoader(ch)
+	if err != nil {
+		logger.Errorf("Failed to create loader client, err: %s", err)
+		return e.ErrLoadServiceTemplate.AddDesc(err.Error())
+	}
+
+	for _, loadPath := range args.ServicePaths {
+		if !loadPath.IsDir {
+			return e.ErrLoadServiceTemplate.AddDesc(fmt.Sprintf("kustomize service %s must be loaded from a directory", loadPath.ServiceName))
+		}
+
+		files, err := downloadKustomizeFiles(ch.ID, owner, namespace, repo, loadPath.Path, branch)
+		if err != nil {
+			logger.Errorf("Failed to download kustomization under path %s, err: %s", loadPath.Path, err)
+			return e.ErrLoadServiceTemplate.AddDesc(err.Error())
+		}
+
+		kustomizeConfig := &models.KustomizeConfig{
+			BasePath: args.Kustomize.BasePath,
+			Overlays: args.Kustomize.Overlays,
+			Files:    files,
+		}
+		baseYaml, err := buildKustomizeService(kustomizeConfig)
+		if err != nil {
+			return e.ErrLoadServiceTemplate.AddDesc(fmt.Sprintf("service %s: %v", loadPath.ServiceName, err))
+		}
+
+		commit, err := loader.GetLatestRepositoryCommit(namespace, repo, loadPath.Path, branch)
+		if err != nil {
+			logger.Errorf("Failed to get latest commit under path %s, error: %s", loadPath.Path, err)
+			return e.ErrLoadServiceTemplate.AddDesc(err.Error())
+		}
+
+		createSvcArgs := &models.Service{
+			CodehostID:    ch.ID,
+			RepoName:      repo,
+			RepoOwner:     owner,
+			RepoNamespace: namespace,
+			BranchName:    branch,
+			LoadPath:      loadPath.Path,
+			LoadFromDir:   true,
+			KubeYamls:     util.SplitYaml(baseYaml),
+			SrcPath:       fmt.Sprintf("%s/%s/%s/%s/%s/%s", ch.Address, namespace, repo, "tree", branch, loadPath.Path),
+			CreateBy:      username,
+			ServiceName:   loadPath.ServiceName,
+			Type:          args.Type,
+			ProductName:   args.ProductName,
+			Source:        setting.SourceFromKustomize,
+			Yaml:          baseYaml,
+			Kustomize:     kustomizeConfig,
+			Commit:        &models.Commit{SHA: commit.SHA, Message: commit.Message},
+		}
+		_, err = CreateServiceTemplate(username, createSvcArgs, force, production, logger)
+		if err != nil {
+			logger.Errorf("Failed to create service template, err: %s", err)
+			_, messageMap := e.ErrorMessage(err)
+			if description, ok := messageMap["description"]; ok {
+				return e.ErrLoadServiceTemplate.AddDesc(description.(string))
+			}
+			return e.ErrLoadServiceTemplate.AddDesc("Load Service Error for unknown reason")
+		}
+	}
+
+	return nil
+}
+
+// buildKustomizeService builds the base and every overlay of the kustomization, and returns the base yaml.
+func buildKustomizeService(config *models.KustomizeConfig) (string, error) {
+	files := config.FileMap()
+	if !kustomize.HasKustomization(files, config.BasePath) {
+		return "", fmt.Errorf("no kustomization file found in base path %q", config.BasePath)
+	}
+	baseYaml, err := kustomize.Build(files, config.BasePath)
+	if err != nil {
+		return "", err
+	}
+
+	envs := make(map[string]bool)
+	for _, overlay := range config.Overlays {
+		if overlay.EnvName == "" {
+			return "", fmt.Errorf("environment of overlay %q cannot be empty", overlay.Path)
+		}
+		if envs[overlay.EnvName] {
+			return "", fmt.Errorf("environment %s has more than one overlay", overlay.EnvName)
+		}
+		envs[overlay.EnvName] = true
+
+		if !kustomize.HasKustomization(files, overlay.Path) {
+			return "", fmt.Errorf("no kustomization file found in overlay path %q", overlay.Path)
+		}
+		if _, err := kustomize.Build(files, overlay.Path); err != nil {
+			return "", err
+		}
+	}
+	return baseYaml, nil
+}
+
+// downloadKustomizeFiles downloads all the files under the load path, keyed by the path relative to the load path.
+func downloadKustomizeFiles(codehostID int, owner, namespace, repo, loadPath, branch string) ([]*models.KustomizeFile, error) {
+	tree, err := fsservice.DownloadFilesFromSource(&fsservice.DownloadFromSourceArgs{
+		CodehostID: codehostID,
+		Owner:      owner,
+		Namespace:  namespace,
+		Repo:       repo,
+		Path:       loadPath,
+		Branch:     branch,
+	}, func(afero.Fs) (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// files are put under a directory named after the last element of the load path
+	prefix := ""
+	if base := path.Base(strings.Trim(loadPath, "/")); base != "." && base != "/" && base != "" {
+		prefix = base + "/"
+	}
+
+	files := make([]*models.KustomizeFile, 0)
+	err = fs.WalkDir(tree, ".", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		content, err := fs.ReadFile(tree, filePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, &models.KustomizeFile{
+			Path:    strings.TrimPrefix(filePath, prefix),
+			Content: string(content),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file found under path %s", loadPath)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
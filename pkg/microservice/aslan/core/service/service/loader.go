@@ -45,6 +45,8 @@ type LoadServiceReq struct {
 	Type         string            `json:"type"`
 	ProductName  string            `json:"product_name"`
 	ServicePaths []LoadServicePath `json:"service_paths"`
+	// Kustomize loads the service paths as kustomizations instead of plain yaml files
+	Kustomize *models.KustomizeConfig `json:"kustomize,omitempty"`
 }
 
 type PreLoadServicePath struct {
@@ -60,7 +62,7 @@ func PreloadServiceFromCodeHost(codehostID int, repoOwner, repoName, repoUUID, b
 		return nil, e.ErrPreloadServiceTemplate.AddDesc(err.Error())
 	}
 	switch ch.Type {
-	case setting.SourceFromGithub, setting.SourceFromGitlab:
+	case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitea:
 		ret, err = preloadService(ch, repoOwner, repoName, branchName, paths, log)
 	case setting.SourceFromGerrit:
 		ret, err = preloadGerritService(ch, repoName, branchName, remoteName, paths, log)
@@ -97,11 +99,20 @@ func LoadServiceFromCodeHost(username string, codehostID int, repoOwner, namespa
 	}
 
 	switch ch.Type {
-	case setting.SourceFromGithub, setting.SourceFromGitlab:
+	case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitea:
+		if args.Kustomize != nil {
+			return loadKustomizeService(username, ch, repoOwner, namespace, repoName, branchName, args, force, production, log)
+		}
 		return loadService(username, ch, repoOwner, namespace, repoName, branchName, args, force, production, log)
 	case setting.SourceFromGerrit:
+		if args.Kustomize != nil {
+			return e.ErrLoadServiceTemplate.AddDesc("kustomize service can only be loaded from github, gitlab or gitea")
+		}
 		return loadGerritService(username, ch, repoOwner, repoName, branchName, remoteName, args, force, production, log)
 	case setting.SourceFromGitee, setting.SourceFromGiteeEE:
+		if args.Kustomize != nil {
+			return e.ErrLoadServiceTemplate.AddDesc("kustomize service can only be loaded from github, gitlab or gitea")
+		}
 		return loadGiteeService(username, ch, repoOwner, repoName, branchName, remoteName, args, force, production, log)
 	default:
 		return e.ErrLoadServiceTemplate.AddDesc("unsupported code source")
@@ -195,7 +195,8 @@ func GetServiceOption(args *commonmodels.Service, production bool, log *zap.Suga
 	serviceOption.ServiceVariableKVs = args.ServiceVariableKVs
 
 	if args.Source == setting.SourceFromGitlab || args.Source == setting.SourceFromGithub ||
-		args.Source == setting.SourceFromGerrit || args.Source == setting.SourceFromGitee {
+		args.Source == setting.SourceFromGerrit || args.Source == setting.SourceFromGitee ||
+		args.Source == setting.SourceFromGitea || args.Source == setting.SourceFromKustomize {
 		serviceOption.Yaml = args.Yaml
 	}
 
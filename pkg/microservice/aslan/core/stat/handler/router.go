@@ -119,6 +119,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		testV2.GET("/count", GetTestCount)
 		testV2.POST("/dailyHealthTrend", GetDailyTestHealthTrend)
 		testV2.GET("/recentTask", GetRecentTestTask)
+		testV2.GET("/coverageTrend", GetTestCoverageTrend)
 	}
 
 }
@@ -99,3 +99,28 @@ func GetRecentTestTask(c *gin.Context) {
 
 	ctx.Resp, ctx.RespErr = service.GetRecentTestTask(projects, number, ctx.Logger)
 }
+
+func GetTestCoverageTrend(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	// Filter out empty strings (frontend passes projects= to mean "all projects")
+	projects := filterEmptyStrings(c.QueryArray("projects"))
+
+	var startTime, endTime int64
+	var err error
+	if startStr := c.Query("start_time"); startStr != "" {
+		if startTime, err = strconv.ParseInt(startStr, 10, 64); err != nil {
+			ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid start_time")
+			return
+		}
+	}
+	if endStr := c.Query("end_time"); endStr != "" {
+		if endTime, err = strconv.ParseInt(endStr, 10, 64); err != nil {
+			ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid end_time")
+			return
+		}
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetTestCoverageTrend(startTime, endTime, projects, c.Query("test_name"), c.Query("branch"), ctx.Logger)
+}
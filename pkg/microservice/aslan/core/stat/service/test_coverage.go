@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+)
+
+type TestCoverageTrend struct {
+	TestName      string               `json:"test_name"`
+	ProjectName   string               `json:"project_name"`
+	ServiceName   string               `json:"service_name"`
+	ServiceModule string               `json:"service_module"`
+	Points        []*TestCoveragePoint `json:"points"`
+}
+
+type TestCoveragePoint struct {
+	WorkflowName string  `json:"workflow_name"`
+	TaskID       int64   `json:"task_id"`
+	Branch       string  `json:"branch"`
+	CommitID     string  `json:"commit_id"`
+	LineRate     float64 `json:"line_rate"`
+	BranchRate   float64 `json:"branch_rate"`
+	LinesTotal   int     `json:"lines_total"`
+	CreateTime   int64   `json:"create_time"`
+}
+
+// GetTestCoverageTrend returns the coverage of the testings over time, pull request runs are excluded since they
+// do not represent a branch
+func GetTestCoverageTrend(startTime, endTime int64, projects []string, testName, branch string, logger *zap.SugaredLogger) ([]*TestCoverageTrend, error) {
+	reports, err := commonrepo.NewCoverageReportColl().List(&commonrepo.ListCoverageReportOption{
+		ProjectNames:  projects,
+		ZadigTestName: testName,
+		Branch:        branch,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		ExcludePR:     true,
+	})
+	if err != nil {
+		logger.Errorf("failed to list coverage reports, error: %s", err)
+		return nil, fmt.Errorf("failed to list coverage reports, error: %s", err)
+	}
+
+	trendMap := make(map[string]*TestCoverageTrend)
+	for _, report := range reports {
+		key := fmt.Sprintf("%s/%s/%s/%s", report.ProjectName, report.ZadigTestName, report.ServiceName, report.ServiceModule)
+		trend, ok := trendMap[key]
+		if !ok {
+			trend = &TestCoverageTrend{
+				TestName:      report.ZadigTestName,
+				ProjectName:   report.ProjectName,
+				ServiceName:   report.ServiceName,
+				ServiceModule: report.ServiceModule,
+				Points:        make([]*TestCoveragePoint, 0),
+			}
+			trendMap[key] = trend
+		}
+		trend.Points = append(trend.Points, &TestCoveragePoint{
+			WorkflowName: report.WorkflowName,
+			TaskID:       report.TaskID,
+			Branch:       report.Branch,
+			CommitID:     report.CommitID,
+			LineRate:     report.LineRate,
+			BranchRate:   report.BranchRate,
+			LinesTotal:   report.LinesTotal,
+			CreateTime:   report.CreateTime,
+		})
+	}
+
+	result := make([]*TestCoverageTrend, 0, len(trendMap))
+	for _, trend := range trendMap {
+		result = append(result, trend)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ProjectName != result[j].ProjectName {
+			return result[i].ProjectName < result[j].ProjectName
+		}
+		if result[i].TestName != result[j].TestName {
+			return result[i].TestName < result[j].TestName
+		}
+		return result[i].ServiceName+"/"+result[i].ServiceModule < result[j].ServiceName+"/"+result[j].ServiceModule
+	})
+	return result, nil
+}
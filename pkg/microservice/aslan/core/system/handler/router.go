@@ -366,6 +366,11 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		workwx.POST("/:id/webhook", WorkWXEventHandler)
 	}
 
+	slack := router.Group("slack")
+	{
+		slack.POST("/:id/interactivity", SlackInteractionHandler)
+	}
+
 	pm := router.Group("project_management")
 	{
 		pm.GET("", ListProjectManagement)
@@ -526,6 +531,9 @@ func (*Router) Inject(router *gin.RouterGroup) {
 	webhook := router.Group("webhook")
 	{
 		webhook.GET("/config", GetWebhookConfig)
+		webhook.GET("/deliveries", ListWebhookDeliveries)
+		webhook.GET("/deliveries/:uuid", GetWebhookDelivery)
+		webhook.POST("/deliveries/:uuid/redeliver", RedeliverWebhook)
 	}
 
 	// ---------------------------------------------------------------------------------------
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	slackservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/slack"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/slack"
+)
+
+// SlackInteractionHandler receives the interactivity callbacks of a slack app, which are sent
+// when the approve/reject buttons of an approval message are clicked.
+func SlackInteractionHandler(c *gin.Context) {
+	log.Infof("SlackInteractionHandler: New request url %s", c.Request.RequestURI)
+	body, err := c.GetRawData()
+	if err != nil {
+		c.Set(setting.ResponseError, err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	err = slackservice.HandleInteraction(c.Param("id"), body, c.GetHeader(slack.TimestampHeader), c.GetHeader(slack.SignatureHeader))
+	if err != nil {
+		c.Set(setting.ResponseError, err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Status(http.StatusOK)
+}
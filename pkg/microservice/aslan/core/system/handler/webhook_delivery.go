@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary 获取 Webhook 投递记录
+// @Description 获取 Webhook 投递记录
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param objectKind query string false "object kind, workflow or release_plan"
+// @Param event query string false "event"
+// @Param address query string false "webhook address"
+// @Param status query string false "pending, success or failed"
+// @Param page query int false "page"
+// @Param pageSize query int false "page size"
+// @Success 200 {object} service.ListWebhookDeliveriesResp
+// @Router /api/aslan/system/webhook/deliveries [get]
+func ListWebhookDeliveries(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(service.ListWebhookDeliveriesArgs)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc(err.Error())
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListWebhookDeliveries(args)
+}
+
+// @Summary 获取 Webhook 投递详情
+// @Description 获取 Webhook 投递详情，包含请求体及每次投递的响应
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param uuid path string true "X-Zadig-Event-UUID of the delivery"
+// @Success 200 {object} models.WebhookDelivery
+// @Router /api/aslan/system/webhook/deliveries/{uuid} [get]
+func GetWebhookDelivery(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.GetWebhookDelivery(c.Param("uuid"))
+}
+
+// @Summary 重新投递 Webhook
+// @Description 使用原始请求体及 X-Zadig-Event-UUID 重新投递 Webhook
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param uuid path string true "X-Zadig-Event-UUID of the delivery"
+// @Success 200 {object} models.WebhookDelivery
+// @Router /api/aslan/system/webhook/deliveries/{uuid}/redeliver [post]
+func RedeliverWebhook(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, "", "重新投递", "Webhook", c.Param("uuid"), c.Param("uuid"), "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	if !ctx.Resources.IsSystemAdmin {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.RedeliverWebhook(c.Param("uuid"))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/tool/dingtalk"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/lark"
+	"github.com/koderover/zadig/v2/pkg/tool/slack"
 	"github.com/koderover/zadig/v2/pkg/tool/workwx"
 )
 
@@ -51,6 +52,8 @@ func CreateIMApp(args *commonmodels.IMApp, log *zap.SugaredLogger) error {
 		return createLarkIMApp(args, log)
 	case setting.IMWorkWx:
 		return createWorkWxIMApp(args, log)
+	case setting.IMSlack:
+		return createSlackIMApp(args, log)
 	default:
 		return errors.Errorf("unknown im type %s", args.Type)
 	}
@@ -125,6 +128,19 @@ func createWorkWxIMApp(args *commonmodels.IMApp, log *zap.SugaredLogger) error {
 	return nil
 }
 
+func createSlackIMApp(args *commonmodels.IMApp, log *zap.SugaredLogger) error {
+	if err := validateSlackIMApp(args); err != nil {
+		return e.ErrCreateIMApp.AddErr(err)
+	}
+
+	_, err := mongodb.NewIMAppColl().Create(context.Background(), args)
+	if err != nil {
+		log.Errorf("create slack IM error: %v", err)
+		return e.ErrCreateIMApp.AddErr(err)
+	}
+	return nil
+}
+
 func UpdateIMApp(id string, args *commonmodels.IMApp, log *zap.SugaredLogger) error {
 	switch args.Type {
 	case setting.IMDingTalk:
@@ -133,6 +149,8 @@ func UpdateIMApp(id string, args *commonmodels.IMApp, log *zap.SugaredLogger) er
 		return updateLarkIMApp(id, args, log)
 	case setting.IMWorkWx:
 		return updateWorkWxIMApp(id, args, log)
+	case setting.IMSlack:
+		return updateSlackIMApp(id, args, log)
 	default:
 		return errors.Errorf("unknown im type %s", args.Type)
 	}
@@ -200,6 +218,28 @@ func updateWorkWxIMApp(id string, args *commonmodels.IMApp, log *zap.SugaredLogg
 	return nil
 }
 
+func updateSlackIMApp(id string, args *commonmodels.IMApp, log *zap.SugaredLogger) error {
+	if err := validateSlackIMApp(args); err != nil {
+		return e.ErrUpdateIMApp.AddErr(err)
+	}
+
+	err := mongodb.NewIMAppColl().Update(context.Background(), id, args)
+	if err != nil {
+		log.Errorf("update slack IM error: %v", err)
+		return e.ErrUpdateIMApp.AddErr(err)
+	}
+	return nil
+}
+
+// validateSlackIMApp requires the signing secret since approval buttons are useless
+// if the interactivity callbacks cannot be verified.
+func validateSlackIMApp(args *commonmodels.IMApp) error {
+	if args.SlackSigningSecret == "" {
+		return errors.New("slack signing secret is required")
+	}
+	return errors.Wrap(slack.Validate(args.SlackBotToken), "validate")
+}
+
 func DeleteIMApp(id string, log *zap.SugaredLogger) error {
 	err := mongodb.NewIMAppColl().DeleteByID(context.Background(), id)
 	if err != nil {
@@ -217,6 +257,8 @@ func ValidateIMApp(im *commonmodels.IMApp, log *zap.SugaredLogger) error {
 		return dingtalk.Validate(im.DingTalkAppKey, im.DingTalkAppSecret)
 	case setting.IMWorkWx:
 		return workwx.Validate(im.Host, im.CorpID, im.AgentID, im.AgentSecret)
+	case setting.IMSlack:
+		return slack.Validate(im.SlackBotToken)
 	default:
 		return e.ErrValidateIMApp.AddDesc("invalid type")
 	}
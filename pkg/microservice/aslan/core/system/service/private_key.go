@@ -31,6 +31,7 @@ import (
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/pm"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/vmdispatch"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/crypto"
@@ -176,6 +177,9 @@ func UpdatePrivateKey(id string, args *commonmodels.PrivateKey, log *zap.Sugared
 		log.Errorf("failed to update privateKey, error: %s", err)
 		return e.ErrUpdatePrivateKey.AddErr(err)
 	}
+	if vm.Agent != nil {
+		vmdispatch.NotifyConfigChanged(id)
+	}
 	return nil
 }
 
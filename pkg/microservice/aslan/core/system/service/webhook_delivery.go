@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/webhooknotify"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+type ListWebhookDeliveriesArgs struct {
+	ObjectKind string                             `form:"objectKind"`
+	Event      string                             `form:"event"`
+	Address    string                             `form:"address"`
+	Status     commonmodels.WebhookDeliveryStatus `form:"status"`
+	Page       int64                              `form:"page"`
+	PageSize   int64                              `form:"pageSize"`
+}
+
+type ListWebhookDeliveriesResp struct {
+	Deliveries []*commonmodels.WebhookDelivery `json:"deliveries"`
+	Total      int64                           `json:"total"`
+}
+
+func ListWebhookDeliveries(args *ListWebhookDeliveriesArgs) (*ListWebhookDeliveriesResp, error) {
+	deliveries, total, err := commonrepo.NewWebhookDeliveryColl().List(&commonrepo.WebhookDeliveryListOption{
+		ObjectKind: args.ObjectKind,
+		Event:      args.Event,
+		Address:    args.Address,
+		Status:     args.Status,
+		Page:       args.Page,
+		PageSize:   args.PageSize,
+	})
+	if err != nil {
+		return nil, e.ErrListWebhookDelivery.AddErr(err)
+	}
+	return &ListWebhookDeliveriesResp{Deliveries: deliveries, Total: total}, nil
+}
+
+func GetWebhookDelivery(eventUUID string) (*commonmodels.WebhookDelivery, error) {
+	delivery, err := commonrepo.NewWebhookDeliveryColl().GetByEventUUID(eventUUID)
+	if err != nil {
+		return nil, e.ErrGetWebhookDelivery.AddErr(err)
+	}
+	return delivery, nil
+}
+
+func RedeliverWebhook(eventUUID string) (*commonmodels.WebhookDelivery, error) {
+	delivery, err := webhooknotify.Redeliver(eventUUID)
+	if err != nil {
+		return nil, e.ErrRedeliverWebhook.AddErr(err)
+	}
+	return delivery, nil
+}
@@ -25,6 +25,7 @@ import (
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/template"
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
@@ -46,6 +47,9 @@ func AddBuildTemplate(userName string, build *commonmodels.BuildTemplate, logger
 	if err := commonutil.CheckDefineResourceParam(build.PreBuild.ResReq, build.PreBuild.ResReqSpec); err != nil {
 		return e.ErrCreateBuildModule.AddDesc(err.Error())
 	}
+	if err := buildcache.ValidateRules(build.CacheRules); err != nil {
+		return e.ErrCreateBuildModule.AddDesc(err.Error())
+	}
 	build.UpdateBy = userName
 	if err := commonrepo.NewBuildTemplateColl().Create(build); err != nil {
 		log.Errorf("[Build.Upsert] %s error: %s", build.Name, err)
@@ -114,6 +118,9 @@ func UpdateBuildTemplate(id string, buildTemplate *commonmodels.BuildTemplate, l
 	if err := commonutil.CheckDefineResourceParam(buildTemplate.PreBuild.ResReq, buildTemplate.PreBuild.ResReqSpec); err != nil {
 		return e.ErrCreateBuildModule.AddDesc(err.Error())
 	}
+	if err := buildcache.ValidateRules(buildTemplate.CacheRules); err != nil {
+		return e.ErrCreateBuildModule.AddDesc(err.Error())
+	}
 	return commonrepo.NewBuildTemplateColl().Update(id, buildTemplate)
 }
 
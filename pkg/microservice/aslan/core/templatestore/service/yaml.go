@@ -83,7 +83,7 @@ func PreloadYamlTemplateFromCodeHost(codehostID int, repoOwner, repoName, repoUU
 	}
 
 	switch ch.Type {
-	case setting.SourceFromGithub, setting.SourceFromGitlab:
+	case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitea:
 		templates, err = preloadYamlTemplatesFromTreeGetter(ch, repoOwner, repoName, branchName, loadPaths, logger)
 	case setting.SourceFromGerrit:
 		templates, err = preloadYamlTemplatesFromGerrit(ch, repoOwner, repoName, branchName, remoteName, loadPaths, logger)
@@ -360,7 +360,7 @@ func LoadYamlTemplateFromCodeHost(username string, codehostID int, repoOwner, na
 	}
 
 	switch ch.Type {
-	case setting.SourceFromGithub, setting.SourceFromGitlab:
+	case setting.SourceFromGithub, setting.SourceFromGitlab, setting.SourceFromGitea:
 		return loadYamlTemplateFromTreeGetter(ch, repoOwner, namespace, repoName, branchName, remoteName, args, isSync, logger)
 	case setting.SourceFromGerrit:
 		return loadYamlTemplateFromGerrit(ch, repoOwner, namespace, repoName, branchName, remoteName, args, isSync, logger)
@@ -29,6 +29,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		vm.PUT("/:vmid/agent/upgrade", UpgradeAgent)
 		vm.GET("/vms", ListVMs)
 		vm.GET("/labels", ListVMLabels)
+		vm.GET("/queues", ListVMJobQueues)
 	}
 
 	vmAgent := router.Group("agents")
@@ -38,6 +39,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		vmAgent.POST("/heartbeat", HeartbeatAgent)
 		vmAgent.GET("/job/request", PollingAgentJob)
 		vmAgent.POST("/job/report", ReportAgentJob)
+		vmAgent.GET("/connect", ConnectAgentDispatch)
 		vmAgent.GET("/tempFile/download/:fileId", DownloadTemporaryFile)
 	}
 }
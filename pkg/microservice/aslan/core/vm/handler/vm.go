@@ -138,6 +138,20 @@ func ListVMLabels(c *gin.Context) {
 	ctx.Resp, ctx.RespErr = service.ListVMLabels(c.Query("projectName"), ctx.Logger)
 }
 
+func ListVMJobQueues(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.Logger.Errorf("failed to generate authorization info for user: %s, error: %s", ctx.UserID, err)
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = service.ListVMJobQueues(c.Query("projectName"), ctx.Logger)
+}
+
 func RegisterAgent(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
@@ -190,6 +204,22 @@ func PollingAgentJob(c *gin.Context) {
 	ctx.Resp, ctx.RespErr = service.PollingAgentJob(token, 0, ctx.Logger)
 }
 
+func ConnectAgentDispatch(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+
+	token := c.Query("token")
+	if token == "" {
+		ctx.RespErr = fmt.Errorf("invalid request: %s", "token is empty")
+		internalhandler.JSONResponse(c, ctx)
+		return
+	}
+
+	if err := service.ConnectAgentDispatch(c, token, ctx.Logger); err != nil {
+		ctx.RespErr = err
+		internalhandler.JSONResponse(c, ctx)
+	}
+}
+
 func ReportAgentJob(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
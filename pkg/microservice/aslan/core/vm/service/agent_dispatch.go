@@ -0,0 +1,329 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/vmdispatch"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	AgentDispatchMessageReady  = "ready"
+	AgentDispatchMessageJob    = "job"
+	AgentDispatchMessageCancel = "cancel"
+	AgentDispatchMessageConfig = "config"
+
+	agentDispatchPingInterval  = 30 * time.Second
+	agentDispatchReadTimeout   = 90 * time.Second
+	agentDispatchWriteTimeout  = 10 * time.Second
+	agentDispatchSweepInterval = 10 * time.Second
+)
+
+// AgentDispatchMessage is the frame exchanged with zadig-agent over the push dispatch connection.
+// The agent sends "ready" with the number of jobs it can still accept, the server sends
+// "job", "cancel" and "config".
+type AgentDispatchMessage struct {
+	Type     string             `json:"type"`
+	Capacity int                `json:"capacity,omitempty"`
+	Job      *PollingJobResp    `json:"job,omitempty"`
+	JobID    string             `json:"job_id,omitempty"`
+	Config   *HeartbeatResponse `json:"config,omitempty"`
+}
+
+var agentDispatchUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+type agentSession struct {
+	vmID   string
+	vmName string
+	token  string
+	conn   *websocket.Conn
+	logger *zap.SugaredLogger
+
+	writeLock sync.Mutex
+	// dispatchLock guards capacity and makes sure a session claims jobs one at a time
+	dispatchLock sync.Mutex
+	capacity     int
+}
+
+func (s *agentSession) send(msg *AgentDispatchMessage) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(agentDispatchWriteTimeout)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(msg)
+}
+
+func (s *agentSession) setCapacity(capacity int) {
+	s.dispatchLock.Lock()
+	defer s.dispatchLock.Unlock()
+	s.capacity = capacity
+}
+
+// dispatch claims created jobs for the vm and pushes them to the agent until its capacity runs out.
+func (s *agentSession) dispatch() {
+	s.dispatchLock.Lock()
+	defer s.dispatchLock.Unlock()
+
+	for s.capacity > 0 {
+		job, err := PollingAgentJob(s.token, 0, s.logger)
+		if err != nil {
+			s.logger.Warnf("failed to claim job for vm %s, error: %s", s.vmName, err)
+			return
+		}
+		if job == nil {
+			return
+		}
+
+		if err := s.send(&AgentDispatchMessage{Type: AgentDispatchMessageJob, Job: job}); err != nil {
+			s.logger.Errorf("failed to push job %s to vm %s, error: %s", job.ID, s.vmName, err)
+			releaseVMJob(job.ID, s.logger)
+			return
+		}
+		s.capacity--
+	}
+}
+
+func (s *agentSession) keepalive(done <-chan struct{}) {
+	ticker := time.NewTicker(agentDispatchPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(agentDispatchWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// releaseVMJob puts a job that was claimed but never delivered back to the queue.
+func releaseVMJob(jobID string, logger *zap.SugaredLogger) {
+	job, err := vmmongodb.NewVMJobColl().FindByID(jobID)
+	if err != nil {
+		logger.Errorf("failed to find job %s, error: %s", jobID, err)
+		return
+	}
+	if job.Status != string(config.StatusPrepare) {
+		return
+	}
+	job.Status = setting.VMJobStatusCreated
+	job.VMID = ""
+	if err := vmmongodb.NewVMJobColl().Update(jobID, job); err != nil {
+		logger.Errorf("failed to release job %s, error: %s", jobID, err)
+	}
+}
+
+type agentSessionRegistry struct {
+	sync.RWMutex
+	sessions map[string]*agentSession
+}
+
+// add registers the session, closing any older connection of the same vm.
+func (r *agentSessionRegistry) add(s *agentSession) {
+	r.Lock()
+	defer r.Unlock()
+	if old, ok := r.sessions[s.vmID]; ok {
+		_ = old.conn.Close()
+	}
+	r.sessions[s.vmID] = s
+}
+
+func (r *agentSessionRegistry) remove(s *agentSession) {
+	r.Lock()
+	defer r.Unlock()
+	if r.sessions[s.vmID] == s {
+		delete(r.sessions, s.vmID)
+	}
+}
+
+func (r *agentSessionRegistry) get(vmID string) *agentSession {
+	r.RLock()
+	defer r.RUnlock()
+	return r.sessions[vmID]
+}
+
+func (r *agentSessionRegistry) list() []*agentSession {
+	r.RLock()
+	defer r.RUnlock()
+	resp := make([]*agentSession, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		resp = append(resp, s)
+	}
+	return resp
+}
+
+var agentSessions = &agentSessionRegistry{
+	sessions: make(map[string]*agentSession),
+}
+
+// ConnectAgentDispatch upgrades the request to a websocket and serves the push dispatch
+// connection of the agent until it goes away. An error is only returned if the connection
+// could not be established, so that the caller can still answer with a regular http response.
+func ConnectAgentDispatch(c *gin.Context, token string, logger *zap.SugaredLogger) error {
+	vm, err := commonrepo.NewPrivateKeyColl().Find(commonrepo.FindPrivateKeyOption{
+		Token: token,
+	})
+	if err != nil {
+		logger.Errorf("failed to find vm by token %s, error: %s", token, err)
+		return fmt.Errorf("failed to find vm by token %s, error: %s", token, err)
+	}
+	if vm.Agent == nil {
+		return fmt.Errorf("vm %s is not registered", vm.Name)
+	}
+	if vm.Status == setting.VMOffline {
+		return fmt.Errorf("vm %s is offline", vm.Name)
+	}
+
+	conn, err := agentDispatchUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Errorf("failed to upgrade dispatch connection of vm %s, error: %s", vm.Name, err)
+		return nil
+	}
+
+	session := &agentSession{
+		vmID:   vm.ID.Hex(),
+		vmName: vm.Name,
+		token:  token,
+		conn:   conn,
+		logger: logger,
+	}
+	agentSessions.add(session)
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		agentSessions.remove(session)
+		_ = conn.Close()
+		logger.Infof("vm %s agent dispatch connection closed", vm.Name)
+	}()
+	logger.Infof("vm %s agent dispatch connection established", vm.Name)
+
+	_ = conn.SetReadDeadline(time.Now().Add(agentDispatchReadTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(agentDispatchReadTimeout))
+	})
+	go session.keepalive(done)
+
+	if err := session.send(&AgentDispatchMessage{Type: AgentDispatchMessageConfig, Config: agentConfigResponse(vm)}); err != nil {
+		logger.Errorf("failed to push config to vm %s, error: %s", vm.Name, err)
+		return nil
+	}
+
+	for {
+		msg := new(AgentDispatchMessage)
+		if err := conn.ReadJSON(msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Warnf("vm %s agent dispatch connection read error: %s", vm.Name, err)
+			}
+			return nil
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(agentDispatchReadTimeout))
+
+		switch msg.Type {
+		case AgentDispatchMessageReady:
+			session.setCapacity(msg.Capacity)
+			session.dispatch()
+		default:
+			logger.Warnf("unknown dispatch message type %s from vm %s", msg.Type, vm.Name)
+		}
+	}
+}
+
+// RunAgentDispatcher forwards dispatch events published by any aslan replica to the agents
+// connected to this one. The periodic sweep covers events lost while redis was unavailable.
+func RunAgentDispatcher(stopCh <-chan struct{}) {
+	events, closeFunc := vmdispatch.Subscribe()
+	defer func() { _ = closeFunc() }()
+
+	ticker := time.NewTicker(agentDispatchSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			handleAgentDispatchEvent(event)
+		case <-ticker.C:
+			for _, s := range agentSessions.list() {
+				go s.dispatch()
+			}
+		}
+	}
+}
+
+func handleAgentDispatchEvent(event *vmdispatch.Event) {
+	switch event.Type {
+	case vmdispatch.EventJobCreated:
+		for _, s := range agentSessions.list() {
+			go s.dispatch()
+		}
+	case vmdispatch.EventJobCancelled:
+		job, err := vmmongodb.NewVMJobColl().FindByID(event.JobID)
+		if err != nil || job.VMID == "" {
+			return
+		}
+		s := agentSessions.get(job.VMID)
+		if s == nil {
+			return
+		}
+		if err := s.send(&AgentDispatchMessage{Type: AgentDispatchMessageCancel, JobID: event.JobID}); err != nil {
+			log.Warnf("failed to push cancellation of job %s to vm %s, error: %s", event.JobID, s.vmName, err)
+		}
+	case vmdispatch.EventConfigChanged:
+		s := agentSessions.get(event.VMID)
+		if s == nil {
+			return
+		}
+		vm, err := commonrepo.NewPrivateKeyColl().Find(commonrepo.FindPrivateKeyOption{
+			ID: event.VMID,
+		})
+		if err != nil {
+			log.Warnf("failed to find vm %s, error: %s", event.VMID, err)
+			return
+		}
+		if err := s.send(&AgentDispatchMessage{Type: AgentDispatchMessageConfig, Config: agentConfigResponse(vm)}); err != nil {
+			log.Warnf("failed to push config to vm %s, error: %s", vm.Name, err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	vmmodel "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/vm"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/util/labelexpr"
+)
+
+// getVMLabels returns the labels of a vm, a vm may carry several labels separated by commas.
+func getVMLabels(vm *commonmodels.PrivateKey) []string {
+	resp := make([]string, 0)
+	for _, label := range strings.Split(vm.Label, setting.VMLabelSeparator) {
+		if label = strings.TrimSpace(label); label != "" {
+			resp = append(resp, label)
+		}
+	}
+	return resp
+}
+
+// vmJobMatchesLabels checks whether a vm with the given labels can run the job. Each entry of the
+// job's vm labels is a label expression, the job can run on the vm if any of them matches.
+func vmJobMatchesLabels(job *vmmodel.VMJob, labels map[string]bool) bool {
+	for _, s := range job.VMLabels {
+		if s == setting.VMLabelAnyOne {
+			return true
+		}
+		if labelexpr.Match(s, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// vmJobQueueKey identifies the queue a job waits in, jobs with the same label requirements share a queue.
+func vmJobQueueKey(job *vmmodel.VMJob) string {
+	labels := make([]string, len(job.VMLabels))
+	copy(labels, job.VMLabels)
+	sort.Strings(labels)
+	return strings.Join(labels, " | ")
+}
+
+// checkVMCapacity returns an error describing why the vm can not take another job right now.
+func checkVMCapacity(vm *commonmodels.PrivateKey) error {
+	if vm.Agent == nil {
+		return fmt.Errorf("vm %s agent is not registered", vm.Name)
+	}
+
+	if vm.Agent.TaskConcurrency > 0 {
+		jobs, err := vmmongodb.NewVMJobColl().ListByOpts(&vmmongodb.VMJobOpts{
+			VMID:     vm.ID.Hex(),
+			Statuses: []string{string(config.StatusPrepare), string(config.StatusRunning)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list jobs of vm %s, error: %s", vm.Name, err)
+		}
+		if len(jobs) >= vm.Agent.TaskConcurrency {
+			return fmt.Errorf("vm %s is running %d jobs, concurrency is %d", vm.Name, len(jobs), vm.Agent.TaskConcurrency)
+		}
+	}
+
+	// resource usage is only trusted while the heartbeat is fresh
+	if vm.VMInfo == nil || time.Now().Unix()-vm.Agent.LastHeartbeatTime > setting.VMSchedulingHeartbeatMaxAge {
+		return nil
+	}
+	if vm.VMInfo.CpuUsage >= setting.VMSchedulingMaxCPUUsage {
+		return fmt.Errorf("vm %s cpu usage %.1f%% is too high", vm.Name, vm.VMInfo.CpuUsage)
+	}
+	if vm.VMInfo.MemeryTotal > 0 && float64(vm.VMInfo.UsedMemery)*100/float64(vm.VMInfo.MemeryTotal) >= setting.VMSchedulingMaxMemoryUsage {
+		return fmt.Errorf("vm %s memory usage is too high", vm.Name)
+	}
+	if vm.VMInfo.DiskSpace > 0 && vm.VMInfo.FreeDiskSpace < setting.VMSchedulingMinFreeDisk {
+		return fmt.Errorf("vm %s free disk space is too low", vm.Name)
+	}
+	return nil
+}
+
+// vmQueueCursor remembers the last queue each vm was served from so that the queues
+// a vm can serve are taken in turn.
+type vmQueueCursor struct {
+	sync.Mutex
+	last map[string]string
+}
+
+var vmJobQueueCursor = &vmQueueCursor{last: make(map[string]string)}
+
+// pickVMJob selects the next created job the vm should run. Jobs that waited longer than
+// VMJobStarvationSeconds come first, otherwise the queues matching the vm are served round-robin
+// so that a long queue on one label set does not block the others.
+func pickVMJob(vm *commonmodels.PrivateKey, jobs []*vmmodel.VMJob) *vmmodel.VMJob {
+	labels := labelexpr.LabelSet(getVMLabels(vm)...)
+
+	// jobs are sorted by created time, so the first job of each queue is its oldest one
+	queues := make(map[string]*vmmodel.VMJob)
+	keys := make([]string, 0)
+	now := time.Now().Unix()
+	for _, job := range jobs {
+		if !vmJobMatchesLabels(job, labels) {
+			continue
+		}
+		if now-job.CreatedTime > setting.VMJobStarvationSeconds {
+			return job
+		}
+		key := vmJobQueueKey(job)
+		if _, ok := queues[key]; !ok {
+			queues[key] = job
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+
+	vmJobQueueCursor.Lock()
+	defer vmJobQueueCursor.Unlock()
+
+	next := keys[0]
+	last := vmJobQueueCursor.last[vm.ID.Hex()]
+	for _, key := range keys {
+		if key > last {
+			next = key
+			break
+		}
+	}
+	vmJobQueueCursor.last[vm.ID.Hex()] = next
+	return queues[next]
+}
+
+type VMJobQueue struct {
+	Labels            []string `json:"labels"`
+	Queued            int      `json:"queued"`
+	Running           int      `json:"running"`
+	OldestWaitSeconds int64    `json:"oldest_wait_seconds"`
+	MatchedVMs        []string `json:"matched_vms"`
+	AvailableVMs      []string `json:"available_vms"`
+}
+
+// ListVMJobQueues shows the vm jobs waiting for an agent grouped by their label requirements,
+// together with the vms able to serve each queue.
+func ListVMJobQueues(projectName string, logger *zap.SugaredLogger) ([]*VMJobQueue, error) {
+	jobs, err := vmmongodb.NewVMJobColl().ListByOpts(&vmmongodb.VMJobOpts{
+		Statuses: []string{string(config.StatusCreated), string(config.StatusPrepare), string(config.StatusRunning)},
+	})
+	if err != nil {
+		logger.Errorf("failed to list vm jobs, error: %s", err)
+		return nil, fmt.Errorf("failed to list vm jobs, error: %s", err)
+	}
+
+	vms, err := commonrepo.NewPrivateKeyColl().List(&commonrepo.PrivateKeyArgs{})
+	if err != nil {
+		logger.Errorf("failed to list VMs, error: %s", err)
+		return nil, fmt.Errorf("failed to list VMs, error: %s", err)
+	}
+	agents := make([]*commonmodels.PrivateKey, 0)
+	for _, vm := range vms {
+		if vm.ScheduleWorkflow && vm.Agent != nil && vm.Type == setting.NewVMType && vm.Status == setting.VMNormal {
+			agents = append(agents, vm)
+		}
+	}
+
+	queueMap := make(map[string]*VMJobQueue)
+	resp := make([]*VMJobQueue, 0)
+	now := time.Now().Unix()
+	for _, job := range jobs {
+		if projectName != "" && job.ProjectName != projectName {
+			continue
+		}
+
+		key := vmJobQueueKey(job)
+		queue, ok := queueMap[key]
+		if !ok {
+			queue = &VMJobQueue{
+				Labels:       job.VMLabels,
+				MatchedVMs:   make([]string, 0),
+				AvailableVMs: make([]string, 0),
+			}
+			for _, vm := range agents {
+				if !vmJobMatchesLabels(job, labelexpr.LabelSet(getVMLabels(vm)...)) {
+					continue
+				}
+				queue.MatchedVMs = append(queue.MatchedVMs, vm.Name)
+				if checkVMCapacity(vm) == nil {
+					queue.AvailableVMs = append(queue.AvailableVMs, vm.Name)
+				}
+			}
+			queueMap[key] = queue
+			resp = append(resp, queue)
+		}
+
+		if job.Status == string(config.StatusCreated) {
+			queue.Queued++
+			if wait := now - job.CreatedTime; wait > queue.OldestWaitSeconds {
+				queue.OldestWaitSeconds = wait
+			}
+		} else {
+			queue.Running++
+		}
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].OldestWaitSeconds > resp[j].OldestWaitSeconds
+	})
+	return resp, nil
+}
@@ -109,12 +109,15 @@ type VerifyAgentResponse struct {
 }
 
 type HeartbeatParameters struct {
-	MemTotal      uint64 `json:"mem_total"`
-	UsedMem       uint64 `json:"used_mem"`
-	DiskSpace     uint64 `json:"disk_space"`
-	FreeDiskSpace uint64 `json:"free_disk_space"`
-	VMname        string `json:"vm_name"`
-	AgentVersion  string `json:"agent_version"`
+	MemTotal      uint64  `json:"mem_total"`
+	UsedMem       uint64  `json:"used_mem"`
+	CpuNum        int     `json:"cpu_num"`
+	CpuUsage      float64 `json:"cpu_usage"`
+	DiskSpace     uint64  `json:"disk_space"`
+	FreeDiskSpace uint64  `json:"free_disk_space"`
+	VMname        string  `json:"vm_name"`
+	AgentVersion  string  `json:"agent_version"`
+	RunningJobs   int     `json:"running_jobs"`
 }
 
 type HeartbeatRequest struct {
@@ -31,10 +31,10 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
-	vmmodel "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models/vm"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
 	vmmongodb "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/vm"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/vmdispatch"
 	systemservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/system/service"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
@@ -85,6 +85,7 @@ func OfflineVM(idString, user string, logger *zap.SugaredLogger) error {
 		logger.Errorf("failed to offline vm %s, error: %s", idString, err)
 		return e.ErrOfflineZadigVM.AddErr(fmt.Errorf("failed to offline vm %s, error: %s", idString, err))
 	}
+	vmdispatch.NotifyConfigChanged(idString)
 
 	return nil
 }
@@ -367,9 +368,7 @@ func ListVMLabels(projectKey string, logger *zap.SugaredLogger) ([]string, error
 		}
 
 		if vm.ScheduleWorkflow && vm.Agent != nil && vm.Type == setting.NewVMType && vm.Status == setting.VMNormal {
-			if vm.Label != "" && !labelSet.Has(vm.Label) {
-				labelSet.Insert(vm.Label)
-			}
+			labelSet.Insert(getVMLabels(vm)...)
 		}
 	}
 
@@ -458,14 +457,8 @@ func Heartbeat(args *HeartbeatRequest, logger *zap.SugaredLogger) (*HeartbeatRes
 		return nil, err
 	}
 
-	resp := &HeartbeatResponse{
-		NeedUpdateAgentVersion: false,
-		NeedOffline:            false,
-	}
-
 	if vm.Status == setting.VMOffline {
-		resp.NeedOffline = true
-		return resp, nil
+		return &HeartbeatResponse{NeedOffline: true}, nil
 	}
 
 	vm.Status = setting.VMNormal
@@ -474,6 +467,10 @@ func Heartbeat(args *HeartbeatRequest, logger *zap.SugaredLogger) (*HeartbeatRes
 		vm.VMInfo.UsedMemery = args.Parameters.UsedMem
 		vm.VMInfo.DiskSpace = args.Parameters.DiskSpace
 		vm.VMInfo.FreeDiskSpace = args.Parameters.FreeDiskSpace
+		vm.VMInfo.CpuUsage = args.Parameters.CpuUsage
+		if args.Parameters.CpuNum > 0 {
+			vm.VMInfo.CpuNum = args.Parameters.CpuNum
+		}
 	}
 	vm.UpdateBy = setting.SystemUser
 	vm.UpdateTime = time.Now().Unix()
@@ -487,6 +484,9 @@ func Heartbeat(args *HeartbeatRequest, logger *zap.SugaredLogger) (*HeartbeatRes
 	if args.Parameters != nil && args.Parameters.AgentVersion != "" {
 		vm.Agent.AgentVersion = args.Parameters.AgentVersion
 	}
+	if args.Parameters != nil {
+		vm.Agent.RunningJobs = args.Parameters.RunningJobs
+	}
 	updateAgentVersionStatus(vm.Agent, logger)
 
 	err = commonrepo.NewPrivateKeyColl().Update(vm.ID.Hex(), vm)
@@ -495,6 +495,20 @@ func Heartbeat(args *HeartbeatRequest, logger *zap.SugaredLogger) (*HeartbeatRes
 		return nil, fmt.Errorf("failed to update vm %s, error: %s", args.Token, err)
 	}
 
+	return agentConfigResponse(vm), nil
+}
+
+// agentConfigResponse builds the agent configuration the server wants the given vm to run with.
+func agentConfigResponse(vm *commonmodels.PrivateKey) *HeartbeatResponse {
+	resp := &HeartbeatResponse{}
+	if vm.Status == setting.VMOffline {
+		resp.NeedOffline = true
+		return resp
+	}
+	if vm.Agent == nil {
+		return resp
+	}
+
 	if vm.Agent.NeedUpdate {
 		resp.NeedUpdateAgentVersion = true
 		resp.AgentVersion = vm.Agent.ZadigVersion
@@ -508,8 +522,7 @@ func Heartbeat(args *HeartbeatRequest, logger *zap.SugaredLogger) (*HeartbeatRes
 	if vm.Agent.TaskConcurrency > 0 {
 		resp.Concurrency = vm.Agent.TaskConcurrency
 	}
-
-	return resp, nil
+	return resp
 }
 
 func updateAgentVersionStatus(agent *commonmodels.VMAgent, logger *zap.SugaredLogger) {
@@ -590,14 +603,12 @@ func PollingAgentJob(token string, retry int, logger *zap.SugaredLogger) (*Polli
 		return nil, fmt.Errorf("vm %s failed to find job that status is created, error: %v", vm.Name, err)
 	}
 
-	var job *vmmodel.VMJob
-	for _, j := range jobs {
-		labelSet := sets.NewString(j.VMLabels...)
-		if j.VMLabels != nil && (j.VMLabels[0] == setting.VMLabelAnyOne || labelSet.Has(vm.Label)) {
-			job = j
-			break
-		}
+	if err := checkVMCapacity(vm); err != nil {
+		logger.Debugf("skip scheduling job to vm: %s", err)
+		return nil, nil
 	}
+
+	job := pickVMJob(vm, jobs)
 	if job == nil {
 		return nil, nil
 	}
@@ -72,6 +72,10 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		workflowV4.POST("/auto", AutoCreateWorkflow)
 		workflowV4.GET("/trigger", ListWorkflowV4CanTrigger)
 		workflowV4.POST("/lint", LintWorkflowV4)
+		workflowV4.GET("/gitsource", GetWorkflowV4GitSource)
+		workflowV4.PUT("/gitsource", UpsertWorkflowV4GitSource)
+		workflowV4.DELETE("/gitsource", DeleteWorkflowV4GitSource)
+		workflowV4.POST("/gitsource/sync", SyncWorkflowV4FromGit)
 		workflowV4.POST("/check/:name", CheckWorkflowV4Approval)
 		workflowV4.GET("/templates/:templateID/versions", ListWorkflowTemplateVersions)
 		workflowV4.GET("/templates/:templateID/diff", DiffWorkflowTemplateVersions)
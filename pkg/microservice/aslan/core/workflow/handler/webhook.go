@@ -27,6 +27,8 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/webhook"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
 	"github.com/koderover/zadig/v2/pkg/tool/gitee"
 )
 
@@ -40,12 +42,17 @@ func ProcessGitWebHook(c *gin.Context) {
 		return
 	}
 
-	if github.WebHookType(c.Request) != "" {
+	// gitea also sends the github event header for compatibility, so it has to be checked first
+	if gitea.HookEventType(c.Request) != "" {
+		ctx.RespErr = webhook.ProcessGiteaHook(payload, c.Request, ctx.RequestID, ctx.Logger)
+	} else if github.WebHookType(c.Request) != "" {
 		ctx.RespErr = processGithub(payload, c.Request, ctx.RequestID, ctx.Logger)
 	} else if gitlab.HookEventType(c.Request) != "" {
 		ctx.RespErr = webhook.ProcessGitlabHook(payload, c.Request, ctx.RequestID, ctx.Logger)
 	} else if gitee.HookEventType(c.Request) != "" {
 		ctx.RespErr = webhook.ProcessGiteeHook(payload, c.Request, ctx.RequestID, ctx.Logger)
+	} else if bitbucket.HookEventKey(c.Request) != "" {
+		ctx.RespErr = webhook.ProcessBitbucketHook(payload, c.Request, ctx.RequestID, ctx.Logger)
 	} else {
 		ctx.RespErr = webhook.ProcessGerritHook(payload, c.Request, ctx.RequestID, ctx.Logger)
 	}
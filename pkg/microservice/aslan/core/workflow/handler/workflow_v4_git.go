@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// @Summary 获取项目工作流代码库
+// @Description 获取项目工作流代码库绑定及各工作流的同步状态
+// @Tags 	workflow
+// @Accept 	json
+// @Produce json
+// @Param 	projectName		query		string								true	"项目标识"
+// @Success 200 			{object} 	workflow.WorkflowV4GitSourceStatus
+// @Router /api/aslan/workflow/v4/gitsource [get]
+func GetWorkflowV4GitSource(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = errors.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+	if !authorizeWorkflowV4GitSource(ctx, projectName, false) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = workflow.GetWorkflowV4GitSource(projectName, ctx.Logger)
+}
+
+// @Summary 绑定项目工作流代码库
+// @Description 将项目的工作流绑定到代码库目录，目录中的每个 yaml 文件为一个工作流定义
+// @Tags 	workflow
+// @Accept 	json
+// @Produce json
+// @Param 	projectName		query		string								true	"项目标识"
+// @Param 	body 			body 		commonmodels.WorkflowV4GitSource 	true 	"代码库信息"
+// @Success 200
+// @Router /api/aslan/workflow/v4/gitsource [put]
+func UpsertWorkflowV4GitSource(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = errors.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+	args := new(commonmodels.WorkflowV4GitSource)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = errors.ErrInvalidParam.AddDesc(err.Error())
+		return
+	}
+
+	data, _ := json.Marshal(args)
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectName, "更新", "工作流代码库", projectName, projectName, string(data), types.RequestBodyTypeJSON, ctx.Logger)
+
+	if !authorizeWorkflowV4GitSource(ctx, projectName, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.RespErr = workflow.UpsertWorkflowV4GitSource(projectName, ctx.UserName, args, ctx.Logger)
+}
+
+// @Summary 解绑项目工作流代码库
+// @Description 解绑后工作流保留，并可以在页面上编辑
+// @Tags 	workflow
+// @Accept 	json
+// @Produce json
+// @Param 	projectName		query		string								true	"项目标识"
+// @Success 200
+// @Router /api/aslan/workflow/v4/gitsource [delete]
+func DeleteWorkflowV4GitSource(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = errors.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+
+	internalhandler.InsertOperationLog(c, ctx.UserName, projectName, "删除", "工作流代码库", projectName, projectName, "", types.RequestBodyTypeJSON, ctx.Logger)
+
+	if !authorizeWorkflowV4GitSource(ctx, projectName, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.RespErr = workflow.DeleteWorkflowV4GitSource(projectName, ctx.Logger)
+}
+
+// @Summary 从代码库同步工作流
+// @Description 按代码库中的定义创建、更新或删除工作流，dryRun 为 true 时只返回变更计划
+// @Tags 	workflow
+// @Accept 	json
+// @Produce json
+// @Param 	projectName		query		string								true	"项目标识"
+// @Param 	commit			query		string								false	"commit sha，默认为分支最新提交"
+// @Param 	dryRun			query		bool								false	"是否仅预览"
+// @Success 200 			{object} 	workflow.WorkflowV4GitSyncResult
+// @Router /api/aslan/workflow/v4/gitsource/sync [post]
+func SyncWorkflowV4FromGit(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = errors.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	if !dryRun {
+		internalhandler.InsertOperationLog(c, ctx.UserName, projectName, "同步", "工作流代码库", projectName, projectName, "", types.RequestBodyTypeJSON, ctx.Logger)
+	}
+
+	if !authorizeWorkflowV4GitSource(ctx, projectName, true) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = workflow.SyncWorkflowV4FromGit(projectName, c.Query("commit"), dryRun, ctx.UserName, ctx.Logger)
+}
+
+func authorizeWorkflowV4GitSource(ctx *internalhandler.Context, projectName string, edit bool) bool {
+	if ctx.Resources.IsSystemAdmin {
+		return true
+	}
+	projectAuth, ok := ctx.Resources.ProjectAuthInfo[projectName]
+	if !ok {
+		return false
+	}
+	if projectAuth.IsProjectAdmin || projectAuth.Workflow.Edit {
+		return true
+	}
+	return !edit && projectAuth.Workflow.View
+}
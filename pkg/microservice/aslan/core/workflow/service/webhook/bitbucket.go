@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	bitbucketservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/bitbucket"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+func ProcessBitbucketHook(payload []byte, req *http.Request, requestID string, log *zap.SugaredLogger) error {
+	eventKey := bitbucket.HookEventKey(req)
+	if eventKey == bitbucket.EventKeyServerPing {
+		return nil
+	}
+
+	if secret := util.GetGitHookSecret(); secret != "" {
+		if err := bitbucket.ValidateSignature(req, payload, secret); err != nil {
+			return fmt.Errorf("failed to validate bitbucket webhook: %w", err)
+		}
+	}
+
+	event, err := bitbucket.ParseHook(eventKey, payload)
+	if err != nil {
+		return err
+	}
+
+	deliveryID := bitbucket.DeliveryID(req)
+	log.Infof("[Webhook] bitbucket event: %s delivery id: %s received", eventKey, deliveryID)
+
+	if ev, ok := event.(*bitbucket.PullRequestEvent); ok && ev.IsCloud() && ev.PullRequest.FromFork {
+		// bitbucket cloud does not expose pull request refs, the source branch in the fork can not be fetched
+		log.Infof("pull request %d of %s/%s is from a fork, ignored", ev.PullRequest.ID, ev.Namespace, ev.RepoName)
+		return nil
+	}
+
+	if err := TriggerWorkflowV4ByBitbucketEvent(event, string(payload), deliveryID, requestID, log); err != nil {
+		return e.ErrGithubWebHook.AddErr(err)
+	}
+	return nil
+}
+
+func newBitbucketClient(codehostID int) (*bitbucketservice.Client, error) {
+	ch, err := systemconfig.New().GetCodeHost(codehostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find codehost %d: %v", codehostID, err)
+	}
+	return bitbucketservice.NewClient(ch, config.ProxyHTTPSAddr())
+}
+
+func findChangedFilesOfBitbucketPush(event *bitbucket.PushEvent, change *bitbucket.RefChange, codehostID int) ([]string, error) {
+	cli, err := newBitbucketClient(codehostID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := cli.ListChangedFiles(event.Namespace, event.RepoName, change.FromHash, change.ToHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes from bitbucket, err: %v", err)
+	}
+	return files, nil
+}
+
+// findChangedFilesOfBitbucketPullRequest returns the changed files of the pull request, the abbreviated commit sha
+// sent by bitbucket cloud is resolved to the full sha as well so that the build status can be reported to it.
+func findChangedFilesOfBitbucketPullRequest(event *bitbucket.PullRequestEvent, codehostID int) ([]string, error) {
+	cli, err := newBitbucketClient(codehostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(event.PullRequest.SourceCommit) < 40 {
+		commit, err := cli.GetCommit(event.Namespace, event.RepoName, event.PullRequest.SourceCommit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit %s from bitbucket, err: %v", event.PullRequest.SourceCommit, err)
+		}
+		event.PullRequest.SourceCommit = commit.ID
+	}
+
+	files, err := cli.ListPullRequestChangedFiles(event.Namespace, event.RepoName, event.PullRequest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes from bitbucket, err: %v", err)
+	}
+	return files, nil
+}
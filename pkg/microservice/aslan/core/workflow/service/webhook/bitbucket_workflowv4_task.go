@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/scmnotify"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow/controller"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/git/bitbucket"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type bitbucketPushDiffFunc func(event *bitbucket.PushEvent, change *bitbucket.RefChange, codehostID int) ([]string, error)
+
+type bitbucketPullRequestDiffFunc func(event *bitbucket.PullRequestEvent, codehostID int) ([]string, error)
+
+// bitbucketPushEventMatcherForWorkflowV4 matches one branch of a push, a single push may update several refs
+type bitbucketPushEventMatcherForWorkflowV4 struct {
+	diffFunc bitbucketPushDiffFunc
+	log      *zap.SugaredLogger
+	event    *bitbucket.PushEvent
+	change   *bitbucket.RefChange
+}
+
+func (bpem *bitbucketPushEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
+	ev := bpem.event
+	if !checkRepoNamespaceMatch(hookRepo, ev.Namespace+"/"+ev.RepoName) {
+		return false, nil
+	}
+
+	if !EventConfigured(hookRepo, config.HookEventPush) {
+		return false, nil
+	}
+
+	if !MatchBranch(hookRepo, config.HookEventPush, bpem.change.Name) {
+		return false, nil
+	}
+	hookRepo.Branch = bpem.change.Name
+	hookRepo.Committer = ev.Actor
+
+	changedFiles, err := bpem.diffFunc(ev, bpem.change, hookRepo.CodehostID)
+	if err != nil {
+		bpem.log.Warnf("failed to get changes of push %s/%s %s", ev.Namespace, ev.RepoName, bpem.change.Ref)
+		return false, err
+	}
+	return MatchChanges(hookRepo, changedFiles), nil
+}
+
+func (bpem *bitbucketPushEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
+	return &types.Repository{
+		CodehostID:    hookRepo.CodehostID,
+		RepoName:      hookRepo.RepoName,
+		RepoOwner:     hookRepo.RepoOwner,
+		RepoNamespace: hookRepo.GetRepoNamespace(),
+		Branch:        hookRepo.Branch,
+		TargetBranch:  hookRepo.Branch,
+		CommitID:      bpem.change.ToHash,
+		CommitMessage: bpem.change.Message,
+		Committer:     hookRepo.Committer,
+		Source:        hookRepo.Source,
+	}
+}
+
+type bitbucketMergeEventMatcherForWorkflowV4 struct {
+	diffFunc bitbucketPullRequestDiffFunc
+	log      *zap.SugaredLogger
+	event    *bitbucket.PullRequestEvent
+}
+
+func (bmem *bitbucketMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
+	ev := bmem.event
+	if !checkRepoNamespaceMatch(hookRepo, ev.Namespace+"/"+ev.RepoName) {
+		return false, nil
+	}
+
+	if !EventConfigured(hookRepo, config.HookEventPr) {
+		return false, nil
+	}
+
+	branch := ev.PullRequest.TargetBranch
+	if !MatchBranch(hookRepo, config.HookEventPr, branch) {
+		return false, nil
+	}
+	hookRepo.Branch = branch
+	hookRepo.Committer = ev.PullRequest.Author
+	if ev.PullRequest.State != "OPEN" {
+		return false, nil
+	}
+
+	changedFiles, err := bmem.diffFunc(ev, hookRepo.CodehostID)
+	if err != nil {
+		bmem.log.Warnf("failed to get changes of pull request %s/%s %d", ev.Namespace, ev.RepoName, ev.PullRequest.ID)
+		return false, err
+	}
+	bmem.log.Debugf("succeed to get %d changes in merge event", len(changedFiles))
+
+	return MatchChanges(hookRepo, changedFiles), nil
+}
+
+func (bmem *bitbucketMergeEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
+	repo := &types.Repository{
+		CodehostID:    hookRepo.CodehostID,
+		RepoName:      hookRepo.RepoName,
+		RepoOwner:     hookRepo.RepoOwner,
+		RepoNamespace: hookRepo.GetRepoNamespace(),
+		Branch:        hookRepo.Branch,
+		TargetBranch:  bmem.event.PullRequest.TargetBranch,
+		PR:            bmem.event.PullRequest.ID,
+		CommitID:      bmem.event.PullRequest.SourceCommit,
+		Committer:     hookRepo.Committer,
+		Source:        hookRepo.Source,
+	}
+	if bmem.event.IsCloud() {
+		repo.CheckoutRef = types.BranchRef(bmem.event.PullRequest.SourceBranch)
+	}
+	return repo
+}
+
+type bitbucketTagEventMatcherForWorkflowV4 struct {
+	log    *zap.SugaredLogger
+	event  *bitbucket.PushEvent
+	change *bitbucket.RefChange
+}
+
+func (btem *bitbucketTagEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
+	ev := btem.event
+	if !checkRepoNamespaceMatch(hookRepo, ev.Namespace+"/"+ev.RepoName) {
+		return false, nil
+	}
+
+	if !EventConfigured(hookRepo, config.HookEventTag) {
+		return false, nil
+	}
+
+	if !MatchTag(hookRepo, btem.change.Name) {
+		return false, nil
+	}
+
+	hookRepo.Tag = btem.change.Name
+	hookRepo.Committer = ev.Actor
+
+	return true, nil
+}
+
+func (btem *bitbucketTagEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
+	return &types.Repository{
+		CodehostID:    hookRepo.CodehostID,
+		RepoName:      hookRepo.RepoName,
+		RepoOwner:     hookRepo.RepoOwner,
+		RepoNamespace: hookRepo.GetRepoNamespace(),
+		Branch:        hookRepo.Branch,
+		TargetBranch:  hookRepo.Branch,
+		Tag:           hookRepo.Tag,
+		Committer:     hookRepo.Committer,
+		Source:        hookRepo.Source,
+	}
+}
+
+// createBitbucketEventMatchersForWorkflowV4 returns a matcher for each ref changed by a push, tags are pushed in the
+// same event as branches by bitbucket. A single matcher is returned for a pull request event.
+func createBitbucketEventMatchersForWorkflowV4(event interface{}, log *zap.SugaredLogger) []gitEventMatcherForWorkflowV4 {
+	var matchers []gitEventMatcherForWorkflowV4
+	switch evt := event.(type) {
+	case *bitbucket.PushEvent:
+		for _, change := range evt.Changes {
+			if change.Deleted {
+				continue
+			}
+			if change.IsTag {
+				matchers = append(matchers, &bitbucketTagEventMatcherForWorkflowV4{
+					log:    log,
+					event:  evt,
+					change: change,
+				})
+				continue
+			}
+			matchers = append(matchers, &bitbucketPushEventMatcherForWorkflowV4{
+				diffFunc: findChangedFilesOfBitbucketPush,
+				log:      log,
+				event:    evt,
+				change:   change,
+			})
+		}
+	case *bitbucket.PullRequestEvent:
+		matchers = append(matchers, &bitbucketMergeEventMatcherForWorkflowV4{
+			diffFunc: findChangedFilesOfBitbucketPullRequest,
+			log:      log,
+			event:    evt,
+		})
+	}
+
+	return matchers
+}
+
+func TriggerWorkflowV4ByBitbucketEvent(event interface{}, rawPayload, deliveryID, requestID string, log *zap.SugaredLogger) error {
+	workflows, _, err := commonrepo.NewWorkflowV4Coll().List(&commonrepo.ListWorkflowV4Option{}, 0, 0)
+	if err != nil {
+		errMsg := fmt.Sprintf("list workflow v4 error: %v", err)
+		log.Error(errMsg)
+		return fmt.Errorf(errMsg)
+	}
+
+	mErr := &multierror.Error{}
+	payloadVariables := commonutil.BuildPayloadVariables(rawPayload)
+
+	for _, workflow := range workflows {
+		gitHooks, err := commonrepo.NewWorkflowV4GitHookColl().List(internalhandler.NewBackgroupContext(), workflow.Name)
+		if err != nil {
+			log.Errorf("list workflow v4 git hook error: %v", err)
+			continue
+		}
+		if len(gitHooks) == 0 {
+			continue
+		}
+
+		for _, item := range gitHooks {
+			if !item.Enabled || item.MainRepo == nil || item.MainRepo.Source != setting.SourceFromBitbucket {
+				continue
+			}
+
+			for _, matcher := range createBitbucketEventMatchersForWorkflowV4(event, log) {
+				matches, err := matcher.Match(item.MainRepo)
+				if err != nil {
+					mErr = multierror.Append(mErr, err)
+				}
+				if !matches {
+					continue
+				}
+				log.Infof("event match hook %v of %s", item.MainRepo, workflow.Name)
+				eventRepo := matcher.GetHookRepo(item.MainRepo)
+
+				autoCancelOpt := &AutoCancelOpt{
+					TaskType:     config.WorkflowType,
+					MainRepo:     item.MainRepo,
+					AutoCancel:   item.AutoCancel,
+					WorkflowName: workflow.Name,
+				}
+				var hookPayload *commonmodels.HookPayload
+				switch m := matcher.(type) {
+				case *bitbucketMergeEventMatcherForWorkflowV4:
+					pr := m.event.PullRequest
+					mergeRequestID := strconv.Itoa(pr.ID)
+					autoCancelOpt.Type = EventTypePR
+					autoCancelOpt.MergeRequestID = mergeRequestID
+					autoCancelOpt.CommitID = pr.SourceCommit
+					hookPayload = &commonmodels.HookPayload{
+						Owner:          eventRepo.RepoOwner,
+						Repo:           eventRepo.RepoName,
+						Branch:         pr.SourceBranch,
+						TargetBranch:   eventRepo.TargetBranch,
+						Ref:            pr.SourceCommit,
+						IsPr:           true,
+						CodehostID:     eventRepo.CodehostID,
+						DeliveryID:     deliveryID,
+						MergeRequestID: mergeRequestID,
+						CommitID:       pr.SourceCommit,
+						CommitSHA:      pr.SourceCommit,
+						Committer:      eventRepo.Committer,
+						EventType:      EventTypePR,
+					}
+				case *bitbucketPushEventMatcherForWorkflowV4:
+					autoCancelOpt.Type = EventTypePush
+					autoCancelOpt.Ref = m.change.Ref
+					autoCancelOpt.CommitID = m.change.ToHash
+					hookPayload = &commonmodels.HookPayload{
+						Owner:         eventRepo.RepoOwner,
+						Repo:          eventRepo.RepoName,
+						Branch:        eventRepo.Branch,
+						TargetBranch:  eventRepo.TargetBranch,
+						Ref:           m.change.Ref,
+						IsPr:          false,
+						CodehostID:    eventRepo.CodehostID,
+						DeliveryID:    deliveryID,
+						CommitID:      m.change.ToHash,
+						CommitSHA:     m.change.ToHash,
+						CommitMessage: eventRepo.CommitMessage,
+						Committer:     eventRepo.Committer,
+						EventType:     EventTypePush,
+					}
+				case *bitbucketTagEventMatcherForWorkflowV4:
+					hookPayload = &commonmodels.HookPayload{
+						Branch:       eventRepo.Branch,
+						TargetBranch: eventRepo.TargetBranch,
+						Committer:    eventRepo.Committer,
+						EventType:    EventTypeTag,
+					}
+				}
+				hookPayload.PayloadVars = payloadVariables
+				if autoCancelOpt.Type != "" {
+					err := AutoCancelWorkflowV4Task(autoCancelOpt, log)
+					if err != nil {
+						log.Errorf("failed to auto cancel workflowV4 task when receive event %v due to %v ", event, err)
+						mErr = multierror.Append(mErr, err)
+					}
+				}
+
+				workflowController := controller.CreateWorkflowController(item.WorkflowArg)
+				if err := workflowservice.UpdateWorkflowControllerWithLatestRenderedWorkflow(workflowController, nil, log); err != nil {
+					errMsg := fmt.Sprintf("merge workflow args error: %v", err)
+					log.Error(errMsg)
+					mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+					continue
+				}
+				if err := workflowController.SetRepo(eventRepo); err != nil {
+					errMsg := fmt.Sprintf("merge webhook repo info to workflowargs error: %v", err)
+					log.Error(errMsg)
+					mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+					continue
+				}
+				workflowController.HookPayload = hookPayload
+				if resp, err := workflowservice.CreateWorkflowTaskV4(&workflowservice.CreateWorkflowTaskV4Args{
+					Name: setting.WebhookTaskCreator,
+				}, workflowController.WorkflowV4, log); err != nil {
+					errMsg := fmt.Sprintf("failed to create workflow task when receive bitbucket event due to %v ", err)
+					log.Error(errMsg)
+					mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+				} else {
+					if workflowController.HookPayload.IsPr {
+						// Updating the build status in the git repository, this will not cause the function to return error if this function call fails
+						if err := scmnotify.NewService().CreateGitCheckForWorkflowV4(workflowController.WorkflowV4, resp.TaskID, log); err != nil {
+							log.Warnf("Failed to create bitbucket build status for custom workflow %s, taskID: %d the error is: %s", workflowController.Name, resp.TaskID, err)
+						}
+					}
+					log.Infof("succeed to create task %v", resp)
+				}
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
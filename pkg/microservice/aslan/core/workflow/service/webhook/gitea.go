@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+func ProcessGiteaHook(payload []byte, req *http.Request, requestID string, log *zap.SugaredLogger) error {
+	if secret := util.GetGitHookSecret(); secret != "" {
+		if err := gitea.ValidateSignature(req, payload, secret); err != nil {
+			return fmt.Errorf("failed to validate gitea webhook: %w", err)
+		}
+	}
+
+	eventType := gitea.HookEventType(req)
+	event, err := gitea.ParseHook(eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	baseURI, err := commonservice.GetSystemServerURL()
+	if err != nil {
+		return fmt.Errorf("failed to get system server URL: %w", err)
+	}
+
+	deliveryID := gitea.DeliveryID(req)
+	log.Infof("[Webhook] gitea event: %s delivery id: %s received", eventType, deliveryID)
+
+	var errorList = &multierror.Error{}
+	var wg sync.WaitGroup
+
+	switch event := event.(type) {
+	case *gitea.PushEvent:
+		// gitea sends push events for tags as well, they are handled by the create event
+		if !strings.HasPrefix(event.Ref, "refs/heads/") {
+			return nil
+		}
+
+		if len(event.Commits) > 0 && event.Commits[0].Author != nil {
+			webhookUser := &commonmodels.WebHookUser{
+				Domain:    req.Header.Get("X-Forwarded-Host"),
+				UserName:  event.Commits[0].Author.Name,
+				Email:     event.Commits[0].Author.Email,
+				Source:    setting.SourceFromGitea,
+				CreatedAt: time.Now().Unix(),
+			}
+			commonrepo.NewWebHookUserColl().Upsert(webhookUser)
+		}
+
+		if err := updateServiceTemplateByGiteaPush(event, log); err != nil {
+			errorList = multierror.Append(errorList, err)
+		}
+	case *gitea.PullRequestEvent:
+		if event.Action != gitea.PullRequestActionOpened && event.Action != gitea.PullRequestActionReopened && event.Action != gitea.PullRequestActionSynchronized {
+			return nil
+		}
+	case *gitea.CreateEvent:
+		if event.RefType != "tag" {
+			return nil
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := TriggerWorkflowV4ByGiteaEvent(event, string(payload), baseURI, deliveryID, requestID, log); err != nil {
+			errorList = multierror.Append(errorList, err)
+		}
+	}()
+	wg.Wait()
+
+	if err := errorList.ErrorOrNil(); err != nil {
+		return e.ErrGithubWebHook.AddErr(err)
+	}
+	return nil
+}
+
+func giteaPushEventChangedFiles(event *gitea.PushEvent) []string {
+	changeFiles := make([]string, 0)
+	for _, commit := range event.Commits {
+		changeFiles = append(changeFiles, commit.Added...)
+		changeFiles = append(changeFiles, commit.Removed...)
+		changeFiles = append(changeFiles, commit.Modified...)
+	}
+	return changeFiles
+}
+
+func updateServiceTemplateByGiteaPush(event *gitea.PushEvent, log *zap.SugaredLogger) error {
+	changeFiles := giteaPushEventChangedFiles(event)
+
+	latestCommitMessage := ""
+	if event.HeadCommit != nil {
+		latestCommitMessage = event.HeadCommit.Message
+	}
+
+	opt := &commonrepo.ServiceListOption{
+		Source: setting.SourceFromGitea,
+	}
+	svcTmplsMap := map[bool][]*commonmodels.Service{}
+	serviceTmpls, err := commonrepo.NewServiceColl().ListMaxRevisions(opt)
+	if err != nil {
+		log.Errorf("Failed to get gitea testing service templates, error: %v", err)
+		return err
+	}
+	svcTmplsMap[false] = serviceTmpls
+	productionServiceTmpls, err := commonrepo.NewProductionServiceColl().ListMaxRevisions(opt)
+	if err != nil {
+		log.Errorf("Failed to get gitea production service templates, error: %v", err)
+		return err
+	}
+	svcTmplsMap[true] = productionServiceTmpls
+
+	errs := &multierror.Error{}
+	for production, serviceTmpls := range svcTmplsMap {
+		for _, service := range serviceTmpls {
+			if event.Repo == nil || service.GetRepoNamespace()+"/"+service.RepoName != event.Repo.FullName {
+				continue
+			}
+
+			if !checkBranchMatch(service, production, event.Ref, log) {
+				continue
+			}
+
+			affected := false
+			for _, changeFile := range changeFiles {
+				if subElem(service.LoadPath, changeFile) {
+					affected = true
+					break
+				}
+			}
+			if !affected {
+				log.Infof("Service template %s from gitea %s is not affected, no sync, production %v", service.ServiceName, service.LoadPath, production)
+				continue
+			}
+
+			log.Infof("Started to sync service template %s from gitea %s, production %v", service.ServiceName, service.LoadPath, production)
+			service.CreateBy = "system"
+			service.Production = production
+			if err := SyncServiceTemplateFromGitea(service, event.After, latestCommitMessage, log); err != nil {
+				log.Errorf("SyncServiceTemplateFromGitea failed, error: %v", err)
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SyncServiceTemplateFromGitea syncs the service template to the latest commit, nothing is changed if the commit remains the same
+func SyncServiceTemplateFromGitea(service *commonmodels.Service, latestCommitID, latestCommitMessage string, log *zap.SugaredLogger) error {
+	if service.Source != setting.SourceFromGitea {
+		return errors.New("service template is not from gitea")
+	}
+
+	var before string
+	if service.Commit != nil {
+		before = service.Commit.SHA
+	}
+	service.Commit = &commonmodels.Commit{
+		SHA:     latestCommitID,
+		Message: latestCommitMessage,
+	}
+	if before == latestCommitID {
+		log.Infof("Before and after SHA: %s remains the same, no need to sync, source:%s", before, service.Source)
+		return nil
+	}
+
+	if err := fillServiceTmpl(setting.WebhookTaskCreator, service, log); err != nil {
+		log.Errorf("ensure gitea serviceTmpl failed, error: %+v", err)
+		return e.ErrValidateTemplate.AddDesc(err.Error())
+	}
+
+	log.Infof("End of sync service template %s from gitea path %s", service.ServiceName, service.LoadPath)
+	return nil
+}
+
+func syncContentFromGitea(args *commonmodels.Service, log *zap.SugaredLogger) error {
+	ch, err := systemconfig.New().GetCodeHost(args.CodehostID)
+	if err != nil {
+		log.Errorf("failed to get codehost %d, err: %s", args.CodehostID, err)
+		return err
+	}
+
+	loader, err := commonutil.GetYAMLLoader(ch)
+	if err != nil {
+		return err
+	}
+
+	yamls, err := loader.GetYAMLContents(args.GetRepoNamespace(), args.RepoName, args.LoadPath, args.BranchName, args.LoadFromDir, false)
+	if err != nil {
+		return err
+	}
+
+	args.Yaml = util.JoinYamls(yamls)
+	args.KubeYamls = util.SplitYaml(args.Yaml)
+	return nil
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/scmnotify"
+	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow/controller"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	"github.com/koderover/zadig/v2/pkg/tool/git/gitea"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type giteaPullRequestDiffFunc func(event *gitea.PullRequestEvent, codehostID int) ([]string, error)
+
+type giteaPushEventMatcherForWorkflowV4 struct {
+	log      *zap.SugaredLogger
+	workflow *commonmodels.WorkflowV4
+	event    *gitea.PushEvent
+}
+
+func (gpem *giteaPushEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
+	ev := gpem.event
+	if ev.Repo == nil || !checkRepoNamespaceMatch(hookRepo, ev.Repo.FullName) {
+		return false, nil
+	}
+
+	if !EventConfigured(hookRepo, config.HookEventPush) {
+		return false, nil
+	}
+
+	branch := getBranchFromRef(ev.Ref)
+	if !MatchBranch(hookRepo, config.HookEventPush, branch) {
+		return false, nil
+	}
+	hookRepo.Branch = branch
+	if ev.Pusher != nil {
+		hookRepo.Committer = ev.Pusher.Login
+	}
+
+	return MatchChanges(hookRepo, giteaPushEventChangedFiles(ev)), nil
+}
+
+func (gpem *giteaPushEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
+	var commitMessage string
+	if gpem.event.HeadCommit != nil {
+		commitMessage = gpem.event.HeadCommit.Message
+	}
+	return &types.Repository{
+		CodehostID:    hookRepo.CodehostID,
+		RepoName:      hookRepo.RepoName,
+		RepoOwner:     hookRepo.RepoOwner,
+		RepoNamespace: hookRepo.GetRepoNamespace(),
+		Branch:        hookRepo.Branch,
+		TargetBranch:  hookRepo.Branch,
+		CommitID:      gpem.event.After,
+		CommitMessage: commitMessage,
+		Committer:     hookRepo.Committer,
+		Source:        hookRepo.Source,
+	}
+}
+
+type giteaMergeEventMatcherForWorkflowV4 struct {
+	diffFunc giteaPullRequestDiffFunc
+	log      *zap.SugaredLogger
+	workflow *commonmodels.WorkflowV4
+	event    *gitea.PullRequestEvent
+}
+
+func (gmem *giteaMergeEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
+	ev := gmem.event
+	if ev.PullRequest == nil || ev.PullRequest.Base == nil || ev.Repo == nil {
+		return false, nil
+	}
+
+	if !checkRepoNamespaceMatch(hookRepo, ev.Repo.FullName) {
+		return false, nil
+	}
+
+	if !EventConfigured(hookRepo, config.HookEventPr) {
+		return false, nil
+	}
+
+	branch := ev.PullRequest.Base.Ref
+	if !MatchBranch(hookRepo, config.HookEventPr, branch) {
+		return false, nil
+	}
+	hookRepo.Branch = branch
+	if ev.PullRequest.User != nil {
+		hookRepo.Committer = ev.PullRequest.User.Login
+	}
+	if ev.PullRequest.State != "open" {
+		return false, nil
+	}
+
+	changedFiles, err := gmem.diffFunc(ev, hookRepo.CodehostID)
+	if err != nil {
+		gmem.log.Warnf("failed to get changes of event %v", ev)
+		return false, err
+	}
+	gmem.log.Debugf("succeed to get %d changes in merge event", len(changedFiles))
+
+	return MatchChanges(hookRepo, changedFiles), nil
+}
+
+func (gmem *giteaMergeEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
+	return &types.Repository{
+		CodehostID:    hookRepo.CodehostID,
+		RepoName:      hookRepo.RepoName,
+		RepoOwner:     hookRepo.RepoOwner,
+		RepoNamespace: hookRepo.GetRepoNamespace(),
+		Branch:        hookRepo.Branch,
+		TargetBranch:  gmem.event.PullRequest.Base.Ref,
+		PR:            gmem.event.PullRequest.Number,
+		CommitID:      gmem.event.PullRequest.Head.Sha,
+		Committer:     hookRepo.Committer,
+		Source:        hookRepo.Source,
+	}
+}
+
+type giteaTagEventMatcherForWorkflowV4 struct {
+	log      *zap.SugaredLogger
+	workflow *commonmodels.WorkflowV4
+	event    *gitea.CreateEvent
+}
+
+func (gtem *giteaTagEventMatcherForWorkflowV4) Match(hookRepo *commonmodels.MainHookRepo) (bool, error) {
+	ev := gtem.event
+	if ev.Repo == nil || !checkRepoNamespaceMatch(hookRepo, ev.Repo.FullName) {
+		return false, nil
+	}
+
+	if !EventConfigured(hookRepo, config.HookEventTag) {
+		return false, nil
+	}
+
+	tag := getTagFromRef(ev.Ref)
+	if !MatchTag(hookRepo, tag) {
+		return false, nil
+	}
+
+	hookRepo.Tag = tag
+	if ev.Sender != nil {
+		hookRepo.Committer = ev.Sender.Login
+	}
+
+	return true, nil
+}
+
+func (gtem *giteaTagEventMatcherForWorkflowV4) GetHookRepo(hookRepo *commonmodels.MainHookRepo) *types.Repository {
+	return &types.Repository{
+		CodehostID:    hookRepo.CodehostID,
+		RepoName:      hookRepo.RepoName,
+		RepoOwner:     hookRepo.RepoOwner,
+		RepoNamespace: hookRepo.GetRepoNamespace(),
+		Branch:        hookRepo.Branch,
+		TargetBranch:  hookRepo.Branch,
+		Tag:           hookRepo.Tag,
+		Committer:     hookRepo.Committer,
+		Source:        hookRepo.Source,
+	}
+}
+
+func createGiteaEventMatcherForWorkflowV4(
+	event interface{}, diffSrv giteaPullRequestDiffFunc, workflow *commonmodels.WorkflowV4, log *zap.SugaredLogger,
+) gitEventMatcherForWorkflowV4 {
+	switch evt := event.(type) {
+	case *gitea.PushEvent:
+		return &giteaPushEventMatcherForWorkflowV4{
+			workflow: workflow,
+			log:      log,
+			event:    evt,
+		}
+	case *gitea.PullRequestEvent:
+		return &giteaMergeEventMatcherForWorkflowV4{
+			diffFunc: diffSrv,
+			log:      log,
+			event:    evt,
+			workflow: workflow,
+		}
+	case *gitea.CreateEvent:
+		return &giteaTagEventMatcherForWorkflowV4{
+			workflow: workflow,
+			log:      log,
+			event:    evt,
+		}
+	}
+
+	return nil
+}
+
+func findChangedFilesOfGiteaPullRequest(event *gitea.PullRequestEvent, codehostID int) ([]string, error) {
+	detail, err := systemconfig.New().GetCodeHost(codehostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find codehost %d: %v", codehostID, err)
+	}
+
+	cli, err := gitea.NewClient(detail.ID, detail.Address, detail.AccessToken, config.ProxyHTTPSAddr(), detail.EnableProxy, detail.DisableSSL)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := cli.ListPullRequestFiles(event.Repo.Owner.Login, event.Repo.Name, event.PullRequest.Number, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes from gitea, err: %v", err)
+	}
+
+	changeFiles := make([]string, 0, len(files))
+	for _, file := range files {
+		changeFiles = append(changeFiles, file.Filename)
+		if file.PreviousFilename != "" {
+			changeFiles = append(changeFiles, file.PreviousFilename)
+		}
+	}
+	return changeFiles, nil
+}
+
+func TriggerWorkflowV4ByGiteaEvent(event interface{}, rawPayload, baseURI, deliveryID, requestID string, log *zap.SugaredLogger) error {
+	workflows, _, err := commonrepo.NewWorkflowV4Coll().List(&commonrepo.ListWorkflowV4Option{}, 0, 0)
+	if err != nil {
+		errMsg := fmt.Sprintf("list workflow v4 error: %v", err)
+		log.Error(errMsg)
+		return fmt.Errorf(errMsg)
+	}
+
+	mErr := &multierror.Error{}
+	payloadVariables := commonutil.BuildPayloadVariables(rawPayload)
+	var notification *commonmodels.Notification
+	var hookPayload *commonmodels.HookPayload
+
+	for _, workflow := range workflows {
+		gitHooks, err := commonrepo.NewWorkflowV4GitHookColl().List(internalhandler.NewBackgroupContext(), workflow.Name)
+		if err != nil {
+			log.Errorf("list workflow v4 git hook error: %v", err)
+			continue
+		}
+		if len(gitHooks) == 0 {
+			continue
+		}
+
+		for _, item := range gitHooks {
+			if !item.Enabled {
+				continue
+			}
+			matcher := createGiteaEventMatcherForWorkflowV4(event, findChangedFilesOfGiteaPullRequest, workflow, log)
+			if matcher == nil {
+				errMsg := fmt.Sprintf("unsupported gitea event type: %T", event)
+				log.Error(errMsg)
+				mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+				continue
+			}
+			matches, err := matcher.Match(item.MainRepo)
+			if err != nil {
+				mErr = multierror.Append(mErr, err)
+			}
+			if !matches {
+				continue
+			}
+			log.Infof("event match hook %v of %s", item.MainRepo, workflow.Name)
+			eventRepo := matcher.GetHookRepo(item.MainRepo)
+
+			autoCancelOpt := &AutoCancelOpt{
+				TaskType:     config.WorkflowType,
+				MainRepo:     item.MainRepo,
+				AutoCancel:   item.AutoCancel,
+				WorkflowName: workflow.Name,
+			}
+			var mergeRequestID, commitID, ref, eventType string
+			var prID int
+			switch ev := event.(type) {
+			case *gitea.PullRequestEvent:
+				eventType = EventTypePR
+				prID = ev.PullRequest.Number
+				mergeRequestID = strconv.Itoa(prID)
+				commitID = ev.PullRequest.Head.Sha
+				autoCancelOpt.Type = eventType
+				autoCancelOpt.MergeRequestID = mergeRequestID
+				autoCancelOpt.CommitID = commitID
+				hookPayload = &commonmodels.HookPayload{
+					Owner:          eventRepo.RepoOwner,
+					Repo:           eventRepo.RepoName,
+					Branch:         ev.PullRequest.Head.Ref,
+					TargetBranch:   eventRepo.TargetBranch,
+					Ref:            commitID,
+					IsPr:           true,
+					CodehostID:     eventRepo.CodehostID,
+					DeliveryID:     deliveryID,
+					MergeRequestID: mergeRequestID,
+					CommitID:       commitID,
+					CommitSHA:      commitID,
+					Committer:      eventRepo.Committer,
+					EventType:      eventType,
+				}
+			case *gitea.PushEvent:
+				eventType = EventTypePush
+				ref = ev.Ref
+				commitID = ev.After
+				autoCancelOpt.Type = eventType
+				autoCancelOpt.Ref = ref
+				autoCancelOpt.CommitID = commitID
+				hookPayload = &commonmodels.HookPayload{
+					Owner:         eventRepo.RepoOwner,
+					Repo:          eventRepo.RepoName,
+					Branch:        eventRepo.Branch,
+					TargetBranch:  eventRepo.TargetBranch,
+					Ref:           ref,
+					IsPr:          false,
+					CodehostID:    eventRepo.CodehostID,
+					DeliveryID:    deliveryID,
+					CommitID:      commitID,
+					CommitSHA:     commitID,
+					CommitMessage: eventRepo.CommitMessage,
+					Committer:     eventRepo.Committer,
+					EventType:     eventType,
+				}
+			case *gitea.CreateEvent:
+				eventType = EventTypeTag
+				hookPayload = &commonmodels.HookPayload{
+					Branch:       eventRepo.Branch,
+					TargetBranch: eventRepo.TargetBranch,
+					Committer:    eventRepo.Committer,
+					EventType:    eventType,
+				}
+			}
+			hookPayload.PayloadVars = payloadVariables
+			if autoCancelOpt.Type != "" {
+				err := AutoCancelWorkflowV4Task(autoCancelOpt, log)
+				if err != nil {
+					log.Errorf("failed to auto cancel workflowV4 task when receive event %v due to %v ", event, err)
+					mErr = multierror.Append(mErr, err)
+				}
+				if autoCancelOpt.Type == EventTypePR && notification == nil {
+					notification, _ = scmnotify.NewService().SendInitWebhookComment(
+						item.MainRepo, prID, baseURI, false, false, false, true, log,
+					)
+				}
+			}
+
+			workflowController := controller.CreateWorkflowController(item.WorkflowArg)
+			if err := workflowservice.UpdateWorkflowControllerWithLatestRenderedWorkflow(workflowController, nil, log); err != nil {
+				errMsg := fmt.Sprintf("merge workflow args error: %v", err)
+				log.Error(errMsg)
+				mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+				continue
+			}
+			if err := workflowController.SetRepo(eventRepo); err != nil {
+				errMsg := fmt.Sprintf("merge webhook repo info to workflowargs error: %v", err)
+				log.Error(errMsg)
+				mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+				continue
+			}
+			if notification != nil {
+				workflowController.NotificationID = notification.ID.Hex()
+			}
+			workflowController.HookPayload = hookPayload
+			if resp, err := workflowservice.CreateWorkflowTaskV4(&workflowservice.CreateWorkflowTaskV4Args{
+				Name: setting.WebhookTaskCreator,
+			}, workflowController.WorkflowV4, log); err != nil {
+				errMsg := fmt.Sprintf("failed to create workflow task when receive gitea event due to %v ", err)
+				log.Error(errMsg)
+				mErr = multierror.Append(mErr, fmt.Errorf(errMsg))
+			} else {
+				if workflowController.HookPayload.IsPr {
+					// Updating the commit status in the git repository, this will not cause the function to return error if this function call fails
+					if err := scmnotify.NewService().CreateGitCheckForWorkflowV4(workflow, resp.TaskID, log); err != nil {
+						log.Warnf("Failed to create gitea commit status for custom workflow %s, taskID: %d the error is: %s", workflowController.Name, resp.TaskID, err)
+					}
+				}
+				log.Infof("succeed to create task %v", resp)
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
@@ -306,6 +306,9 @@ func ProcessGithubWebHook(payload []byte, req *http.Request, requestID string, l
 		if *et.Action != "opened" && *et.Action != "synchronize" {
 			return nil
 		}
+		if err = lintWorkflowV4ByGithubPullRequest(et, log); err != nil {
+			log.Errorf("lintWorkflowV4ByGithubPullRequest failed, error:%v", err)
+		}
 	case *github.PushEvent:
 		// sync service template
 		if err = updateServiceTemplateByGithubPush(et, log); err != nil {
@@ -322,6 +325,11 @@ func ProcessGithubWebHook(payload []byte, req *http.Request, requestID string, l
 			log.Errorf("updateServiceTemplateHelmValuesByGithubPush failed, error:%v", err)
 		}
 
+		// sync git managed workflows
+		if err = syncWorkflowV4ByGithubPush(et, log); err != nil {
+			log.Errorf("syncWorkflowV4ByGithubPush failed, error:%v", err)
+		}
+
 		//add webhook user
 		if et.Pusher != nil {
 			webhookUser := &commonmodels.WebHookUser{
@@ -116,8 +116,16 @@ func ProcessGitlabHook(payload []byte, req *http.Request, requestID string, log
 			errorList = multierror.Append(errorList, err)
 		}
 		log.Infof("gitlab webhook updateYamlTemplateByGitlabPush cost %s", time.Since(yamlSyncStart))
+		workflowSyncStart := time.Now()
+		if err = syncWorkflowV4ByGitPush(setting.SourceFromGitlab, pathWithNamespace, pushEvent.Ref, pushEvent.After, changeFiles, log); err != nil {
+			errorList = multierror.Append(errorList, err)
+		}
+		log.Infof("gitlab webhook syncWorkflowV4ByGitPush cost %s", time.Since(workflowSyncStart))
 	case *gitlab.MergeEvent:
 		mergeEvent = event
+		if err = lintWorkflowV4ByGitlabMergeEvent(mergeEvent, log); err != nil {
+			log.Errorf("lintWorkflowV4ByGitlabMergeEvent failed, error:%v", err)
+		}
 	case *gitlab.TagEvent:
 		tagEvent = event
 	}
@@ -172,6 +172,11 @@ func fillServiceTmpl(userName string, args *commonmodels.Service, log *zap.Sugar
 				log.Errorf("Sync content from github failed, error: %v", err)
 				return err
 			}
+		} else if args.Source == setting.SourceFromGitea {
+			if err := syncContentFromGitea(args, log); err != nil {
+				log.Errorf("Sync content from gitea failed, error: %v", err)
+				return err
+			}
 		} else {
 			// 拆分 all-in-one yaml文件
 			// 替换分隔符
@@ -197,9 +202,9 @@ func fillServiceTmpl(userName string, args *commonmodels.Service, log *zap.Sugar
 				log.Errorf("Sync content from gitlab failed, error: %s", err)
 				return err
 			}
-		} else if args.Source == setting.SourceFromGithub {
+		} else if args.Source == setting.SourceFromGithub || args.Source == setting.SourceFromGitea {
 			if err := reloadServiceTmplFromGit(args, log); err != nil {
-				log.Errorf("Sync content from github failed, error: %s", err)
+				log.Errorf("Sync content from %s failed, error: %s", args.Source, err)
 				return err
 			}
 		}
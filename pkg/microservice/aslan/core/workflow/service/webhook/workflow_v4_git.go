@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v35/github"
+	"github.com/hashicorp/go-multierror"
+	"github.com/xanzy/go-gitlab"
+	"go.uber.org/zap"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/scmnotify"
+	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+// listWorkflowV4GitSources returns the workflow git sources bound to the given repository and branch.
+func listWorkflowV4GitSources(source, pathWithNamespace, branch string) ([]*commonmodels.WorkflowV4GitSource, error) {
+	repoName := pathWithNamespace[strings.LastIndex(pathWithNamespace, "/")+1:]
+	sources, err := commonrepo.NewWorkflowV4GitSourceColl().List(&commonrepo.ListWorkflowV4GitSourceOption{
+		RepoName:   repoName,
+		BranchName: branch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*commonmodels.WorkflowV4GitSource, 0)
+	for _, gitSource := range sources {
+		if gitSource.Source != source || gitSource.GetNamespace()+"/"+gitSource.RepoName != pathWithNamespace {
+			continue
+		}
+		resp = append(resp, gitSource)
+	}
+	return resp, nil
+}
+
+// syncWorkflowV4ByGitPush reconciles the workflows of every project bound to the pushed branch.
+func syncWorkflowV4ByGitPush(source, pathWithNamespace, ref, after string, changeFiles []string, log *zap.SugaredLogger) error {
+	if strings.Trim(after, "0") == "" {
+		// the branch is deleted
+		return nil
+	}
+	sources, err := listWorkflowV4GitSources(source, pathWithNamespace, strings.TrimPrefix(ref, "refs/heads/"))
+	if err != nil {
+		return err
+	}
+
+	errs := &multierror.Error{}
+	for _, gitSource := range sources {
+		if len(changeFiles) > 0 && !workflowservice.WorkflowV4GitSourceChanged(gitSource, changeFiles) {
+			log.Infof("workflow definitions of project %s in %s are not affected, no sync", gitSource.ProjectName, gitSource.Path)
+			continue
+		}
+		log.Infof("Started to sync workflows of project %s from %s:%s", gitSource.ProjectName, pathWithNamespace, after)
+		result, err := workflowservice.SyncWorkflowV4FromGit(gitSource.ProjectName, after, false, setting.WebhookTaskCreator, log)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if len(result.Errors) > 0 {
+			log.Warnf("workflows of project %s synced from %s with %d errors", gitSource.ProjectName, after, len(result.Errors))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// lintWorkflowV4ByPullRequest dry-runs the sync with the head commit of the pull request and reports the result on it.
+func lintWorkflowV4ByPullRequest(source, pathWithNamespace, targetBranch, headSHA string, prID int, log *zap.SugaredLogger) error {
+	sources, err := listWorkflowV4GitSources(source, pathWithNamespace, targetBranch)
+	if err != nil {
+		return err
+	}
+
+	errs := &multierror.Error{}
+	for _, gitSource := range sources {
+		result, err := workflowservice.SyncWorkflowV4FromGit(gitSource.ProjectName, headSHA, true, setting.WebhookTaskCreator, log)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		err = scmnotify.NewService().PublishWorkflowLintReport(gitSource.CodehostID, gitSource.GetNamespace(), gitSource.RepoName, prID, workflowLintReport(result), log)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func workflowLintReport(result *workflowservice.WorkflowV4GitSyncResult) *scmnotify.WorkflowLintReport {
+	report := &scmnotify.WorkflowLintReport{
+		ProjectName: result.ProjectName,
+		CommitSHA:   result.CommitSHA,
+	}
+	for _, change := range result.Changes {
+		report.Changes = append(report.Changes, &scmnotify.WorkflowLintChange{
+			WorkflowName: change.WorkflowName,
+			Path:         change.Path,
+			Action:       change.Action,
+			DiffCount:    len(change.Diff),
+		})
+	}
+	for _, syncErr := range result.Errors {
+		report.Errors = append(report.Errors, &scmnotify.WorkflowLintError{
+			WorkflowName: syncErr.WorkflowName,
+			Path:         syncErr.Path,
+			Error:        syncErr.Error,
+		})
+	}
+	return report
+}
+
+func lintWorkflowV4ByGitlabMergeEvent(ev *gitlab.MergeEvent, log *zap.SugaredLogger) error {
+	switch ev.ObjectAttributes.Action {
+	case "open", "reopen", "update":
+	default:
+		return nil
+	}
+	return lintWorkflowV4ByPullRequest(setting.SourceFromGitlab, ev.Project.PathWithNamespace, ev.ObjectAttributes.TargetBranch, ev.ObjectAttributes.LastCommit.ID, ev.ObjectAttributes.IID, log)
+}
+
+func lintWorkflowV4ByGithubPullRequest(ev *github.PullRequestEvent, log *zap.SugaredLogger) error {
+	pr := ev.GetPullRequest()
+	return lintWorkflowV4ByPullRequest(setting.SourceFromGithub, ev.GetRepo().GetFullName(), pr.GetBase().GetRef(), pr.GetHead().GetSHA(), pr.GetNumber(), log)
+}
+
+func syncWorkflowV4ByGithubPush(ev *github.PushEvent, log *zap.SugaredLogger) error {
+	changeFiles := make([]string, 0)
+	for _, commit := range ev.Commits {
+		changeFiles = append(changeFiles, commit.Added...)
+		changeFiles = append(changeFiles, commit.Removed...)
+		changeFiles = append(changeFiles, commit.Modified...)
+	}
+	return syncWorkflowV4ByGitPush(setting.SourceFromGithub, ev.GetRepo().GetFullName(), ev.GetRef(), ev.GetAfter(), changeFiles, log)
+}
@@ -150,6 +150,12 @@ func CreateJobController(job *commonmodels.Job, workflow *commonmodels.WorkflowV
 		return CreateScanningJobController(job, workflow)
 	case config.JobSQL:
 		return CreateSQLJobController(job, workflow)
+	case config.JobSchemaMigration:
+		return CreateSchemaMigrationJobController(job, workflow)
+	case config.JobProgressiveDelivery:
+		return CreateProgressiveDeliveryJobController(job, workflow)
+	case config.JobTerraform:
+		return CreateTerraformJobController(job, workflow)
 	case config.JobZadigTesting:
 		return CreateTestingJobController(job, workflow)
 	case config.JobUpdateEnvIstioConfig:
@@ -122,6 +122,9 @@ func (j ApprovalJobController) Update(useUserInput bool, ticket *commonmodels.Ap
 		if latestJobSpec.WorkWXApproval != nil && j.jobSpec.WorkWXApproval != nil {
 			latestJobSpec.WorkWXApproval.ApprovalNodes = j.jobSpec.WorkWXApproval.ApprovalNodes
 		}
+		if latestJobSpec.SlackApproval != nil && j.jobSpec.SlackApproval != nil {
+			latestJobSpec.SlackApproval.ApproveUsers = j.jobSpec.SlackApproval.ApproveUsers
+		}
 	}
 
 	if latestJobSpec.ApprovalMessageSource == config.SourceFixed {
@@ -132,6 +135,7 @@ func (j ApprovalJobController) Update(useUserInput bool, ticket *commonmodels.Ap
 	j.jobSpec.LarkApproval = latestJobSpec.LarkApproval
 	j.jobSpec.DingTalkApproval = latestJobSpec.DingTalkApproval
 	j.jobSpec.WorkWXApproval = latestJobSpec.WorkWXApproval
+	j.jobSpec.SlackApproval = latestJobSpec.SlackApproval
 	return nil
 }
 
@@ -154,6 +158,12 @@ func (j ApprovalJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, er
 		nativeApproval.ApproveUsers = approvalUser
 	}
 
+	slackApproval := j.jobSpec.SlackApproval
+	if slackApproval != nil && j.jobSpec.Source != config.SourceFromJob {
+		approvalUser, _ := util.GeneFlatUsers(slackApproval.ApproveUsers)
+		slackApproval.ApproveUsers = approvalUser
+	}
+
 	jobSpec := &commonmodels.JobTaskApprovalSpec{
 		Timeout:          j.jobSpec.Timeout,
 		Type:             j.jobSpec.Type,
@@ -162,6 +172,7 @@ func (j ApprovalJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, er
 		LarkApproval:     j.jobSpec.LarkApproval,
 		DingTalkApproval: j.jobSpec.DingTalkApproval,
 		WorkWXApproval:   j.jobSpec.WorkWXApproval,
+		SlackApproval:    slackApproval,
 		ApprovalMessage:  j.jobSpec.ApprovalMessage,
 		ApprovalTitle:    j.jobSpec.ApprovalTitle,
 	}
@@ -230,6 +241,17 @@ func (j ApprovalJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, er
 			}
 
 			jobSpec.WorkWXApproval.ApprovalNodes = originJobSpec.WorkWXApproval.ApprovalNodes
+		case config.SlackApproval:
+			if originJobSpec.SlackApproval == nil {
+				return nil, fmt.Errorf("%s's slack approval not found", serviceReferredJob)
+			}
+
+			if originJobSpec.SlackApproval.ID != jobSpec.SlackApproval.ID {
+				return nil, fmt.Errorf("origin refered %s's slack id is different from current %s's slack id", serviceReferredJob, j.jobSpec.JobName)
+			}
+
+			approvalUser, _ := util.GeneFlatUsers(originJobSpec.SlackApproval.ApproveUsers)
+			jobSpec.SlackApproval.ApproveUsers = approvalUser
 		default:
 			return nil, fmt.Errorf("%s's invalid approval type %s's", originJobSpec.Type, serviceReferredJob)
 		}
@@ -330,6 +352,19 @@ func (j ApprovalJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, er
 		// if len(jobSpec.WorkWXApproval.ApprovalNodes) == 0 {
 		// 	return nil, fmt.Errorf("num of approval-node is 0")
 		// }
+	case config.SlackApproval:
+		if jobSpec.SlackApproval == nil {
+			return nil, fmt.Errorf("slack approval not found")
+		}
+		if jobSpec.SlackApproval.Channel == "" {
+			return nil, fmt.Errorf("slack channel is empty")
+		}
+		if len(jobSpec.SlackApproval.ApproveUsers) == 0 {
+			return nil, fmt.Errorf("num of approve-users is 0")
+		}
+		if len(jobSpec.SlackApproval.ApproveUsers) < jobSpec.SlackApproval.NeededApprovers {
+			return nil, fmt.Errorf("all approve users should not less than needed approvers")
+		}
 	default:
 		return nil, fmt.Errorf("invalid approval type %s", jobSpec.Type)
 	}
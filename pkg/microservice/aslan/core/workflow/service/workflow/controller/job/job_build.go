@@ -108,6 +108,10 @@ func (j BuildJobController) Validate(isExecution bool) error {
 		optionMap[key] = build
 	}
 
+	if err := validateJobMatrix(j.jobSpec.Matrix); err != nil {
+		return err
+	}
+
 	if isExecution {
 		latestJob, err := j.workflow.FindJob(j.name, j.jobType)
 		if err != nil {
@@ -277,6 +281,7 @@ func (j BuildJobController) Update(useUserInput bool, ticket *commonmodels.Appro
 	}
 
 	j.jobSpec.DockerRegistryID = latestJobSpec.DockerRegistryID
+	j.jobSpec.Matrix = latestJobSpec.Matrix
 	j.jobSpec.DefaultServiceAndBuilds = newDefault
 	j.jobSpec.ServiceAndBuildsOptions = newOption
 	j.jobSpec.ServiceAndBuilds = newSelection
@@ -348,9 +353,23 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 		j.jobSpec.ServiceAndBuilds = targets
 	}
 
+	combinations, err := jobMatrixCombinations(j.jobSpec.Matrix)
+	if err != nil {
+		return nil, err
+	}
+	// every combination of the matrix builds its own image and package, so the sub-tasks are expanded here
+	// before the steps referring to them are generated
+	targets := make([]*buildMatrixTarget, 0, len(j.jobSpec.ServiceAndBuilds)*len(combinations))
+	for _, build := range j.jobSpec.ServiceAndBuilds {
+		for _, combination := range combinations {
+			targets = append(targets, &buildMatrixTarget{build: build, combination: combination})
+		}
+	}
+
 	buildSvc := commonservice.NewBuildService()
-	for jobSubTaskID, build := range j.jobSpec.ServiceAndBuilds {
-		imageTag := commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "image")
+	for jobSubTaskID, target := range targets {
+		build, combination := target.build, target.combination
+		imageTag := combination.suffix(commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "image"), "-")
 
 		image := fmt.Sprintf("%s/%s", registry.RegAddr, imageTag)
 		if len(registry.Namespace) > 0 {
@@ -360,7 +379,7 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 		image = strings.TrimPrefix(image, "http://")
 		image = strings.TrimPrefix(image, "https://")
 
-		pkgFile := fmt.Sprintf("%s.tar.gz", commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "tar"))
+		pkgFile := fmt.Sprintf("%s.tar.gz", combination.suffix(commonservice.ReleaseCandidate(build.Repos, taskID, j.workflow.Project, build.ServiceModule, "", build.ImageName, "tar"), "-"))
 
 		buildInfo, err := buildSvc.GetBuild(build.BuildName, build.ServiceName, build.ServiceModule)
 		if err != nil {
@@ -378,9 +397,9 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 				"service_module": build.ServiceModule,
 				JobNameKey:       j.name,
 			},
-			Key:            genJobKey(j.name, build.ServiceName, build.ServiceModule),
+			Key:            combination.suffix(genJobKey(j.name, build.ServiceName, build.ServiceModule), "."),
 			Name:           GenJobName(j.workflow, j.name, jobSubTaskID),
-			DisplayName:    genJobDisplayName(j.name, build.ServiceName, build.ServiceModule),
+			DisplayName:    combination.suffix(genJobDisplayName(j.name, build.ServiceName, build.ServiceModule), "-"),
 			OriginName:     j.name,
 			JobType:        string(config.JobZadigBuild),
 			Spec:           jobTaskSpec,
@@ -412,6 +431,7 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 		paramEnvs := generateKeyValsFromWorkflowParam(j.workflow.Params)
 		envs := mergeKeyVals(jobTaskSpec.Properties.CustomEnvs, paramEnvs)
 		jobTaskSpec.Properties.Envs = append(envs, getBuildJobVariables(build, taskID, j.workflow.Project, j.workflow.Name, j.workflow.DisplayName, image, pkgFile, jobTask.Infrastructure, registry, logger)...)
+		setJobTaskMatrix(jobTask, jobTaskSpec, genJobKey(j.name, build.ServiceName, build.ServiceModule), j.jobSpec.Matrix, combination)
 
 		// Add keyvault envs for credential masking
 		keyvaultEnvs, err := GetKeyVaultEnvs(j.workflow.Project)
@@ -468,6 +488,8 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 			if jobTaskSpec.Properties.CacheEnable {
 				jobTaskSpec.Properties.CacheUserDir = commonutil.RenderEnv(jobTaskSpec.Properties.CacheUserDir, jobTaskSpec.Properties.Envs)
 				jobTaskSpec.Properties.IgnoreCache = j.workflow.IgnoreCache
+				jobTaskSpec.Properties.CacheRules = buildInfo.CacheRules
+				jobTaskSpec.Properties.CacheQuotaInMiB = buildInfo.CacheQuotaInMiB
 				if jobTaskSpec.Properties.Cache.MediumType == types.NFSMedium {
 					jobTaskSpec.Properties.Cache.NFSProperties.Subpath = commonutil.RenderEnv(jobTaskSpec.Properties.Cache.NFSProperties.Subpath, jobTaskSpec.Properties.Envs)
 				} else if jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
@@ -480,7 +502,7 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 			}
 		}
 
-		// for other job refer current latest image.
+		// for other job refer current latest image, jobs referring to a matrix build get the image of its last combination.
 		build.Image = job.GetJobOutputKey(jobTask.Key, "IMAGE")
 		build.Package = job.GetJobOutputKey(jobTask.Key, "PKG_FILE")
 		log.Infof("BuildJob ToJobs %d: workflow %s service %s, module %s, image %s, package %s",
@@ -503,7 +525,7 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 		jobTaskSpec.Steps = append(jobTaskSpec.Steps, toolInstallStep)
 
 		// init download object cache step
-		if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium && !shouldSkipObjectCacheRestore(jobTaskSpec) {
+		if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium && !shouldSkipObjectCacheRestore(jobTaskSpec) && !useNamedCache(jobTaskSpec) {
 			cacheDir := "/workspace"
 			if jobTaskSpec.Properties.CacheDirType == types.UserDefinedCacheDir {
 				cacheDir = jobTaskSpec.Properties.CacheUserDir
@@ -554,6 +576,14 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 		}
 
 		jobTaskSpec.Steps = append(jobTaskSpec.Steps, p4Step)
+
+		// init named cache steps, the cache keys may depend on the files checked out above
+		var saveCacheStep *commonmodels.StepTask
+		if useNamedCache(jobTaskSpec) {
+			var restoreCacheStep *commonmodels.StepTask
+			restoreCacheStep, saveCacheStep = buildNamedCacheSteps(jobTaskSpec, cacheS3, getBuildJobNamedCacheDir(j.workflow.Project, build.ServiceName, build.ServiceModule), build.ServiceName, jobTask.Name, jobTask.Key)
+			jobTaskSpec.Steps = append(jobTaskSpec.Steps, restoreCacheStep)
+		}
 		// init debug before step
 		debugBeforeStep := &commonmodels.StepTask{
 			Name:     build.ServiceName + "-debug_before",
@@ -635,10 +665,17 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 				},
 			}
 			jobTaskSpec.Steps = append(jobTaskSpec.Steps, dockerBuildStep)
+
+			// sbom generation and image signing are only supported by the job executor in kubernetes
+			if buildInfo.PostBuild.SupplyChain != nil && jobTask.Infrastructure != setting.JobVMInfrastructure {
+				jobTaskSpec.Steps = append(jobTaskSpec.Steps, buildSupplyChainSteps(buildInfo.PostBuild.SupplyChain, build, image, jobTask.Name, j.workflow.Name, taskID, registry, defaultS3)...)
+			}
 		}
 
 		// init object cache step
-		if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
+		if saveCacheStep != nil {
+			jobTaskSpec.Steps = append(jobTaskSpec.Steps, saveCacheStep)
+		} else if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
 			cacheDir := "/workspace"
 			if jobTaskSpec.Properties.CacheDirType == types.UserDefinedCacheDir {
 				cacheDir = jobTaskSpec.Properties.CacheUserDir
@@ -743,6 +780,11 @@ func (j BuildJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error
 	return resp, nil
 }
 
+type buildMatrixTarget struct {
+	build       *commonmodels.ServiceAndBuild
+	combination *jobMatrixCombination
+}
+
 func (j BuildJobController) SetRepo(repo *types.Repository) error {
 	for _, build := range j.jobSpec.ServiceAndBuilds {
 		build.Repos = applyRepos(build.Repos, []*types.Repository{repo})
@@ -1387,6 +1429,11 @@ func getBuildJobCacheObjectPath(workflowName, serviceName, serviceModule string)
 	return fmt.Sprintf("%s/cache/%s/%s", workflowName, serviceName, serviceModule)
 }
 
+// getBuildJobNamedCacheDir is shared by all workflows of the project so that a build reuses the caches of the others
+func getBuildJobNamedCacheDir(project, serviceName, serviceModule string) string {
+	return fmt.Sprintf("%s/named-cache/build/%s/%s", project, serviceName, serviceModule)
+}
+
 func getBuildJobVariables(build *commonmodels.ServiceAndBuild, taskID int64, project, workflowName, workflowDisplayName, image, pkgFile, infrastructure string, registry *commonmodels.RegistryNamespace, log *zap.SugaredLogger) []*commonmodels.KeyVal {
 	ret := make([]*commonmodels.KeyVal, 0)
 	// basic envs
@@ -1438,3 +1485,71 @@ func ensureBuiltInBuildOutputs(outputs []*commonmodels.Output) []*commonmodels.O
 	}
 	return outputs
 }
+
+func buildSupplyChainSteps(supplyChain *commonmodels.SupplyChain, build *commonmodels.ServiceAndBuild, image, jobTaskName, workflowName string, taskID int64, registry *commonmodels.RegistryNamespace, defaultS3 *commonmodels.S3Storage) []*commonmodels.StepTask {
+	resp := make([]*commonmodels.StepTask, 0)
+	dockerRegistry := &step.DockerRegistry{
+		Host:      registry.RegAddr,
+		UserName:  registry.AccessKey,
+		Password:  registry.SecretKey,
+		Namespace: registry.Namespace,
+	}
+
+	sbomSpec := &step.StepSBOMSpec{}
+	if supplyChain.SBOM != nil && supplyChain.SBOM.Enabled {
+		sbomSpec = &step.StepSBOMSpec{
+			Image:          image,
+			Format:         supplyChain.SBOM.Format,
+			ScanSource:     supplyChain.SBOM.ScanSource,
+			OutputDir:      setting.SBOMOutputDir,
+			DockerRegistry: dockerRegistry,
+		}
+		resp = append(resp, &commonmodels.StepTask{
+			Name:     build.ServiceName + "-sbom",
+			JobName:  jobTaskName,
+			StepType: config.StepSBOM,
+			Spec:     sbomSpec,
+		})
+		// the generated sboms are saved as the artifacts of the task
+		resp = append(resp, &commonmodels.StepTask{
+			Name:     build.ServiceName + "-sbom-archive",
+			JobName:  jobTaskName,
+			StepType: config.StepArchive,
+			Spec: step.StepArchiveSpec{
+				UploadDetail: []*step.Upload{
+					{
+						Name:            setting.SBOMOutputDir,
+						ServiceName:     build.ServiceName,
+						ServiceModule:   build.ServiceModule,
+						JobTaskName:     jobTaskName,
+						FilePath:        setting.SBOMOutputDir,
+						DestinationPath: path.Join(workflowName, fmt.Sprint(taskID), jobTaskName, "sbom"),
+					},
+				},
+				S3: modelToS3StepSpec(defaultS3),
+			},
+		})
+	}
+
+	if supplyChain.Signing != nil && supplyChain.Signing.Enabled {
+		signSpec := &step.StepImageSignSpec{
+			Image:          image,
+			KeyRef:         supplyChain.Signing.KeyRef,
+			TlogUpload:     supplyChain.Signing.TlogUpload,
+			DockerRegistry: dockerRegistry,
+		}
+		if supplyChain.Signing.AttachSBOM && sbomSpec.OutputDir != "" {
+			signSpec.Attestations = append(signSpec.Attestations, &step.ImageAttestation{
+				PredicateFile: path.Join(sbomSpec.OutputDir, sbomSpec.ImageSBOMFile()),
+				PredicateType: sbomSpec.AttestationPredicateType(),
+			})
+		}
+		resp = append(resp, &commonmodels.StepTask{
+			Name:     build.ServiceName + "-image-sign",
+			JobName:  jobTaskName,
+			StepType: config.StepImageSign,
+			Spec:     signSpec,
+		})
+	}
+	return resp
+}
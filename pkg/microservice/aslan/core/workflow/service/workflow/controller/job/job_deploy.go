@@ -30,6 +30,7 @@ import (
 	templaterepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb/template"
 	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/kube"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/registry"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/repository"
 	commontypes "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/types"
 	aslanUtil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
@@ -90,6 +91,12 @@ func (j DeployJobController) Validate(isExecution bool) error {
 		}
 	}
 
+	if verification := j.jobSpec.ImageSignatureVerification; verification != nil && verification.Enabled {
+		if _, err := registry.ParsePublicKey(verification.PublicKey); err != nil {
+			return fmt.Errorf("invalid public key for image signature verification in job %s: %v", j.name, err)
+		}
+	}
+
 	if j.jobSpec.Source != config.SourceFromJob {
 		return nil
 	}
@@ -495,17 +502,18 @@ func (j DeployJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, erro
 		for jobSubTaskID, svc := range j.jobSpec.Services {
 			serviceName := svc.ServiceName
 			jobTaskSpec := &commonmodels.JobTaskDeploySpec{
-				Env:                j.jobSpec.Env,
-				SkipCheckRunStatus: j.jobSpec.SkipCheckRunStatus,
-				ServiceName:        serviceName,
-				ServiceType:        setting.K8SDeployType,
-				CreateEnvType:      project.ProductFeature.CreateEnvType,
-				ClusterID:          product.ClusterID,
-				Production:         j.jobSpec.Production,
-				VersionName:        j.jobSpec.VersionName,
-				DeployContents:     j.jobSpec.DeployContents,
-				Timeout:            timeout,
-				OverrideResource:   svc.YAMLMergeStrategy == config.YAMLMergeStrategyOverride,
+				Env:                        j.jobSpec.Env,
+				SkipCheckRunStatus:         j.jobSpec.SkipCheckRunStatus,
+				ServiceName:                serviceName,
+				ServiceType:                setting.K8SDeployType,
+				CreateEnvType:              project.ProductFeature.CreateEnvType,
+				ClusterID:                  product.ClusterID,
+				Production:                 j.jobSpec.Production,
+				VersionName:                j.jobSpec.VersionName,
+				DeployContents:             j.jobSpec.DeployContents,
+				Timeout:                    timeout,
+				OverrideResource:           svc.YAMLMergeStrategy == config.YAMLMergeStrategyOverride,
+				ImageSignatureVerification: j.jobSpec.ImageSignatureVerification,
 			}
 
 			if len(jobTaskSpec.DeployContents) == 1 && slices.Contains(jobTaskSpec.DeployContents, config.DeployImage) &&
@@ -692,6 +700,7 @@ func (j DeployJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, erro
 				IsProduction:                 j.jobSpec.Production,
 				ValueMergeStrategy:           svc.ValueMergeStrategy,
 				MaxHistory:                   templateProduct.ReleaseMaxHistory,
+				ImageSignatureVerification:   j.jobSpec.ImageSignatureVerification,
 			}
 
 			for _, module := range svc.Modules {
@@ -95,7 +95,7 @@ func (j FreestyleJobController) Validate(isExecution bool) error {
 		return err
 	}
 
-	return nil
+	return validateJobMatrix(j.jobSpec.Matrix)
 }
 
 func (j FreestyleJobController) Update(useUserInput bool, ticket *commonmodels.ApprovalTicket) error {
@@ -118,6 +118,7 @@ func (j FreestyleJobController) Update(useUserInput bool, ticket *commonmodels.A
 	j.jobSpec.JobName = currJobSpec.JobName
 	j.jobSpec.ObjectStorageUpload = currJobSpec.ObjectStorageUpload
 	j.jobSpec.DefaultServices = currJobSpec.DefaultServices
+	j.jobSpec.Matrix = currJobSpec.Matrix
 	if useUserInput {
 		j.jobSpec.Repos = applyRepos(currJobSpec.Repos, j.jobSpec.Repos)
 		j.jobSpec.Envs = applyKeyVals(currJobSpec.Envs, j.jobSpec.Envs, false)
@@ -187,7 +188,7 @@ func (j FreestyleJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, e
 		resp = append(resp, task)
 	}
 
-	return resp, nil
+	return expandJobMatrix(resp, j.jobSpec.Matrix)
 }
 
 func (j FreestyleJobController) SetRepo(repo *types.Repository) error {
@@ -109,6 +109,11 @@ func validateNotificationJobDynamicRecipients(spec *commonmodels.NotificationJob
 			return nil
 		}
 		return validate("", spec.MSTeamsNotificationConfig.DynamicRecipients)
+	case setting.NotifyWebHookTypeSlack:
+		if spec.SlackNotificationConfig == nil {
+			return nil
+		}
+		return validate(spec.SlackNotificationConfig.AppID, spec.SlackNotificationConfig.DynamicRecipients)
 	case setting.NotifyWebHookTypeMail:
 		if spec.MailNotificationConfig == nil {
 			return nil
@@ -163,6 +168,11 @@ func (j NotificationJobController) Update(useUserInput bool, ticket *commonmodel
 			currJobSpec.MSTeamsNotificationConfig.AtEmails = j.jobSpec.MSTeamsNotificationConfig.AtEmails
 			currJobSpec.MSTeamsNotificationConfig.DynamicRecipients = j.jobSpec.MSTeamsNotificationConfig.DynamicRecipients
 		}
+		if currJobSpec.SlackNotificationConfig != nil && j.jobSpec.SlackNotificationConfig != nil {
+			currJobSpec.SlackNotificationConfig.AtUsers = j.jobSpec.SlackNotificationConfig.AtUsers
+			currJobSpec.SlackNotificationConfig.DynamicRecipients = j.jobSpec.SlackNotificationConfig.DynamicRecipients
+			currJobSpec.SlackNotificationConfig.IsAtAll = j.jobSpec.SlackNotificationConfig.IsAtAll
+		}
 		if currJobSpec.MailNotificationConfig != nil && j.jobSpec.MailNotificationConfig != nil {
 			currJobSpec.MailNotificationConfig.TargetUsers = j.jobSpec.MailNotificationConfig.TargetUsers
 			currJobSpec.MailNotificationConfig.DynamicRecipients = j.jobSpec.MailNotificationConfig.DynamicRecipients
@@ -176,6 +186,7 @@ func (j NotificationJobController) Update(useUserInput bool, ticket *commonmodel
 	j.jobSpec.WechatNotificationConfig = currJobSpec.WechatNotificationConfig
 	j.jobSpec.DingDingNotificationConfig = currJobSpec.DingDingNotificationConfig
 	j.jobSpec.MSTeamsNotificationConfig = currJobSpec.MSTeamsNotificationConfig
+	j.jobSpec.SlackNotificationConfig = currJobSpec.SlackNotificationConfig
 	j.jobSpec.MailNotificationConfig = currJobSpec.MailNotificationConfig
 	j.jobSpec.WebhookNotificationConfig = currJobSpec.WebhookNotificationConfig
 
@@ -286,6 +297,7 @@ func generateNotificationJobSpec(spec *commonmodels.NotificationJobSpec) (*commo
 	resp.LarkGroupNotificationConfig = spec.LarkGroupNotificationConfig
 	resp.DingDingNotificationConfig = spec.DingDingNotificationConfig
 	resp.MSTeamsNotificationConfig = spec.MSTeamsNotificationConfig
+	resp.SlackNotificationConfig = spec.SlackNotificationConfig
 	resp.WebhookNotificationConfig = spec.WebhookNotificationConfig
 
 	return resp, nil
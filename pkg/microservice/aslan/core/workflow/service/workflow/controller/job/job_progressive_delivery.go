@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
+	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type ProgressiveDeliveryJobController struct {
+	*BasicInfo
+
+	jobSpec *commonmodels.ProgressiveDeliveryJobSpec
+}
+
+func CreateProgressiveDeliveryJobController(job *commonmodels.Job, workflow *commonmodels.WorkflowV4) (Job, error) {
+	spec := new(commonmodels.ProgressiveDeliveryJobSpec)
+	if err := commonmodels.IToi(job.Spec, spec); err != nil {
+		return nil, fmt.Errorf("failed to create progressive delivery job controller, error: %s", err)
+	}
+
+	basicInfo := &BasicInfo{
+		name:          job.Name,
+		jobType:       job.JobType,
+		errorPolicy:   job.ErrorPolicy,
+		executePolicy: job.ExecutePolicy,
+		workflow:      workflow,
+	}
+
+	return ProgressiveDeliveryJobController{
+		BasicInfo: basicInfo,
+		jobSpec:   spec,
+	}, nil
+}
+
+func (j ProgressiveDeliveryJobController) SetWorkflow(wf *commonmodels.WorkflowV4) {
+	j.workflow = wf
+}
+
+func (j ProgressiveDeliveryJobController) GetSpec() interface{} {
+	return j.jobSpec
+}
+
+func (j ProgressiveDeliveryJobController) Validate(isExecution bool) error {
+	if err := util.CheckZadigProfessionalLicense(); err != nil {
+		return e.ErrLicenseInvalid.AddDesc("")
+	}
+
+	if len(j.jobSpec.Steps) == 0 {
+		return fmt.Errorf("[%s] at least one step is required", j.name)
+	}
+	lastWeight := 0
+	for i, step := range j.jobSpec.Steps {
+		if step.Weight < 0 || step.Weight > 100 {
+			return fmt.Errorf("[%s] step %d: weight must be between 0 and 100", j.name, i+1)
+		}
+		if step.Weight < lastWeight {
+			return fmt.Errorf("[%s] step %d: weight cannot be less than the previous step", j.name, i+1)
+		}
+		if step.Weight == 100 && i != len(j.jobSpec.Steps)-1 {
+			return fmt.Errorf("[%s] step %d: only the last step can shift all the replicas", j.name, i+1)
+		}
+		lastWeight = step.Weight
+		if step.Pause < 0 {
+			return fmt.Errorf("[%s] step %d: pause cannot be negative", j.name, i+1)
+		}
+		for _, analysis := range step.Analyses {
+			if err := validateProgressiveAnalysis(analysis); err != nil {
+				return fmt.Errorf("[%s] step %d: analysis %s: %v", j.name, i+1, analysis.Name, err)
+			}
+		}
+	}
+
+	if isExecution {
+		for _, target := range j.jobSpec.Targets {
+			if target.Image == "" {
+				return fmt.Errorf("[%s] image of workload %s cannot be empty", j.name, target.WorkloadName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateProgressiveAnalysis(analysis *commonmodels.ProgressiveAnalysis) error {
+	if analysis.ID == "" {
+		return fmt.Errorf("observability integration is required")
+	}
+	switch analysis.Provider {
+	case config.ObservabilityTypeGrafana:
+		if analysis.DataSourceUID == "" {
+			return fmt.Errorf("grafana data source is required")
+		}
+		if analysis.Query == "" {
+			return fmt.Errorf("query is required")
+		}
+	case config.ObservabilityTypePrometheus:
+		if analysis.Query == "" {
+			return fmt.Errorf("query is required")
+		}
+	case config.ObservabilityTypeGuanceyun:
+		if len(analysis.Monitors) == 0 {
+			return fmt.Errorf("at least one monitor is required")
+		}
+	default:
+		return fmt.Errorf("unsupported provider: %s", analysis.Provider)
+	}
+
+	switch analysis.Condition {
+	case config.AnalysisConditionLessThan, config.AnalysisConditionLessOrEqual, config.AnalysisConditionGreaterThan,
+		config.AnalysisConditionGreaterOrEqual, config.AnalysisConditionEqual:
+	default:
+		return fmt.Errorf("invalid condition: %s", analysis.Condition)
+	}
+
+	if analysis.Interval < 0 || analysis.Count < 0 || analysis.FailureLimit < 0 {
+		return fmt.Errorf("interval, count and failure limit cannot be negative")
+	}
+	if analysis.Count > 0 && analysis.FailureLimit >= analysis.Count {
+		return fmt.Errorf("failure limit must be less than count")
+	}
+	return nil
+}
+
+func (j ProgressiveDeliveryJobController) Update(useUserInput bool, ticket *commonmodels.ApprovalTicket) error {
+	currJob, err := j.workflow.FindJob(j.name, j.jobType)
+	if err != nil {
+		return err
+	}
+
+	currJobSpec := new(commonmodels.ProgressiveDeliveryJobSpec)
+	if err := commonmodels.IToi(currJob.Spec, currJobSpec); err != nil {
+		return fmt.Errorf("failed to decode progressive delivery job spec, error: %s", err)
+	}
+	j.errorPolicy = currJob.ErrorPolicy
+	j.executePolicy = currJob.ExecutePolicy
+
+	j.jobSpec.ClusterID = currJobSpec.ClusterID
+	j.jobSpec.Namespace = currJobSpec.Namespace
+	j.jobSpec.DockerRegistryID = currJobSpec.DockerRegistryID
+	j.jobSpec.DeployTimeout = currJobSpec.DeployTimeout
+	j.jobSpec.Steps = currJobSpec.Steps
+	j.jobSpec.TargetOptions = currJobSpec.TargetOptions
+	return nil
+}
+
+func (j ProgressiveDeliveryJobController) SetOptions(ticket *commonmodels.ApprovalTicket) error {
+	return nil
+}
+
+func (j ProgressiveDeliveryJobController) ClearOptions() {
+	return
+}
+
+func (j ProgressiveDeliveryJobController) ClearSelection() {
+	j.jobSpec.Targets = make([]*commonmodels.GrayReleaseTarget, 0)
+	return
+}
+
+func (j ProgressiveDeliveryJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error) {
+	resp := make([]*commonmodels.JobTask, 0)
+
+	kubeClient, err := clientmanager.NewKubeClientManager().GetControllerRuntimeClient(j.jobSpec.ClusterID)
+	if err != nil {
+		return resp, fmt.Errorf("failed to get kube client, err: %v", err)
+	}
+	cluster, err := commonrepo.NewK8SClusterColl().Get(j.jobSpec.ClusterID)
+	if err != nil {
+		return resp, fmt.Errorf("cluster id: %s not found", j.jobSpec.ClusterID)
+	}
+
+	for _, target := range j.jobSpec.Targets {
+		deployment, found, err := getter.GetDeployment(j.jobSpec.Namespace, target.WorkloadName, kubeClient)
+		if err != nil || !found {
+			return resp, fmt.Errorf("deployment %s not found in namespace: %s", target.WorkloadName, j.jobSpec.Namespace)
+		}
+		target.Replica = int(*deployment.Spec.Replicas)
+
+		jobTask := &commonmodels.JobTask{
+			Name:        GenJobName(j.workflow, j.name, 0),
+			Key:         genJobKey(j.name, target.WorkloadName),
+			DisplayName: genJobDisplayName(j.name, target.WorkloadName),
+			OriginName:  j.name,
+			JobInfo: map[string]string{
+				JobNameKey:      j.name,
+				"workload_name": target.WorkloadName,
+			},
+			JobType: string(config.JobProgressiveDelivery),
+			Spec: &commonmodels.JobTaskProgressiveDeliverySpec{
+				ClusterID:          j.jobSpec.ClusterID,
+				ClusterName:        cluster.Name,
+				Namespace:          j.jobSpec.Namespace,
+				WorkloadType:       target.WorkloadType,
+				WorkloadName:       target.WorkloadName,
+				ContainerName:      target.ContainerName,
+				Image:              target.Image,
+				CanaryWorkloadName: target.WorkloadName + config.ProgressiveDeploymentSuffix,
+				TotalReplica:       target.Replica,
+				DeployTimeout:      j.jobSpec.DeployTimeout,
+				Steps:              j.jobSpec.Steps,
+			},
+			ErrorPolicy:   j.errorPolicy,
+			ExecutePolicy: j.executePolicy,
+		}
+		resp = append(resp, jobTask)
+	}
+	return resp, nil
+}
+
+func (j ProgressiveDeliveryJobController) SetRepo(repo *types.Repository) error {
+	return nil
+}
+
+func (j ProgressiveDeliveryJobController) SetRepoCommitInfo() error {
+	return nil
+}
+
+func (j ProgressiveDeliveryJobController) GetVariableList(jobName string, getAggregatedVariables, getRuntimeVariables, getPlaceHolderVariables, getServiceSpecificVariables, useUserInputValue bool) ([]*commonmodels.KeyVal, error) {
+	resp := make([]*commonmodels.KeyVal, 0)
+	if getRuntimeVariables {
+		resp = append(resp, &commonmodels.KeyVal{
+			Key:          strings.Join([]string{"job", j.name, "status"}, "."),
+			Value:        "",
+			Type:         "string",
+			IsCredential: false,
+		})
+	}
+	return resp, nil
+}
+
+func (j ProgressiveDeliveryJobController) GetUsedRepos() ([]*types.Repository, error) {
+	return make([]*types.Repository, 0), nil
+}
+
+func (j ProgressiveDeliveryJobController) RenderDynamicVariableOptions(key string, option *RenderDynamicVariableValue) ([]string, error) {
+	return nil, fmt.Errorf("invalid job type: %s to render dynamic variable", j.name)
+}
+
+func (j ProgressiveDeliveryJobController) IsServiceTypeJob() bool {
+	return false
+}
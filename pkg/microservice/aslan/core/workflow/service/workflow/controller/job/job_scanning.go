@@ -37,6 +37,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/tool/sonar"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 )
@@ -881,6 +882,12 @@ func (j ScanningJobController) toJobTask(jobSubTaskID int, scanning *commonmodel
 			}
 			jobTaskSpec.Steps = append(jobTaskSpec.Steps, sonarChekStep)
 		}
+	} else if scanningInfo.ScannerType == types.ScannerTypeImageVuln {
+		vulnScanSteps, err := j.imageVulnScanSteps(scanningInfo.ImageScanSetting, scanning.Name, jobTask, taskID, logger)
+		if err != nil {
+			return nil, err
+		}
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, vulnScanSteps...)
 	} else {
 		scriptStep := &commonmodels.StepTask{
 			JobName: jobTask.Name,
@@ -1350,6 +1357,74 @@ func (j ScanningJobController) getReferredJobTargets(jobName string) ([]*commonm
 	return nil, fmt.Errorf("ScanningJob: refered job %s not found", jobName)
 }
 
+// imageVulnScanSteps scans the image and uploads the reports, the reports are uploaded even if the thresholds are exceeded
+func (j ScanningJobController) imageVulnScanSteps(scanSetting *commonmodels.ImageScanSetting, scanningName string, jobTask *commonmodels.JobTask, taskID int64, logger *zap.SugaredLogger) ([]*commonmodels.StepTask, error) {
+	if scanSetting == nil || scanSetting.Image == "" {
+		return nil, fmt.Errorf("image to scan is not configured in scanning: %s", scanningName)
+	}
+	if jobTask.Infrastructure == setting.JobVMInfrastructure {
+		return nil, fmt.Errorf("image vulnerability scanning: %s does not support vm infrastructure", scanningName)
+	}
+
+	defaultS3, err := commonrepo.NewS3StorageColl().FindDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default object storage, error: %v", err)
+	}
+
+	reportPath := path.Join(j.workflow.Name, fmt.Sprint(taskID), jobTask.Name, "vuln-scan")
+	scanSpec := &step.StepImageVulnScanSpec{
+		Image:          scanSetting.Image,
+		DBRepository:   scanSetting.DBRepository,
+		SkipDBUpdate:   scanSetting.SkipDBUpdate,
+		IgnoreUnfixed:  scanSetting.IgnoreUnfixed,
+		Thresholds:     scanSetting.Thresholds,
+		OutputDir:      setting.ImageVulnScanOutputDir,
+		SARIFObjectKey: path.Join(reportPath, trivy.ReportSARIFFile),
+	}
+	if scanSetting.RegistryID != "" {
+		registry, err := commonservice.FindRegistryById(scanSetting.RegistryID, true, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find registry: %s, error: %v", scanSetting.RegistryID, err)
+		}
+		scanSpec.DockerRegistry = &step.DockerRegistry{
+			DockerRegistryID: scanSetting.RegistryID,
+			Host:             registry.RegAddr,
+			UserName:         registry.AccessKey,
+			Password:         registry.SecretKey,
+			Namespace:        registry.Namespace,
+		}
+	}
+
+	return []*commonmodels.StepTask{
+		{
+			Name:     scanningName + "-image-vuln-scan",
+			JobName:  jobTask.Name,
+			JobKey:   jobTask.Key,
+			StepType: config.StepImageVulnScan,
+			Spec:     scanSpec,
+		},
+		{
+			Name:      scanningName + "-image-vuln-report-archive",
+			JobName:   jobTask.Name,
+			StepType:  config.StepArchive,
+			Onfailure: true,
+			Spec: step.StepArchiveSpec{
+				UploadDetail: []*step.Upload{
+					{
+						FilePath:        path.Join(setting.ImageVulnScanOutputDir, trivy.ReportJSONFile),
+						DestinationPath: reportPath,
+					},
+					{
+						FilePath:        path.Join(setting.ImageVulnScanOutputDir, trivy.ReportSARIFFile),
+						DestinationPath: reportPath,
+					},
+				},
+				S3: modelToS3StepSpec(defaultS3),
+			},
+		},
+	}, nil
+}
+
 func fillScanningDetail(moduleScanning *commonmodels.Scanning) error {
 	if moduleScanning.TemplateID == "" {
 		return nil
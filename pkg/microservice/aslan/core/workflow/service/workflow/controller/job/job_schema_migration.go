@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/database"
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+type SchemaMigrationJobController struct {
+	*BasicInfo
+
+	jobSpec *commonmodels.SchemaMigrationJobSpec
+}
+
+func CreateSchemaMigrationJobController(job *commonmodels.Job, workflow *commonmodels.WorkflowV4) (Job, error) {
+	spec := new(commonmodels.SchemaMigrationJobSpec)
+	if err := commonmodels.IToi(job.Spec, spec); err != nil {
+		return nil, fmt.Errorf("failed to create schema migration job controller, error: %s", err)
+	}
+
+	basicInfo := &BasicInfo{
+		name:          job.Name,
+		jobType:       job.JobType,
+		errorPolicy:   job.ErrorPolicy,
+		executePolicy: job.ExecutePolicy,
+		workflow:      workflow,
+	}
+
+	return SchemaMigrationJobController{
+		BasicInfo: basicInfo,
+		jobSpec:   spec,
+	}, nil
+}
+
+func (j SchemaMigrationJobController) SetWorkflow(wf *commonmodels.WorkflowV4) {
+	j.workflow = wf
+}
+
+func (j SchemaMigrationJobController) GetSpec() interface{} {
+	return j.jobSpec
+}
+
+func (j SchemaMigrationJobController) Validate(isExecution bool) error {
+	info, err := mongodb.NewDBInstanceColl().Find(&mongodb.DBInstanceCollFindOption{Id: j.jobSpec.ID})
+	if err != nil {
+		return fmt.Errorf("not found db instance in mongo, err: %v", err)
+	}
+
+	if j.jobSpec.Repo == nil || j.jobSpec.Repo.RepoName == "" {
+		return fmt.Errorf("migration repository cannot be empty")
+	}
+
+	switch j.jobSpec.Action {
+	case config.SchemaMigrationActionUp, config.SchemaMigrationActionDown, config.SchemaMigrationActionInfo:
+	default:
+		return fmt.Errorf("invalid schema migration action: %s", j.jobSpec.Action)
+	}
+
+	historyTable := j.jobSpec.HistoryTable
+	if historyTable == "" {
+		historyTable = database.DefaultMigrationHistoryTable
+	}
+	if err := database.ValidateHistoryTable(historyTable); err != nil {
+		return err
+	}
+	// without a database in the db instance an unqualified history table cannot be resolved, e.g. mysql fails with
+	// "no database selected" only when the job runs
+	if info.Database == "" && !strings.Contains(historyTable, ".") {
+		return fmt.Errorf("db instance %s has no database, the migration history table must be qualified with a schema, e.g. <schema>.%s", info.Name, database.DefaultMigrationHistoryTable)
+	}
+
+	if j.jobSpec.StatementTimeout < 0 {
+		return fmt.Errorf("statement timeout cannot be negative")
+	}
+
+	return nil
+}
+
+func (j SchemaMigrationJobController) Update(useUserInput bool, ticket *commonmodels.ApprovalTicket) error {
+	currJob, err := j.workflow.FindJob(j.name, j.jobType)
+	if err != nil {
+		return err
+	}
+
+	currJobSpec := new(commonmodels.SchemaMigrationJobSpec)
+	if err := commonmodels.IToi(currJob.Spec, currJobSpec); err != nil {
+		return fmt.Errorf("failed to decode schema migration job spec, error: %s", err)
+	}
+	j.errorPolicy = currJob.ErrorPolicy
+	j.executePolicy = currJob.ExecutePolicy
+
+	if j.jobSpec.Source == "fixed" {
+		j.jobSpec.ID = currJobSpec.ID
+		j.jobSpec.Type = currJobSpec.Type
+	}
+	j.jobSpec.Source = currJobSpec.Source
+
+	// only the branch of the migration repository and the target version can be changed at runtime
+	if useUserInput && j.jobSpec.Repo != nil && currJobSpec.Repo != nil {
+		repos := applyRepos([]*types.Repository{currJobSpec.Repo}, []*types.Repository{j.jobSpec.Repo})
+		j.jobSpec.Repo = repos[0]
+	} else {
+		j.jobSpec.Repo = currJobSpec.Repo
+		j.jobSpec.TargetVersion = currJobSpec.TargetVersion
+	}
+	j.jobSpec.Path = currJobSpec.Path
+	j.jobSpec.HistoryTable = currJobSpec.HistoryTable
+	j.jobSpec.Action = currJobSpec.Action
+	j.jobSpec.IgnoreDrift = currJobSpec.IgnoreDrift
+	j.jobSpec.StatementTimeout = currJobSpec.StatementTimeout
+
+	return nil
+}
+
+func (j SchemaMigrationJobController) SetOptions(ticket *commonmodels.ApprovalTicket) error {
+	return nil
+}
+
+func (j SchemaMigrationJobController) ClearOptions() {
+	return
+}
+
+func (j SchemaMigrationJobController) ClearSelection() {
+	return
+}
+
+func (j SchemaMigrationJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error) {
+	resp := make([]*commonmodels.JobTask, 0)
+
+	historyTable := j.jobSpec.HistoryTable
+	if historyTable == "" {
+		historyTable = database.DefaultMigrationHistoryTable
+	}
+
+	jobTask := &commonmodels.JobTask{
+		Key:         genJobKey(j.name),
+		DisplayName: genJobDisplayName(j.name),
+		Name:        GenJobName(j.workflow, j.name, 0),
+		OriginName:  j.name,
+		JobInfo: map[string]string{
+			JobNameKey: j.name,
+		},
+		JobType: string(config.JobSchemaMigration),
+		Spec: &commonmodels.JobTaskSchemaMigrationSpec{
+			ID:               j.jobSpec.ID,
+			Type:             j.jobSpec.Type,
+			Repo:             j.jobSpec.Repo,
+			Path:             j.jobSpec.Path,
+			HistoryTable:     historyTable,
+			Action:           j.jobSpec.Action,
+			TargetVersion:    j.jobSpec.TargetVersion,
+			IgnoreDrift:      j.jobSpec.IgnoreDrift,
+			StatementTimeout: j.jobSpec.StatementTimeout,
+		},
+		Outputs: []*commonmodels.Output{
+			{Name: config.SchemaMigrationOutputCurrentVersion, Description: "current schema version after the job"},
+			{Name: config.SchemaMigrationOutputPendingCount, Description: "number of pending migrations after the job"},
+		},
+		Timeout:       0,
+		ErrorPolicy:   j.errorPolicy,
+		ExecutePolicy: j.executePolicy,
+	}
+	resp = append(resp, jobTask)
+
+	return resp, nil
+}
+
+func (j SchemaMigrationJobController) SetRepo(repo *types.Repository) error {
+	if j.jobSpec.Repo == nil {
+		return nil
+	}
+	repos := applyRepos([]*types.Repository{j.jobSpec.Repo}, []*types.Repository{repo})
+	j.jobSpec.Repo = repos[0]
+	return nil
+}
+
+func (j SchemaMigrationJobController) SetRepoCommitInfo() error {
+	if j.jobSpec.Repo == nil {
+		return nil
+	}
+	return setRepoInfo([]*types.Repository{j.jobSpec.Repo})
+}
+
+func (j SchemaMigrationJobController) GetVariableList(jobName string, getAggregatedVariables, getRuntimeVariables, getPlaceHolderVariables, getServiceSpecificVariables, useUserInputValue bool) ([]*commonmodels.KeyVal, error) {
+	resp := make([]*commonmodels.KeyVal, 0)
+	if getRuntimeVariables {
+		resp = append(resp, &commonmodels.KeyVal{
+			Key:          strings.Join([]string{"job", j.name, "status"}, "."),
+			Value:        "",
+			Type:         "string",
+			IsCredential: false,
+		})
+		for _, output := range []string{config.SchemaMigrationOutputCurrentVersion, config.SchemaMigrationOutputPendingCount} {
+			resp = append(resp, &commonmodels.KeyVal{
+				Key:          strings.Join([]string{"job", j.name, "output", output}, "."),
+				Value:        "",
+				Type:         "string",
+				IsCredential: false,
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (j SchemaMigrationJobController) GetUsedRepos() ([]*types.Repository, error) {
+	resp := make([]*types.Repository, 0)
+	if j.jobSpec.Repo != nil {
+		resp = append(resp, j.jobSpec.Repo)
+	}
+	return resp, nil
+}
+
+func (j SchemaMigrationJobController) RenderDynamicVariableOptions(key string, option *RenderDynamicVariableValue) ([]string, error) {
+	return nil, fmt.Errorf("invalid job type: %s to render dynamic variable", j.name)
+}
+
+func (j SchemaMigrationJobController) IsServiceTypeJob() bool {
+	return false
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package job
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	runtimeJobController "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/workflowcontroller/jobcontroller"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
@@ -71,6 +74,10 @@ func (j SQLJobController) Validate(isExecution bool) error {
 		}
 	}
 
+	if j.jobSpec.StatementTimeout < 0 {
+		return fmt.Errorf("statement timeout cannot be negative")
+	}
+
 	return nil
 }
 
@@ -92,6 +99,8 @@ func (j SQLJobController) Update(useUserInput bool, ticket *commonmodels.Approva
 		j.jobSpec.Type = currJobSpec.Type
 	}
 	j.jobSpec.Source = currJobSpec.Source
+	j.jobSpec.Transactional = currJobSpec.Transactional
+	j.jobSpec.StatementTimeout = currJobSpec.StatementTimeout
 
 	return nil
 }
@@ -111,6 +120,21 @@ func (j SQLJobController) ClearSelection() {
 func (j SQLJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error) {
 	resp := make([]*commonmodels.JobTask, 0)
 
+	taskSpec := &commonmodels.JobTaskSQLSpec{
+		ID:               j.jobSpec.ID,
+		Type:             j.jobSpec.Type,
+		SQL:              j.jobSpec.SQL,
+		Transactional:    j.jobSpec.Transactional,
+		StatementTimeout: j.jobSpec.StatementTimeout,
+	}
+	// explain the statements when the task is created so that the plans can be reviewed before the job is approved,
+	// the job explains them again right before execution.
+	if info, err := mongodb.NewDBInstanceColl().Find(&mongodb.DBInstanceCollFindOption{Id: j.jobSpec.ID}); err != nil {
+		log.Warnf("failed to find db instance %s, error: %s", j.jobSpec.ID, err)
+	} else if err := runtimeJobController.ExplainSQLStatements(context.Background(), info, taskSpec); err != nil {
+		log.Warnf("failed to explain sql of job %s, error: %s", j.name, err)
+	}
+
 	jobTask := &commonmodels.JobTask{
 		Key:         genJobKey(j.name),
 		DisplayName: genJobDisplayName(j.name),
@@ -119,12 +143,8 @@ func (j SQLJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error)
 		JobInfo: map[string]string{
 			JobNameKey: j.name,
 		},
-		JobType: string(config.JobSQL),
-		Spec: &commonmodels.JobTaskSQLSpec{
-			ID:   j.jobSpec.ID,
-			Type: j.jobSpec.Type,
-			SQL:  j.jobSpec.SQL,
-		},
+		JobType:       string(config.JobSQL),
+		Spec:          taskSpec,
 		Timeout:       0,
 		ErrorPolicy:   j.errorPolicy,
 		ExecutePolicy: j.executePolicy,
@@ -0,0 +1,466 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	commonservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service"
+	codehostrepo "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/codehost/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/terraform"
+	"github.com/koderover/zadig/v2/pkg/types"
+	steptypes "github.com/koderover/zadig/v2/pkg/types/step"
+)
+
+type TerraformJobController struct {
+	*BasicInfo
+
+	jobSpec *commonmodels.TerraformJobSpec
+}
+
+func CreateTerraformJobController(job *commonmodels.Job, workflow *commonmodels.WorkflowV4) (Job, error) {
+	spec := new(commonmodels.TerraformJobSpec)
+	if err := commonmodels.IToi(job.Spec, spec); err != nil {
+		return nil, fmt.Errorf("failed to create terraform job controller, error: %s", err)
+	}
+
+	basicInfo := &BasicInfo{
+		name:          job.Name,
+		jobType:       job.JobType,
+		errorPolicy:   job.ErrorPolicy,
+		executePolicy: job.ExecutePolicy,
+		workflow:      workflow,
+	}
+
+	return TerraformJobController{
+		BasicInfo: basicInfo,
+		jobSpec:   spec,
+	}, nil
+}
+
+func (j TerraformJobController) SetWorkflow(wf *commonmodels.WorkflowV4) {
+	j.workflow = wf
+}
+
+func (j TerraformJobController) GetSpec() interface{} {
+	return j.jobSpec
+}
+
+func (j TerraformJobController) Validate(isExecution bool) error {
+	if j.jobSpec.Repo == nil || j.jobSpec.Repo.RepoName == "" {
+		return fmt.Errorf("terraform repository cannot be empty")
+	}
+	if j.jobSpec.Runtime == nil || j.jobSpec.AdvancedSetting == nil {
+		return fmt.Errorf("terraform runtime cannot be empty")
+	}
+
+	switch j.jobSpec.Binary {
+	case "", terraform.BinaryTerraform, terraform.BinaryOpenTofu:
+	default:
+		return fmt.Errorf("invalid terraform binary: %s", j.jobSpec.Binary)
+	}
+
+	if j.jobSpec.Backend != nil {
+		switch j.jobSpec.Backend.Type {
+		case "", config.TerraformBackendDefault:
+		case config.TerraformBackendS3:
+			if j.jobSpec.Backend.ObjectStorageID != "" {
+				if _, err := commonrepo.NewS3StorageColl().Find(j.jobSpec.Backend.ObjectStorageID); err != nil {
+					return fmt.Errorf("failed to find object storage %s for the terraform state, error: %v", j.jobSpec.Backend.ObjectStorageID, err)
+				}
+			}
+		default:
+			return fmt.Errorf("invalid terraform backend type: %s", j.jobSpec.Backend.Type)
+		}
+	}
+
+	if j.jobSpec.Approval != nil && j.jobSpec.Approval.Enabled {
+		if j.jobSpec.Approval.ApprovalJobSpec == nil {
+			return fmt.Errorf("terraform approval cannot be empty")
+		}
+		if j.jobSpec.Approval.Source == config.SourceFromJob {
+			return fmt.Errorf("terraform approval cannot refer to other jobs")
+		}
+	}
+
+	if isExecution {
+		if err := ValidateRequiredRuntimeKeyVals(j.jobSpec.Variables, fmt.Sprintf("job %s", j.name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j TerraformJobController) Update(useUserInput bool, ticket *commonmodels.ApprovalTicket) error {
+	currJob, err := j.workflow.FindJob(j.name, j.jobType)
+	if err != nil {
+		return err
+	}
+
+	currJobSpec := new(commonmodels.TerraformJobSpec)
+	if err := commonmodels.IToi(currJob.Spec, currJobSpec); err != nil {
+		return fmt.Errorf("failed to decode terraform job spec, error: %s", err)
+	}
+	j.errorPolicy = currJob.ErrorPolicy
+	j.executePolicy = currJob.ExecutePolicy
+
+	j.jobSpec.WorkDir = currJobSpec.WorkDir
+	j.jobSpec.Binary = currJobSpec.Binary
+	j.jobSpec.Workspace = currJobSpec.Workspace
+	j.jobSpec.Destroy = currJobSpec.Destroy
+	j.jobSpec.Backend = currJobSpec.Backend
+	j.jobSpec.Approval = currJobSpec.Approval
+	j.jobSpec.Runtime = currJobSpec.Runtime
+	j.jobSpec.AdvancedSetting = currJobSpec.AdvancedSetting
+	// only the branch of the repository and the variables can be changed at runtime
+	if useUserInput && j.jobSpec.Repo != nil && currJobSpec.Repo != nil {
+		repos := applyRepos([]*types.Repository{currJobSpec.Repo}, []*types.Repository{j.jobSpec.Repo})
+		j.jobSpec.Repo = repos[0]
+		j.jobSpec.Variables = applyKeyVals(currJobSpec.Variables, j.jobSpec.Variables, false)
+	} else {
+		j.jobSpec.Repo = currJobSpec.Repo
+		j.jobSpec.Variables = currJobSpec.Variables
+	}
+
+	return nil
+}
+
+func (j TerraformJobController) SetOptions(ticket *commonmodels.ApprovalTicket) error {
+	return nil
+}
+
+func (j TerraformJobController) ClearOptions() {
+	return
+}
+
+func (j TerraformJobController) ClearSelection() {
+	return
+}
+
+func (j TerraformJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, error) {
+	resp := make([]*commonmodels.JobTask, 0)
+	jobName := GenJobName(j.workflow, j.name, 0)
+
+	defaultS3, err := commonrepo.NewS3StorageColl().FindDefault()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default object storage, error: %v", err)
+	}
+	artifactPath := path.Join(j.workflow.Name, fmt.Sprint(taskID), jobName, "terraform")
+
+	repoDir := j.jobSpec.Repo.RepoName
+	if j.jobSpec.Repo.CheckoutPath != "" {
+		repoDir = j.jobSpec.Repo.CheckoutPath
+	}
+	workDir := path.Join(repoDir, j.jobSpec.WorkDir)
+
+	scriptOption := &terraform.ScriptOption{
+		Binary:    j.jobSpec.Binary,
+		WorkDir:   workDir,
+		Workspace: j.jobSpec.Workspace,
+		Destroy:   j.jobSpec.Destroy,
+	}
+	envs, err := j.getEnvs(taskID, scriptOption)
+	if err != nil {
+		return nil, err
+	}
+
+	planSteps, err := j.getCommonSteps(jobName)
+	if err != nil {
+		return nil, err
+	}
+	planSteps = append(planSteps,
+		&commonmodels.StepTask{
+			Name:     stepNameShell,
+			JobName:  jobName,
+			StepType: config.StepShell,
+			Spec:     steptypes.StepShellSpec{Scripts: terraform.PlanScript(scriptOption)},
+		},
+		&commonmodels.StepTask{
+			Name:     "plan-archive",
+			JobName:  jobName,
+			StepType: config.StepArchive,
+			Spec: steptypes.StepArchiveSpec{
+				UploadDetail: []*steptypes.Upload{
+					{FilePath: path.Join(workDir, terraform.PlanFile), DestinationPath: artifactPath},
+					{FilePath: path.Join(workDir, terraform.PlanJSONFile), DestinationPath: artifactPath},
+				},
+				S3: modelToS3StepSpec(defaultS3),
+			},
+		},
+	)
+
+	applySteps, err := j.getCommonSteps(jobName)
+	if err != nil {
+		return nil, err
+	}
+	applySteps = append(applySteps,
+		&commonmodels.StepTask{
+			Name:     "plan-download",
+			JobName:  jobName,
+			StepType: config.StepDownloadArchive,
+			Spec: steptypes.StepDownloadArchiveSpec{
+				FileName:   terraform.PlanFile,
+				ObjectPath: path.Join(defaultS3.Subfolder, artifactPath),
+				DestDir:    workDir,
+				S3:         modelToS3StepSpec(defaultS3),
+			},
+		},
+		&commonmodels.StepTask{
+			Name:     stepNameShell,
+			JobName:  jobName,
+			StepType: config.StepShell,
+			Spec:     steptypes.StepShellSpec{Scripts: terraform.ApplyScript(scriptOption)},
+		},
+	)
+
+	planProperties, err := j.getProperties(taskID, envs)
+	if err != nil {
+		return nil, err
+	}
+	applyProperties, err := j.getProperties(taskID, envs)
+	if err != nil {
+		return nil, err
+	}
+
+	jobTaskSpec := &commonmodels.JobTaskTerraformSpec{
+		Binary:       scriptOption.Binary,
+		WorkDir:      j.jobSpec.WorkDir,
+		Workspace:    j.jobSpec.Workspace,
+		Destroy:      j.jobSpec.Destroy,
+		ArtifactPath: artifactPath,
+		Plan:         &commonmodels.JobTaskFreestyleSpec{Properties: *planProperties, Steps: planSteps},
+		Apply:        &commonmodels.JobTaskFreestyleSpec{Properties: *applyProperties, Steps: applySteps},
+	}
+	if jobTaskSpec.Binary == "" {
+		jobTaskSpec.Binary = terraform.BinaryTerraform
+	}
+
+	if j.jobSpec.Approval != nil && j.jobSpec.Approval.Enabled {
+		// reuse the approval job to validate the approval and flatten the approvers
+		approvalCtl := ApprovalJobController{
+			BasicInfo: j.BasicInfo,
+			jobSpec:   j.jobSpec.Approval.ApprovalJobSpec,
+		}
+		approvalTasks, err := approvalCtl.ToTask(taskID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid terraform approval: %v", err)
+		}
+		jobTaskSpec.Approval = approvalTasks[0].Spec.(*commonmodels.JobTaskApprovalSpec)
+	}
+
+	jobTask := &commonmodels.JobTask{
+		Key:         genJobKey(j.name),
+		DisplayName: genJobDisplayName(j.name),
+		Name:        jobName,
+		OriginName:  j.name,
+		JobInfo: map[string]string{
+			JobNameKey: j.name,
+		},
+		JobType: string(config.JobTerraform),
+		Spec:    jobTaskSpec,
+		Outputs: []*commonmodels.Output{
+			{Name: config.TerraformOutputHasChanges, Description: "whether the plan has changes"},
+			{Name: config.TerraformOutputAdd, Description: "number of resources to add"},
+			{Name: config.TerraformOutputChange, Description: "number of resources to change"},
+			{Name: config.TerraformOutputDestroy, Description: "number of resources to destroy"},
+		},
+		Timeout:        j.jobSpec.AdvancedSetting.Timeout,
+		ErrorPolicy:    j.errorPolicy,
+		ExecutePolicy:  j.executePolicy,
+		Infrastructure: j.jobSpec.Runtime.Infrastructure,
+		VMLabels:       j.jobSpec.Runtime.VMLabels,
+	}
+	resp = append(resp, jobTask)
+
+	return resp, nil
+}
+
+// getEnvs returns the envs of the plan and apply phases, the s3 backend of the script option is set if it is configured.
+func (j TerraformJobController) getEnvs(taskID int64, scriptOption *terraform.ScriptOption) ([]*commonmodels.KeyVal, error) {
+	envs := generateKeyValsFromWorkflowParam(j.workflow.Params)
+	envs = append(envs, prepareDefaultWorkflowTaskEnvs(j.workflow.Project, j.workflow.Name, j.workflow.DisplayName, j.jobSpec.Runtime.Infrastructure, taskID)...)
+	envs = append(envs, getReposVariables([]*types.Repository{j.jobSpec.Repo})...)
+	envs = append(envs, &commonmodels.KeyVal{Key: "TF_IN_AUTOMATION", Value: "true"})
+	for _, kv := range j.jobSpec.Variables.ToKVList() {
+		envs = append(envs, &commonmodels.KeyVal{
+			Key:          "TF_VAR_" + kv.Key,
+			Value:        kv.GetValue(),
+			IsCredential: kv.IsCredential,
+		})
+	}
+
+	if j.jobSpec.Backend != nil && j.jobSpec.Backend.Type == config.TerraformBackendS3 {
+		var storage *commonmodels.S3Storage
+		var err error
+		if j.jobSpec.Backend.ObjectStorageID == "" {
+			storage, err = commonrepo.NewS3StorageColl().FindDefault()
+		} else {
+			storage, err = commonrepo.NewS3StorageColl().Find(j.jobSpec.Backend.ObjectStorageID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find object storage for the terraform state, error: %v", err)
+		}
+
+		key := j.jobSpec.Backend.Key
+		if key == "" {
+			key = path.Join(j.workflow.Project, j.workflow.Name, j.name, "terraform.tfstate")
+		}
+		scriptOption.Backend = &terraform.S3Backend{
+			Bucket:   storage.Bucket,
+			Key:      strings.TrimLeft(path.Join(storage.Subfolder, key), "/"),
+			Region:   storage.Region,
+			Endpoint: storage.Endpoint,
+			Insecure: storage.Insecure,
+		}
+		envs = append(envs,
+			&commonmodels.KeyVal{Key: "AWS_ACCESS_KEY_ID", Value: storage.Ak, IsCredential: true},
+			&commonmodels.KeyVal{Key: "AWS_SECRET_ACCESS_KEY", Value: storage.Sk, IsCredential: true},
+		)
+	}
+
+	keyvaultEnvs, err := GetKeyVaultEnvs(j.workflow.Project)
+	if err != nil {
+		return nil, fmt.Errorf("get keyvault envs error: %v", err)
+	}
+	envs = append(envs, keyvaultEnvs...)
+	return envs, nil
+}
+
+func (j TerraformJobController) getProperties(taskID int64, envs []*commonmodels.KeyVal) (*commonmodels.JobProperties, error) {
+	basicImage, err := commonrepo.NewBasicImageColl().Find(j.jobSpec.Runtime.ImageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find base image: %s, error: %v", j.jobSpec.Runtime.ImageID, err)
+	}
+	registries, err := commonservice.ListRegistryNamespaces("", true, log.SugaredLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	return &commonmodels.JobProperties{
+		Timeout:             j.jobSpec.AdvancedSetting.Timeout,
+		ResourceRequest:     j.jobSpec.AdvancedSetting.ResourceRequest,
+		ResReqSpec:          j.jobSpec.AdvancedSetting.ResReqSpec,
+		Infrastructure:      j.jobSpec.Runtime.Infrastructure,
+		VMLabels:            j.jobSpec.Runtime.VMLabels,
+		ClusterID:           j.jobSpec.AdvancedSetting.ClusterID,
+		ClusterSource:       j.jobSpec.AdvancedSetting.ClusterSource,
+		StrategyID:          j.jobSpec.AdvancedSetting.StrategyID,
+		BuildOS:             basicImage.Value,
+		ImageFrom:           j.jobSpec.Runtime.ImageFrom,
+		ImageID:             j.jobSpec.Runtime.ImageID,
+		Registries:          registries,
+		ShareStorageDetails: getShareStorageDetail(j.workflow.ShareStorages, j.jobSpec.AdvancedSetting.ShareStorageInfo, j.workflow.Name, taskID),
+		UseHostDockerDaemon: j.jobSpec.AdvancedSetting.UseHostDockerDaemon,
+		CustomAnnotations:   j.jobSpec.AdvancedSetting.CustomAnnotations,
+		CustomLabels:        j.jobSpec.AdvancedSetting.CustomLabels,
+		Envs:                envs,
+	}, nil
+}
+
+// getCommonSteps returns the steps installing the packages and cloning the repository.
+func (j TerraformJobController) getCommonSteps(jobName string) ([]*commonmodels.StepTask, error) {
+	tools := make([]*steptypes.Tool, 0)
+	for _, install := range j.jobSpec.Runtime.Installs {
+		tools = append(tools, &steptypes.Tool{
+			Name:    install.Name,
+			Version: install.Version,
+		})
+	}
+
+	codehosts, err := codehostrepo.NewCodehostColl().AvailableCodeHost(j.workflow.Project)
+	if err != nil {
+		return nil, fmt.Errorf("find %s project codehost error: %v", j.workflow.Project, err)
+	}
+
+	return []*commonmodels.StepTask{
+		{
+			Name:     stepNameInstallDeps,
+			JobName:  jobName,
+			StepType: config.StepTools,
+			Spec:     steptypes.StepToolInstallSpec{Installs: tools},
+		},
+		{
+			Name:     stepNameGit,
+			JobName:  jobName,
+			StepType: config.StepGit,
+			Spec: steptypes.StepGitSpec{
+				CodeHosts: codehosts,
+				Repos:     []*types.Repository{j.jobSpec.Repo},
+			},
+		},
+	}, nil
+}
+
+func (j TerraformJobController) SetRepo(repo *types.Repository) error {
+	if j.jobSpec.Repo == nil {
+		return nil
+	}
+	repos := applyRepos([]*types.Repository{j.jobSpec.Repo}, []*types.Repository{repo})
+	j.jobSpec.Repo = repos[0]
+	return nil
+}
+
+func (j TerraformJobController) SetRepoCommitInfo() error {
+	if j.jobSpec.Repo == nil {
+		return nil
+	}
+	return setRepoInfo([]*types.Repository{j.jobSpec.Repo})
+}
+
+func (j TerraformJobController) GetVariableList(jobName string, getAggregatedVariables, getRuntimeVariables, getPlaceHolderVariables, getServiceSpecificVariables, useUserInputValue bool) ([]*commonmodels.KeyVal, error) {
+	resp := make([]*commonmodels.KeyVal, 0)
+	if getRuntimeVariables {
+		resp = append(resp, &commonmodels.KeyVal{
+			Key:          strings.Join([]string{"job", j.name, "status"}, "."),
+			Value:        "",
+			Type:         "string",
+			IsCredential: false,
+		})
+		for _, output := range []string{config.TerraformOutputHasChanges, config.TerraformOutputAdd, config.TerraformOutputChange, config.TerraformOutputDestroy} {
+			resp = append(resp, &commonmodels.KeyVal{
+				Key:          strings.Join([]string{"job", j.name, "output", output}, "."),
+				Value:        "",
+				Type:         "string",
+				IsCredential: false,
+			})
+		}
+	}
+	return resp, nil
+}
+
+func (j TerraformJobController) GetUsedRepos() ([]*types.Repository, error) {
+	resp := make([]*types.Repository, 0)
+	if j.jobSpec.Repo != nil {
+		resp = append(resp, j.jobSpec.Repo)
+	}
+	return resp, nil
+}
+
+func (j TerraformJobController) RenderDynamicVariableOptions(key string, option *RenderDynamicVariableValue) ([]string, error) {
+	return nil, fmt.Errorf("invalid job type: %s to render dynamic variable", j.name)
+}
+
+func (j TerraformJobController) IsServiceTypeJob() bool {
+	return false
+}
@@ -92,7 +92,7 @@ func (j TestingJobController) Validate(isExecution bool) error {
 		}
 	}
 
-	return nil
+	return validateJobMatrix(j.jobSpec.Matrix)
 }
 
 func (j TestingJobController) Update(useUserInput bool, ticket *commonmodels.ApprovalTicket) error {
@@ -116,6 +116,7 @@ func (j TestingJobController) Update(useUserInput bool, ticket *commonmodels.App
 	j.jobSpec.RefRepos = currJobSpec.RefRepos
 	j.jobSpec.TestModuleOptions = currJobSpec.TestModuleOptions
 	j.jobSpec.ServiceTestOptions = currJobSpec.ServiceTestOptions
+	j.jobSpec.Matrix = currJobSpec.Matrix
 
 	testSvc := commonservice.NewTestingService()
 
@@ -267,7 +268,7 @@ func (j TestingJobController) ToTask(taskID int64) ([]*commonmodels.JobTask, err
 		}
 	}
 
-	return resp, nil
+	return expandJobMatrix(resp, j.jobSpec.Matrix)
 }
 
 func (j TestingJobController) SetRepo(repo *types.Repository) error {
@@ -705,6 +706,8 @@ func (j TestingJobController) toJobTask(jobSubTaskID int, testing *commonmodels.
 		if jobTaskSpec.Properties.CacheEnable {
 			jobTaskSpec.Properties.CacheUserDir = commonutil.RenderEnv(jobTaskSpec.Properties.CacheUserDir, jobTaskSpec.Properties.Envs)
 			jobTaskSpec.Properties.IgnoreCache = j.workflow.IgnoreCache
+			jobTaskSpec.Properties.CacheRules = testingInfo.CacheRules
+			jobTaskSpec.Properties.CacheQuotaInMiB = testingInfo.CacheQuotaInMiB
 			if jobTaskSpec.Properties.Cache.MediumType == types.NFSMedium {
 				jobTaskSpec.Properties.Cache.NFSProperties.Subpath = commonutil.RenderEnv(jobTaskSpec.Properties.Cache.NFSProperties.Subpath, jobTaskSpec.Properties.Envs)
 			} else if jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
@@ -744,7 +747,7 @@ func (j TestingJobController) toJobTask(jobSubTaskID int, testing *commonmodels.
 	}
 	jobTaskSpec.Steps = append(jobTaskSpec.Steps, toolInstallStep)
 	// init download object cache step
-	if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium && !shouldSkipObjectCacheRestore(jobTaskSpec) {
+	if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium && !shouldSkipObjectCacheRestore(jobTaskSpec) && !useNamedCache(jobTaskSpec) {
 		cacheDir := "/workspace"
 		if jobTaskSpec.Properties.CacheDirType == types.UserDefinedCacheDir {
 			cacheDir = jobTaskSpec.Properties.CacheUserDir
@@ -794,6 +797,14 @@ func (j TestingJobController) toJobTask(jobSubTaskID int, testing *commonmodels.
 
 	jobTaskSpec.Steps = append(jobTaskSpec.Steps, p4Step)
 
+	// init named cache steps, the cache keys may depend on the files checked out above
+	var saveCacheStep *commonmodels.StepTask
+	if useNamedCache(jobTaskSpec) {
+		var restoreCacheStep *commonmodels.StepTask
+		restoreCacheStep, saveCacheStep = buildNamedCacheSteps(jobTaskSpec, cacheS3, getTestingJobNamedCacheDir(j.workflow.Project, testing.Name), testing.Name, jobTask.Name, jobTask.Key)
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, restoreCacheStep)
+	}
+
 	// init debug before step
 	debugBeforeStep := &commonmodels.StepTask{
 		Name:     testing.Name + "-debug_before",
@@ -907,8 +918,49 @@ func (j TestingJobController) toJobTask(jobSubTaskID int, testing *commonmodels.
 		jobTaskSpec.Steps = append(jobTaskSpec.Steps, junitStep)
 	}
 
+	// init coverage report step, it is only supported by the job executor in kubernetes
+	if len(testingInfo.CoverageReportPaths) > 0 && jobTask.Infrastructure != setting.JobVMInfrastructure {
+		coverageSpec := &step.StepCoverageReportSpec{
+			SourceWorkflow:  j.workflow.Name,
+			SourceJobKey:    j.name,
+			JobTaskName:     jobName,
+			TaskID:          taskID,
+			ServiceName:     serviceName,
+			ServiceModule:   serviceModule,
+			TestName:        testing.Name,
+			TestProject:     testing.ProjectName,
+			ReportPaths:     testingInfo.CoverageReportPaths,
+			Format:          testingInfo.CoverageFormat,
+			MinLineCoverage: testingInfo.MinLineCoverage,
+			MaxCoverageDrop: testingInfo.MaxCoverageDrop,
+			DestDir:         tarDestDir,
+			S3DestDir:       path.Join(j.workflow.Name, fmt.Sprint(taskID), jobTask.Name, "coverage"),
+		}
+		if repo := getCoverageRepo(gitRepos); repo != nil {
+			coverageSpec.CodehostID = repo.CodehostID
+			coverageSpec.RepoOwner = repo.GetRepoNamespace()
+			coverageSpec.RepoName = repo.RepoName
+			coverageSpec.Branch = repo.Branch
+			coverageSpec.CommitID = repo.CommitID
+			coverageSpec.PR = repo.PR
+			if coverageSpec.PR == 0 && len(repo.PRs) == 1 {
+				coverageSpec.PR = repo.PRs[0]
+			}
+		}
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, &commonmodels.StepTask{
+			Name:      config.TestJobCoverageReportStepName,
+			JobName:   jobTask.Name,
+			JobKey:    jobTask.Key,
+			StepType:  config.StepCoverageReport,
+			Onfailure: true,
+			Spec:      coverageSpec,
+		})
+	}
+
 	// init object cache step
-	if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
+	if saveCacheStep != nil {
+		jobTaskSpec.Steps = append(jobTaskSpec.Steps, saveCacheStep)
+	} else if jobTaskSpec.Properties.CacheEnable && jobTaskSpec.Properties.Cache.MediumType == types.ObjectMedium {
 		cacheDir := "/workspace"
 		if jobTaskSpec.Properties.CacheDirType == types.UserDefinedCacheDir {
 			cacheDir = jobTaskSpec.Properties.CacheUserDir
@@ -973,6 +1025,24 @@ func getTestingJobCacheObjectPath(workflowName, testingName string) string {
 	return fmt.Sprintf("%s/cache/%s", workflowName, testingName)
 }
 
+func getTestingJobNamedCacheDir(project, testingName string) string {
+	return fmt.Sprintf("%s/named-cache/testing/%s", project, testingName)
+}
+
+// getCoverageRepo returns the repository the coverage is compared and published against, a repository built from a
+// pull request is preferred
+func getCoverageRepo(repos []*types.Repository) *types.Repository {
+	for _, repo := range repos {
+		if repo.PR > 0 || len(repo.PRs) > 0 {
+			return repo
+		}
+	}
+	if len(repos) > 0 {
+		return repos[0]
+	}
+	return nil
+}
+
 // internal use only
 func getTestingJobVariables(repos []*types.Repository, taskID int64, project, workflowName, workflowDisplayName, testingProject, testingName, testType, serviceName, serviceModule, infrastructure string, log *zap.SugaredLogger) []*commonmodels.KeyVal {
 	ret := make([]*commonmodels.KeyVal, 0)
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	workflowtool "github.com/koderover/zadig/v2/pkg/tool/workflow"
+)
+
+const matrixEnvKeyPrefix = "MATRIX_"
+
+var matrixCombinationNameRegex = regexp.MustCompile(`[^\w.-]`)
+
+func jobMatrixAxes(matrix *commonmodels.JobMatrix) []*workflowtool.MatrixAxis {
+	axes := make([]*workflowtool.MatrixAxis, 0, len(matrix.Axes))
+	for _, axis := range matrix.Axes {
+		axes = append(axes, &workflowtool.MatrixAxis{Name: axis.Name, Values: axis.Values})
+	}
+	return axes
+}
+
+func validateJobMatrix(matrix *commonmodels.JobMatrix) error {
+	if matrix == nil {
+		return nil
+	}
+	if matrix.MaxParallel < 0 {
+		return fmt.Errorf("matrix max parallel can not be negative")
+	}
+	if _, err := workflowtool.ExpandMatrix(jobMatrixAxes(matrix), matrix.Include, matrix.Exclude); err != nil {
+		return fmt.Errorf("invalid matrix: %s", err)
+	}
+	return nil
+}
+
+// jobMatrixCombination is a combination of the axis values of a job matrix, its name tells apart the keys, images
+// and packages of the sub-tasks expanded from the same task.
+type jobMatrixCombination struct {
+	name   string
+	values map[string]string
+}
+
+// suffix appends the combination name to s, s is returned as is for a nil combination.
+func (c *jobMatrixCombination) suffix(s, sep string) string {
+	if c == nil {
+		return s
+	}
+	return s + sep + c.name
+}
+
+// jobMatrixCombinations returns the combinations of the matrix, a single nil combination is returned if there is
+// no matrix so that callers could range over the result either way.
+func jobMatrixCombinations(matrix *commonmodels.JobMatrix) ([]*jobMatrixCombination, error) {
+	if matrix == nil {
+		return []*jobMatrixCombination{nil}, nil
+	}
+	axes := jobMatrixAxes(matrix)
+	combinations, err := workflowtool.ExpandMatrix(axes, matrix.Include, matrix.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid matrix: %s", err)
+	}
+
+	resp := make([]*jobMatrixCombination, 0, len(combinations))
+	names := make(map[string]bool, len(combinations))
+	for i, combination := range combinations {
+		// the name is used in image tags and package names, so only keep the characters allowed there
+		name := matrixCombinationNameRegex.ReplaceAllString(workflowtool.MatrixCombinationName(axes, combination), "-")
+		if names[name] {
+			name = fmt.Sprintf("%s-%d", name, i)
+		}
+		names[name] = true
+		resp = append(resp, &jobMatrixCombination{name: name, values: combination})
+	}
+	return resp, nil
+}
+
+// setJobTaskMatrix marks the task as a sub-task of the matrix group and adds the axis values as MATRIX_<AXIS> envs.
+func setJobTaskMatrix(task *commonmodels.JobTask, spec *commonmodels.JobTaskFreestyleSpec, group string, matrix *commonmodels.JobMatrix, combination *jobMatrixCombination) {
+	if matrix == nil || combination == nil {
+		return
+	}
+	task.Matrix = &commonmodels.JobTaskMatrix{
+		Group:       group,
+		Values:      combination.values,
+		MaxParallel: matrix.MaxParallel,
+		FailFast:    matrix.FailFast,
+	}
+	for _, axis := range matrix.Axes {
+		value, ok := combination.values[axis.Name]
+		if !ok {
+			continue
+		}
+		spec.Properties.Envs = append(spec.Properties.Envs, &commonmodels.KeyVal{
+			Key:   matrixEnvKeyPrefix + strings.ToUpper(axis.Name),
+			Value: value,
+			Type:  commonmodels.StringType,
+		})
+	}
+}
+
+// expandJobMatrix fans every task out to one sub-task per matrix combination. The combination name is appended to
+// the key of every sub-task, so the outputs of the sub-tasks are kept apart, and the axis values are set as
+// MATRIX_<AXIS> envs.
+func expandJobMatrix(tasks []*commonmodels.JobTask, matrix *commonmodels.JobMatrix) ([]*commonmodels.JobTask, error) {
+	if matrix == nil || len(tasks) == 0 {
+		return tasks, nil
+	}
+	combinations, err := jobMatrixCombinations(matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*commonmodels.JobTask, 0, len(tasks)*len(combinations))
+	for _, task := range tasks {
+		for i, combination := range combinations {
+			spec := new(commonmodels.JobTaskFreestyleSpec)
+			if err := commonmodels.IToi(task.Spec, spec); err != nil {
+				return nil, fmt.Errorf("failed to copy spec of job task %s, error: %s", task.Name, err)
+			}
+
+			subTask := *task
+			subTask.Name = fmt.Sprintf("%s-%d", task.Name, i)
+			subTask.Key = combination.suffix(task.Key, ".")
+			subTask.DisplayName = combination.suffix(task.DisplayName, "-")
+			for _, step := range spec.Steps {
+				if step.JobName == task.Name {
+					step.JobName = subTask.Name
+				}
+				if step.JobKey == task.Key {
+					step.JobKey = subTask.Key
+				}
+			}
+			setJobTaskMatrix(&subTask, spec, task.Key, matrix, combination)
+			subTask.Spec = spec
+			resp = append(resp, &subTask)
+		}
+	}
+	return resp, nil
+}
@@ -120,7 +120,47 @@ func shouldBypassNFSMountForIgnoreCache(jobTaskSpec *commonmodels.JobTaskFreesty
 	return jobTaskSpec != nil &&
 		jobTaskSpec.Properties.CacheEnable &&
 		jobTaskSpec.Properties.IgnoreCache &&
-		jobTaskSpec.Properties.Cache.MediumType == types.NFSMedium
+		jobTaskSpec.Properties.Cache.MediumType == types.NFSMedium &&
+		!useNamedCache(jobTaskSpec)
+}
+
+// useNamedCache tells whether the job restores and saves named caches instead of the single cache dir
+func useNamedCache(jobTaskSpec *commonmodels.JobTaskFreestyleSpec) bool {
+	return jobTaskSpec != nil &&
+		jobTaskSpec.Properties.CacheEnable &&
+		len(jobTaskSpec.Properties.CacheRules) > 0
+}
+
+// buildNamedCacheSteps returns the restore and save steps of the named caches, storeDir is relative to the
+// object storage subfolder or the root of the cache volume.
+func buildNamedCacheSteps(jobTaskSpec *commonmodels.JobTaskFreestyleSpec, cacheS3 *commonmodels.S3Storage, storeDir, stepPrefix, jobName, jobKey string) (*commonmodels.StepTask, *commonmodels.StepTask) {
+	spec := &step.StepCacheSpec{
+		Rules:       jobTaskSpec.Properties.CacheRules,
+		MediumType:  jobTaskSpec.Properties.Cache.MediumType,
+		StoreDir:    storeDir,
+		QuotaInMiB:  jobTaskSpec.Properties.CacheQuotaInMiB,
+		IgnoreCache: jobTaskSpec.Properties.IgnoreCache,
+	}
+	if spec.MediumType == types.ObjectMedium {
+		spec.S3 = modelToS3StepSpec(cacheS3)
+		spec.StoreDir = path.Join(cacheS3.Subfolder, storeDir)
+	}
+	restoreSpec, saveSpec := *spec, *spec
+	restoreStep := &commonmodels.StepTask{
+		Name:     fmt.Sprintf("%s-%s", stepPrefix, "restore-cache"),
+		JobName:  jobName,
+		JobKey:   jobKey,
+		StepType: config.StepRestoreCache,
+		Spec:     &restoreSpec,
+	}
+	saveStep := &commonmodels.StepTask{
+		Name:     fmt.Sprintf("%s-%s", stepPrefix, "save-cache"),
+		JobName:  jobName,
+		JobKey:   jobKey,
+		StepType: config.StepSaveCache,
+		Spec:     &saveSpec,
+	}
+	return restoreStep, saveStep
 }
 
 func shouldSkipObjectCacheRestore(jobTaskSpec *commonmodels.JobTaskFreestyleSpec) bool {
@@ -36,6 +36,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/shared/client/plutusenterprise"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	workflowtool "github.com/koderover/zadig/v2/pkg/tool/workflow"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/util"
 )
@@ -174,7 +175,8 @@ func (w *Workflow) SetPreset(ticket *commonmodels.ApprovalTicket) error {
 				job.JobType == config.JobK8sBlueGreenDeploy ||
 				job.JobType == config.JobApollo ||
 				job.JobType == config.JobK8sCanaryDeploy ||
-				job.JobType == config.JobK8sGrayRelease {
+				job.JobType == config.JobK8sGrayRelease ||
+				job.JobType == config.JobProgressiveDelivery {
 				ctrl.ClearSelection()
 			}
 
@@ -294,6 +296,7 @@ func (w *Workflow) ToJobTasks(taskID int64, creator, account, uid string, releas
 			}
 			for _, task := range tasks {
 				task.NotifyCtls = job.NotifyCtls
+				task.DependsOn = job.DependsOn
 			}
 
 			// Update the spec, since sometimes we update the calculated field
@@ -818,9 +821,40 @@ func (w *Workflow) ValidateWithWorkflowSettings(isExecution bool, latestWorkflow
 			}
 		}
 	}
+	if err := validateJobDependencies(w.Stages); err != nil {
+		return e.ErrLintWorkflow.AddErr(err)
+	}
 	return nil
 }
 
+// validateJobDependencies checks that the jobs depend on existing jobs without forming a cycle.
+// manual execution pauses a stage as a whole, so it can not be combined with job dependencies.
+func validateJobDependencies(stages []*commonmodels.WorkflowStage) error {
+	hasDependencies, hasManualExec := false, false
+	dagStages := make([]*workflowtool.DAGStage, 0, len(stages))
+	for _, stage := range stages {
+		if stage.ManualExec != nil && stage.ManualExec.Enabled {
+			hasManualExec = true
+		}
+		dagStage := &workflowtool.DAGStage{Parallel: stage.Parallel}
+		for _, job := range stage.Jobs {
+			if len(job.DependsOn) > 0 {
+				hasDependencies = true
+			}
+			dagStage.Nodes = append(dagStage.Nodes, &workflowtool.DAGNode{Name: job.Name, Group: job.Name, DependsOn: job.DependsOn})
+		}
+		dagStages = append(dagStages, dagStage)
+	}
+	if !hasDependencies {
+		return nil
+	}
+	if hasManualExec {
+		return fmt.Errorf("job dependencies can not be used together with manual execution stages")
+	}
+	_, err := workflowtool.BuildStageDAG(dagStages)
+	return err
+}
+
 func validateRequiredWorkflowParams(params []*commonmodels.Param) error {
 	for _, param := range params {
 		if !param.Required {
@@ -377,6 +377,10 @@ func GetInputUpdater(job *commonmodels.Job, input interface{}, workflow *commonm
 		updater := new(GrayReleaseJobInput)
 		err := commonmodels.IToi(input, updater)
 		return updater, err
+	case config.JobProgressiveDelivery:
+		updater := new(ProgressiveDeliveryJobInput)
+		err := commonmodels.IToi(input, updater)
+		return updater, err
 	case config.JobK8sGrayRollback:
 		updater := new(GrayRollbackJobInput)
 		err := commonmodels.IToi(input, updater)
@@ -399,7 +403,7 @@ func GetInputUpdater(job *commonmodels.Job, input interface{}, workflow *commonm
 	case config.JobApproval:
 		updater := new(ApprovalJobInput)
 		return updater, nil
-	case config.JobAIReleaseSpecialist, config.JobAI:
+	case config.JobAIReleaseSpecialist, config.JobAI, config.JobSchemaMigration:
 		updater := new(EmptyInput)
 		err := commonmodels.IToi(input, updater)
 		return updater, err
@@ -407,6 +411,10 @@ func GetInputUpdater(job *commonmodels.Job, input interface{}, workflow *commonm
 		updater := new(SQLJobInput)
 		err := commonmodels.IToi(input, updater)
 		return updater, err
+	case config.JobTerraform:
+		updater := new(TerraformJobInput)
+		err := commonmodels.IToi(input, updater)
+		return updater, err
 	case config.JobTapd:
 		updater := new(TapdJobInput)
 		err := commonmodels.IToi(input, updater)
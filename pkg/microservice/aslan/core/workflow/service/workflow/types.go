@@ -158,6 +158,8 @@ type CreateCustomTaskNotifyInput struct {
 	WechatNotificationConfig *CreateCustomTaskWechatNotificationConfig `json:"wechat_notification_config"`
 	// MSTeams通知配置
 	MSTeamsNotificationConfig *CreateCustomTaskMSTeamsNotificationConfig `json:"msteams_notification_config"`
+	// Slack通知配置
+	SlackNotificationConfig *CreateCustomTaskSlackNotificationConfig `json:"slack_notification_config"`
 	// 邮件通知配置
 	MailNotificationConfig *CreateCustomTaskMailNotificationConfig `json:"mail_notification_config"`
 }
@@ -205,6 +207,12 @@ type CreateCustomTaskMSTeamsNotificationConfig struct {
 	DynamicRecipients []string `json:"dynamic_recipients"`
 }
 
+type CreateCustomTaskSlackNotificationConfig struct {
+	AtUsers           []*commonmodels.User `json:"at_users"`
+	DynamicRecipients []string             `json:"dynamic_recipients"`
+	IsAtAll           bool                 `json:"is_at_all"`
+}
+
 type CreateCustomTaskMailNotificationConfig struct {
 	UserIDs           []string             `json:"user_ids"`
 	Users             []*commonmodels.User `json:"users"`
@@ -1152,6 +1160,36 @@ func (p *GrayReleaseJobInput) UpdateJobSpec(job *commonmodels.Job) (*commonmodel
 	return job, nil
 }
 
+type ProgressiveDeliveryJobInput struct {
+	TargetList []*GrayReleaseTarget `json:"target_list"`
+}
+
+func (p *ProgressiveDeliveryJobInput) UpdateJobSpec(job *commonmodels.Job) (*commonmodels.Job, error) {
+	newSpec := new(commonmodels.ProgressiveDeliveryJobSpec)
+	if err := commonmodels.IToi(job.Spec, newSpec); err != nil {
+		return nil, errors.New("unable to cast job.Spec into commonmodels.ProgressiveDeliveryJobSpec")
+	}
+
+	newTargets := []*commonmodels.GrayReleaseTarget{}
+
+	for _, target := range newSpec.TargetOptions {
+		for _, inputTarget := range p.TargetList {
+			if target.WorkloadName != inputTarget.WorkloadName {
+				continue
+			}
+			target.ContainerName = inputTarget.ContainerName
+			target.Image = inputTarget.ImageName
+			newTargets = append(newTargets, target)
+		}
+	}
+
+	newSpec.Targets = newTargets
+
+	job.Spec = newSpec
+
+	return job, nil
+}
+
 type GrayRollbackJobInput struct {
 	TargetList []*GrayReleaseTarget `json:"target_list"`
 }
@@ -1546,6 +1584,31 @@ func (p *SQLJobInput) UpdateJobSpec(job *commonmodels.Job) (*commonmodels.Job, e
 	return job, nil
 }
 
+type TerraformJobInput struct {
+	KVs      []*types.KV             `json:"kv"`
+	RepoInfo *types.OpenAPIRepoInput `json:"repo_info"`
+}
+
+func (p *TerraformJobInput) UpdateJobSpec(job *commonmodels.Job) (*commonmodels.Job, error) {
+	newSpec := new(commonmodels.TerraformJobSpec)
+	if err := commonmodels.IToi(job.Spec, newSpec); err != nil {
+		return nil, fmt.Errorf("failed to convert job.Spec to commonmodels.TerraformJobSpec, err: %w", err)
+	}
+
+	newSpec.Variables = OpenAPIKVInputToKeyValList(newSpec.Variables, p.KVs)
+	if p.RepoInfo != nil && newSpec.Repo != nil {
+		newRepos, err := OpenAPIFreestyleRepoInputToRepository([]*types.Repository{newSpec.Repo}, []*types.OpenAPIRepoInput{p.RepoInfo})
+		if err != nil {
+			return nil, err
+		}
+		newSpec.Repo = newRepos[0]
+	}
+
+	job.Spec = newSpec
+
+	return job, nil
+}
+
 type TapdJobInput struct {
 	ProjectID    string                     `json:"project_id"`
 	ProjectName  string                     `json:"project_name"`
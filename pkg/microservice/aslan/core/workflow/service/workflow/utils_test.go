@@ -120,3 +120,22 @@ func TestValidateFrontendWorkflowDeltaJobExecutePolicy(t *testing.T) {
 		assert.Equal(t, base.Stages[0].Jobs[0].ExecutePolicy, rendered.Stages[0].Jobs[0].ExecutePolicy)
 	})
 }
+
+func TestAggregateJobMatrixStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []config.Status
+		expect   config.Status
+	}{
+		{name: "not started", statuses: []config.Status{"", ""}, expect: ""},
+		{name: "running", statuses: []config.Status{config.StatusPassed, ""}, expect: config.StatusRunning},
+		{name: "all passed", statuses: []config.Status{config.StatusPassed, config.StatusPassed}, expect: config.StatusPassed},
+		{name: "unstable wins over passed", statuses: []config.Status{config.StatusPassed, config.StatusUnstable, config.StatusPassed}, expect: config.StatusUnstable},
+		{name: "failed wins over unstable", statuses: []config.Status{config.StatusUnstable, config.StatusFailed}, expect: config.StatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, aggregateJobMatrixStatus(tt.statuses))
+		})
+	}
+}
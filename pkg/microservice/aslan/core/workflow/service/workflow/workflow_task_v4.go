@@ -18,7 +18,6 @@ package workflow
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -110,6 +109,8 @@ type WorkflowTaskPreview struct {
 	ApprovalTicketID    string                       `bson:"approval_ticket_id"        json:"approval_ticket_id"`
 	ApprovalID          string                       `bson:"approval_id"               json:"approval_id"`
 	ReleasePlan         *commonmodels.ReleasePlanRef `bson:"release_plan,omitempty"    json:"release_plan,omitempty"`
+	// CriticalPath is the chain of dependent job names with the longest run time, only set when jobs declare dependencies
+	CriticalPath []string `bson:"critical_path,omitempty" json:"critical_path,omitempty"`
 }
 
 type StageTaskPreview struct {
@@ -142,10 +143,24 @@ type JobTaskPreview struct {
 	ErrorHandlerUserID   string                       `bson:"error_handler_user_id"  yaml:"error_handler_user_id" json:"error_handler_user_id"`
 	ErrorHandlerUserName string                       `bson:"error_handler_username"  yaml:"error_handler_username" json:"error_handler_username"`
 	RetryCount           int                          `bson:"retry_count"           yaml:"retry_count"               json:"retry_count"`
+	DependsOn            []string                     `bson:"depends_on,omitempty"  yaml:"depends_on,omitempty"      json:"depends_on,omitempty"`
+	CriticalPath         bool                         `bson:"critical_path"         yaml:"critical_path"             json:"critical_path"`
+	Matrix               *commonmodels.JobTaskMatrix  `bson:"matrix,omitempty"      yaml:"matrix,omitempty"          json:"matrix,omitempty"`
+	// MatrixGroup summarizes all the sub-tasks expanded from the same job matrix, shared by their previews
+	MatrixGroup *JobMatrixGroupPreview `bson:"matrix_group,omitempty" yaml:"matrix_group,omitempty" json:"matrix_group,omitempty"`
 	// JobInfo contains the fields that make up the job task name, for frontend display
 	JobInfo interface{} `bson:"job_info" json:"job_info"`
 }
 
+type JobMatrixGroupPreview struct {
+	Name        string                `bson:"name"         json:"name"`
+	Status      config.Status         `bson:"status"       json:"status"`
+	Total       int                   `bson:"total"        json:"total"`
+	StatusCount map[config.Status]int `bson:"status_count" json:"status_count"`
+	// Artifacts are the images and packages built by the sub-tasks
+	Artifacts []string `bson:"artifacts" json:"artifacts"`
+}
+
 type ZadigBuildJobSpec struct {
 	Repos         []*types.Repository    `bson:"repos"           json:"repos"`
 	Image         string                 `bson:"image"           json:"image"`
@@ -918,6 +933,25 @@ func updateNotifyCtls(notifyCtls []*commonmodels.NotifyCtl, notifyInputs []*Crea
 				}
 
 				notifyCtl.MSTeamsNotificationConfig = config
+			case setting.NotifyWebHookTypeSlack:
+				if notifyCtl.SlackNotificationConfig == nil {
+					log.Errorf("slack notification config is nil for notify type: %s", notifyCtl.WebHookType)
+					continue
+				}
+				dynamicRecipients, err := toDynamicRecipients(notifyCtl.WebHookType, notifyCtl.SlackNotificationConfig.AppID, notifyInput.SlackNotificationConfig.DynamicRecipients)
+				if err != nil {
+					return nil, err
+				}
+
+				config := &commonmodels.SlackNotificationConfig{
+					AppID:             notifyCtl.SlackNotificationConfig.AppID,
+					Channel:           notifyCtl.SlackNotificationConfig.Channel,
+					AtUsers:           notifyInput.SlackNotificationConfig.AtUsers,
+					DynamicRecipients: commonmodels.DynamicRecipients(dynamicRecipients),
+					IsAtAll:           notifyInput.SlackNotificationConfig.IsAtAll,
+				}
+
+				notifyCtl.SlackNotificationConfig = config
 			case setting.NotifyWebHookTypeMail:
 				if notifyCtl.MailNotificationConfig == nil {
 					log.Errorf("mail notification config is nil for notify type: %s", notifyCtl.WebHookType)
@@ -2041,65 +2075,34 @@ func RevertWorkflowTaskV4Job(ctx *internalhandler.Context, workflowName, jobName
 					}
 
 					revertTaskSpec := &commonmodels.JobTaskSQLSpec{
-						ID:      jobTaskSpec.ID,
-						Type:    jobTaskSpec.Type,
-						SQL:     inputSpec.SQL,
-						Results: make([]*commonmodels.SQLExecResult, 0),
+						ID:               jobTaskSpec.ID,
+						Type:             jobTaskSpec.Type,
+						SQL:              inputSpec.SQL,
+						Transactional:    jobTaskSpec.Transactional,
+						StatementTimeout: jobTaskSpec.StatementTimeout,
+						Results:          make([]*commonmodels.SQLExecResult, 0),
 						JobTaskCommonRevertSpec: commonmodels.JobTaskCommonRevertSpec{
 							Detail: inputSpec.Detail,
 						},
 					}
 
-					db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%s)/?charset=utf8&multiStatements=true", info.Username, info.Password, info.Host, info.Port))
-					if err != nil {
-						return errors.Errorf("failed to run rollback sql, connect db error: %v", err)
-					}
-					defer db.Close()
-
-					sqls := strings.SplitAfter(inputSpec.SQL, ";")
-					for _, sql := range sqls {
-						if sql == "" {
-							continue
-						}
-
-						execResult := &commonmodels.SQLExecResult{}
-
-						execResult.SQL = strings.TrimSpace(sql)
-						execResult.Status = setting.SQLExecStatusNotExec
-
-						revertTaskSpec.Results = append(revertTaskSpec.Results, execResult)
-					}
-
-					for _, execResult := range revertTaskSpec.Results {
-						now := time.Now()
-						result, err := db.Exec(execResult.SQL)
-						if err != nil {
-							execResult.Status = setting.SQLExecStatusFailed
-							_, err = commonrepo.NewWorkflowTaskRevertColl().Create(&commonmodels.WorkflowTaskRevert{
-								TaskID:        taskID,
-								WorkflowName:  workflowName,
-								JobName:       jobName,
-								RevertSpec:    revertTaskSpec,
-								CreateTime:    time.Now().Unix(),
-								TaskCreator:   userName,
-								TaskCreatorID: userID,
-								Status:        config.StatusFailed,
-							})
-
-							if err != nil {
-								log.Warnf("failed to insert revert task logs, error: %s", err)
-							}
+					if err := runtimeJobController.ExecSQLStatements(context.Background(), info, revertTaskSpec, nil); err != nil {
+						_, createErr := commonrepo.NewWorkflowTaskRevertColl().Create(&commonmodels.WorkflowTaskRevert{
+							TaskID:        taskID,
+							WorkflowName:  workflowName,
+							JobName:       jobName,
+							RevertSpec:    revertTaskSpec,
+							CreateTime:    time.Now().Unix(),
+							TaskCreator:   userName,
+							TaskCreatorID: userID,
+							Status:        config.StatusFailed,
+						})
 
-							return fmt.Errorf("exec SQL \"%s\" error: %v", execResult.SQL, err)
+						if createErr != nil {
+							log.Warnf("failed to insert revert task logs, error: %s", createErr)
 						}
-						execResult.Status = setting.SQLExecStatusSuccess
-						execResult.ElapsedTime = time.Now().Sub(now).Milliseconds()
 
-						rowsAffected, err := result.RowsAffected()
-						if err != nil {
-							return fmt.Errorf("get affect rows error: %v", err)
-						}
-						execResult.RowsAffected = rowsAffected
+						return fmt.Errorf("failed to run rollback sql, error: %v", err)
 					}
 
 					_, err = commonrepo.NewWorkflowTaskRevertColl().Create(&commonmodels.WorkflowTaskRevert{
@@ -2590,9 +2593,39 @@ func GetWorkflowTaskV4(workflowName string, taskID int64, logger *zap.SugaredLog
 			Error:      stage.Error,
 		})
 	}
+	if runtimeWorkflowController.HasJobDependencies(task.Stages) {
+		setWorkflowTaskCriticalPath(resp, task.Stages, logger)
+	}
 	return resp, nil
 }
 
+// setWorkflowTaskCriticalPath marks the chain of dependent jobs that took the longest, unfinished jobs count with
+// the time they have been running so far.
+func setWorkflowTaskCriticalPath(resp *WorkflowTaskPreview, stages []*commonmodels.StageTask, logger *zap.SugaredLogger) {
+	dag, err := runtimeWorkflowController.BuildJobTaskDAG(stages)
+	if err != nil {
+		logger.Warnf("failed to build job dependency graph of workflow %s task %d, error: %s", resp.WorkflowName, resp.TaskID, err)
+		return
+	}
+	jobs := make(map[string]*JobTaskPreview)
+	for _, stage := range resp.Stages {
+		for _, job := range stage.Jobs {
+			jobs[job.Name] = job
+		}
+	}
+	resp.CriticalPath = dag.CriticalPath(func(name string) int64 {
+		if job, ok := jobs[name]; ok {
+			return job.CostSeconds
+		}
+		return 0
+	})
+	for _, name := range resp.CriticalPath {
+		if job, ok := jobs[name]; ok {
+			job.CriticalPath = true
+		}
+	}
+}
+
 func ApproveStage(workflowName, jobName, userName, userID, comment string, taskID int64, approve, isSystemAdmin bool, logger *zap.SugaredLogger) error {
 	if workflowName == "" || jobName == "" || taskID == 0 {
 		errMsg := fmt.Sprintf("can not find approved workflow: %s, taskID: %d,jobName: %s", workflowName, taskID, jobName)
@@ -2849,6 +2882,8 @@ func jobsToJobPreviews(jobs []*commonmodels.JobTask, context map[string]string,
 			ErrorHandlerUserID:   job.ErrorHandlerUserID,
 			ErrorHandlerUserName: job.ErrorHandlerUserName,
 			RetryCount:           job.RetryCount,
+			DependsOn:            job.DependsOn,
+			Matrix:               job.Matrix,
 		}
 		switch job.JobType {
 		case string(config.JobFreestyle):
@@ -3300,6 +3335,77 @@ func jobsToJobPreviews(jobs []*commonmodels.JobTask, context map[string]string,
 		}
 		resp = append(resp, jobPreview)
 	}
+	setJobMatrixGroupPreviews(resp)
+	return resp
+}
+
+// setJobMatrixGroupPreviews aggregates the status and artifacts of the sub-tasks expanded from the same job matrix.
+func setJobMatrixGroupPreviews(jobs []*JobTaskPreview) {
+	groups := make(map[string]*JobMatrixGroupPreview)
+	statuses := make(map[string][]config.Status)
+	for _, job := range jobs {
+		if job.Matrix == nil {
+			continue
+		}
+		group, ok := groups[job.Matrix.Group]
+		if !ok {
+			group = &JobMatrixGroupPreview{
+				Name:        job.Matrix.Group,
+				StatusCount: make(map[config.Status]int),
+				Artifacts:   make([]string, 0),
+			}
+			groups[job.Matrix.Group] = group
+		}
+		group.Total++
+		group.StatusCount[job.Status]++
+		statuses[job.Matrix.Group] = append(statuses[job.Matrix.Group], job.Status)
+		if spec, ok := job.Spec.(ZadigBuildJobSpec); ok {
+			for _, artifact := range []string{spec.Image, spec.Package} {
+				if artifact != "" && !utils.Contains(group.Artifacts, artifact) {
+					group.Artifacts = append(group.Artifacts, artifact)
+				}
+			}
+		}
+		job.MatrixGroup = group
+	}
+	for name, group := range groups {
+		group.Status = aggregateJobMatrixStatus(statuses[name])
+	}
+}
+
+// aggregateJobMatrixStatus reports the matrix as running until all of its sub-tasks finished, then as its worst result.
+func aggregateJobMatrixStatus(statuses []config.Status) config.Status {
+	priority := map[config.Status]int{
+		config.StatusCancelled: 7,
+		config.StatusTimeout:   6,
+		config.StatusFailed:    5,
+		config.StatusPause:     4,
+		config.StatusReject:    3,
+		config.StatusUnstable:  2,
+		config.StatusPassed:    1,
+		config.StatusSkipped:   0,
+	}
+	resp := config.Status("")
+	pending, started := false, false
+	for _, status := range statuses {
+		if status != "" {
+			started = true
+		}
+		code, ok := priority[status]
+		if !ok {
+			pending = true
+			continue
+		}
+		if resp == "" || code > priority[resp] {
+			resp = status
+		}
+	}
+	if pending {
+		if started {
+			return config.StatusRunning
+		}
+		return ""
+	}
 	return resp
 }
 
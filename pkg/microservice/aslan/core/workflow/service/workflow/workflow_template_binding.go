@@ -279,6 +279,9 @@ func ResolveWorkflowTemplateBinding(workflowName, user string, req *ResolveWorkf
 	if !isTemplateBindingEnabled(workflow) {
 		return fmt.Errorf("workflow %s is not template bound", workflowName)
 	}
+	if err := ensureWorkflowV4Editable(workflow); err != nil {
+		return err
+	}
 	if req == nil {
 		req = &ResolveWorkflowTemplateBindingRequest{}
 	}
@@ -321,6 +324,9 @@ func UnbindWorkflowTemplateBinding(workflowName, user string, req *UnbindWorkflo
 	if !isTemplateBindingEnabled(workflow) {
 		return nil
 	}
+	if err := ensureWorkflowV4Editable(workflow); err != nil {
+		return err
+	}
 	rendered, _, err := renderWorkflowWithTemplateBinding(workflow, 0)
 	if err != nil {
 		return err
@@ -31,8 +31,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/pingcap/tidb/parser"
-	_ "github.com/pingcap/tidb/parser/test_driver"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -74,6 +72,7 @@ import (
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	"github.com/koderover/zadig/v2/pkg/tool/cache"
 	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"github.com/koderover/zadig/v2/pkg/tool/database"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/jenkins"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
@@ -261,6 +260,14 @@ func GetWorkflowTasksCustomFields(projectName, workflowName string, logger *zap.
 }
 
 func UpdateWorkflowV4(name, user string, inputWorkflow *commonmodels.WorkflowV4, logger *zap.SugaredLogger) error {
+	if err := EnsureWorkflowV4Editable(name); err != nil {
+		return e.ErrUpsertWorkflow.AddDesc(err.Error())
+	}
+	return updateWorkflowV4(name, user, inputWorkflow, logger)
+}
+
+// updateWorkflowV4 saves the workflow without checking whether it is managed in git, it is used by the git sync.
+func updateWorkflowV4(name, user string, inputWorkflow *commonmodels.WorkflowV4, logger *zap.SugaredLogger) error {
 	workflow, err := commonrepo.NewWorkflowV4Coll().Find(name)
 	if err != nil {
 		logger.Errorf("Failed to find WorkflowV4: %s, the error is: %v", name, err)
@@ -389,6 +396,14 @@ func FindWorkflowV4Raw(name string, logger *zap.SugaredLogger) (*commonmodels.Wo
 }
 
 func DeleteWorkflowV4(name string, logger *zap.SugaredLogger) error {
+	if err := EnsureWorkflowV4Editable(name); err != nil {
+		return e.ErrDeleteWorkflow.AddDesc(err.Error())
+	}
+	return deleteWorkflowV4(name, logger)
+}
+
+// deleteWorkflowV4 deletes the workflow without checking whether it is managed in git, it is used by the git sync.
+func deleteWorkflowV4(name string, logger *zap.SugaredLogger) error {
 	// Cancel any queued or running tasks before deleting the workflow.
 	taskQueue, err := commonrepo.NewWorkflowQueueColl().List(&commonrepo.ListWorfklowQueueOption{
 		WorkflowName: name,
@@ -3406,22 +3421,11 @@ func GetJenkinsJobParams(id, jobName string) ([]*JenkinsJobParams, error) {
 }
 
 func ValidateSQL(_type config.DBInstanceType, sql string) error {
-	switch _type {
-	case config.DBInstanceTypeMySQL, config.DBInstanceTypeMariaDB:
-		return ValidateMySQL(sql)
-	default:
-		return errors.Errorf("not supported db type: %s", _type)
-	}
-}
-
-func ValidateMySQL(sql string) error {
-	p := parser.New()
-
-	_, _, err := p.Parse(sql, "", "")
+	dialect, err := database.GetDialect(_type)
 	if err != nil {
-		return errors.Errorf("parse sql statement error: %v", err)
+		return err
 	}
-	return nil
+	return dialect.Validate(sql)
 }
 
 func getWorkflowStatMap(workflowNames []string, workflowType config.PipelineType) map[string]*commonmodels.WorkflowStat {
@@ -0,0 +1,465 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	codeservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/code/service"
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	fsservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/fs"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+const (
+	WorkflowV4GitActionCreate    = "create"
+	WorkflowV4GitActionUpdate    = "update"
+	WorkflowV4GitActionDelete    = "delete"
+	WorkflowV4GitActionUnchanged = "unchanged"
+)
+
+// workflowV4GitVolatileFields are changed on every save and never come from git.
+var workflowV4GitVolatileFields = []string{"created_by", "create_time", "updated_by", "update_time", "hash"}
+
+type WorkflowV4GitSourceStatus struct {
+	*commonmodels.WorkflowV4GitSource
+	Workflows []*WorkflowV4GitStatus `json:"workflows"`
+}
+
+type WorkflowV4GitStatus struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Path        string `json:"path"`
+	CommitSHA   string `json:"commit_sha"`
+	// Drifted is true when the workflow was changed after the last sync from git
+	Drifted bool `json:"drifted"`
+}
+
+type WorkflowV4GitSyncResult struct {
+	ProjectName string                    `json:"project_name"`
+	CommitSHA   string                    `json:"commit_sha"`
+	DryRun      bool                      `json:"dry_run"`
+	Applied     bool                      `json:"applied"`
+	Changes     []*WorkflowV4GitChange    `json:"changes"`
+	Errors      []*WorkflowV4GitSyncError `json:"errors"`
+}
+
+type WorkflowV4GitChange struct {
+	WorkflowName string                             `json:"workflow_name"`
+	Path         string                             `json:"path"`
+	Action       string                             `json:"action"`
+	Diff         []*commonmodels.JSONPatchOperation `json:"diff,omitempty"`
+}
+
+type WorkflowV4GitSyncError struct {
+	Path         string `json:"path"`
+	WorkflowName string `json:"workflow_name"`
+	Error        string `json:"error"`
+}
+
+type workflowV4GitFile struct {
+	path     string
+	workflow *commonmodels.WorkflowV4
+}
+
+func UpsertWorkflowV4GitSource(projectName, user string, args *commonmodels.WorkflowV4GitSource, logger *zap.SugaredLogger) error {
+	if args == nil || args.CodehostID == 0 || args.RepoName == "" || args.BranchName == "" {
+		return e.ErrInvalidParam.AddDesc("代码源、代码库和分支不能为空")
+	}
+	ch, err := systemconfig.New().GetCodeHost(args.CodehostID)
+	if err != nil {
+		logger.Errorf("failed to get codehost %d, error: %s", args.CodehostID, err)
+		return e.ErrInvalidParam.AddErr(err)
+	}
+
+	args.ProjectName = projectName
+	args.Source = ch.Type
+	args.Path = strings.Trim(args.Path, "/")
+	args.UpdatedBy = user
+	if err := commonrepo.NewWorkflowV4GitSourceColl().Upsert(args); err != nil {
+		logger.Errorf("failed to save workflow git source of project %s, error: %s", projectName, err)
+		return e.ErrUpsertWorkflow.AddErr(err)
+	}
+	return nil
+}
+
+func GetWorkflowV4GitSource(projectName string, logger *zap.SugaredLogger) (*WorkflowV4GitSourceStatus, error) {
+	source, err := commonrepo.NewWorkflowV4GitSourceColl().Find(projectName)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		logger.Errorf("failed to find workflow git source of project %s, error: %s", projectName, err)
+		return nil, e.ErrFindWorkflow.AddErr(err)
+	}
+
+	workflows, _, err := commonrepo.NewWorkflowV4Coll().List(&commonrepo.ListWorkflowV4Option{ProjectName: projectName}, 0, 0)
+	if err != nil {
+		logger.Errorf("failed to list workflows of project %s, error: %s", projectName, err)
+		return nil, e.ErrFindWorkflow.AddErr(err)
+	}
+
+	resp := &WorkflowV4GitSourceStatus{
+		WorkflowV4GitSource: source,
+		Workflows:           make([]*WorkflowV4GitStatus, 0),
+	}
+	for _, workflow := range workflows {
+		if workflow.GitSource == nil {
+			continue
+		}
+		resp.Workflows = append(resp.Workflows, &WorkflowV4GitStatus{
+			Name:        workflow.Name,
+			DisplayName: workflow.DisplayName,
+			Path:        workflow.GitSource.Path,
+			CommitSHA:   workflow.GitSource.CommitSHA,
+			Drifted:     workflow.Hash != workflow.GitSource.Hash,
+		})
+	}
+	return resp, nil
+}
+
+// DeleteWorkflowV4GitSource unbinds the project from git, the workflows are kept and can be edited in zadig again.
+func DeleteWorkflowV4GitSource(projectName string, logger *zap.SugaredLogger) error {
+	if err := commonrepo.NewWorkflowV4GitSourceColl().Delete(projectName); err != nil {
+		logger.Errorf("failed to delete workflow git source of project %s, error: %s", projectName, err)
+		return e.ErrUpsertWorkflow.AddErr(err)
+	}
+	if err := commonrepo.NewWorkflowV4Coll().UnsetGitSourceByProject(projectName); err != nil {
+		logger.Errorf("failed to release git managed workflows of project %s, error: %s", projectName, err)
+		return e.ErrUpsertWorkflow.AddErr(err)
+	}
+	return nil
+}
+
+// EnsureWorkflowV4Editable returns an error if the workflow is managed in git and must not be changed in zadig.
+func EnsureWorkflowV4Editable(name string) error {
+	workflow, err := commonrepo.NewWorkflowV4Coll().Find(name)
+	if err != nil {
+		return nil
+	}
+	return ensureWorkflowV4Editable(workflow)
+}
+
+func ensureWorkflowV4Editable(workflow *commonmodels.WorkflowV4) error {
+	if workflow.GitSource == nil {
+		return nil
+	}
+	return fmt.Errorf("工作流 [%s] 由代码库文件 %s 管理，请通过提交代码修改", workflow.DisplayName, workflow.GitSource.Path)
+}
+
+// SyncWorkflowV4FromGit reconciles the workflows of the project with the definitions in its git source at the given commit,
+// the latest commit of the bound branch is used if commitSHA is empty. Nothing is changed if any definition is invalid or
+// dryRun is set, the planned changes are returned either way.
+func SyncWorkflowV4FromGit(projectName, commitSHA string, dryRun bool, user string, logger *zap.SugaredLogger) (*WorkflowV4GitSyncResult, error) {
+	source, err := commonrepo.NewWorkflowV4GitSourceColl().Find(projectName)
+	if err != nil {
+		logger.Errorf("failed to find workflow git source of project %s, error: %s", projectName, err)
+		return nil, e.ErrFindWorkflow.AddDesc("项目未绑定工作流代码库")
+	}
+
+	commit := &commonmodels.Commit{SHA: commitSHA}
+	if commitSHA == "" {
+		commits, err := codeservice.CodeHostListCommits(source.CodehostID, source.RepoName, source.GetNamespace(), source.BranchName, 1, 1, logger)
+		if err != nil {
+			logger.Errorf("failed to get latest commit of %s/%s:%s, error: %s", source.GetNamespace(), source.RepoName, source.BranchName, err)
+			return nil, e.ErrUpsertWorkflow.AddErr(err)
+		}
+		if len(commits) > 0 {
+			commit = &commonmodels.Commit{SHA: commits[0].ID, Message: commits[0].Message}
+		}
+	}
+
+	result := &WorkflowV4GitSyncResult{
+		ProjectName: projectName,
+		CommitSHA:   commit.SHA,
+		DryRun:      dryRun,
+		Changes:     make([]*WorkflowV4GitChange, 0),
+		Errors:      make([]*WorkflowV4GitSyncError, 0),
+	}
+
+	files, err := loadWorkflowV4GitFiles(source, commit.SHA, result)
+	if err != nil {
+		logger.Errorf("failed to load workflows of project %s from git, error: %s", projectName, err)
+		if !dryRun {
+			_ = commonrepo.NewWorkflowV4GitSourceColl().UpdateSyncStatus(projectName, nil, err.Error())
+		}
+		return nil, e.ErrUpsertWorkflow.AddErr(err)
+	}
+
+	plan, err := planWorkflowV4GitSync(projectName, files, result, logger)
+	if err != nil {
+		return nil, e.ErrUpsertWorkflow.AddErr(err)
+	}
+	if dryRun || len(result.Errors) > 0 {
+		if !dryRun {
+			_ = commonrepo.NewWorkflowV4GitSourceColl().UpdateSyncStatus(projectName, nil, formatWorkflowV4GitSyncErrors(result.Errors))
+		}
+		return result, nil
+	}
+
+	for _, change := range result.Changes {
+		if err := applyWorkflowV4GitChange(change, plan[change.WorkflowName], commit.SHA, user, logger); err != nil {
+			result.Errors = append(result.Errors, &WorkflowV4GitSyncError{Path: change.Path, WorkflowName: change.WorkflowName, Error: err.Error()})
+		}
+	}
+	result.Applied = true
+
+	if err := commonrepo.NewWorkflowV4GitSourceColl().UpdateSyncStatus(projectName, commit, formatWorkflowV4GitSyncErrors(result.Errors)); err != nil {
+		logger.Errorf("failed to update workflow git sync status of project %s, error: %s", projectName, err)
+	}
+	return result, nil
+}
+
+func loadWorkflowV4GitFiles(source *commonmodels.WorkflowV4GitSource, ref string, result *WorkflowV4GitSyncResult) ([]*workflowV4GitFile, error) {
+	if ref == "" {
+		ref = source.BranchName
+	}
+	getter, err := fsservice.GetTreeGetter(source.CodehostID)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := getter.GetTree(source.GetNamespace(), source.RepoName, source.Path, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s in %s/%s", source.Path, source.GetNamespace(), source.RepoName)
+	}
+
+	files := make([]*workflowV4GitFile, 0)
+	for _, node := range nodes {
+		if node.IsDir || !isWorkflowV4GitFile(node.Name) {
+			continue
+		}
+		filePath := node.FullPath
+		if filePath == "" {
+			filePath = path.Join(source.Path, node.Name)
+		}
+		content, err := getter.GetFileContent(source.GetNamespace(), source.RepoName, filePath, ref)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to download %s", filePath)
+		}
+
+		workflow := new(commonmodels.WorkflowV4)
+		if err := yaml.Unmarshal(content, workflow); err != nil {
+			result.Errors = append(result.Errors, &WorkflowV4GitSyncError{Path: filePath, Error: fmt.Sprintf("invalid yaml: %s", err)})
+			continue
+		}
+		files = append(files, &workflowV4GitFile{path: filePath, workflow: workflow})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return files, nil
+}
+
+func isWorkflowV4GitFile(name string) bool {
+	ext := path.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// planWorkflowV4GitSync lints the definitions and fills the changes of the result, the returned map holds the
+// desired workflow of every created or updated workflow.
+func planWorkflowV4GitSync(projectName string, files []*workflowV4GitFile, result *WorkflowV4GitSyncResult, logger *zap.SugaredLogger) (map[string]*workflowV4GitFile, error) {
+	desired := make(map[string]*workflowV4GitFile)
+	for _, file := range files {
+		workflow := file.workflow
+		addError := func(msg string) {
+			result.Errors = append(result.Errors, &WorkflowV4GitSyncError{Path: file.path, WorkflowName: workflow.Name, Error: msg})
+		}
+
+		if workflow.Name == "" {
+			addError("workflow name is empty")
+			continue
+		}
+		if workflow.Project == "" {
+			workflow.Project = projectName
+		}
+		if workflow.Project != projectName {
+			addError(fmt.Sprintf("workflow belongs to project %s instead of %s", workflow.Project, projectName))
+			continue
+		}
+		if existed, ok := desired[workflow.Name]; ok {
+			addError(fmt.Sprintf("workflow is also defined in %s", existed.path))
+			continue
+		}
+		if err := LintWorkflowV4(workflow, logger); err != nil {
+			addError(err.Error())
+			continue
+		}
+		desired[workflow.Name] = file
+	}
+
+	workflows, _, err := commonrepo.NewWorkflowV4Coll().List(&commonrepo.ListWorkflowV4Option{ProjectName: projectName}, 0, 0)
+	if err != nil {
+		logger.Errorf("failed to list workflows of project %s, error: %s", projectName, err)
+		return nil, err
+	}
+	existing := make(map[string]*commonmodels.WorkflowV4)
+	for _, workflow := range workflows {
+		existing[workflow.Name] = workflow
+	}
+
+	for _, file := range files {
+		if desired[file.workflow.Name] != file {
+			continue
+		}
+		change := &WorkflowV4GitChange{WorkflowName: file.workflow.Name, Path: file.path}
+		current, ok := existing[file.workflow.Name]
+		if !ok {
+			if other, err := commonrepo.NewWorkflowV4Coll().Find(file.workflow.Name); err == nil {
+				result.Errors = append(result.Errors, &WorkflowV4GitSyncError{
+					Path:         file.path,
+					WorkflowName: file.workflow.Name,
+					Error:        fmt.Sprintf("workflow name is already used in project %s", other.Project),
+				})
+				continue
+			}
+			change.Action = WorkflowV4GitActionCreate
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+		if current.GitSource == nil {
+			result.Errors = append(result.Errors, &WorkflowV4GitSyncError{
+				Path:         file.path,
+				WorkflowName: file.workflow.Name,
+				Error:        "workflow already exists and is not managed by git, delete or rename it first",
+			})
+			continue
+		}
+
+		diff, err := diffWorkflowV4ForGit(current, file.workflow)
+		if err != nil {
+			return nil, err
+		}
+		change.Action = WorkflowV4GitActionUnchanged
+		if len(diff) > 0 {
+			change.Action = WorkflowV4GitActionUpdate
+			change.Diff = diff
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	for _, workflow := range workflows {
+		if workflow.GitSource == nil {
+			continue
+		}
+		if _, ok := desired[workflow.Name]; ok || workflowV4GitFileFailed(result.Errors, workflow) {
+			continue
+		}
+		result.Changes = append(result.Changes, &WorkflowV4GitChange{
+			WorkflowName: workflow.Name,
+			Path:         workflow.GitSource.Path,
+			Action:       WorkflowV4GitActionDelete,
+		})
+	}
+	return desired, nil
+}
+
+// workflowV4GitFileFailed tells whether the file of a managed workflow is still in git but failed to load,
+// such workflows must not be planned for deletion.
+func workflowV4GitFileFailed(syncErrors []*WorkflowV4GitSyncError, workflow *commonmodels.WorkflowV4) bool {
+	for _, syncErr := range syncErrors {
+		if syncErr.WorkflowName == workflow.Name || syncErr.Path == workflow.GitSource.Path {
+			return true
+		}
+	}
+	return false
+}
+
+func diffWorkflowV4ForGit(current, desired *commonmodels.WorkflowV4) ([]*commonmodels.JSONPatchOperation, error) {
+	from, err := normalizeWorkflowV4ForGit(current)
+	if err != nil {
+		return nil, err
+	}
+	to, err := normalizeWorkflowV4ForGit(desired)
+	if err != nil {
+		return nil, err
+	}
+	return createJSONPatchOperations(from, to)
+}
+
+// normalizeWorkflowV4ForGit converts the workflow to the generic form it has in git, so that only the fields
+// which can be defined in git are compared.
+func normalizeWorkflowV4ForGit(workflow *commonmodels.WorkflowV4) (map[string]interface{}, error) {
+	content, err := yaml.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	resp := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &resp); err != nil {
+		return nil, err
+	}
+	for _, field := range workflowV4GitVolatileFields {
+		delete(resp, field)
+	}
+	return resp, nil
+}
+
+func applyWorkflowV4GitChange(change *WorkflowV4GitChange, file *workflowV4GitFile, commitSHA, user string, logger *zap.SugaredLogger) error {
+	switch change.Action {
+	case WorkflowV4GitActionDelete:
+		return deleteWorkflowV4(change.WorkflowName, logger)
+	case WorkflowV4GitActionCreate:
+		if err := CreateWorkflowV4(user, file.workflow, logger); err != nil {
+			return err
+		}
+	case WorkflowV4GitActionUpdate:
+		if err := updateWorkflowV4(change.WorkflowName, user, file.workflow, logger); err != nil {
+			return err
+		}
+	}
+
+	saved, err := commonrepo.NewWorkflowV4Coll().Find(change.WorkflowName)
+	if err != nil {
+		return err
+	}
+	return commonrepo.NewWorkflowV4Coll().SetGitSource(change.WorkflowName, &commonmodels.WorkflowV4GitRef{
+		Path:      change.Path,
+		CommitSHA: commitSHA,
+		Hash:      saved.Hash,
+	})
+}
+
+func formatWorkflowV4GitSyncErrors(syncErrors []*WorkflowV4GitSyncError) string {
+	msgs := make([]string, 0, len(syncErrors))
+	for _, syncErr := range syncErrors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", syncErr.Path, syncErr.Error))
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// WorkflowV4GitSourceChanged tells whether any of the changed files of a push is in the bound path.
+func WorkflowV4GitSourceChanged(source *commonmodels.WorkflowV4GitSource, changedFiles []string) bool {
+	dir := strings.Trim(source.Path, "/")
+	if dir == "" {
+		dir = "."
+	}
+	for _, file := range changedFiles {
+		if isWorkflowV4GitFile(file) && path.Dir(strings.Trim(file, "/")) == dir {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+)
+
+var _ = Describe("Testing workflow git source", func() {
+
+	Context("WorkflowV4GitSourceChanged", func() {
+		source := &commonmodels.WorkflowV4GitSource{Path: "/zadig/workflows/"}
+
+		It("should match yaml files in the bound directory", func() {
+			Expect(WorkflowV4GitSourceChanged(source, []string{"README.md", "zadig/workflows/build.yaml"})).Should(BeTrue())
+		})
+		It("should ignore nested directories and other files", func() {
+			Expect(WorkflowV4GitSourceChanged(source, []string{"zadig/workflows/docs/build.yaml", "zadig/workflows/README.md"})).Should(BeFalse())
+		})
+		It("should match top level files for the repository root", func() {
+			root := &commonmodels.WorkflowV4GitSource{}
+			Expect(WorkflowV4GitSourceChanged(root, []string{"build.yml"})).Should(BeTrue())
+			Expect(WorkflowV4GitSourceChanged(root, []string{"ci/build.yml"})).Should(BeFalse())
+		})
+	})
+
+	Context("ensureWorkflowV4Editable", func() {
+		It("should allow workflows created in zadig", func() {
+			Expect(ensureWorkflowV4Editable(&commonmodels.WorkflowV4{Name: "build"})).Should(Succeed())
+		})
+		It("should refuse workflows managed in git", func() {
+			workflow := &commonmodels.WorkflowV4{Name: "build", GitSource: &commonmodels.WorkflowV4GitRef{Path: "zadig/workflows/build.yaml"}}
+			Expect(ensureWorkflowV4Editable(workflow)).ShouldNot(Succeed())
+		})
+	})
+
+	Context("diffWorkflowV4ForGit", func() {
+		It("should ignore fields changed on save", func() {
+			current := &commonmodels.WorkflowV4{Name: "build", Project: "demo", UpdatedBy: "admin", UpdateTime: 1, Hash: "a"}
+			desired := &commonmodels.WorkflowV4{Name: "build", Project: "demo"}
+			diff, err := diffWorkflowV4ForGit(current, desired)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(diff).Should(BeEmpty())
+		})
+		It("should report changed fields", func() {
+			current := &commonmodels.WorkflowV4{Name: "build", Project: "demo", DisplayName: "build"}
+			desired := &commonmodels.WorkflowV4{Name: "build", Project: "demo", DisplayName: "build-v2"}
+			diff, err := diffWorkflowV4ForGit(current, desired)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(diff).Should(HaveLen(1))
+			Expect(diff[0].Path).Should(Equal("/display_name"))
+		})
+	})
+})
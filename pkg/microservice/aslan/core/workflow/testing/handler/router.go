@@ -79,6 +79,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		scanner.GET("/:id/task", FindScanningProjectNameFromID, ListScanningTask)
 		scanner.GET("/:id/task/:scan_id", FindScanningProjectNameFromID, GetScanningTask)
 		scanner.GET("/:id/task/:scan_id/events", FindScanningProjectNameFromID, GetScanningTaskEvents)
+		scanner.GET("/:id/task/:scan_id/sarif", FindScanningProjectNameFromID, GetScanningTaskSARIF)
 		scanner.DELETE("/:id/task/:scan_id", FindScanningProjectNameFromID, CancelScanningTask)
 		scanner.GET("/:id/task/:scan_id/sse", FindScanningProjectNameFromID, GetScanningTaskSSE)
 
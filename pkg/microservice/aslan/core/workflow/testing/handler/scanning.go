@@ -536,6 +536,46 @@ func GetScanningTask(c *gin.Context) {
 	ctx.Resp, ctx.RespErr = service.GetScanningTaskInfo(scanningID, taskID, ctx.Logger)
 }
 
+func GetScanningTaskSARIF(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectKey := c.GetString("projectKey")
+	scanningID := c.Param("id")
+
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectKey]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+
+		if !ctx.Resources.ProjectAuthInfo[projectKey].IsProjectAdmin &&
+			!ctx.Resources.ProjectAuthInfo[projectKey].Scanning.View {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	taskID, err := strconv.ParseInt(c.Param("scan_id"), 10, 64)
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc(fmt.Sprintf("invalid task id: %s", err))
+		return
+	}
+
+	resp, err := service.GetScanningTaskSARIF(scanningID, taskID, ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+	c.Data(200, "application/sarif+json", resp)
+}
+
 func GetScanningTaskEvents(c *gin.Context) {
 	ctx, err := internalhandler.NewContextWithAuthorization(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
@@ -39,6 +39,7 @@ import (
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/sonar"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
 	"github.com/koderover/zadig/v2/pkg/types"
 	jobspec "github.com/koderover/zadig/v2/pkg/types/job"
 	stepspec "github.com/koderover/zadig/v2/pkg/types/step"
@@ -59,6 +60,11 @@ func CreateScanningModule(username string, args *Scanning, log *zap.SugaredLogge
 			return e.ErrCreateScanningModule.AddErr(err)
 		}
 	}
+	if args.ScannerType == types.ScannerTypeImageVuln {
+		if err := validateImageVulnScanningConfig(args); err != nil {
+			return e.ErrCreateScanningModule.AddErr(err)
+		}
+	}
 
 	err := util.CheckDefineResourceParam(args.AdvancedSetting.ResReq, args.AdvancedSetting.ResReqSpec)
 	if err != nil {
@@ -98,6 +104,11 @@ func UpdateScanningModule(id, username string, args *Scanning, log *zap.SugaredL
 			return e.ErrUpdateScanningModule.AddErr(err)
 		}
 	}
+	if args.ScannerType == types.ScannerTypeImageVuln {
+		if err := validateImageVulnScanningConfig(args); err != nil {
+			return e.ErrUpdateScanningModule.AddErr(err)
+		}
+	}
 
 	scanning, err := commonrepo.NewScanningColl().GetByID(id)
 	if err != nil {
@@ -154,6 +165,21 @@ func validateAIReviewScanningConfig(args *Scanning) error {
 	return nil
 }
 
+func validateImageVulnScanningConfig(args *Scanning) error {
+	if args.Infrastructure != "" && args.Infrastructure != setting.JobK8sInfrastructure {
+		return fmt.Errorf("image vulnerability scanning only supports Kubernetes infrastructure")
+	}
+	if args.ImageScanSetting == nil || args.ImageScanSetting.Image == "" {
+		return fmt.Errorf("image to scan cannot be empty")
+	}
+	if args.ImageScanSetting.RegistryID != "" {
+		if _, err := commonrepo.NewRegistryNamespaceColl().Find(&commonrepo.FindRegOps{ID: args.ImageScanSetting.RegistryID}); err != nil {
+			return fmt.Errorf("failed to find registry %s, error: %v", args.ImageScanSetting.RegistryID, err)
+		}
+	}
+	return trivy.ValidateThresholds(args.ImageScanSetting.Thresholds)
+}
+
 type ListCodeRepoScanningRespItem struct {
 	CodehostID        int                 `json:"codehost_id"`
 	Source            string              `json:"source"`
@@ -540,6 +566,7 @@ func GetScanningTaskInfo(scanningID string, taskID int64, log *zap.SugaredLogger
 	jobName := ""
 	isHasArtifact := false
 	var aiReviewReport *AIReviewReportResult
+	var imageVulnScan *ImageVulnScanResult
 	for _, step := range jobTaskSpec.Steps {
 		if step.Name == config.TestJobArchiveResultStepName {
 			if step.StepType != config.StepTarArchive {
@@ -561,6 +588,21 @@ func GetScanningTaskInfo(scanningID string, taskID int64, log *zap.SugaredLogger
 			}
 			jobName = step.JobName
 		}
+		if step.StepType == config.StepImageVulnScan {
+			scanSpec := new(stepspec.StepImageVulnScanSpec)
+			if err := commonmodels.IToi(step.Spec, scanSpec); err != nil {
+				return nil, fmt.Errorf("decode image vulnerability scan step: %w", err)
+			}
+			imageVulnScan = &ImageVulnScanResult{
+				Image:           scanSpec.Image,
+				Summary:         scanSpec.Summary,
+				Thresholds:      scanSpec.Thresholds,
+				ExceededReasons: scanSpec.ExceededReasons,
+				CollectionError: scanSpec.CollectionError,
+				HasSARIF:        scanSpec.Summary != nil && scanSpec.SARIFObjectKey != "",
+			}
+			jobName = step.JobName
+		}
 	}
 
 	sonarMetrics := &stepspec.SonarMetrics{}
@@ -625,6 +667,7 @@ func GetScanningTaskInfo(scanningID string, taskID int64, log *zap.SugaredLogger
 		IsHasArtifact:  isHasArtifact,
 		JobDisplayName: workflowTask.Stages[0].Jobs[0].DisplayName,
 		AIReviewReport: aiReviewReport,
+		ImageVulnScan:  imageVulnScan,
 	}, nil
 }
 
@@ -41,6 +41,8 @@ import (
 	commonutil "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/util"
 	workflowservice "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/workflow/service/workflow"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
 	tartool "github.com/koderover/zadig/v2/pkg/tool/tar"
@@ -58,6 +60,12 @@ func CreateTesting(username string, testing *commonmodels.Testing, log *zap.Suga
 	if err := commonutil.CheckDefineResourceParam(testing.PreTest.ResReq, testing.PreTest.ResReqSpec); err != nil {
 		return e.ErrCreateTestModule.AddDesc(err.Error())
 	}
+	if err := buildcache.ValidateRules(testing.CacheRules); err != nil {
+		return e.ErrCreateTestModule.AddDesc(err.Error())
+	}
+	if err := validateCoverageReport(testing); err != nil {
+		return e.ErrCreateTestModule.AddDesc(err.Error())
+	}
 	err := HandleCronjob(testing, log)
 	if err != nil {
 		return e.ErrCreateTestModule.AddErr(err)
@@ -124,6 +132,12 @@ func UpdateTesting(username string, testing *commonmodels.Testing, log *zap.Suga
 	if err := commonutil.CheckDefineResourceParam(testing.PreTest.ResReq, testing.PreTest.ResReqSpec); err != nil {
 		return e.ErrUpdateTestModule.AddDesc(err.Error())
 	}
+	if err := buildcache.ValidateRules(testing.CacheRules); err != nil {
+		return e.ErrUpdateTestModule.AddDesc(err.Error())
+	}
+	if err := validateCoverageReport(testing); err != nil {
+		return e.ErrUpdateTestModule.AddDesc(err.Error())
+	}
 	err := HandleCronjob(testing, log)
 	if err != nil {
 		return e.ErrUpdateTestModule.AddErr(err)
@@ -648,3 +662,18 @@ func validateTestReportParam(pipelineName, pipelineType, taskIDStr, testName str
 
 	return nil
 }
+
+func validateCoverageReport(testing *commonmodels.Testing) error {
+	switch testing.CoverageFormat {
+	case "", coverage.FormatCobertura, coverage.FormatJaCoCo, coverage.FormatLCOV, coverage.FormatGo:
+	default:
+		return fmt.Errorf("unsupported coverage report format: %s", testing.CoverageFormat)
+	}
+	if testing.MinLineCoverage < 0 || testing.MinLineCoverage > 100 {
+		return fmt.Errorf("min line coverage must be between 0 and 100")
+	}
+	if testing.MaxCoverageDrop < 0 || testing.MaxCoverageDrop > 100 {
+		return fmt.Errorf("max coverage drop must be between 0 and 100")
+	}
+	return nil
+}
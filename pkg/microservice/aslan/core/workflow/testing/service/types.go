@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
 	"github.com/koderover/zadig/v2/pkg/types"
 	"github.com/koderover/zadig/v2/pkg/types/step"
 )
@@ -56,6 +57,9 @@ type Scanning struct {
 	ReviewExcludePaths []string                   `json:"review_exclude_paths"`
 	ReviewRules        []*commonmodels.ReviewRule `json:"review_rules"`
 
+	// Image Vulnerability Scanning Configs
+	ImageScanSetting *commonmodels.ImageScanSetting `json:"image_scan_setting"`
+
 	// template IDs
 	TemplateID string `json:"template_id"`
 }
@@ -232,6 +236,7 @@ type ScanningTaskDetail struct {
 	JobName        string                `json:"job_name"`
 	JobDisplayName string                `json:"job_display_name"`
 	AIReviewReport *AIReviewReportResult `json:"ai_review_report,omitempty"`
+	ImageVulnScan  *ImageVulnScanResult  `json:"image_vuln_scan,omitempty"`
 }
 
 type AIReviewReportResult struct {
@@ -239,6 +244,16 @@ type AIReviewReportResult struct {
 	CollectionError string               `json:"collection_error,omitempty"`
 }
 
+type ImageVulnScanResult struct {
+	Image           string             `json:"image"`
+	Summary         *trivy.Summary     `json:"summary,omitempty"`
+	Thresholds      []*trivy.Threshold `json:"thresholds"`
+	ExceededReasons []string           `json:"exceeded_reasons"`
+	CollectionError string             `json:"collection_error,omitempty"`
+	// HasSARIF indicates whether the sarif report could be fetched
+	HasSARIF bool `json:"has_sarif"`
+}
+
 func ConvertToDBScanningModule(args *Scanning) *commonmodels.Scanning {
 	// ID is omitted since they are of different type and there will be no use of it
 	return &commonmodels.Scanning{
@@ -264,6 +279,7 @@ func ConvertToDBScanningModule(args *Scanning) *commonmodels.Scanning {
 		ReviewIncludePaths: args.ReviewIncludePaths,
 		ReviewExcludePaths: args.ReviewExcludePaths,
 		ReviewRules:        args.ReviewRules,
+		ImageScanSetting:   args.ImageScanSetting,
 	}
 }
 
@@ -295,6 +311,7 @@ func ConvertDBScanningModule(scanning *commonmodels.Scanning) *Scanning {
 		ReviewIncludePaths: scanning.ReviewIncludePaths,
 		ReviewExcludePaths: scanning.ReviewExcludePaths,
 		ReviewRules:        scanning.ReviewRules,
+		ImageScanSetting:   scanning.ImageScanSetting,
 	}
 }
 
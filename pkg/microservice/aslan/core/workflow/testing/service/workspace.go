@@ -19,6 +19,7 @@ package service
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -379,3 +380,58 @@ func GetWorkflowV4ScanningArtifactInfo(scanningID, jobName string, taskID int64,
 	resp.FileNames = fis
 	return resp, nil
 }
+
+// GetScanningTaskSARIF returns the sarif report generated by the image vulnerability scanning task
+func GetScanningTaskSARIF(scanningID string, taskID int64, log *zap.SugaredLogger) ([]byte, error) {
+	scanningInfo, err := commonrepo.NewScanningColl().GetByID(scanningID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scanning from mongodb, the error is: %s", err)
+	}
+
+	workflowName := commonutil.GenScanningWorkflowName(scanningInfo.ID.Hex())
+	workflowTask, err := mongodb.NewworkflowTaskv4Coll().Find(workflowName, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find workflow task, workflow name: %s, task id: %d", workflowName, taskID)
+	}
+	if len(workflowTask.Stages) != 1 || len(workflowTask.Stages[0].Jobs) != 1 {
+		return nil, fmt.Errorf("invalid scan task")
+	}
+
+	jobSpec := &commonmodels.JobTaskFreestyleSpec{}
+	if err := commonmodels.IToi(workflowTask.Stages[0].Jobs[0].Spec, jobSpec); err != nil {
+		return nil, fmt.Errorf("unmashal job spec error: %v", err)
+	}
+	objectKey := ""
+	for _, stepTask := range jobSpec.Steps {
+		if stepTask.StepType != config.StepImageVulnScan {
+			continue
+		}
+		stepSpec := &step.StepImageVulnScanSpec{}
+		if err := commonmodels.IToi(stepTask.Spec, stepSpec); err != nil {
+			return nil, fmt.Errorf("unmashal step spec error: %v", err)
+		}
+		objectKey = stepSpec.SARIFObjectKey
+	}
+	if objectKey == "" {
+		return nil, fmt.Errorf("no sarif report found in scanning task %d", taskID)
+	}
+
+	storage, err := s3.FindDefaultS3()
+	if err != nil {
+		log.Errorf("GetScanningTaskSARIF FindDefaultS3 err:%v", err)
+		return nil, err
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		log.Errorf("GetScanningTaskSARIF create s3 client err:%v", err)
+		return nil, err
+	}
+	object, err := client.GetFile(storage.Bucket, storage.GetObjectPath(objectKey), &s3tool.DownloadOption{RetryNum: 2})
+	if err != nil {
+		log.Errorf("GetScanningTaskSARIF GetFile err:%s", err)
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	return io.ReadAll(object.Body)
+}
@@ -172,6 +172,12 @@ func (s *engine) injectRouterGroup(router *gin.RouterGroup) {
 		podexec.GET("/:productName/:podName/:containerName/podExec/:envName", podexecservice.ServeWs)
 		podexec.GET("/production/:productName/:podName/:containerName/podExec/:envName", podexecservice.ServeWs)
 		podexec.GET("/debug/:workflowName/:jobName/task/:taskID", podexecservice.DebugWorkflow)
+
+		podexec.GET("/terminal/sessions", podexecservice.ListTerminalSessions)
+		podexec.GET("/terminal/sessions/:id/recording", podexecservice.GetTerminalSessionRecording)
+		podexec.GET("/terminal/requests", podexecservice.ListTerminalAccessRequests)
+		podexec.POST("/terminal/requests", podexecservice.CreateTerminalAccessRequest)
+		podexec.POST("/terminal/requests/:id/review", podexecservice.ReviewTerminalAccessRequest)
 	}
 
 	// inject picket APIs
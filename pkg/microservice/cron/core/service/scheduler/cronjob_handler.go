@@ -214,6 +214,11 @@ func (h *CronjobHandler) updateCronjob(name, productName, scheduleType, jobType
 				if err != nil {
 					return err
 				}
+			case setting.EnvHealthReportCronjob:
+				err := h.registerEnvHealthReportJob(name, cron, job)
+				if err != nil {
+					return err
+				}
 			case setting.EnvSleepCronjob:
 				err := h.registerEnvSleepJob(name, cron, job)
 				if err != nil {
@@ -539,6 +544,34 @@ func registerCronjob(job *service.Cronjob, client *client.Client, scheduler *cro
 				return err
 			}
 
+			log.Infof("registering jobID: %s with cron: %s", job.ID, cron)
+			err = scheduler.UpdateJobModel(job.ID, scheduleJob)
+			if err != nil {
+				log.Errorf("Failed to register job of ID: %s to scheduler, the error is: %v", job.ID, err)
+				return err
+			}
+		case setting.EnvHealthReportCronjob:
+			if job.EnvAnalysisArgs == nil {
+				return nil
+			}
+
+			var cron string
+			if job.JobType == "" || job.JobType == setting.CrontabCronjob {
+				cron = fmt.Sprintf("%s%s", "0 ", job.Cron)
+			} else {
+				cron, _ = convertCronString(job.JobType, job.Time, job.Frequency, job.Number)
+			}
+
+			scheduleJob, err := cronlib.NewJobModel(cron, func() {
+				if err := client.ScheduleCall(envHealthReportURL(job.EnvAnalysisArgs), nil, log.SugaredLogger()); err != nil {
+					log.Errorf("[%s]RunScheduledTask err: %v", job.Name, err)
+				}
+			})
+			if err != nil {
+				log.Errorf("Failed to create job of ID: %s, the error is: %v", job.ID, err)
+				return err
+			}
+
 			log.Infof("registering jobID: %s with cron: %s", job.ID, cron)
 			err = scheduler.UpdateJobModel(job.ID, scheduleJob)
 			if err != nil {
@@ -623,6 +656,37 @@ func (h *CronjobHandler) registerEnvAnalysisJob(name, schedule string, job *serv
 	return nil
 }
 
+func (h *CronjobHandler) registerEnvHealthReportJob(name, schedule string, job *service.Schedule) error {
+	if job.EnvAnalysisArgs == nil {
+		return nil
+	}
+	scheduleJob, err := cronlib.NewJobModel(schedule, func() {
+		if err := h.aslanCli.ScheduleCall(envHealthReportURL(job.EnvAnalysisArgs), nil, log.SugaredLogger()); err != nil {
+			log.Errorf("[%s]RunScheduledTask err: %v", name, err)
+		}
+	})
+	if err != nil {
+		log.Errorf("Failed to create job of ID: %s, the error is: %v", job.ID.Hex(), err)
+		return err
+	}
+
+	log.Infof("registering jobID: %s with cron: %s", job.ID.Hex(), schedule)
+	err = h.Scheduler.UpdateJobModel(job.ID.Hex(), scheduleJob)
+	if err != nil {
+		log.Errorf("Failed to register job of ID: %s to scheduler, the error is: %v", job.ID, err)
+		return err
+	}
+	return nil
+}
+
+func envHealthReportURL(args *service.EnvArgs) string {
+	production := "false"
+	if args.Production {
+		production = "true"
+	}
+	return fmt.Sprintf("environment/environments/%s/health-reports?projectName=%s&triggerName=%s&production=%s", args.EnvName, args.ProductName, setting.CronTaskCreator, production)
+}
+
 func (h *CronjobHandler) registerEnvSleepJob(name, schedule string, job *service.Schedule) error {
 	if job.EnvArgs == nil {
 		return nil
@@ -107,6 +107,21 @@ func RunStep(ctx context.Context, step *meta.Step, workspace, paths string, envs
 		if err != nil {
 			return err
 		}
+	case "sbom":
+		stepInstance, err = NewSBOMStep(step.Spec, workspace, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
+	case "image_sign":
+		stepInstance, err = NewImageSignStep(step.Spec, workspace, envs, secretEnvs)
+		if err != nil {
+			return err
+		}
+	case "image_vuln_scan":
+		stepInstance, err = NewImageVulnScanStep(step.Spec, workspace, envs, secretEnvs, updater)
+		if err != nil {
+			return err
+		}
 	case "debug_before":
 		stepInstance, err = NewDebugStep("before", workspace, envs, secretEnvs, updater)
 		if err != nil {
@@ -117,6 +132,21 @@ func RunStep(ctx context.Context, step *meta.Step, workspace, paths string, envs
 		if err != nil {
 			return err
 		}
+	case "restore_cache":
+		stepInstance, err = NewCacheStep(false, step.Spec, workspace, envs, secretEnvs, updater)
+		if err != nil {
+			return err
+		}
+	case "save_cache":
+		stepInstance, err = NewCacheStep(true, step.Spec, workspace, envs, secretEnvs, updater)
+		if err != nil {
+			return err
+		}
+	case "coverage_report":
+		stepInstance, err = NewCoverageReportStep(step.Spec, workspace, envs, secretEnvs, updater)
+		if err != nil {
+			return err
+		}
 	case "perforce":
 		stepInstance, err = NewP4Step(step.Spec, workspace, envs, secretEnvs)
 		if err != nil {
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/configmap"
+	"github.com/koderover/zadig/v2/pkg/tool/buildcache"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	"github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+// CacheStep restores or saves the named caches of a job. Cache failures are logged and recorded in the cache
+// stats but never fail the job. Every entry is recorded in its own metadata object, so concurrent jobs sharing
+// a store never overwrite the entries saved by each other.
+type CacheStep struct {
+	spec       *step.StepCacheSpec
+	save       bool
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	updater    configmap.Updater
+}
+
+func NewCacheStep(save bool, spec interface{}, workspace string, envs, secretEnvs []string, updater configmap.Updater) (*CacheStep, error) {
+	cacheStep := &CacheStep{save: save, workspace: workspace, envs: envs, secretEnvs: secretEnvs, updater: updater}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return cacheStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &cacheStep.spec); err != nil {
+		return cacheStep, fmt.Errorf("unmarshal spec %s to cache spec failed", yamlBytes)
+	}
+	return cacheStep, nil
+}
+
+func (s *CacheStep) Run(ctx context.Context) error {
+	if len(s.spec.Rules) == 0 {
+		return nil
+	}
+	tempDir, err := os.MkdirTemp("", "zadig-cache-")
+	if err != nil {
+		log.Errorf("failed to create cache temp dir, err: %s", err)
+		return nil
+	}
+	defer os.RemoveAll(tempDir)
+
+	stats := s.loadStats()
+	if err := s.run(tempDir, stats); err != nil {
+		log.Errorf("cache store is not available, err: %s", err)
+		for _, stat := range stats {
+			if stat.Error == "" {
+				stat.Error = err.Error()
+			}
+		}
+	}
+	if err := s.saveStats(stats); err != nil {
+		log.Warnf("failed to record cache stats, err: %s", err)
+	}
+	return nil
+}
+
+func (s *CacheStep) run(tempDir string, stats []*step.CacheStat) error {
+	store, err := s.newStore()
+	if err != nil {
+		return err
+	}
+	idx, err := buildcache.LoadIndex(store, tempDir)
+	if err != nil {
+		return fmt.Errorf("load cache index: %w", err)
+	}
+
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	for i, rule := range s.spec.Rules {
+		stat := stats[i]
+		if stat.Key == "" {
+			key, err := buildcache.Key(util.ReplaceEnvWithValue(rule.Key, envMap), s.workspace, rule.KeyFiles)
+			if err != nil {
+				log.Warnf("failed to compute key of cache %s, err: %s", rule.Name, err)
+				stat.Result = types.CacheMiss
+				stat.Error = err.Error()
+				continue
+			}
+			stat.Key = key
+		}
+
+		if s.save {
+			if err := s.saveEntry(store, idx, rule, stat, envMap, tempDir); err != nil {
+				log.Warnf("failed to save cache %s, err: %s", rule.Name, err)
+				stat.Error = err.Error()
+			}
+		} else {
+			if err := s.restoreEntry(store, idx, rule, stat, envMap, tempDir); err != nil {
+				log.Warnf("failed to restore cache %s, err: %s", rule.Name, err)
+				stat.Result = types.CacheMiss
+				stat.Error = err.Error()
+			}
+		}
+	}
+
+	if s.save && s.spec.QuotaInMiB > 0 {
+		evicted := idx.Evict(s.spec.QuotaInMiB * 1024 * 1024)
+		if len(evicted) > 0 {
+			for _, entry := range evicted {
+				for _, stat := range stats {
+					if stat.Name == entry.Name {
+						stat.Evicted = append(stat.Evicted, entry.Key)
+					}
+				}
+				log.Infof("evict cache %s with key %s", entry.Name, entry.Key)
+			}
+			if err := buildcache.DeleteEntries(store, evicted); err != nil {
+				log.Warnf("failed to delete evicted caches, err: %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *CacheStep) restoreEntry(store buildcache.Store, idx *buildcache.Index, rule *types.CacheRule, stat *step.CacheStat, envMap map[string]string, tempDir string) error {
+	if s.spec.IgnoreCache {
+		log.Infof("cache %s is ignored", rule.Name)
+		stat.Result = types.CacheSkipped
+		return nil
+	}
+
+	restoreKeys := make([]string, 0, len(rule.RestoreKeys))
+	for _, restoreKey := range rule.RestoreKeys {
+		restoreKeys = append(restoreKeys, util.ReplaceEnvWithValue(restoreKey, envMap))
+	}
+	entry, exact := idx.Match(rule.Name, stat.Key, restoreKeys)
+	if entry == nil {
+		log.Infof("cache %s with key %s is not found", rule.Name, stat.Key)
+		stat.Result = types.CacheMiss
+		return nil
+	}
+
+	archive := filepath.Join(tempDir, "restore.tar.gz")
+	defer os.Remove(archive)
+	if err := store.Read(entry.Path(), archive); err != nil {
+		return err
+	}
+	if out, err := exec.Command("tar", "xzPf", archive).CombinedOutput(); err != nil {
+		return fmt.Errorf("extract cache: %s %v", strings.TrimSpace(string(out)), err)
+	}
+
+	stat.MatchedKey = entry.Key
+	stat.RestoreSize = entry.Size
+	stat.Result = types.CachePartialHit
+	if exact {
+		stat.Result = types.CacheHit
+	}
+	log.Infof("restored cache %s from key %s (%s)", rule.Name, entry.Key, stat.Result)
+	idx.Touch(entry.Name, entry.Key, time.Now().Unix())
+	if err := buildcache.SaveEntry(store, entry, tempDir); err != nil {
+		log.Warnf("failed to record the usage of cache %s, err: %s", rule.Name, err)
+	}
+	return nil
+}
+
+func (s *CacheStep) saveEntry(store buildcache.Store, idx *buildcache.Index, rule *types.CacheRule, stat *step.CacheStat, envMap map[string]string, tempDir string) error {
+	if idx.Get(rule.Name, stat.Key) != nil {
+		log.Infof("cache %s with key %s already exists, skip saving", rule.Name, stat.Key)
+		return nil
+	}
+
+	paths := make([]string, 0, len(rule.Paths))
+	for _, p := range rule.Paths {
+		p = util.ReplaceEnvWithValue(p, envMap)
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(s.workspace, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			log.Warnf("cache path %s of cache %s does not exist, skip", p, rule.Name)
+			continue
+		}
+		paths = append(paths, p)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("none of the cache paths exists")
+	}
+
+	archive := filepath.Join(tempDir, "save.tar.gz")
+	defer os.Remove(archive)
+	args := append([]string{"czPf", archive}, paths...)
+	if out, err := exec.Command("tar", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("compress cache: %s %v", strings.TrimSpace(string(out)), err)
+	}
+	info, err := os.Stat(archive)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	entry := &buildcache.Entry{Name: rule.Name, Key: stat.Key, Size: info.Size(), CreatedAt: now, LastUsed: now}
+	if err := store.Write(archive, entry.Path()); err != nil {
+		return err
+	}
+	if err := buildcache.SaveEntry(store, entry, tempDir); err != nil {
+		return err
+	}
+	idx.Add(entry)
+	stat.Saved = true
+	stat.SaveSize = entry.Size
+	log.Infof("saved cache %s with key %s, size %d", rule.Name, stat.Key, entry.Size)
+	return nil
+}
+
+func (s *CacheStep) newStore() (buildcache.Store, error) {
+	switch s.spec.MediumType {
+	case types.NFSMedium:
+		return buildcache.NewDirStore(filepath.Join(types.NamedCacheNFSMountPath, s.spec.StoreDir)), nil
+	case types.ObjectMedium:
+		if s.spec.S3 == nil {
+			return nil, fmt.Errorf("cache object storage is not configured")
+		}
+		client, err := s3.NewClient(s.spec.S3.Endpoint, s.spec.S3.Ak, s.spec.S3.Sk, s.spec.S3.Region, s.spec.S3.Insecure, s.spec.S3.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("create s3 client: %w", err)
+		}
+		return buildcache.NewObjectStore(client, s.spec.S3.Bucket, s.spec.StoreDir), nil
+	default:
+		return nil, fmt.Errorf("unknown cache medium type: %s", s.spec.MediumType)
+	}
+}
+
+// loadStats returns the stats of the rules, the keys computed by the restore step are reused when saving so that
+// changes to the key files during the build do not change the key.
+func (s *CacheStep) loadStats() []*step.CacheStat {
+	recorded := make(map[string]*step.CacheStat)
+	if s.save && s.updater != nil {
+		if cm, err := s.updater.Get(); err == nil && cm.Data[types.JobCacheStatsKey] != "" {
+			stats := make([]*step.CacheStat, 0)
+			if err := json.Unmarshal([]byte(cm.Data[types.JobCacheStatsKey]), &stats); err == nil {
+				for _, stat := range stats {
+					recorded[stat.Name] = stat
+				}
+			}
+		}
+	}
+
+	stats := make([]*step.CacheStat, 0, len(s.spec.Rules))
+	for _, rule := range s.spec.Rules {
+		stat, ok := recorded[rule.Name]
+		if !ok {
+			stat = &step.CacheStat{Name: rule.Name, Result: types.CacheMiss}
+		}
+		stat.Error = ""
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// saveStats writes the stats to the job ConfigMap so that aslan could record them in the task
+func (s *CacheStep) saveStats(stats []*step.CacheStat) error {
+	if s.updater == nil {
+		return fmt.Errorf("cache stats ConfigMap updater is not configured")
+	}
+	statsBytes, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	cm, err := s.updater.Get()
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[types.JobCacheStatsKey] = string(statsBytes)
+	return s.updater.UpdateWithRetry(cm, 3, 3*time.Second)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/configmap"
+	"github.com/koderover/zadig/v2/pkg/tool/coverage"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+	jobtypes "github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+type CoverageReportStep struct {
+	spec       *step.StepCoverageReportSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	updater    configmap.Updater
+}
+
+func NewCoverageReportStep(spec interface{}, workspace string, envs, secretEnvs []string, updater configmap.Updater) (*CoverageReportStep, error) {
+	coverageStep := &CoverageReportStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs, updater: updater}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return coverageStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &coverageStep.spec); err != nil {
+		return coverageStep, fmt.Errorf("unmarshal spec %s to coverage report spec failed", yamlBytes)
+	}
+	return coverageStep, nil
+}
+
+func (s *CoverageReportStep) Run(ctx context.Context) error {
+	log.Info("Start parsing coverage reports.")
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+
+	reports := make([]*coverage.Report, 0)
+	for _, reportPath := range s.spec.ReportPaths {
+		pattern := filepath.Join(s.workspace, util.ReplaceEnvWithValue(reportPath, envMap))
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid coverage report path %s: %s", reportPath, err)
+		}
+		for _, match := range matches {
+			data, err := os.ReadFile(match)
+			if err != nil {
+				return fmt.Errorf("failed to read coverage report %s: %s", match, err)
+			}
+			report, err := coverage.Parse(s.spec.Format, data)
+			if err != nil {
+				return fmt.Errorf("failed to parse coverage report %s: %s", match, err)
+			}
+			reports = append(reports, report)
+		}
+	}
+	if len(reports) == 0 {
+		return fmt.Errorf("no coverage report found in %s", strings.Join(s.spec.ReportPaths, ", "))
+	}
+	report := coverage.Merge(reports...)
+	log.Infof("Coverage of %d files: %s.", report.FileCount, report.Summary.String())
+
+	if err := s.upload(report); err != nil {
+		return err
+	}
+
+	result := &step.CoverageReportResult{
+		Summary:      &report.Summary,
+		GateFailures: report.GateFailures(s.spec.Base, s.spec.MinLineCoverage, s.spec.MaxCoverageDrop),
+	}
+	if err := s.saveResult(result); err != nil {
+		return err
+	}
+	if len(result.GateFailures) > 0 {
+		return fmt.Errorf("coverage gate failed: %s", strings.Join(result.GateFailures, ", "))
+	}
+	return nil
+}
+
+// upload archives the per file coverage, it is too large for the job ConfigMap
+func (s *CoverageReportStep) upload(report *coverage.Report) error {
+	if s.spec.S3DestDir == "" || s.spec.S3Storage == nil {
+		return nil
+	}
+	if err := os.MkdirAll(s.spec.DestDir, os.ModePerm); err != nil {
+		return fmt.Errorf("create dest dir: %s error: %s", s.spec.DestDir, err)
+	}
+	reportBytes, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal coverage report: %s", err)
+	}
+	absFilePath := filepath.Join(s.spec.DestDir, coverage.ReportJSONFile)
+	if err := os.WriteFile(absFilePath, reportBytes, 0644); err != nil {
+		return fmt.Errorf("write coverage report: %s", err)
+	}
+
+	client, err := s3.NewClient(s.spec.S3Storage.Endpoint, s.spec.S3Storage.Ak, s.spec.S3Storage.Sk, s.spec.S3Storage.Region, s.spec.S3Storage.Insecure, s.spec.S3Storage.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client to upload file, err: %s", err)
+	}
+	key := strings.TrimLeft(path.Join(s.spec.S3Storage.Subfolder, s.spec.S3DestDir, coverage.ReportJSONFile), "/")
+	if err := client.Upload(s.spec.S3Storage.Bucket, absFilePath, key); err != nil {
+		return fmt.Errorf("failed to upload coverage report: %s", err)
+	}
+	return nil
+}
+
+// saveResult writes the summary to the job ConfigMap so that aslan could record it in the task
+func (s *CoverageReportStep) saveResult(result *step.CoverageReportResult) error {
+	if s.updater == nil {
+		return fmt.Errorf("coverage report ConfigMap updater is not configured")
+	}
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal coverage summary: %w", err)
+	}
+	cm, err := s.updater.Get()
+	if err != nil {
+		return fmt.Errorf("get job ConfigMap for coverage summary: %w", err)
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[jobtypes.JobCoverageReportKey] = string(resultBytes)
+	if err := s.updater.UpdateWithRetry(cm, 3, 3*time.Second); err != nil {
+		return fmt.Errorf("write coverage summary to job ConfigMap: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+const cosignExe = "cosign"
+
+type ImageSignStep struct {
+	spec       *step.StepImageSignSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewImageSignStep(spec interface{}, workspace string, envs, secretEnvs []string) (*ImageSignStep, error) {
+	imageSignStep := &ImageSignStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return imageSignStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &imageSignStep.spec); err != nil {
+		return imageSignStep, fmt.Errorf("unmarshal spec %s to image sign spec failed", yamlBytes)
+	}
+	return imageSignStep, nil
+}
+
+func (s *ImageSignStep) Run(ctx context.Context) error {
+	start := time.Now()
+	log.Infof("Start signing image.")
+	defer func() {
+		log.Infof("Image signing ended. Duration: %.2f seconds.", time.Since(start).Seconds())
+	}()
+
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	if image, ok := envMap["IMAGE"]; ok {
+		s.spec.Image = image
+	}
+	if s.spec.KeyRef == "" {
+		return fmt.Errorf("signing key is not configured")
+	}
+	keyRef := util.ReplaceEnvWithValue(s.spec.KeyRef, envMap)
+
+	if s.spec.DockerRegistry != nil && s.spec.DockerRegistry.UserName != "" {
+		log.Infof("Logging in Docker Registry: %s.", s.spec.DockerRegistry.Host)
+		loginCmd := exec.Command(cosignExe, "login", strings.TrimPrefix(strings.TrimPrefix(s.spec.DockerRegistry.Host, "https://"), "http://"),
+			"-u", s.spec.DockerRegistry.UserName, "--password-stdin")
+		loginCmd.Stdin = strings.NewReader(s.spec.DockerRegistry.Password)
+		loginCmd.Env = s.envs
+		if out, err := loginCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to login docker registry: %s %s", err, util.MaskSecretEnvs(string(out), s.secretEnvs))
+		}
+	}
+
+	tlogUpload := fmt.Sprintf("--tlog-upload=%t", s.spec.TlogUpload)
+	cmds := []*exec.Cmd{exec.Command(cosignExe, "sign", "--yes", "--key", keyRef, tlogUpload, s.spec.Image)}
+	for _, attestation := range s.spec.Attestations {
+		predicate := filepath.Join(s.workspace, util.ReplaceEnvWithValue(attestation.PredicateFile, envMap))
+		cmds = append(cmds, exec.Command(cosignExe, "attest", "--yes", "--key", keyRef, tlogUpload,
+			"--type", attestation.PredicateType, "--predicate", predicate, s.spec.Image))
+	}
+
+	for _, c := range cmds {
+		c.Dir = s.workspace
+		c.Env = s.envs
+		if err := runCmdWithOutput(c, s.secretEnvs); err != nil {
+			return fmt.Errorf("failed to run %s %s: %s", cosignExe, c.Args[1], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/jobexecutor/core/service/configmap"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/tool/trivy"
+	jobtypes "github.com/koderover/zadig/v2/pkg/types"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+const trivyExe = "trivy"
+
+type ImageVulnScanStep struct {
+	spec       *step.StepImageVulnScanSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+	updater    configmap.Updater
+}
+
+func NewImageVulnScanStep(spec interface{}, workspace string, envs, secretEnvs []string, updater configmap.Updater) (*ImageVulnScanStep, error) {
+	scanStep := &ImageVulnScanStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs, updater: updater}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return scanStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &scanStep.spec); err != nil {
+		return scanStep, fmt.Errorf("unmarshal spec %s to image vulnerability scan spec failed", yamlBytes)
+	}
+	return scanStep, nil
+}
+
+func (s *ImageVulnScanStep) Run(ctx context.Context) error {
+	start := time.Now()
+	log.Infof("Start scanning image vulnerabilities.")
+	defer func() {
+		log.Infof("Image vulnerability scanning ended. Duration: %.2f seconds.", time.Since(start).Seconds())
+	}()
+
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	image := util.ReplaceEnvWithValue(s.spec.Image, envMap)
+	if image == "" {
+		return fmt.Errorf("image to scan is empty")
+	}
+
+	outputDir := filepath.Join(s.workspace, s.spec.OutputDir)
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create scan output dir %s: %s", outputDir, err)
+	}
+	jsonReport := filepath.Join(outputDir, trivy.ReportJSONFile)
+	sarifReport := filepath.Join(outputDir, trivy.ReportSARIFFile)
+
+	// trivy reads the registry credential from the env instead of the docker config
+	envs := append([]string{}, s.envs...)
+	if s.spec.DockerRegistry != nil && s.spec.DockerRegistry.UserName != "" {
+		envs = append(envs,
+			"TRIVY_USERNAME="+s.spec.DockerRegistry.UserName,
+			"TRIVY_PASSWORD="+s.spec.DockerRegistry.Password,
+		)
+	}
+
+	args := []string{"image", "--format", "json", "--output", jsonReport, "--exit-code", "0"}
+	if s.spec.DBRepository != "" {
+		args = append(args, "--db-repository", s.spec.DBRepository)
+	}
+	if s.spec.SkipDBUpdate {
+		args = append(args, "--skip-db-update", "--skip-java-db-update")
+	}
+	if s.spec.IgnoreUnfixed {
+		args = append(args, "--ignore-unfixed")
+	}
+	args = append(args, image)
+
+	cmds := []*exec.Cmd{
+		exec.Command(trivyExe, args...),
+		// convert the json report instead of scanning twice
+		exec.Command(trivyExe, "convert", "--format", "sarif", "--output", sarifReport, jsonReport),
+	}
+	for _, c := range cmds {
+		c.Dir = s.workspace
+		c.Env = envs
+		if err := runCmdWithOutput(c, s.secretEnvs); err != nil {
+			return fmt.Errorf("failed to scan image %s: %s", image, err)
+		}
+	}
+
+	data, err := os.ReadFile(jsonReport)
+	if err != nil {
+		return fmt.Errorf("failed to read scan report %s: %s", jsonReport, err)
+	}
+	summary, err := trivy.ParseReport(data)
+	if err != nil {
+		return err
+	}
+	log.Infof("Vulnerabilities found in %s: %s.", image, summary)
+
+	if err := s.saveSummary(summary); err != nil {
+		return err
+	}
+
+	if reasons := summary.ExceededThresholds(s.spec.Thresholds); len(reasons) > 0 {
+		return fmt.Errorf("vulnerability thresholds exceeded: %s", strings.Join(reasons, ", "))
+	}
+	return nil
+}
+
+// saveSummary writes the summary to the job ConfigMap so that aslan could record it in the task
+func (s *ImageVulnScanStep) saveSummary(summary *trivy.Summary) error {
+	if s.updater == nil {
+		return fmt.Errorf("image vulnerability scan ConfigMap updater is not configured")
+	}
+	summaryBytes, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("marshal image vulnerability scan summary: %w", err)
+	}
+	cm, err := s.updater.Get()
+	if err != nil {
+		return fmt.Errorf("get job ConfigMap for image vulnerability scan summary: %w", err)
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[jobtypes.JobImageVulnScanKey] = string(summaryBytes)
+	if err := s.updater.UpdateWithRetry(cm, 3, 3*time.Second); err != nil {
+		return fmt.Errorf("write image vulnerability scan summary to job ConfigMap: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types/step"
+	"github.com/koderover/zadig/v2/pkg/util"
+)
+
+const syftExe = "syft"
+
+type SBOMStep struct {
+	spec       *step.StepSBOMSpec
+	envs       []string
+	secretEnvs []string
+	workspace  string
+}
+
+func NewSBOMStep(spec interface{}, workspace string, envs, secretEnvs []string) (*SBOMStep, error) {
+	sbomStep := &SBOMStep{workspace: workspace, envs: envs, secretEnvs: secretEnvs}
+	yamlBytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return sbomStep, fmt.Errorf("marshal spec %+v failed", spec)
+	}
+	if err := yaml.Unmarshal(yamlBytes, &sbomStep.spec); err != nil {
+		return sbomStep, fmt.Errorf("unmarshal spec %s to sbom spec failed", yamlBytes)
+	}
+	return sbomStep, nil
+}
+
+func (s *SBOMStep) Run(ctx context.Context) error {
+	start := time.Now()
+	log.Infof("Start generating SBOM.")
+	defer func() {
+		log.Infof("SBOM generation ended. Duration: %.2f seconds.", time.Since(start).Seconds())
+	}()
+
+	envMap := util.MakeEnvMap(s.envs, s.secretEnvs)
+	if image, ok := envMap["IMAGE"]; ok {
+		s.spec.Image = image
+	}
+	s.spec.SourceDir = util.ReplaceEnvWithValue(s.spec.SourceDir, envMap)
+
+	outputDir := filepath.Join(s.workspace, s.spec.OutputDir)
+	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create sbom output dir %s: %s", outputDir, err)
+	}
+
+	// syft reads the registry credential from the env instead of the docker config
+	envs := append([]string{}, s.envs...)
+	if s.spec.DockerRegistry != nil && s.spec.DockerRegistry.UserName != "" {
+		envs = append(envs,
+			"SYFT_REGISTRY_AUTH_AUTHORITY="+s.spec.DockerRegistry.Host,
+			"SYFT_REGISTRY_AUTH_USERNAME="+s.spec.DockerRegistry.UserName,
+			"SYFT_REGISTRY_AUTH_PASSWORD="+s.spec.DockerRegistry.Password,
+		)
+	}
+
+	output := fmt.Sprintf("%s-json=%s", s.spec.GetFormat(), filepath.Join(outputDir, s.spec.ImageSBOMFile()))
+	cmds := []*exec.Cmd{exec.Command(syftExe, "scan", "registry:"+s.spec.Image, "-o", output)}
+	if s.spec.ScanSource {
+		sourceDir := filepath.Join(s.workspace, s.spec.SourceDir)
+		output := fmt.Sprintf("%s-json=%s", s.spec.GetFormat(), filepath.Join(outputDir, s.spec.SourceSBOMFile()))
+		cmds = append(cmds, exec.Command(syftExe, "scan", "dir:"+sourceDir, "-o", output))
+	}
+
+	for _, c := range cmds {
+		c.Dir = s.workspace
+		c.Env = envs
+		if err := runCmdWithOutput(c, s.secretEnvs); err != nil {
+			return fmt.Errorf("failed to generate sbom: %s", err)
+		}
+	}
+	return nil
+}
+
+// runCmdWithOutput runs the command and prints its output with the secrets masked
+func runCmdWithOutput(c *exec.Cmd, secretEnvs []string) error {
+	cmdOutReader, err := c.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmdErrReader, err := c.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		handleCmdOutput(cmdOutReader, false, "", secretEnvs)
+	}()
+	go func() {
+		defer wg.Done()
+		handleCmdOutput(cmdErrReader, false, "", secretEnvs)
+	}()
+
+	if err := c.Start(); err != nil {
+		return err
+	}
+	wg.Wait()
+
+	return c.Wait()
+}
@@ -17,22 +17,27 @@ limitations under the License.
 package service
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
 	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/setting"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/kube/getter"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	"github.com/koderover/zadig/v2/pkg/types"
 )
 
 func ServeWs(c *gin.Context) {
@@ -63,7 +68,45 @@ func ServeWs(c *gin.Context) {
 	}
 	namespace, clusterID := productInfo.Namespace, productInfo.ClusterID
 
-	pty, err := NewTerminalSession(c.Writer, c.Request, nil)
+	// every attempt is audited with the session it opens, including the ones denied by the terminal policy
+	sessionID := primitive.NewObjectID()
+	accessRequest, reason, policyErr := checkTerminalPolicy(productInfo, ctx.UserID, c.Query("reason"), c.Query("requestID"))
+	accessRequestID := ""
+	if accessRequest != nil {
+		accessRequestID = accessRequest.ID.Hex()
+	}
+	detail := fmt.Sprintf("%s:%s:%s", envName, podName, containerName)
+	logBody, _ := json.Marshal(map[string]string{"session_id": sessionID.Hex(), "reason": reason, "access_request_id": accessRequestID})
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, productName, setting.OperationSceneEnv, "登录", "容器终端", detail, detail, string(logBody), types.RequestBodyTypeJSON, ctx.Logger, envName, podName, sessionID.Hex())
+	if policyErr != nil {
+		ctx.RespErr = policyErr
+		return
+	}
+
+	audit, err := startTerminalAudit(&commonmodels.TerminalSession{
+		ID:              sessionID,
+		ProjectName:     productName,
+		EnvName:         envName,
+		Production:      productInfo.Production,
+		Namespace:       namespace,
+		ClusterID:       clusterID,
+		PodName:         podName,
+		ContainerName:   containerName,
+		UserID:          ctx.UserID,
+		Username:        ctx.UserName,
+		Reason:          reason,
+		AccessRequestID: accessRequestID,
+	}, productInfo.TerminalPolicy != nil && productInfo.TerminalPolicy.Record)
+	if err != nil {
+		ctx.RespErr = e.ErrOpenTerminal.AddErr(err)
+		return
+	}
+	defer audit.finish(ctx.Logger)
+
+	pty, err := NewTerminalSession(c.Writer, c.Request, nil, &TerminalSessionOption{
+		Type:     Environment,
+		Recorder: audit.recorder,
+	})
 	if err != nil {
 		log.Errorf("get pty failed: %v", err)
 		ctx.RespErr = e.ErrInternalError.AddDesc(fmt.Sprintf("get pty failed: %v", err))
@@ -108,6 +151,155 @@ func ServeWs(c *gin.Context) {
 	}
 }
 
+func ListTerminalSessions(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(ListTerminalSessionsArgs)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	if args.ProjectName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+
+	// terminal recordings may contain anything printed in the containers, only project admins can audit them
+	if !isTerminalProjectAdmin(ctx.Resources, args.ProjectName) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = listTerminalSessions(args)
+}
+
+func GetTerminalSessionRecording(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+	if !isTerminalProjectAdmin(ctx.Resources, projectName) {
+		ctx.UnAuthorized = true
+		return
+	}
+
+	data, err := getTerminalSessionRecording(projectName, c.Param("id"))
+	if err != nil {
+		ctx.RespErr = err
+		return
+	}
+	c.Data(http.StatusOK, "application/x-asciicast", data)
+}
+
+func CreateTerminalAccessRequest(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+
+	data, err := internalhandler.GetRawData(c)
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	args := new(CreateTerminalAccessRequestArgs)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectName, setting.OperationSceneEnv, "申请", "容器终端访问", args.EnvName, args.EnvName, string(data), types.RequestBodyTypeJSON, ctx.Logger, args.EnvName)
+
+	if !ctx.Resources.IsSystemAdmin {
+		if _, ok := ctx.Resources.ProjectAuthInfo[projectName]; !ok {
+			ctx.UnAuthorized = true
+			return
+		}
+	}
+
+	ctx.Resp, ctx.RespErr = createTerminalAccessRequest(projectName, ctx.UserID, ctx.UserName, args)
+}
+
+func ListTerminalAccessRequests(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	args := new(ListTerminalAccessRequestsArgs)
+	if err := c.ShouldBindQuery(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	if args.ProjectName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = listTerminalAccessRequests(args, ctx.UserID, isTerminalProjectAdmin(ctx.Resources, args.ProjectName))
+}
+
+func ReviewTerminalAccessRequest(c *gin.Context) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	if err != nil {
+		ctx.RespErr = fmt.Errorf("authorization Info Generation failed: err %s", err)
+		ctx.UnAuthorized = true
+		return
+	}
+
+	projectName := c.Query("projectName")
+	if projectName == "" {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("projectName can not be empty")
+		return
+	}
+
+	data, err := internalhandler.GetRawData(c)
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	args := new(ReviewTerminalAccessRequestArgs)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	internalhandler.InsertDetailedOperationLog(c, ctx.UserName, projectName, setting.OperationSceneEnv, "审批", "容器终端访问", c.Param("id"), c.Param("id"), string(data), types.RequestBodyTypeJSON, ctx.Logger, c.Param("id"))
+
+	ctx.RespErr = reviewTerminalAccessRequest(projectName, c.Param("id"), ctx.UserID, ctx.UserName, ctx.Resources.IsSystemAdmin, isTerminalProjectAdmin(ctx.Resources, projectName), args)
+}
+
 func DebugWorkflow(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	asciicastVersion = 2
+
+	asciicastEventOutput = "o"
+	asciicastEventInput  = "i"
+	asciicastEventResize = "r"
+
+	defaultTerminalWidth  = 80
+	defaultTerminalHeight = 24
+)
+
+type asciicastHeader struct {
+	Version   int    `json:"version"`
+	Width     uint16 `json:"width"`
+	Height    uint16 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title,omitempty"`
+}
+
+// TerminalRecorder writes the input, output and resize events of a terminal session in the
+// asciicast v2 format, see https://docs.asciinema.org/manual/asciicast/v2/.
+// A nil recorder discards all events.
+type TerminalRecorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+
+	title  string
+	start  time.Time
+	width  uint16
+	height uint16
+	// the header is written lazily so that the first resize sent by the client sets the initial size
+	headerWritten bool
+	err           error
+}
+
+func NewTerminalRecorder(w io.Writer, title string) *TerminalRecorder {
+	return newTerminalRecorder(w, title, time.Now)
+}
+
+func newTerminalRecorder(w io.Writer, title string, now func() time.Time) *TerminalRecorder {
+	return &TerminalRecorder{
+		w:      w,
+		now:    now,
+		title:  title,
+		start:  now(),
+		width:  defaultTerminalWidth,
+		height: defaultTerminalHeight,
+	}
+}
+
+func (r *TerminalRecorder) Input(data []byte) {
+	if r == nil || len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writeEvent(asciicastEventInput, string(data))
+}
+
+func (r *TerminalRecorder) Output(data []byte) {
+	if r == nil || len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writeEvent(asciicastEventOutput, string(data))
+}
+
+func (r *TerminalRecorder) Resize(width, height uint16) {
+	if r == nil || width == 0 || height == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.headerWritten {
+		r.width, r.height = width, height
+		return
+	}
+	r.writeEvent(asciicastEventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Close writes the header of an empty recording and returns the first write error, if any.
+func (r *TerminalRecorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writeHeader()
+	return r.err
+}
+
+func (r *TerminalRecorder) writeHeader() {
+	if r.headerWritten || r.err != nil {
+		return
+	}
+	r.headerWritten = true
+	r.writeLine(asciicastHeader{
+		Version:   asciicastVersion,
+		Width:     r.width,
+		Height:    r.height,
+		Timestamp: r.start.Unix(),
+		Title:     r.title,
+	})
+}
+
+func (r *TerminalRecorder) writeEvent(code, data string) {
+	r.writeHeader()
+	elapsed := r.now().Sub(r.start).Seconds()
+	r.writeLine([]interface{}{elapsed, code, data})
+}
+
+func (r *TerminalRecorder) writeLine(v interface{}) {
+	if r.err != nil {
+		return
+	}
+	line, err := json.Marshal(v)
+	if err != nil {
+		r.err = err
+		return
+	}
+	_, r.err = r.w.Write(append(line, '\n'))
+}
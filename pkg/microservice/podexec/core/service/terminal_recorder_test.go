@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalRecorder(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	now := start
+	buf := &bytes.Buffer{}
+	r := newTerminalRecorder(buf, "prod/nginx", func() time.Time { return now })
+
+	r.Resize(120, 40)
+	now = start.Add(500 * time.Millisecond)
+	r.Input([]byte("ls\r"))
+	now = start.Add(time.Second)
+	r.Output([]byte("a.txt\r\n"))
+	r.Resize(100, 30)
+	r.Output(nil)
+	assert.NoError(t, r.Close())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		`{"version":2,"width":120,"height":40,"timestamp":1700000000,"title":"prod/nginx"}`,
+		`[0.5,"i","ls\r"]`,
+		`[1,"o","a.txt\r\n"]`,
+		`[1,"r","100x30"]`,
+	}, lines)
+}
+
+func TestTerminalRecorderEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := newTerminalRecorder(buf, "", func() time.Time { return time.Unix(1700000000, 0) })
+	assert.NoError(t, r.Close())
+	assert.Equal(t, `{"version":2,"width":80,"height":24,"timestamp":1700000000}`+"\n", buf.String())
+
+	var nilRecorder *TerminalRecorder
+	nilRecorder.Output([]byte("ignored"))
+	assert.NoError(t, nilRecorder.Close())
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	commonmodels "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/models"
+	commonrepo "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/repository/mongodb"
+	s3service "github.com/koderover/zadig/v2/pkg/microservice/aslan/core/common/service/s3"
+	"github.com/koderover/zadig/v2/pkg/shared/client/user"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	s3tool "github.com/koderover/zadig/v2/pkg/tool/s3"
+)
+
+const defaultTerminalApprovalTTL = 30 * time.Minute
+
+// checkTerminalPolicy verifies that the user can open a shell in the environment under its terminal policy.
+// It returns the access request the shell is opened with, if the policy requires one, and the reason of the session.
+func checkTerminalPolicy(env *commonmodels.Product, userID, reason, requestID string) (*commonmodels.TerminalAccessRequest, string, error) {
+	policy := env.TerminalPolicy
+	if policy == nil {
+		return nil, reason, nil
+	}
+
+	var accessRequest *commonmodels.TerminalAccessRequest
+	if policy.RequireApproval {
+		if requestID == "" {
+			return nil, reason, e.ErrOpenTerminal.AddDesc("an approved access request is required to open a shell in this environment")
+		}
+		request, err := commonrepo.NewTerminalAccessRequestColl().GetByID(requestID)
+		if err != nil {
+			return nil, reason, e.ErrOpenTerminal.AddDesc(fmt.Sprintf("failed to find access request %s: %s", requestID, err))
+		}
+		if err := validateTerminalAccessRequest(request, env, userID, time.Now()); err != nil {
+			return nil, reason, e.ErrOpenTerminal.AddErr(err)
+		}
+		if reason == "" {
+			reason = request.Reason
+		}
+		accessRequest = request
+	}
+
+	if policy.RequireReason && strings.TrimSpace(reason) == "" {
+		return accessRequest, reason, e.ErrOpenTerminal.AddDesc("a reason is required to open a shell in this environment")
+	}
+	return accessRequest, reason, nil
+}
+
+func validateTerminalAccessRequest(request *commonmodels.TerminalAccessRequest, env *commonmodels.Product, userID string, now time.Time) error {
+	if request.ProjectName != env.ProductName || request.EnvName != env.EnvName || request.Production != env.Production {
+		return fmt.Errorf("access request %s is not for environment %s", request.ID.Hex(), env.EnvName)
+	}
+	if request.UserID != userID {
+		return fmt.Errorf("access request %s belongs to another user", request.ID.Hex())
+	}
+	if request.Status != commonmodels.TerminalAccessRequestStatusApproved {
+		return fmt.Errorf("access request %s is %s", request.ID.Hex(), request.Status)
+	}
+	if request.ExpireTime <= now.Unix() {
+		return fmt.Errorf("access request %s has expired", request.ID.Hex())
+	}
+	return nil
+}
+
+// terminalAudit tracks a shell session from the moment it is opened, recording it if the policy asks for it.
+type terminalAudit struct {
+	session  *commonmodels.TerminalSession
+	file     *os.File
+	writer   *bufio.Writer
+	recorder *TerminalRecorder
+}
+
+func startTerminalAudit(session *commonmodels.TerminalSession, record bool) (*terminalAudit, error) {
+	session.StartTime = time.Now().Unix()
+	if err := commonrepo.NewTerminalSessionColl().Create(session); err != nil {
+		return nil, fmt.Errorf("failed to create terminal session: %s", err)
+	}
+
+	audit := &terminalAudit{session: session}
+	if !record {
+		return audit, nil
+	}
+
+	file, err := os.CreateTemp("", "terminal-*.cast")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create terminal recording file: %s", err)
+	}
+	audit.file = file
+	audit.writer = bufio.NewWriter(file)
+	audit.recorder = NewTerminalRecorder(audit.writer, fmt.Sprintf("%s/%s/%s/%s", session.ProjectName, session.EnvName, session.PodName, session.ContainerName))
+	return audit, nil
+}
+
+// finish closes the session and uploads its recording, failures are only logged since the shell is already gone.
+func (a *terminalAudit) finish(logger *zap.SugaredLogger) {
+	a.session.EndTime = time.Now().Unix()
+	if a.recorder != nil {
+		if err := a.uploadRecording(); err != nil {
+			logger.Errorf("failed to upload recording of terminal session %s: %s", a.session.ID.Hex(), err)
+		}
+	}
+
+	if err := commonrepo.NewTerminalSessionColl().Finish(a.session); err != nil {
+		logger.Errorf("failed to finish terminal session %s: %s", a.session.ID.Hex(), err)
+	}
+}
+
+func (a *terminalAudit) uploadRecording() error {
+	defer func() {
+		_ = a.file.Close()
+		_ = os.Remove(a.file.Name())
+	}()
+
+	if err := a.recorder.Close(); err != nil {
+		return err
+	}
+	if err := a.writer.Flush(); err != nil {
+		return err
+	}
+
+	storage, err := s3service.FindDefaultS3()
+	if err != nil {
+		return err
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return err
+	}
+
+	session := a.session
+	objectKey := storage.GetObjectPath(fmt.Sprintf("terminal-sessions/%s/%s/%s.cast", session.ProjectName, session.EnvName, session.ID.Hex()))
+	if err := client.Upload(storage.Bucket, a.file.Name(), objectKey); err != nil {
+		return err
+	}
+
+	session.Recorded = true
+	// the storage falls back to the system default when none is configured
+	if !storage.ID.IsZero() {
+		session.StorageID = storage.ID.Hex()
+	}
+	session.ObjectKey = objectKey
+	return nil
+}
+
+type ListTerminalSessionsArgs struct {
+	ProjectName string `form:"projectName"`
+	EnvName     string `form:"envName"`
+	Production  *bool  `form:"production"`
+	PodName     string `form:"podName"`
+	Username    string `form:"username"`
+	StartTime   int64  `form:"startTime"`
+	EndTime     int64  `form:"endTime"`
+	Page        int64  `form:"page"`
+	PageSize    int64  `form:"pageSize"`
+}
+
+type ListTerminalSessionsResp struct {
+	Sessions []*commonmodels.TerminalSession `json:"sessions"`
+	Total    int64                           `json:"total"`
+}
+
+func listTerminalSessions(args *ListTerminalSessionsArgs) (*ListTerminalSessionsResp, error) {
+	sessions, total, err := commonrepo.NewTerminalSessionColl().List(&commonrepo.TerminalSessionListOption{
+		ProjectName: args.ProjectName,
+		EnvName:     args.EnvName,
+		Production:  args.Production,
+		PodName:     args.PodName,
+		Username:    args.Username,
+		StartTime:   args.StartTime,
+		EndTime:     args.EndTime,
+		Page:        args.Page,
+		PageSize:    args.PageSize,
+	})
+	if err != nil {
+		return nil, e.ErrListTerminalSession.AddErr(err)
+	}
+	return &ListTerminalSessionsResp{Sessions: sessions, Total: total}, nil
+}
+
+func getTerminalSessionRecording(projectName, id string) ([]byte, error) {
+	session, err := commonrepo.NewTerminalSessionColl().GetByID(id)
+	if err != nil {
+		return nil, e.ErrGetTerminalRecording.AddErr(err)
+	}
+	if session.ProjectName != projectName {
+		return nil, e.ErrGetTerminalRecording.AddDesc(fmt.Sprintf("terminal session %s does not belong to project %s", id, projectName))
+	}
+	if !session.Recorded {
+		return nil, e.ErrGetTerminalRecording.AddDesc(fmt.Sprintf("terminal session %s was not recorded", id))
+	}
+
+	var storage *s3service.S3
+	if session.StorageID != "" {
+		storage, err = s3service.FindS3ById(session.StorageID)
+	} else {
+		storage, err = s3service.FindDefaultS3()
+	}
+	if err != nil {
+		return nil, e.ErrGetTerminalRecording.AddErr(err)
+	}
+	client, err := s3tool.NewClient(storage.Endpoint, storage.Ak, storage.Sk, storage.Region, storage.Insecure, storage.Provider)
+	if err != nil {
+		return nil, e.ErrGetTerminalRecording.AddErr(err)
+	}
+	object, err := client.GetFile(storage.Bucket, session.ObjectKey, &s3tool.DownloadOption{RetryNum: 2})
+	if err != nil {
+		return nil, e.ErrGetTerminalRecording.AddErr(err)
+	}
+	defer object.Body.Close()
+
+	data, err := io.ReadAll(object.Body)
+	if err != nil {
+		return nil, e.ErrGetTerminalRecording.AddErr(err)
+	}
+	return data, nil
+}
+
+type CreateTerminalAccessRequestArgs struct {
+	EnvName    string `json:"env_name"`
+	Production bool   `json:"production"`
+	Reason     string `json:"reason"`
+}
+
+func createTerminalAccessRequest(projectName, userID, username string, args *CreateTerminalAccessRequestArgs) (*commonmodels.TerminalAccessRequest, error) {
+	if strings.TrimSpace(args.Reason) == "" {
+		return nil, e.ErrCreateTerminalAccessRequest.AddDesc("reason can not be empty")
+	}
+
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{Name: projectName, EnvName: args.EnvName, Production: &args.Production})
+	if err != nil {
+		return nil, e.ErrCreateTerminalAccessRequest.AddDesc(fmt.Sprintf("failed to find environment %s/%s: %s", projectName, args.EnvName, err))
+	}
+	if env.TerminalPolicy == nil || !env.TerminalPolicy.RequireApproval {
+		return nil, e.ErrCreateTerminalAccessRequest.AddDesc(fmt.Sprintf("environment %s does not require approval to open a shell", args.EnvName))
+	}
+
+	request := &commonmodels.TerminalAccessRequest{
+		ProjectName: projectName,
+		EnvName:     args.EnvName,
+		Production:  args.Production,
+		UserID:      userID,
+		Username:    username,
+		Reason:      args.Reason,
+		Status:      commonmodels.TerminalAccessRequestStatusPending,
+	}
+	if err := commonrepo.NewTerminalAccessRequestColl().Create(request); err != nil {
+		return nil, e.ErrCreateTerminalAccessRequest.AddErr(err)
+	}
+	return request, nil
+}
+
+type ListTerminalAccessRequestsArgs struct {
+	ProjectName string                                   `form:"projectName"`
+	EnvName     string                                   `form:"envName"`
+	Production  *bool                                    `form:"production"`
+	Status      commonmodels.TerminalAccessRequestStatus `form:"status"`
+}
+
+// listTerminalAccessRequests returns the requests the user can see: all of them for reviewers, their own otherwise.
+func listTerminalAccessRequests(args *ListTerminalAccessRequestsArgs, userID string, isAdmin bool) ([]*commonmodels.TerminalAccessRequest, error) {
+	requests, err := commonrepo.NewTerminalAccessRequestColl().List(&commonrepo.TerminalAccessRequestListOption{
+		ProjectName: args.ProjectName,
+		EnvName:     args.EnvName,
+		Production:  args.Production,
+		Status:      args.Status,
+	})
+	if err != nil {
+		return nil, e.ErrListTerminalAccessRequest.AddErr(err)
+	}
+	if isAdmin {
+		return requests, nil
+	}
+
+	policies := make(map[string]*commonmodels.TerminalPolicy)
+	resp := make([]*commonmodels.TerminalAccessRequest, 0)
+	for _, request := range requests {
+		if request.UserID == userID {
+			resp = append(resp, request)
+			continue
+		}
+		key := fmt.Sprintf("%s/%v", request.EnvName, request.Production)
+		policy, ok := policies[key]
+		if !ok {
+			env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{Name: request.ProjectName, EnvName: request.EnvName, Production: &request.Production, IgnoreNotFoundErr: true})
+			if err != nil {
+				return nil, e.ErrListTerminalAccessRequest.AddErr(err)
+			}
+			if env != nil {
+				policy = env.TerminalPolicy
+			}
+			policies[key] = policy
+		}
+		if canReviewTerminalAccessRequest(policy, userID, false) {
+			resp = append(resp, request)
+		}
+	}
+	return resp, nil
+}
+
+// canReviewTerminalAccessRequest reports whether the user is one of the approvers of the policy,
+// project admins approve the requests of environments without explicit approvers.
+func canReviewTerminalAccessRequest(policy *commonmodels.TerminalPolicy, userID string, isAdmin bool) bool {
+	if policy == nil || len(policy.Approvers) == 0 {
+		return isAdmin
+	}
+	return sets.NewString(policy.Approvers...).Has(userID)
+}
+
+type ReviewTerminalAccessRequestArgs struct {
+	Approve bool   `json:"approve"`
+	Comment string `json:"comment"`
+}
+
+func reviewTerminalAccessRequest(projectName, id, userID, username string, isSystemAdmin, isProjectAdmin bool, args *ReviewTerminalAccessRequestArgs) error {
+	request, err := commonrepo.NewTerminalAccessRequestColl().GetByID(id)
+	if err != nil {
+		return e.ErrReviewTerminalAccessRequest.AddErr(err)
+	}
+	if request.ProjectName != projectName {
+		return e.ErrReviewTerminalAccessRequest.AddDesc(fmt.Sprintf("access request %s does not belong to project %s", id, projectName))
+	}
+	if request.UserID == userID {
+		return e.ErrReviewTerminalAccessRequest.AddDesc("access requests can not be reviewed by the requester")
+	}
+
+	env, err := commonrepo.NewProductColl().Find(&commonrepo.ProductFindOptions{Name: request.ProjectName, EnvName: request.EnvName, Production: &request.Production})
+	if err != nil {
+		return e.ErrReviewTerminalAccessRequest.AddDesc(fmt.Sprintf("failed to find environment %s/%s: %s", request.ProjectName, request.EnvName, err))
+	}
+	if !isSystemAdmin && !canReviewTerminalAccessRequest(env.TerminalPolicy, userID, isProjectAdmin) {
+		return e.ErrForbidden.AddDesc("you are not an approver of this environment")
+	}
+
+	request.Status = commonmodels.TerminalAccessRequestStatusRejected
+	request.ExpireTime = 0
+	if args.Approve {
+		ttl := defaultTerminalApprovalTTL
+		if env.TerminalPolicy != nil && env.TerminalPolicy.ApprovalTTL > 0 {
+			ttl = time.Duration(env.TerminalPolicy.ApprovalTTL) * time.Minute
+		}
+		request.Status = commonmodels.TerminalAccessRequestStatusApproved
+		request.ExpireTime = time.Now().Add(ttl).Unix()
+	}
+	request.ReviewerID = userID
+	request.ReviewerName = username
+	request.Comment = args.Comment
+
+	if err := commonrepo.NewTerminalAccessRequestColl().Review(request); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return e.ErrReviewTerminalAccessRequest.AddDesc(fmt.Sprintf("access request %s has already been reviewed", id))
+		}
+		return e.ErrReviewTerminalAccessRequest.AddErr(err)
+	}
+	return nil
+}
+
+func isTerminalProjectAdmin(resources *user.AuthorizedResources, projectName string) bool {
+	if resources.IsSystemAdmin {
+		return true
+	}
+	info, ok := resources.ProjectAuthInfo[projectName]
+	return ok && info.IsProjectAdmin
+}
@@ -82,11 +82,14 @@ type TerminalSession struct {
 	// SecretEnvs is a list of environment variables that should be hidden from the client.
 	SecretEnvs []string
 	Type       TerminalSessionType
+	// Recorder records everything typed and printed in the session, nil if the session is not recorded.
+	Recorder *TerminalRecorder
 }
 
 type TerminalSessionOption struct {
 	SecretEnvs []string
 	Type       TerminalSessionType
+	Recorder   *TerminalRecorder
 }
 
 func NewTerminalSession(w http.ResponseWriter, r *http.Request, responseHeader http.Header, opt ...*TerminalSessionOption) (*TerminalSession, error) {
@@ -103,6 +106,7 @@ func NewTerminalSession(w http.ResponseWriter, r *http.Request, responseHeader h
 	if len(opt) > 0 {
 		session.SecretEnvs = opt[0].SecretEnvs
 		session.Type = opt[0].Type
+		session.Recorder = opt[0].Recorder
 	}
 	return session, nil
 }
@@ -136,8 +140,11 @@ func (t *TerminalSession) Read(p []byte) (int, error) {
 	}
 	switch msg.Operation {
 	case "stdin":
-		return copy(p, msg.Data), nil
+		n := copy(p, msg.Data)
+		t.Recorder.Input(p[:n])
+		return n, nil
 	case "resize":
+		t.Recorder.Resize(msg.Cols, msg.Rows)
 		t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
 		return 0, nil
 	default:
@@ -156,11 +163,14 @@ func (t *TerminalSession) Write(p []byte) (int, error) {
 		log.Errorf("write parse message err: %v", err)
 		return 0, err
 	}
+	output := p
 	if t.Type == Workflow {
 		for _, secretEnv := range t.SecretEnvs {
 			msg = bytes.ReplaceAll(msg, []byte(secretEnv), []byte("********"))
+			output = bytes.ReplaceAll(output, []byte(secretEnv), []byte("********"))
 		}
 	}
+	t.Recorder.Output(output)
 	if err := t.wsConn.WriteMessage(websocket.TextMessage, msg); err != nil {
 		log.Errorf("write message err: %v", err)
 		return 0, err
@@ -313,9 +313,9 @@ func (c *CodehostColl) updateCodeHost(query bson.M, host *models.CodeHost) (*mod
 		"updated_at":     time.Now().Unix(),
 		"disable_ssl":    host.DisableSSL,
 	}
-	if host.Type == setting.SourceFromGerrit {
+	if host.Type == setting.SourceFromGerrit || host.Type == setting.SourceFromBitbucket {
 		modifyValue["access_token"] = host.AccessToken
-	} else if host.Type == setting.SourceFromGitee || host.Type == setting.SourceFromGitlab || host.Type == setting.SourceFromGiteeEE {
+	} else if host.Type == setting.SourceFromGitee || host.Type == setting.SourceFromGitlab || host.Type == setting.SourceFromGiteeEE || host.Type == setting.SourceFromGitea {
 		modifyValue["access_token"] = host.AccessToken
 		modifyValue["refresh_token"] = host.RefreshToken
 		modifyValue["updated_at"] = host.UpdatedAt
@@ -57,6 +57,10 @@ func CreateSystemCodeHost(codehost *models.CodeHost, _ *zap.SugaredLogger) (*mod
 	if codehost.Type == types.ProviderPerforce {
 		codehost.IsReady = "2"
 	}
+	// bitbucket is authorized with an app password or an access token, no oauth flow is needed
+	if codehost.Type == types.ProviderBitbucket {
+		codehost.IsReady = "2"
+	}
 
 	if codehost.Alias != "" {
 		if _, err := mongodb.NewCodehostColl().GetSystemCodeHostByAlias(codehost.Alias); err == nil {
@@ -286,6 +290,11 @@ func newOAuth(provider, callbackURL, clientID, clientSecret, address string) (*o
 			AuthURL:  address + "/oauth/authorize",
 			TokenURL: address + "/oauth/token",
 		}), nil
+	case setting.SourceFromGitea:
+		return oauth.New(callbackURL, clientID, clientSecret, []string{"write:repository", "read:user", "read:organization"}, oauth2.Endpoint{
+			AuthURL:  address + "/login/oauth/authorize",
+			TokenURL: address + "/login/oauth/access_token",
+		}), nil
 	case setting.SourceFromGitee, setting.SourceFromGiteeEE:
 		return oauth.New(callbackURL, clientID, clientSecret, []string{"projects", "pull_requests", "hook", "groups"}, oauth2.Endpoint{
 			AuthURL:  address + "/oauth/authorize",
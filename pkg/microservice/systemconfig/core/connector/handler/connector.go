@@ -18,6 +18,7 @@ package handler
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 
@@ -144,3 +145,22 @@ func UpdateConnector(c *gin.Context) {
 
 	ctx.RespErr = service.UpdateConnector(args, ctx.Logger)
 }
+
+// @Summary 获取 SAML SP 元数据
+// @Description 生成 SAML 连接器的服务提供方元数据，用于导入到身份提供方
+// @Tags connector
+// @Produce xml
+// @Param id	path		string	true	"连接器 ID"
+// @Success 200
+// @Router /api/v1/connectors/{id}/saml/metadata [get]
+func GetSAMLServiceProviderMetadata(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+
+	metadata, err := service.GetSAMLServiceProviderMetadata(c.Param("id"), ctx.Logger)
+	if err != nil {
+		ctx.RespErr = err
+		internalhandler.JSONResponse(c, ctx)
+		return
+	}
+	c.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
@@ -31,5 +31,6 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		connector.GET("/:id", GetConnector)
 		connector.PUT("/:id", UpdateConnector)
 		connector.DELETE("/:id", DeleteConnector)
+		connector.GET("/:id/saml/metadata", GetSAMLServiceProviderMetadata)
 	}
 }
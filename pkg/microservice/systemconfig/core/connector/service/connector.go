@@ -147,6 +147,10 @@ func DeleteConnector(id string, _ *zap.SugaredLogger) error {
 }
 
 func CreateConnector(ct *Connector, logger *zap.SugaredLogger) error {
+	if err := validateConnector(ct); err != nil {
+		return err
+	}
+
 	cf, err := json.Marshal(ct.Config)
 	if err != nil {
 		logger.Errorf("Failed to marshal config, err: %s", err)
@@ -177,6 +181,10 @@ func CreateConnector(ct *Connector, logger *zap.SugaredLogger) error {
 }
 
 func UpdateConnector(ct *Connector, logger *zap.SugaredLogger) error {
+	if err := validateConnector(ct); err != nil {
+		return err
+	}
+
 	cf, err := json.Marshal(ct.Config)
 	if err != nil {
 		logger.Errorf("Failed to marshal config, err: %s", err)
@@ -205,3 +213,11 @@ func UpdateConnector(ct *Connector, logger *zap.SugaredLogger) error {
 
 	return orm.NewConnectorColl().Update(obj)
 }
+
+func validateConnector(ct *Connector) error {
+	switch cfg := ct.Config.(type) {
+	case *SAMLConfig:
+		return validateSAMLConfig(ct.ID, cfg)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/repository/orm"
+)
+
+const (
+	samlMetadataNamespace = "urn:oasis:names:tc:SAML:2.0:metadata"
+	samlProtocolNamespace = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlHTTPPostBinding   = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	samlDefaultNameID     = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+)
+
+// SAMLACSURL is the assertion consumer service of a SAML connector. It accepts both the responses
+// of SP-initiated logins, which are handed over to dex, and IdP-initiated logins.
+func SAMLACSURL(id string) string {
+	return fmt.Sprintf("%s/api/v1/saml/%s/acs", strings.TrimSuffix(config.SystemAddress(), "/"), id)
+}
+
+// SAMLEntityID returns the entity id of zadig as a service provider for the given connector
+func SAMLEntityID(id string, cfg *SAMLConfig) string {
+	if cfg != nil && cfg.EntityIssuer != "" {
+		return cfg.EntityIssuer
+	}
+	return fmt.Sprintf("%s/api/v1/connectors/%s/saml/metadata", strings.TrimSuffix(config.SystemAddress(), "/"), id)
+}
+
+// validateSAMLConfig makes sure assertions are always signature checked and fills the defaults.
+func validateSAMLConfig(id string, cfg *SAMLConfig) error {
+	if cfg.SSOURL == "" {
+		return fmt.Errorf("ssoURL is required for saml connector")
+	}
+	if cfg.UsernameAttr == "" || cfg.EmailAttr == "" {
+		return fmt.Errorf("usernameAttr and emailAttr are required for saml connector")
+	}
+	if cfg.InsecureSkipSignatureValidation {
+		return fmt.Errorf("signature validation can not be skipped for saml connector")
+	}
+
+	caData := cfg.CAData
+	if len(caData) == 0 {
+		if cfg.CA == "" {
+			return fmt.Errorf("the certificate of the identity provider is required for saml connector")
+		}
+		data, err := os.ReadFile(cfg.CA)
+		if err != nil {
+			return fmt.Errorf("failed to read ca file %s: %s", cfg.CA, err)
+		}
+		caData = data
+	}
+	if err := validateCertificates(caData); err != nil {
+		return err
+	}
+
+	if len(cfg.GroupMapping) > 0 && cfg.GroupsAttr == "" {
+		return fmt.Errorf("groupsAttr is required when group mapping is configured")
+	}
+
+	if cfg.RedirectURI == "" {
+		cfg.RedirectURI = SAMLACSURL(id)
+	}
+	if cfg.EntityIssuer == "" {
+		cfg.EntityIssuer = SAMLEntityID(id, nil)
+	}
+	if cfg.NameIDPolicyFormat == "" {
+		cfg.NameIDPolicyFormat = samlDefaultNameID
+	}
+	return nil
+}
+
+func validateCertificates(data []byte) error {
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("invalid identity provider certificate: %s", err)
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no PEM encoded certificate found in the identity provider certificate")
+	}
+	return nil
+}
+
+type samlEntityDescriptor struct {
+	XMLName         xml.Name            `xml:"md:EntityDescriptor"`
+	XMLNS           string              `xml:"xmlns:md,attr"`
+	EntityID        string              `xml:"entityID,attr"`
+	SPSSODescriptor samlSPSSODescriptor `xml:"md:SPSSODescriptor"`
+}
+
+type samlSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                             `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                             `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                           `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormat               string                           `xml:"md:NameIDFormat"`
+	AssertionConsumerServices  []samlAssertionConsumerServiceEP `xml:"md:AssertionConsumerService"`
+}
+
+type samlAssertionConsumerServiceEP struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// GetSAMLServiceProviderMetadata generates the SP metadata to be imported into the identity provider
+func GetSAMLServiceProviderMetadata(id string, logger *zap.SugaredLogger) ([]byte, error) {
+	c, err := orm.NewConnectorColl().Get(id)
+	if err != nil {
+		logger.Errorf("Failed to get connector %s, err: %s", id, err)
+		return nil, err
+	}
+	if ConnectorType(c.Type) != TypeSAML {
+		return nil, fmt.Errorf("connector %s is not a saml connector", id)
+	}
+
+	cfg := &SAMLConfig{}
+	if err := json.Unmarshal([]byte(c.Config), cfg); err != nil {
+		logger.Errorf("Failed to unmarshal config, err: %s", err)
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	nameIDFormat := cfg.NameIDPolicyFormat
+	if nameIDFormat == "" {
+		nameIDFormat = samlDefaultNameID
+	}
+	acsURL := cfg.RedirectURI
+	if acsURL == "" {
+		acsURL = SAMLACSURL(id)
+	}
+
+	metadata := &samlEntityDescriptor{
+		XMLNS:    samlMetadataNamespace,
+		EntityID: SAMLEntityID(id, cfg),
+		SPSSODescriptor: samlSPSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: samlProtocolNamespace,
+			NameIDFormat:               nameIDFormat,
+			AssertionConsumerServices: []samlAssertionConsumerServiceEP{
+				{
+					Binding:   samlHTTPPostBinding,
+					Location:  acsURL,
+					Index:     0,
+					IsDefault: true,
+				},
+			},
+		},
+	}
+
+	out, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
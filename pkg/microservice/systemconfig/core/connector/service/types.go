@@ -27,6 +27,7 @@ import (
 	"github.com/dexidp/dex/connector/linkedin"
 	"github.com/dexidp/dex/connector/microsoft"
 	"github.com/dexidp/dex/connector/oidc"
+	"github.com/dexidp/dex/connector/saml"
 )
 
 type ConnectorType string
@@ -40,6 +41,7 @@ const (
 	TypeGoogle    ConnectorType = "google"
 	TypeLinkedIn  ConnectorType = "linkedin"
 	TypeMicrosoft ConnectorType = "microsoft"
+	TypeSAML      ConnectorType = "saml"
 )
 
 type Connector struct {
@@ -52,6 +54,15 @@ type Connector struct {
 	LogoutRedirectURL string      `json:"logout_redirect_url"`
 }
 
+// SAMLConfig is the dex SAML connector config with the zadig specific group mapping,
+// dex ignores the extra field.
+type SAMLConfig struct {
+	saml.Config
+	// GroupMapping maps the groups in the SAML assertion to zadig user group names,
+	// membership of the mapped user groups is synchronized on every login.
+	GroupMapping map[string]string `json:"groupMapping,omitempty"`
+}
+
 type ConnectorBase struct {
 	Type ConnectorType `json:"type"`
 }
@@ -79,6 +90,8 @@ func (c *Connector) UnmarshalJSON(data []byte) error {
 		c.Config = &linkedin.Config{}
 	case TypeMicrosoft:
 		c.Config = &microsoft.Config{}
+	case TypeSAML:
+		c.Config = &SAMLConfig{}
 	}
 
 	type tmp Connector
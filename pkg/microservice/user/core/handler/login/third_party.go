@@ -22,23 +22,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-gonic/gin"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/permission"
 	"golang.org/x/oauth2"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	configbase "github.com/koderover/zadig/v2/pkg/config"
+	connectorservice "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/connector/service"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/login"
 	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/shared/client/aslan"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
 	e "github.com/koderover/zadig/v2/pkg/tool/errors"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
 
+const groupsScope = "groups"
+
 func provider() *oidc.Provider {
 	ctx := oidc.ClientContext(context.Background(), http.DefaultClient)
 	provider, err := oidc.NewProvider(ctx, config.IssuerURL())
@@ -57,15 +63,6 @@ func Login(c *gin.Context) {
 	ctx := internalhandler.NewContext(c)
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
 
-	oauth2Config := &oauth2.Config{
-		ClientID:     config.ClientID(),
-		ClientSecret: config.ClientSecret(),
-		Endpoint:     provider().Endpoint(),
-		Scopes:       config.Scopes(),
-		RedirectURL:  config.RedirectURI(),
-	}
-
-	authCodeURL := oauth2Config.AuthCodeURL(config.AppState, oauth2.AccessTypeOffline)
 	systemConfig, err := aslan.New(configbase.AslanServiceAddress()).GetDefaultLogin()
 	if err != nil {
 		ctx.RespErr = err
@@ -75,8 +72,34 @@ func Login(c *gin.Context) {
 	replaceURL := configbase.SystemAddress() + "/dex/auth"
 	if systemConfig.DefaultLogin != setting.DefaultLoginLocal {
 		defaultLogin = systemConfig.DefaultLogin
+	}
+	// a specific connector can be requested, e.g. when an IdP-initiated saml login is redirected here
+	if connectorID := c.Query("connector_id"); connectorID != "" {
+		defaultLogin = connectorID
+	}
+	if defaultLogin != "" {
 		replaceURL = replaceURL + "/" + defaultLogin
 	}
+
+	scopes := config.Scopes()
+	// groups are only required to synchronize the user group membership of saml users with a group mapping
+	if defaultLogin != "" && !sets.New[string](scopes...).Has(groupsScope) {
+		groupMapping, err := permission.GetConnectorGroupMapping(defaultLogin)
+		if err != nil {
+			ctx.Logger.Warnf("failed to get group mapping of connector %s, error: %s", defaultLogin, err)
+		} else if len(groupMapping) > 0 {
+			scopes = append(scopes, groupsScope)
+		}
+	}
+	oauth2Config := &oauth2.Config{
+		ClientID:     config.ClientID(),
+		ClientSecret: config.ClientSecret(),
+		Endpoint:     provider().Endpoint(),
+		Scopes:       scopes,
+		RedirectURL:  config.RedirectURI(),
+	}
+
+	authCodeURL := oauth2Config.AuthCodeURL(config.AppState, oauth2.AccessTypeOffline)
 	authCodeURL = strings.Replace(authCodeURL, config.IssuerURL()+"/auth", replaceURL, -1)
 
 	c.Redirect(http.StatusSeeOther, authCodeURL)
@@ -177,6 +200,15 @@ func Callback(c *gin.Context) {
 		return
 	}
 
+	groupMapping, err := permission.GetConnectorGroupMapping(claims.FederatedClaims.ConnectorId)
+	if err != nil {
+		ctx.Logger.Warnf("failed to get group mapping of connector %s, error: %s", claims.FederatedClaims.ConnectorId, err)
+	} else if len(groupMapping) > 0 {
+		if err := permission.SyncConnectorUserGroups(user.UID, claims.FederatedClaims.ConnectorId, groupMapping, claims.Groups, ctx.Logger); err != nil {
+			ctx.Logger.Warnf("failed to sync user groups of user %s from connector %s, error: %s", user.Account, claims.FederatedClaims.ConnectorId, err)
+		}
+	}
+
 	redirectURL, err := login.HandleThirdPartyLoginSuccess(user, ctx.Logger)
 	if err != nil {
 		ctx.RespErr = err
@@ -184,3 +216,33 @@ func Callback(c *gin.Context) {
 	}
 	c.Redirect(http.StatusSeeOther, redirectURL)
 }
+
+// @Summary SAML 断言消费服务
+// @Description 接收身份提供方提交的 SAML 响应。SP 发起的登录交由 dex 校验断言签名，IdP 发起的登录会重新发起一次 SP 登录流程
+// @Tags user
+// @Param id	path		string	true	"连接器 ID"
+// @Success 303
+// @Router /api/v1/saml/{id}/acs [post]
+func SAMLAssertionConsumerService(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+
+	connectorID := c.Param("id")
+	connector, err := systemconfig.New().GetConnector(connectorID)
+	if err != nil || connector.Type != string(connectorservice.TypeSAML) {
+		ctx.RespErr = e.ErrCallBackUser.AddDesc(fmt.Sprintf("saml connector %s not found", connectorID))
+		internalhandler.JSONResponse(c, ctx)
+		return
+	}
+
+	// SP-initiated: the relay state carries the dex auth request, dex validates the signed assertion
+	if c.PostForm("RelayState") != "" {
+		c.Redirect(http.StatusTemporaryRedirect, configbase.SystemAddress()+"/dex/callback")
+		return
+	}
+
+	// IdP-initiated: unsolicited responses are never trusted directly, start an SP-initiated login
+	// instead, the identity provider already holds the session so the user is not prompted again.
+	v := url.Values{}
+	v.Add("connector_id", connectorID)
+	c.Redirect(http.StatusSeeOther, "/api/v1/login?"+v.Encode())
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/handler/login"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/handler/permission"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/handler/scim"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/handler/user"
 )
 
@@ -45,12 +46,43 @@ func (*Router) Inject(router *gin.RouterGroup) {
 		users.GET("/:uid/personal", user.GetPersonalUser)
 		users.GET("/:uid/setting", user.GetUserSetting)
 		users.POST("/:uid/token", user.GenerateAPIToken)
+		users.GET("/:uid/tokens", user.ListPersonalAccessTokens)
+		users.POST("/:uid/tokens", user.CreatePersonalAccessToken)
+		users.DELETE("/:uid/tokens/:id", user.RevokePersonalAccessToken)
 		users.POST("/brief", user.ListUsersBrief)
 		users.POST("/search", user.ListUsers)
 		users.GET("/count", user.CountSystemUsers)
 		users.GET("/check/duplicate", user.CheckDuplicateUser)
 	}
 
+	scimTokens := router.Group("/scim/tokens")
+	{
+		scimTokens.GET("", scim.ListTokens)
+		scimTokens.POST("", scim.CreateToken)
+		scimTokens.DELETE("/:id", scim.DeleteToken)
+	}
+
+	// SCIM 2.0 provisioning APIs, authenticated with a scim token instead of a user token
+	scimV2 := router.Group("/scim/v2", scim.Authenticate)
+	{
+		scimV2.GET("/ServiceProviderConfig", scim.GetServiceProviderConfig)
+		scimV2.GET("/ResourceTypes", scim.ListResourceTypes)
+
+		scimV2.GET("/Users", scim.ListUsers)
+		scimV2.POST("/Users", scim.CreateUser)
+		scimV2.GET("/Users/:id", scim.GetUser)
+		scimV2.PUT("/Users/:id", scim.ReplaceUser)
+		scimV2.PATCH("/Users/:id", scim.PatchUser)
+		scimV2.DELETE("/Users/:id", scim.DeleteUser)
+
+		scimV2.GET("/Groups", scim.ListGroups)
+		scimV2.POST("/Groups", scim.CreateGroup)
+		scimV2.GET("/Groups/:id", scim.GetGroup)
+		scimV2.PUT("/Groups/:id", scim.ReplaceGroup)
+		scimV2.PATCH("/Groups/:id", scim.PatchGroup)
+		scimV2.DELETE("/Groups/:id", scim.DeleteGroup)
+	}
+
 	usergroups := router.Group("user-group")
 	{
 		// user group related apis
@@ -84,6 +116,7 @@ func (*Router) Inject(router *gin.RouterGroup) {
 	{
 		general.GET("/callback", login.Callback)
 		general.GET("/login", login.Login)
+		general.POST("/saml/:id/acs", login.SAMLAssertionConsumerService)
 		general.POST("/login", login.LocalLogin)
 		general.POST("/login/mfa/setup", login.MFASetup)
 		general.POST("/login/mfa/enroll", login.MFAEnroll)
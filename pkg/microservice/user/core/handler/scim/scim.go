@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+	scimservice "github.com/koderover/zadig/v2/pkg/microservice/user/core/service/scim"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/util/ginzap"
+)
+
+const scimTokenKey = "scim_token"
+
+// @Summary 创建 SCIM 令牌
+// @Description 创建身份提供方调用 SCIM 接口使用的令牌，令牌明文只返回一次，仅系统管理员可操作
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param body body scim.CreateTokenArgs true "body"
+// @Success 200 {object} scim.Token
+// @Router /api/v1/scim/tokens [post]
+func CreateToken(c *gin.Context) {
+	ctx, err := newAdminContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		return
+	}
+
+	args := new(scimservice.CreateTokenArgs)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+	ctx.Resp, ctx.RespErr = scimservice.CreateToken(args, ctx.Logger)
+}
+
+// @Summary 列出 SCIM 令牌
+// @Description 仅系统管理员可操作
+// @Tags user
+// @Produce json
+// @Success 200 {array} models.SCIMToken
+// @Router /api/v1/scim/tokens [get]
+func ListTokens(c *gin.Context) {
+	ctx, err := newAdminContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = scimservice.ListTokens(ctx.Logger)
+}
+
+// @Summary 删除 SCIM 令牌
+// @Description 仅系统管理员可操作
+// @Tags user
+// @Param id path int true "令牌 ID"
+// @Success 200
+// @Router /api/v1/scim/tokens/{id} [delete]
+func DeleteToken(c *gin.Context) {
+	ctx, err := newAdminContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+	if err != nil {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddDesc("invalid token id")
+		return
+	}
+	ctx.RespErr = scimservice.DeleteToken(uint(id), ctx.Logger)
+}
+
+func newAdminContext(c *gin.Context) (*internalhandler.Context, error) {
+	ctx, err := internalhandler.NewContextWithAuthorization(c)
+	if err != nil {
+		ctx.UnAuthorized = true
+		ctx.RespErr = fmt.Errorf("authorization info generation failed: %s", err)
+		return ctx, ctx.RespErr
+	}
+	if !ctx.Resources.IsSystemAdmin || ctx.TokenID != "" {
+		ctx.RespErr = e.ErrForbidden
+		return ctx, ctx.RespErr
+	}
+	return ctx, nil
+}
+
+// Authenticate checks the SCIM bearer token, the SCIM endpoints are public in the gateway
+func Authenticate(c *gin.Context) {
+	token := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+	record, err := scimservice.Authenticate(token)
+	if err != nil {
+		respondError(c, err)
+		c.Abort()
+		return
+	}
+	c.Set(scimTokenKey, record)
+	c.Next()
+}
+
+func tokenFromContext(c *gin.Context) *models.SCIMToken {
+	return c.MustGet(scimTokenKey).(*models.SCIMToken)
+}
+
+func respond(c *gin.Context, code int, resp interface{}, err error) {
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if resp == nil {
+		c.Status(code)
+		return
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Data(code, scimservice.ContentType, data)
+}
+
+func respondError(c *gin.Context, err error) {
+	scimErr := scimservice.ToError(err)
+	if scimErr.StatusCode() >= http.StatusInternalServerError {
+		ginzap.WithContext(c).Sugar().Errorf("scim request %s %s failed: %s", c.Request.Method, c.Request.URL.Path, err)
+	}
+	data, _ := json.Marshal(scimErr)
+	c.Data(scimErr.StatusCode(), scimservice.ContentType, data)
+}
+
+func bindBody(c *gin.Context, obj interface{}) error {
+	if err := json.NewDecoder(c.Request.Body).Decode(obj); err != nil {
+		return scimservice.ErrBadRequest(err)
+	}
+	return nil
+}
+
+func GetServiceProviderConfig(c *gin.Context) {
+	respond(c, http.StatusOK, scimservice.ServiceProviderConfig(), nil)
+}
+
+func ListResourceTypes(c *gin.Context) {
+	respond(c, http.StatusOK, scimservice.ResourceTypes(), nil)
+}
+
+func ListUsers(c *gin.Context) {
+	opts := new(scimservice.ListOptions)
+	if err := c.ShouldBindQuery(opts); err != nil {
+		respondError(c, scimservice.ErrBadRequest(err))
+		return
+	}
+	resp, err := scimservice.ListUsers(tokenFromContext(c), opts, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusOK, resp, err)
+}
+
+func GetUser(c *gin.Context) {
+	resp, err := scimservice.GetUser(tokenFromContext(c), c.Param("id"))
+	respond(c, http.StatusOK, resp, err)
+}
+
+func CreateUser(c *gin.Context) {
+	args := new(scimservice.User)
+	if err := bindBody(c, args); err != nil {
+		respondError(c, err)
+		return
+	}
+	resp, err := scimservice.CreateUser(tokenFromContext(c), args, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusCreated, resp, err)
+}
+
+func ReplaceUser(c *gin.Context) {
+	args := new(scimservice.User)
+	if err := bindBody(c, args); err != nil {
+		respondError(c, err)
+		return
+	}
+	resp, err := scimservice.ReplaceUser(tokenFromContext(c), c.Param("id"), args, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusOK, resp, err)
+}
+
+func PatchUser(c *gin.Context) {
+	args := new(scimservice.PatchRequest)
+	if err := bindBody(c, args); err != nil {
+		respondError(c, err)
+		return
+	}
+	resp, err := scimservice.PatchUser(tokenFromContext(c), c.Param("id"), args, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusOK, resp, err)
+}
+
+func DeleteUser(c *gin.Context) {
+	err := scimservice.DeleteUser(tokenFromContext(c), c.Param("id"), ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusNoContent, nil, err)
+}
+
+func ListGroups(c *gin.Context) {
+	opts := new(scimservice.ListOptions)
+	if err := c.ShouldBindQuery(opts); err != nil {
+		respondError(c, scimservice.ErrBadRequest(err))
+		return
+	}
+	resp, err := scimservice.ListGroups(tokenFromContext(c), opts, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusOK, resp, err)
+}
+
+func GetGroup(c *gin.Context) {
+	resp, err := scimservice.GetGroup(tokenFromContext(c), c.Param("id"))
+	respond(c, http.StatusOK, resp, err)
+}
+
+func CreateGroup(c *gin.Context) {
+	args := new(scimservice.Group)
+	if err := bindBody(c, args); err != nil {
+		respondError(c, err)
+		return
+	}
+	resp, err := scimservice.CreateGroup(tokenFromContext(c), args, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusCreated, resp, err)
+}
+
+func ReplaceGroup(c *gin.Context) {
+	args := new(scimservice.Group)
+	if err := bindBody(c, args); err != nil {
+		respondError(c, err)
+		return
+	}
+	resp, err := scimservice.ReplaceGroup(tokenFromContext(c), c.Param("id"), args, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusOK, resp, err)
+}
+
+func PatchGroup(c *gin.Context) {
+	args := new(scimservice.PatchRequest)
+	if err := bindBody(c, args); err != nil {
+		respondError(c, err)
+		return
+	}
+	resp, err := scimservice.PatchGroup(tokenFromContext(c), c.Param("id"), args, ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusOK, resp, err)
+}
+
+func DeleteGroup(c *gin.Context) {
+	err := scimservice.DeleteGroup(tokenFromContext(c), c.Param("id"), ginzap.WithContext(c).Sugar())
+	respond(c, http.StatusNoContent, nil, err)
+}
@@ -32,8 +32,9 @@ func GetUserAuthInfo(c *gin.Context) {
 	defer func() { internalhandler.JSONResponse(c, ctx) }()
 
 	uid := c.Query("uid")
+	tokenID := c.Query("token_id")
 
-	ctx.Resp, ctx.RespErr = userservice.GetUserAuthInfo(uid, ctx.Logger)
+	ctx.Resp, ctx.RespErr = userservice.GetUserAuthInfoByToken(uid, tokenID, ctx.Logger)
 }
 
 func CheckCollaborationModePermission(c *gin.Context) {
@@ -203,7 +204,7 @@ func ListAuthorizedEnvs(c *gin.Context) {
 }
 
 func GenerateUserAuthInfo(ctx *internalhandler.Context) error {
-	resourceAuthInfo, err := userservice.GetUserAuthInfo(ctx.UserID, ctx.Logger)
+	resourceAuthInfo, err := userservice.GetUserAuthInfoByToken(ctx.UserID, ctx.TokenID, ctx.Logger)
 	if err != nil {
 		ctx.Logger.Errorf("Failed to generate user auth info for userID: %s, error is: %s", ctx.UserID, err)
 		return err
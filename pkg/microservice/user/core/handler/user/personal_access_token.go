@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package user
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/permission"
+	internalhandler "github.com/koderover/zadig/v2/pkg/shared/handler"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+)
+
+// @Summary 创建个人访问令牌
+// @Description 为当前用户创建带有项目和权限范围、过期时间的个人访问令牌，令牌明文只返回一次
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param uid	path		string									true	"用户 ID"
+// @Param body	body		permission.CreatePersonalAccessTokenArgs	true	"body"
+// @Success 200 {object} permission.PersonalAccessToken
+// @Router /api/v1/users/{uid}/tokens [post]
+func CreatePersonalAccessToken(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	uid := c.Param("uid")
+	// tokens can only be created by the owner with an interactive login
+	if ctx.UserID != uid || ctx.TokenID != "" {
+		ctx.RespErr = e.ErrForbidden
+		return
+	}
+
+	args := new(permission.CreatePersonalAccessTokenArgs)
+	if err := c.ShouldBindJSON(args); err != nil {
+		ctx.RespErr = e.ErrInvalidParam.AddErr(err)
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = permission.CreatePersonalAccessToken(uid, args, ctx.Logger)
+}
+
+// @Summary 列出个人访问令牌
+// @Description 列出用户的个人访问令牌，只有用户本人和系统管理员可以查看
+// @Tags user
+// @Produce json
+// @Param uid	path		string	true	"用户 ID"
+// @Success 200 {array} permission.PersonalAccessToken
+// @Router /api/v1/users/{uid}/tokens [get]
+func ListPersonalAccessTokens(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	uid := c.Param("uid")
+	if err := checkPersonalAccessTokenOwner(ctx, uid); err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	ctx.Resp, ctx.RespErr = permission.ListPersonalAccessTokens(uid, ctx.Logger)
+}
+
+// @Summary 吊销个人访问令牌
+// @Description 吊销用户的个人访问令牌，只有用户本人和系统管理员可以操作
+// @Tags user
+// @Produce json
+// @Param uid	path		string	true	"用户 ID"
+// @Param id	path		string	true	"令牌 ID"
+// @Success 200
+// @Router /api/v1/users/{uid}/tokens/{id} [delete]
+func RevokePersonalAccessToken(c *gin.Context) {
+	ctx := internalhandler.NewContext(c)
+	defer func() { internalhandler.JSONResponse(c, ctx) }()
+
+	uid := c.Param("uid")
+	if err := checkPersonalAccessTokenOwner(ctx, uid); err != nil {
+		ctx.RespErr = err
+		return
+	}
+
+	ctx.RespErr = permission.RevokePersonalAccessToken(uid, c.Param("id"), ctx.Logger)
+}
+
+// checkPersonalAccessTokenOwner allows the owner and system admins to manage tokens, but never
+// through a personal access token itself.
+func checkPersonalAccessTokenOwner(ctx *internalhandler.Context, uid string) error {
+	if ctx.TokenID != "" {
+		return e.ErrForbidden
+	}
+	if ctx.UserID == uid {
+		return nil
+	}
+
+	if err := GenerateUserAuthInfo(ctx); err != nil {
+		ctx.UnAuthorized = true
+		return fmt.Errorf("authorization info generation failed: %s", err)
+	}
+	if !ctx.Resources.IsSystemAdmin {
+		return e.ErrForbidden
+	}
+	return nil
+}
@@ -23,6 +23,40 @@ CREATE TABLE IF NOT EXISTS user_mfa(
 
 CREATE UNIQUE INDEX IF NOT EXISTS user_mfa_uid ON user_mfa(uid);
 
+CREATE TABLE IF NOT EXISTS personal_access_token(
+    id bigint NOT NULL AUTO_INCREMENT,
+    token_id varchar(64) NOT NULL COMMENT 'token唯一标识',
+    uid varchar(64) NOT NULL DEFAULT '0' COMMENT '用户id',
+    name varchar(64) NOT NULL DEFAULT '' COMMENT 'token名称',
+    token_hash varchar(64) NOT NULL DEFAULT '' COMMENT 'token sha256',
+    projects_json text COMMENT '可访问项目(json)',
+    verbs_json text COMMENT '可执行操作(json)',
+    expires_at int NOT NULL DEFAULT '0' COMMENT '过期时间',
+    last_used_at int NOT NULL DEFAULT '0' COMMENT '最后使用时间',
+    revoked int NOT NULL DEFAULT '0' COMMENT '是否已吊销',
+    revoked_at int NOT NULL DEFAULT '0' COMMENT '吊销时间',
+    created_at int NOT NULL DEFAULT '0' COMMENT '创建时间',
+    updated_at int NOT NULL DEFAULT '0' COMMENT '更新时间',
+    PRIMARY KEY (id)
+) ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS personal_access_token_token_id ON personal_access_token(token_id);
+
+CREATE INDEX IF NOT EXISTS personal_access_token_uid ON personal_access_token(uid);
+
+CREATE TABLE IF NOT EXISTS scim_token(
+    id bigint NOT NULL AUTO_INCREMENT,
+    name varchar(64) NOT NULL DEFAULT '' COMMENT 'token名称',
+    token_hash varchar(64) NOT NULL DEFAULT '' COMMENT 'token sha256',
+    identity_type varchar(32) NOT NULL DEFAULT '' COMMENT '创建用户的来源',
+    last_used_at int NOT NULL DEFAULT '0' COMMENT '最后使用时间',
+    created_at int NOT NULL DEFAULT '0' COMMENT '创建时间',
+    updated_at int NOT NULL DEFAULT '0' COMMENT '更新时间',
+    PRIMARY KEY (id)
+) ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS scim_token_token_hash ON scim_token(token_hash);
+
 CREATE INDEX IF NOT EXISTS idx_uid ON user_login(uid);
 
 CREATE UNIQUE INDEX IF NOT EXISTS "login" ON user_login(uid,login_id,login_type);
@@ -36,6 +70,8 @@ CREATE TABLE IF NOT EXISTS "user"(
     email varchar(100) NOT NULL DEFAULT '' COMMENT '邮箱',
     api_token varchar(1024) NOT NULL DEFAULT '' COMMENT 'openAPIToken',
     api_token_enabled int NOT NULL DEFAULT '0' COMMENT 'api token authorization enabled',
+    disabled int NOT NULL DEFAULT '0' COMMENT '是否已停用',
+    external_id varchar(255) NOT NULL DEFAULT '' COMMENT 'SCIM 外部ID',
     created_at int NOT NULL COMMENT '创建时间',
     updated_at int NOT NULL COMMENT '修改时间',
     PRIMARY KEY (uid)
@@ -50,6 +86,7 @@ CREATE TABLE IF NOT EXISTS user_group (
     group_name  varchar(32) NOT NULL UNIQUE COMMENT '用户组名',
     description varchar(64) NOT NULL COMMENT '简介',
     type        int NOT NULL COMMENT '资源范围，1-系统自带， 2-用户自定义',
+    identity_type varchar(32) NOT NULL DEFAULT '' COMMENT 'SCIM 创建用户组的来源',
     created_at  int NOT NULL DEFAULT '0' COMMENT '创建时间',
     updated_at  int NOT NULL DEFAULT '0' COMMENT '更新时间',
     PRIMARY KEY (group_id)
@@ -26,6 +26,37 @@ CREATE TABLE IF NOT EXISTS `user_mfa`(
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB CHARACTER SET = utf8 COLLATE = utf8_general_ci COMMENT = '用户MFA配置表' ROW_FORMAT = Compact;
 
+CREATE TABLE IF NOT EXISTS `personal_access_token`(
+    `id` bigint(20) NOT NULL AUTO_INCREMENT,
+    `token_id` varchar(64) NOT NULL COMMENT 'token唯一标识',
+    `uid` varchar(64) NOT NULL DEFAULT '0' COMMENT '用户id',
+    `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'token名称',
+    `token_hash` varchar(64) NOT NULL DEFAULT '' COMMENT 'token sha256',
+    `projects_json` text COMMENT '可访问项目(json)',
+    `verbs_json` text COMMENT '可执行操作(json)',
+    `expires_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '过期时间',
+    `last_used_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '最后使用时间',
+    `revoked` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否已吊销',
+    `revoked_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '吊销时间',
+    `created_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '创建时间',
+    `updated_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '更新时间',
+    UNIQUE KEY `token_id` (`token_id`),
+    PRIMARY KEY (`id`),
+    KEY `idx_uid` (`uid`) USING BTREE
+) ENGINE = InnoDB CHARACTER SET = utf8 COLLATE = utf8_general_ci COMMENT = '个人访问令牌表' ROW_FORMAT = Compact;
+
+CREATE TABLE IF NOT EXISTS `scim_token`(
+    `id` bigint(20) NOT NULL AUTO_INCREMENT,
+    `name` varchar(64) NOT NULL DEFAULT '' COMMENT 'token名称',
+    `token_hash` varchar(64) NOT NULL DEFAULT '' COMMENT 'token sha256',
+    `identity_type` varchar(32) NOT NULL DEFAULT '' COMMENT '创建用户的来源',
+    `last_used_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '最后使用时间',
+    `created_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '创建时间',
+    `updated_at` int(11) unsigned NOT NULL DEFAULT '0' COMMENT '更新时间',
+    UNIQUE KEY `token_hash` (`token_hash`),
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB CHARACTER SET = utf8 COLLATE = utf8_general_ci COMMENT = 'SCIM 令牌表' ROW_FORMAT = Compact;
+
 CREATE TABLE IF NOT EXISTS `user`(
     `uid` varchar(64) NOT NULL COMMENT '用户ID',
     `account` varchar(32) NOT NULL DEFAULT '' COMMENT '用户账号',
@@ -35,6 +66,8 @@ CREATE TABLE IF NOT EXISTS `user`(
     `email` varchar(100) NOT NULL DEFAULT '' COMMENT '邮箱',
     `api_token` varchar(1024) NOT NULL DEFAULT '' COMMENT 'openAPIToken',
     `api_token_enabled` tinyint(1) NOT NULL DEFAULT '0' COMMENT 'api token authorization enabled',
+    `disabled` tinyint(1) NOT NULL DEFAULT '0' COMMENT '是否已停用',
+    `external_id` varchar(255) NOT NULL DEFAULT '' COMMENT 'SCIM 外部ID',
     `created_at` int(11) unsigned NOT NULL COMMENT '创建时间',
     `updated_at` int(11) unsigned NOT NULL COMMENT '修改时间',
     UNIQUE KEY `account` (`account`,`identity_type`),
@@ -48,6 +81,7 @@ CREATE TABLE IF NOT EXISTS `user_group` (
     `group_name`  varchar(32) NOT NULL UNIQUE COMMENT '用户组名',
     `description` varchar(64) NOT NULL COMMENT '简介',
     `type`        int(11) NOT NULL COMMENT '资源范围，1-系统自带， 2-用户自定义',
+    `identity_type` varchar(32) NOT NULL DEFAULT '' COMMENT 'SCIM 创建用户组的来源',
     `created_at`  int(11) unsigned NOT NULL DEFAULT '0' COMMENT '创建时间',
     `updated_at`  int(11) unsigned NOT NULL DEFAULT '0' COMMENT '更新时间',
     PRIMARY KEY (`group_id`)
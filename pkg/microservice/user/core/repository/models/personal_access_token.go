@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// PersonalAccessToken is a named OpenAPI token of a user. Only the sha256 of the token is stored,
+// the token itself is shown once when it is created.
+type PersonalAccessToken struct {
+	Model
+	ID           uint   `gorm:"primarykey" json:"-"`
+	TokenID      string `json:"token_id"`
+	UID          string `json:"uid"`
+	Name         string `json:"name"`
+	TokenHash    string `json:"-"`
+	ProjectsJSON string `json:"-"`
+	VerbsJSON    string `json:"-"`
+	ExpiresAt    int64  `json:"expires_at"`
+	LastUsedAt   int64  `json:"last_used_at"`
+	Revoked      bool   `json:"revoked"`
+	RevokedAt    int64  `json:"revoked_at"`
+}
+
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_token"
+}
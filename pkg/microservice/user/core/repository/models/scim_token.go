@@ -0,0 +1,34 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// SCIMToken is the bearer token an identity provider uses to call the SCIM endpoints. Only the
+// sha256 of the token is stored.
+type SCIMToken struct {
+	Model
+	ID        uint   `gorm:"primarykey" json:"id"`
+	Name      string `json:"name"`
+	TokenHash string `json:"-"`
+	// IdentityType is the identity type of the users provisioned with this token, it should be the
+	// id of the connector the users log in with.
+	IdentityType string `json:"identity_type"`
+	LastUsedAt   int64  `json:"last_used_at"`
+}
+
+func (SCIMToken) TableName() string {
+	return "scim_token"
+}
@@ -27,6 +27,10 @@ type User struct {
 	MFAEnabled      bool   `gorm:"->;column:mfa_enabled;-:migration" json:"mfa_enabled"`
 	APIToken        string `gorm:"api_token" json:"api_token"`
 	APITokenEnabled bool   `gorm:"column:api_token_enabled;default:0" json:"api_token_enabled"`
+	// Disabled users can not log in, their tokens are rejected. Set by SCIM deprovisioning.
+	Disabled bool `gorm:"column:disabled;default:0" json:"disabled"`
+	// ExternalID is the id of the user in the identity provider that provisioned it through SCIM
+	ExternalID string `gorm:"column:external_id" json:"external_id"`
 
 	// used to mention the foreign key relationship between user and groupBinding
 	// and specify the onDelete action.
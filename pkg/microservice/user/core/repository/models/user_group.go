@@ -27,6 +27,8 @@ type UserGroup struct {
 	GroupName   string `gorm:"column:group_name"  json:"group_name"`
 	Description string `gorm:"column:description" json:"description"`
 	Type        int64  `gorm:"column:type"        json:"type"`
+	// IdentityType is set on the groups provisioned by SCIM, it is the identity type of the SCIM token
+	IdentityType string `gorm:"column:identity_type" json:"identity_type,omitempty"`
 	// used to mention the foreign key relationship between userGroup and groupBinding
 	// and specify the onDelete action.
 	GroupBindings     []GroupBinding     `gorm:"foreignKey:GroupID;references:GroupID;constraint:OnDelete:CASCADE;" json:"-"`
@@ -61,3 +61,16 @@ func BulkDeleteGroupBindings(groupID string, uids []string, db *gorm.DB) error {
 	}
 	return nil
 }
+
+func ListGroupBindingsByGroupIDs(groupIDs []string, db *gorm.DB) ([]*models.GroupBinding, error) {
+	resp := make([]*models.GroupBinding, 0)
+	if len(groupIDs) == 0 {
+		return resp, nil
+	}
+
+	err := db.Where("group_id IN ?", groupIDs).Find(&resp).Error
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
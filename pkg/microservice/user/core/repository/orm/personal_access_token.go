@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orm
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+)
+
+func CreatePersonalAccessToken(token *models.PersonalAccessToken, db *gorm.DB) error {
+	now := time.Now().Unix()
+	token.CreatedAt = now
+	token.UpdatedAt = now
+	return db.Create(token).Error
+}
+
+// GetPersonalAccessToken returns nil if the token does not exist
+func GetPersonalAccessToken(tokenID string, db *gorm.DB) (*models.PersonalAccessToken, error) {
+	res := &models.PersonalAccessToken{}
+	err := db.Where("token_id = ?", tokenID).First(res).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func ListPersonalAccessTokensByUID(uid string, db *gorm.DB) ([]*models.PersonalAccessToken, error) {
+	res := make([]*models.PersonalAccessToken, 0)
+	err := db.Where("uid = ?", uid).Order("created_at desc").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func RevokePersonalAccessToken(uid, tokenID string, db *gorm.DB) error {
+	now := time.Now().Unix()
+	return db.Model(&models.PersonalAccessToken{}).
+		Where("uid = ? AND token_id = ?", uid, tokenID).
+		Updates(map[string]interface{}{
+			"revoked":    true,
+			"revoked_at": now,
+			"updated_at": now,
+		}).Error
+}
+
+func UpdatePersonalAccessTokenLastUsed(tokenID string, lastUsedAt int64, db *gorm.DB) error {
+	return db.Model(&models.PersonalAccessToken{}).
+		Where("token_id = ?", tokenID).
+		Update("last_used_at", lastUsedAt).Error
+}
+
+func DeletePersonalAccessTokensByUID(uid string, db *gorm.DB) error {
+	return db.Where("uid = ?", uid).Delete(&models.PersonalAccessToken{}).Error
+}
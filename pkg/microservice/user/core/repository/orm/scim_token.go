@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orm
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+)
+
+func CreateSCIMToken(token *models.SCIMToken, db *gorm.DB) error {
+	now := time.Now().Unix()
+	token.CreatedAt = now
+	token.UpdatedAt = now
+	return db.Create(token).Error
+}
+
+// GetSCIMTokenByHash returns nil if the token does not exist
+func GetSCIMTokenByHash(tokenHash string, db *gorm.DB) (*models.SCIMToken, error) {
+	res := &models.SCIMToken{}
+	err := db.Where("token_hash = ?", tokenHash).First(res).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	return res, nil
+}
+
+func ListSCIMTokens(db *gorm.DB) ([]*models.SCIMToken, error) {
+	res := make([]*models.SCIMToken, 0)
+	err := db.Order("created_at desc").Find(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func UpdateSCIMTokenLastUsed(id uint, lastUsedAt int64, db *gorm.DB) error {
+	return db.Model(&models.SCIMToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).Error
+}
+
+func DeleteSCIMToken(id uint, db *gorm.DB) error {
+	return db.Where("id = ?", id).Delete(&models.SCIMToken{}).Error
+}
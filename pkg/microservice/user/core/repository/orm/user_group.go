@@ -128,3 +128,23 @@ func GetAllUserGroup(db *gorm.DB) (*models.UserGroup, error) {
 
 	return resp, nil
 }
+
+func ListUserGroupsByIdentityType(identityType string, db *gorm.DB) ([]*models.UserGroup, error) {
+	resp := make([]*models.UserGroup, 0)
+
+	err := db.Where("identity_type = ?", identityType).Order("created_at").Find(&resp).Error
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func ListAllUserGroups(db *gorm.DB) ([]*models.UserGroup, error) {
+	resp := make([]*models.UserGroup, 0)
+
+	err := db.Order("created_at").Find(&resp).Error
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
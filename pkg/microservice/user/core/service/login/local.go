@@ -307,6 +307,10 @@ func issueLoginTokenByUID(uid string, mfaVerified bool, logger *zap.SugaredLogge
 }
 
 func issueLoginToken(user *models.User, mfaVerified bool, logger *zap.SugaredLogger) (*User, error) {
+	if user.Disabled {
+		return nil, fmt.Errorf("user %s is disabled", user.Account)
+	}
+
 	systemSettings, err := common.GetSystemSecuritySettings(logger)
 	if err != nil {
 		logger.Errorf("failed to get system security settings, error: %s", err)
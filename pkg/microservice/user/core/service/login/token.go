@@ -34,6 +34,10 @@ type Claims struct {
 	PreferredUsername string          `json:"preferred_username"`
 	MFAVerified       bool            `json:"mfa_verified"`
 	FederatedClaims   FederatedClaims `json:"federated_claims"`
+	Groups            []string        `json:"groups,omitempty"`
+	// TokenType is set to setting.PersonalAccessTokenType for personal access tokens, the token
+	// id is kept in the jti claim
+	TokenType string `json:"token_type,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -27,6 +27,7 @@ import (
 	globalConfig "github.com/koderover/zadig/v2/pkg/config"
 	userConfig "github.com/koderover/zadig/v2/pkg/microservice/user/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/login"
+	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/cache"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
@@ -35,6 +36,7 @@ const (
 	larkWebhookURLRegExp         = `^\/api\/aslan\/system\/lark\/\w+\/webhook$`
 	dingTalkWebhookURLRegExp     = `^\/api\/aslan\/system\/dingtalk\/\w+\/webhook$`
 	workwxWebhookURLRegExp       = `^\/api\/aslan\/system\/workwx\/\w+\/webhook$`
+	slackInteractivityURLRegExp  = `^\/api\/aslan\/system\/slack\/\w+\/interactivity$`
 	getClusterAgentYamlURLRegExp = `^\/api\/aslan\/cluster\/agent\/\w+\/agent.yaml$`
 	envWorkloadUrlRegExp         = `^\/api\/aslan\/environment\/environments\/[\w-]+\/check\/workloads\/k8services$`
 	envShareEnableURLRegExp      = `^\/api\/aslan\/environment\/environments\/[\w-]+\/check\/sharenv\/enable\/ready$`
@@ -42,6 +44,8 @@ const (
 	serviceDeployableURLRegExp   = `^\/api\/aslan\/service\/services\/[\w-]+\/environments\/deployable$`
 	generalWebhookURLRegExp      = `^\/api\/aslan\/workflow\/v4\/generalhook\/[\w-]+\/[^/]+\/webhook$`
 	codeHostAuthURLRegExp        = `^\/api\/v1\/codehosts\/\w+\/auth$`
+	samlMetadataURLRegExp        = `^\/api\/v1\/connectors\/[\w-]+\/saml\/metadata$`
+	samlACSURLRegExp             = `^\/api\/v1\/saml\/[\w-]+\/acs$`
 	// workflowTestTaskReportURLRegExp = `^\/api\/aslan\/testing\/report\/workflowv4\/[\w-]+\/id\/\w+\/job\/[^/]+$`
 	// testingTaskReportURLRegExp      = `^\/api\/aslan\/testing\/testtask\/[\w-]+\/\w+\/[^/]+$`
 )
@@ -62,6 +66,11 @@ func IsPublicURL(reqPath, method string) bool {
 		return true
 	}
 
+	// SCIM APIs are authenticated with scim tokens in the business logic layer
+	if strings.HasPrefix(realPath, "/api/v1/scim/v2/") {
+		return true
+	}
+
 	// all agent related APIs do their authentication process in the business logic layer
 	if strings.HasPrefix(realPath, "/api/aslan/vm/agents") {
 		return true
@@ -226,6 +235,11 @@ func IsPublicURL(reqPath, method string) bool {
 		return true
 	}
 
+	match, _ = regexp.MatchString(slackInteractivityURLRegExp, realPath)
+	if match && method == http.MethodPost {
+		return true
+	}
+
 	match, _ = regexp.MatchString(getClusterAgentYamlURLRegExp, realPath)
 	if match && method == http.MethodGet {
 		return true
@@ -236,6 +250,16 @@ func IsPublicURL(reqPath, method string) bool {
 		return true
 	}
 
+	match, _ = regexp.MatchString(samlMetadataURLRegExp, realPath)
+	if match && method == http.MethodGet {
+		return true
+	}
+
+	match, _ = regexp.MatchString(samlACSURLRegExp, realPath)
+	if match && method == http.MethodPost {
+		return true
+	}
+
 	match, _ = regexp.MatchString(envWorkloadUrlRegExp, realPath)
 	if match && method == http.MethodGet {
 		return true
@@ -283,6 +307,20 @@ func ValidateToken(tokenString string) (*login.Claims, bool, error) {
 			return claims, true, nil
 		}
 
+		// personal access token: validate against its stored record
+		if claims.TokenType == setting.PersonalAccessTokenType {
+			valid, err := ValidatePersonalAccessToken(claims, tokenString)
+			if err != nil {
+				log.Errorf("Failed to validate personal access token, uid: %s, err: %s", claims.UID, err)
+				return nil, false, err
+			}
+			if !valid {
+				log.Errorf("personal access token revoked, expired or unauthorized for uid: %s", claims.UID)
+				return nil, false, fmt.Errorf("token mismatch")
+			}
+			return claims, true, nil
+		}
+
 		// short-lived login token: validate against redis cache
 		if claims.ExpiresAt-time.Now().Unix() < 8760*60*60 {
 			cachedToken, err := cache.NewRedisCache(userConfig.RedisUserTokenDB()).GetString(claims.UID)
@@ -0,0 +1,425 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package permission
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/mongodb"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/orm"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/login"
+	"github.com/koderover/zadig/v2/pkg/setting"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+// personalAccessTokenLastUsedInterval limits how often the last used time of a token is written
+const personalAccessTokenLastUsedInterval = 60
+
+type CreatePersonalAccessTokenArgs struct {
+	Name      string `json:"name"`
+	ExpiresAt int64  `json:"expires_at"`
+	// Projects limits the token to the given projects, empty means every project the user can access
+	Projects []string `json:"projects"`
+	// Verbs limits the token to the given actions, empty means every action the user is granted
+	Verbs []string `json:"verbs"`
+}
+
+type PersonalAccessToken struct {
+	TokenID    string   `json:"token_id"`
+	Name       string   `json:"name"`
+	Projects   []string `json:"projects"`
+	Verbs      []string `json:"verbs"`
+	ExpiresAt  int64    `json:"expires_at"`
+	LastUsedAt int64    `json:"last_used_at"`
+	CreatedAt  int64    `json:"created_at"`
+	Revoked    bool     `json:"revoked"`
+	RevokedAt  int64    `json:"revoked_at"`
+	Expired    bool     `json:"expired"`
+	// Token is only returned when the token is created
+	Token string `json:"token,omitempty"`
+}
+
+func (args *CreatePersonalAccessTokenArgs) Validate() error {
+	if args.Name == "" {
+		return fmt.Errorf("token name is required")
+	}
+	if args.ExpiresAt <= time.Now().Unix() {
+		return fmt.Errorf("token expiry must be in the future")
+	}
+
+	for _, verb := range args.Verbs {
+		action, err := orm.GetActionByVerb(verb, repository.DB)
+		if err != nil {
+			return fmt.Errorf("failed to find action %s, error: %s", verb, err)
+		}
+		if action == nil || action.Action == "" {
+			return fmt.Errorf("unknown action: %s", verb)
+		}
+	}
+
+	if len(args.Projects) > 0 {
+		projectList, err := mongodb.NewProjectColl().List()
+		if err != nil {
+			return fmt.Errorf("failed to list projects, error: %s", err)
+		}
+		projectSet := sets.New[string]()
+		for _, project := range projectList {
+			projectSet.Insert(project.ProductName)
+		}
+		for _, project := range args.Projects {
+			if !projectSet.Has(project) {
+				return fmt.Errorf("project %s not found", project)
+			}
+		}
+	}
+	return nil
+}
+
+func CreatePersonalAccessToken(uid string, args *CreatePersonalAccessTokenArgs, logger *zap.SugaredLogger) (*PersonalAccessToken, error) {
+	if err := args.Validate(); err != nil {
+		return nil, e.ErrInvalidParam.AddErr(err)
+	}
+
+	user, err := orm.GetUserByUid(uid, repository.DB)
+	if err != nil {
+		logger.Errorf("CreatePersonalAccessToken getUserByUid:%s error, error msg:%s", uid, err.Error())
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not exist")
+	}
+
+	tokenEnabled, err := userCanUseAPIToken(user)
+	if err != nil {
+		logger.Errorf("CreatePersonalAccessToken check user token authorization uid:%s error, error msg:%s", uid, err.Error())
+		return nil, err
+	}
+	if !tokenEnabled {
+		return nil, e.ErrForbidden
+	}
+
+	userMFA, err := orm.GetUserMFA(uid, repository.DB)
+	if err != nil {
+		logger.Errorf("CreatePersonalAccessToken GetUserMFA:%s error, error msg:%s", uid, err.Error())
+		return nil, err
+	}
+
+	tokenID := uuid.NewString()
+	token, err := login.CreateToken(&login.Claims{
+		Name:              user.Name,
+		UID:               user.UID,
+		Email:             user.Email,
+		PreferredUsername: user.Account,
+		MFAVerified:       userMFA != nil && userMFA.Enabled,
+		TokenType:         setting.PersonalAccessTokenType,
+		StandardClaims: jwt.StandardClaims{
+			Audience:  setting.ProductName,
+			ExpiresAt: args.ExpiresAt,
+			Id:        tokenID,
+		},
+		FederatedClaims: login.FederatedClaims{
+			ConnectorId: user.IdentityType,
+			UserId:      user.Account,
+		},
+	})
+	if err != nil {
+		logger.Errorf("CreatePersonalAccessToken create token for user:%s error, error msg:%s", user.Account, err.Error())
+		return nil, err
+	}
+
+	projectsJSON, err := json.Marshal(args.Projects)
+	if err != nil {
+		return nil, err
+	}
+	verbsJSON, err := json.Marshal(args.Verbs)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.PersonalAccessToken{
+		TokenID:      tokenID,
+		UID:          uid,
+		Name:         args.Name,
+		TokenHash:    hashPersonalAccessToken(token),
+		ProjectsJSON: string(projectsJSON),
+		VerbsJSON:    string(verbsJSON),
+		ExpiresAt:    args.ExpiresAt,
+	}
+	if err := orm.CreatePersonalAccessToken(record, repository.DB); err != nil {
+		logger.Errorf("CreatePersonalAccessToken save token for user:%s error, error msg:%s", user.Account, err.Error())
+		return nil, err
+	}
+
+	resp := toPersonalAccessToken(record)
+	resp.Token = token
+	return resp, nil
+}
+
+func ListPersonalAccessTokens(uid string, logger *zap.SugaredLogger) ([]*PersonalAccessToken, error) {
+	tokens, err := orm.ListPersonalAccessTokensByUID(uid, repository.DB)
+	if err != nil {
+		logger.Errorf("ListPersonalAccessTokens uid:%s error, error msg:%s", uid, err.Error())
+		return nil, err
+	}
+
+	resp := make([]*PersonalAccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, toPersonalAccessToken(token))
+	}
+	return resp, nil
+}
+
+func RevokePersonalAccessToken(uid, tokenID string, logger *zap.SugaredLogger) error {
+	token, err := orm.GetPersonalAccessToken(tokenID, repository.DB)
+	if err != nil {
+		logger.Errorf("RevokePersonalAccessToken token:%s error, error msg:%s", tokenID, err.Error())
+		return err
+	}
+	if token == nil || token.UID != uid {
+		return e.ErrInvalidParam.AddDesc("token not found")
+	}
+	return orm.RevokePersonalAccessToken(uid, tokenID, repository.DB)
+}
+
+// ValidatePersonalAccessToken checks that the token is known, belongs to the user of the claims,
+// has not been revoked or expired, and records when it was last used.
+func ValidatePersonalAccessToken(claims *login.Claims, tokenString string) (bool, error) {
+	token, err := orm.GetPersonalAccessToken(claims.Id, repository.DB)
+	if err != nil {
+		return false, err
+	}
+	if token == nil || token.UID != claims.UID || token.Revoked {
+		return false, nil
+	}
+	now := time.Now().Unix()
+	if token.ExpiresAt <= now || token.TokenHash != hashPersonalAccessToken(tokenString) {
+		return false, nil
+	}
+
+	user, err := orm.GetUserByUid(claims.UID, repository.DB)
+	if err != nil {
+		return false, err
+	}
+	if user == nil {
+		return false, nil
+	}
+	tokenEnabled, err := userCanUseAPIToken(user)
+	if err != nil || !tokenEnabled {
+		return false, err
+	}
+
+	if now-token.LastUsedAt > personalAccessTokenLastUsedInterval {
+		if err := orm.UpdatePersonalAccessTokenLastUsed(token.TokenID, now, repository.DB); err != nil {
+			log.Warnf("failed to update last used time of token %s, error: %s", token.TokenID, err)
+		}
+	}
+	return true, nil
+}
+
+func hashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func toPersonalAccessToken(token *models.PersonalAccessToken) *PersonalAccessToken {
+	projects, verbs := decodePersonalAccessTokenScope(token)
+	return &PersonalAccessToken{
+		TokenID:    token.TokenID,
+		Name:       token.Name,
+		Projects:   projects,
+		Verbs:      verbs,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+		Revoked:    token.Revoked,
+		RevokedAt:  token.RevokedAt,
+		Expired:    token.ExpiresAt <= time.Now().Unix(),
+	}
+}
+
+func decodePersonalAccessTokenScope(token *models.PersonalAccessToken) (projects, verbs []string) {
+	projects, verbs = make([]string, 0), make([]string, 0)
+	if token.ProjectsJSON != "" {
+		_ = json.Unmarshal([]byte(token.ProjectsJSON), &projects)
+	}
+	if token.VerbsJSON != "" {
+		_ = json.Unmarshal([]byte(token.VerbsJSON), &verbs)
+	}
+	return
+}
+
+// GetUserAuthInfoByToken returns the resources of the user limited to the scope of the given personal access token
+func GetUserAuthInfoByToken(uid, tokenID string, logger *zap.SugaredLogger) (*AuthorizedResources, error) {
+	resources, err := GetUserAuthInfo(uid, logger)
+	if err != nil {
+		return nil, err
+	}
+	if tokenID == "" {
+		return resources, nil
+	}
+
+	resp, err := restrictAuthorizedResources(resources, tokenID)
+	if err != nil {
+		logger.Errorf("failed to restrict auth info of uid: %s by token: %s, error: %s", uid, tokenID, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// restrictAuthorizedResources narrows the resources of the token owner down to the projects and
+// verbs of the token. A scoped token never carries system admin rights.
+func restrictAuthorizedResources(resources *AuthorizedResources, tokenID string) (*AuthorizedResources, error) {
+	token, err := orm.GetPersonalAccessToken(tokenID, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, fmt.Errorf("token %s not found", tokenID)
+	}
+
+	projects, verbs := decodePersonalAccessTokenScope(token)
+	if len(projects) == 0 && len(verbs) == 0 {
+		return resources, nil
+	}
+
+	projectAuthInfo := resources.ProjectAuthInfo
+	systemActions := resources.SystemActions
+	if resources.IsSystemAdmin {
+		projectAuthInfo = make(map[string]ProjectActions)
+		names := projects
+		if len(names) == 0 {
+			projectList, err := mongodb.NewProjectColl().List()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list projects, error: %s", err)
+			}
+			for _, project := range projectList {
+				names = append(names, project.ProductName)
+			}
+		}
+		for _, name := range names {
+			projectAuthInfo[name] = ProjectActions{IsProjectAdmin: true}
+		}
+		systemActions = generateDefaultSystemActions()
+		grantAllActions(systemActions)
+	}
+
+	projectSet := sets.New[string](projects...)
+	resp := &AuthorizedResources{
+		IsSystemAdmin:   false,
+		ProjectAuthInfo: make(map[string]ProjectActions),
+		SystemActions:   systemActions,
+	}
+	for name, actions := range projectAuthInfo {
+		if len(projects) > 0 && !projectSet.Has(name) {
+			continue
+		}
+		if len(verbs) > 0 {
+			if actions.IsProjectAdmin {
+				actions = *generateDefaultProjectActions()
+				grantAllActions(&actions)
+				actions.IsProjectAdmin = false
+			}
+			allowed := generateDefaultProjectActions()
+			for _, verb := range verbs {
+				modifyUserProjectAuth(allowed, verb)
+			}
+			intersectActions(&actions, allowed)
+		}
+		resp.ProjectAuthInfo[name] = actions
+	}
+
+	if len(verbs) > 0 {
+		allowed := generateDefaultSystemActions()
+		for _, verb := range verbs {
+			modifySystemAction(allowed, verb)
+		}
+		if resp.SystemActions == nil {
+			resp.SystemActions = generateDefaultSystemActions()
+		}
+		intersectActions(resp.SystemActions, allowed)
+	} else {
+		// a token scoped only by projects carries no system action since none of its verbs grants one
+		resp.SystemActions = generateDefaultSystemActions()
+	}
+	return resp, nil
+}
+
+// grantAllActions sets every action flag of a ProjectActions or SystemActions to true.
+func grantAllActions(actions interface{}) {
+	walkActions(reflect.ValueOf(actions), reflect.Value{})
+}
+
+// intersectActions keeps an action flag of dst only if it is also set in allowed.
+func intersectActions(dst, allowed interface{}) {
+	walkActions(reflect.ValueOf(dst), reflect.ValueOf(allowed))
+}
+
+func walkActions(dst, allowed reflect.Value) {
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			return
+		}
+		dst = dst.Elem()
+		if allowed.IsValid() {
+			if allowed.IsNil() {
+				allowed = reflect.Value{}
+			} else {
+				allowed = allowed.Elem()
+			}
+		}
+	}
+
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Field(i)
+		var allowedField reflect.Value
+		if allowed.IsValid() {
+			allowedField = allowed.Field(i)
+		}
+		switch field.Kind() {
+		case reflect.Bool:
+			if allowed.IsValid() {
+				field.SetBool(field.Bool() && allowedField.Bool())
+			} else {
+				field.SetBool(true)
+			}
+		case reflect.Ptr:
+			if field.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			if field.IsNil() {
+				if allowed.IsValid() {
+					continue
+				}
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			walkActions(field, allowedField)
+		}
+	}
+}
@@ -126,10 +126,10 @@ type UserSetting struct {
 
 func SearchAndSyncUser(ldapId string, logger *zap.SugaredLogger) error {
 	systemConfigClient := systemconfig.New()
-	si, err := systemConfigClient.GetLDAPConnector(ldapId)
+	si, err := systemConfigClient.GetConnector(ldapId)
 	if err != nil {
-		logger.Errorf("SearchAndSyncUser GetLDAPConnector error, error msg:%s", err)
-		return fmt.Errorf("SearchAndSyncUser GetLDAPConnector error, error msg:%s", err)
+		logger.Errorf("SearchAndSyncUser GetConnector error, error msg:%s", err)
+		return fmt.Errorf("SearchAndSyncUser GetConnector error, error msg:%s", err)
 	}
 	if si == nil || si.Config == nil {
 		logger.Error("can't find connector")
@@ -377,6 +377,9 @@ func ValidateAPIToken(uid, token string) (bool, error) {
 }
 
 func userCanUseAPIToken(user *models.User) (bool, error) {
+	if user.Disabled {
+		return false, nil
+	}
 	isSystemAdmin, err := checkUserIsSystemAdmin(user.UID, repository.DB)
 	if err != nil {
 		return false, err
@@ -774,6 +777,12 @@ func DeleteUserByUID(uid string, logger *zap.SugaredLogger) error {
 		logger.Errorf("DeleteUserByUID DeleteUserMFA:%s error, error msg:%s", uid, err.Error())
 		return err
 	}
+	err = orm.DeletePersonalAccessTokensByUID(uid, tx)
+	if err != nil {
+		tx.Rollback()
+		logger.Errorf("DeleteUserByUID DeletePersonalAccessTokensByUID:%s error, error msg:%s", uid, err.Error())
+		return err
+	}
 	err = mongodb.NewUserSettingColl().DeleteUserSettingByUid(uid)
 	if err != nil {
 		tx.Rollback()
@@ -801,6 +810,22 @@ func DeleteUserByUID(uid string, logger *zap.SugaredLogger) error {
 	return nil
 }
 
+// SetUserDisabled enables or disables a user, a disabled user is logged out immediately
+func SetUserDisabled(uid string, disabled bool, logger *zap.SugaredLogger) error {
+	if err := orm.UpdateUserValues(uid, map[string]interface{}{"disabled": disabled}, repository.DB); err != nil {
+		logger.Errorf("SetUserDisabled uid:%s error, error msg:%s", uid, err.Error())
+		return err
+	}
+	if !disabled {
+		return nil
+	}
+
+	if err := zadigCache.NewRedisCache(config.RedisUserTokenDB()).Delete(uid); err != nil {
+		logger.Warnf("failed to invalidate token for disabled user %s: %v", uid, err)
+	}
+	return nil
+}
+
 func DeleteCollaborationModeByUid(uid string) error {
 	// cleanup collaboration resources
 	err := aslanmongodb.NewCollaborationInstanceColl().LogicDeleteByUserID(uid)
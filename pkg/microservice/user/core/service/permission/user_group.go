@@ -17,24 +17,38 @@ limitations under the License.
 package permission
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
+	gocache "github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/koderover/zadig/v2/pkg/config"
+	connectorservice "github.com/koderover/zadig/v2/pkg/microservice/systemconfig/core/connector/service"
 	userconfig "github.com/koderover/zadig/v2/pkg/microservice/user/config"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/orm"
 	"github.com/koderover/zadig/v2/pkg/setting"
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
 	"github.com/koderover/zadig/v2/pkg/tool/cache"
 	"github.com/koderover/zadig/v2/pkg/types"
 )
 
 func CreateUserGroup(groupName, desc string, uids []string, logger *zap.SugaredLogger) (*models.UserGroup, error) {
+	return createUserGroup(groupName, desc, "", uids, logger)
+}
+
+// CreateProvisionedUserGroup creates a user group provisioned by SCIM for the given identity type
+func CreateProvisionedUserGroup(groupName, desc, identityType string, logger *zap.SugaredLogger) (*models.UserGroup, error) {
+	return createUserGroup(groupName, desc, identityType, nil, logger)
+}
+
+func createUserGroup(groupName, desc, identityType string, uids []string, logger *zap.SugaredLogger) (*models.UserGroup, error) {
 	tx := repository.DB.Begin()
 	defer func() {
 		if r := recover(); r != nil {
@@ -45,10 +59,11 @@ func CreateUserGroup(groupName, desc string, uids []string, logger *zap.SugaredL
 	gid, _ := uuid.NewUUID()
 
 	userGroup := &models.UserGroup{
-		GroupID:     gid.String(),
-		GroupName:   groupName,
-		Description: desc,
-		Type:        int64(setting.RoleTypeCustom),
+		GroupID:      gid.String(),
+		GroupName:    groupName,
+		Description:  desc,
+		Type:         int64(setting.RoleTypeCustom),
+		IdentityType: identityType,
 	}
 
 	err := orm.CreateUserGroup(userGroup, tx)
@@ -289,3 +304,100 @@ func BulkRemoveUserFromUserGroup(groupID string, uids []string, logger *zap.Suga
 
 	return nil
 }
+
+// connectorGroupMappingCache caches the group mapping of connectors so that logins through a connector
+// without a mapping do not need to load the connector every time
+var connectorGroupMappingCache = gocache.New(time.Minute, time.Minute*5)
+
+// GetConnectorGroupMapping returns the IdP group to user group mapping of a saml connector,
+// it is empty if the connector is not a saml connector or has no mapping.
+func GetConnectorGroupMapping(connectorID string) (map[string]string, error) {
+	if mapping, ok := connectorGroupMappingCache.Get(connectorID); ok {
+		return mapping.(map[string]string), nil
+	}
+
+	connector, err := systemconfig.New().GetConnector(connectorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connector %s, error: %s", connectorID, err)
+	}
+	mappingConfig := &struct {
+		GroupMapping map[string]string `json:"groupMapping"`
+	}{}
+	if connector.Type == string(connectorservice.TypeSAML) {
+		configBytes, err := json.Marshal(connector.Config)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(configBytes, mappingConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse group mapping of connector %s: %s", connectorID, err)
+		}
+	}
+	if mappingConfig.GroupMapping == nil {
+		mappingConfig.GroupMapping = make(map[string]string)
+	}
+	connectorGroupMappingCache.Set(connectorID, mappingConfig.GroupMapping, gocache.DefaultExpiration)
+	return mappingConfig.GroupMapping, nil
+}
+
+// SyncConnectorUserGroups synchronizes the membership of the user groups in the group mapping of the given
+// connector with the groups the identity provider reported for the user. User groups that are not part of
+// the mapping are left untouched.
+func SyncConnectorUserGroups(uid, connectorID string, mapping map[string]string, idpGroups []string, logger *zap.SugaredLogger) error {
+	currentGroups, err := orm.ListUserGroupByUID(uid, repository.DB)
+	if err != nil {
+		logger.Errorf("failed to list groups by uid: %s, error: %s", uid, err)
+		return err
+	}
+	currentGroupNames := sets.New[string]()
+	for _, group := range currentGroups {
+		currentGroupNames.Insert(group.GroupName)
+	}
+
+	toAdd, toRemove := diffMappedUserGroups(mapping, idpGroups, currentGroupNames)
+	for _, name := range toAdd {
+		group, err := orm.GetUserGroupByName(name, repository.DB)
+		if err != nil {
+			return err
+		}
+		if group.GroupID == "" {
+			logger.Warnf("user group %s mapped by connector %s does not exist, skip it", name, connectorID)
+			continue
+		}
+		if err := BulkAddUserToUserGroup(group.GroupID, []string{uid}, logger); err != nil {
+			logger.Errorf("failed to add user %s to user group %s, error: %s", uid, name, err)
+			return err
+		}
+	}
+	for _, name := range toRemove {
+		group, err := orm.GetUserGroupByName(name, repository.DB)
+		if err != nil {
+			return err
+		}
+		if group.GroupID == "" {
+			continue
+		}
+		if err := BulkRemoveUserFromUserGroup(group.GroupID, []string{uid}, logger); err != nil {
+			logger.Errorf("failed to remove user %s from user group %s, error: %s", uid, name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// diffMappedUserGroups returns the mapped user groups the user should join and the ones the user should leave
+func diffMappedUserGroups(mapping map[string]string, idpGroups []string, currentGroups sets.Set[string]) (toAdd, toRemove []string) {
+	desired := sets.New[string]()
+	for _, group := range idpGroups {
+		if name, ok := mapping[group]; ok && name != "" {
+			desired.Insert(name)
+		}
+	}
+	managed := sets.New[string]()
+	for _, name := range mapping {
+		managed.Insert(name)
+	}
+
+	toAdd = sets.List(desired.Difference(currentGroups))
+	toRemove = sets.List(managed.Difference(desired).Intersection(currentGroups))
+	return
+}
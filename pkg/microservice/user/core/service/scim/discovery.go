@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+// ServiceProviderConfig describes the supported SCIM features, see RFC 7643 section 5
+func ServiceProviderConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":        []string{SchemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxPageSize},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication with a SCIM token created by a system admin",
+				"primary":     true,
+			},
+		},
+		"meta": &Meta{ResourceType: "ServiceProviderConfig"},
+	}
+}
+
+// ResourceTypes lists the resources served by the SCIM endpoint
+func ResourceTypes() *ListResponse {
+	resources := []interface{}{
+		map[string]interface{}{
+			"schemas":  []string{SchemaResourceType},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   SchemaUser,
+			"meta":     &Meta{ResourceType: "ResourceType"},
+		},
+		map[string]interface{}{
+			"schemas":  []string{SchemaResourceType},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   SchemaGroup,
+			"meta":     &Meta{ResourceType: "ResourceType"},
+		},
+	}
+	return newListResponse(resources, len(resources), &ListOptions{StartIndex: 1})
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Attributes holds the values of a resource keyed by the lower cased attribute path, e.g. "username"
+// or "emails.value". Multi-valued attributes match a filter if any of their values does.
+type Attributes map[string][]string
+
+// Filter is a parsed SCIM filter expression, see RFC 7644 section 3.4.2.2
+type Filter interface {
+	Match(attrs Attributes) bool
+}
+
+type logicalFilter struct {
+	and         bool
+	left, right Filter
+}
+
+func (f *logicalFilter) Match(attrs Attributes) bool {
+	if f.and {
+		return f.left.Match(attrs) && f.right.Match(attrs)
+	}
+	return f.left.Match(attrs) || f.right.Match(attrs)
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f *notFilter) Match(attrs Attributes) bool {
+	return !f.filter.Match(attrs)
+}
+
+type compareFilter struct {
+	attr  string
+	op    string
+	value string
+}
+
+func (f *compareFilter) Match(attrs Attributes) bool {
+	values := attrs[f.attr]
+	if f.op == "pr" {
+		for _, v := range values {
+			if v != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if f.op == "ne" {
+		for _, v := range values {
+			if strings.EqualFold(v, f.value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, v := range values {
+		if compareValue(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValue(v, op, expected string) bool {
+	lv, le := strings.ToLower(v), strings.ToLower(expected)
+	switch op {
+	case "eq":
+		return lv == le
+	case "co":
+		return strings.Contains(lv, le)
+	case "sw":
+		return strings.HasPrefix(lv, le)
+	case "ew":
+		return strings.HasSuffix(lv, le)
+	case "gt", "ge", "lt", "le":
+		cmp := strings.Compare(lv, le)
+		if a, err := strconv.ParseFloat(v, 64); err == nil {
+			if b, err := strconv.ParseFloat(expected, 64); err == nil {
+				switch {
+				case a < b:
+					cmp = -1
+				case a > b:
+					cmp = 1
+				default:
+					cmp = 0
+				}
+			}
+		}
+		switch op {
+		case "gt":
+			return cmp > 0
+		case "ge":
+			return cmp >= 0
+		case "lt":
+			return cmp < 0
+		default:
+			return cmp <= 0
+		}
+	}
+	return false
+}
+
+// ParseFilter parses a SCIM filter. Complex attribute filters like emails[type eq "work"] are
+// flattened into their sub attribute.
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenizeFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in filter", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(s string) ([]filterToken, error) {
+	tokens := make([]filterToken, 0)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, filterToken{text: string(c)})
+			i++
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string in filter")
+			}
+			tokens = append(tokens, filterToken{text: b.String(), quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && !strings.ContainsRune("()[]\"", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, filterToken{text: s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	// prefix is the complex attribute the parser is inside of, e.g. "emails"
+	prefix string
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, fmt.Errorf("unexpected end of filter")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseFactor() (Filter, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		f, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if t.text == "(" && !t.quoted {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, err := p.next(); err != nil || t.text != ")" {
+			return nil, fmt.Errorf("missing ) in filter")
+		}
+		return f, nil
+	}
+	if t.quoted {
+		return nil, fmt.Errorf("unexpected value %q in filter", t.text)
+	}
+
+	attr := normalizeAttributePath(t.text)
+	if p.prefix != "" {
+		attr = p.prefix + "." + attr
+	}
+
+	// complex attribute filter, e.g. emails[type eq "work" and value co "@example.com"]
+	if p.pos < len(p.tokens) && p.tokens[p.pos].text == "[" && !p.tokens[p.pos].quoted {
+		p.pos++
+		outer := p.prefix
+		p.prefix = attr
+		f, err := p.parseOr()
+		p.prefix = outer
+		if err != nil {
+			return nil, err
+		}
+		if t, err := p.next(); err != nil || t.text != "]" {
+			return nil, fmt.Errorf("missing ] in filter")
+		}
+		return f, nil
+	}
+
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	opText := strings.ToLower(op.text)
+	switch opText {
+	case "pr":
+		return &compareFilter{attr: attr, op: opText}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("unsupported operator %q in filter", op.text)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if !value.quoted && value.text == "null" {
+		// attr eq null is the same as not present
+		if opText == "eq" {
+			return &notFilter{filter: &compareFilter{attr: attr, op: "pr"}}, nil
+		}
+		return &compareFilter{attr: attr, op: "pr"}, nil
+	}
+	return &compareFilter{attr: attr, op: opText, value: value.text}, nil
+}
+
+// normalizeAttributePath removes the schema urn prefix and lower cases the path
+func normalizeAttributePath(path string) string {
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		if i := strings.LastIndex(path, ":"); i >= 0 {
+			path = path[i+1:]
+		}
+	}
+	return strings.ToLower(path)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	attrs := Attributes{
+		"username":     {"Alice"},
+		"externalid":   {"00u1"},
+		"active":       {"true"},
+		"emails.value": {"alice@example.com", "alice@corp.example.com"},
+		"emails.type":  {"work"},
+	}
+
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{`userName eq "alice"`, true},
+		{`userName eq "bob"`, false},
+		{`userName ne "bob"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName sw "ali"`, true},
+		{`emails.value co "@corp."`, true},
+		{`emails[type eq "work" and value ew "example.com"]`, true},
+		{`emails[type eq "home"]`, false},
+		{`externalId pr`, true},
+		{`displayName pr`, false},
+		{`displayName eq null`, true},
+		{`userName eq "bob" or active eq true`, true},
+		{`not (userName eq "alice") and active eq true`, false},
+		{`(userName eq "bob" or userName eq "alice") and externalId eq "00u1"`, true},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.filter)
+		require.NoError(t, err, tt.filter)
+		assert.Equal(t, tt.match, f.Match(attrs), tt.filter)
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, filter := range []string{
+		`userName`,
+		`userName xx "a"`,
+		`userName eq "a`,
+		`(userName eq "a"`,
+		`emails[type eq "work"`,
+		`userName eq "a" extra`,
+	} {
+		_, err := ParseFilter(filter)
+		assert.Error(t, err, filter)
+	}
+}
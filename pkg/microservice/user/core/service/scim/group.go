@@ -0,0 +1,414 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/orm"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/permission"
+)
+
+const (
+	// maxGroupNameLength follows the column size of the user group table
+	maxGroupNameLength = 32
+	groupDescription   = "Provisioned by SCIM"
+)
+
+func ListGroups(token *models.SCIMToken, opts *ListOptions, logger *zap.SugaredLogger) (*ListResponse, error) {
+	var filter Filter
+	if opts.Filter != "" {
+		f, err := ParseFilter(opts.Filter)
+		if err != nil {
+			return nil, errInvalidFilter(err)
+		}
+		filter = f
+	}
+
+	groups, err := listManagedGroups(token)
+	if err != nil {
+		logger.Errorf("failed to list user groups, error: %s", err)
+		return nil, err
+	}
+	groupIDs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		groupIDs = append(groupIDs, group.GroupID)
+	}
+	bindings, err := orm.ListGroupBindingsByGroupIDs(groupIDs, repository.DB)
+	if err != nil {
+		logger.Errorf("failed to list group bindings, error: %s", err)
+		return nil, err
+	}
+	membersByGroup := make(map[string][]string)
+	for _, binding := range bindings {
+		membersByGroup[binding.GroupID] = append(membersByGroup[binding.GroupID], binding.UID)
+	}
+
+	matched := make([]*Group, 0)
+	for _, group := range groups {
+		resource := toSCIMGroup(group, membersByGroup[group.GroupID])
+		if filter != nil && !filter.Match(groupAttributes(resource)) {
+			continue
+		}
+		matched = append(matched, resource)
+	}
+
+	start, end := opts.page(len(matched))
+	resources := make([]interface{}, 0, end-start)
+	for _, resource := range matched[start:end] {
+		resources = append(resources, resource)
+	}
+	return newListResponse(resources, len(matched), opts), nil
+}
+
+func GetGroup(token *models.SCIMToken, id string) (*Group, error) {
+	group, err := getManagedGroup(token, id)
+	if err != nil {
+		return nil, err
+	}
+	members, err := listGroupMembers(group.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMGroup(group, members), nil
+}
+
+func CreateGroup(token *models.SCIMToken, args *Group, logger *zap.SugaredLogger) (*Group, error) {
+	if err := validateGroup(args); err != nil {
+		return nil, err
+	}
+	existing, err := orm.GetUserGroupByName(args.DisplayName, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	if existing.GroupID != "" {
+		return nil, errUniqueness("group %s already exists", args.DisplayName)
+	}
+
+	uids, err := memberUIDs(token, args.Members)
+	if err != nil {
+		return nil, err
+	}
+	group, err := permission.CreateProvisionedUserGroup(args.DisplayName, groupDescription, token.IdentityType, logger)
+	if err != nil {
+		return nil, err
+	}
+	// members are added separately so the group cache of the members is flushed
+	if err := permission.BulkAddUserToUserGroup(group.GroupID, uids, logger); err != nil {
+		return nil, err
+	}
+	return GetGroup(token, group.GroupID)
+}
+
+func ReplaceGroup(token *models.SCIMToken, id string, args *Group, logger *zap.SugaredLogger) (*Group, error) {
+	group, err := getManagedGroup(token, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGroup(args); err != nil {
+		return nil, err
+	}
+	if err := renameGroup(group, args.DisplayName, logger); err != nil {
+		return nil, err
+	}
+
+	uids, err := memberUIDs(token, args.Members)
+	if err != nil {
+		return nil, err
+	}
+	if err := setGroupMembers(group.GroupID, sets.New[string](uids...), logger); err != nil {
+		return nil, err
+	}
+	return GetGroup(token, id)
+}
+
+func PatchGroup(token *models.SCIMToken, id string, args *PatchRequest, logger *zap.SugaredLogger) (*Group, error) {
+	group, err := getManagedGroup(token, id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := listGroupMembers(group.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := sets.New[string](current...)
+	displayName := group.GroupName
+	for _, op := range args.Operations {
+		displayName, err = applyGroupPatch(displayName, members, op)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if displayName != group.GroupName {
+		if err := validateGroup(&Group{DisplayName: displayName}); err != nil {
+			return nil, err
+		}
+		if err := renameGroup(group, displayName, logger); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkUsersExist(token, sets.List(members.Difference(sets.New[string](current...)))); err != nil {
+		return nil, err
+	}
+	if err := setGroupMembers(group.GroupID, members, logger); err != nil {
+		return nil, err
+	}
+	return GetGroup(token, id)
+}
+
+func DeleteGroup(token *models.SCIMToken, id string, logger *zap.SugaredLogger) error {
+	group, err := getManagedGroup(token, id)
+	if err != nil {
+		return err
+	}
+	// remove the members first so their group cache is flushed, the role bindings of the group
+	// stop applying to them right away
+	if err := setGroupMembers(group.GroupID, sets.New[string](), logger); err != nil {
+		return err
+	}
+	return permission.DeleteUserGroup(group.GroupID, logger)
+}
+
+func applyGroupPatch(displayName string, members sets.Set[string], op *PatchOperation) (string, error) {
+	opName, err := normalizeOp(op.Op)
+	if err != nil {
+		return displayName, err
+	}
+
+	if op.Path == "" {
+		if opName == patchOpRemove {
+			return displayName, newError(http.StatusBadRequest, "noTarget", "path is required for remove operations")
+		}
+		values, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return displayName, errInvalidValue("value must be an object when no path is given")
+		}
+		for key, value := range values {
+			displayName, err = applyGroupPatch(displayName, members, &PatchOperation{Op: opName, Path: key, Value: value})
+			if err != nil {
+				return displayName, err
+			}
+		}
+		return displayName, nil
+	}
+
+	path, err := parsePatchPath(op.Path)
+	if err != nil {
+		return displayName, err
+	}
+
+	switch path.attr {
+	case "displayname":
+		if opName == patchOpRemove {
+			return displayName, errInvalidValue("displayName can not be removed")
+		}
+		return stringValue(op.Value)
+	case "members":
+		switch opName {
+		case patchOpRemove:
+			if path.filter != nil {
+				for _, uid := range members.UnsortedList() {
+					if path.filter.Match(Attributes{"value": {uid}}) {
+						members.Delete(uid)
+					}
+				}
+				return displayName, nil
+			}
+			// without a filter the value lists the members to remove, no value removes all of them
+			if op.Value == nil {
+				members.Clear()
+				return displayName, nil
+			}
+			values, err := multiValues(op.Value)
+			if err != nil {
+				return displayName, err
+			}
+			for _, v := range values {
+				members.Delete(v.Value)
+			}
+		case patchOpReplace:
+			values, err := multiValues(op.Value)
+			if err != nil {
+				return displayName, err
+			}
+			members.Clear()
+			for _, v := range values {
+				members.Insert(v.Value)
+			}
+		default:
+			values, err := multiValues(op.Value)
+			if err != nil {
+				return displayName, err
+			}
+			for _, v := range values {
+				members.Insert(v.Value)
+			}
+		}
+	default:
+		// externalId and unknown attributes are not stored for groups
+	}
+	return displayName, nil
+}
+
+// listManagedGroups returns the groups provisioned by SCIM for the identity type of the token,
+// the groups created in zadig are never exposed to SCIM clients
+func listManagedGroups(token *models.SCIMToken) ([]*models.UserGroup, error) {
+	return orm.ListUserGroupsByIdentityType(token.IdentityType, repository.DB)
+}
+
+func getManagedGroup(token *models.SCIMToken, id string) (*models.UserGroup, error) {
+	group, err := orm.GetUserGroup(id, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	if group.GroupID == "" || group.IdentityType == "" || group.IdentityType != token.IdentityType {
+		return nil, errNotFound("Group", id)
+	}
+	return group, nil
+}
+
+func listGroupMembers(groupID string) ([]string, error) {
+	bindings, err := orm.ListGroupBindingsByGroupIDs([]string{groupID}, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		resp = append(resp, binding.UID)
+	}
+	return resp, nil
+}
+
+func setGroupMembers(groupID string, members sets.Set[string], logger *zap.SugaredLogger) error {
+	current, err := listGroupMembers(groupID)
+	if err != nil {
+		return err
+	}
+	currentSet := sets.New[string](current...)
+
+	if toAdd := sets.List(members.Difference(currentSet)); len(toAdd) > 0 {
+		if err := permission.BulkAddUserToUserGroup(groupID, toAdd, logger); err != nil {
+			return err
+		}
+	}
+	if toRemove := sets.List(currentSet.Difference(members)); len(toRemove) > 0 {
+		if err := permission.BulkRemoveUserFromUserGroup(groupID, toRemove, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renameGroup(group *models.UserGroup, name string, logger *zap.SugaredLogger) error {
+	if name == group.GroupName {
+		return nil
+	}
+	existing, err := orm.GetUserGroupByName(name, repository.DB)
+	if err != nil {
+		return err
+	}
+	if existing.GroupID != "" {
+		return errUniqueness("group %s already exists", name)
+	}
+	return permission.UpdateUserGroupInfo(group.GroupID, name, group.Description, logger)
+}
+
+func memberUIDs(token *models.SCIMToken, members []*MultiValue) ([]string, error) {
+	uids := sets.New[string]()
+	for _, member := range members {
+		uids.Insert(member.Value)
+	}
+	list := sets.List(uids)
+	if err := checkUsersExist(token, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// checkUsersExist makes sure every member is a user provisioned for the identity type of the token,
+// SCIM clients can not add local users or users of other identity providers to a group
+func checkUsersExist(token *models.SCIMToken, uids []string) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	users, err := orm.ListUsersByUIDs(uids, nil, repository.DB)
+	if err != nil {
+		return err
+	}
+	found := sets.New[string]()
+	for _, user := range users {
+		if user.IdentityType == token.IdentityType {
+			found.Insert(user.UID)
+		}
+	}
+	for _, uid := range uids {
+		if !found.Has(uid) {
+			return errInvalidValue("member %s does not exist", uid)
+		}
+	}
+	return nil
+}
+
+func validateGroup(args *Group) error {
+	if strings.TrimSpace(args.DisplayName) == "" {
+		return errInvalidValue("displayName is required")
+	}
+	if len(args.DisplayName) > maxGroupNameLength {
+		return errInvalidValue("displayName can not be longer than %d characters", maxGroupNameLength)
+	}
+	return nil
+}
+
+func toSCIMGroup(group *models.UserGroup, members []string) *Group {
+	resp := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          group.GroupID,
+		DisplayName: group.GroupName,
+		Members:     make([]*MultiValue, 0, len(members)),
+		Meta:        newMeta("Group", "Groups", group.GroupID, group.CreatedAt, group.UpdatedAt),
+	}
+	for _, uid := range members {
+		resp.Members = append(resp.Members, &MultiValue{
+			Value: uid,
+			Ref:   newMeta("User", "Users", uid, 0, 0).Location,
+		})
+	}
+	return resp
+}
+
+func groupAttributes(g *Group) Attributes {
+	attrs := Attributes{
+		"id":                {g.ID},
+		"externalid":        {g.ExternalID},
+		"displayname":       {g.DisplayName},
+		"meta.created":      {g.Meta.Created},
+		"meta.lastmodified": {g.Meta.LastModified},
+	}
+	for _, member := range g.Members {
+		attrs["members"] = append(attrs["members"], member.Value)
+		attrs["members.value"] = append(attrs["members.value"], member.Value)
+	}
+	return attrs
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+// patchPath is a parsed PATCH path like `emails[type eq "work"].value`
+type patchPath struct {
+	attr      string
+	filter    Filter
+	subAttr   string
+	rawFilter string
+}
+
+func parsePatchPath(path string) (*patchPath, error) {
+	p := &patchPath{}
+	path = strings.TrimSpace(path)
+	if i := strings.Index(path, "["); i >= 0 {
+		j := strings.LastIndex(path, "]")
+		if j < i {
+			return nil, newError(http.StatusBadRequest, "invalidPath", "invalid path %q", path)
+		}
+		p.rawFilter = path[i+1 : j]
+		filter, err := ParseFilter(p.rawFilter)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, "invalidPath", "invalid path %q: %s", path, err)
+		}
+		p.filter = filter
+		p.subAttr = strings.TrimPrefix(path[j+1:], ".")
+		path = path[:i]
+	}
+
+	p.attr = normalizeAttributePath(path)
+	if p.filter == nil {
+		if i := strings.Index(p.attr, "."); i >= 0 {
+			p.attr, p.subAttr = p.attr[:i], p.attr[i+1:]
+		}
+	}
+	p.subAttr = strings.ToLower(p.subAttr)
+	return p, nil
+}
+
+func normalizeOp(op string) (string, error) {
+	switch strings.ToLower(op) {
+	case patchOpAdd:
+		return patchOpAdd, nil
+	case patchOpReplace:
+		return patchOpReplace, nil
+	case patchOpRemove:
+		return patchOpRemove, nil
+	}
+	return "", errInvalidValue("unsupported patch operation %q", op)
+}
+
+func applyUserPatch(u *User, op *PatchOperation) error {
+	opName, err := normalizeOp(op.Op)
+	if err != nil {
+		return err
+	}
+
+	// without a path the value holds the attributes to set
+	if op.Path == "" {
+		if opName == patchOpRemove {
+			return newError(http.StatusBadRequest, "noTarget", "path is required for remove operations")
+		}
+		values, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return errInvalidValue("value must be an object when no path is given")
+		}
+		for key, value := range values {
+			if err := applyUserPatch(u, &PatchOperation{Op: opName, Path: key, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parsePatchPath(op.Path)
+	if err != nil {
+		return err
+	}
+	remove := opName == patchOpRemove
+
+	switch path.attr {
+	case "username":
+		if remove {
+			return errInvalidValue("userName can not be removed")
+		}
+		u.UserName, err = stringValue(op.Value)
+	case "displayname":
+		u.DisplayName, err = optionalString(remove, op.Value)
+	case "externalid":
+		u.ExternalID, err = optionalString(remove, op.Value)
+	case "active":
+		if remove {
+			return errInvalidValue("active can not be removed")
+		}
+		var active bool
+		active, err = boolValue(op.Value)
+		u.Active = &active
+	case "name":
+		err = patchUserName(u, path.subAttr, remove, op.Value)
+	case "emails":
+		u.Emails, err = patchMultiValue(u.Emails, path, remove, op.Value)
+	case "phonenumbers":
+		u.PhoneNumbers, err = patchMultiValue(u.PhoneNumbers, path, remove, op.Value)
+	case "groups":
+		return newError(http.StatusBadRequest, "mutability", "groups of a user are managed through the Groups resource")
+	default:
+		// unknown attributes, e.g. enterprise extension attributes, are ignored
+	}
+	return err
+}
+
+func patchUserName(u *User, subAttr string, remove bool, value interface{}) error {
+	if u.Name == nil {
+		u.Name = &Name{}
+	}
+	// the formatted name is kept in displayName, so it has to follow
+	u.DisplayName = ""
+	if subAttr == "" {
+		if remove {
+			u.Name = &Name{}
+			return nil
+		}
+		name := &Name{}
+		if err := convertValue(value, name); err != nil {
+			return err
+		}
+		u.Name = name
+		return nil
+	}
+
+	s, err := optionalString(remove, value)
+	if err != nil {
+		return err
+	}
+	switch subAttr {
+	case "formatted":
+		u.Name.Formatted = s
+	case "givenname":
+		u.Name.GivenName = s
+		u.Name.Formatted = ""
+	case "familyname":
+		u.Name.FamilyName = s
+		u.Name.Formatted = ""
+	}
+	return nil
+}
+
+// patchMultiValue patches a multi valued attribute of a user. Zadig keeps a single value, so the
+// value targeted by a path filter is the primary value.
+func patchMultiValue(values []*MultiValue, path *patchPath, remove bool, value interface{}) ([]*MultiValue, error) {
+	if remove {
+		if path.filter == nil {
+			return nil, nil
+		}
+		resp := make([]*MultiValue, 0)
+		for _, v := range values {
+			if !path.filter.Match(multiValueAttributes(v)) {
+				resp = append(resp, v)
+			}
+		}
+		return resp, nil
+	}
+
+	if path.subAttr != "" {
+		s, err := stringValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if path.subAttr != "value" {
+			return values, nil
+		}
+		return []*MultiValue{{Value: s, Primary: true}}, nil
+	}
+
+	newValues, err := multiValues(value)
+	if err != nil {
+		return nil, err
+	}
+	return newValues, nil
+}
+
+func multiValueAttributes(v *MultiValue) Attributes {
+	return Attributes{
+		"value":   {v.Value},
+		"type":    {v.Type},
+		"display": {v.Display},
+		"primary": {strconv.FormatBool(v.Primary)},
+	}
+}
+
+// multiValues accepts a single object or a list of objects
+func multiValues(value interface{}) ([]*MultiValue, error) {
+	resp := make([]*MultiValue, 0)
+	if _, ok := value.([]interface{}); ok {
+		if err := convertValue(value, &resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	v := &MultiValue{}
+	if err := convertValue(value, v); err != nil {
+		return nil, err
+	}
+	return append(resp, v), nil
+}
+
+func convertValue(value interface{}, out interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errInvalidValue("invalid value: %s", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return errInvalidValue("invalid value: %s", err)
+	}
+	return nil
+}
+
+func stringValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []interface{}:
+		// some identity providers wrap single values in a list
+		if len(v) == 1 {
+			return stringValue(v[0])
+		}
+	case map[string]interface{}:
+		if inner, ok := v["value"]; ok {
+			return stringValue(inner)
+		}
+	}
+	return "", errInvalidValue("expected a string value, got %v", value)
+}
+
+func optionalString(remove bool, value interface{}) (string, error) {
+	if remove || value == nil {
+		return "", nil
+	}
+	return stringValue(value)
+}
+
+// boolValue also accepts "True" and "False" strings which some identity providers send
+func boolValue(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return false, errInvalidValue("expected a boolean value, got %q", v)
+		}
+		return b, nil
+	}
+	return false, errInvalidValue("expected a boolean value, got %v", value)
+}
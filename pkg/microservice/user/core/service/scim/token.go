@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/orm"
+	e "github.com/koderover/zadig/v2/pkg/tool/errors"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+const (
+	tokenPrefix            = "zscim_"
+	tokenLastUsedInterval  = 60
+	tokenRandomBytesLength = 32
+)
+
+type CreateTokenArgs struct {
+	Name string `json:"name"`
+	// IdentityType is the identity type of the provisioned users, it should be the id of the
+	// connector the users log in with so the first login finds the provisioned user.
+	IdentityType string `json:"identity_type"`
+}
+
+type Token struct {
+	*models.SCIMToken
+	// Token is only returned when the token is created
+	Token string `json:"token,omitempty"`
+}
+
+func CreateToken(args *CreateTokenArgs, logger *zap.SugaredLogger) (*Token, error) {
+	if args.Name == "" || args.IdentityType == "" {
+		return nil, e.ErrInvalidParam.AddDesc("name and identity_type are required")
+	}
+
+	buf := make([]byte, tokenRandomBytesLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := tokenPrefix + hex.EncodeToString(buf)
+
+	record := &models.SCIMToken{
+		Name:         args.Name,
+		TokenHash:    hashToken(token),
+		IdentityType: args.IdentityType,
+	}
+	if err := orm.CreateSCIMToken(record, repository.DB); err != nil {
+		logger.Errorf("failed to create scim token %s, error: %s", args.Name, err)
+		return nil, err
+	}
+	return &Token{SCIMToken: record, Token: token}, nil
+}
+
+func ListTokens(logger *zap.SugaredLogger) ([]*models.SCIMToken, error) {
+	tokens, err := orm.ListSCIMTokens(repository.DB)
+	if err != nil {
+		logger.Errorf("failed to list scim tokens, error: %s", err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func DeleteToken(id uint, logger *zap.SugaredLogger) error {
+	if err := orm.DeleteSCIMToken(id, repository.DB); err != nil {
+		logger.Errorf("failed to delete scim token %d, error: %s", id, err)
+		return err
+	}
+	return nil
+}
+
+// Authenticate returns the token record of the given bearer token
+func Authenticate(token string) (*models.SCIMToken, error) {
+	if token == "" {
+		return nil, newError(http.StatusUnauthorized, "", "missing bearer token")
+	}
+
+	record, err := orm.GetSCIMTokenByHash(hashToken(token), repository.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scim token: %s", err)
+	}
+	if record == nil {
+		return nil, newError(http.StatusUnauthorized, "", "invalid bearer token")
+	}
+
+	now := time.Now().Unix()
+	if now-record.LastUsedAt > tokenLastUsedInterval {
+		if err := orm.UpdateSCIMTokenLastUsed(record.ID, now, repository.DB); err != nil {
+			log.Warnf("failed to update last used time of scim token %d, error: %s", record.ID, err)
+		}
+	}
+	return record, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
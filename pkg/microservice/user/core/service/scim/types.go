@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	// ContentType is the media type of all SCIM responses
+	ContentType = "application/scim+json"
+
+	// BasePath is where the SCIM endpoints are served
+	BasePath = "/api/v1/scim/v2"
+
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Error is a SCIM error response, see RFC 7644 section 3.12
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+
+	code int
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// StatusCode returns the http status code of the error
+func (e *Error) StatusCode() int {
+	return e.code
+}
+
+func newError(code int, scimType, format string, args ...interface{}) *Error {
+	return &Error{
+		Schemas:  []string{SchemaError},
+		Status:   fmt.Sprintf("%d", code),
+		ScimType: scimType,
+		Detail:   fmt.Sprintf(format, args...),
+		code:     code,
+	}
+}
+
+func errNotFound(resource, id string) *Error {
+	return newError(http.StatusNotFound, "", "%s %s not found", resource, id)
+}
+
+func errInvalidValue(format string, args ...interface{}) *Error {
+	return newError(http.StatusBadRequest, "invalidValue", format, args...)
+}
+
+func errInvalidFilter(err error) *Error {
+	return newError(http.StatusBadRequest, "invalidFilter", "%s", err)
+}
+
+func errUniqueness(format string, args ...interface{}) *Error {
+	return newError(http.StatusConflict, "uniqueness", format, args...)
+}
+
+// ErrBadRequest wraps a malformed request error
+func ErrBadRequest(err error) *Error {
+	return newError(http.StatusBadRequest, "invalidSyntax", "%s", err)
+}
+
+// ToError converts any error into a SCIM error response
+func ToError(err error) *Error {
+	if scimErr, ok := err.(*Error); ok {
+		return scimErr
+	}
+	return newError(http.StatusInternalServerError, "", "%s", err)
+}
+
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// ListOptions are the query parameters of a list request
+type ListOptions struct {
+	Filter     string `form:"filter"`
+	StartIndex int    `form:"startIndex"`
+	Count      int    `form:"count"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type MultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type User struct {
+	Schemas      []string      `json:"schemas"`
+	ID           string        `json:"id,omitempty"`
+	ExternalID   string        `json:"externalId,omitempty"`
+	UserName     string        `json:"userName"`
+	Name         *Name         `json:"name,omitempty"`
+	DisplayName  string        `json:"displayName,omitempty"`
+	Active       *bool         `json:"active,omitempty"`
+	Emails       []*MultiValue `json:"emails,omitempty"`
+	PhoneNumbers []*MultiValue `json:"phoneNumbers,omitempty"`
+	Groups       []*MultiValue `json:"groups,omitempty"`
+	Meta         *Meta         `json:"meta,omitempty"`
+}
+
+type Group struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	ExternalID  string        `json:"externalId,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []*MultiValue `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func newListResponse(resources []interface{}, total int, opts *ListOptions) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   opts.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// page normalizes the options and returns the slice bounds of the requested page
+func (opts *ListOptions) page(total int) (int, int) {
+	if opts.StartIndex < 1 {
+		opts.StartIndex = 1
+	}
+	if opts.Count <= 0 {
+		opts.Count = defaultPageSize
+	}
+	if opts.Count > maxPageSize {
+		opts.Count = maxPageSize
+	}
+
+	start := opts.StartIndex - 1
+	if start > total {
+		start = total
+	}
+	end := start + opts.Count
+	if end > total {
+		end = total
+	}
+	return start, end
+}
@@ -0,0 +1,364 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scim
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/koderover/zadig/v2/pkg/config"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/models"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/repository/orm"
+	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/permission"
+)
+
+const (
+	// maxAccountLength and maxNameLength follow the column sizes of the user table
+	maxAccountLength = 32
+	maxNameLength    = 32
+)
+
+func ListUsers(token *models.SCIMToken, opts *ListOptions, logger *zap.SugaredLogger) (*ListResponse, error) {
+	var filter Filter
+	if opts.Filter != "" {
+		f, err := ParseFilter(opts.Filter)
+		if err != nil {
+			return nil, errInvalidFilter(err)
+		}
+		filter = f
+	}
+
+	users, err := orm.ListUsersByIdentityType(token.IdentityType, repository.DB)
+	if err != nil {
+		logger.Errorf("failed to list users of identity type %s, error: %s", token.IdentityType, err)
+		return nil, err
+	}
+	groupsByUID, err := listGroupsByUID()
+	if err != nil {
+		logger.Errorf("failed to list user groups, error: %s", err)
+		return nil, err
+	}
+
+	matched := make([]*User, 0)
+	for i := range users {
+		resource := toSCIMUser(&users[i], groupsByUID[users[i].UID])
+		if filter != nil && !filter.Match(userAttributes(resource)) {
+			continue
+		}
+		matched = append(matched, resource)
+	}
+
+	start, end := opts.page(len(matched))
+	resources := make([]interface{}, 0, end-start)
+	for _, resource := range matched[start:end] {
+		resources = append(resources, resource)
+	}
+	return newListResponse(resources, len(matched), opts), nil
+}
+
+func GetUser(token *models.SCIMToken, id string) (*User, error) {
+	user, err := getManagedUser(token, id)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := orm.ListUserGroupByUID(user.UID, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	return toSCIMUser(user, scimGroupRefs(groups)), nil
+}
+
+func CreateUser(token *models.SCIMToken, args *User, logger *zap.SugaredLogger) (*User, error) {
+	if err := validateUser(args); err != nil {
+		return nil, err
+	}
+
+	existing, err := orm.GetUser(args.UserName, token.IdentityType, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errUniqueness("user %s already exists", args.UserName)
+	}
+
+	user := &models.User{
+		UID:          uuid.NewString(),
+		Account:      args.UserName,
+		IdentityType: token.IdentityType,
+		ExternalID:   args.ExternalID,
+		Name:         userDisplayName(args),
+		Email:        primaryValue(args.Emails),
+		Phone:        primaryValue(args.PhoneNumbers),
+		Disabled:     args.Active != nil && !*args.Active,
+	}
+	if err := orm.CreateUser(user, repository.DB); err != nil {
+		logger.Errorf("failed to create scim user %s, error: %s", args.UserName, err)
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return nil, errUniqueness("user %s already exists", args.UserName)
+		}
+		return nil, err
+	}
+	return toSCIMUser(user, nil), nil
+}
+
+func ReplaceUser(token *models.SCIMToken, id string, args *User, logger *zap.SugaredLogger) (*User, error) {
+	user, err := getManagedUser(token, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateUser(args); err != nil {
+		return nil, err
+	}
+	if err := saveUser(user, args, logger); err != nil {
+		return nil, err
+	}
+	return GetUser(token, id)
+}
+
+func PatchUser(token *models.SCIMToken, id string, args *PatchRequest, logger *zap.SugaredLogger) (*User, error) {
+	user, err := getManagedUser(token, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := toSCIMUser(user, nil)
+	for _, op := range args.Operations {
+		if err := applyUserPatch(resource, op); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateUser(resource); err != nil {
+		return nil, err
+	}
+	if err := saveUser(user, resource, logger); err != nil {
+		return nil, err
+	}
+	return GetUser(token, id)
+}
+
+func DeleteUser(token *models.SCIMToken, id string, logger *zap.SugaredLogger) error {
+	user, err := getManagedUser(token, id)
+	if err != nil {
+		return err
+	}
+	return permission.DeleteUserByUID(user.UID, logger)
+}
+
+// getManagedUser only returns users of the identity type of the token, other users can not be
+// seen or changed by the identity provider.
+func getManagedUser(token *models.SCIMToken, id string) (*models.User, error) {
+	user, err := orm.GetUserByUid(id, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IdentityType != token.IdentityType {
+		return nil, errNotFound("User", id)
+	}
+	return user, nil
+}
+
+func saveUser(user *models.User, args *User, logger *zap.SugaredLogger) error {
+	if args.UserName != user.Account {
+		existing, err := orm.GetUser(args.UserName, user.IdentityType, repository.DB)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return errUniqueness("user %s already exists", args.UserName)
+		}
+	}
+
+	values := map[string]interface{}{
+		"account":     args.UserName,
+		"name":        userDisplayName(args),
+		"email":       primaryValue(args.Emails),
+		"phone":       primaryValue(args.PhoneNumbers),
+		"external_id": args.ExternalID,
+	}
+	if err := orm.UpdateUserValues(user.UID, values, repository.DB); err != nil {
+		logger.Errorf("failed to update scim user %s, error: %s", user.UID, err)
+		return err
+	}
+
+	disabled := args.Active != nil && !*args.Active
+	if disabled != user.Disabled {
+		return permission.SetUserDisabled(user.UID, disabled, logger)
+	}
+	return nil
+}
+
+func validateUser(args *User) error {
+	if args.UserName == "" {
+		return errInvalidValue("userName is required")
+	}
+	if len(args.UserName) > maxAccountLength {
+		return errInvalidValue("userName can not be longer than %d characters", maxAccountLength)
+	}
+	if len(userDisplayName(args)) > maxNameLength {
+		return errInvalidValue("name can not be longer than %d characters", maxNameLength)
+	}
+	return nil
+}
+
+func userDisplayName(u *User) string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if name := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); name != "" {
+			return name
+		}
+	}
+	return u.UserName
+}
+
+func primaryValue(values []*MultiValue) string {
+	for _, v := range values {
+		if v.Primary {
+			return v.Value
+		}
+	}
+	if len(values) > 0 {
+		return values[0].Value
+	}
+	return ""
+}
+
+func toSCIMUser(user *models.User, groups []*MultiValue) *User {
+	active := !user.Disabled
+	resp := &User{
+		Schemas:     []string{SchemaUser},
+		ID:          user.UID,
+		ExternalID:  user.ExternalID,
+		UserName:    user.Account,
+		Name:        &Name{Formatted: user.Name},
+		DisplayName: user.Name,
+		Active:      &active,
+		Groups:      groups,
+		Meta:        newMeta("User", "Users", user.UID, user.CreatedAt, user.UpdatedAt),
+	}
+	if user.Email != "" {
+		resp.Emails = []*MultiValue{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	if user.Phone != "" {
+		resp.PhoneNumbers = []*MultiValue{{Value: user.Phone, Type: "work", Primary: true}}
+	}
+	return resp
+}
+
+func userAttributes(u *User) Attributes {
+	attrs := Attributes{
+		"id":                {u.ID},
+		"externalid":        {u.ExternalID},
+		"username":          {u.UserName},
+		"displayname":       {u.DisplayName},
+		"name.formatted":    {u.DisplayName},
+		"active":            {strconv.FormatBool(u.Active == nil || *u.Active)},
+		"meta.created":      {u.Meta.Created},
+		"meta.lastmodified": {u.Meta.LastModified},
+	}
+	for _, email := range u.Emails {
+		attrs["emails"] = append(attrs["emails"], email.Value)
+		attrs["emails.value"] = append(attrs["emails.value"], email.Value)
+		attrs["emails.type"] = append(attrs["emails.type"], email.Type)
+		attrs["emails.primary"] = append(attrs["emails.primary"], strconv.FormatBool(email.Primary))
+	}
+	for _, phone := range u.PhoneNumbers {
+		attrs["phonenumbers"] = append(attrs["phonenumbers"], phone.Value)
+		attrs["phonenumbers.value"] = append(attrs["phonenumbers.value"], phone.Value)
+		attrs["phonenumbers.type"] = append(attrs["phonenumbers.type"], phone.Type)
+	}
+	for _, group := range u.Groups {
+		attrs["groups"] = append(attrs["groups"], group.Value)
+		attrs["groups.value"] = append(attrs["groups.value"], group.Value)
+		attrs["groups.display"] = append(attrs["groups.display"], group.Display)
+	}
+	return attrs
+}
+
+func newMeta(resourceType, endpoint, id string, created, updated int64) *Meta {
+	meta := &Meta{
+		ResourceType: resourceType,
+		Location:     fmt.Sprintf("%s%s/%s/%s", strings.TrimSuffix(config.SystemAddress(), "/"), BasePath, endpoint, id),
+	}
+	if created > 0 {
+		meta.Created = time.Unix(created, 0).UTC().Format(time.RFC3339)
+	}
+	if updated > 0 {
+		meta.LastModified = time.Unix(updated, 0).UTC().Format(time.RFC3339)
+	}
+	return meta
+}
+
+// listGroupsByUID returns the group references of every user, the built-in group of all users is skipped
+func listGroupsByUID() (map[string][]*MultiValue, error) {
+	groups, err := orm.ListAllUserGroups(repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	groupByID := make(map[string]*models.UserGroup)
+	groupIDs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if group.IsAllUserGroup() {
+			continue
+		}
+		groupByID[group.GroupID] = group
+		groupIDs = append(groupIDs, group.GroupID)
+	}
+
+	bindings, err := orm.ListGroupBindingsByGroupIDs(groupIDs, repository.DB)
+	if err != nil {
+		return nil, err
+	}
+	resp := make(map[string][]*MultiValue)
+	for _, binding := range bindings {
+		group := groupByID[binding.GroupID]
+		resp[binding.UID] = append(resp[binding.UID], groupRef(group))
+	}
+	return resp, nil
+}
+
+func scimGroupRefs(groups []*models.UserGroup) []*MultiValue {
+	resp := make([]*MultiValue, 0, len(groups))
+	for _, group := range groups {
+		if group.IsAllUserGroup() {
+			continue
+		}
+		resp = append(resp, groupRef(group))
+	}
+	return resp
+}
+
+func groupRef(group *models.UserGroup) *MultiValue {
+	return &MultiValue{
+		Value:   group.GroupID,
+		Display: group.GroupName,
+		Ref:     newMeta("Group", "Groups", group.GroupID, 0, 0).Location,
+	}
+}
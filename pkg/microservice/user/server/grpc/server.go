@@ -36,6 +36,7 @@ import (
 	"github.com/koderover/zadig/v2/pkg/microservice/user/config"
 	loginsvc "github.com/koderover/zadig/v2/pkg/microservice/user/core/service/login"
 	"github.com/koderover/zadig/v2/pkg/microservice/user/core/service/permission"
+	"github.com/koderover/zadig/v2/pkg/setting"
 	"github.com/koderover/zadig/v2/pkg/tool/cache"
 	"github.com/koderover/zadig/v2/pkg/tool/log"
 )
@@ -130,7 +131,8 @@ func (s *AuthServer) Check(ctx context.Context, request *ext_authz_v3.CheckReque
 			}
 
 			// if the expiration time is so huge that it is not possible, it is a constant api token, we don't check for the redis.
-			if claims.ExpiresAt-time.Now().Unix() < 8760*60*60 {
+			// personal access tokens are checked against their own record instead.
+			if claims.TokenType != setting.PersonalAccessTokenType && claims.ExpiresAt-time.Now().Unix() < 8760*60*60 {
 				// check if the given token is removed from the cache
 				token, err := cache.NewRedisCache(config.RedisUserTokenDB()).GetString(claims.UID)
 				if err != nil {
@@ -65,6 +65,9 @@ const (
 
 	ENVBuildBaseImage = "BUILD_BASE_IMAGE"
 
+	// ENVWebhookDeliveryRetentionDays is the number of days webhook deliveries are kept, 30 by default
+	ENVWebhookDeliveryRetentionDays = "WEBHOOK_DELIVERY_RETENTION_DAYS"
+
 	ENVS3StorageAK       = "S3STORAGE_AK"
 	ENVS3StorageSK       = "S3STORAGE_SK"
 	ENVS3StorageEndpoint = "S3STORAGE_ENDPOINT"
@@ -264,6 +267,10 @@ const (
 	SourceFromGitee = "gitee"
 	// SourceFromGiteeEE Configure the source as gitee-enterprise
 	SourceFromGiteeEE = "gitee-enterprise"
+	// SourceFromGitea Configure the source as gitea, forgejo is api compatible and shares this type
+	SourceFromGitea = "gitea"
+	// SourceFromBitbucket Configure the source as bitbucket, both cloud and data center share this type
+	SourceFromBitbucket = "bitbucket"
 	// SourceFromOther Configure the source as other
 	SourceFromOther = "other"
 	// SourceFromChartTemplate The configuration source is helmTemplate
@@ -273,6 +280,8 @@ const (
 	SourceFromChartRepo   = "chartRepo"
 	SourceFromCustomEdit  = "customEdit"
 	SourceFromVariableSet = "variableSet"
+	// SourceFromKustomize The configuration source is a kustomization in git repository
+	SourceFromKustomize = "kustomize"
 
 	// SourceFromGUI The configuration source is gui
 	SourceFromGUI = "gui"
@@ -320,6 +329,11 @@ const (
 	BuildOSSCacheFileName    = "zadig-build-cache.tar.gz"
 	ScanningOSSCacheFileName = "zadig-scanning-cache.tar.gz"
 	TestingOSSCacheFileName  = "zadig-testing-cache.tar.gz"
+
+	// SBOMOutputDir is the dir in the workspace where the generated sboms are saved
+	SBOMOutputDir = "zadig-sbom"
+	// ImageVulnScanOutputDir is the dir in the workspace where the vulnerability reports are saved
+	ImageVulnScanOutputDir = "zadig-vuln-scan"
 )
 
 type DeliveryVersionType string
@@ -336,6 +350,9 @@ const (
 
 const (
 	AuthorizationHeader = "Authorization"
+
+	// PersonalAccessTokenType is the token_type claim of a scoped personal access token
+	PersonalAccessTokenType = "personal_access_token"
 )
 
 // install script constants
@@ -570,12 +587,13 @@ const (
 	UnixStampSchedule   = "unix_stamp"
 
 	// 定时器的所属job类型
-	WorkflowCronjob    = "workflow"
-	WorkflowV4Cronjob  = "workflow_v4"
-	TestingCronjob     = "test"
-	EnvAnalysisCronjob = "env_analysis"
-	EnvSleepCronjob    = "env_sleep"
-	ReleasePlanCronjob = "release_plan"
+	WorkflowCronjob        = "workflow"
+	WorkflowV4Cronjob      = "workflow_v4"
+	TestingCronjob         = "test"
+	EnvAnalysisCronjob     = "env_analysis"
+	EnvHealthReportCronjob = "env_health_report"
+	EnvSleepCronjob        = "env_sleep"
+	ReleasePlanCronjob     = "release_plan"
 
 	TopicProcess      = "task.process"
 	TopicCancel       = "task.cancel"
@@ -824,6 +842,7 @@ const (
 	IMLarkIntl = "lark_intl"
 	IMDingTalk = "dingtalk"
 	IMWorkWx   = "workwx"
+	IMSlack    = "slack"
 )
 
 // lark app
@@ -886,6 +905,17 @@ const (
 
 	AgentDefaultHeartbeatTimeout = 10
 
+	// VMLabelSeparator separates the labels of a vm that carries more than one label
+	VMLabelSeparator = ","
+
+	// vm job scheduling, resource usage reported by a heartbeat older than VMSchedulingHeartbeatMaxAge
+	// seconds is ignored, a job waiting longer than VMJobStarvationSeconds is scheduled before any other
+	VMSchedulingMaxCPUUsage     = 90
+	VMSchedulingMaxMemoryUsage  = 90
+	VMSchedulingMinFreeDisk     = 1 << 30
+	VMSchedulingHeartbeatMaxAge = 60
+	VMJobStarvationSeconds      = 600
+
 	// vm job status
 	VMJobStatusCreated     = "created"
 	VMJobStatusDistributed = "distributed"
@@ -926,9 +956,28 @@ const (
 type SQLExecStatus string
 
 const (
-	SQLExecStatusSuccess SQLExecStatus = "success"
-	SQLExecStatusFailed  SQLExecStatus = "failed"
-	SQLExecStatusNotExec SQLExecStatus = "not_exec"
+	SQLExecStatusSuccess   SQLExecStatus = "success"
+	SQLExecStatusFailed    SQLExecStatus = "failed"
+	SQLExecStatusNotExec   SQLExecStatus = "not_exec"
+	SQLExecStatusRollback  SQLExecStatus = "rollback"
+	SQLExecStatusExplained SQLExecStatus = "explained"
+)
+
+type SchemaMigrationStatus string
+
+const (
+	// SchemaMigrationStatusApplied the migration has been applied before the job runs
+	SchemaMigrationStatusApplied SchemaMigrationStatus = "applied"
+	// SchemaMigrationStatusPending the migration has not been applied yet
+	SchemaMigrationStatusPending SchemaMigrationStatus = "pending"
+	// SchemaMigrationStatusDrifted the migration file has been changed after it was applied
+	SchemaMigrationStatusDrifted SchemaMigrationStatus = "drifted"
+	// SchemaMigrationStatusMissing the migration has been applied but its file no longer exists
+	SchemaMigrationStatusMissing SchemaMigrationStatus = "missing"
+	SchemaMigrationStatusSuccess SchemaMigrationStatus = "success"
+	SchemaMigrationStatusFailed  SchemaMigrationStatus = "failed"
+	// SchemaMigrationStatusReverted the migration has been rolled back by its down script
+	SchemaMigrationStatusReverted SchemaMigrationStatus = "reverted"
 )
 
 type ProjectApplicationType string
@@ -955,6 +1004,7 @@ const (
 	NotifyWebHookTypeMSTeam       NotifyWebHookType = "msteams"
 	NotifyWebHookTypeMail         NotifyWebHookType = "mail"
 	NotifyWebHookTypeWebook       NotifyWebHookType = "webhook"
+	NotifyWebHookTypeSlack        NotifyWebHookType = "slack"
 )
 
 const (
@@ -1002,6 +1052,8 @@ const (
 	DeliveryVersionSourceManual      DeliveryVersionSource = "manual"
 	DeliveryVersionSourceFromEnv     DeliveryVersionSource = "from_env"
 	DeliveryVersionSourceFromVersion DeliveryVersionSource = "from_version"
+	// DeliveryVersionSourceBundle means the version is imported from an offline bundle exported by another zadig
+	DeliveryVersionSourceBundle DeliveryVersionSource = "bundle"
 )
 
 type ListUserOrderBy string
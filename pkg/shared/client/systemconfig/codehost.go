@@ -26,12 +26,14 @@ import (
 )
 
 const (
-	GitLabProvider  = "gitlab"
-	GitHubProvider  = "github"
-	GerritProvider  = "gerrit"
-	GiteeProvider   = "gitee"
-	GiteeEEProvider = "gitee-enterprise"
-	OtherProvider   = "other"
+	GitLabProvider    = "gitlab"
+	GitHubProvider    = "github"
+	GerritProvider    = "gerrit"
+	GiteeProvider     = "gitee"
+	GiteeEEProvider   = "gitee-enterprise"
+	GiteaProvider     = "gitea"
+	BitbucketProvider = "bitbucket"
+	OtherProvider     = "other"
 )
 
 type CodeHost struct {
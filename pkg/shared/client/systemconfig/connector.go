@@ -28,19 +28,17 @@ type Connector struct {
 	Config interface{} `json:"config"`
 }
 
-func (c *Client) GetLDAPConnector(id string) (*Connector, error) {
+func (c *Client) GetConnector(id string) (*Connector, error) {
 	resp, err := connectorservice.GetConnector(id, log.SugaredLogger())
 	if err != nil {
 		return nil, err
 	}
-	res := &Connector{
+	return &Connector{
 		Type:   string(resp.Type),
 		ID:     resp.ID,
 		Name:   resp.Name,
 		Config: resp.Config,
-	}
-
-	return res, nil
+	}, nil
 }
 
 func (c *Client) ListConnectorsInternal() ([]*Connector, error) {
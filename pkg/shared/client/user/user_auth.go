@@ -268,6 +268,18 @@ func (c *Client) GetUserAuthInfo(uid string) (*AuthorizedResources, error) {
 	return resp, err
 }
 
+// GetUserAuthInfoByToken returns the auth info of the user limited to the scope of the given personal access token
+func (c *Client) GetUserAuthInfoByToken(uid, tokenID string) (*AuthorizedResources, error) {
+	url := "/authorization/auth-info"
+	resp := &AuthorizedResources{}
+	queries := make(map[string]string)
+	queries["uid"] = uid
+	queries["token_id"] = tokenID
+
+	_, err := c.Get(url, httpclient.SetQueryParams(queries), httpclient.SetResult(resp))
+	return resp, err
+}
+
 func (c *Client) CheckUserAuthInfoForCollaborationMode(uid, projectKey, resource, resourceName, action string) (bool, error) {
 	url := "/authorization/collaboration-permission"
 	resp := &types.CheckCollaborationModePermissionResp{}
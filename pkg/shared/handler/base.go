@@ -54,7 +54,9 @@ type Context struct {
 	IdentityType string
 	RequestID    string
 	Resources    *user.AuthorizedResources
-	LarkPlugin   *LarkPluginContext
+	// TokenID is set when the request is authenticated with a personal access token
+	TokenID    string
+	LarkPlugin *LarkPluginContext
 }
 
 type LarkPluginContext struct {
@@ -71,6 +73,7 @@ type jwtClaims struct {
 	Account         string          `json:"preferred_username"`
 	MFAVerified     bool            `json:"mfa_verified"`
 	FederatedClaims FederatedClaims `json:"federated_claims"`
+	TokenType       string          `json:"token_type,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -120,8 +123,14 @@ func NewContext(c *gin.Context) *Context {
 		}
 	}
 
+	var tokenID string
+	if claims.TokenType == setting.PersonalAccessTokenType {
+		tokenID = claims.Id
+	}
+
 	return &Context{
 		Context:      c.Request.Context(),
+		TokenID:      tokenID,
 		UserName:     claims.Name,
 		UserID:       claims.UID,
 		Account:      claims.Account,
@@ -139,7 +148,12 @@ func NewContextWithAuthorization(c *gin.Context) (*Context, error) {
 	var err error
 	resp := NewContext(c)
 	// there is a case where the request does not have token (system call), in this case we will have admin access
-	resourceAuthInfo, err = user.New().GetUserAuthInfo(resp.UserID)
+	// requests made with a personal access token are limited to the scope of the token
+	if resp.TokenID != "" {
+		resourceAuthInfo, err = user.New().GetUserAuthInfoByToken(resp.UserID, resp.TokenID)
+	} else {
+		resourceAuthInfo, err = user.New().GetUserAuthInfo(resp.UserID)
+	}
 	if err != nil {
 		logger.Errorf("failed to generate user authorization info, error: %s", err)
 		return resp, err
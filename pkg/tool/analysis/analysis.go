@@ -41,6 +41,8 @@ type Analysis struct {
 	MaxConcurrency     int
 	AnalysisAIProvider string // The name of the AI Provider used for this analysis
 	WithDoc            bool
+	// Rules are declarative analyzers run alongside the built-in ones, addressable by name in filters
+	Rules []Rule
 }
 
 type AnalysisStatus string
@@ -77,6 +79,11 @@ func NewAnalysis(ctx context.Context, clusterID string, llmClient llm.ILLM, filt
 		return nil, fmtErr
 	}
 
+	aiProvider := ""
+	if llmClient != nil {
+		aiProvider = llmClient.GetName()
+	}
+
 	client, err := NewClient(clusterID)
 	if err != nil {
 		log.Errorf("Error initialising kubernetes client: %v", err)
@@ -92,13 +99,17 @@ func NewAnalysis(ctx context.Context, clusterID string, llmClient llm.ILLM, filt
 		Cache:              cache.New(noCache, cache.CacheTypeRedis),
 		Explain:            explain,
 		MaxConcurrency:     maxConcurrency,
-		AnalysisAIProvider: llmClient.GetName(),
+		AnalysisAIProvider: aiProvider,
 		WithDoc:            withDoc,
 	}, nil
 }
 
 func (a *Analysis) RunAnalysis(activeFilters []string) {
 	coreAnalyzerMap, analyzerMap := GetAnalyzerMap()
+	for _, rule := range a.Rules {
+		coreAnalyzerMap[rule.Name] = RuleAnalyzer{Rule: rule}
+		analyzerMap[rule.Name] = RuleAnalyzer{Rule: rule}
+	}
 
 	// we get the openapi schema from the server only if required by the flag "with-doc"
 	openapiSchema := &openapi_v2.Document{}
@@ -17,14 +17,20 @@ package analysis
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	kubernetes "github.com/koderover/zadig/v2/pkg/shared/kube/wrapper"
 	cron "github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// CronJobFailureStreakThreshold is the number of consecutive failed runs from which a CronJob is reported
+const CronJobFailureStreakThreshold = 3
+
 type CronJobAnalyzer struct{}
 
 func (analyzer CronJobAnalyzer) Analyze(a Analyzer) ([]Result, error) {
@@ -48,6 +54,20 @@ func (analyzer CronJobAnalyzer) Analyze(a Analyzer) ([]Result, error) {
 		return nil, err
 	}
 
+	jobList, err := a.Client.GetClient().BatchV1().Jobs(a.Namespace).List(a.Context, v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ownedJobs := map[string][]batchv1.Job{}
+	for _, job := range jobList.Items {
+		for _, owner := range job.OwnerReferences {
+			if owner.Kind == kind {
+				key := fmt.Sprintf("%s/%s", job.Namespace, owner.Name)
+				ownedJobs[key] = append(ownedJobs[key], job)
+			}
+		}
+	}
+
 	var preAnalysis = map[string]PreAnalysis{}
 
 	for _, cronJob := range cronJobList.Items {
@@ -116,6 +136,25 @@ func (analyzer CronJobAnalyzer) Analyze(a Analyzer) ([]Result, error) {
 
 		}
 
+		if streak := CronJobFailureStreak(ownedJobs[fmt.Sprintf("%s/%s", cronJob.Namespace, cronJob.Name)]); streak >= CronJobFailureStreakThreshold {
+			doc := apiDoc.GetApiDocV2("spec.jobTemplate")
+
+			failures = append(failures, Failure{
+				Text:          fmt.Sprintf("CronJob %s has failed %d times in a row", cronJob.Name, streak),
+				KubernetesDoc: doc,
+				Sensitive: []Sensitive{
+					{
+						Unmasked: cronJob.Namespace,
+						Masked:   MaskString(cronJob.Namespace),
+					},
+					{
+						Unmasked: cronJob.Name,
+						Masked:   MaskString(cronJob.Name),
+					},
+				},
+			})
+		}
+
 		if len(failures) > 0 {
 			preAnalysis[fmt.Sprintf("%s/%s", cronJob.Namespace, cronJob.Name)] = PreAnalysis{
 				FailureDetails: failures,
@@ -123,15 +162,15 @@ func (analyzer CronJobAnalyzer) Analyze(a Analyzer) ([]Result, error) {
 			AnalyzerErrorsMetric.WithLabelValues(kind, cronJob.Name, cronJob.Namespace).Set(float64(len(failures)))
 
 		}
+	}
 
-		for key, value := range preAnalysis {
-			currentAnalysis := Result{
-				Kind:  kind,
-				Name:  key,
-				Error: value.FailureDetails,
-			}
-			a.Results = append(a.Results, currentAnalysis)
+	for key, value := range preAnalysis {
+		currentAnalysis := Result{
+			Kind:  kind,
+			Name:  key,
+			Error: value.FailureDetails,
 		}
+		a.Results = append(a.Results, currentAnalysis)
 	}
 
 	return a.Results, nil
@@ -146,3 +185,33 @@ func CheckCronScheduleIsValid(schedule string) (bool, error) {
 
 	return true, nil
 }
+
+// CronJobFailureStreak returns the number of consecutive failed runs among the given jobs,
+// counting back from the most recent finished one. Runs that are still active are ignored.
+func CronJobFailureStreak(jobs []batchv1.Job) int {
+	sorted := make([]batchv1.Job, len(jobs))
+	copy(sorted, jobs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+
+	streak := 0
+	for _, job := range sorted {
+		switch {
+		case jobHasCondition(job, batchv1.JobFailed):
+			streak++
+		case jobHasCondition(job, batchv1.JobComplete):
+			return streak
+		}
+	}
+	return streak
+}
+
+func jobHasCondition(job batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
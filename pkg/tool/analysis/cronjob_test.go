@@ -18,6 +18,7 @@ package analysis
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/magiconair/properties/assert"
 	batchv1 "k8s.io/api/batch/v1"
@@ -231,3 +232,59 @@ func TestCronJobBrokenMultipleNamespaceFiltering(t *testing.T) {
 	assert.Equal(t, analysisResults[0].Name, "default/example-cronjob")
 	assert.Equal(t, analysisResults[0].Kind, "CronJob")
 }
+
+func TestCronJobFailureStreak(t *testing.T) {
+	newJob := func(name string, minute int, condition batchv1.JobConditionType) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Date(2026, 1, 1, 0, minute, 0, 0, time.UTC)),
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "CronJob", Name: "example-cronjob"},
+				},
+			},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{
+					{Type: condition, Status: v1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	jobs := []batchv1.Job{
+		newJob("job-1", 1, batchv1.JobFailed),
+		newJob("job-2", 2, batchv1.JobComplete),
+		newJob("job-3", 3, batchv1.JobFailed),
+		newJob("job-4", 4, batchv1.JobFailed),
+		newJob("job-5", 5, batchv1.JobFailed),
+	}
+	assert.Equal(t, CronJobFailureStreak(jobs), 3)
+	assert.Equal(t, CronJobFailureStreak(jobs[:3]), 1)
+
+	clientset := fake.NewSimpleClientset(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-cronjob",
+			Namespace: "default",
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "*/1 * * * *",
+		},
+	}, &jobs[0], &jobs[1], &jobs[2], &jobs[3], &jobs[4])
+
+	config := Analyzer{
+		Client: &Client{
+			Client: clientset,
+		},
+		Context:   context.Background(),
+		Namespace: "default",
+	}
+
+	analysisResults, err := CronJobAnalyzer{}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Error[0].Text, "CronJob example-cronjob has failed 3 times in a row")
+}
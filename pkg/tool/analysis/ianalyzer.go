@@ -52,6 +52,9 @@ var additionalAnalyzerMap = map[string]IAnalyzer{
 	"HorizontalPodAutoScaler": HpaAnalyzer{},
 	"PodDisruptionBudget":     PdbAnalyzer{},
 	"NetworkPolicy":           NetworkPolicyAnalyzer{},
+	"VirtualService":          VirtualServiceAnalyzer{},
+	"DestinationRule":         DestinationRuleAnalyzer{},
+	"ResourceQuota":           ResourceQuotaAnalyzer{},
 }
 
 type IAnalyzer interface {
@@ -0,0 +1,297 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const istioMeshGateway = "mesh"
+
+type VirtualServiceAnalyzer struct{}
+
+func (VirtualServiceAnalyzer) Analyze(a Analyzer) ([]Result, error) {
+	kind := "VirtualService"
+
+	AnalyzerErrorsMetric.DeletePartialMatch(map[string]string{
+		"analyzer_name": kind,
+	})
+
+	if a.Client.IstioClient == nil {
+		return a.Results, nil
+	}
+
+	vsList, err := a.Client.IstioClient.NetworkingV1alpha3().VirtualServices(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		// istio is not installed in the cluster
+		if apierrors.IsNotFound(err) {
+			return a.Results, nil
+		}
+		return nil, err
+	}
+	if len(vsList.Items) == 0 {
+		return a.Results, nil
+	}
+
+	drList, err := a.Client.IstioClient.NetworkingV1alpha3().DestinationRules(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	// namespace/service -> subset names defined by destination rules
+	subsets := map[string]map[string]bool{}
+	if drList != nil {
+		for i := range drList.Items {
+			dr := drList.Items[i]
+			name, namespace, ok := resolveIstioHost(dr.Spec.Host, dr.Namespace)
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s", namespace, name)
+			if subsets[key] == nil {
+				subsets[key] = map[string]bool{}
+			}
+			for _, subset := range dr.Spec.Subsets {
+				subsets[key][subset.Name] = true
+			}
+		}
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for i := range vsList.Items {
+		vs := vsList.Items[i]
+		var failures []Failure
+
+		for _, gateway := range vs.Spec.Gateways {
+			if gateway == istioMeshGateway {
+				continue
+			}
+			gwNamespace, gwName := vs.Namespace, gateway
+			if parts := strings.SplitN(gateway, "/", 2); len(parts) == 2 {
+				gwNamespace, gwName = parts[0], parts[1]
+			}
+			_, err := a.Client.IstioClient.NetworkingV1alpha3().Gateways(gwNamespace).Get(a.Context, gwName, metav1.GetOptions{})
+			if err != nil && apierrors.IsNotFound(err) {
+				failures = append(failures, Failure{
+					Text: fmt.Sprintf("VirtualService %s uses gateway %s/%s which does not exist", vs.Name, gwNamespace, gwName),
+					Sensitive: []Sensitive{
+						{
+							Unmasked: gwName,
+							Masked:   MaskString(gwName),
+						},
+					},
+				})
+			}
+		}
+
+		checked := map[string]bool{}
+		for _, dest := range virtualServiceDestinations(vs) {
+			name, namespace, ok := resolveIstioHost(dest.host, vs.Namespace)
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s", namespace, name)
+			if !checked[key] {
+				checked[key] = true
+				_, err := a.Client.GetClient().CoreV1().Services(namespace).Get(a.Context, name, metav1.GetOptions{})
+				if err != nil && apierrors.IsNotFound(err) {
+					failures = append(failures, Failure{
+						Text: fmt.Sprintf("VirtualService %s routes to service %s which does not exist", vs.Name, key),
+						Sensitive: []Sensitive{
+							{
+								Unmasked: name,
+								Masked:   MaskString(name),
+							},
+						},
+					})
+					continue
+				}
+			}
+
+			if dest.subset != "" && !subsets[key][dest.subset] {
+				failures = append(failures, Failure{
+					Text: fmt.Sprintf("VirtualService %s routes to subset %s of %s which is not defined in any DestinationRule", vs.Name, dest.subset, key),
+					Sensitive: []Sensitive{
+						{
+							Unmasked: name,
+							Masked:   MaskString(name),
+						},
+					},
+				})
+			}
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", vs.Namespace, vs.Name)] = PreAnalysis{
+				FailureDetails: failures,
+			}
+			AnalyzerErrorsMetric.WithLabelValues(kind, vs.Name, vs.Namespace).Set(float64(len(failures)))
+		}
+	}
+
+	for key, value := range preAnalysis {
+		a.Results = append(a.Results, Result{
+			Kind:  kind,
+			Name:  key,
+			Error: value.FailureDetails,
+		})
+	}
+
+	return a.Results, nil
+}
+
+type DestinationRuleAnalyzer struct{}
+
+func (DestinationRuleAnalyzer) Analyze(a Analyzer) ([]Result, error) {
+	kind := "DestinationRule"
+
+	AnalyzerErrorsMetric.DeletePartialMatch(map[string]string{
+		"analyzer_name": kind,
+	})
+
+	if a.Client.IstioClient == nil {
+		return a.Results, nil
+	}
+
+	drList, err := a.Client.IstioClient.NetworkingV1alpha3().DestinationRules(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return a.Results, nil
+		}
+		return nil, err
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for i := range drList.Items {
+		dr := drList.Items[i]
+		var failures []Failure
+
+		name, namespace, ok := resolveIstioHost(dr.Spec.Host, dr.Namespace)
+		if ok {
+			svc, err := a.Client.GetClient().CoreV1().Services(namespace).Get(a.Context, name, metav1.GetOptions{})
+			if err != nil && apierrors.IsNotFound(err) {
+				failures = append(failures, Failure{
+					Text: fmt.Sprintf("DestinationRule %s targets service %s/%s which does not exist", dr.Name, namespace, name),
+					Sensitive: []Sensitive{
+						{
+							Unmasked: name,
+							Masked:   MaskString(name),
+						},
+					},
+				})
+			} else if err == nil {
+				for _, subset := range dr.Spec.Subsets {
+					if len(subset.Labels) == 0 {
+						continue
+					}
+					// subset labels are combined with the service selector when istio picks endpoints
+					selector := labels.Merge(svc.Spec.Selector, subset.Labels)
+					pods, err := a.Client.GetClient().CoreV1().Pods(namespace).List(a.Context, metav1.ListOptions{
+						LabelSelector: labels.SelectorFromSet(selector).String(),
+					})
+					if err != nil {
+						return nil, err
+					}
+					if len(pods.Items) == 0 {
+						failures = append(failures, Failure{
+							Text: fmt.Sprintf("DestinationRule %s subset %s does not match any pod, expected labels %s", dr.Name, subset.Name, labels.FormatLabels(subset.Labels)),
+							Sensitive: []Sensitive{
+								{
+									Unmasked: subset.Name,
+									Masked:   MaskString(subset.Name),
+								},
+							},
+						})
+					}
+				}
+			}
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", dr.Namespace, dr.Name)] = PreAnalysis{
+				FailureDetails: failures,
+			}
+			AnalyzerErrorsMetric.WithLabelValues(kind, dr.Name, dr.Namespace).Set(float64(len(failures)))
+		}
+	}
+
+	for key, value := range preAnalysis {
+		a.Results = append(a.Results, Result{
+			Kind:  kind,
+			Name:  key,
+			Error: value.FailureDetails,
+		})
+	}
+
+	return a.Results, nil
+}
+
+type istioDestination struct {
+	host   string
+	subset string
+}
+
+func virtualServiceDestinations(vs *networkingv1alpha3.VirtualService) []istioDestination {
+	var destinations []istioDestination
+	for _, route := range vs.Spec.Http {
+		for _, dest := range route.Route {
+			if dest.Destination != nil {
+				destinations = append(destinations, istioDestination{host: dest.Destination.Host, subset: dest.Destination.Subset})
+			}
+		}
+	}
+	for _, route := range vs.Spec.Tcp {
+		for _, dest := range route.Route {
+			if dest.Destination != nil {
+				destinations = append(destinations, istioDestination{host: dest.Destination.Host, subset: dest.Destination.Subset})
+			}
+		}
+	}
+	for _, route := range vs.Spec.Tls {
+		for _, dest := range route.Route {
+			if dest.Destination != nil {
+				destinations = append(destinations, istioDestination{host: dest.Destination.Host, subset: dest.Destination.Subset})
+			}
+		}
+	}
+	return destinations
+}
+
+// resolveIstioHost maps an istio host to a kubernetes service name and namespace.
+// Only short names and <service>.<namespace>.svc[.<domain>] are resolved, wildcard and
+// external hosts return false since they cannot be checked against the cluster.
+func resolveIstioHost(host, namespace string) (string, string, bool) {
+	if host == "" || strings.Contains(host, "*") {
+		return "", "", false
+	}
+	parts := strings.Split(host, ".")
+	switch {
+	case len(parts) == 1:
+		return parts[0], namespace, true
+	case len(parts) >= 3 && parts[2] == "svc":
+		return parts[0], parts[1], true
+	default:
+		return "", "", false
+	}
+}
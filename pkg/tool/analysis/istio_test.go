@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	networkingapi "istio.io/api/networking/v1alpha3"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVirtualServiceAnalyzer(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews",
+			Namespace: "default",
+		},
+	})
+	istioClientset := istiofake.NewSimpleClientset(
+		&networkingv1alpha3.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reviews",
+				Namespace: "default",
+			},
+			Spec: networkingapi.VirtualService{
+				Hosts:    []string{"reviews"},
+				Gateways: []string{"mesh", "public-gateway"},
+				Http: []*networkingapi.HTTPRoute{
+					{
+						Route: []*networkingapi.HTTPRouteDestination{
+							{Destination: &networkingapi.Destination{Host: "reviews", Subset: "v1"}},
+							{Destination: &networkingapi.Destination{Host: "reviews.default.svc.cluster.local", Subset: "v2"}},
+							{Destination: &networkingapi.Destination{Host: "ratings"}},
+							{Destination: &networkingapi.Destination{Host: "api.example.com"}},
+						},
+					},
+				},
+			},
+		},
+		&networkingv1alpha3.DestinationRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reviews",
+				Namespace: "default",
+			},
+			Spec: networkingapi.DestinationRule{
+				Host: "reviews",
+				Subsets: []*networkingapi.Subset{
+					{Name: "v1", Labels: map[string]string{"version": "v1"}},
+				},
+			},
+		},
+	)
+
+	config := Analyzer{
+		Client: &Client{
+			Client:      clientset,
+			IstioClient: istioClientset,
+		},
+		Context:   context.Background(),
+		Namespace: "default",
+	}
+
+	analysisResults, err := VirtualServiceAnalyzer{}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Name, "default/reviews")
+	assert.Equal(t, len(analysisResults[0].Error), 3)
+	assert.Equal(t, analysisResults[0].Error[0].Text, "VirtualService reviews uses gateway default/public-gateway which does not exist")
+	assert.Equal(t, analysisResults[0].Error[1].Text, "VirtualService reviews routes to subset v2 of default/reviews which is not defined in any DestinationRule")
+	assert.Equal(t, analysisResults[0].Error[2].Text, "VirtualService reviews routes to service default/ratings which does not exist")
+}
+
+func TestDestinationRuleAnalyzer(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reviews",
+				Namespace: "default",
+			},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "reviews"},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reviews-v1",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "reviews", "version": "v1"},
+			},
+		},
+	)
+	istioClientset := istiofake.NewSimpleClientset(
+		&networkingv1alpha3.DestinationRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reviews",
+				Namespace: "default",
+			},
+			Spec: networkingapi.DestinationRule{
+				Host: "reviews",
+				Subsets: []*networkingapi.Subset{
+					{Name: "v1", Labels: map[string]string{"version": "v1"}},
+					{Name: "v2", Labels: map[string]string{"version": "v2"}},
+				},
+			},
+		},
+		&networkingv1alpha3.DestinationRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "details",
+				Namespace: "default",
+			},
+			Spec: networkingapi.DestinationRule{
+				Host: "details.default.svc.cluster.local",
+			},
+		},
+	)
+
+	config := Analyzer{
+		Client: &Client{
+			Client:      clientset,
+			IstioClient: istioClientset,
+		},
+		Context:   context.Background(),
+		Namespace: "default",
+	}
+
+	analysisResults, err := DestinationRuleAnalyzer{}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+
+	failures := map[string]string{}
+	for _, result := range analysisResults {
+		assert.Equal(t, len(result.Error), 1)
+		failures[result.Name] = result.Error[0].Text
+	}
+	assert.Equal(t, len(failures), 2)
+	assert.Equal(t, failures["default/reviews"], "DestinationRule reviews subset v2 does not match any pod, expected labels version=v2")
+	assert.Equal(t, failures["default/details"], "DestinationRule details targets service default/details which does not exist")
+}
+
+func TestIstioAnalyzerWithoutIstio(t *testing.T) {
+	config := Analyzer{
+		Client: &Client{
+			Client: fake.NewSimpleClientset(),
+		},
+		Context:   context.Background(),
+		Namespace: "default",
+	}
+
+	analysisResults, err := VirtualServiceAnalyzer{}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, len(analysisResults), 0)
+}
@@ -17,7 +17,10 @@ package analysis
 
 import (
 	"github.com/koderover/zadig/v2/pkg/tool/clientmanager"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+	istioversioned "istio.io/client-go/pkg/clientset/versioned"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 )
@@ -25,6 +28,9 @@ import (
 type Client struct {
 	Client        kubernetes.Interface
 	ServerVersion *version.Info
+	// IstioClient and DynamicClient are optional, analyzers depending on them are skipped when nil
+	IstioClient   istioversioned.Interface
+	DynamicClient dynamic.Interface
 }
 
 func (c *Client) GetClient() kubernetes.Interface {
@@ -42,8 +48,26 @@ func NewClient(clusterID string) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{
+	client := &Client{
 		Client:        clientSet,
 		ServerVersion: serverVersion,
-	}, nil
+	}
+
+	istioClient, err := clientmanager.NewKubeClientManager().GetIstioClientSet(clusterID)
+	if err != nil {
+		log.Warnf("failed to create istio client for cluster %s: %v", clusterID, err)
+	} else {
+		client.IstioClient = istioClient
+	}
+
+	restConfig, err := clientmanager.NewKubeClientManager().GetRestConfig(clusterID)
+	if err != nil {
+		log.Warnf("failed to get rest config for cluster %s: %v", clusterID, err)
+	} else if dynamicClient, err := dynamic.NewForConfig(restConfig); err != nil {
+		log.Warnf("failed to create dynamic client for cluster %s: %v", clusterID, err)
+	} else {
+		client.DynamicClient = dynamicClient
+	}
+
+	return client, nil
 }
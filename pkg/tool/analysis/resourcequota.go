@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	kubernetes "github.com/koderover/zadig/v2/pkg/shared/kube/wrapper"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResourceQuotaPressureThreshold is the used/hard ratio from which a quota is reported as under pressure
+const ResourceQuotaPressureThreshold = 0.9
+
+type ResourceQuotaAnalyzer struct{}
+
+func (ResourceQuotaAnalyzer) Analyze(a Analyzer) ([]Result, error) {
+	kind := "ResourceQuota"
+	apiDoc := kubernetes.K8sApiReference{
+		Kind: kind,
+		ApiVersion: schema.GroupVersion{
+			Group:   "",
+			Version: "v1",
+		},
+		OpenapiSchema: a.OpenapiSchema,
+	}
+
+	AnalyzerErrorsMetric.DeletePartialMatch(map[string]string{
+		"analyzer_name": kind,
+	})
+
+	list, err := a.Client.GetClient().CoreV1().ResourceQuotas(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var preAnalysis = map[string]PreAnalysis{}
+
+	for _, quota := range list.Items {
+		var failures []Failure
+
+		resourceNames := make([]string, 0, len(quota.Status.Hard))
+		for name := range quota.Status.Hard {
+			resourceNames = append(resourceNames, string(name))
+		}
+		sort.Strings(resourceNames)
+
+		for _, name := range resourceNames {
+			hard := quota.Status.Hard[v1.ResourceName(name)]
+			used, ok := quota.Status.Used[v1.ResourceName(name)]
+			if !ok {
+				continue
+			}
+
+			var ratio float64
+			if hard.IsZero() {
+				if used.IsZero() {
+					continue
+				}
+				ratio = 1
+			} else {
+				ratio = used.AsApproximateFloat64() / hard.AsApproximateFloat64()
+			}
+			if ratio < ResourceQuotaPressureThreshold {
+				continue
+			}
+
+			doc := apiDoc.GetApiDocV2("spec.hard")
+			text := fmt.Sprintf("ResourceQuota %s is under pressure for %s: %s of %s used (%.0f%%)", quota.Name, name, used.String(), hard.String(), ratio*100)
+			if ratio >= 1 {
+				text = fmt.Sprintf("ResourceQuota %s is exhausted for %s: %s of %s used, new workloads requesting it will be rejected", quota.Name, name, used.String(), hard.String())
+			}
+			failures = append(failures, Failure{
+				Text:          text,
+				KubernetesDoc: doc,
+				Sensitive: []Sensitive{
+					{
+						Unmasked: quota.Namespace,
+						Masked:   MaskString(quota.Namespace),
+					},
+					{
+						Unmasked: quota.Name,
+						Masked:   MaskString(quota.Name),
+					},
+				},
+			})
+		}
+
+		if len(failures) > 0 {
+			preAnalysis[fmt.Sprintf("%s/%s", quota.Namespace, quota.Name)] = PreAnalysis{
+				FailureDetails: failures,
+			}
+			AnalyzerErrorsMetric.WithLabelValues(kind, quota.Name, quota.Namespace).Set(float64(len(failures)))
+		}
+	}
+
+	for key, value := range preAnalysis {
+		a.Results = append(a.Results, Result{
+			Kind:  kind,
+			Name:  key,
+			Error: value.FailureDetails,
+		})
+	}
+
+	return a.Results, nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResourceQuotaAnalyzer(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-quota",
+			Namespace: "default",
+		},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{
+				v1.ResourceLimitsCPU:    resource.MustParse("4"),
+				v1.ResourceLimitsMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:         resource.MustParse("10"),
+			},
+			Used: v1.ResourceList{
+				v1.ResourceLimitsCPU:    resource.MustParse("3800m"),
+				v1.ResourceLimitsMemory: resource.MustParse("2Gi"),
+				v1.ResourcePods:         resource.MustParse("10"),
+			},
+		},
+	})
+
+	config := Analyzer{
+		Client: &Client{
+			Client: clientset,
+		},
+		Context:   context.Background(),
+		Namespace: "default",
+	}
+
+	analysisResults, err := ResourceQuotaAnalyzer{}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Name, "default/example-quota")
+	assert.Equal(t, len(analysisResults[0].Error), 2)
+	assert.Equal(t, analysisResults[0].Error[0].Text, "ResourceQuota example-quota is under pressure for limits.cpu: 3800m of 4 used (95%)")
+	assert.Equal(t, analysisResults[0].Error[1].Text, "ResourceQuota example-quota is exhausted for pods: 10 of 10 used, new workloads requesting it will be rejected")
+}
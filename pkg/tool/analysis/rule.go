@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+type RuleOperator string
+
+const (
+	RuleOperatorExists    RuleOperator = "exists"
+	RuleOperatorNotExists RuleOperator = "notExists"
+	RuleOperatorEqual     RuleOperator = "eq"
+	RuleOperatorNotEqual  RuleOperator = "ne"
+	RuleOperatorGreater   RuleOperator = "gt"
+	RuleOperatorLess      RuleOperator = "lt"
+	RuleOperatorMatches   RuleOperator = "matches"
+)
+
+// Rule is a declarative analyzer. Every object of the given resource for which all conditions
+// hold is reported as a failure with the rendered message.
+//
+//	name: container-without-limits
+//	apiVersion: apps/v1
+//	resource: deployments
+//	kind: Deployment
+//	conditions:
+//	  - path: spec.template.spec.containers[*].resources.limits
+//	    operator: notExists
+//	message: "Deployment {{.Name}} has containers without resource limits"
+type Rule struct {
+	Name       string          `json:"name"`
+	APIVersion string          `json:"apiVersion"`
+	Resource   string          `json:"resource"`
+	Kind       string          `json:"kind"`
+	Conditions []RuleCondition `json:"conditions"`
+	Message    string          `json:"message"`
+}
+
+// RuleCondition matches the value found at Path, a dot separated field path in which a
+// "[*]" suffix iterates over a list. With a wildcard the condition holds if any element matches.
+type RuleCondition struct {
+	Path     string       `json:"path"`
+	Operator RuleOperator `json:"operator"`
+	Value    string       `json:"value,omitempty"`
+}
+
+type ruleMessageArgs struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// ParseRules parses and validates a yaml list of rules.
+func ParseRules(data []byte) ([]Rule, error) {
+	rules := make([]Rule, 0)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return rules, nil
+	}
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse analysis rules: %w", err)
+	}
+
+	_, builtin := GetAnalyzerMap()
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := builtin[rule.Name]; ok {
+			return nil, fmt.Errorf("rule %s conflicts with a built-in analyzer", rule.Name)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule %s", rule.Name)
+		}
+		names[rule.Name] = true
+	}
+	return rules, nil
+}
+
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name is empty")
+	}
+	if _, err := schema.ParseGroupVersion(r.APIVersion); err != nil || r.APIVersion == "" {
+		return fmt.Errorf("rule %s has an invalid apiVersion %q", r.Name, r.APIVersion)
+	}
+	if r.Resource == "" {
+		return fmt.Errorf("rule %s has no resource", r.Name)
+	}
+	if len(r.Conditions) == 0 {
+		return fmt.Errorf("rule %s has no conditions", r.Name)
+	}
+	for _, condition := range r.Conditions {
+		if condition.Path == "" {
+			return fmt.Errorf("rule %s has a condition without path", r.Name)
+		}
+		switch condition.Operator {
+		case RuleOperatorExists, RuleOperatorNotExists, RuleOperatorEqual, RuleOperatorNotEqual:
+		case RuleOperatorGreater, RuleOperatorLess:
+			if _, ok := parseRuleNumber(condition.Value); !ok {
+				return fmt.Errorf("rule %s: value %q of %s is not a number", r.Name, condition.Value, condition.Path)
+			}
+		case RuleOperatorMatches:
+			if _, err := regexp.Compile(condition.Value); err != nil {
+				return fmt.Errorf("rule %s: invalid pattern %q: %w", r.Name, condition.Value, err)
+			}
+		default:
+			return fmt.Errorf("rule %s: unsupported operator %q", r.Name, condition.Operator)
+		}
+	}
+	if _, err := template.New(r.Name).Parse(r.Message); err != nil {
+		return fmt.Errorf("rule %s: invalid message template: %w", r.Name, err)
+	}
+	return nil
+}
+
+// Match reports whether all conditions hold for the given unstructured object.
+func (r Rule) Match(obj map[string]interface{}) bool {
+	for _, condition := range r.Conditions {
+		if !condition.match(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) message(args ruleMessageArgs) string {
+	if r.Message == "" {
+		return fmt.Sprintf("%s %s violates rule %s", args.Kind, args.Name, r.Name)
+	}
+	tmpl, err := template.New(r.Name).Parse(r.Message)
+	if err != nil {
+		return r.Message
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, args); err != nil {
+		return r.Message
+	}
+	return buf.String()
+}
+
+type RuleAnalyzer struct {
+	Rule Rule
+}
+
+func (analyzer RuleAnalyzer) Analyze(a Analyzer) ([]Result, error) {
+	rule := analyzer.Rule
+	kind := rule.Kind
+	if kind == "" {
+		kind = rule.Resource
+	}
+
+	AnalyzerErrorsMetric.DeletePartialMatch(map[string]string{
+		"analyzer_name": rule.Name,
+	})
+
+	if a.Client.DynamicClient == nil {
+		return a.Results, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(rule.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	list, err := a.Client.DynamicClient.Resource(gv.WithResource(rule.Resource)).Namespace(a.Namespace).List(a.Context, metav1.ListOptions{})
+	if err != nil {
+		// the resource is not served by the cluster, e.g. a crd that is not installed
+		if apierrors.IsNotFound(err) {
+			return a.Results, nil
+		}
+		return nil, err
+	}
+
+	for _, item := range list.Items {
+		if !rule.Match(item.Object) {
+			continue
+		}
+
+		text := rule.message(ruleMessageArgs{Kind: kind, Name: item.GetName(), Namespace: item.GetNamespace()})
+		a.Results = append(a.Results, Result{
+			Kind: kind,
+			Name: fmt.Sprintf("%s/%s", item.GetNamespace(), item.GetName()),
+			Error: []Failure{
+				{
+					Text: text,
+					Sensitive: []Sensitive{
+						{
+							Unmasked: item.GetNamespace(),
+							Masked:   MaskString(item.GetNamespace()),
+						},
+						{
+							Unmasked: item.GetName(),
+							Masked:   MaskString(item.GetName()),
+						},
+					},
+				},
+			},
+		})
+		AnalyzerErrorsMetric.WithLabelValues(rule.Name, item.GetName(), item.GetNamespace()).Set(1)
+	}
+
+	return a.Results, nil
+}
+
+func (c RuleCondition) match(obj map[string]interface{}) bool {
+	for _, value := range lookupRulePath(obj, strings.Split(c.Path, ".")) {
+		if c.matchValue(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c RuleCondition) matchValue(value rulePathValue) bool {
+	switch c.Operator {
+	case RuleOperatorExists:
+		return value.found
+	case RuleOperatorNotExists:
+		return !value.found
+	}
+	if !value.found {
+		return false
+	}
+
+	switch c.Operator {
+	case RuleOperatorEqual:
+		return fmt.Sprint(value.value) == c.Value
+	case RuleOperatorNotEqual:
+		return fmt.Sprint(value.value) != c.Value
+	case RuleOperatorGreater, RuleOperatorLess:
+		actual, ok := parseRuleNumber(fmt.Sprint(value.value))
+		if !ok {
+			return false
+		}
+		expected, _ := parseRuleNumber(c.Value)
+		if c.Operator == RuleOperatorGreater {
+			return actual > expected
+		}
+		return actual < expected
+	case RuleOperatorMatches:
+		matched, err := regexp.MatchString(c.Value, fmt.Sprint(value.value))
+		return err == nil && matched
+	}
+	return false
+}
+
+type rulePathValue struct {
+	value interface{}
+	found bool
+}
+
+func lookupRulePath(obj interface{}, segments []string) []rulePathValue {
+	if len(segments) == 0 {
+		return []rulePathValue{{value: obj, found: true}}
+	}
+
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return []rulePathValue{{}}
+	}
+
+	segment := segments[0]
+	field := strings.TrimSuffix(segment, "[*]")
+	value, ok := m[field]
+	if !ok || value == nil {
+		return []rulePathValue{{}}
+	}
+	if field == segment {
+		return lookupRulePath(value, segments[1:])
+	}
+
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return []rulePathValue{{}}
+	}
+	values := make([]rulePathValue, 0, len(list))
+	for _, item := range list {
+		values = append(values, lookupRulePath(item, segments[1:])...)
+	}
+	return values
+}
+
+// parseRuleNumber accepts plain numbers as well as kubernetes quantities such as 500m or 1Gi
+func parseRuleNumber(s string) (float64, bool) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0, false
+	}
+	return q.AsApproximateFloat64(), true
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+const testRules = `
+- name: container-without-limits
+  apiVersion: apps/v1
+  resource: deployments
+  kind: Deployment
+  conditions:
+    - path: spec.template.spec.containers[*].resources.limits
+      operator: notExists
+  message: "Deployment {{.Name}} has containers without resource limits"
+- name: too-many-replicas
+  apiVersion: apps/v1
+  resource: deployments
+  conditions:
+    - path: spec.replicas
+      operator: gt
+      value: "5"
+    - path: metadata.labels.tier
+      operator: matches
+      value: "^front"
+`
+
+func newTestDeployment(name string, replicas int64, limits map[string]interface{}) *unstructured.Unstructured {
+	container := map[string]interface{}{"name": "app", "image": "nginx"}
+	if limits != nil {
+		container["resources"] = map[string]interface{}{"limits": limits}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+			"labels":    map[string]interface{}{"tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{container},
+				},
+			},
+		},
+	}}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(rules), 2)
+	assert.Equal(t, rules[0].Conditions[0].Operator, RuleOperatorNotExists)
+
+	invalid := []string{
+		"- name: Pod\n  apiVersion: v1\n  resource: pods\n  conditions:\n    - path: spec\n      operator: exists\n",
+		"- name: a\n  apiVersion: v1\n  resource: pods\n",
+		"- name: a\n  apiVersion: v1\n  resource: pods\n  conditions:\n    - path: spec\n      operator: in\n",
+		"- name: a\n  apiVersion: v1\n  resource: pods\n  conditions:\n    - path: spec.replicas\n      operator: gt\n      value: many\n",
+	}
+	for _, rule := range invalid {
+		_, err := ParseRules([]byte(rule))
+		assert.Equal(t, err != nil, true, rule)
+	}
+}
+
+func TestRuleAnalyzer(t *testing.T) {
+	rules, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "DeploymentList"},
+		newTestDeployment("web", 10, nil),
+		newTestDeployment("api", 1, map[string]interface{}{"cpu": "500m"}),
+	)
+
+	config := Analyzer{
+		Client: &Client{
+			DynamicClient: dynamicClient,
+		},
+		Context:   context.Background(),
+		Namespace: "default",
+	}
+
+	analysisResults, err := RuleAnalyzer{Rule: rules[0]}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Name, "default/web")
+	assert.Equal(t, analysisResults[0].Error[0].Text, "Deployment web has containers without resource limits")
+
+	analysisResults, err = RuleAnalyzer{Rule: rules[1]}.Analyze(config)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, len(analysisResults), 1)
+	assert.Equal(t, analysisResults[0].Kind, "deployments")
+	assert.Equal(t, analysisResults[0].Error[0].Text, "deployments web violates rule too-many-replicas")
+}
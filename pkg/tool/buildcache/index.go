@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	archiveExt = ".tar.gz"
+	metaExt    = ".json"
+)
+
+// Entry is an immutable archive of a named cache, an entry is never overwritten once saved. Every entry keeps its
+// metadata in its own object next to the archive so that concurrent jobs never overwrite the records of each other.
+type Entry struct {
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+	LastUsed  int64  `json:"last_used"`
+}
+
+// Path is the path of the entry archive relative to the store directory.
+func (e *Entry) Path() string {
+	return EntryPath(e.Name, e.Key)
+}
+
+// MetaPath is the path of the entry metadata relative to the store directory.
+func (e *Entry) MetaPath() string {
+	return path.Join(e.Name, e.Key+metaExt)
+}
+
+func EntryPath(name, key string) string {
+	return path.Join(name, key+archiveExt)
+}
+
+// Index is the view of the entries of a cache store directory loaded from their metadata, it is used to match
+// restore keys and to evict the least recently used entries.
+type Index struct {
+	Entries []*Entry `json:"entries"`
+}
+
+func (idx *Index) Get(name, key string) *Entry {
+	for _, entry := range idx.Entries {
+		if entry.Name == name && entry.Key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Match finds the entry to restore for a named cache: the entry with the exact key, or else the most recently
+// created entry whose key starts with the first restore key that matches anything.
+func (idx *Index) Match(name, key string, restoreKeys []string) (entry *Entry, exact bool) {
+	if entry := idx.Get(name, key); entry != nil {
+		return entry, true
+	}
+	for _, prefix := range restoreKeys {
+		if prefix == "" {
+			continue
+		}
+		var latest *Entry
+		for _, entry := range idx.Entries {
+			if entry.Name != name || !strings.HasPrefix(entry.Key, prefix) {
+				continue
+			}
+			if latest == nil || entry.CreatedAt > latest.CreatedAt {
+				latest = entry
+			}
+		}
+		if latest != nil {
+			return latest, false
+		}
+	}
+	return nil, false
+}
+
+// Add records a new entry, nothing is changed if the entry already exists since entries are immutable.
+func (idx *Index) Add(entry *Entry) bool {
+	if idx.Get(entry.Name, entry.Key) != nil {
+		return false
+	}
+	idx.Entries = append(idx.Entries, entry)
+	return true
+}
+
+func (idx *Index) Touch(name, key string, now int64) {
+	if entry := idx.Get(name, key); entry != nil {
+		entry.LastUsed = now
+	}
+}
+
+func (idx *Index) Size() int64 {
+	var size int64
+	for _, entry := range idx.Entries {
+		size += entry.Size
+	}
+	return size
+}
+
+// Evict removes the least recently used entries until the total size is within quota and returns them,
+// a quota of 0 means no limit.
+func (idx *Index) Evict(quota int64) []*Entry {
+	if quota <= 0 || idx.Size() <= quota {
+		return nil
+	}
+
+	entries := make([]*Entry, len(idx.Entries))
+	copy(entries, idx.Entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].LastUsed != entries[j].LastUsed {
+			return entries[i].LastUsed < entries[j].LastUsed
+		}
+		return entries[i].CreatedAt < entries[j].CreatedAt
+	})
+
+	size := idx.Size()
+	evicted := make([]*Entry, 0)
+	for _, entry := range entries {
+		if size <= quota {
+			break
+		}
+		evicted = append(evicted, entry)
+		size -= entry.Size
+	}
+
+	kept := make([]*Entry, 0, len(idx.Entries)-len(evicted))
+	for _, entry := range idx.Entries {
+		if !containsEntry(evicted, entry) {
+			kept = append(kept, entry)
+		}
+	}
+	idx.Entries = kept
+	return evicted
+}
+
+func containsEntry(entries []*Entry, target *Entry) bool {
+	for _, entry := range entries {
+		if entry == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexMatch(t *testing.T) {
+	idx := &Index{Entries: []*Entry{
+		{Name: "go", Key: "go-linux-aaaa", CreatedAt: 1},
+		{Name: "go", Key: "go-linux-bbbb", CreatedAt: 3},
+		{Name: "go", Key: "go-darwin-cccc", CreatedAt: 5},
+		{Name: "npm", Key: "go-linux-dddd", CreatedAt: 9},
+	}}
+
+	tests := []struct {
+		name        string
+		key         string
+		restoreKeys []string
+		expectKey   string
+		exact       bool
+	}{
+		{name: "exact hit", key: "go-linux-aaaa", restoreKeys: []string{"go-"}, expectKey: "go-linux-aaaa", exact: true},
+		{name: "latest entry of the first matching prefix", key: "go-linux-eeee", restoreKeys: []string{"go-linux-", "go-"}, expectKey: "go-linux-bbbb"},
+		{name: "fall back to the next prefix", key: "go-windows-ffff", restoreKeys: []string{"go-windows-", "go-"}, expectKey: "go-darwin-cccc"},
+		{name: "miss", key: "go-windows-ffff", restoreKeys: []string{"go-windows-"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, exact := idx.Match("go", tt.key, tt.restoreKeys)
+			if tt.expectKey == "" {
+				assert.Nil(t, entry)
+				return
+			}
+			assert.Equal(t, tt.expectKey, entry.Key)
+			assert.Equal(t, tt.exact, exact)
+		})
+	}
+}
+
+func TestIndexAddIsImmutable(t *testing.T) {
+	idx := &Index{}
+	assert.True(t, idx.Add(&Entry{Name: "go", Key: "k", Size: 1}))
+	assert.False(t, idx.Add(&Entry{Name: "go", Key: "k", Size: 2}))
+	assert.Equal(t, int64(1), idx.Get("go", "k").Size)
+	assert.Equal(t, "go/k.tar.gz", idx.Get("go", "k").Path())
+	assert.Equal(t, "go/k.json", idx.Get("go", "k").MetaPath())
+}
+
+func TestIndexEvict(t *testing.T) {
+	idx := &Index{Entries: []*Entry{
+		{Name: "go", Key: "a", Size: 40, CreatedAt: 1, LastUsed: 10},
+		{Name: "go", Key: "b", Size: 40, CreatedAt: 2, LastUsed: 5},
+		{Name: "npm", Key: "c", Size: 40, CreatedAt: 3, LastUsed: 20},
+	}}
+
+	assert.Empty(t, idx.Evict(0))
+	assert.Empty(t, idx.Evict(120))
+
+	evicted := idx.Evict(70)
+	assert.Len(t, evicted, 2)
+	assert.Equal(t, "b", evicted[0].Key)
+	assert.Equal(t, "a", evicted[1].Key)
+	assert.Len(t, idx.Entries, 1)
+	assert.Equal(t, "c", idx.Entries[0].Key)
+	assert.Equal(t, int64(40), idx.Size())
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// digestLength is the number of hex characters of the key files digest appended to the key
+const digestLength = 16
+
+// skippedDirs are never walked when matching recursive key file patterns
+var skippedDirs = map[string]bool{".git": true, "node_modules": true}
+
+// Key returns the key of a cache entry: the rendered key prefix followed by the digest of the content of the
+// files under workspace matching any of the keyFiles patterns. Patterns are relative to workspace and may use
+// `**` to match any number of directories, an error is returned if a pattern matches no file.
+func Key(prefix, workspace string, keyFiles []string) (string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if len(keyFiles) == 0 {
+		if prefix == "" {
+			return "", fmt.Errorf("cache key is empty")
+		}
+		return prefix, nil
+	}
+
+	files, err := matchKeyFiles(workspace, keyFiles)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, file := range files {
+		f, err := os.Open(filepath.Join(workspace, file))
+		if err != nil {
+			return "", err
+		}
+		// the path is part of the digest so that moving a lockfile changes the key
+		fmt.Fprintf(hash, "%s\x00", file)
+		_, err = io.Copy(hash, f)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))[:digestLength]
+	if prefix == "" {
+		return digest, nil
+	}
+	return prefix + "-" + digest, nil
+}
+
+// matchKeyFiles returns the sorted slash separated paths relative to workspace of the files matching the patterns.
+func matchKeyFiles(workspace string, patterns []string) ([]string, error) {
+	matched := make(map[string]bool)
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(pattern)), "./")
+		if pattern == "" {
+			continue
+		}
+
+		count := 0
+		if strings.Contains(pattern, "**") {
+			re, err := globToRegexp(pattern)
+			if err != nil {
+				return nil, err
+			}
+			err = filepath.WalkDir(workspace, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					if skippedDirs[d.Name()] && p != workspace {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				rel, err := filepath.Rel(workspace, p)
+				if err != nil {
+					return err
+				}
+				if re.MatchString(filepath.ToSlash(rel)) {
+					matched[filepath.ToSlash(rel)] = true
+					count++
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			files, err := filepath.Glob(filepath.Join(workspace, filepath.FromSlash(pattern)))
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range files {
+				if info, err := os.Stat(file); err != nil || info.IsDir() {
+					continue
+				}
+				rel, err := filepath.Rel(workspace, file)
+				if err != nil {
+					return nil, err
+				}
+				matched[filepath.ToSlash(rel)] = true
+				count++
+			}
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("no file matches cache key file pattern %q", pattern)
+		}
+	}
+
+	resp := make([]string, 0, len(matched))
+	for file := range matched {
+		resp = append(resp, file)
+	}
+	sort.Strings(resp)
+	return resp, nil
+}
+
+// globToRegexp converts a slash separated glob pattern to a regular expression, `**/` matches any number of
+// directories, `*` and `?` do not match the separator.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					builder.WriteString("(.*/)?")
+				} else {
+					builder.WriteString(".*")
+				}
+			} else {
+				builder.WriteString("[^/]*")
+			}
+		case '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.Compile(builder.String())
+}
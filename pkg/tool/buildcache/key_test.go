@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		assert.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+func TestKey(t *testing.T) {
+	workspace := t.TempDir()
+	writeFiles(t, workspace, map[string]string{
+		"go.sum":                             "a v1.0.0 h1:xxx",
+		"web/package-lock.json":              `{"lockfileVersion": 3}`,
+		"web/admin/package-lock.json":        `{"lockfileVersion": 2}`,
+		"web/node_modules/x/go.sum":          "ignored",
+		"web/node_modules/package-lock.json": "ignored",
+	})
+
+	key, err := Key("go-linux", workspace, []string{"go.sum"})
+	assert.NoError(t, err)
+	assert.Regexp(t, `^go-linux-[0-9a-f]{16}$`, key)
+
+	same, err := Key("go-linux", workspace, []string{"./go.sum"})
+	assert.NoError(t, err)
+	assert.Equal(t, key, same)
+
+	npm, err := Key("npm", workspace, []string{"**/package-lock.json"})
+	assert.NoError(t, err)
+	writeFiles(t, workspace, map[string]string{"web/node_modules/package-lock.json": "changed"})
+	unchanged, err := Key("npm", workspace, []string{"**/package-lock.json"})
+	assert.NoError(t, err)
+	assert.Equal(t, npm, unchanged, "files in node_modules must not change the key")
+
+	writeFiles(t, workspace, map[string]string{"web/admin/package-lock.json": `{"lockfileVersion": 3}`})
+	changed, err := Key("npm", workspace, []string{"**/package-lock.json"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, npm, changed)
+
+	plain, err := Key("static", workspace, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "static", plain)
+
+	_, err = Key("go", workspace, []string{"vendor/modules.txt"})
+	assert.Error(t, err)
+
+	_, err = Key(" ", workspace, nil)
+	assert.Error(t, err)
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"**/go.sum", "go.sum", true},
+		{"**/go.sum", "a/b/go.sum", true},
+		{"**/go.sum", "a/b/xgo.sum", false},
+		{"web/*.json", "web/a.json", true},
+		{"web/*.json", "web/a/b.json", false},
+		{"web/**", "web/a/b.json", true},
+		{"go.su?", "go.sum", true},
+	}
+	for _, tt := range tests {
+		re, err := globToRegexp(tt.pattern)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.match, re.MatchString(tt.path), "%s %s", tt.pattern, tt.path)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+// ruleNameRegexp keeps cache names usable as a single path element of the store
+var ruleNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// ValidateRules checks the named caches of a build or testing before they are saved.
+func ValidateRules(rules []*types.CacheRule) error {
+	names := make(map[string]bool)
+	for _, rule := range rules {
+		if rule == nil {
+			return fmt.Errorf("cache rule is empty")
+		}
+		if !ruleNameRegexp.MatchString(rule.Name) {
+			return fmt.Errorf("invalid cache name %q, only letters, digits, '.', '_' and '-' are allowed", rule.Name)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicated cache name %s", rule.Name)
+		}
+		names[rule.Name] = true
+		if len(rule.Paths) == 0 {
+			return fmt.Errorf("cache %s has no paths", rule.Name)
+		}
+		if rule.Key == "" && len(rule.KeyFiles) == 0 {
+			return fmt.Errorf("cache %s needs a key or key files", rule.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/types"
+)
+
+func TestValidateRules(t *testing.T) {
+	valid := &types.CacheRule{Name: "go-mod", Paths: []string{"/root/go/pkg/mod"}, KeyFiles: []string{"go.sum"}}
+	assert.NoError(t, ValidateRules(nil))
+	assert.NoError(t, ValidateRules([]*types.CacheRule{valid}))
+
+	assert.Error(t, ValidateRules([]*types.CacheRule{valid, valid}))
+	assert.Error(t, ValidateRules([]*types.CacheRule{{Name: "../npm", Paths: []string{"node_modules"}, Key: "npm"}}))
+	assert.Error(t, ValidateRules([]*types.CacheRule{{Name: "npm", Key: "npm"}}))
+	assert.Error(t, ValidateRules([]*types.CacheRule{{Name: "npm", Paths: []string{"node_modules"}}}))
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/tool/s3"
+)
+
+// ErrNotExist is returned by a store if the object does not exist
+var ErrNotExist = errors.New("cache object does not exist")
+
+// Store keeps the cache entries of a cache store directory.
+type Store interface {
+	// Read downloads the object at the relative path to the local file dest
+	Read(name, dest string) error
+	// Write uploads the local file src to the relative path
+	Write(src, name string) error
+	Delete(names []string) error
+	// List returns the relative paths of all the objects in the store
+	List() ([]string, error)
+}
+
+type objectStore struct {
+	client *s3.Client
+	bucket string
+	dir    string
+}
+
+// NewObjectStore returns a store in the directory of a bucket of the object storage.
+func NewObjectStore(client *s3.Client, bucket, dir string) Store {
+	return &objectStore{client: client, bucket: bucket, dir: dir}
+}
+
+func (s *objectStore) objectKey(name string) string {
+	return path.Join(s.dir, name)
+}
+
+func (s *objectStore) Read(name, dest string) error {
+	_ = os.Remove(dest)
+	err := s.client.DownloadWithOption(s.bucket, s.objectKey(name), dest, &s3.DownloadOption{IgnoreNotExistError: true, RetryNum: 3})
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		return ErrNotExist
+	}
+	return nil
+}
+
+func (s *objectStore) Write(src, name string) error {
+	return s.client.Upload(s.bucket, src, s.objectKey(name))
+}
+
+func (s *objectStore) Delete(names []string) error {
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, s.objectKey(name))
+	}
+	return s.client.DeleteObjects(s.bucket, keys)
+}
+
+func (s *objectStore) List() ([]string, error) {
+	prefix := ""
+	if s.dir != "" {
+		prefix = strings.TrimSuffix(s.dir, "/") + "/"
+	}
+	keys, err := s.client.ListFiles(s.bucket, prefix, true)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, strings.TrimPrefix(key, prefix))
+	}
+	return names, nil
+}
+
+type dirStore struct {
+	dir string
+}
+
+// NewDirStore returns a store in a local directory, it is used for caches on a mounted nfs volume.
+func NewDirStore(dir string) Store {
+	return &dirStore{dir: dir}
+}
+
+func (s *dirStore) Read(name, dest string) error {
+	src, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return ErrNotExist
+	}
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return copyToFile(src, dest)
+}
+
+func (s *dirStore) Write(src, name string) error {
+	target := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// write to a temp file first so that a concurrent reader never sees a partial entry
+	temp := target + ".tmp"
+	if err := copyToFile(f, temp); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+	return os.Rename(temp, target)
+}
+
+func (s *dirStore) Delete(names []string) error {
+	var errs []error
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(name))); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *dirStore) List() ([]string, error) {
+	names := make([]string, 0)
+	err := filepath.WalkDir(s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(p, ".tmp") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	return names, err
+}
+
+func copyToFile(src io.Reader, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadIndex reads the metadata of all the entries in the store. An entry is recorded only after its archive is
+// written, so every listed entry could be restored unless it is evicted meanwhile.
+func LoadIndex(store Store, tempDir string) (*Index, error) {
+	names, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	temp := filepath.Join(tempDir, "entry"+metaExt)
+	defer os.Remove(temp)
+
+	idx := &Index{}
+	for _, name := range names {
+		if !strings.HasSuffix(name, metaExt) {
+			continue
+		}
+		if err := store.Read(name, temp); err != nil {
+			if errors.Is(err, ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		content, err := os.ReadFile(temp)
+		if err != nil {
+			return nil, err
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(content, entry); err != nil || entry.MetaPath() != name {
+			continue
+		}
+		idx.Add(entry)
+	}
+	return idx, nil
+}
+
+// SaveEntry writes the metadata of the entry, its archive must be written before.
+func SaveEntry(store Store, entry *Entry, tempDir string) error {
+	temp := filepath.Join(tempDir, "entry"+metaExt)
+	defer os.Remove(temp)
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(temp, content, 0644); err != nil {
+		return err
+	}
+	return store.Write(temp, entry.MetaPath())
+}
+
+// DeleteEntries removes the metadata and the archives of the entries.
+func DeleteEntries(store Store, entries []*Entry) error {
+	names := make([]string, 0, 2*len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.MetaPath(), entry.Path())
+	}
+	return store.Delete(names)
+}
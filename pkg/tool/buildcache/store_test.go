@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentSaveEntry(t *testing.T) {
+	store := NewDirStore(t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tempDir := t.TempDir()
+			archive := filepath.Join(tempDir, "archive")
+			assert.NoError(t, os.WriteFile(archive, []byte("cache"), 0644))
+
+			entry := &Entry{Name: "go", Key: fmt.Sprintf("key-%d", i), Size: 5}
+			assert.NoError(t, store.Write(archive, entry.Path()))
+			assert.NoError(t, SaveEntry(store, entry, tempDir))
+		}(i)
+	}
+	wg.Wait()
+
+	idx, err := LoadIndex(store, t.TempDir())
+	assert.NoError(t, err)
+	assert.Len(t, idx.Entries, 8)
+
+	assert.NoError(t, DeleteEntries(store, []*Entry{idx.Get("go", "key-0")}))
+	idx, err = LoadIndex(store, t.TempDir())
+	assert.NoError(t, err)
+	assert.Len(t, idx.Entries, 7)
+	assert.Nil(t, idx.Get("go", "key-0"))
+
+	names, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, names, 14)
+}
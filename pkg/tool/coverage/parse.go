@@ -0,0 +1,361 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// conditionCoverageRegexp matches the covered and total conditions in a cobertura condition-coverage like `50% (1/2)`
+var conditionCoverageRegexp = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+// Detect returns the format of a coverage report by its content
+func Detect(data []byte) (Format, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("mode:")) {
+		return FormatGo, nil
+	}
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return "", fmt.Errorf("unknown coverage report format: %w", err)
+			}
+			if start, ok := token.(xml.StartElement); ok {
+				switch start.Name.Local {
+				case "coverage":
+					return FormatCobertura, nil
+				case "report":
+					return FormatJaCoCo, nil
+				default:
+					return "", fmt.Errorf("unknown coverage report format with xml root %s", start.Name.Local)
+				}
+			}
+		}
+	}
+	if bytes.Contains(trimmed, []byte("SF:")) && bytes.Contains(trimmed, []byte("end_of_record")) {
+		return FormatLCOV, nil
+	}
+	return "", fmt.Errorf("unknown coverage report format")
+}
+
+// Parse parses a coverage report of the given format, the format is detected if it is empty
+func Parse(format Format, data []byte) (*Report, error) {
+	if format == "" {
+		detected, err := Detect(data)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+	var (
+		files map[string]*FileCoverage
+		err   error
+	)
+	switch format {
+	case FormatCobertura:
+		files, err = parseCobertura(data)
+	case FormatJaCoCo:
+		files, err = parseJaCoCo(data)
+	case FormatLCOV:
+		files, err = parseLCOV(data)
+	case FormatGo:
+		files, err = parseGoProfile(data)
+	default:
+		return nil, fmt.Errorf("unsupported coverage report format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s coverage report: %w", format, err)
+	}
+	return newReport(format, files), nil
+}
+
+type coberturaReport struct {
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number            int    `xml:"number,attr"`
+				Hits              int64  `xml:"hits,attr"`
+				ConditionCoverage string `xml:"condition-coverage,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+type lineHits struct {
+	hits            int64
+	branchesCovered int
+	branchesTotal   int
+}
+
+func parseCobertura(data []byte) (map[string]*FileCoverage, error) {
+	report := &coberturaReport{}
+	if err := xml.Unmarshal(data, report); err != nil {
+		return nil, err
+	}
+	// a file may be split into several classes, the lines are merged by line number
+	lines := make(map[string]map[int]*lineHits)
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			if lines[class.Filename] == nil {
+				lines[class.Filename] = make(map[int]*lineHits)
+			}
+			for _, line := range class.Lines {
+				current := &lineHits{hits: line.Hits}
+				if match := conditionCoverageRegexp.FindStringSubmatch(line.ConditionCoverage); match != nil {
+					current.branchesCovered, _ = strconv.Atoi(match[1])
+					current.branchesTotal, _ = strconv.Atoi(match[2])
+				}
+				if existed, ok := lines[class.Filename][line.Number]; ok {
+					current.hits = max(current.hits, existed.hits)
+					current.branchesCovered = max(current.branchesCovered, existed.branchesCovered)
+					current.branchesTotal = max(current.branchesTotal, existed.branchesTotal)
+				}
+				lines[class.Filename][line.Number] = current
+			}
+		}
+	}
+
+	files := make(map[string]*FileCoverage)
+	for filename, fileLines := range lines {
+		file := &FileCoverage{Path: filename}
+		for _, line := range fileLines {
+			file.LinesTotal++
+			if line.hits > 0 {
+				file.LinesCovered++
+			}
+			file.BranchesCovered += line.branchesCovered
+			file.BranchesTotal += line.branchesTotal
+		}
+		files[filename] = file
+	}
+	return files, nil
+}
+
+type jacocoCounter struct {
+	Type    string `xml:"type,attr"`
+	Missed  int    `xml:"missed,attr"`
+	Covered int    `xml:"covered,attr"`
+}
+
+type jacocoPackage struct {
+	Name        string `xml:"name,attr"`
+	SourceFiles []struct {
+		Name     string          `xml:"name,attr"`
+		Counters []jacocoCounter `xml:"counter"`
+	} `xml:"sourcefile"`
+}
+
+// jacocoGroup is the root report or a module of a multi-module report, groups could be nested
+type jacocoGroup struct {
+	Groups   []jacocoGroup   `xml:"group"`
+	Packages []jacocoPackage `xml:"package"`
+}
+
+func parseJaCoCo(data []byte) (map[string]*FileCoverage, error) {
+	report := &jacocoGroup{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// the report refers to an external dtd which is never loaded
+	decoder.Strict = false
+	if err := decoder.Decode(report); err != nil {
+		return nil, err
+	}
+	files := make(map[string]*FileCoverage)
+	var walk func(group jacocoGroup)
+	walk = func(group jacocoGroup) {
+		for _, pkg := range group.Packages {
+			for _, sourceFile := range pkg.SourceFiles {
+				file := &FileCoverage{Path: strings.TrimLeft(pkg.Name+"/"+sourceFile.Name, "/")}
+				for _, counter := range sourceFile.Counters {
+					switch counter.Type {
+					case "LINE":
+						file.LinesCovered = counter.Covered
+						file.LinesTotal = counter.Covered + counter.Missed
+					case "BRANCH":
+						file.BranchesCovered = counter.Covered
+						file.BranchesTotal = counter.Covered + counter.Missed
+					}
+				}
+				files[file.Path] = file
+			}
+		}
+		for _, child := range group.Groups {
+			walk(child)
+		}
+	}
+	walk(*report)
+	return files, nil
+}
+
+func parseLCOV(data []byte) (map[string]*FileCoverage, error) {
+	files := make(map[string]*FileCoverage)
+	var (
+		file     *FileCoverage
+		lines    map[string]int64
+		branches map[string]bool
+		summary  *FileCoverage
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(line, ":")
+		if key != "SF" && key != "end_of_record" && file == nil {
+			continue
+		}
+		switch key {
+		case "SF":
+			file = &FileCoverage{Path: value}
+			lines = make(map[string]int64)
+			branches = make(map[string]bool)
+			summary = &FileCoverage{}
+		case "DA":
+			fields := strings.Split(value, ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid line record %q", line)
+			}
+			hits, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid line record %q", line)
+			}
+			lines[fields[0]] = max(lines[fields[0]], hits)
+		case "BRDA":
+			fields := strings.Split(value, ",")
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("invalid branch record %q", line)
+			}
+			id := strings.Join(fields[:3], ",")
+			branches[id] = branches[id] || (fields[3] != "-" && fields[3] != "0")
+		case "LF", "LH", "BRF", "BRH":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid summary record %q", line)
+			}
+			switch key {
+			case "LF":
+				summary.LinesTotal = n
+			case "LH":
+				summary.LinesCovered = n
+			case "BRF":
+				summary.BranchesTotal = n
+			case "BRH":
+				summary.BranchesCovered = n
+			}
+		case "end_of_record":
+			if file == nil {
+				continue
+			}
+			// the detail records are preferred, the summary records are used when a tool only emits them
+			if len(lines) > 0 {
+				for _, hits := range lines {
+					file.LinesTotal++
+					if hits > 0 {
+						file.LinesCovered++
+					}
+				}
+			} else {
+				file.LinesTotal, file.LinesCovered = summary.LinesTotal, summary.LinesCovered
+			}
+			if len(branches) > 0 {
+				for _, taken := range branches {
+					file.BranchesTotal++
+					if taken {
+						file.BranchesCovered++
+					}
+				}
+			} else {
+				file.BranchesTotal, file.BranchesCovered = summary.BranchesTotal, summary.BranchesCovered
+			}
+			if existed, ok := files[file.Path]; !ok || file.LinesCovered > existed.LinesCovered {
+				files[file.Path] = file
+			}
+			file = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+type goBlock struct {
+	statements int
+	count      int64
+}
+
+func parseGoProfile(data []byte) (map[string]*FileCoverage, error) {
+	// the same block shows up once per test binary when the profiles of several packages are concatenated
+	blocks := make(map[string]map[string]*goBlock)
+	reader := bufio.NewReader(bytes.NewReader(data))
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "mode:") {
+			// name.go:line.column,line.column numberOfStatements count
+			idx := strings.LastIndex(line, ":")
+			fields := strings.Fields(line[idx+1:])
+			if idx <= 0 || len(fields) != 3 {
+				return nil, fmt.Errorf("invalid profile line %q", line)
+			}
+			statements, convErr := strconv.Atoi(fields[1])
+			if convErr != nil {
+				return nil, fmt.Errorf("invalid profile line %q", line)
+			}
+			count, convErr := strconv.ParseInt(fields[2], 10, 64)
+			if convErr != nil {
+				return nil, fmt.Errorf("invalid profile line %q", line)
+			}
+			filename := line[:idx]
+			if blocks[filename] == nil {
+				blocks[filename] = make(map[string]*goBlock)
+			}
+			if existed, ok := blocks[filename][fields[0]]; ok {
+				existed.count = max(existed.count, count)
+			} else {
+				blocks[filename][fields[0]] = &goBlock{statements: statements, count: count}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := make(map[string]*FileCoverage)
+	for filename, fileBlocks := range blocks {
+		file := &FileCoverage{Path: filename}
+		for _, block := range fileBlocks {
+			file.LinesTotal += block.statements
+			if block.count > 0 {
+				file.LinesCovered += block.statements
+			}
+		}
+		files[filename] = file
+	}
+	return files, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+type Format string
+
+const (
+	FormatCobertura Format = "cobertura"
+	FormatJaCoCo    Format = "jacoco"
+	FormatLCOV      Format = "lcov"
+	// FormatGo is the `go test -coverprofile` output, statements are counted as lines like `go tool cover -func` does
+	FormatGo Format = "go"
+)
+
+// ReportJSONFile is the normalized report uploaded by the coverage report step
+const ReportJSONFile = "coverage.json"
+
+// FileCoverage is the coverage of a single source file
+type FileCoverage struct {
+	Path            string  `bson:"path"             json:"path"             yaml:"path"`
+	LinesCovered    int     `bson:"lines_covered"    json:"lines_covered"    yaml:"lines_covered"`
+	LinesTotal      int     `bson:"lines_total"      json:"lines_total"      yaml:"lines_total"`
+	BranchesCovered int     `bson:"branches_covered" json:"branches_covered" yaml:"branches_covered"`
+	BranchesTotal   int     `bson:"branches_total"   json:"branches_total"   yaml:"branches_total"`
+	LineRate        float64 `bson:"line_rate"        json:"line_rate"        yaml:"line_rate"`
+	BranchRate      float64 `bson:"branch_rate"      json:"branch_rate"      yaml:"branch_rate"`
+}
+
+// Summary is the total coverage of a report, rates are percentages rounded to two decimals
+type Summary struct {
+	Format          Format  `bson:"format"           json:"format"           yaml:"format"`
+	FileCount       int     `bson:"file_count"       json:"file_count"       yaml:"file_count"`
+	LinesCovered    int     `bson:"lines_covered"    json:"lines_covered"    yaml:"lines_covered"`
+	LinesTotal      int     `bson:"lines_total"      json:"lines_total"      yaml:"lines_total"`
+	BranchesCovered int     `bson:"branches_covered" json:"branches_covered" yaml:"branches_covered"`
+	BranchesTotal   int     `bson:"branches_total"   json:"branches_total"   yaml:"branches_total"`
+	LineRate        float64 `bson:"line_rate"        json:"line_rate"        yaml:"line_rate"`
+	BranchRate      float64 `bson:"branch_rate"      json:"branch_rate"      yaml:"branch_rate"`
+}
+
+// Report is a parsed coverage report with the per file coverage sorted by path
+type Report struct {
+	Summary `bson:",inline"  json:",inline"  yaml:",inline"`
+	Files   []*FileCoverage `bson:"files"     json:"files"     yaml:"files"`
+}
+
+// FileDelta is the line coverage change of a file compared to the base report
+type FileDelta struct {
+	Path         string  `json:"path"`
+	LineRate     float64 `json:"line_rate"`
+	BaseLineRate float64 `json:"base_line_rate"`
+	Delta        float64 `json:"delta"`
+	// New is true if the file is not in the base report
+	New bool `json:"new"`
+}
+
+func (s *Summary) String() string {
+	if s.BranchesTotal == 0 {
+		return fmt.Sprintf("lines %.2f%% (%d/%d)", s.LineRate, s.LinesCovered, s.LinesTotal)
+	}
+	return fmt.Sprintf("lines %.2f%% (%d/%d), branches %.2f%% (%d/%d)", s.LineRate, s.LinesCovered, s.LinesTotal, s.BranchRate, s.BranchesCovered, s.BranchesTotal)
+}
+
+// GateFailures returns the reasons why the coverage does not pass the gate. minLineRate is the minimum line
+// coverage in percent, maxDrop is the max drop of the line coverage in percentage points compared to base,
+// zero disables the check and a nil base skips the drop check.
+func (s *Summary) GateFailures(base *Summary, minLineRate, maxDrop float64) []string {
+	reasons := make([]string, 0)
+	if minLineRate > 0 && s.LineRate < minLineRate {
+		reasons = append(reasons, fmt.Sprintf("line coverage %.2f%% is below %.2f%%", s.LineRate, minLineRate))
+	}
+	if maxDrop > 0 && base != nil {
+		if drop := round(base.LineRate - s.LineRate); drop > maxDrop {
+			reasons = append(reasons, fmt.Sprintf("line coverage dropped by %.2f%% from %.2f%%, more than %.2f%%", drop, base.LineRate, maxDrop))
+		}
+	}
+	return reasons
+}
+
+// Merge combines the reports of several files into one. A file found in more than one report is not merged line
+// by line, the entry with the most covered lines is kept.
+func Merge(reports ...*Report) *Report {
+	files := make(map[string]*FileCoverage)
+	var format Format
+	for i, report := range reports {
+		if i == 0 {
+			format = report.Format
+		} else if format != report.Format {
+			format = ""
+		}
+		for _, file := range report.Files {
+			if existed, ok := files[file.Path]; !ok || file.LinesCovered > existed.LinesCovered {
+				files[file.Path] = file
+			}
+		}
+	}
+	return newReport(format, files)
+}
+
+// DiffFiles returns the files whose line coverage differs from base, the largest changes first
+func DiffFiles(head, base *Report) []*FileDelta {
+	baseFiles := make(map[string]*FileCoverage)
+	if base != nil {
+		for _, file := range base.Files {
+			baseFiles[file.Path] = file
+		}
+	}
+	deltas := make([]*FileDelta, 0)
+	for _, file := range head.Files {
+		delta := &FileDelta{Path: file.Path, LineRate: file.LineRate}
+		if baseFile, ok := baseFiles[file.Path]; ok {
+			delta.BaseLineRate = baseFile.LineRate
+		} else {
+			delta.New = true
+		}
+		delta.Delta = round(delta.LineRate - delta.BaseLineRate)
+		if delta.Delta == 0 && !delta.New {
+			continue
+		}
+		deltas = append(deltas, delta)
+	}
+	sort.SliceStable(deltas, func(i, j int) bool {
+		return math.Abs(deltas[i].Delta) > math.Abs(deltas[j].Delta)
+	})
+	return deltas
+}
+
+func newReport(format Format, files map[string]*FileCoverage) *Report {
+	report := &Report{Summary: Summary{Format: format}, Files: make([]*FileCoverage, 0, len(files))}
+	for _, file := range files {
+		file.LineRate = rate(file.LinesCovered, file.LinesTotal)
+		file.BranchRate = rate(file.BranchesCovered, file.BranchesTotal)
+		report.Files = append(report.Files, file)
+		report.LinesCovered += file.LinesCovered
+		report.LinesTotal += file.LinesTotal
+		report.BranchesCovered += file.BranchesCovered
+		report.BranchesTotal += file.BranchesTotal
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Path < report.Files[j].Path
+	})
+	report.FileCount = len(report.Files)
+	report.LineRate = rate(report.LinesCovered, report.LinesTotal)
+	report.BranchRate = rate(report.BranchesCovered, report.BranchesTotal)
+	return report
+}
+
+func rate(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(covered) / float64(total) * 100)
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
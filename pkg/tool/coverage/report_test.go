@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coverage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCoberturaReport = `<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.6" branch-rate="0.5" version="1.9">
+  <packages>
+    <package name="app">
+      <classes>
+        <class name="app.Main" filename="app/main.py">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="0"/>
+            <line number="3" hits="4" branch="true" condition-coverage="50% (1/2)"/>
+          </lines>
+        </class>
+        <class name="app.Main$Inner" filename="app/main.py">
+          <lines>
+            <line number="2" hits="2"/>
+          </lines>
+        </class>
+        <class name="app.Util" filename="app/util.py">
+          <lines>
+            <line number="1" hits="0"/>
+            <line number="2" hits="0"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`
+
+const testJaCoCoReport = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="demo">
+  <group name="service">
+    <package name="com/demo">
+      <sourcefile name="App.java">
+        <counter type="INSTRUCTION" missed="3" covered="30"/>
+        <counter type="BRANCH" missed="1" covered="3"/>
+        <counter type="LINE" missed="2" covered="8"/>
+      </sourcefile>
+    </package>
+  </group>
+  <package name="com/demo/util">
+    <sourcefile name="Strings.java">
+      <counter type="LINE" missed="5" covered="5"/>
+    </sourcefile>
+  </package>
+</report>`
+
+const testLCOVReport = `TN:
+SF:src/index.js
+DA:1,1
+DA:2,0
+DA:3,5
+BRDA:3,0,0,1
+BRDA:3,0,1,-
+LF:3
+LH:2
+end_of_record
+SF:src/summary-only.js
+LF:10
+LH:4
+BRF:2
+BRH:2
+end_of_record
+`
+
+const testGoProfile = `mode: set
+github.com/demo/app/main.go:10.13,12.2 2 1
+github.com/demo/app/main.go:14.20,16.2 3 0
+github.com/demo/app/util.go:5.10,7.2 1 0
+mode: set
+github.com/demo/app/util.go:5.10,7.2 1 1
+`
+
+func TestDetect(t *testing.T) {
+	for data, expected := range map[string]Format{
+		testCoberturaReport: FormatCobertura,
+		testJaCoCoReport:    FormatJaCoCo,
+		testLCOVReport:      FormatLCOV,
+		testGoProfile:       FormatGo,
+	} {
+		format, err := Detect([]byte(data))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+	_, err := Detect([]byte(`{"coverage": 1}`))
+	assert.Error(t, err)
+}
+
+func TestParseCobertura(t *testing.T) {
+	report, err := Parse(FormatCobertura, []byte(testCoberturaReport))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.FileCount)
+	assert.Equal(t, &FileCoverage{Path: "app/main.py", LinesCovered: 3, LinesTotal: 3, BranchesCovered: 1, BranchesTotal: 2, LineRate: 100, BranchRate: 50}, report.Files[0])
+	assert.Equal(t, 3, report.LinesCovered)
+	assert.Equal(t, 5, report.LinesTotal)
+	assert.Equal(t, 60.0, report.LineRate)
+}
+
+func TestParseJaCoCo(t *testing.T) {
+	report, err := Parse("", []byte(testJaCoCoReport))
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJaCoCo, report.Format)
+	assert.Equal(t, []*FileCoverage{
+		{Path: "com/demo/App.java", LinesCovered: 8, LinesTotal: 10, BranchesCovered: 3, BranchesTotal: 4, LineRate: 80, BranchRate: 75},
+		{Path: "com/demo/util/Strings.java", LinesCovered: 5, LinesTotal: 10, LineRate: 50},
+	}, report.Files)
+	assert.Equal(t, 65.0, report.LineRate)
+}
+
+func TestParseLCOV(t *testing.T) {
+	report, err := Parse(FormatLCOV, []byte(testLCOVReport))
+	assert.NoError(t, err)
+	assert.Equal(t, []*FileCoverage{
+		{Path: "src/index.js", LinesCovered: 2, LinesTotal: 3, BranchesCovered: 1, BranchesTotal: 2, LineRate: 66.67, BranchRate: 50},
+		{Path: "src/summary-only.js", LinesCovered: 4, LinesTotal: 10, BranchesCovered: 2, BranchesTotal: 2, LineRate: 40, BranchRate: 100},
+	}, report.Files)
+	assert.Equal(t, 3, report.BranchesCovered)
+}
+
+func TestParseGoProfile(t *testing.T) {
+	report, err := Parse(FormatGo, []byte(testGoProfile))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.LinesCovered)
+	assert.Equal(t, 6, report.LinesTotal)
+	assert.Equal(t, 50.0, report.LineRate)
+	assert.Equal(t, 100.0, report.Files[1].LineRate)
+
+	_, err = Parse(FormatGo, []byte("mode: set\nbroken line\n"))
+	assert.Error(t, err)
+}
+
+func TestGateFailures(t *testing.T) {
+	summary := &Summary{LineRate: 70}
+	assert.Empty(t, summary.GateFailures(nil, 0, 0))
+	assert.Empty(t, summary.GateFailures(&Summary{LineRate: 72}, 70, 2))
+	assert.Len(t, summary.GateFailures(nil, 80, 2), 1)
+	assert.Len(t, summary.GateFailures(&Summary{LineRate: 75}, 80, 2), 2)
+}
+
+func TestMergeAndDiffFiles(t *testing.T) {
+	head := Merge(
+		&Report{Summary: Summary{Format: FormatGo}, Files: []*FileCoverage{{Path: "a.go", LinesCovered: 1, LinesTotal: 4}}},
+		&Report{Summary: Summary{Format: FormatGo}, Files: []*FileCoverage{{Path: "a.go", LinesCovered: 3, LinesTotal: 4}, {Path: "b.go", LinesCovered: 1, LinesTotal: 1}}},
+	)
+	assert.Equal(t, FormatGo, head.Format)
+	assert.Equal(t, 4, head.LinesCovered)
+	assert.Equal(t, 80.0, head.LineRate)
+
+	base := &Report{Files: []*FileCoverage{{Path: "a.go", LineRate: 100}, {Path: "b.go", LineRate: 100}}}
+	assert.Equal(t, []*FileDelta{
+		{Path: "a.go", LineRate: 75, BaseLineRate: 100, Delta: -25},
+	}, DiffFiles(head, base))
+	assert.Len(t, DiffFiles(head, nil), 2)
+}
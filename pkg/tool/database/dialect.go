@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+// ConnInfo is the connection information of a db instance.
+type ConnInfo struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	// Database is optional for mysql, postgresql will use the default database "postgres" if it is empty
+	Database string
+	// SSLMode and SSLRootCert (PEM encoded CA) are only used by postgresql, the sslmode defaults to disable
+	SSLMode     string
+	SSLRootCert string
+}
+
+// Dialect abstracts the differences between database engines, such as driver, dsn, sql validation and error reporting.
+// New engines can be supported by implementing this interface and calling RegisterDialect.
+type Dialect interface {
+	// Type returns the db instance type this dialect serves
+	Type() config.DBInstanceType
+	// DriverName returns the database/sql driver name
+	DriverName() string
+	// DSN returns the data source name used to open a connection
+	DSN(info *ConnInfo) string
+	// Validate checks the syntax of the given sql script
+	Validate(sql string) error
+	// Split splits the given sql script into statements according to the lexical rules of the dialect
+	Split(sql string) ([]string, error)
+	// Explain returns the statement used to show the execution plan of the given statement,
+	// the second return value is false if the statement cannot be explained, e.g. DDL statements
+	Explain(stmt string) (string, bool)
+	// ImplicitCommit reports whether the statement commits the current transaction implicitly,
+	// such statements cannot be rolled back, e.g. DDL statements in mysql
+	ImplicitCommit(stmt string) bool
+	// Placeholder returns the bind parameter placeholder of the n-th (starting from 1) argument
+	Placeholder(n int) string
+	// ErrorCode extracts the engine specific error code from an execution error, returns an empty string if not found
+	ErrorCode(err error) string
+}
+
+var (
+	dialectMu sync.RWMutex
+	dialects  = make(map[config.DBInstanceType]Dialect)
+)
+
+func init() {
+	RegisterDialect(&mysqlDialect{dbType: config.DBInstanceTypeMySQL})
+	RegisterDialect(&mysqlDialect{dbType: config.DBInstanceTypeMariaDB})
+	RegisterDialect(&postgresqlDialect{})
+}
+
+// RegisterDialect registers a dialect, an existing dialect with the same type will be replaced.
+func RegisterDialect(d Dialect) {
+	dialectMu.Lock()
+	defer dialectMu.Unlock()
+	dialects[d.Type()] = d
+}
+
+func GetDialect(dbType config.DBInstanceType) (Dialect, error) {
+	dialectMu.RLock()
+	defer dialectMu.RUnlock()
+	d, ok := dialects[dbType]
+	if !ok {
+		return nil, fmt.Errorf("not supported db type: %s", dbType)
+	}
+	return d, nil
+}
+
+func IsSupported(dbType config.DBInstanceType) bool {
+	_, err := GetDialect(dbType)
+	return err == nil
+}
+
+// Open opens a connection pool to the db instance with the dialect of the given type.
+func Open(dbType config.DBInstanceType, info *ConnInfo) (*sql.DB, Dialect, error) {
+	d, err := GetDialect(dbType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := sql.Open(d.DriverName(), d.DSN(info))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect %s failed, err: %s", dbType, err)
+	}
+	return db, d, nil
+}
+
+// Ping checks the connectivity of the db instance.
+func Ping(dbType config.DBInstanceType, info *ConnInfo) error {
+	db, _, err := Open(dbType, info)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err = db.Ping(); err != nil {
+		return fmt.Errorf("ping %s failed, err: %s", dbType, err)
+	}
+	return nil
+}
+
+// leadingKeyword returns the first keyword of the statement in upper case, leading comments and parentheses are skipped.
+func leadingKeyword(stmt string) string {
+	for {
+		stmt = strings.TrimLeft(stmt, " \t\r\n\f\v(")
+		switch {
+		case strings.HasPrefix(stmt, "--"), strings.HasPrefix(stmt, "#"):
+			idx := strings.IndexByte(stmt, '\n')
+			if idx < 0 {
+				return ""
+			}
+			stmt = stmt[idx+1:]
+		case strings.HasPrefix(stmt, "/*"):
+			idx := strings.Index(stmt, "*/")
+			if idx < 0 {
+				return ""
+			}
+			stmt = stmt[idx+2:]
+		default:
+			end := 0
+			for end < len(stmt) && isIdentChar(stmt[end]) {
+				end++
+			}
+			return strings.ToUpper(stmt[:end])
+		}
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+const DefaultMigrationHistoryTable = "zadig_schema_history"
+
+var (
+	// flyway style: V1.2__add_users.sql for up and U1.2__add_users.sql for down
+	flywayMigrationRegexp = regexp.MustCompile(`^([VU])([0-9]+(?:[._][0-9]+)*)__(.+)\.sql$`)
+	// golang-migrate style: 0001_add_users.up.sql and 0001_add_users.down.sql
+	migrateMigrationRegexp = regexp.MustCompile(`^([0-9]+)_(.+)\.(up|down)\.sql$`)
+	historyTableRegexp     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+)
+
+// Migration is a versioned schema migration parsed from the migration files.
+type Migration struct {
+	Version     string
+	Description string
+	UpFile      string
+	UpSQL       string
+	DownFile    string
+	DownSQL     string
+	// Checksum is the sha256 of the up script, it is used to detect the drift of applied migrations
+	Checksum string
+}
+
+// AppliedMigration is a row of the migration history table.
+type AppliedMigration struct {
+	Version       string
+	Description   string
+	Checksum      string
+	InstalledBy   string
+	InstalledAt   int64
+	ExecutionTime int64
+}
+
+// ParseMigrations parses migration files keyed by file path, files that do not follow the flyway or golang-migrate
+// naming convention are ignored. The result is sorted by version in ascending order.
+func ParseMigrations(files map[string]string) ([]*Migration, error) {
+	migrationMap := make(map[string]*Migration)
+	get := func(version, description string) *Migration {
+		m, ok := migrationMap[version]
+		if !ok {
+			m = &Migration{Version: version, Description: description}
+			migrationMap[version] = m
+		}
+		return m
+	}
+
+	for filePath, content := range files {
+		name := path.Base(filePath)
+
+		var (
+			version, description string
+			isUp                 bool
+		)
+		if match := flywayMigrationRegexp.FindStringSubmatch(name); match != nil {
+			version = strings.ReplaceAll(match[2], "_", ".")
+			description = strings.ReplaceAll(match[3], "_", " ")
+			isUp = match[1] == "V"
+		} else if match := migrateMigrationRegexp.FindStringSubmatch(name); match != nil {
+			version = strings.TrimLeft(match[1], "0")
+			if version == "" {
+				version = "0"
+			}
+			description = strings.ReplaceAll(match[2], "_", " ")
+			isUp = match[3] == "up"
+		} else {
+			continue
+		}
+
+		m := get(version, description)
+		if isUp {
+			if m.UpFile != "" {
+				return nil, fmt.Errorf("duplicated migration version %s: %s and %s", version, m.UpFile, filePath)
+			}
+			m.UpFile = filePath
+			m.UpSQL = content
+			m.Description = description
+			m.Checksum = Checksum(content)
+		} else {
+			if m.DownFile != "" {
+				return nil, fmt.Errorf("duplicated rollback migration version %s: %s and %s", version, m.DownFile, filePath)
+			}
+			m.DownFile = filePath
+			m.DownSQL = content
+		}
+	}
+
+	resp := make([]*Migration, 0, len(migrationMap))
+	for _, m := range migrationMap {
+		if m.UpFile == "" {
+			return nil, fmt.Errorf("migration version %s has a rollback script %s but no migration script", m.Version, m.DownFile)
+		}
+		resp = append(resp, m)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return CompareVersions(resp[i].Version, resp[j].Version) < 0
+	})
+	return resp, nil
+}
+
+// MigrationInfo is the state of a migration version, either from the migration files, the history table or both.
+type MigrationInfo struct {
+	Version   string
+	Migration *Migration
+	Applied   *AppliedMigration
+	Status    setting.SchemaMigrationStatus
+}
+
+// ResolveMigrations merges the migration files with the history table, the result is sorted by version in ascending order.
+func ResolveMigrations(migrations []*Migration, applied []*AppliedMigration) []*MigrationInfo {
+	infoMap := make(map[string]*MigrationInfo)
+	for _, m := range migrations {
+		infoMap[m.Version] = &MigrationInfo{Version: m.Version, Migration: m, Status: setting.SchemaMigrationStatusPending}
+	}
+	for _, a := range applied {
+		info, ok := infoMap[a.Version]
+		if !ok {
+			infoMap[a.Version] = &MigrationInfo{Version: a.Version, Applied: a, Status: setting.SchemaMigrationStatusMissing}
+			continue
+		}
+		info.Applied = a
+		if info.Migration.Checksum != a.Checksum {
+			info.Status = setting.SchemaMigrationStatusDrifted
+		} else {
+			info.Status = setting.SchemaMigrationStatusApplied
+		}
+	}
+
+	resp := make([]*MigrationInfo, 0, len(infoMap))
+	for _, info := range infoMap {
+		resp = append(resp, info)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return CompareVersions(resp[i].Version, resp[j].Version) < 0
+	})
+	return resp
+}
+
+func Checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// CompareVersions compares two dot separated numeric versions such as "1.10" and "1.2".
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int64
+		if i < len(as) {
+			x, _ = strconv.ParseInt(as[i], 10, 64)
+		}
+		if i < len(bs) {
+			y, _ = strconv.ParseInt(bs[i], 10, 64)
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+func ValidateHistoryTable(table string) error {
+	if !historyTableRegexp.MatchString(table) {
+		return fmt.Errorf("invalid migration history table name: %s", table)
+	}
+	return nil
+}
+
+// Migrator applies and reverts migrations and keeps track of them in the history table.
+type Migrator struct {
+	db      *sql.DB
+	dialect Dialect
+	table   string
+}
+
+func NewMigrator(db *sql.DB, dialect Dialect, table string) (*Migrator, error) {
+	if table == "" {
+		table = DefaultMigrationHistoryTable
+	}
+	if err := ValidateHistoryTable(table); err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, table: table}, nil
+}
+
+func (m *Migrator) EnsureHistoryTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	version VARCHAR(64) NOT NULL PRIMARY KEY,
+	description VARCHAR(255) NOT NULL,
+	checksum VARCHAR(64) NOT NULL,
+	installed_by VARCHAR(255) NOT NULL,
+	installed_at BIGINT NOT NULL,
+	execution_time BIGINT NOT NULL
+)`, m.table))
+	if err != nil {
+		return fmt.Errorf("create migration history table %s error: %v", m.table, err)
+	}
+	return nil
+}
+
+// Applied lists the applied migrations sorted by version in ascending order.
+func (m *Migrator) Applied(ctx context.Context) ([]*AppliedMigration, error) {
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf("SELECT version, description, checksum, installed_by, installed_at, execution_time FROM %s", m.table))
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := make([]*AppliedMigration, 0)
+	for rows.Next() {
+		applied := &AppliedMigration{}
+		if err := rows.Scan(&applied.Version, &applied.Description, &applied.Checksum, &applied.InstalledBy, &applied.InstalledAt, &applied.ExecutionTime); err != nil {
+			return nil, fmt.Errorf("scan applied migration error: %v", err)
+		}
+		resp = append(resp, applied)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list applied migrations error: %v", err)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return CompareVersions(resp[i].Version, resp[j].Version) < 0
+	})
+	return resp, nil
+}
+
+// Apply runs the up script of the migration and records it in the history table within one transaction.
+// Note that mysql commits DDL statements implicitly, so a failed migration may be partially applied there.
+func (m *Migrator) Apply(ctx context.Context, migration *Migration, installedBy string, statementTimeout time.Duration) (int64, error) {
+	start := time.Now()
+	err := m.runInTx(ctx, migration.UpSQL, statementTimeout, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			fmt.Sprintf("INSERT INTO %s (version, description, checksum, installed_by, installed_at, execution_time) VALUES (%s)", m.table, m.placeholders(6)),
+			migration.Version, migration.Description, migration.Checksum, installedBy, time.Now().Unix(), time.Since(start).Milliseconds(),
+		)
+		return err
+	})
+	return time.Since(start).Milliseconds(), err
+}
+
+// Revert runs the down script of the migration and removes it from the history table within one transaction.
+func (m *Migrator) Revert(ctx context.Context, migration *Migration, statementTimeout time.Duration) (int64, error) {
+	start := time.Now()
+	if migration.DownFile == "" {
+		return 0, fmt.Errorf("migration version %s has no rollback script", migration.Version)
+	}
+	err := m.runInTx(ctx, migration.DownSQL, statementTimeout, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE version = %s", m.table, m.dialect.Placeholder(1)), migration.Version)
+		return err
+	})
+	return time.Since(start).Milliseconds(), err
+}
+
+func (m *Migrator) runInTx(ctx context.Context, script string, statementTimeout time.Duration, record func(tx *sql.Tx) error) error {
+	stmts, err := m.dialect.Split(script)
+	if err != nil {
+		return fmt.Errorf("parse migration script error: %v", err)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction error: %v", err)
+	}
+
+	for _, stmt := range stmts {
+		stmtCtx, cancel := ctx, context.CancelFunc(func() {})
+		if statementTimeout > 0 {
+			stmtCtx, cancel = context.WithTimeout(ctx, statementTimeout)
+		}
+		_, err := tx.ExecContext(stmtCtx, stmt)
+		cancel()
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("exec SQL \"%s\" error: %v", stmt, err)
+		}
+	}
+
+	if err := record(tx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("update migration history error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction error: %v", err)
+	}
+	return nil
+}
+
+func (m *Migrator) placeholders(n int) string {
+	resp := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		resp = append(resp, m.dialect.Placeholder(i))
+	}
+	return strings.Join(resp, ", ")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/setting"
+)
+
+func TestParseMigrations(t *testing.T) {
+	migrations, err := ParseMigrations(map[string]string{
+		"db/V1.10__add_index.sql":        "CREATE INDEX idx ON users (name);",
+		"db/V1.2__create_users.sql":      "CREATE TABLE users (id INT);",
+		"db/U1.2__create_users.sql":      "DROP TABLE users;",
+		"db/0003_add_orders.up.sql":      "CREATE TABLE orders (id INT);",
+		"db/0003_add_orders.down.sql":    "DROP TABLE orders;",
+		"db/README.md":                   "not a migration",
+		"db/seed.sql":                    "INSERT INTO users VALUES (1);",
+		"db/nested/V2_1__add_column.sql": "ALTER TABLE users ADD COLUMN age INT;",
+	})
+	assert.NoError(t, err)
+
+	versions := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	assert.Equal(t, []string{"1.2", "1.10", "2.1", "3"}, versions)
+
+	assert.Equal(t, "create users", migrations[0].Description)
+	assert.Equal(t, "DROP TABLE users;", migrations[0].DownSQL)
+	assert.Equal(t, Checksum("CREATE TABLE users (id INT);"), migrations[0].Checksum)
+	assert.Equal(t, "", migrations[1].DownFile)
+	assert.Equal(t, "db/0003_add_orders.down.sql", migrations[3].DownFile)
+
+	_, err = ParseMigrations(map[string]string{
+		"V1__a.sql":     "SELECT 1;",
+		"0001_b.up.sql": "SELECT 2;",
+	})
+	assert.Error(t, err)
+
+	_, err = ParseMigrations(map[string]string{
+		"U1__a.sql": "SELECT 1;",
+	})
+	assert.Error(t, err)
+}
+
+func TestResolveMigrations(t *testing.T) {
+	migrations := []*Migration{
+		{Version: "1", Checksum: Checksum("a")},
+		{Version: "2", Checksum: Checksum("b")},
+		{Version: "3", Checksum: Checksum("c")},
+	}
+	applied := []*AppliedMigration{
+		{Version: "0", Checksum: Checksum("z")},
+		{Version: "1", Checksum: Checksum("a")},
+		{Version: "2", Checksum: Checksum("changed")},
+	}
+
+	infos := ResolveMigrations(migrations, applied)
+	statuses := make(map[string]setting.SchemaMigrationStatus)
+	versions := make([]string, 0, len(infos))
+	for _, info := range infos {
+		statuses[info.Version] = info.Status
+		versions = append(versions, info.Version)
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3"}, versions)
+	assert.Equal(t, setting.SchemaMigrationStatusMissing, statuses["0"])
+	assert.Equal(t, setting.SchemaMigrationStatusApplied, statuses["1"])
+	assert.Equal(t, setting.SchemaMigrationStatusDrifted, statuses["2"])
+	assert.Equal(t, setting.SchemaMigrationStatusPending, statuses["3"])
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/tidb/parser"
+	_ "github.com/pingcap/tidb/parser/test_driver"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+// mysqlDialect serves both mysql and mariadb since they share the same protocol
+type mysqlDialect struct {
+	dbType config.DBInstanceType
+}
+
+func (d *mysqlDialect) Type() config.DBInstanceType {
+	return d.dbType
+}
+
+func (d *mysqlDialect) DriverName() string {
+	return "mysql"
+}
+
+func (d *mysqlDialect) DSN(info *ConnInfo) string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local", info.Username, info.Password, info.Host, info.Port, info.Database)
+}
+
+func (d *mysqlDialect) Validate(sql string) error {
+	// the tidb parser does not understand DELIMITER, so the script is split first and each statement is parsed on its own
+	stmts, err := d.Split(sql)
+	if err != nil {
+		return err
+	}
+
+	p := parser.New()
+	for _, stmt := range stmts {
+		if _, _, err := p.Parse(stmt, "", ""); err != nil {
+			return fmt.Errorf("parse sql statement error: %v", err)
+		}
+	}
+	return nil
+}
+
+func (d *mysqlDialect) Split(sql string) ([]string, error) {
+	return splitStatements(sql, splitOptions{
+		hashComment:           true,
+		dashCommentNeedsSpace: true,
+		backtickQuote:         true,
+		backslashEscape:       true,
+		delimiterDirective:    true,
+	})
+}
+
+func (d *mysqlDialect) Explain(stmt string) (string, bool) {
+	switch leadingKeyword(stmt) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "REPLACE", "WITH", "TABLE":
+		return "EXPLAIN " + stmt, true
+	default:
+		return "", false
+	}
+}
+
+func (d *mysqlDialect) ImplicitCommit(stmt string) bool {
+	switch leadingKeyword(stmt) {
+	case "CREATE", "ALTER", "DROP", "RENAME", "TRUNCATE", "GRANT", "REVOKE", "LOCK", "UNLOCK",
+		"ANALYZE", "OPTIMIZE", "REPAIR", "CACHE", "FLUSH", "RESET", "INSTALL", "UNINSTALL":
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (d *mysqlDialect) ErrorCode(err error) string {
+	mysqlErr := &mysql.MySQLError{}
+	if errors.As(err, &mysqlErr) {
+		return strconv.Itoa(int(mysqlErr.Number))
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/lib/pq"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+const (
+	defaultPostgreSQLDatabase = "postgres"
+	defaultPostgreSQLSSLMode  = "disable"
+)
+
+// postgresqlSSLModes are the sslmode values supported by the driver
+var postgresqlSSLModes = map[string]bool{
+	"disable":     true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+// ValidatePostgreSQLSSLMode checks the sslmode of a postgresql instance, an empty sslmode means disable
+func ValidatePostgreSQLSSLMode(sslMode string) error {
+	if sslMode != "" && !postgresqlSSLModes[sslMode] {
+		return fmt.Errorf("unsupported postgresql sslmode %s", sslMode)
+	}
+	return nil
+}
+
+type postgresqlDialect struct{}
+
+func (d *postgresqlDialect) Type() config.DBInstanceType {
+	return config.DBInstanceTypePostgreSQL
+}
+
+func (d *postgresqlDialect) DriverName() string {
+	return "postgres"
+}
+
+func (d *postgresqlDialect) DSN(info *ConnInfo) string {
+	database := info.Database
+	if database == "" {
+		database = defaultPostgreSQLDatabase
+	}
+
+	sslMode := info.SSLMode
+	if sslMode == "" {
+		sslMode = defaultPostgreSQLSSLMode
+	}
+	query := url.Values{}
+	query.Set("sslmode", sslMode)
+	if info.SSLRootCert != "" {
+		// the CA is stored in the integration instead of a file, sslinline makes the driver read it as PEM data
+		query.Set("sslrootcert", info.SSLRootCert)
+		query.Set("sslinline", "true")
+	}
+
+	u := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(info.Username, info.Password),
+		Host:     net.JoinHostPort(info.Host, info.Port),
+		Path:     "/" + database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// Validate only checks that the script can be tokenized since there is no postgresql parser available,
+// syntax errors will be reported by the server when the statement is executed.
+func (d *postgresqlDialect) Validate(sql string) error {
+	stmts, err := d.Split(sql)
+	if err != nil {
+		return fmt.Errorf("parse sql statement error: %v", err)
+	}
+	if len(stmts) == 0 {
+		return fmt.Errorf("sql statement is empty")
+	}
+	return nil
+}
+
+func (d *postgresqlDialect) Split(sql string) ([]string, error) {
+	return splitStatements(sql, splitOptions{
+		dollarQuote:        true,
+		nestedBlockComment: true,
+	})
+}
+
+func (d *postgresqlDialect) Explain(stmt string) (string, bool) {
+	switch leadingKeyword(stmt) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE", "VALUES", "WITH", "TABLE":
+		return "EXPLAIN " + stmt, true
+	default:
+		return "", false
+	}
+}
+
+// ImplicitCommit always returns false since DDL statements are transactional in postgresql
+func (d *postgresqlDialect) ImplicitCommit(stmt string) bool {
+	return false
+}
+
+func (d *postgresqlDialect) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (d *postgresqlDialect) ErrorCode(err error) string {
+	pqErr := &pq.Error{}
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
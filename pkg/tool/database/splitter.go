@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultDelimiter = ";"
+
+// splitOptions describes the lexical rules of a dialect which affect how statements are separated.
+type splitOptions struct {
+	// hashComment treats "#" as the start of a single line comment
+	hashComment bool
+	// dashCommentNeedsSpace requires "--" to be followed by a whitespace to start a comment, as mysql does
+	dashCommentNeedsSpace bool
+	// backtickQuote treats "`" as an identifier quote
+	backtickQuote bool
+	// backslashEscape allows "\" to escape the next character in a quoted string
+	backslashEscape bool
+	// dollarQuote enables $$...$$ and $tag$...$tag$ quoted bodies
+	dollarQuote bool
+	// nestedBlockComment allows /* */ comments to be nested
+	nestedBlockComment bool
+	// delimiterDirective enables the client side DELIMITER directive, which is used to define procedures and triggers
+	delimiterDirective bool
+}
+
+// splitStatements splits a sql script into statements. Unlike a plain split on ";", delimiters inside quoted
+// strings, quoted identifiers, comments and dollar quoted bodies are ignored. Fragments that contain nothing
+// but whitespaces and comments are dropped, and the delimiter is not included in the returned statements.
+func splitStatements(sql string, opts splitOptions) ([]string, error) {
+	var (
+		stmts      []string
+		buf        strings.Builder
+		hasContent bool
+		delimiter  = defaultDelimiter
+	)
+
+	flush := func() {
+		if hasContent {
+			stmts = append(stmts, strings.TrimSpace(buf.String()))
+		}
+		buf.Reset()
+		hasContent = false
+	}
+
+	n := len(sql)
+	for i := 0; i < n; {
+		c := sql[i]
+
+		if opts.delimiterDirective && !hasContent && isDelimiterDirective(sql, i) {
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = n
+			} else {
+				end += i
+			}
+			newDelimiter := strings.TrimSpace(sql[i+len("DELIMITER") : end])
+			if newDelimiter == "" {
+				return nil, fmt.Errorf("empty delimiter at offset %d", i)
+			}
+			delimiter = newDelimiter
+			buf.Reset()
+			i = end
+			continue
+		}
+
+		if strings.HasPrefix(sql[i:], delimiter) {
+			flush()
+			i += len(delimiter)
+			continue
+		}
+
+		var (
+			end       int
+			err       error
+			isContent bool
+		)
+		switch {
+		case c == '\'' || c == '"':
+			end, err = skipQuoted(sql, i, c, opts.backslashEscape)
+			isContent = true
+		case c == '`' && opts.backtickQuote:
+			end, err = skipQuoted(sql, i, c, false)
+			isContent = true
+		case isDashComment(sql, i, opts.dashCommentNeedsSpace), c == '#' && opts.hashComment:
+			end = strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = n
+			} else {
+				end += i
+			}
+		case c == '/' && i+1 < n && sql[i+1] == '*':
+			end, err = skipBlockComment(sql, i, opts.nestedBlockComment)
+		case c == '$' && opts.dollarQuote:
+			if tag := dollarQuoteTag(sql, i); tag != "" {
+				end, err = skipDollarQuoted(sql, i, tag)
+				isContent = true
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if end > i {
+			buf.WriteString(sql[i:end])
+			hasContent = hasContent || isContent
+			i = end
+			continue
+		}
+
+		if !isSpace(c) {
+			hasContent = true
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return stmts, nil
+}
+
+func isDashComment(sql string, i int, needsSpace bool) bool {
+	if !strings.HasPrefix(sql[i:], "--") {
+		return false
+	}
+	return !needsSpace || i+2 == len(sql) || isSpace(sql[i+2])
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// isDelimiterDirective checks whether a "DELIMITER xx" directive starts at offset i, the directive must be the
+// first token of its line.
+func isDelimiterDirective(sql string, i int) bool {
+	const keyword = "DELIMITER"
+	if len(sql)-i <= len(keyword) || !strings.EqualFold(sql[i:i+len(keyword)], keyword) || !isSpace(sql[i+len(keyword)]) {
+		return false
+	}
+	for j := i - 1; j >= 0 && sql[j] != '\n'; j-- {
+		if !isSpace(sql[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// skipQuoted returns the offset right after the closing quote of the quoted string starting at offset start.
+// A doubled quote is treated as an escaped quote.
+func skipQuoted(sql string, start int, quote byte, backslashEscape bool) (int, error) {
+	for i := start + 1; i < len(sql); i++ {
+		c := sql[i]
+		if backslashEscape && c == '\\' {
+			i++
+			continue
+		}
+		if c == quote {
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quoted string starting at offset %d", start)
+}
+
+func skipBlockComment(sql string, start int, nested bool) (int, error) {
+	depth := 0
+	for i := start; i+1 < len(sql); i++ {
+		switch {
+		case sql[i] == '/' && sql[i+1] == '*':
+			if depth == 0 || nested {
+				depth++
+			}
+			i++
+		case sql[i] == '*' && sql[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unterminated comment starting at offset %d", start)
+}
+
+// dollarQuoteTag returns the dollar quote tag such as "$$" or "$body$" starting at offset i, or an empty string
+// if there is none, e.g. for positional parameters like $1.
+func dollarQuoteTag(sql string, i int) string {
+	if i > 0 && (isIdentChar(sql[i-1]) || sql[i-1] == '$') {
+		return ""
+	}
+	j := i + 1
+	if j < len(sql) && isIdentStart(sql[j]) {
+		for j < len(sql) && isIdentChar(sql[j]) {
+			j++
+		}
+	}
+	if j < len(sql) && sql[j] == '$' {
+		return sql[i : j+1]
+	}
+	return ""
+}
+
+func skipDollarQuoted(sql string, start int, tag string) (int, error) {
+	end := strings.Index(sql[start+len(tag):], tag)
+	if end < 0 {
+		return 0, fmt.Errorf("unterminated dollar quoted string %s starting at offset %d", tag, start)
+	}
+	return start + len(tag) + end + len(tag), nil
+}
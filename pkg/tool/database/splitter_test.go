@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koderover/zadig/v2/pkg/microservice/aslan/config"
+)
+
+func TestMySQLSplit(t *testing.T) {
+	d, err := GetDialect(config.DBInstanceTypeMySQL)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "simple statements",
+			sql:  "CREATE TABLE t (id INT);\nINSERT INTO t VALUES (1);",
+			want: []string{"CREATE TABLE t (id INT)", "INSERT INTO t VALUES (1)"},
+		},
+		{
+			name: "semicolons in literals and identifiers",
+			sql:  "INSERT INTO t VALUES ('a;b', \"c;d\", 'it''s; ok', 'e\\';f');SELECT `x;y` FROM t",
+			want: []string{"INSERT INTO t VALUES ('a;b', \"c;d\", 'it''s; ok', 'e\\';f')", "SELECT `x;y` FROM t"},
+		},
+		{
+			name: "comments",
+			sql:  "-- drop; it\n# another; comment\nSELECT 1; /* a; b */ SELECT 2;\n-- trailing;",
+			want: []string{"-- drop; it\n# another; comment\nSELECT 1", "/* a; b */ SELECT 2"},
+		},
+		{
+			name: "double dash without space is not a comment",
+			sql:  "SELECT 1--1; SELECT 2",
+			want: []string{"SELECT 1--1", "SELECT 2"},
+		},
+		{
+			name: "delimiter directive",
+			sql:  "DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END//\nDELIMITER ;\nCALL p();",
+			want: []string{"CREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END", "CALL p()"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := d.Split(tc.sql)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err = d.Split("SELECT 'unterminated;")
+	assert.Error(t, err)
+}
+
+func TestPostgreSQLSplit(t *testing.T) {
+	d, err := GetDialect(config.DBInstanceTypePostgreSQL)
+	assert.NoError(t, err)
+
+	got, err := d.Split(`CREATE FUNCTION f() RETURNS trigger AS $body$
+BEGIN
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql;
+/* outer /* nested; */ still comment; */
+SELECT $$a;b$$, $1;`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE FUNCTION f() RETURNS trigger AS $body$\nBEGIN\n  NEW.updated_at := now();\n  RETURN NEW;\nEND;\n$body$ LANGUAGE plpgsql",
+		"/* outer /* nested; */ still comment; */\nSELECT $$a;b$$, $1",
+	}, got)
+
+	_, err = d.Split("SELECT $tag$ never closed;")
+	assert.Error(t, err)
+}
+
+func TestExplain(t *testing.T) {
+	d, err := GetDialect(config.DBInstanceTypePostgreSQL)
+	assert.NoError(t, err)
+
+	stmt, ok := d.Explain("-- comment\nUPDATE t SET a = 1")
+	assert.True(t, ok)
+	assert.Equal(t, "EXPLAIN -- comment\nUPDATE t SET a = 1", stmt)
+
+	_, ok = d.Explain("ALTER TABLE t ADD COLUMN b INT")
+	assert.False(t, ok)
+}
+
+func TestPostgreSQLDSN(t *testing.T) {
+	d, err := GetDialect(config.DBInstanceTypePostgreSQL)
+	assert.NoError(t, err)
+
+	info := &ConnInfo{Host: "127.0.0.1", Port: "5432", Username: "u", Password: "p"}
+	assert.Equal(t, "postgres://u:p@127.0.0.1:5432/postgres?sslmode=disable", d.DSN(info))
+
+	info.SSLMode = "verify-full"
+	info.SSLRootCert = "-----BEGIN CERTIFICATE-----"
+	assert.Equal(t, "postgres://u:p@127.0.0.1:5432/postgres?sslinline=true&sslmode=verify-full&sslrootcert=-----BEGIN+CERTIFICATE-----", d.DSN(info))
+
+	assert.NoError(t, ValidatePostgreSQLSSLMode(""))
+	assert.Error(t, ValidatePostgreSQLSSLMode("prefer-ssl"))
+}
+
+func TestMySQLValidate(t *testing.T) {
+	d, err := GetDialect(config.DBInstanceTypeMySQL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, d.Validate("DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END//\nDELIMITER ;\nCALL p();"))
+	assert.Error(t, d.Validate("SELECT 1; SELEC 2;"))
+
+	assert.True(t, d.ImplicitCommit("ALTER TABLE t ADD COLUMN c INT"))
+	assert.False(t, d.ImplicitCommit("UPDATE t SET c = 1"))
+}
@@ -567,6 +567,8 @@ var (
 	ErrFindDeliveryProducts  = NewHTTPError(6564, "查询交付中心产品列表失败")
 	ErrUpdateDeliveryVersion = NewHTTPError(6565, "更新交付中心版本失败")
 	ErrCheckDeliveryVersion  = NewHTTPError(6566, "检查交付中心版本失败")
+	ErrExportDeliveryVersion = NewHTTPError(6567, "导出交付中心版本失败")
+	ErrImportDeliveryVersion = NewHTTPError(6568, "导入交付中心版本失败")
 
 	//-----------------------------------------------------------------------------------------------
 	// delivery_build APIs Range: 6570 - 6579
@@ -1040,4 +1042,21 @@ var (
 	ErrDeleteAgentIntegration   = NewHTTPError(7203, "删除 Agent 集成失败")
 	ErrGetAgentIntegration      = NewHTTPError(7204, "获取 Agent 集成详情失败")
 	ErrValidateAgentIntegration = NewHTTPError(7205, "验证 Agent 集成失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// pod exec terminal errors: 7210 - 7219
+	//-----------------------------------------------------------------------------------------------
+	ErrOpenTerminal                = NewHTTPError(7210, "打开容器终端失败")
+	ErrCreateTerminalAccessRequest = NewHTTPError(7211, "创建容器终端访问申请失败")
+	ErrListTerminalAccessRequest   = NewHTTPError(7212, "获取容器终端访问申请列表失败")
+	ErrReviewTerminalAccessRequest = NewHTTPError(7213, "审批容器终端访问申请失败")
+	ErrListTerminalSession         = NewHTTPError(7214, "获取容器终端会话列表失败")
+	ErrGetTerminalRecording        = NewHTTPError(7215, "获取容器终端会话录像失败")
+
+	//-----------------------------------------------------------------------------------------------
+	// outbound webhook delivery errors: 7220 - 7229
+	//-----------------------------------------------------------------------------------------------
+	ErrListWebhookDelivery = NewHTTPError(7220, "获取 Webhook 投递记录失败")
+	ErrGetWebhookDelivery  = NewHTTPError(7221, "获取 Webhook 投递详情失败")
+	ErrRedeliverWebhook    = NewHTTPError(7222, "重新投递 Webhook 失败")
 )
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+const (
+	cloudHost       = "bitbucket.org"
+	cloudAPIAddress = "https://api.bitbucket.org/2.0"
+	serverAPIPrefix = "/rest/api/1.0"
+
+	// tokenUsername is the username bitbucket expects when an access token is used for git over https
+	tokenUsername = "x-token-auth"
+)
+
+type ListOptions struct {
+	// Page number of the results to fetch. Default: 1
+	Page int
+	// Results per page. Default: 100
+	PerPage int
+
+	// NoPaginated indicates if we need to fetch all result or just one page. True means fetching just one page
+	NoPaginated bool
+}
+
+// Client talks to both bitbucket cloud (api 2.0) and bitbucket data center (rest api 1.0), the flavor is decided
+// by the address of the code host. Namespace is the workspace for cloud and the project key for data center.
+type Client struct {
+	*httpclient.Client
+	Cloud bool
+}
+
+// NewClient creates a bitbucket client, username and password (app password) are used when password is set,
+// otherwise the access token is sent as a bearer token.
+func NewClient(address, username, password, accessToken, proxyAddr string, enableProxy, disableSSL bool) (*Client, error) {
+	cloud := IsCloud(address)
+	host := strings.TrimSuffix(address, "/") + serverAPIPrefix
+	if cloud {
+		host = cloudAPIAddress
+	}
+
+	cfs := []httpclient.ClientFunc{
+		httpclient.SetHostURL(host),
+		httpclient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: disableSSL}),
+	}
+	switch {
+	case password != "":
+		cfs = append(cfs, httpclient.SetBasicAuth(username, password))
+	case accessToken != "":
+		cfs = append(cfs, httpclient.SetAuthToken(accessToken))
+	default:
+		return nil, fmt.Errorf("either app password or access token is required for bitbucket")
+	}
+	if enableProxy {
+		cfs = append(cfs, httpclient.SetProxy(proxyAddr))
+	}
+
+	return &Client{Client: httpclient.New(cfs...), Cloud: cloud}, nil
+}
+
+// IsCloud returns true if the address points to bitbucket cloud instead of a self-hosted data center.
+func IsCloud(address string) bool {
+	u, err := url.Parse(address)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	return host == cloudHost || host == "api."+cloudHost
+}
+
+// CloneURL returns the https clone url without credentials.
+// e.g.
+// https://bitbucket.org/workspace/repo.git
+// https://bitbucket.example.com/scm/PROJECT/repo.git
+func CloneURL(address, namespace, name string) string {
+	if IsCloud(address) {
+		return fmt.Sprintf("https://%s/%s/%s.git", cloudHost, namespace, name)
+	}
+	return fmt.Sprintf("%s/scm/%s/%s.git", strings.TrimSuffix(address, "/"), namespace, name)
+}
+
+// CloneUserInfo returns the credentials used for git over https.
+func CloneUserInfo(username, password, accessToken string) *url.Userinfo {
+	if password != "" {
+		return url.UserPassword(username, password)
+	}
+	if username == "" {
+		username = tokenUsername
+	}
+	return url.UserPassword(username, accessToken)
+}
+
+func cloudRepoURL(namespace, repo string) string {
+	return fmt.Sprintf("/repositories/%s/%s", namespace, repo)
+}
+
+func serverRepoURL(namespace, repo string) string {
+	return fmt.Sprintf("/projects/%s/repos/%s", namespace, repo)
+}
+
+func (opts *ListOptions) pageAndSize() (int, int) {
+	page, perPage := 1, 100
+	if opts == nil {
+		return page, perPage
+	}
+	if opts.Page > 0 {
+		page = opts.Page
+	}
+	if opts.PerPage > 0 {
+		perPage = opts.PerPage
+	}
+	return page, perPage
+}
+
+type cloudPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// listCloud walks through the pages of a bitbucket cloud list api until there is no next page.
+func listCloud[T any](c *Client, url string, query map[string]string, opts *ListOptions) ([]T, error) {
+	page, perPage := opts.pageAndSize()
+	// pagelen of bitbucket cloud is capped at 100
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	var all []T
+	for {
+		params := map[string]string{
+			"page":    strconv.Itoa(page),
+			"pagelen": strconv.Itoa(perPage),
+		}
+		for k, v := range query {
+			params[k] = v
+		}
+
+		result := &cloudPage[T]{}
+		if _, err := c.Get(url, httpclient.SetQueryParams(params), httpclient.SetResult(result)); err != nil {
+			return nil, err
+		}
+		all = append(all, result.Values...)
+
+		if (opts != nil && opts.NoPaginated) || result.Next == "" {
+			return all, nil
+		}
+		page++
+	}
+}
+
+type serverPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// listServer walks through the pages of a bitbucket data center list api until the last page is returned.
+func listServer[T any](c *Client, url string, query map[string]string, opts *ListOptions) ([]T, error) {
+	page, perPage := opts.pageAndSize()
+	start := (page - 1) * perPage
+
+	var all []T
+	for {
+		params := map[string]string{
+			"start": strconv.Itoa(start),
+			"limit": strconv.Itoa(perPage),
+		}
+		for k, v := range query {
+			params[k] = v
+		}
+
+		result := &serverPage[T]{}
+		if _, err := c.Get(url, httpclient.SetQueryParams(params), httpclient.SetResult(result)); err != nil {
+			return nil, err
+		}
+		all = append(all, result.Values...)
+
+		if (opts != nil && opts.NoPaginated) || result.IsLastPage {
+			return all, nil
+		}
+		start = result.NextPageStart
+	}
+}
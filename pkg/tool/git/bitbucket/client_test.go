@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneURL(t *testing.T) {
+	assert.True(t, IsCloud("https://bitbucket.org"))
+	assert.True(t, IsCloud("https://www.bitbucket.org/"))
+	assert.False(t, IsCloud("https://bitbucket.example.com"))
+
+	assert.Equal(t, "https://bitbucket.org/team/repo.git", CloneURL("https://bitbucket.org/", "team", "repo"))
+	assert.Equal(t, "https://git.example.com/bitbucket/scm/PRJ/repo.git", CloneURL("https://git.example.com/bitbucket/", "PRJ", "repo"))
+
+	assert.Equal(t, "jane:app-password", CloneUserInfo("jane", "app-password", "token").String())
+	assert.Equal(t, "x-token-auth:token", CloneUserInfo("", "", "token").String())
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// EventKey is sent in the X-Event-Key header, cloud and data center use different keys for the same event.
+type EventKey string
+
+const (
+	EventKeyCloudPush               EventKey = "repo:push"
+	EventKeyCloudPullRequestCreated EventKey = "pullrequest:created"
+	EventKeyCloudPullRequestUpdated EventKey = "pullrequest:updated"
+
+	EventKeyServerRefsChanged               EventKey = "repo:refs_changed"
+	EventKeyServerPullRequestOpened         EventKey = "pr:opened"
+	EventKeyServerPullRequestFromRefUpdated EventKey = "pr:from_ref_updated"
+	// EventKeyServerPing is sent by the test connection button of data center
+	EventKeyServerPing EventKey = "diagnostics:ping"
+)
+
+const (
+	eventKeyHeader       = "X-Event-Key"
+	signatureHeader      = "X-Hub-Signature"
+	cloudDeliveryHeader  = "X-Request-UUID"
+	serverDeliveryHeader = "X-Request-Id"
+
+	signaturePrefix = "sha256="
+)
+
+// HookEventKey returns the event key for the given request.
+func HookEventKey(r *http.Request) EventKey {
+	return EventKey(r.Header.Get(eventKeyHeader))
+}
+
+// DeliveryID returns the unique id of the webhook delivery.
+func DeliveryID(r *http.Request) string {
+	if id := r.Header.Get(cloudDeliveryHeader); id != "" {
+		return id
+	}
+	return r.Header.Get(serverDeliveryHeader)
+}
+
+// ValidateSignature checks the HMAC-SHA256 signature of the payload sent in X-Hub-Signature header,
+// both cloud and data center sign the payload in the format of sha256=<hex digest>.
+func ValidateSignature(r *http.Request, payload []byte, secret string) error {
+	signature := r.Header.Get(signatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errors.New("missing signature")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(strings.TrimPrefix(signature, signaturePrefix)), []byte(expected)) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+// RefChange is a branch or tag changed by a push.
+type RefChange struct {
+	// Ref is the full ref name, e.g. refs/heads/main or refs/tags/v1.0.0
+	Ref      string
+	Name     string
+	IsTag    bool
+	FromHash string
+	ToHash   string
+	// Message and Author of the latest commit are only sent by cloud
+	Message string
+	Author  string
+	// Deleted marks if the ref is removed
+	Deleted bool
+}
+
+type PushEvent struct {
+	Namespace string
+	RepoName  string
+	Actor     string
+	Changes   []*RefChange
+}
+
+type PullRequestEvent struct {
+	EventKey    EventKey
+	Namespace   string
+	RepoName    string
+	Actor       string
+	PullRequest *PullRequest
+}
+
+// IsCloud returns true if the event is sent by bitbucket cloud.
+func (e *PullRequestEvent) IsCloud() bool {
+	return strings.HasPrefix(string(e.EventKey), "pullrequest:")
+}
+
+type cloudPushPayload struct {
+	Actor      *cloudUser       `json:"actor"`
+	Repository *cloudRepository `json:"repository"`
+	Push       struct {
+		Changes []struct {
+			New    *cloudRef `json:"new"`
+			Old    *cloudRef `json:"old"`
+			Closed bool      `json:"closed"`
+		} `json:"changes"`
+	} `json:"push"`
+}
+
+type cloudPullRequestPayload struct {
+	Actor       *cloudUser        `json:"actor"`
+	Repository  *cloudRepository  `json:"repository"`
+	PullRequest *cloudPullRequest `json:"pullrequest"`
+}
+
+type serverRefsChangedPayload struct {
+	Actor      *serverUser       `json:"actor"`
+	Repository *serverRepository `json:"repository"`
+	Changes    []struct {
+		Ref      *serverRef `json:"ref"`
+		FromHash string     `json:"fromHash"`
+		ToHash   string     `json:"toHash"`
+		Type     string     `json:"type"`
+	} `json:"changes"`
+}
+
+type serverPullRequestPayload struct {
+	Actor       *serverUser        `json:"actor"`
+	PullRequest *serverPullRequest `json:"pullRequest"`
+}
+
+// ParseHook parses the payload of cloud and data center into a *PushEvent or *PullRequestEvent.
+func ParseHook(eventKey EventKey, payload []byte) (interface{}, error) {
+	switch eventKey {
+	case EventKeyCloudPush:
+		p := &cloudPushPayload{}
+		if err := json.Unmarshal(payload, p); err != nil {
+			return nil, err
+		}
+		event := &PushEvent{}
+		event.Namespace, event.RepoName = p.Repository.split()
+		if p.Actor != nil {
+			event.Actor = p.Actor.DisplayName
+		}
+		for _, change := range p.Push.Changes {
+			ref := change.New
+			if ref == nil {
+				ref = change.Old
+			}
+			if ref == nil {
+				continue
+			}
+			rc := &RefChange{
+				Name:    ref.Name,
+				IsTag:   ref.Type == "tag" || ref.Type == "annotated_tag",
+				Deleted: change.Closed || change.New == nil,
+			}
+			rc.Ref = "refs/heads/" + ref.Name
+			if rc.IsTag {
+				rc.Ref = "refs/tags/" + ref.Name
+			}
+			if change.Old != nil && change.Old.Target != nil {
+				rc.FromHash = change.Old.Target.Hash
+			}
+			if change.New != nil && change.New.Target != nil {
+				rc.ToHash = change.New.Target.Hash
+				rc.Message = change.New.Target.Message
+				rc.Author = change.New.Target.Author.name()
+			}
+			event.Changes = append(event.Changes, rc)
+		}
+		return event, nil
+	case EventKeyCloudPullRequestCreated, EventKeyCloudPullRequestUpdated:
+		p := &cloudPullRequestPayload{}
+		if err := json.Unmarshal(payload, p); err != nil {
+			return nil, err
+		}
+		if p.PullRequest == nil {
+			return nil, errors.New("pull request is missing in the payload")
+		}
+		event := &PullRequestEvent{EventKey: eventKey, PullRequest: p.PullRequest.convert()}
+		event.Namespace, event.RepoName = p.Repository.split()
+		if p.Actor != nil {
+			event.Actor = p.Actor.DisplayName
+		}
+		return event, nil
+	case EventKeyServerRefsChanged:
+		p := &serverRefsChangedPayload{}
+		if err := json.Unmarshal(payload, p); err != nil {
+			return nil, err
+		}
+		event := &PushEvent{Namespace: p.Repository.namespace(), RepoName: p.Repository.slug(), Actor: p.Actor.name()}
+		for _, change := range p.Changes {
+			if change.Ref == nil {
+				continue
+			}
+			event.Changes = append(event.Changes, &RefChange{
+				Ref:      change.Ref.ID,
+				Name:     change.Ref.DisplayID,
+				IsTag:    change.Ref.Type == "TAG",
+				FromHash: change.FromHash,
+				ToHash:   change.ToHash,
+				Deleted:  change.Type == "DELETE",
+			})
+		}
+		return event, nil
+	case EventKeyServerPullRequestOpened, EventKeyServerPullRequestFromRefUpdated:
+		p := &serverPullRequestPayload{}
+		if err := json.Unmarshal(payload, p); err != nil {
+			return nil, err
+		}
+		if p.PullRequest == nil || p.PullRequest.ToRef == nil {
+			return nil, errors.New("pull request is missing in the payload")
+		}
+		return &PullRequestEvent{
+			EventKey:    eventKey,
+			Namespace:   p.PullRequest.ToRef.Repository.namespace(),
+			RepoName:    p.PullRequest.ToRef.Repository.slug(),
+			Actor:       p.Actor.name(),
+			PullRequest: p.PullRequest.convert(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected event key: %s", eventKey)
+}
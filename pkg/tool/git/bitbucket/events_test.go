@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"eventKey":"repo:refs_changed"}`)
+	req := &http.Request{Header: http.Header{}}
+
+	assert.Error(t, ValidateSignature(req, payload, "secret"))
+
+	req.Header.Set(signatureHeader, "sha256=48d95a2d170cb5315b6de0d267c789afc728b27149c2d67ca9b1033f61a50d00")
+	assert.NoError(t, ValidateSignature(req, payload, "secret"))
+	assert.Error(t, ValidateSignature(req, payload, "another"))
+
+	req.Header.Set(signatureHeader, "48d95a2d170cb5315b6de0d267c789afc728b27149c2d67ca9b1033f61a50d00")
+	assert.Error(t, ValidateSignature(req, payload, "secret"))
+}
+
+func TestParseCloudHook(t *testing.T) {
+	event, err := ParseHook(EventKeyCloudPush, []byte(`{
+		"actor": {"display_name": "Jane"},
+		"repository": {"full_name": "team/repo", "name": "Repo"},
+		"push": {"changes": [
+			{"new": {"type": "branch", "name": "main", "target": {"hash": "bbb", "message": "fix", "author": {"raw": "Jane <jane@example.com>"}}},
+			 "old": {"type": "branch", "name": "main", "target": {"hash": "aaa"}}},
+			{"new": {"type": "tag", "name": "v1.0.0", "target": {"hash": "ccc"}}, "old": null},
+			{"new": null, "old": {"type": "branch", "name": "feature", "target": {"hash": "ddd"}}, "closed": true}
+		]}
+	}`))
+	assert.NoError(t, err)
+	push, ok := event.(*PushEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "team", push.Namespace)
+	assert.Equal(t, "repo", push.RepoName)
+	assert.Len(t, push.Changes, 3)
+	assert.Equal(t, &RefChange{Ref: "refs/heads/main", Name: "main", FromHash: "aaa", ToHash: "bbb", Message: "fix", Author: "Jane"}, push.Changes[0])
+	assert.True(t, push.Changes[1].IsTag)
+	assert.Equal(t, "refs/tags/v1.0.0", push.Changes[1].Ref)
+	assert.True(t, push.Changes[2].Deleted)
+
+	event, err = ParseHook(EventKeyCloudPullRequestUpdated, []byte(`{
+		"repository": {"full_name": "team/repo"},
+		"pullrequest": {
+			"id": 7, "state": "OPEN", "author": {"display_name": "Jane"},
+			"source": {"branch": {"name": "feature"}, "commit": {"hash": "abc123"}, "repository": {"full_name": "jane/repo"}},
+			"destination": {"branch": {"name": "main"}, "repository": {"full_name": "team/repo"}}
+		}
+	}`))
+	assert.NoError(t, err)
+	pr, ok := event.(*PullRequestEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "team", pr.Namespace)
+	assert.Equal(t, 7, pr.PullRequest.ID)
+	assert.Equal(t, "feature", pr.PullRequest.SourceBranch)
+	assert.Equal(t, "main", pr.PullRequest.TargetBranch)
+	assert.True(t, pr.PullRequest.FromFork)
+}
+
+func TestParseServerHook(t *testing.T) {
+	event, err := ParseHook(EventKeyServerRefsChanged, []byte(`{
+		"actor": {"name": "admin", "displayName": "Administrator"},
+		"repository": {"slug": "repo", "project": {"key": "PRJ"}},
+		"changes": [{"ref": {"id": "refs/tags/v1", "displayId": "v1", "type": "TAG"}, "fromHash": "0000000000000000000000000000000000000000", "toHash": "bbb", "type": "ADD"}]
+	}`))
+	assert.NoError(t, err)
+	push, ok := event.(*PushEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "PRJ", push.Namespace)
+	assert.Equal(t, "Administrator", push.Actor)
+	assert.True(t, push.Changes[0].IsTag)
+	assert.True(t, isEmptyCommit(push.Changes[0].FromHash))
+
+	event, err = ParseHook(EventKeyServerPullRequestFromRefUpdated, []byte(`{
+		"pullRequest": {
+			"id": 3, "state": "OPEN", "author": {"user": {"name": "dev"}}, "createdDate": 1700000000000,
+			"fromRef": {"displayId": "feature", "latestCommit": "abc", "repository": {"slug": "repo", "project": {"key": "PRJ"}}},
+			"toRef": {"displayId": "main", "repository": {"slug": "repo", "project": {"key": "PRJ"}}}
+		}
+	}`))
+	assert.NoError(t, err)
+	pr, ok := event.(*PullRequestEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "repo", pr.RepoName)
+	assert.Equal(t, "dev", pr.PullRequest.Author)
+	assert.Equal(t, "abc", pr.PullRequest.SourceCommit)
+	assert.Equal(t, int64(1700000000), pr.PullRequest.CreatedAt)
+	assert.False(t, pr.PullRequest.FromFork)
+
+	_, err = ParseHook(EventKeyServerPing, []byte(`{}`))
+	assert.Error(t, err)
+}
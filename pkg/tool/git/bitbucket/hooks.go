@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/koderover/zadig/v2/pkg/tool/git"
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+// events subscribed by the webhooks created by zadig
+var (
+	cloudHookEvents  = []string{string(EventKeyCloudPush), string(EventKeyCloudPullRequestCreated), string(EventKeyCloudPullRequestUpdated)}
+	serverHookEvents = []string{string(EventKeyServerRefsChanged), string(EventKeyServerPullRequestOpened), string(EventKeyServerPullRequestFromRefUpdated)}
+)
+
+type cloudHook struct {
+	UUID        string   `json:"uuid,omitempty"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Active      bool     `json:"active"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+}
+
+type serverHook struct {
+	ID            int               `json:"id,omitempty"`
+	Name          string            `json:"name"`
+	URL           string            `json:"url"`
+	Active        bool              `json:"active"`
+	Events        []string          `json:"events"`
+	Configuration map[string]string `json:"configuration,omitempty"`
+}
+
+// CreateHook creates a repository webhook and returns its id, the events of the hook are ignored since bitbucket
+// uses its own event keys.
+func (c *Client) CreateHook(namespace, repo, name string, hook *git.Hook) (string, error) {
+	if c.Cloud {
+		res := &cloudHook{}
+		_, err := c.Post(cloudRepoURL(namespace, repo)+"/hooks", httpclient.SetBody(&cloudHook{
+			Description: name,
+			URL:         hook.URL,
+			Active:      true,
+			Secret:      hook.Secret,
+			Events:      cloudHookEvents,
+		}), httpclient.SetResult(res))
+		return res.UUID, err
+	}
+
+	res := &serverHook{}
+	_, err := c.Post(serverRepoURL(namespace, repo)+"/webhooks", httpclient.SetBody(&serverHook{
+		Name:   name,
+		URL:    hook.URL,
+		Active: true,
+		Events: serverHookEvents,
+		Configuration: map[string]string{
+			"secret": hook.Secret,
+		},
+	}), httpclient.SetResult(res))
+	return strconv.Itoa(res.ID), err
+}
+
+func (c *Client) DeleteHook(namespace, repo, id string) error {
+	if c.Cloud {
+		// the uuid of cloud hooks is wrapped in braces
+		_, err := c.Delete(fmt.Sprintf("%s/hooks/%s", cloudRepoURL(namespace, repo), url.PathEscape(id)))
+		return err
+	}
+
+	_, err := c.Delete(fmt.Sprintf("%s/webhooks/%s", serverRepoURL(namespace, repo), id))
+	return err
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"fmt"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+// ListPullRequests lists the open pull requests, only the ones targeting the given branch are returned if it is not empty.
+func (c *Client) ListPullRequests(namespace, repo, targetBranch string) ([]*PullRequest, error) {
+	var res []*PullRequest
+	if c.Cloud {
+		query := map[string]string{"state": "OPEN"}
+		if targetBranch != "" {
+			query["q"] = fmt.Sprintf(`destination.branch.name = "%s"`, targetBranch)
+		}
+		prs, err := listCloud[*cloudPullRequest](c, cloudRepoURL(namespace, repo)+"/pullrequests", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			res = append(res, pr.convert())
+		}
+		return res, nil
+	}
+
+	query := map[string]string{"state": "OPEN", "direction": "INCOMING"}
+	if targetBranch != "" {
+		query["at"] = "refs/heads/" + targetBranch
+	}
+	prs, err := listServer[*serverPullRequest](c, serverRepoURL(namespace, repo)+"/pull-requests", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		res = append(res, pr.convert())
+	}
+	return res, nil
+}
+
+func (c *Client) GetPullRequest(namespace, repo string, id int) (*PullRequest, error) {
+	if c.Cloud {
+		pr := &cloudPullRequest{}
+		if _, err := c.Get(fmt.Sprintf("%s/pullrequests/%d", cloudRepoURL(namespace, repo), id), httpclient.SetResult(pr)); err != nil {
+			return nil, err
+		}
+		return pr.convert(), nil
+	}
+
+	pr := &serverPullRequest{}
+	if _, err := c.Get(fmt.Sprintf("%s/pull-requests/%d", serverRepoURL(namespace, repo), id), httpclient.SetResult(pr)); err != nil {
+		return nil, err
+	}
+	return pr.convert(), nil
+}
+
+func (c *Client) ListPullRequestChangedFiles(namespace, repo string, id int) ([]string, error) {
+	if c.Cloud {
+		stats, err := listCloud[*cloudDiffStat](c, fmt.Sprintf("%s/pullrequests/%d/diffstat", cloudRepoURL(namespace, repo), id), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return changedFilesFromDiffStats(stats), nil
+	}
+
+	changes, err := listServer[*serverChange](c, fmt.Sprintf("%s/pull-requests/%d/changes", serverRepoURL(namespace, repo), id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return changedFilesFromChanges(changes), nil
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+// ListNamespaces lists the workspaces (cloud) or projects (data center) the user can access.
+func (c *Client) ListNamespaces(keyword string) ([]*Namespace, error) {
+	var res []*Namespace
+	if c.Cloud {
+		type permission struct {
+			Workspace *cloudWorkspace `json:"workspace"`
+		}
+		permissions, err := listCloud[*permission](c, "/user/permissions/workspaces", nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range permissions {
+			if p.Workspace == nil || !containsFold(p.Workspace.Slug, keyword) {
+				continue
+			}
+			res = append(res, &Namespace{Key: p.Workspace.Slug, Name: p.Workspace.Name})
+		}
+		return res, nil
+	}
+
+	query := map[string]string{}
+	if keyword != "" {
+		query["name"] = keyword
+	}
+	projects, err := listServer[*serverProject](c, "/projects", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		res = append(res, &Namespace{Key: p.Key, Name: p.Name})
+	}
+	return res, nil
+}
+
+// ListRepositories lists the repositories under the namespace, all the accessible repositories are searched
+// by the keyword if namespace is empty.
+func (c *Client) ListRepositories(namespace, keyword string, opts *ListOptions) ([]*Repository, error) {
+	var res []*Repository
+	if c.Cloud {
+		if namespace == "" {
+			return nil, fmt.Errorf("workspace is required to list bitbucket cloud repositories")
+		}
+		query := map[string]string{"role": "member"}
+		if keyword != "" {
+			query["q"] = fmt.Sprintf(`name ~ "%s"`, keyword)
+		}
+		repos, err := listCloud[*cloudRepository](c, "/repositories/"+namespace, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			res = append(res, r.convert())
+		}
+		return res, nil
+	}
+
+	u, query := "/repos", map[string]string{}
+	if namespace != "" {
+		u = fmt.Sprintf("/projects/%s/repos", namespace)
+	}
+	if keyword != "" && namespace == "" {
+		query["name"] = keyword
+	}
+	repos, err := listServer[*serverRepository](c, u, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range repos {
+		if !containsFold(r.Name, keyword) && !containsFold(r.Slug, keyword) {
+			continue
+		}
+		res = append(res, &Repository{
+			Namespace:   r.namespace(),
+			Name:        r.Slug,
+			DisplayName: r.Name,
+		})
+	}
+	return res, nil
+}
+
+// GetDefaultBranch returns the default branch of the data center repository, cloud returns it in the repository list.
+func (c *Client) GetDefaultBranch(namespace, repo string) (string, error) {
+	if c.Cloud {
+		r := &cloudRepository{}
+		if _, err := c.Get(cloudRepoURL(namespace, repo), httpclient.SetResult(r)); err != nil {
+			return "", err
+		}
+		return r.convert().DefaultBranch, nil
+	}
+
+	ref := &serverRef{}
+	if _, err := c.Get(serverRepoURL(namespace, repo)+"/default-branch", httpclient.SetResult(ref)); err != nil {
+		return "", err
+	}
+	return ref.DisplayID, nil
+}
+
+func (c *Client) ListBranches(namespace, repo, keyword string) ([]*Branch, error) {
+	var res []*Branch
+	if c.Cloud {
+		query := map[string]string{}
+		if keyword != "" {
+			query["q"] = fmt.Sprintf(`name ~ "%s"`, keyword)
+		}
+		refs, err := listCloud[*cloudRef](c, cloudRepoURL(namespace, repo)+"/refs/branches", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range refs {
+			res = append(res, &Branch{Name: r.Name, Commit: r.Target.convert()})
+		}
+		return res, nil
+	}
+
+	query := map[string]string{}
+	if keyword != "" {
+		query["filterText"] = keyword
+	}
+	refs, err := listServer[*serverRef](c, serverRepoURL(namespace, repo)+"/branches", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range refs {
+		res = append(res, &Branch{Name: r.DisplayID, Commit: &Commit{ID: r.LatestCommit}})
+	}
+	return res, nil
+}
+
+func (c *Client) ListTags(namespace, repo, keyword string) ([]*Tag, error) {
+	var res []*Tag
+	if c.Cloud {
+		query := map[string]string{"sort": "-target.date"}
+		if keyword != "" {
+			query["q"] = fmt.Sprintf(`name ~ "%s"`, keyword)
+		}
+		refs, err := listCloud[*cloudRef](c, cloudRepoURL(namespace, repo)+"/refs/tags", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range refs {
+			res = append(res, &Tag{Name: r.Name, Message: r.Message, Commit: r.Target.convert()})
+		}
+		return res, nil
+	}
+
+	query := map[string]string{"orderBy": "MODIFICATION"}
+	if keyword != "" {
+		query["filterText"] = keyword
+	}
+	refs, err := listServer[*serverRef](c, serverRepoURL(namespace, repo)+"/tags", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range refs {
+		res = append(res, &Tag{Name: r.DisplayID, Commit: &Commit{ID: r.LatestCommit}})
+	}
+	return res, nil
+}
+
+// ListCommits lists the commits reachable from the given branch, the latest commit comes first.
+func (c *Client) ListCommits(namespace, repo, branch string, opts *ListOptions) ([]*Commit, error) {
+	var res []*Commit
+	if c.Cloud {
+		commits, err := listCloud[*cloudCommit](c, fmt.Sprintf("%s/commits/%s", cloudRepoURL(namespace, repo), url.PathEscape(branch)), nil, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			res = append(res, commit.convert())
+		}
+		return res, nil
+	}
+
+	commits, err := listServer[*serverCommit](c, serverRepoURL(namespace, repo)+"/commits", map[string]string{"until": branch}, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range commits {
+		res = append(res, commit.convert())
+	}
+	return res, nil
+}
+
+// GetCommit returns the commit of a sha, the sha can be abbreviated.
+// A branch or tag name is accepted as well since the latest commit of the ref is returned by both flavors.
+func (c *Client) GetCommit(namespace, repo, ref string) (*Commit, error) {
+	if c.Cloud {
+		commit := &cloudCommit{}
+		if _, err := c.Get(fmt.Sprintf("%s/commit/%s", cloudRepoURL(namespace, repo), url.PathEscape(ref)), httpclient.SetResult(commit)); err != nil {
+			return nil, err
+		}
+		return commit.convert(), nil
+	}
+
+	commit := &serverCommit{}
+	if _, err := c.Get(fmt.Sprintf("%s/commits/%s", serverRepoURL(namespace, repo), url.PathEscape(ref)), httpclient.SetResult(commit)); err != nil {
+		return nil, err
+	}
+	return commit.convert(), nil
+}
+
+// ListChangedFiles returns the files changed between two commits, the parent of the to commit is used
+// when from is empty, e.g. for a newly created branch.
+func (c *Client) ListChangedFiles(namespace, repo, from, to string) ([]string, error) {
+	if isEmptyCommit(from) {
+		from = ""
+	}
+
+	if c.Cloud {
+		spec := to
+		if from != "" {
+			spec = fmt.Sprintf("%s..%s", to, from)
+		}
+		stats, err := listCloud[*cloudDiffStat](c, fmt.Sprintf("%s/diffstat/%s", cloudRepoURL(namespace, repo), spec), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return changedFilesFromDiffStats(stats), nil
+	}
+
+	query := map[string]string{"until": to}
+	if from != "" {
+		query["since"] = from
+	}
+	changes, err := listServer[*serverChange](c, serverRepoURL(namespace, repo)+"/changes", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return changedFilesFromChanges(changes), nil
+}
+
+// isEmptyCommit checks the all zero sha sent when a ref is created
+func isEmptyCommit(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
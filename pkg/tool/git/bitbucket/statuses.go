@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"fmt"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+type BuildState string
+
+const (
+	BuildStateInProgress BuildState = "INPROGRESS"
+	BuildStateSuccessful BuildState = "SUCCESSFUL"
+	BuildStateFailed     BuildState = "FAILED"
+	// BuildStateStopped is only supported by cloud, it is reported as failed to data center
+	BuildStateStopped BuildState = "STOPPED"
+)
+
+// BuildStatus is reported to a commit and shown in the pull requests containing the commit,
+// statuses with the same key overwrite each other.
+type BuildStatus struct {
+	Key         string     `json:"key"`
+	State       BuildState `json:"state"`
+	Name        string     `json:"name,omitempty"`
+	URL         string     `json:"url"`
+	Description string     `json:"description,omitempty"`
+}
+
+func (c *Client) CreateBuildStatus(namespace, repo, commit string, status *BuildStatus) error {
+	if c.Cloud {
+		_, err := c.Post(fmt.Sprintf("%s/commit/%s/statuses/build", cloudRepoURL(namespace, repo), commit), httpclient.SetBody(status))
+		return err
+	}
+
+	if status.State == BuildStateStopped {
+		s := *status
+		s.State = BuildStateFailed
+		status = &s
+	}
+	_, err := c.Post(fmt.Sprintf("%s/commits/%s/builds", serverRepoURL(namespace, repo), commit), httpclient.SetBody(status))
+	return err
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"strings"
+	"time"
+)
+
+// The types below are shared by bitbucket cloud and data center, the raw responses of both flavors are converted to them.
+
+type Namespace struct {
+	Key  string
+	Name string
+}
+
+type Repository struct {
+	Namespace     string
+	Name          string
+	DisplayName   string
+	DefaultBranch string
+}
+
+type Commit struct {
+	ID        string
+	Message   string
+	Author    string
+	CreatedAt int64
+}
+
+type Branch struct {
+	Name   string
+	Commit *Commit
+}
+
+type Tag struct {
+	Name    string
+	Message string
+	Commit  *Commit
+}
+
+type PullRequest struct {
+	ID           int
+	Title        string
+	State        string
+	Author       string
+	SourceBranch string
+	SourceCommit string
+	TargetBranch string
+	// FromFork marks if the source branch lives in another repository
+	FromFork  bool
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// cloud types
+
+type cloudUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+type cloudAuthor struct {
+	Raw  string     `json:"raw"`
+	User *cloudUser `json:"user"`
+}
+
+type cloudCommit struct {
+	Hash    string       `json:"hash"`
+	Message string       `json:"message"`
+	Date    time.Time    `json:"date"`
+	Author  *cloudAuthor `json:"author"`
+}
+
+type cloudRef struct {
+	Type    string       `json:"type"`
+	Name    string       `json:"name"`
+	Message string       `json:"message"`
+	Target  *cloudCommit `json:"target"`
+}
+
+type cloudWorkspace struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type cloudRepository struct {
+	FullName   string          `json:"full_name"`
+	Name       string          `json:"name"`
+	Workspace  *cloudWorkspace `json:"workspace"`
+	MainBranch *cloudRef       `json:"mainbranch"`
+}
+
+type cloudPullRequestEndpoint struct {
+	Branch     *cloudRef        `json:"branch"`
+	Commit     *cloudCommit     `json:"commit"`
+	Repository *cloudRepository `json:"repository"`
+}
+
+type cloudPullRequest struct {
+	ID          int                       `json:"id"`
+	Title       string                    `json:"title"`
+	State       string                    `json:"state"`
+	Author      *cloudUser                `json:"author"`
+	Source      *cloudPullRequestEndpoint `json:"source"`
+	Destination *cloudPullRequestEndpoint `json:"destination"`
+	CreatedOn   time.Time                 `json:"created_on"`
+	UpdatedOn   time.Time                 `json:"updated_on"`
+}
+
+type cloudDiffStat struct {
+	Status string `json:"status"`
+	Old    *struct {
+		Path string `json:"path"`
+	} `json:"old"`
+	New *struct {
+		Path string `json:"path"`
+	} `json:"new"`
+}
+
+// split returns the workspace and slug of a full name like workspace/slug
+func (r *cloudRepository) split() (string, string) {
+	if r == nil {
+		return "", ""
+	}
+	namespace, name, _ := strings.Cut(r.FullName, "/")
+	return namespace, name
+}
+
+func (a *cloudAuthor) name() string {
+	if a == nil {
+		return ""
+	}
+	if a.User != nil && a.User.DisplayName != "" {
+		return a.User.DisplayName
+	}
+	// raw is in the format of "name <email>"
+	return strings.TrimSpace(strings.Split(a.Raw, "<")[0])
+}
+
+func (c *cloudCommit) convert() *Commit {
+	if c == nil {
+		return nil
+	}
+	return &Commit{
+		ID:        c.Hash,
+		Message:   c.Message,
+		Author:    c.Author.name(),
+		CreatedAt: c.Date.Unix(),
+	}
+}
+
+func (r *cloudRepository) convert() *Repository {
+	namespace, name := r.split()
+	repo := &Repository{
+		Namespace:   namespace,
+		Name:        name,
+		DisplayName: r.Name,
+	}
+	if r.MainBranch != nil {
+		repo.DefaultBranch = r.MainBranch.Name
+	}
+	return repo
+}
+
+func (pr *cloudPullRequest) convert() *PullRequest {
+	res := &PullRequest{
+		ID:        pr.ID,
+		Title:     pr.Title,
+		State:     pr.State,
+		CreatedAt: pr.CreatedOn.Unix(),
+		UpdatedAt: pr.UpdatedOn.Unix(),
+	}
+	if pr.Author != nil {
+		res.Author = pr.Author.DisplayName
+	}
+	if pr.Source != nil {
+		if pr.Source.Branch != nil {
+			res.SourceBranch = pr.Source.Branch.Name
+		}
+		if pr.Source.Commit != nil {
+			res.SourceCommit = pr.Source.Commit.Hash
+		}
+	}
+	if pr.Destination != nil && pr.Destination.Branch != nil {
+		res.TargetBranch = pr.Destination.Branch.Name
+	}
+	if pr.Source != nil && pr.Destination != nil && pr.Source.Repository != nil && pr.Destination.Repository != nil {
+		res.FromFork = pr.Source.Repository.FullName != pr.Destination.Repository.FullName
+	}
+	return res
+}
+
+// data center types
+
+type serverUser struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type serverProject struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type serverRepository struct {
+	Slug    string         `json:"slug"`
+	Name    string         `json:"name"`
+	Project *serverProject `json:"project"`
+}
+
+type serverRef struct {
+	ID           string            `json:"id"`
+	DisplayID    string            `json:"displayId"`
+	Type         string            `json:"type"`
+	LatestCommit string            `json:"latestCommit"`
+	IsDefault    bool              `json:"isDefault"`
+	Repository   *serverRepository `json:"repository"`
+}
+
+type serverCommit struct {
+	ID              string      `json:"id"`
+	Message         string      `json:"message"`
+	Author          *serverUser `json:"author"`
+	AuthorTimestamp int64       `json:"authorTimestamp"`
+}
+
+type serverPullRequestParticipant struct {
+	User *serverUser `json:"user"`
+}
+
+type serverPullRequest struct {
+	ID          int                           `json:"id"`
+	Title       string                        `json:"title"`
+	State       string                        `json:"state"`
+	Author      *serverPullRequestParticipant `json:"author"`
+	FromRef     *serverRef                    `json:"fromRef"`
+	ToRef       *serverRef                    `json:"toRef"`
+	CreatedDate int64                         `json:"createdDate"`
+	UpdatedDate int64                         `json:"updatedDate"`
+}
+
+type serverPath struct {
+	ToString string `json:"toString"`
+}
+
+type serverChange struct {
+	Path    *serverPath `json:"path"`
+	SrcPath *serverPath `json:"srcPath"`
+}
+
+func (u *serverUser) name() string {
+	if u == nil {
+		return ""
+	}
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
+
+func (r *serverRepository) namespace() string {
+	if r == nil || r.Project == nil {
+		return ""
+	}
+	return r.Project.Key
+}
+
+func (r *serverRepository) slug() string {
+	if r == nil {
+		return ""
+	}
+	return r.Slug
+}
+
+func (c *serverCommit) convert() *Commit {
+	return &Commit{
+		ID:      c.ID,
+		Message: c.Message,
+		Author:  c.Author.name(),
+		// data center returns timestamps in milliseconds
+		CreatedAt: c.AuthorTimestamp / 1000,
+	}
+}
+
+func (pr *serverPullRequest) convert() *PullRequest {
+	res := &PullRequest{
+		ID:        pr.ID,
+		Title:     pr.Title,
+		State:     pr.State,
+		CreatedAt: pr.CreatedDate / 1000,
+		UpdatedAt: pr.UpdatedDate / 1000,
+	}
+	if pr.Author != nil {
+		res.Author = pr.Author.User.name()
+	}
+	if pr.FromRef != nil {
+		res.SourceBranch = pr.FromRef.DisplayID
+		res.SourceCommit = pr.FromRef.LatestCommit
+	}
+	if pr.ToRef != nil {
+		res.TargetBranch = pr.ToRef.DisplayID
+	}
+	if pr.FromRef != nil && pr.ToRef != nil && pr.FromRef.Repository != nil && pr.ToRef.Repository != nil {
+		res.FromFork = pr.FromRef.Repository.namespace() != pr.ToRef.Repository.namespace() || pr.FromRef.Repository.Slug != pr.ToRef.Repository.Slug
+	}
+	return res
+}
+
+func changedFilesFromDiffStats(stats []*cloudDiffStat) []string {
+	files := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		if stat.New != nil {
+			files = append(files, stat.New.Path)
+		}
+		if stat.Old != nil && (stat.New == nil || stat.Old.Path != stat.New.Path) {
+			files = append(files, stat.Old.Path)
+		}
+	}
+	return files
+}
+
+func changedFilesFromChanges(changes []*serverChange) []string {
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.Path != nil {
+			files = append(files, change.Path.ToString)
+		}
+		if change.SrcPath != nil && (change.Path == nil || change.SrcPath.ToString != change.Path.ToString) {
+			files = append(files, change.SrcPath.ToString)
+		}
+	}
+	return files
+}
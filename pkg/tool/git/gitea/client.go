@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+const apiPrefix = "/api/v1"
+
+type ListOptions struct {
+	// Page number of the results to fetch. Default: 1
+	Page int
+	// Results per page. Default: 50, the default MAX_RESPONSE_ITEMS of gitea
+	PerPage int
+
+	// NoPaginated indicates if we need to fetch all result or just one page. True means fetching just one page
+	NoPaginated bool
+}
+
+// Client is a thin REST client for the gitea api, forgejo keeps the same api and is served by this client as well.
+type Client struct {
+	*httpclient.Client
+}
+
+func NewClient(id int, address, accessToken, proxyAddr string, enableProxy, disableSSL bool) (*Client, error) {
+	token, err := UpdateGiteaToken(id, accessToken, disableSSL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh gitea token, err: %s", err)
+	}
+
+	cfs := []httpclient.ClientFunc{
+		httpclient.SetHostURL(strings.TrimSuffix(address, "/") + apiPrefix),
+		httpclient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: disableSSL}),
+	}
+	if token != "" {
+		cfs = append(cfs, httpclient.SetAuthToken(token))
+	}
+	if enableProxy {
+		cfs = append(cfs, httpclient.SetProxy(proxyAddr))
+	}
+
+	return &Client{Client: httpclient.New(cfs...)}, nil
+}
+
+func repoURL(owner, repo string) string {
+	return fmt.Sprintf("/repos/%s/%s", owner, repo)
+}
+
+// paginated calls the list api page by page until a page with fewer items than requested is returned,
+// gitea does not return the link header consistently among versions so the page size is used instead.
+func paginated[T any](c *Client, url string, query map[string]string, opts *ListOptions) ([]T, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	page, perPage := opts.Page, opts.PerPage
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = 50
+	}
+
+	var all []T
+	for {
+		params := map[string]string{
+			"page":  strconv.Itoa(page),
+			"limit": strconv.Itoa(perPage),
+		}
+		for k, v := range query {
+			params[k] = v
+		}
+
+		var result []T
+		if _, err := c.Get(url, httpclient.SetQueryParams(params), httpclient.SetResult(&result)); err != nil {
+			return nil, err
+		}
+		all = append(all, result...)
+
+		if opts.NoPaginated || len(result) < perPage {
+			return all, nil
+		}
+		page++
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// EventType represents a gitea event type.
+type EventType string
+
+// List of available event types.
+const (
+	EventTypePush        EventType = "push"
+	EventTypePullRequest EventType = "pull_request"
+	EventTypeCreate      EventType = "create"
+)
+
+// List of the pull request actions which need to trigger a build.
+const (
+	PullRequestActionOpened       = "opened"
+	PullRequestActionReopened     = "reopened"
+	PullRequestActionSynchronized = "synchronized"
+)
+
+const (
+	// forgejo sends both X-Forgejo-Event and X-Gitea-Event, so checking the gitea one is enough
+	eventTypeHeader = "X-Gitea-Event"
+	signatureHeader = "X-Gitea-Signature"
+	deliveryHeader  = "X-Gitea-Delivery"
+)
+
+// HookEventType returns the event type for the given request.
+func HookEventType(r *http.Request) EventType {
+	return EventType(r.Header.Get(eventTypeHeader))
+}
+
+// DeliveryID returns the unique id of the webhook delivery.
+func DeliveryID(r *http.Request) string {
+	return r.Header.Get(deliveryHeader)
+}
+
+// ValidateSignature checks the hex encoded HMAC-SHA256 signature of the payload sent in X-Gitea-Signature header.
+func ValidateSignature(r *http.Request, payload []byte, secret string) error {
+	signature := r.Header.Get(signatureHeader)
+	if signature == "" {
+		return errors.New("missing signature")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func ParseHook(eventType EventType, payload []byte) (event interface{}, err error) {
+	switch eventType {
+	case EventTypePush:
+		event = &PushEvent{}
+	case EventTypePullRequest:
+		event = &PullRequestEvent{}
+	case EventTypeCreate:
+		event = &CreateEvent{}
+	default:
+		return nil, fmt.Errorf("unexpected event type: %s", eventType)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+type PayloadUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserName string `json:"username"`
+}
+
+type PushEventCommit struct {
+	ID        string       `json:"id"`
+	Message   string       `json:"message"`
+	URL       string       `json:"url"`
+	Author    *PayloadUser `json:"author"`
+	Committer *PayloadUser `json:"committer"`
+	Added     []string     `json:"added"`
+	Removed   []string     `json:"removed"`
+	Modified  []string     `json:"modified"`
+}
+
+type PushEvent struct {
+	Ref        string             `json:"ref"`
+	Before     string             `json:"before"`
+	After      string             `json:"after"`
+	CompareURL string             `json:"compare_url"`
+	Commits    []*PushEventCommit `json:"commits"`
+	HeadCommit *PushEventCommit   `json:"head_commit"`
+	Repo       *Repository        `json:"repository"`
+	Pusher     *User              `json:"pusher"`
+	Sender     *User              `json:"sender"`
+}
+
+type PullRequestEvent struct {
+	Action      string       `json:"action"`
+	Number      int          `json:"number"`
+	PullRequest *PullRequest `json:"pull_request"`
+	Repo        *Repository  `json:"repository"`
+	Sender      *User        `json:"sender"`
+}
+
+// CreateEvent is sent when a branch or tag is created, RefType is either branch or tag
+type CreateEvent struct {
+	Sha     string      `json:"sha"`
+	Ref     string      `json:"ref"`
+	RefType string      `json:"ref_type"`
+	Repo    *Repository `json:"repository"`
+	Sender  *User       `json:"sender"`
+}
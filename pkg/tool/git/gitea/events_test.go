@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/main"}`)
+	req := &http.Request{Header: http.Header{}}
+
+	assert.Error(t, ValidateSignature(req, payload, "secret"))
+
+	req.Header.Set(signatureHeader, "d8f89f0618acd61fe621aa4e64078c0e2bca15d0b578b7f3eb734f55883c5320")
+	assert.NoError(t, ValidateSignature(req, payload, "secret"))
+	assert.Error(t, ValidateSignature(req, payload, "another"))
+	assert.Error(t, ValidateSignature(req, []byte(`{"ref":"refs/heads/dev"}`), "secret"))
+}
+
+func TestParseHook(t *testing.T) {
+	event, err := ParseHook(EventTypePullRequest, []byte(`{
+		"action": "synchronized",
+		"number": 3,
+		"pull_request": {"number": 3, "head": {"ref": "feature", "sha": "abc"}, "base": {"ref": "main"}},
+		"repository": {"name": "repo", "full_name": "org/repo", "owner": {"login": "org"}}
+	}`))
+	assert.NoError(t, err)
+	pr, ok := event.(*PullRequestEvent)
+	assert.True(t, ok)
+	assert.Equal(t, PullRequestActionSynchronized, pr.Action)
+	assert.Equal(t, "feature", pr.PullRequest.Head.Ref)
+	assert.Equal(t, "org/repo", pr.Repo.FullName)
+
+	_, err = ParseHook("issues", []byte(`{}`))
+	assert.Error(t, err)
+}
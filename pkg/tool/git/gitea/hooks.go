@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"fmt"
+
+	"github.com/koderover/zadig/v2/pkg/tool/git"
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+type createHookOption struct {
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+type editHookOption struct {
+	Config map[string]string `json:"config,omitempty"`
+	Events []string          `json:"events,omitempty"`
+	Active *bool             `json:"active,omitempty"`
+}
+
+func (c *Client) CreateHook(owner, repo string, hook *git.Hook) (*Hook, error) {
+	res := &Hook{}
+	_, err := c.Post(repoURL(owner, repo)+"/hooks", httpclient.SetBody(&createHookOption{
+		Type: "gitea",
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+			"secret":       hook.Secret,
+		},
+		Events: hook.Events,
+		Active: true,
+	}), httpclient.SetResult(res))
+	return res, err
+}
+
+func (c *Client) UpdateHook(owner, repo string, id int64, hook *git.Hook) (*Hook, error) {
+	res := &Hook{}
+	_, err := c.Patch(fmt.Sprintf("%s/hooks/%d", repoURL(owner, repo), id), httpclient.SetBody(&editHookOption{
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+			"secret":       hook.Secret,
+		},
+		Events: hook.Events,
+		Active: hook.Active,
+	}), httpclient.SetResult(res))
+	return res, err
+}
+
+func (c *Client) DeleteHook(owner, repo string, id int64) error {
+	_, err := c.Delete(fmt.Sprintf("%s/hooks/%d", repoURL(owner, repo), id))
+	return err
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+type PRBranchInfo struct {
+	Label  string      `json:"label"`
+	Ref    string      `json:"ref"`
+	Sha    string      `json:"sha"`
+	RepoID int64       `json:"repo_id"`
+	Repo   *Repository `json:"repo"`
+}
+
+type PullRequest struct {
+	ID        int64         `json:"id"`
+	Number    int           `json:"number"`
+	User      *User         `json:"user"`
+	Title     string        `json:"title"`
+	Body      string        `json:"body"`
+	State     string        `json:"state"`
+	HTMLURL   string        `json:"html_url"`
+	Merged    bool          `json:"merged"`
+	Head      *PRBranchInfo `json:"head"`
+	Base      *PRBranchInfo `json:"base"`
+	Created   time.Time     `json:"created_at"`
+	Updated   time.Time     `json:"updated_at"`
+	MergeBase string        `json:"merge_base"`
+}
+
+type ChangedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+	Status           string `json:"status"`
+}
+
+type Comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+	User *User  `json:"user"`
+}
+
+func (c *Client) ListPullRequests(owner, repo, state string, opts *ListOptions) ([]*PullRequest, error) {
+	return paginated[*PullRequest](c, repoURL(owner, repo)+"/pulls", map[string]string{"state": state}, opts)
+}
+
+func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, error) {
+	pr := &PullRequest{}
+	_, err := c.Get(fmt.Sprintf("%s/pulls/%d", repoURL(owner, repo), number), httpclient.SetResult(pr))
+	return pr, err
+}
+
+// ListPullRequestFiles lists the files changed by the pull request, the api is available since gitea 1.19
+func (c *Client) ListPullRequestFiles(owner, repo string, number int, opts *ListOptions) ([]*ChangedFile, error) {
+	return paginated[*ChangedFile](c, fmt.Sprintf("%s/pulls/%d/files", repoURL(owner, repo), number), nil, opts)
+}
+
+// CreateIssueComment creates a comment on the issue or pull request, they share the same index in gitea
+func (c *Client) CreateIssueComment(owner, repo string, number int, body string) (*Comment, error) {
+	comment := &Comment{}
+	_, err := c.Post(fmt.Sprintf("%s/issues/%d/comments", repoURL(owner, repo), number),
+		httpclient.SetBody(map[string]string{"body": body}), httpclient.SetResult(comment))
+	return comment, err
+}
+
+func (c *Client) EditIssueComment(owner, repo string, commentID int64, body string) (*Comment, error) {
+	comment := &Comment{}
+	_, err := c.Patch(fmt.Sprintf("%s/issues/comments/%d", repoURL(owner, repo), commentID),
+		httpclient.SetBody(map[string]string{"body": body}), httpclient.SetResult(comment))
+	return comment, err
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/27149chen/afero"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+	"github.com/koderover/zadig/v2/pkg/util"
+	fsutil "github.com/koderover/zadig/v2/pkg/util/fs"
+)
+
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+type Organization struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+type Repository struct {
+	ID            int64  `json:"id"`
+	Owner         *User  `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	SSHURL        string `json:"ssh_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type PayloadCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+type Branch struct {
+	Name      string         `json:"name"`
+	Commit    *PayloadCommit `json:"commit"`
+	Protected bool           `json:"protected"`
+}
+
+type Tag struct {
+	Name       string `json:"name"`
+	Message    string `json:"message"`
+	ID         string `json:"id"`
+	ZipballURL string `json:"zipball_url"`
+	TarballURL string `json:"tarball_url"`
+}
+
+type CommitUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+}
+
+type RepoCommit struct {
+	Author    *CommitUser `json:"author"`
+	Committer *CommitUser `json:"committer"`
+	Message   string      `json:"message"`
+}
+
+type Commit struct {
+	SHA     string      `json:"sha"`
+	HTMLURL string      `json:"html_url"`
+	Commit  *RepoCommit `json:"commit"`
+	Created time.Time   `json:"created"`
+}
+
+type Compare struct {
+	TotalCommits int       `json:"total_commits"`
+	Commits      []*Commit `json:"commits"`
+}
+
+// ContentsResponse is the file or directory entry returned by the contents api, type is one of file, dir, symlink and submodule
+type ContentsResponse struct {
+	Name     string  `json:"name"`
+	Path     string  `json:"path"`
+	SHA      string  `json:"sha"`
+	Type     string  `json:"type"`
+	Size     int     `json:"size"`
+	Encoding *string `json:"encoding"`
+	Content  *string `json:"content"`
+}
+
+func (c *Client) GetAuthenticatedUser() (*User, error) {
+	user := &User{}
+	_, err := c.Get("/user", httpclient.SetResult(user))
+	return user, err
+}
+
+func (c *Client) ListOrganizationsForAuthenticatedUser(opts *ListOptions) ([]*Organization, error) {
+	return paginated[*Organization](c, "/user/orgs", nil, opts)
+}
+
+func (c *Client) ListRepositoriesForAuthenticatedUser(opts *ListOptions) ([]*Repository, error) {
+	return paginated[*Repository](c, "/user/repos", nil, opts)
+}
+
+func (c *Client) ListRepositoriesForOrg(org string, opts *ListOptions) ([]*Repository, error) {
+	return paginated[*Repository](c, fmt.Sprintf("/orgs/%s/repos", org), nil, opts)
+}
+
+func (c *Client) SearchRepositories(keyword string, opts *ListOptions) ([]*Repository, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	// search api wraps the result as {"ok": true, "data": [...]}, so it is not able to use the paginated helper
+	page, perPage := opts.Page, opts.PerPage
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = 50
+	}
+	res := &struct {
+		Data []*Repository `json:"data"`
+	}{}
+	_, err := c.Get("/repos/search", httpclient.SetQueryParams(map[string]string{
+		"q":     keyword,
+		"page":  fmt.Sprintf("%d", page),
+		"limit": fmt.Sprintf("%d", perPage),
+	}), httpclient.SetResult(res))
+	return res.Data, err
+}
+
+func (c *Client) ListBranches(owner, repo string, opts *ListOptions) ([]*Branch, error) {
+	return paginated[*Branch](c, repoURL(owner, repo)+"/branches", nil, opts)
+}
+
+func (c *Client) ListTags(owner, repo string, opts *ListOptions) ([]*Tag, error) {
+	return paginated[*Tag](c, repoURL(owner, repo)+"/tags", nil, opts)
+}
+
+func (c *Client) ListCommits(owner, repo, branch, path string, opts *ListOptions) ([]*Commit, error) {
+	query := map[string]string{
+		"sha": branch,
+		// skip the diff stat and verification to make the listing cheaper
+		"stat":         "false",
+		"verification": "false",
+		"files":        "false",
+	}
+	if path != "" {
+		query["path"] = path
+	}
+	return paginated[*Commit](c, repoURL(owner, repo)+"/commits", query, opts)
+}
+
+func (c *Client) GetLatestRepositoryCommit(owner, repo, path, branch string) (*Commit, error) {
+	commits, err := c.ListCommits(owner, repo, branch, path, &ListOptions{PerPage: 1, NoPaginated: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commit found under path %s of branch %s", path, branch)
+	}
+
+	return commits[0], nil
+}
+
+// Compare lists the commits that head has but base does not have
+func (c *Client) Compare(owner, repo, base, head string) (*Compare, error) {
+	compare := &Compare{}
+	_, err := c.Get(fmt.Sprintf("%s/compare/%s...%s", repoURL(owner, repo), url.PathEscape(base), url.PathEscape(head)), httpclient.SetResult(compare))
+	return compare, err
+}
+
+// ListTree lists the entries under the given directory, the root of the repository is used if path is empty
+func (c *Client) ListTree(owner, repo, path, branch string) ([]*ContentsResponse, error) {
+	var res []*ContentsResponse
+	_, err := c.Get(contentsURL(owner, repo, path), httpclient.SetQueryParam("ref", branch), httpclient.SetResult(&res))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Client) GetFileContent(owner, repo, path, branch string) ([]byte, error) {
+	file := &ContentsResponse{}
+	_, err := c.Get(contentsURL(owner, repo, path), httpclient.SetQueryParam("ref", branch), httpclient.SetResult(file))
+	if err != nil {
+		return nil, err
+	}
+	if file.Type != "file" || file.Content == nil {
+		return nil, fmt.Errorf("%s is not a file", path)
+	}
+
+	return base64.StdEncoding.DecodeString(*file.Content)
+}
+
+// GetYAMLContents recursively gets all yaml contents under the given path. if split is true, manifests in the same file
+// will be split to separated ones.
+func (c *Client) GetYAMLContents(owner, repo, path, branch string, isDir, split bool) ([]string, error) {
+	var res []string
+	if !isDir {
+		if !(strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
+			return nil, nil
+		}
+
+		ct, err := c.GetFileContent(owner, repo, path, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		content := string(ct)
+		if split {
+			res = util.SplitManifests(content)
+		} else {
+			res = []string{content}
+		}
+
+		return res, nil
+	}
+
+	entries, err := c.ListTree(owner, repo, path, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	loadMutex := &sync.Mutex{}
+	var loadErr error = nil
+	wg := sync.WaitGroup{}
+
+	for _, entry := range entries {
+		if entry.Type != "file" && entry.Type != "dir" {
+			continue
+		}
+		wg.Add(1)
+		go func(entry *ContentsResponse) {
+			defer wg.Done()
+			r, err := c.GetYAMLContents(owner, repo, entry.Path, branch, entry.Type == "dir", split)
+			loadMutex.Lock()
+			defer loadMutex.Unlock()
+			if err != nil {
+				loadErr = err
+				return
+			}
+			res = append(res, r...)
+		}(entry)
+	}
+	wg.Wait()
+
+	return res, loadErr
+}
+
+// GetTreeContents recursively gets all file contents under the given path, and writes to an in-memory file system.
+func (c *Client) GetTreeContents(owner, repo, path, branch string) (afero.Fs, error) {
+	fs := afero.NewMemMapFs()
+	err := c.getTreeContents(owner, repo, branch, path, path, true, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+func (c *Client) getTreeContents(owner, repo, branch, base, path string, isDir bool, fs afero.Fs) error {
+	if !isDir {
+		ct, err := c.GetFileContent(owner, repo, path, branch)
+		if err != nil {
+			return err
+		}
+
+		return afero.WriteFile(fs, fsutil.ShortenFileBase(base, path), ct, 0644)
+	}
+
+	entries, err := c.ListTree(owner, repo, path, branch)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Type != "file" && entry.Type != "dir" {
+			continue
+		}
+		err = c.getTreeContents(owner, repo, branch, base, entry.Path, entry.Type == "dir", fs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func contentsURL(owner, repo, path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return repoURL(owner, repo) + "/contents"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return repoURL(owner, repo) + "/contents/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"fmt"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+type StatusState string
+
+const (
+	StatusPending StatusState = "pending"
+	StatusSuccess StatusState = "success"
+	StatusError   StatusState = "error"
+	StatusFailure StatusState = "failure"
+	StatusWarning StatusState = "warning"
+)
+
+type CreateStatusOption struct {
+	State       StatusState `json:"state"`
+	TargetURL   string      `json:"target_url"`
+	Description string      `json:"description"`
+	Context     string      `json:"context"`
+}
+
+type Status struct {
+	ID          int64       `json:"id"`
+	State       StatusState `json:"status"`
+	TargetURL   string      `json:"target_url"`
+	Description string      `json:"description"`
+	Context     string      `json:"context"`
+}
+
+// CreateStatus creates a commit status, statuses with the same context overwrite the previous one on the page of the pull request
+func (c *Client) CreateStatus(owner, repo, sha string, opt *CreateStatusOption) (*Status, error) {
+	status := &Status{}
+	_, err := c.Post(fmt.Sprintf("%s/statuses/%s", repoURL(owner, repo), sha), httpclient.SetBody(opt), httpclient.SetResult(status))
+	return status, err
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitea
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/shared/client/systemconfig"
+	"github.com/koderover/zadig/v2/pkg/tool/cache"
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+	"github.com/koderover/zadig/v2/pkg/tool/log"
+)
+
+type AccessToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenExpirationThreshold is a bit less than the default ACCESS_TOKEN_EXPIRATION_TIME(3600s) of gitea oauth2 provider
+const TokenExpirationThreshold int64 = 3000
+
+// UpdateGiteaToken returns a valid access token of the codehost, refreshing it if the oauth token is about to expire.
+// Codehosts configured with a personal access token have no refresh token and are returned as is.
+func UpdateGiteaToken(id int, accessToken string, disableTLS bool) (string, error) {
+	if accessToken == "" {
+		return "", nil
+	}
+
+	ch, err := systemconfig.New().GetRawCodeHost(id)
+	if err != nil {
+		return "", fmt.Errorf("get codehost info error: [%s]", err)
+	}
+
+	if ch.RefreshToken == "" || time.Now().Unix()-ch.UpdatedAt <= TokenExpirationThreshold {
+		return ch.AccessToken, nil
+	}
+
+	mu := cache.NewRedisLockWithExpiry(fmt.Sprintf("gitea_token_refresh:%d", id), time.Second*30)
+	err = mu.Lock()
+	if err != nil {
+		log.Errorf("failed to acquire gitea token refresh lock, err: %s", err)
+		return "", fmt.Errorf("failed to update gitea token, err: %s", err)
+	}
+	defer mu.Unlock()
+
+	log.Infof("Starting to refresh gitea token, old token issued time: %d", ch.UpdatedAt)
+
+	token, err := refreshAccessToken(ch.Address, ch.AccessKey, ch.SecretKey, ch.RefreshToken, disableTLS)
+	if err != nil {
+		return "", err
+	}
+
+	ch.AccessToken = token.AccessToken
+	ch.RefreshToken = token.RefreshToken
+	ch.UpdatedAt = time.Now().Unix()
+
+	// Since the new token is valid, we simply log the error
+	if err = systemconfig.New().UpdateCodeHost(ch.ID, ch); err != nil {
+		log.Errorf("failed to update codehost, err: %s", err)
+	}
+
+	return token.AccessToken, nil
+}
+
+func refreshAccessToken(address, clientID, clientSecret, refreshToken string, disableSSL bool) (*AccessToken, error) {
+	httpClient := httpclient.New(
+		httpclient.SetHostURL(address),
+		httpclient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: disableSSL}),
+	)
+
+	var accessToken *AccessToken
+	_, err := httpClient.Post("/login/oauth/access_token", httpclient.SetBody(map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+	}), httpclient.SetResult(&accessToken))
+	if err != nil {
+		return nil, err
+	}
+
+	return accessToken, nil
+}
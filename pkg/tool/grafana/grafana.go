@@ -16,6 +16,13 @@
 
 package grafana
 
+import (
+	"strconv"
+	"time"
+
+	"github.com/koderover/zadig/v2/pkg/tool/prometheus"
+)
+
 type ListAlertInstanceResp struct {
 	Annotations  Annotations `json:"annotations"`
 	Fingerprint  string      `json:"fingerprint"`
@@ -124,3 +131,18 @@ func (c *Client) ListAlert() (resp []*ListAlertResp, err error) {
 	_, err = c.R().SetSuccessResult(&resp).Get("/api/v1/provisioning/alert-rules")
 	return
 }
+
+// QueryPrometheus evaluates an instant query against a Prometheus data source through the Grafana data source proxy.
+func (c *Client) QueryPrometheus(datasourceUID, query string, ts time.Time) (float64, error) {
+	resp := new(prometheus.QueryResponse)
+	_, err := c.R().
+		SetPathParam("uid", datasourceUID).
+		SetQueryParam("query", query).
+		SetQueryParam("time", strconv.FormatInt(ts.Unix(), 10)).
+		SetSuccessResult(resp).
+		Get("/api/datasources/proxy/uid/{uid}/api/v1/query")
+	if err != nil {
+		return 0, err
+	}
+	return resp.Value()
+}
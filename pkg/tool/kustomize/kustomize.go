@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// kustomizationFileNames are the file names kustomize recognizes as a kustomization, in lookup order
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// IsKustomization returns true if the file name is a kustomization file.
+func IsKustomization(name string) bool {
+	name = path.Base(name)
+	for _, fileName := range kustomizationFileNames {
+		if name == fileName {
+			return true
+		}
+	}
+	return false
+}
+
+// HasKustomization returns true if the directory dir of the files contains a kustomization file.
+func HasKustomization(files map[string]string, dir string) bool {
+	for _, fileName := range kustomizationFileNames {
+		if _, ok := files[cleanPath(path.Join(dir, fileName))]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Build runs `kustomize build` on the directory dir in an in-memory file system made of the files,
+// which are keyed by slash separated paths relative to the same root as dir.
+// Only files under the root can be referenced, remote bases and plugins are not supported.
+func Build(files map[string]string, dir string) (string, error) {
+	fSys := filesys.MakeFsInMemory()
+	for filePath, content := range files {
+		filePath = "/" + cleanPath(filePath)
+		if err := fSys.MkdirAll(path.Dir(filePath)); err != nil {
+			return "", fmt.Errorf("failed to create dir for %s: %v", filePath, err)
+		}
+		if err := fSys.WriteFile(filePath, []byte(content)); err != nil {
+			return "", fmt.Errorf("failed to write file %s: %v", filePath, err)
+		}
+	}
+
+	dir = "/" + cleanPath(dir)
+	if !fSys.IsDir(dir) {
+		return "", fmt.Errorf("kustomization directory %s not found", dir)
+	}
+
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := kustomizer.Run(fSys, dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to build kustomization %s: %v", dir, err)
+	}
+	out, err := resMap.AsYaml()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal kustomization %s: %v", dir, err)
+	}
+	return string(out), nil
+}
+
+func cleanPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFiles = map[string]string{
+	"base/kustomization.yaml": `resources:
+- deployment.yaml
+`,
+	"base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+`,
+	"overlays/prod/kustomization.yaml": `resources:
+- ../../base
+namePrefix: prod-
+patches:
+- patch: |-
+    - op: replace
+      path: /spec/replicas
+      value: 3
+  target:
+    kind: Deployment
+images:
+- name: app
+  newTag: v2
+`,
+}
+
+func TestBuild(t *testing.T) {
+	base, err := Build(testFiles, "base")
+	assert.NoError(t, err)
+	assert.Contains(t, base, "name: app\n")
+	assert.Contains(t, base, "replicas: 1")
+	assert.Contains(t, base, "image: app:v1")
+
+	prod, err := Build(testFiles, "/overlays/prod/")
+	assert.NoError(t, err)
+	assert.Contains(t, prod, "name: prod-app")
+	assert.Contains(t, prod, "replicas: 3")
+	assert.Contains(t, prod, "image: app:v2")
+
+	_, err = Build(testFiles, "overlays/dev")
+	assert.Error(t, err)
+}
+
+func TestHasKustomization(t *testing.T) {
+	assert.True(t, HasKustomization(testFiles, "base"))
+	assert.True(t, HasKustomization(testFiles, "./overlays/prod"))
+	assert.False(t, HasKustomization(testFiles, "overlays"))
+	assert.True(t, IsKustomization("a/b/Kustomization"))
+	assert.False(t, IsKustomization("a/b/deployment.yaml"))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocilayout
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+const defaultTag = "latest"
+
+// Registry describes how to reach the registry an image is pulled from or pushed to.
+type Registry struct {
+	// Address is the registry address, the scheme is optional and "http://" means plain http.
+	Address  string
+	Username string
+	Password string
+	// Insecure skips the verification of the registry certificate, it is ignored when CACert is set.
+	Insecure bool
+	CACert   string
+}
+
+// Layout is an OCI image layout on the local disk, images are indexed by their full reference.
+type Layout struct {
+	store *oci.Store
+}
+
+// Open opens the OCI image layout under root, the layout is created if it does not exist.
+func Open(root string) (*Layout, error) {
+	store, err := oci.New(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open oci layout %s: %w", root, err)
+	}
+	return &Layout{store: store}, nil
+}
+
+// Pull copies image from the registry into the layout and returns the digest of its manifest.
+// All the platforms of a multi-arch image are kept.
+func (l *Layout) Pull(ctx context.Context, image string, reg *Registry) (string, error) {
+	repo, ref, err := newRepository(image, reg)
+	if err != nil {
+		return "", err
+	}
+
+	desc, err := oras.Copy(ctx, repo, ref, l.store, image, oras.DefaultCopyOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull image %s: %w", image, err)
+	}
+	return desc.Digest.String(), nil
+}
+
+// Push copies the image stored under name in the layout to the target image of the registry.
+func (l *Layout) Push(ctx context.Context, name, target string, reg *Registry) (string, error) {
+	repo, ref, err := newRepository(target, reg)
+	if err != nil {
+		return "", err
+	}
+
+	desc, err := oras.Copy(ctx, l.store, name, repo, ref, oras.DefaultCopyOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to push image %s: %w", target, err)
+	}
+	return desc.Digest.String(), nil
+}
+
+// Resolve returns the manifest digest of the image stored under name in the layout.
+func (l *Layout) Resolve(ctx context.Context, name string) (string, error) {
+	desc, err := l.store.Resolve(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image %s: %w", name, err)
+	}
+	return desc.Digest.String(), nil
+}
+
+func newRepository(image string, reg *Registry) (*remote.Repository, string, error) {
+	repo, err := remote.NewRepository(image)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid image %s: %w", image, err)
+	}
+
+	ref := repo.Reference.Reference
+	if ref == "" {
+		ref = defaultTag
+	}
+
+	if reg == nil {
+		reg = &Registry{}
+	}
+	repo.PlainHTTP = strings.HasPrefix(reg.Address, "http://")
+	repo.Client = &auth.Client{
+		Client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: reg.tlsConfig(),
+			},
+		},
+		Cache: auth.NewCache(),
+		Credential: auth.StaticCredential(repo.Reference.Registry, auth.Credential{
+			Username: reg.Username,
+			Password: reg.Password,
+		}),
+	}
+	return repo, ref, nil
+}
+
+func (r *Registry) tlsConfig() *tls.Config {
+	tlsConfig := &tls.Config{}
+	if r.CACert != "" {
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM([]byte(r.CACert))
+		tlsConfig.RootCAs = caCertPool
+	} else if r.Insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	return tlsConfig
+}
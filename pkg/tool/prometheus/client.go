@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 The KodeRover Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/pkg/errors"
+)
+
+// Client queries a Prometheus-compatible HTTP API, e.g. Prometheus, Thanos or VictoriaMetrics.
+type Client struct {
+	*req.Client
+	BaseURL string
+}
+
+func NewClient(url, token string) *Client {
+	client := req.C().
+		SetBaseURL(url).
+		OnAfterResponse(func(client *req.Client, resp *req.Response) error {
+			if resp.Err != nil {
+				resp.Err = errors.Wrapf(resp.Err, "body: %s", resp.String())
+				return nil
+			}
+			if !resp.IsSuccessState() {
+				resp.Err = errors.Errorf("unexpected status code %d, body: %s", resp.GetStatusCode(), resp.String())
+				return nil
+			}
+			return nil
+		})
+	if token != "" {
+		client.SetCommonBearerAuthToken(token)
+	}
+	return &Client{
+		Client:  client,
+		BaseURL: url,
+	}
+}
+
+// Query evaluates an instant query at the given time and returns its value.
+func (c *Client) Query(query string, ts time.Time) (float64, error) {
+	resp := new(QueryResponse)
+	_, err := c.R().
+		SetQueryParam("query", query).
+		SetQueryParam("time", strconv.FormatInt(ts.Unix(), 10)).
+		SetSuccessResult(resp).
+		Get("/api/v1/query")
+	if err != nil {
+		return 0, err
+	}
+	return resp.Value()
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2026 The KodeRover Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ResultTypeScalar = "scalar"
+	ResultTypeVector = "vector"
+)
+
+type QueryResponse struct {
+	Status    string    `json:"status"`
+	Data      QueryData `json:"data"`
+	ErrorType string    `json:"errorType"`
+	Error     string    `json:"error"`
+}
+
+type QueryData struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+type VectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// Value returns the value of a query result. A scalar is returned as is, a vector must
+// contain exactly one sample so that the analysis result is never ambiguous.
+func (r *QueryResponse) Value() (float64, error) {
+	if r.Status != "success" {
+		return 0, errors.Errorf("query failed, %s: %s", r.ErrorType, r.Error)
+	}
+
+	switch r.Data.ResultType {
+	case ResultTypeScalar:
+		sample := make([]interface{}, 0)
+		if err := json.Unmarshal(r.Data.Result, &sample); err != nil {
+			return 0, errors.Wrap(err, "failed to decode scalar result")
+		}
+		return parseSampleValue(sample)
+	case ResultTypeVector:
+		samples := make([]*VectorSample, 0)
+		if err := json.Unmarshal(r.Data.Result, &samples); err != nil {
+			return 0, errors.Wrap(err, "failed to decode vector result")
+		}
+		if len(samples) == 0 {
+			return 0, errors.New("query returned no data")
+		}
+		if len(samples) > 1 {
+			return 0, errors.Errorf("query returned %d series, aggregate it into a single series", len(samples))
+		}
+		return parseSampleValue(samples[0].Value)
+	default:
+		return 0, errors.Errorf("unsupported result type: %s", r.Data.ResultType)
+	}
+}
+
+// parseSampleValue parses a [<unix_time>, "<value>"] pair.
+func parseSampleValue(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, errors.Errorf("invalid sample: %v", sample)
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.Errorf("invalid sample value: %v", sample[1])
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value %q: %v", value, err)
+	}
+	return f, nil
+}
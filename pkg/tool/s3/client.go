@@ -309,16 +309,17 @@ func (c *Client) ListFiles(bucketName, prefix string, recursive bool) ([]string,
 	if !recursive {
 		input.Delimiter = aws.String("/")
 	}
-	output, err := c.ListObjects(input)
+	err := c.ListObjectsPages(input, func(output *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, item := range output.Contents {
+			itemKey := *item.Key
+			ret = append(ret, itemKey)
+		}
+		return true
+	})
 	if err != nil {
 		log.Errorf("bucket [%s] listing objects with prefix [%v] failed, error: %v", bucketName, prefix, err)
 		return nil, err
 	}
 
-	for _, item := range output.Contents {
-		itemKey := *item.Key
-		ret = append(ret, itemKey)
-	}
-
 	return ret, nil
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+// Block is a Block Kit layout block, only the fields used by zadig messages are modeled.
+type Block struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Text     *TextObject   `json:"text,omitempty"`
+	Fields   []*TextObject `json:"fields,omitempty"`
+	Elements []*Element    `json:"elements,omitempty"`
+}
+
+type TextObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Element is either a button in an actions block or a text object in a context block.
+type Element struct {
+	Type     string      `json:"type"`
+	Text     interface{} `json:"text,omitempty"`
+	ActionID string      `json:"action_id,omitempty"`
+	Value    string      `json:"value,omitempty"`
+	Style    string      `json:"style,omitempty"`
+	URL      string      `json:"url,omitempty"`
+}
+
+const (
+	ButtonStylePrimary = "primary"
+	ButtonStyleDanger  = "danger"
+)
+
+func NewHeaderBlock(text string) *Block {
+	return &Block{Type: "header", Text: &TextObject{Type: "plain_text", Text: text}}
+}
+
+func NewSectionBlock(markdown string) *Block {
+	return &Block{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: markdown}}
+}
+
+func NewFieldsBlock(fields ...string) *Block {
+	block := &Block{Type: "section"}
+	for _, field := range fields {
+		block.Fields = append(block.Fields, &TextObject{Type: "mrkdwn", Text: field})
+	}
+	return block
+}
+
+func NewContextBlock(markdown string) *Block {
+	return &Block{Type: "context", Elements: []*Element{{Type: "mrkdwn", Text: markdown}}}
+}
+
+func NewDividerBlock() *Block {
+	return &Block{Type: "divider"}
+}
+
+func NewActionsBlock(blockID string, elements ...*Element) *Block {
+	return &Block{Type: "actions", BlockID: blockID, Elements: elements}
+}
+
+func NewButton(text, actionID, value, style string) *Element {
+	return &Element{
+		Type:     "button",
+		Text:     &TextObject{Type: "plain_text", Text: text},
+		ActionID: actionID,
+		Value:    value,
+		Style:    style,
+	}
+}
+
+func NewLinkButton(text, url string) *Element {
+	return &Element{
+		Type: "button",
+		Text: &TextObject{Type: "plain_text", Text: text},
+		URL:  url,
+	}
+}
+
+// Mention returns the mrkdwn syntax mentioning a slack user.
+func Mention(userID string) string {
+	return "<@" + userID + ">"
+}
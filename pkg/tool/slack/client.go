@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koderover/zadig/v2/pkg/tool/httpclient"
+)
+
+const defaultHost = "https://slack.com/api"
+
+type Client struct {
+	Host     string
+	BotToken string
+}
+
+func NewClient(botToken string) *Client {
+	return &Client{
+		Host:     defaultHost,
+		BotToken: botToken,
+	}
+}
+
+func (c *Client) post(api string, body interface{}, result responseError) error {
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(c.Host, "/"), api)
+	_, err := httpclient.Post(
+		url,
+		httpclient.SetHeader("Authorization", "Bearer "+c.BotToken),
+		httpclient.SetHeader("Content-Type", "application/json; charset=utf-8"),
+		httpclient.SetBody(body),
+		httpclient.SetResult(result),
+	)
+	if err != nil {
+		return err
+	}
+	return result.ToError()
+}
+
+func (c *Client) get(api string, query map[string]string, result responseError) error {
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(c.Host, "/"), api)
+	_, err := httpclient.Get(
+		url,
+		httpclient.SetHeader("Authorization", "Bearer "+c.BotToken),
+		httpclient.SetQueryParams(query),
+		httpclient.SetResult(result),
+	)
+	if err != nil {
+		return err
+	}
+	return result.ToError()
+}
+
+// PostMessage posts a Block Kit message to the channel, the returned timestamp identifies the message
+// and is required to update it later.
+func (c *Client) PostMessage(channel, text string, blocks []*Block) (*PostMessageResp, error) {
+	resp := new(PostMessageResp)
+	err := c.post(postMessageAPI, &messageReq{
+		Channel: channel,
+		Text:    text,
+		Blocks:  blocks,
+	}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("post slack message to %s error: %s", channel, err)
+	}
+	return resp, nil
+}
+
+// UpdateMessage replaces the content of a message previously posted by the bot.
+func (c *Client) UpdateMessage(channel, ts, text string, blocks []*Block) error {
+	resp := new(PostMessageResp)
+	err := c.post(updateMessageAPI, &messageReq{
+		Channel: channel,
+		TS:      ts,
+		Text:    text,
+		Blocks:  blocks,
+	}, resp)
+	if err != nil {
+		return fmt.Errorf("update slack message %s error: %s", ts, err)
+	}
+	return nil
+}
+
+func (c *Client) LookupUserByEmail(email string) (*User, error) {
+	resp := new(lookupUserResp)
+	err := c.get(lookupUserByEmailAPI, map[string]string{"email": email}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("lookup slack user by email %s error: %s", email, err)
+	}
+	return resp.User, nil
+}
+
+func (c *Client) GetUserInfo(userID string) (*User, error) {
+	resp := new(lookupUserResp)
+	err := c.get(userInfoAPI, map[string]string{"user": userID}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("get slack user %s error: %s", userID, err)
+	}
+	return resp.User, nil
+}
+
+// Respond replies to an interaction through its response url, the reply is only visible to the
+// user who clicked and leaves the original message untouched.
+func Respond(responseURL, text string) error {
+	_, err := httpclient.Post(
+		responseURL,
+		httpclient.SetBody(map[string]interface{}{
+			"response_type":    "ephemeral",
+			"replace_original": false,
+			"text":             text,
+		}),
+	)
+	return err
+}
+
+func (c *Client) AuthTest() (*AuthTestResp, error) {
+	resp := new(AuthTestResp)
+	if err := c.post(authTestAPI, struct{}{}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import "fmt"
+
+const (
+	postMessageAPI       = "chat.postMessage"
+	updateMessageAPI     = "chat.update"
+	lookupUserByEmailAPI = "users.lookupByEmail"
+	userInfoAPI          = "users.info"
+	authTestAPI          = "auth.test"
+)
+
+const (
+	SignatureHeader = "X-Slack-Signature"
+	TimestampHeader = "X-Slack-Request-Timestamp"
+
+	InteractionTypeBlockActions = "block_actions"
+)
+
+type responseError interface {
+	ToError() error
+}
+
+type generalResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func (r *generalResponse) ToError() error {
+	if !r.OK {
+		return fmt.Errorf("slack api error: %s", r.Error)
+	}
+	return nil
+}
+
+type messageReq struct {
+	Channel string   `json:"channel"`
+	TS      string   `json:"ts,omitempty"`
+	Text    string   `json:"text"`
+	Blocks  []*Block `json:"blocks,omitempty"`
+}
+
+type PostMessageResp struct {
+	generalResponse
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+type AuthTestResp struct {
+	generalResponse
+	Team   string `json:"team"`
+	User   string `json:"user"`
+	BotID  string `json:"bot_id"`
+	UserID string `json:"user_id"`
+}
+
+type lookupUserResp struct {
+	generalResponse
+	User *User `json:"user"`
+}
+
+type User struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Profile *UserProfile `json:"profile,omitempty"`
+}
+
+type UserProfile struct {
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	RealName    string `json:"real_name"`
+}
+
+// InteractionPayload is the json payload slack posts to the interactivity request url
+// when a user clicks a button in a message.
+type InteractionPayload struct {
+	Type        string                `json:"type"`
+	User        *User                 `json:"user"`
+	Actions     []*InteractionAction  `json:"actions"`
+	Channel     *InteractionChannel   `json:"channel"`
+	Container   *InteractionContainer `json:"container"`
+	ResponseURL string                `json:"response_url"`
+}
+
+type InteractionAction struct {
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id"`
+	Value    string `json:"value"`
+}
+
+type InteractionChannel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type InteractionContainer struct {
+	MessageTS string `json:"message_ts"`
+	ChannelID string `json:"channel_id"`
+}
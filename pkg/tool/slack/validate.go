@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// maxRequestAge rejects replayed interaction requests, it is the window suggested by slack.
+const maxRequestAge = 5 * time.Minute
+
+func Validate(botToken string) error {
+	_, err := NewClient(botToken).AuthTest()
+	return err
+}
+
+// VerifySignature checks the v0 request signature slack attaches to every interaction request.
+func VerifySignature(signingSecret, timestamp, signature string, body []byte, now time.Time) error {
+	if signingSecret == "" {
+		return fmt.Errorf("slack signing secret is not configured")
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid slack request timestamp %q", timestamp)
+	}
+	age := now.Sub(time.Unix(ts, 0))
+	if age > maxRequestAge || age < -maxRequestAge {
+		return fmt.Errorf("slack request timestamp is out of range")
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("slack request signature mismatch")
+	}
+	return nil
+}
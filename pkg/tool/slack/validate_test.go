@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	ast := require.New(t)
+
+	secret := "8f742231b10e8888abcd99yyyzzz85a5"
+	body := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J")
+	signature := "v0=bca5eef5dd737ed259b428b18cd24f679baa18c3fc5f1cb2a6ac9f03e717969a"
+	now := time.Unix(1531420618, 0).Add(time.Minute)
+
+	ast.Nil(VerifySignature(secret, "1531420618", signature, body, now))
+	ast.NotNil(VerifySignature(secret, "1531420618", signature, []byte("tampered"), now))
+	ast.NotNil(VerifySignature("other", "1531420618", signature, body, now))
+	ast.NotNil(VerifySignature(secret, "1531420618", signature, body, now.Add(time.Hour)))
+	ast.NotNil(VerifySignature(secret, "not-a-time", signature, body, now))
+	ast.NotNil(VerifySignature("", "1531420618", signature, body, now))
+}
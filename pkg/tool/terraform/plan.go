@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReplace = "replace"
+)
+
+// plan is the part of the `terraform show -json` output we care about
+type plan struct {
+	FormatVersion   string            `json:"format_version"`
+	ResourceChanges []*resourceChange `json:"resource_changes"`
+}
+
+type resourceChange struct {
+	Address string `json:"address"`
+	Mode    string `json:"mode"`
+	Type    string `json:"type"`
+	Change  struct {
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+// PlanSummary is the resource change summary of a rendered plan
+type PlanSummary struct {
+	Add     int                   `bson:"add"     json:"add"     yaml:"add"`
+	Change  int                   `bson:"change"  json:"change"  yaml:"change"`
+	Destroy int                   `bson:"destroy" json:"destroy" yaml:"destroy"`
+	Replace int                   `bson:"replace" json:"replace" yaml:"replace"`
+	Changes []*PlanResourceChange `bson:"changes" json:"changes" yaml:"changes"`
+}
+
+type PlanResourceChange struct {
+	Address string `bson:"address" json:"address" yaml:"address"`
+	Type    string `bson:"type"    json:"type"    yaml:"type"`
+	Action  string `bson:"action"  json:"action"  yaml:"action"`
+}
+
+// HasChanges returns true if applying the plan changes any resource.
+func (s *PlanSummary) HasChanges() bool {
+	return s.Add+s.Change+s.Destroy+s.Replace > 0
+}
+
+func (s *PlanSummary) String() string {
+	return fmt.Sprintf("%d to add, %d to change, %d to destroy, %d to replace", s.Add, s.Change, s.Destroy, s.Replace)
+}
+
+// ParsePlan summarizes the resource changes of a plan rendered by `terraform show -json`,
+// which is compatible with the output of `tofu show -json`.
+func ParsePlan(data []byte) (*PlanSummary, error) {
+	p := &plan{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %v", err)
+	}
+	if p.FormatVersion == "" {
+		return nil, fmt.Errorf("invalid plan: format version not found")
+	}
+
+	summary := &PlanSummary{Changes: make([]*PlanResourceChange, 0)}
+	for _, rc := range p.ResourceChanges {
+		// data sources are read, not changed
+		if rc.Mode == "data" {
+			continue
+		}
+		action := changeAction(rc.Change.Actions)
+		switch action {
+		case ActionCreate:
+			summary.Add++
+		case ActionUpdate:
+			summary.Change++
+		case ActionDelete:
+			summary.Destroy++
+		case ActionReplace:
+			summary.Replace++
+		default:
+			continue
+		}
+		summary.Changes = append(summary.Changes, &PlanResourceChange{
+			Address: rc.Address,
+			Type:    rc.Type,
+			Action:  action,
+		})
+	}
+	sort.SliceStable(summary.Changes, func(i, j int) bool {
+		return summary.Changes[i].Address < summary.Changes[j].Address
+	})
+	return summary, nil
+}
+
+// changeAction converts the actions of a resource change to a single action,
+// an empty string is returned for no-op and read.
+func changeAction(actions []string) string {
+	switch len(actions) {
+	case 1:
+		switch actions[0] {
+		case ActionCreate, ActionUpdate, ActionDelete:
+			return actions[0]
+		}
+	case 2:
+		// ["delete", "create"] or ["create", "delete"] for create before destroy
+		if (actions[0] == ActionDelete && actions[1] == ActionCreate) || (actions[0] == ActionCreate && actions[1] == ActionDelete) {
+			return ActionReplace
+		}
+	}
+	return ""
+}
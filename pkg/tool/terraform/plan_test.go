@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPlan = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_s3_bucket.logs", "mode": "managed", "type": "aws_s3_bucket", "change": {"actions": ["create"]}},
+    {"address": "aws_instance.web", "mode": "managed", "type": "aws_instance", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_security_group.web", "mode": "managed", "type": "aws_security_group", "change": {"actions": ["update"]}},
+    {"address": "aws_iam_role.old", "mode": "managed", "type": "aws_iam_role", "change": {"actions": ["delete"]}},
+    {"address": "aws_vpc.main", "mode": "managed", "type": "aws_vpc", "change": {"actions": ["no-op"]}},
+    {"address": "data.aws_ami.ubuntu", "mode": "data", "type": "aws_ami", "change": {"actions": ["read"]}}
+  ]
+}`
+
+func TestParsePlan(t *testing.T) {
+	summary, err := ParsePlan([]byte(testPlan))
+	assert.NoError(t, err)
+	assert.True(t, summary.HasChanges())
+	assert.Equal(t, "1 to add, 1 to change, 1 to destroy, 1 to replace", summary.String())
+	assert.Equal(t, []*PlanResourceChange{
+		{Address: "aws_iam_role.old", Type: "aws_iam_role", Action: ActionDelete},
+		{Address: "aws_instance.web", Type: "aws_instance", Action: ActionReplace},
+		{Address: "aws_s3_bucket.logs", Type: "aws_s3_bucket", Action: ActionCreate},
+		{Address: "aws_security_group.web", Type: "aws_security_group", Action: ActionUpdate},
+	}, summary.Changes)
+
+	summary, err = ParsePlan([]byte(`{"format_version": "1.2"}`))
+	assert.NoError(t, err)
+	assert.False(t, summary.HasChanges())
+
+	_, err = ParsePlan([]byte(`{}`))
+	assert.Error(t, err)
+	_, err = ParsePlan([]byte(`Plan: 1 to add`))
+	assert.Error(t, err)
+}
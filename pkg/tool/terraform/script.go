@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	BinaryTerraform = "terraform"
+	BinaryOpenTofu  = "tofu"
+
+	// PlanFile is the file the plan is saved to, apply runs against the same file
+	PlanFile = "tfplan"
+	// PlanJSONFile is the file the plan is rendered to by `show -json`
+	PlanJSONFile = "tfplan.json"
+
+	defaultS3Region = "us-east-1"
+)
+
+// S3Backend is an S3 compatible remote state backend, the credentials are passed with
+// the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+type S3Backend struct {
+	Bucket   string
+	Key      string
+	Region   string
+	Endpoint string
+	Insecure bool
+}
+
+// BackendConfig returns the partial backend configuration passed to init.
+func (b *S3Backend) BackendConfig() []string {
+	region := b.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	resp := []string{
+		"bucket=" + b.Bucket,
+		"key=" + b.Key,
+		"region=" + region,
+	}
+	if b.Endpoint != "" {
+		endpoint := b.Endpoint
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			if b.Insecure {
+				endpoint = "http://" + endpoint
+			} else {
+				endpoint = "https://" + endpoint
+			}
+		}
+		// none AWS S3 services don't support the AWS specific checks
+		resp = append(resp,
+			fmt.Sprintf("endpoints={s3=%q}", endpoint),
+			"use_path_style=true",
+			"skip_credentials_validation=true",
+			"skip_region_validation=true",
+			"skip_requesting_account_id=true",
+			"skip_metadata_api_check=true",
+			"skip_s3_checksum=true",
+		)
+	}
+	return resp
+}
+
+type ScriptOption struct {
+	Binary string
+	// WorkDir is the directory of the root module relative to the workspace
+	WorkDir   string
+	Workspace string
+	Destroy   bool
+	// Backend overrides the backend of the configuration if set
+	Backend *S3Backend
+}
+
+func (o *ScriptOption) binary() string {
+	if o.Binary == "" {
+		return BinaryTerraform
+	}
+	return o.Binary
+}
+
+// PlanScript returns the shell script which saves the plan to PlanFile and renders it to PlanJSONFile.
+func PlanScript(opt *ScriptOption) []string {
+	planCmd := fmt.Sprintf("%s plan -input=false -no-color -out=%s", opt.binary(), PlanFile)
+	if opt.Destroy {
+		planCmd += " -destroy"
+	}
+	return append(opt.initScript(),
+		planCmd,
+		fmt.Sprintf("%s show -json %s > %s", opt.binary(), PlanFile, PlanJSONFile),
+		fmt.Sprintf("%s show -no-color %s", opt.binary(), PlanFile),
+	)
+}
+
+// ApplyScript returns the shell script which applies the plan saved in PlanFile.
+func ApplyScript(opt *ScriptOption) []string {
+	return append(opt.initScript(),
+		fmt.Sprintf("%s apply -input=false -no-color -auto-approve %s", opt.binary(), PlanFile),
+	)
+}
+
+func (o *ScriptOption) initScript() []string {
+	initCmd := fmt.Sprintf("%s init -input=false -no-color", o.binary())
+	if o.Backend != nil {
+		initCmd += " -reconfigure"
+		for _, config := range o.Backend.BackendConfig() {
+			initCmd += " -backend-config=" + shellQuote(config)
+		}
+	}
+
+	resp := []string{
+		"set -e",
+		`cd "$WORKSPACE"/` + shellQuote(o.WorkDir),
+		initCmd,
+	}
+	if o.Workspace != "" {
+		resp = append(resp, fmt.Sprintf("%s workspace select -or-create %s", o.binary(), shellQuote(o.Workspace)))
+	}
+	return resp
+}
+
+// shellQuote quotes s with single quotes, environment variables in s are not expanded.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanScript(t *testing.T) {
+	script := PlanScript(&ScriptOption{
+		Binary:    BinaryOpenTofu,
+		WorkDir:   "infra/envs/dev",
+		Workspace: "dev",
+		Destroy:   true,
+		Backend: &S3Backend{
+			Bucket:   "state",
+			Key:      "project/dev.tfstate",
+			Endpoint: "minio:9000",
+			Insecure: true,
+		},
+	})
+	assert.Equal(t, []string{
+		"set -e",
+		`cd "$WORKSPACE"/'infra/envs/dev'`,
+		"tofu init -input=false -no-color -reconfigure -backend-config='bucket=state' -backend-config='key=project/dev.tfstate' -backend-config='region=us-east-1' " +
+			`-backend-config='endpoints={s3="http://minio:9000"}' -backend-config='use_path_style=true' -backend-config='skip_credentials_validation=true' ` +
+			"-backend-config='skip_region_validation=true' -backend-config='skip_requesting_account_id=true' -backend-config='skip_metadata_api_check=true' -backend-config='skip_s3_checksum=true'",
+		"tofu workspace select -or-create 'dev'",
+		"tofu plan -input=false -no-color -out=tfplan -destroy",
+		"tofu show -json tfplan > tfplan.json",
+		"tofu show -no-color tfplan",
+	}, script)
+}
+
+func TestApplyScript(t *testing.T) {
+	script := ApplyScript(&ScriptOption{WorkDir: "it's"})
+	assert.Equal(t, []string{
+		"set -e",
+		`cd "$WORKSPACE"/'it'"'"'s'`,
+		"terraform init -input=false -no-color",
+		"terraform apply -input=false -no-color -auto-approve tfplan",
+	}, script)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trivy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityMedium   = "MEDIUM"
+	SeverityLow      = "LOW"
+	SeverityUnknown  = "UNKNOWN"
+)
+
+const (
+	ReportJSONFile  = "trivy-report.json"
+	ReportSARIFFile = "trivy-report.sarif"
+)
+
+// MaxFindings is the max number of findings kept in the summary, the full report is kept in the sarif file
+const MaxFindings = 200
+
+var severityRank = map[string]int{
+	SeverityCritical: 4,
+	SeverityHigh:     3,
+	SeverityMedium:   2,
+	SeverityLow:      1,
+	SeverityUnknown:  0,
+}
+
+// report is the part of the `trivy image --format json` output we care about
+type report struct {
+	SchemaVersion int       `json:"SchemaVersion"`
+	ArtifactName  string    `json:"ArtifactName"`
+	Results       []*result `json:"Results"`
+}
+
+type result struct {
+	Target          string           `json:"Target"`
+	Vulnerabilities []*vulnerability `json:"Vulnerabilities"`
+}
+
+type vulnerability struct {
+	VulnerabilityID  string `json:"VulnerabilityID"`
+	PkgName          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	Severity         string `json:"Severity"`
+	Title            string `json:"Title"`
+	PrimaryURL       string `json:"PrimaryURL"`
+}
+
+// Summary is the vulnerability summary of a scanned image
+type Summary struct {
+	Image     string     `bson:"image"     json:"image"     yaml:"image"`
+	Critical  int        `bson:"critical"  json:"critical"  yaml:"critical"`
+	High      int        `bson:"high"      json:"high"      yaml:"high"`
+	Medium    int        `bson:"medium"    json:"medium"    yaml:"medium"`
+	Low       int        `bson:"low"       json:"low"       yaml:"low"`
+	Unknown   int        `bson:"unknown"   json:"unknown"   yaml:"unknown"`
+	Findings  []*Finding `bson:"findings"  json:"findings"  yaml:"findings"`
+	Truncated bool       `bson:"truncated" json:"truncated" yaml:"truncated"`
+}
+
+type Finding struct {
+	VulnerabilityID  string `bson:"vulnerability_id"  json:"vulnerability_id"  yaml:"vulnerability_id"`
+	Target           string `bson:"target"            json:"target"            yaml:"target"`
+	PkgName          string `bson:"pkg_name"          json:"pkg_name"          yaml:"pkg_name"`
+	InstalledVersion string `bson:"installed_version" json:"installed_version" yaml:"installed_version"`
+	FixedVersion     string `bson:"fixed_version"     json:"fixed_version"     yaml:"fixed_version"`
+	Severity         string `bson:"severity"          json:"severity"          yaml:"severity"`
+	Title            string `bson:"title"             json:"title"             yaml:"title"`
+	URL              string `bson:"url"               json:"url"               yaml:"url"`
+}
+
+// Threshold is the max number of vulnerabilities of the severity allowed
+type Threshold struct {
+	Severity string `bson:"severity"  json:"severity"  yaml:"severity"`
+	MaxCount int    `bson:"max_count" json:"max_count" yaml:"max_count"`
+}
+
+// Count returns the number of vulnerabilities of the given severity
+func (s *Summary) Count(severity string) int {
+	switch strings.ToUpper(severity) {
+	case SeverityCritical:
+		return s.Critical
+	case SeverityHigh:
+		return s.High
+	case SeverityMedium:
+		return s.Medium
+	case SeverityLow:
+		return s.Low
+	default:
+		return s.Unknown
+	}
+}
+
+func (s *Summary) String() string {
+	return fmt.Sprintf("%d critical, %d high, %d medium, %d low, %d unknown", s.Critical, s.High, s.Medium, s.Low, s.Unknown)
+}
+
+// ExceededThresholds returns the description of every threshold exceeded by the summary
+func (s *Summary) ExceededThresholds(thresholds []*Threshold) []string {
+	resp := make([]string, 0)
+	for _, threshold := range thresholds {
+		if threshold == nil || threshold.MaxCount < 0 {
+			continue
+		}
+		if count := s.Count(threshold.Severity); count > threshold.MaxCount {
+			resp = append(resp, fmt.Sprintf("%s vulnerabilities: %d > %d", strings.ToUpper(threshold.Severity), count, threshold.MaxCount))
+		}
+	}
+	return resp
+}
+
+// ValidateThresholds makes sure every threshold targets a known severity
+func ValidateThresholds(thresholds []*Threshold) error {
+	for _, threshold := range thresholds {
+		if threshold == nil {
+			continue
+		}
+		if _, ok := severityRank[strings.ToUpper(threshold.Severity)]; !ok {
+			return fmt.Errorf("invalid severity: %s", threshold.Severity)
+		}
+	}
+	return nil
+}
+
+// ParseReport summarizes the vulnerabilities of a report rendered by `trivy image --format json`,
+// the findings are sorted by severity and at most MaxFindings of them are kept.
+func ParseReport(data []byte) (*Summary, error) {
+	r := &report{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %v", err)
+	}
+	if r.SchemaVersion == 0 {
+		return nil, fmt.Errorf("invalid report: schema version not found")
+	}
+
+	summary := &Summary{Image: r.ArtifactName, Findings: make([]*Finding, 0)}
+	for _, res := range r.Results {
+		for _, vuln := range res.Vulnerabilities {
+			severity := strings.ToUpper(vuln.Severity)
+			switch severity {
+			case SeverityCritical:
+				summary.Critical++
+			case SeverityHigh:
+				summary.High++
+			case SeverityMedium:
+				summary.Medium++
+			case SeverityLow:
+				summary.Low++
+			default:
+				severity = SeverityUnknown
+				summary.Unknown++
+			}
+			summary.Findings = append(summary.Findings, &Finding{
+				VulnerabilityID:  vuln.VulnerabilityID,
+				Target:           res.Target,
+				PkgName:          vuln.PkgName,
+				InstalledVersion: vuln.InstalledVersion,
+				FixedVersion:     vuln.FixedVersion,
+				Severity:         severity,
+				Title:            vuln.Title,
+				URL:              vuln.PrimaryURL,
+			})
+		}
+	}
+
+	sort.SliceStable(summary.Findings, func(i, j int) bool {
+		return severityRank[summary.Findings[i].Severity] > severityRank[summary.Findings[j].Severity]
+	})
+	if len(summary.Findings) > MaxFindings {
+		summary.Findings = summary.Findings[:MaxFindings]
+		summary.Truncated = true
+	}
+	return summary, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trivy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testReport = `{
+  "SchemaVersion": 2,
+  "ArtifactName": "koderover.tencentcloudcr.com/test/nginx:v1",
+  "Results": [
+    {
+      "Target": "koderover.tencentcloudcr.com/test/nginx:v1 (debian 12.5)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-0001", "PkgName": "libc6", "InstalledVersion": "2.36-9", "FixedVersion": "2.36-9+deb12u7", "Severity": "LOW"},
+        {"VulnerabilityID": "CVE-2024-0002", "PkgName": "openssl", "InstalledVersion": "3.0.11-1", "FixedVersion": "3.0.13-1", "Severity": "CRITICAL", "Title": "openssl: remote code execution"},
+        {"VulnerabilityID": "CVE-2024-0003", "PkgName": "zlib1g", "InstalledVersion": "1.2.13", "Severity": "HIGH"}
+      ]
+    },
+    {
+      "Target": "app/go.mod",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "GHSA-xxxx", "PkgName": "golang.org/x/net", "InstalledVersion": "0.17.0", "FixedVersion": "0.23.0", "Severity": "MEDIUM"},
+        {"VulnerabilityID": "GHSA-yyyy", "PkgName": "golang.org/x/text", "InstalledVersion": "0.3.0", "Severity": "NEGLIGIBLE"}
+      ]
+    },
+    {"Target": "app/package-lock.json"}
+  ]
+}`
+
+func TestParseReport(t *testing.T) {
+	summary, err := ParseReport([]byte(testReport))
+	assert.NoError(t, err)
+	assert.Equal(t, "koderover.tencentcloudcr.com/test/nginx:v1", summary.Image)
+	assert.Equal(t, "1 critical, 1 high, 1 medium, 1 low, 1 unknown", summary.String())
+	assert.False(t, summary.Truncated)
+	assert.Len(t, summary.Findings, 5)
+	assert.Equal(t, "CVE-2024-0002", summary.Findings[0].VulnerabilityID)
+	assert.Equal(t, "CVE-2024-0003", summary.Findings[1].VulnerabilityID)
+	assert.Equal(t, "GHSA-xxxx", summary.Findings[2].VulnerabilityID)
+	assert.Equal(t, "app/go.mod", summary.Findings[2].Target)
+	assert.Equal(t, SeverityUnknown, summary.Findings[4].Severity)
+
+	summary, err = ParseReport([]byte(`{"SchemaVersion": 2, "ArtifactName": "nginx"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, summary.Count(SeverityCritical))
+
+	_, err = ParseReport([]byte(`{}`))
+	assert.Error(t, err)
+	_, err = ParseReport([]byte(`Total: 0`))
+	assert.Error(t, err)
+}
+
+func TestExceededThresholds(t *testing.T) {
+	summary := &Summary{Critical: 1, High: 3, Medium: 10}
+
+	assert.Empty(t, summary.ExceededThresholds(nil))
+	assert.Empty(t, summary.ExceededThresholds([]*Threshold{{Severity: "high", MaxCount: 3}, {Severity: SeverityMedium, MaxCount: -1}}))
+	assert.Equal(t, []string{"CRITICAL vulnerabilities: 1 > 0", "HIGH vulnerabilities: 3 > 2"}, summary.ExceededThresholds([]*Threshold{
+		{Severity: SeverityCritical, MaxCount: 0},
+		{Severity: SeverityHigh, MaxCount: 2},
+		{Severity: SeverityLow, MaxCount: 0},
+	}))
+
+	assert.NoError(t, ValidateThresholds([]*Threshold{{Severity: "critical"}, {Severity: SeverityUnknown}}))
+	assert.Error(t, ValidateThresholds([]*Threshold{{Severity: "urgent"}}))
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DAGNode is a single job task taking part in dependency scheduling.
+type DAGNode struct {
+	Name string
+	// Group is the name of the workflow job the node was generated from, explicit dependencies refer to groups.
+	Group     string
+	DependsOn []string
+}
+
+// DAGStage is an ordered list of nodes, nodes of a serial stage implicitly depend on the node before them.
+type DAGStage struct {
+	Parallel bool
+	Nodes    []*DAGNode
+}
+
+// DAG is a directed acyclic graph of job dependencies, edges point from a node to the nodes it waits for.
+type DAG struct {
+	nodes []string
+	deps  map[string][]string
+}
+
+// BuildStageDAG builds the dependency graph of the given stages. A node that declares no dependencies waits for
+// every node of the previous non-empty stage, or for the node before it in a serial stage, so workflows without
+// explicit dependencies keep their stage by stage order. Dependencies on unknown jobs and cycles are errors.
+func BuildStageDAG(stages []*DAGStage) (*DAG, error) {
+	dag := &DAG{deps: make(map[string][]string)}
+	groups := make(map[string][]string)
+	for _, stage := range stages {
+		for _, node := range stage.Nodes {
+			if _, ok := dag.deps[node.Name]; ok {
+				return nil, fmt.Errorf("duplicated job name %s", node.Name)
+			}
+			dag.nodes = append(dag.nodes, node.Name)
+			dag.deps[node.Name] = []string{}
+			groups[node.Group] = append(groups[node.Group], node.Name)
+		}
+	}
+
+	var previous []string
+	for _, stage := range stages {
+		current := make([]string, 0, len(stage.Nodes))
+		for i, node := range stage.Nodes {
+			switch {
+			case len(node.DependsOn) > 0:
+				for _, dep := range node.DependsOn {
+					if dep == node.Group {
+						return nil, fmt.Errorf("job %s can not depend on itself", node.Group)
+					}
+					members, ok := groups[dep]
+					if !ok {
+						return nil, fmt.Errorf("job %s depends on unknown job %s", node.Group, dep)
+					}
+					dag.addDependencies(node.Name, members...)
+				}
+			case !stage.Parallel && i > 0:
+				dag.addDependencies(node.Name, stage.Nodes[i-1].Name)
+			default:
+				dag.addDependencies(node.Name, previous...)
+			}
+			current = append(current, node.Name)
+		}
+		if len(current) > 0 {
+			previous = current
+		}
+	}
+
+	if cycle := dag.findCycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("job dependency cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+	return dag, nil
+}
+
+func (d *DAG) addDependencies(name string, deps ...string) {
+	for _, dep := range deps {
+		exists := false
+		for _, existing := range d.deps[name] {
+			if existing == dep {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			d.deps[name] = append(d.deps[name], dep)
+		}
+	}
+}
+
+// Nodes returns the node names in the order they were declared.
+func (d *DAG) Nodes() []string {
+	return d.nodes
+}
+
+// Dependencies returns the names of the nodes the given node waits for.
+func (d *DAG) Dependencies(name string) []string {
+	return d.deps[name]
+}
+
+// findCycle returns a dependency cycle as a path starting and ending with the same node, or nil if there is none.
+func (d *DAG) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(d.nodes))
+	var stack []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range d.deps[name] {
+			switch state[dep] {
+			case visiting:
+				for i := range stack {
+					if stack[i] == dep {
+						cycle := append([]string{}, stack[i:]...)
+						return append(cycle, dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range d.nodes {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// TopologicalOrder returns the nodes ordered so that every node comes after the nodes it depends on,
+// nodes that become ready at the same time keep their declaration order.
+func (d *DAG) TopologicalOrder() []string {
+	remaining := make(map[string]int, len(d.nodes))
+	dependents := make(map[string][]string, len(d.nodes))
+	ready := make([]string, 0)
+	for _, name := range d.nodes {
+		remaining[name] = len(d.deps[name])
+		for _, dep := range d.deps[name] {
+			dependents[dep] = append(dependents[dep], name)
+		}
+		if remaining[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order := make([]string, 0, len(d.nodes))
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	return order
+}
+
+// CriticalPath returns the chain of dependent nodes with the largest total duration, which bounds the run time
+// of the whole graph no matter how much parallelism is available.
+func (d *DAG) CriticalPath(duration func(name string) int64) []string {
+	total := make(map[string]int64, len(d.nodes))
+	prev := make(map[string]string, len(d.nodes))
+	last := ""
+	for _, name := range d.TopologicalOrder() {
+		longest := int64(0)
+		for _, dep := range d.deps[name] {
+			if total[dep] > longest || prev[name] == "" {
+				longest = total[dep]
+				prev[name] = dep
+			}
+		}
+		total[name] = longest + duration(name)
+		if last == "" || total[name] > total[last] {
+			last = name
+		}
+	}
+
+	path := make([]string, 0)
+	for name := last; name != ""; name = prev[name] {
+		path = append([]string{name}, path...)
+	}
+	return path
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStageDAG(t *testing.T) {
+	testCases := []struct {
+		name    string
+		stages  []*DAGStage
+		deps    map[string][]string
+		wantErr string
+	}{
+		{
+			name: "implicit stage order",
+			stages: []*DAGStage{
+				{Parallel: true, Nodes: []*DAGNode{{Name: "build-a", Group: "build"}, {Name: "build-b", Group: "build"}}},
+				{Nodes: []*DAGNode{{Name: "deploy", Group: "deploy"}, {Name: "test", Group: "test"}}},
+			},
+			deps: map[string][]string{
+				"build-a": {},
+				"build-b": {},
+				"deploy":  {"build-a", "build-b"},
+				"test":    {"deploy"},
+			},
+		},
+		{
+			name: "explicit dependencies across stages",
+			stages: []*DAGStage{
+				{Parallel: true, Nodes: []*DAGNode{{Name: "build", Group: "build"}, {Name: "lint", Group: "lint"}}},
+				{Parallel: true, Nodes: []*DAGNode{{Name: "scan", Group: "scan"}}},
+				{Parallel: true, Nodes: []*DAGNode{{Name: "deploy", Group: "deploy", DependsOn: []string{"build"}}}},
+			},
+			deps: map[string][]string{
+				"build":  {},
+				"lint":   {},
+				"scan":   {"build", "lint"},
+				"deploy": {"build"},
+			},
+		},
+		{
+			name: "unknown dependency",
+			stages: []*DAGStage{
+				{Nodes: []*DAGNode{{Name: "deploy", Group: "deploy", DependsOn: []string{"build"}}}},
+			},
+			wantErr: "job deploy depends on unknown job build",
+		},
+		{
+			name: "self dependency",
+			stages: []*DAGStage{
+				{Nodes: []*DAGNode{{Name: "deploy", Group: "deploy", DependsOn: []string{"deploy"}}}},
+			},
+			wantErr: "job deploy can not depend on itself",
+		},
+		{
+			name: "cycle",
+			stages: []*DAGStage{
+				{Nodes: []*DAGNode{{Name: "a", Group: "a", DependsOn: []string{"c"}}}},
+				{Nodes: []*DAGNode{{Name: "b", Group: "b"}}},
+				{Nodes: []*DAGNode{{Name: "c", Group: "c", DependsOn: []string{"b"}}}},
+			},
+			wantErr: "job dependency cycle detected: a -> c -> b -> a",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dag, err := BuildStageDAG(tc.stages)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			for name, deps := range tc.deps {
+				assert.ElementsMatch(t, deps, dag.Dependencies(name), name)
+			}
+		})
+	}
+}
+
+func TestDAGCriticalPath(t *testing.T) {
+	dag, err := BuildStageDAG([]*DAGStage{
+		{Parallel: true, Nodes: []*DAGNode{{Name: "build", Group: "build"}, {Name: "lint", Group: "lint"}}},
+		{Parallel: true, Nodes: []*DAGNode{
+			{Name: "deploy", Group: "deploy", DependsOn: []string{"build"}},
+			{Name: "scan", Group: "scan", DependsOn: []string{"lint"}},
+		}},
+		{Nodes: []*DAGNode{{Name: "test", Group: "test", DependsOn: []string{"deploy", "scan"}}}},
+	})
+	assert.NoError(t, err)
+
+	durations := map[string]int64{"build": 60, "lint": 10, "deploy": 30, "scan": 100, "test": 20}
+	assert.Equal(t, []string{"build", "lint", "deploy", "scan", "test"}, dag.TopologicalOrder())
+	assert.Equal(t, []string{"lint", "scan", "test"}, dag.CriticalPath(func(name string) int64 { return durations[name] }))
+}
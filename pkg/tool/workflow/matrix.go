@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxMatrixCombinations caps the number of sub-tasks a single job matrix can expand to.
+const MaxMatrixCombinations = 256
+
+var matrixAxisNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// MatrixAxis is a dimension of a job matrix with the values it takes.
+type MatrixAxis struct {
+	Name   string
+	Values []string
+}
+
+// ExpandMatrix returns every combination of the axis values in axis order, without the combinations matched by an
+// exclude entry, followed by the include entries that are not part of the result yet. An entry matches a combination
+// when each of its keys has the same value in the combination.
+func ExpandMatrix(axes []*MatrixAxis, include, exclude []map[string]string) ([]map[string]string, error) {
+	axisSet := make(map[string]bool, len(axes))
+	for _, axis := range axes {
+		if !matrixAxisNameRegex.MatchString(axis.Name) {
+			return nil, fmt.Errorf("invalid matrix axis name %q, it must start with a letter and contain only letters, digits and underscores", axis.Name)
+		}
+		if axisSet[axis.Name] {
+			return nil, fmt.Errorf("duplicated matrix axis %s", axis.Name)
+		}
+		axisSet[axis.Name] = true
+		if len(axis.Values) == 0 {
+			return nil, fmt.Errorf("matrix axis %s has no value", axis.Name)
+		}
+		values := make(map[string]bool, len(axis.Values))
+		for _, value := range axis.Values {
+			if values[value] {
+				return nil, fmt.Errorf("duplicated value %s of matrix axis %s", value, axis.Name)
+			}
+			values[value] = true
+		}
+	}
+	for _, entries := range [][]map[string]string{include, exclude} {
+		for _, entry := range entries {
+			if len(entry) == 0 {
+				return nil, fmt.Errorf("empty matrix include or exclude entry")
+			}
+			for key := range entry {
+				if !axisSet[key] {
+					return nil, fmt.Errorf("unknown matrix axis %s", key)
+				}
+			}
+		}
+	}
+
+	combinations := make([]map[string]string, 0)
+	if len(axes) > 0 {
+		combinations = append(combinations, map[string]string{})
+		for _, axis := range axes {
+			expanded := make([]map[string]string, 0, len(combinations)*len(axis.Values))
+			for _, combination := range combinations {
+				for _, value := range axis.Values {
+					next := make(map[string]string, len(combination)+1)
+					for k, v := range combination {
+						next[k] = v
+					}
+					next[axis.Name] = value
+					expanded = append(expanded, next)
+				}
+			}
+			if len(expanded) > MaxMatrixCombinations {
+				return nil, fmt.Errorf("matrix expands to more than %d combinations", MaxMatrixCombinations)
+			}
+			combinations = expanded
+		}
+	}
+
+	resp := make([]map[string]string, 0, len(combinations)+len(include))
+	for _, combination := range combinations {
+		if !matrixEntriesMatch(exclude, combination) {
+			resp = append(resp, combination)
+		}
+	}
+	for _, entry := range include {
+		duplicated := false
+		for _, combination := range resp {
+			if len(combination) == len(entry) && matrixEntryMatches(entry, combination) {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			resp = append(resp, entry)
+		}
+	}
+
+	if len(resp) == 0 {
+		return nil, fmt.Errorf("matrix expands to no combination")
+	}
+	if len(resp) > MaxMatrixCombinations {
+		return nil, fmt.Errorf("matrix expands to more than %d combinations", MaxMatrixCombinations)
+	}
+	return resp, nil
+}
+
+func matrixEntriesMatch(entries []map[string]string, combination map[string]string) bool {
+	for _, entry := range entries {
+		if matrixEntryMatches(entry, combination) {
+			return true
+		}
+	}
+	return false
+}
+
+func matrixEntryMatches(entry, combination map[string]string) bool {
+	for k, v := range entry {
+		if value, ok := combination[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// MatrixCombinationName joins the values of the combination in axis order, e.g. "linux-amd64".
+func MatrixCombinationName(axes []*MatrixAxis, combination map[string]string) string {
+	parts := make([]string, 0, len(combination))
+	for _, axis := range axes {
+		if value, ok := combination[axis.Name]; ok {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandMatrix(t *testing.T) {
+	axes := []*MatrixAxis{
+		{Name: "os", Values: []string{"linux", "windows"}},
+		{Name: "arch", Values: []string{"amd64", "arm64"}},
+	}
+
+	testCases := []struct {
+		name    string
+		axes    []*MatrixAxis
+		include []map[string]string
+		exclude []map[string]string
+		want    []map[string]string
+		wantErr string
+	}{
+		{
+			name: "cartesian product",
+			axes: axes,
+			want: []map[string]string{
+				{"os": "linux", "arch": "amd64"},
+				{"os": "linux", "arch": "arm64"},
+				{"os": "windows", "arch": "amd64"},
+				{"os": "windows", "arch": "arm64"},
+			},
+		},
+		{
+			name:    "include and exclude",
+			axes:    axes,
+			exclude: []map[string]string{{"os": "windows", "arch": "arm64"}, {"arch": "amd64"}},
+			include: []map[string]string{{"os": "linux", "arch": "arm64"}, {"os": "darwin", "arch": "arm64"}},
+			want: []map[string]string{
+				{"os": "linux", "arch": "arm64"},
+				{"os": "darwin", "arch": "arm64"},
+			},
+		},
+		{
+			name:    "unknown axis in include",
+			include: []map[string]string{{"go": "1.22"}},
+			wantErr: "unknown matrix axis go",
+		},
+		{
+			name:    "everything excluded",
+			axes:    axes,
+			exclude: []map[string]string{{"os": "linux"}, {"os": "windows"}},
+			wantErr: "matrix expands to no combination",
+		},
+		{
+			name:    "invalid axis name",
+			axes:    []*MatrixAxis{{Name: "go-version", Values: []string{"1.22"}}},
+			wantErr: `invalid matrix axis name "go-version", it must start with a letter and contain only letters, digits and underscores`,
+		},
+		{
+			name:    "empty axis",
+			axes:    []*MatrixAxis{{Name: "os"}},
+			wantErr: "matrix axis os has no value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExpandMatrix(tc.axes, tc.include, tc.exclude)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMatrixCombinationName(t *testing.T) {
+	axes := []*MatrixAxis{{Name: "os"}, {Name: "arch"}}
+	assert.Equal(t, "linux-amd64", MatrixCombinationName(axes, map[string]string{"arch": "amd64", "os": "linux"}))
+	assert.Equal(t, "arm64", MatrixCombinationName(axes, map[string]string{"arch": "arm64"}))
+}
@@ -69,6 +69,29 @@ type Cache struct {
 	NFSProperties    NFSProperties    `json:"nfs_properties"    bson:"nfs_properties"`
 }
 
+// CacheRule is a named cache of a job. The key of a cache entry is the rendered Key followed by the digest of
+// the files matching KeyFiles, so the entry changes whenever a lockfile changes. RestoreKeys are key prefixes
+// tried in order to restore the latest matching entry when there is no entry with the exact key.
+type CacheRule struct {
+	Name        string   `json:"name"         bson:"name"         yaml:"name"`
+	Paths       []string `json:"paths"        bson:"paths"        yaml:"paths"`
+	Key         string   `json:"key"          bson:"key"          yaml:"key"`
+	KeyFiles    []string `json:"key_files"    bson:"key_files"    yaml:"key_files"`
+	RestoreKeys []string `json:"restore_keys" bson:"restore_keys" yaml:"restore_keys"`
+}
+
+type CacheResult string
+
+const (
+	CacheHit        CacheResult = "hit"
+	CachePartialHit CacheResult = "partial_hit"
+	CacheMiss       CacheResult = "miss"
+	CacheSkipped    CacheResult = "skipped"
+)
+
+// NamedCacheNFSMountPath is where the nfs cache volume is mounted when named caches are used
+const NamedCacheNFSMountPath = "/zadig/cache-store"
+
 type CacheDirType string
 
 const (
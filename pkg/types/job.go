@@ -27,6 +27,9 @@ const (
 	JobResultKey         = "job-result"
 	JobOutputsKey        = "job-outputs"
 	JobAIReviewReportKey = "ai-review-report"
+	JobImageVulnScanKey  = "image-vuln-scan"
+	JobCacheStatsKey     = "cache-stats"
+	JobCoverageReportKey = "coverage-report"
 
 	JobDebugStatusKey    = "job-debug-status"
 	JobDebugStatusBefore = "before"
@@ -186,6 +186,12 @@ const (
 	// ProviderGiteeEE
 	ProviderGiteeEE = "gitee-enterprise"
 
+	// ProviderGitea, forgejo shares the same provider
+	ProviderGitea = "gitea"
+
+	// ProviderBitbucket, cloud and data center are told apart by the address
+	ProviderBitbucket = "bitbucket"
+
 	// ProviderPerforce
 	ProviderPerforce = "perforce"
 
@@ -203,6 +209,8 @@ func (r *Repository) PRRef() string {
 		return fmt.Sprintf("merge-requests/%d/head", r.PR)
 	} else if strings.ToLower(r.Source) == ProviderGerrit {
 		return r.CheckoutRef
+	} else if strings.ToLower(r.Source) == ProviderBitbucket {
+		return r.bitbucketPRRef(r.PR)
 	}
 	return fmt.Sprintf("refs/pull/%d/head", r.PR)
 }
@@ -212,10 +220,22 @@ func (r *Repository) PRRefByPRID(pr int) string {
 		return fmt.Sprintf("merge-requests/%d/head", pr)
 	} else if strings.ToLower(r.Source) == ProviderGerrit {
 		return r.CheckoutRef
+	} else if strings.ToLower(r.Source) == ProviderBitbucket {
+		return r.bitbucketPRRef(pr)
 	}
 	return fmt.Sprintf("refs/pull/%d/head", pr)
 }
 
+// bitbucketPRRef returns the pull request ref of bitbucket data center, e.g. refs/pull-requests/1/from.
+// Bitbucket cloud does not expose pull request refs, so the source branch of the pull request is resolved into
+// CheckoutRef and fetched instead.
+func (r *Repository) bitbucketPRRef(pr int) string {
+	if r.CheckoutRef != "" {
+		return r.CheckoutRef
+	}
+	return fmt.Sprintf("refs/pull-requests/%d/from", pr)
+}
+
 // BranchRef returns branch refs format
 // e.g. refs/heads/master
 func (r *Repository) BranchRef() string {
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import "github.com/koderover/zadig/v2/pkg/types"
+
+// StepCacheSpec is shared by the restore_cache and save_cache steps.
+type StepCacheSpec struct {
+	Rules      []*types.CacheRule `bson:"rules"                json:"rules"                yaml:"rules"`
+	MediumType types.MediumType   `bson:"medium_type"          json:"medium_type"          yaml:"medium_type"`
+	S3         *S3                `bson:"s3"                   json:"s3"                   yaml:"s3"`
+	// StoreDir is the object path prefix on object storage, or the directory under the mounted nfs volume
+	StoreDir string `bson:"store_dir"            json:"store_dir"            yaml:"store_dir"`
+	// QuotaInMiB limits the total size of the entries in StoreDir, least recently used entries are evicted, 0 means no limit
+	QuotaInMiB  int64 `bson:"quota_in_mib"         json:"quota_in_mib"         yaml:"quota_in_mib"`
+	IgnoreCache bool  `bson:"ignore_cache"         json:"ignore_cache"         yaml:"ignore_cache"`
+
+	Stats           []*CacheStat `bson:"stats,omitempty"            json:"stats,omitempty"            yaml:"stats,omitempty"`
+	CollectionError string       `bson:"collection_error,omitempty" json:"collection_error,omitempty" yaml:"collection_error,omitempty"`
+}
+
+// CacheStat is the restore and save result of a named cache in a job.
+type CacheStat struct {
+	Name string `bson:"name"                 json:"name"                 yaml:"name"`
+	Key  string `bson:"key"                  json:"key"                  yaml:"key"`
+	// MatchedKey is the key of the restored entry, it differs from Key on a partial hit
+	MatchedKey  string            `bson:"matched_key"          json:"matched_key"          yaml:"matched_key"`
+	Result      types.CacheResult `bson:"result"               json:"result"               yaml:"result"`
+	RestoreSize int64             `bson:"restore_size"         json:"restore_size"         yaml:"restore_size"`
+	Saved       bool              `bson:"saved"                json:"saved"                yaml:"saved"`
+	SaveSize    int64             `bson:"save_size"            json:"save_size"            yaml:"save_size"`
+	Evicted     []string          `bson:"evicted,omitempty"    json:"evicted,omitempty"    yaml:"evicted,omitempty"`
+	Error       string            `bson:"error,omitempty"      json:"error,omitempty"      yaml:"error,omitempty"`
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import "github.com/koderover/zadig/v2/pkg/tool/coverage"
+
+type StepCoverageReportSpec struct {
+	SourceWorkflow string `bson:"source_workflow"      json:"source_workflow"      yaml:"source_workflow"`
+	SourceJobKey   string `bson:"source_job_key"       json:"source_job_key"       yaml:"source_job_key"`
+	JobTaskName    string `bson:"job_task_name"        json:"job_task_name"        yaml:"job_task_name"`
+	TaskID         int64  `bson:"task_id"              json:"task_id"              yaml:"task_id"`
+	ServiceName    string `bson:"service_name"         json:"service_name"         yaml:"service_name"`
+	ServiceModule  string `bson:"service_module"       json:"service_module"       yaml:"service_module"`
+	TestName       string `bson:"test_name"            json:"test_name"            yaml:"test_name"`
+	TestProject    string `bson:"test_project"         json:"test_project"         yaml:"test_project"`
+	// ReportPaths are the coverage reports relative to the workspace, glob patterns are supported
+	ReportPaths []string        `bson:"report_paths"         json:"report_paths"         yaml:"report_paths"`
+	Format      coverage.Format `bson:"format"               json:"format"               yaml:"format"`
+	// MinLineCoverage fails the step if the line coverage in percent is below it, 0 means no limit
+	MinLineCoverage float64 `bson:"min_line_coverage"    json:"min_line_coverage"    yaml:"min_line_coverage"`
+	// MaxCoverageDrop fails the step if the line coverage drops by more percentage points than it compared to Base, 0 means no limit
+	MaxCoverageDrop float64 `bson:"max_coverage_drop"    json:"max_coverage_drop"    yaml:"max_coverage_drop"`
+	DestDir         string  `bson:"dest_dir"             json:"dest_dir"             yaml:"dest_dir"`
+	S3DestDir       string  `bson:"s3_dest_dir"          json:"s3_dest_dir"          yaml:"s3_dest_dir"`
+	S3Storage       *S3     `bson:"s3_storage"           json:"s3_storage"           yaml:"s3_storage"`
+
+	// the repository under test, the base coverage is taken from the target branch of a pull request
+	CodehostID int    `bson:"codehost_id"          json:"codehost_id"          yaml:"codehost_id"`
+	RepoOwner  string `bson:"repo_owner"           json:"repo_owner"           yaml:"repo_owner"`
+	RepoName   string `bson:"repo_name"            json:"repo_name"            yaml:"repo_name"`
+	Branch     string `bson:"branch"               json:"branch"               yaml:"branch"`
+	CommitID   string `bson:"commit_id"            json:"commit_id"            yaml:"commit_id"`
+	PR         int    `bson:"pr"                   json:"pr"                   yaml:"pr"`
+
+	// Base is the coverage of the latest report on the base branch, it is set before the job runs
+	Base            *coverage.Summary `bson:"base,omitempty"             json:"base,omitempty"             yaml:"base,omitempty"`
+	BaseReportID    string            `bson:"base_report_id,omitempty"   json:"base_report_id,omitempty"   yaml:"base_report_id,omitempty"`
+	Summary         *coverage.Summary `bson:"summary,omitempty"          json:"summary,omitempty"          yaml:"summary,omitempty"`
+	GateFailures    []string          `bson:"gate_failures,omitempty"    json:"gate_failures,omitempty"    yaml:"gate_failures,omitempty"`
+	CollectionError string            `bson:"collection_error,omitempty" json:"collection_error,omitempty" yaml:"collection_error,omitempty"`
+}
+
+// CoverageReportResult is returned by the job executor through the job ConfigMap
+type CoverageReportResult struct {
+	Summary      *coverage.Summary `json:"summary"`
+	GateFailures []string          `json:"gate_failures"`
+}
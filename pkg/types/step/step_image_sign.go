@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+type StepImageSignSpec struct {
+	// Image is the image to be signed, the IMAGE env of the job takes precedence if it is set
+	Image string `bson:"image"                       json:"image"                          yaml:"image"`
+	// KeyRef is the cosign key reference, e.g. env://COSIGN_PRIVATE_KEY for a key saved in the keyvault,
+	// the password of the key is read from the COSIGN_PASSWORD env.
+	KeyRef string `bson:"key_ref"                     json:"key_ref"                        yaml:"key_ref"`
+	// TlogUpload uploads the signature to the public transparency log, it is disabled for private registries by default
+	TlogUpload bool `bson:"tlog_upload"                 json:"tlog_upload"                    yaml:"tlog_upload"`
+	// Attestations are attached to the image after it is signed
+	Attestations   []*ImageAttestation `bson:"attestations"                json:"attestations"                   yaml:"attestations"`
+	DockerRegistry *DockerRegistry     `bson:"docker_registry"             json:"docker_registry"                yaml:"docker_registry"`
+}
+
+type ImageAttestation struct {
+	// PredicateFile is relative to the workspace
+	PredicateFile string `bson:"predicate_file"              json:"predicate_file"                 yaml:"predicate_file"`
+	// PredicateType is the cosign predicate type, e.g. cyclonedx, spdxjson
+	PredicateType string `bson:"predicate_type"              json:"predicate_type"                 yaml:"predicate_type"`
+}
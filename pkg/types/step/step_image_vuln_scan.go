@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+import "github.com/koderover/zadig/v2/pkg/tool/trivy"
+
+type StepImageVulnScanSpec struct {
+	Image string `bson:"image"                json:"image"                yaml:"image"`
+	// DBRepository is the OCI repository of the vulnerability database, use a mirror in the private registry for offline environments
+	DBRepository string `bson:"db_repository"        json:"db_repository"        yaml:"db_repository"`
+	// SkipDBUpdate uses the vulnerability database shipped in the scanner image as is
+	SkipDBUpdate    bool               `bson:"skip_db_update"       json:"skip_db_update"       yaml:"skip_db_update"`
+	IgnoreUnfixed   bool               `bson:"ignore_unfixed"       json:"ignore_unfixed"       yaml:"ignore_unfixed"`
+	Thresholds      []*trivy.Threshold `bson:"thresholds"           json:"thresholds"           yaml:"thresholds"`
+	OutputDir       string             `bson:"output_dir"           json:"output_dir"           yaml:"output_dir"`
+	DockerRegistry  *DockerRegistry    `bson:"docker_registry"      json:"docker_registry"      yaml:"docker_registry"`
+	Summary         *trivy.Summary     `bson:"summary,omitempty"    json:"summary,omitempty"    yaml:"summary,omitempty"`
+	ExceededReasons []string           `bson:"exceeded_reasons"     json:"exceeded_reasons"     yaml:"exceeded_reasons"`
+	// SARIFObjectKey is the object key of the sarif report in the default object storage
+	SARIFObjectKey  string `bson:"sarif_object_key"     json:"sarif_object_key"     yaml:"sarif_object_key"`
+	CollectionError string `bson:"collection_error,omitempty" json:"collection_error,omitempty" yaml:"collection_error,omitempty"`
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package step
+
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDX      = "spdx"
+)
+
+type StepSBOMSpec struct {
+	// Image is the image to be scanned, the IMAGE env of the job takes precedence if it is set
+	Image  string `bson:"image"                       json:"image"                          yaml:"image"`
+	Format string `bson:"format"                      json:"format"                         yaml:"format"`
+	// ScanSource generates another SBOM for the source tree in the workspace
+	ScanSource bool   `bson:"scan_source"                 json:"scan_source"                    yaml:"scan_source"`
+	SourceDir  string `bson:"source_dir"                  json:"source_dir"                     yaml:"source_dir"`
+	// OutputDir is relative to the workspace, the generated SBOMs are archived from it
+	OutputDir      string          `bson:"output_dir"                  json:"output_dir"                     yaml:"output_dir"`
+	DockerRegistry *DockerRegistry `bson:"docker_registry"             json:"docker_registry"                yaml:"docker_registry"`
+}
+
+// ImageSBOMFile returns the file name of the SBOM generated for the image
+func (s *StepSBOMSpec) ImageSBOMFile() string {
+	return "image." + s.GetFormat() + ".json"
+}
+
+// SourceSBOMFile returns the file name of the SBOM generated for the source tree
+func (s *StepSBOMSpec) SourceSBOMFile() string {
+	return "source." + s.GetFormat() + ".json"
+}
+
+func (s *StepSBOMSpec) GetFormat() string {
+	if s.Format == SBOMFormatSPDX {
+		return SBOMFormatSPDX
+	}
+	return SBOMFormatCycloneDX
+}
+
+// AttestationPredicateType returns the cosign predicate type to attach the SBOM as an attestation
+func (s *StepSBOMSpec) AttestationPredicateType() string {
+	if s.GetFormat() == SBOMFormatSPDX {
+		return "spdxjson"
+	}
+	return "cyclonedx"
+}
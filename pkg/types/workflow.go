@@ -32,6 +32,7 @@ type ScannerType string
 const (
 	ScannerTypeSonarQube ScannerType = "sonarQube"
 	ScannerTypeAIReview  ScannerType = "ai_review"
+	ScannerTypeImageVuln ScannerType = "image_vulnerability"
 	ScannerTypeOther     ScannerType = "other"
 )
 
@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package labelexpr evaluates boolean label expressions such as
+// "linux && (amd64 || arm64) && !gpu" against a set of labels.
+// The keywords AND, OR and NOT (case-insensitive) can be used instead of &&, || and !.
+package labelexpr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Expr is a parsed label expression.
+type Expr interface {
+	Match(labels map[string]bool) bool
+	String() string
+}
+
+type labelExpr string
+
+func (e labelExpr) Match(labels map[string]bool) bool { return labels[string(e)] }
+func (e labelExpr) String() string                    { return string(e) }
+
+type notExpr struct{ expr Expr }
+
+func (e notExpr) Match(labels map[string]bool) bool { return !e.expr.Match(labels) }
+func (e notExpr) String() string                    { return "!" + e.expr.String() }
+
+type andExpr []Expr
+
+func (e andExpr) Match(labels map[string]bool) bool {
+	for _, expr := range e {
+		if !expr.Match(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e andExpr) String() string { return join(e, " && ") }
+
+type orExpr []Expr
+
+func (e orExpr) Match(labels map[string]bool) bool {
+	for _, expr := range e {
+		if expr.Match(labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e orExpr) String() string { return join(e, " || ") }
+
+func join(exprs []Expr, sep string) string {
+	parts := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		s := expr.String()
+		if _, ok := expr.(labelExpr); !ok {
+			if _, ok := expr.(notExpr); !ok {
+				s = "(" + s + ")"
+			}
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, sep)
+}
+
+const (
+	tokenLabel = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  int
+	value string
+}
+
+func tokenize(s string) ([]token, error) {
+	tokens := make([]token, 0)
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen})
+			i++
+		case r == '!':
+			tokens = append(tokens, token{kind: tokenNot})
+			i++
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+			if r == '&' {
+				tokens = append(tokens, token{kind: tokenAnd})
+			} else {
+				tokens = append(tokens, token{kind: tokenOr})
+			}
+			i += 2
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()!&|", runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			switch strings.ToUpper(word) {
+			case "AND":
+				tokens = append(tokens, token{kind: tokenAnd})
+			case "OR":
+				tokens = append(tokens, token{kind: tokenOr})
+			case "NOT":
+				tokens = append(tokens, token{kind: tokenNot})
+			default:
+				tokens = append(tokens, token{kind: tokenLabel, value: word})
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	exprs := orExpr{left}
+	for t := p.peek(); t != nil && t.kind == tokenOr; t = p.peek() {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, right)
+	}
+	if len(exprs) == 1 {
+		return left, nil
+	}
+	return exprs, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	exprs := andExpr{left}
+	for t := p.peek(); t != nil && t.kind == tokenAnd; t = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, right)
+	}
+	if len(exprs) == 1 {
+		return left, nil
+	}
+	return exprs, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+	switch t.kind {
+	case tokenNot:
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next := p.peek(); next == nil || next.kind != tokenRParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case tokenLabel:
+		return labelExpr(t.value), nil
+	default:
+		return nil, fmt.Errorf("unexpected operator in expression")
+	}
+}
+
+// Parse parses a label expression. A plain label is a valid expression that
+// matches exactly that label.
+func Parse(s string) (Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label expression %q: %s", s, err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("label expression is empty")
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid label expression %q: %s", s, err)
+	}
+	if p.pos != len(tokens) {
+		return nil, fmt.Errorf("invalid label expression %q: unexpected trailing tokens", s)
+	}
+	return expr, nil
+}
+
+// Match reports whether the labels match s. A label equal to s matches before s is evaluated as an
+// expression, so that labels containing spaces or keywords, e.g. "build and test", keep matching exactly.
+// A string which is not a valid expression only matches the label equal to it.
+func Match(s string, labels map[string]bool) bool {
+	if labels[strings.TrimSpace(s)] {
+		return true
+	}
+	expr, err := Parse(s)
+	if err != nil {
+		return false
+	}
+	return expr.Match(labels)
+}
+
+// LabelSet builds the label set an expression is matched against.
+func LabelSet(labels ...string) map[string]bool {
+	resp := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		resp[label] = true
+	}
+	return resp
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The KodeRover Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labelexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAndMatch(t *testing.T) {
+	labels := LabelSet("linux", "amd64", "docker")
+
+	cases := []struct {
+		expr  string
+		match bool
+	}{
+		{"linux", true},
+		{"windows", false},
+		{"linux && amd64", true},
+		{"linux && arm64", false},
+		{"arm64 || amd64", true},
+		{"!gpu", true},
+		{"!docker", false},
+		{"linux && (arm64 || amd64) && !gpu", true},
+		{"linux AND NOT docker", false},
+		{"windows or (linux and docker)", true},
+		{"!!linux", true},
+		{"build-pool/large", false},
+	}
+	for _, c := range cases {
+		expr, err := Parse(c.expr)
+		assert.NoError(t, err, c.expr)
+		assert.Equal(t, c.match, expr.Match(labels), c.expr)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{"", "   ", "linux &&", "linux & amd64", "(linux || amd64", "linux amd64", "&& linux", "linux )"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestString(t *testing.T) {
+	expr, err := Parse("linux and (amd64 or arm64) and not gpu")
+	assert.NoError(t, err)
+	assert.Equal(t, "linux && (amd64 || arm64) && !gpu", expr.String())
+}
+
+func TestMatch(t *testing.T) {
+	labels := LabelSet("build and test", "gpu pool", "linux")
+
+	assert.True(t, Match("build and test", labels))
+	assert.True(t, Match("gpu pool", labels))
+	assert.True(t, Match("linux && !windows", labels))
+	assert.False(t, Match("cpu pool", labels))
+	assert.False(t, Match("build and gpu", labels))
+}